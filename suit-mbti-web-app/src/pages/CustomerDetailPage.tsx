@@ -11,6 +11,7 @@ import {
   DialogContent,
   DialogTitle,
   Divider,
+  MenuItem,
   Paper,
   Table,
   TableBody,
@@ -24,8 +25,11 @@ import {
 import { getCustomer, getCustomerOrders } from '../api/customers';
 import { createOrder, generateOrderDocument } from '../api/orders';
 import type { Customer, OrderSummary } from '../types/customer';
+import type { PlanType } from '../types';
 import type { CreateOrderRequest } from '../types/order';
 
+const PLAN_TYPES: PlanType[] = ['Best Value', 'Authentic'];
+
 const formatDateInput = (date: Date) => {
   const year = date.getFullYear();
   const month = String(date.getMonth() + 1).padStart(2, '0');
@@ -91,7 +95,9 @@ const CustomerDetailPage = () => {
 
   const [isDialogOpen, setIsDialogOpen] = React.useState(false);
   const [fabricId, setFabricId] = React.useState('');
+  const [planType, setPlanType] = React.useState<PlanType>('Best Value');
   const [totalAmount, setTotalAmount] = React.useState<string>('');
+  const [priceOverrideReason, setPriceOverrideReason] = React.useState('');
   const [deliveryDate, setDeliveryDate] = React.useState<string>(formatDateInput(new Date()));
   const [description, setDescription] = React.useState('オーダースーツ縫製');
   const [lastGeneratedDocUrl, setLastGeneratedDocUrl] = React.useState<string | null>(null);
@@ -312,6 +318,27 @@ const CustomerDetailPage = () => {
                 'aria-label': '生地ID',
               }}
             />
+            <TextField
+              select
+              label="プラン"
+              value={planType}
+              onChange={(e) => {
+                setPlanType(e.target.value as PlanType);
+                setValidationError(null);
+              }}
+              fullWidth
+              size="small"
+              required
+              inputProps={{
+                'aria-label': 'プラン',
+              }}
+            >
+              {PLAN_TYPES.map((plan) => (
+                <MenuItem key={plan} value={plan}>
+                  {plan}
+                </MenuItem>
+              ))}
+            </TextField>
             <TextField
               label="金額（円）"
               type="number"
@@ -322,17 +349,30 @@ const CustomerDetailPage = () => {
               }}
               fullWidth
               size="small"
-              required
-              error={(!totalAmount || Number(totalAmount) <= 0) && validationError !== null}
-              helperText={
-                !totalAmount || Number(totalAmount) <= 0 ? '金額は1円以上を入力してください' : ''
-              }
+              error={totalAmount !== '' && Number(totalAmount) <= 0 && validationError !== null}
+              helperText="空欄の場合は価格表から計算した金額で作成します"
               inputProps={{
                 min: 1,
                 step: 1,
                 'aria-label': '金額',
               }}
             />
+            {totalAmount !== '' && (
+              <TextField
+                label="計算価格と異なる場合の理由"
+                value={priceOverrideReason}
+                onChange={(e) => {
+                  setPriceOverrideReason(e.target.value);
+                  setValidationError(null);
+                }}
+                fullWidth
+                size="small"
+                helperText="価格表の金額と異なる場合に必須です（オーナーのみ）"
+                inputProps={{
+                  'aria-label': '金額の変更理由',
+                }}
+              />
+            )}
             <TextField
               label="納期"
               type="date"
@@ -421,8 +461,8 @@ const CustomerDetailPage = () => {
                 setValidationError('生地IDは必須です');
                 return;
               }
-              const amount = Number(totalAmount);
-              if (!Number.isFinite(amount) || amount <= 0) {
+              const amount = totalAmount === '' ? undefined : Number(totalAmount);
+              if (amount !== undefined && (!Number.isFinite(amount) || amount <= 0)) {
                 setValidationError('金額は1円以上の数値を入力してください');
                 return;
               }
@@ -448,7 +488,9 @@ const CustomerDetailPage = () => {
               const req: CreateOrderRequest = {
                 customer_id: typedCustomer.id,
                 fabric_id: fabricId.trim(),
+                plan_type: planType,
                 total_amount: amount,
+                price_override_reason: priceOverrideReason.trim() || undefined,
                 delivery_date: isoDeliveryDate,
                 details: {
                   description: description.trim() || 'オーダースーツ縫製',
@@ -1,5 +1,7 @@
 // 注文およびコンプライアンス文書まわりの型定義
 
+import type { PlanType } from './index';
+
 export type OrderStatus = 'Draft' | 'Confirmed' | 'Cancelled' | 'Completed' | string;
 
 export interface Order {
@@ -27,7 +29,11 @@ export interface Order {
 export interface CreateOrderRequest {
   customer_id: string;
   fabric_id: string; // バックエンドでは必須
-  total_amount: number;
+  plan_type: PlanType; // 価格表による金額計算に必須
+  fabric_length?: number; // 用尺（メートル、省略時は生地の最小発注数量）
+  option_codes?: string[]; // オプションコード（価格表）
+  total_amount?: number; // 省略時は価格表から計算した金額で作成
+  price_override_reason?: string; // 計算価格と異なる金額で作成する場合の理由（オーナーのみ）
   delivery_date: string; // ISO 8601 (RFC3339形式: "2025-12-31T00:00:00Z")
   details: {
     description: string;
@@ -86,7 +86,10 @@ class CreateOrderRequest with _$CreateOrderRequest {
   const factory CreateOrderRequest({
     @JsonKey(name: 'customer_id') required String customerId,
     @JsonKey(name: 'fabric_id') required String fabricId,
-    @JsonKey(name: 'total_amount') required int totalAmount,
+    // プラン（価格表による金額計算に必須: Best Value / Authentic）
+    @JsonKey(name: 'plan_type') required String planType,
+    // 省略時は価格表から計算した金額で作成
+    @JsonKey(name: 'total_amount') int? totalAmount,
     @JsonKey(name: 'delivery_date') required DateTime deliveryDate,
     OrderDetails? details,
   }) = _CreateOrderRequest;
@@ -57,7 +57,11 @@ Future<Order> order(
   return Order.fromJson(response);
 }
 
+/// 注文一覧の1ページあたりの取得件数（APIの上限）
+const _orderListPageSize = 100;
+
 /// 注文一覧プロバイダー
+/// カーソルページネーションのため、next_cursor がなくなるまで全ページを取得
 @riverpod
 Future<List<Order>> orderList(
   OrderListRef ref,
@@ -65,15 +69,27 @@ Future<List<Order>> orderList(
 ) async {
   final apiClient = ref.watch(apiClientProvider);
 
-  final response = await apiClient.get<List<dynamic>>(
-    '/api/orders',
-    queryParameters: {
-      'tenant_id': tenantId,
-    },
-  );
+  final orders = <Order>[];
+  String? cursor;
+  do {
+    final response = await apiClient.get<Map<String, dynamic>>(
+      '/api/orders',
+      queryParameters: {
+        'tenant_id': tenantId,
+        'limit': _orderListPageSize.toString(),
+        if (cursor != null) 'cursor': cursor,
+      },
+    );
 
-  return response
-      .map((json) => Order.fromJson(json as Map<String, dynamic>))
-      .toList();
-}
+    // ページネーション付きレスポンス（data に注文一覧、next_cursor に次ページのカーソル）
+    final data = response['data'] as List<dynamic>? ?? [];
+    orders.addAll(
+      data.map((json) => Order.fromJson(json as Map<String, dynamic>)),
+    );
 
+    final nextCursor = response['next_cursor'] as String?;
+    cursor = (nextCursor == null || nextCursor.isEmpty) ? null : nextCursor;
+  } while (cursor != null);
+
+  return orders;
+}
@@ -36,6 +36,10 @@ class _QuickOrderScreenState extends ConsumerState<QuickOrderScreen> {
   Customer? _selectedCustomer;
   Fabric? _selectedFabric;
   DateTime? _deliveryDate;
+  String _planType = _planTypes.first;
+
+  // プラン（価格表の基本価格）
+  static const _planTypes = ['Best Value', 'Authentic'];
 
   // バリデーション状態
   ValidationResponse? _validationResult;
@@ -458,17 +462,68 @@ class _QuickOrderScreenState extends ConsumerState<QuickOrderScreen> {
         ),
         const SizedBox(height: 24),
         
-        // 金額入力
+        // プラン選択
+        DropdownButtonFormField<String>(
+          value: _planType,
+          decoration: InputDecoration(
+            labelText: 'プラン',
+            labelStyle: const TextStyle(
+              color: EnterpriseColors.textSecondary,
+            ),
+            filled: true,
+            fillColor: EnterpriseColors.surfaceGray,
+            border: OutlineInputBorder(
+              borderRadius: BorderRadius.circular(8),
+              borderSide: BorderSide(
+                color: EnterpriseColors.borderGray,
+              ),
+            ),
+            enabledBorder: OutlineInputBorder(
+              borderRadius: BorderRadius.circular(8),
+              borderSide: BorderSide(
+                color: EnterpriseColors.borderGray,
+              ),
+            ),
+            focusedBorder: OutlineInputBorder(
+              borderRadius: BorderRadius.circular(8),
+              borderSide: const BorderSide(
+                color: EnterpriseColors.primaryBlue,
+                width: 2,
+              ),
+            ),
+          ),
+          dropdownColor: EnterpriseColors.surfaceGray,
+          style: const TextStyle(
+            color: EnterpriseColors.textPrimary,
+          ),
+          items: _planTypes.map((plan) {
+            return DropdownMenuItem<String>(
+              value: plan,
+              child: Text(plan),
+            );
+          }).toList(),
+          onChanged: (plan) {
+            if (plan != null) {
+              setState(() {
+                _planType = plan;
+              });
+            }
+          },
+        ),
+        
+        const SizedBox(height: 16),
+        
+        // 金額入力（空欄の場合は価格表から計算）
         _buildTextField(
           controller: _amountController,
-          label: '金額',
+          label: '金額（空欄の場合は価格表から計算）',
           hintText: '135000',
           icon: Icons.attach_money,
           keyboardType: TextInputType.number,
           prefixText: '¥',
           validator: (value) {
             if (value == null || value.isEmpty) {
-              return '金額を入力してください';
+              return null;
             }
             if (int.tryParse(value) == null) {
               return '有効な数値を入力してください';
@@ -804,7 +859,8 @@ class _QuickOrderScreenState extends ConsumerState<QuickOrderScreen> {
       final orderRequest = CreateOrderRequest(
         customerId: _selectedCustomer!.id,
         fabricId: _selectedFabric!.id,
-        totalAmount: int.parse(_amountController.text),
+        planType: _planType,
+        totalAmount: _amountController.text.isEmpty ? null : int.parse(_amountController.text),
         deliveryDate: _deliveryDate!,
         details: OrderDetails(
           description: 'オーダースーツ縫製',
@@ -5,6 +5,8 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"path/filepath"
+	"time"
 
 	"cloud.google.com/go/firestore"
 	firebase "firebase.google.com/go"
@@ -155,6 +157,13 @@ func main() {
 		log.Println("Fabric repository initialized")
 	}
 
+	// 生地レコメンドの重みリポジトリ: PostgreSQLを使用
+	var fabricRecommendationRepo repository.FabricRecommendationRepository
+	if db != nil {
+		fabricRecommendationRepo = repository.NewPostgreSQLFabricRecommendationRepository(db)
+		log.Println("Fabric recommendation repository initialized")
+	}
+
 	// アンバサダーリポジトリ: PostgreSQLを使用
 	var ambassadorRepo repository.AmbassadorRepository
 	var commissionRepo repository.CommissionRepository
@@ -206,6 +215,108 @@ func main() {
 		log.Println("Appointment repository initialized")
 	}
 
+	// 入出金明細・入金取引リポジトリ: PostgreSQLを使用（入金消込）
+	var bankStatementRepo repository.BankStatementRepository
+	var transactionRepo repository.TransactionRepository
+	if db != nil {
+		bankStatementRepo = repository.NewPostgreSQLBankStatementRepository(db)
+		transactionRepo = repository.NewPostgreSQLTransactionRepository(db)
+		log.Println("Bank statement repositories initialized")
+	}
+
+	// 銀行口座・支払バッチリポジトリ: PostgreSQLを使用（総合振込）
+	var bankAccountRepo repository.BankAccountRepository
+	var payoutRepo repository.PayoutRepository
+	if db != nil {
+		bankAccountRepo = repository.NewPostgreSQLBankAccountRepository(db)
+		payoutRepo = repository.NewPostgreSQLPayoutRepository(db)
+		log.Println("Payout repositories initialized")
+	}
+
+	// 勘定科目マッピングリポジトリ: PostgreSQLを使用（会計連携）
+	var accountMappingRepo repository.AccountMappingRepository
+	if db != nil {
+		accountMappingRepo = repository.NewPostgreSQLAccountMappingRepository(db)
+		log.Println("Account mapping repository initialized")
+	}
+
+	// 見積書リポジトリ: PostgreSQLを使用
+	var quoteRepo repository.QuoteRepository
+	if db != nil {
+		quoteRepo = repository.NewPostgreSQLQuoteRepository(db)
+		log.Println("Quote repository initialized")
+	}
+
+	// 団体注文リポジトリ: PostgreSQLを使用
+	var groupOrderRepo repository.GroupOrderRepository
+	if db != nil {
+		groupOrderRepo = repository.NewPostgreSQLGroupOrderRepository(db)
+		log.Println("Group order repository initialized")
+	}
+
+	// 工場の生産能力リポジトリ: PostgreSQLを使用（生産計画）
+	var factoryCapacityRepo repository.FactoryCapacityRepository
+	if db != nil {
+		factoryCapacityRepo = repository.NewPostgreSQLFactoryCapacityRepository(db)
+		log.Println("Factory capacity repository initialized")
+	}
+
+	// 製造タスクリポジトリ: PostgreSQLを使用（作業者の工程管理）
+	var productionTaskRepo repository.ProductionTaskRepository
+	if db != nil {
+		productionTaskRepo = repository.NewPostgreSQLProductionTaskRepository(db)
+		log.Println("Production task repository initialized")
+	}
+
+	// 検品リポジトリ: PostgreSQLを使用（検品チェックリスト・検品記録）
+	var inspectionRepo repository.InspectionRepository
+	if db != nil {
+		inspectionRepo = repository.NewPostgreSQLInspectionRepository(db)
+		log.Println("Inspection repository initialized")
+	}
+
+	// 出荷記録リポジトリ: PostgreSQLを使用（出荷管理）
+	var shipmentRepo repository.ShipmentRepository
+	if db != nil {
+		shipmentRepo = repository.NewPostgreSQLShipmentRepository(db)
+		log.Println("Shipment repository initialized")
+	}
+
+	// 採寸プロファイルリポジトリ: PostgreSQLを使用（顧客ごとの採寸履歴）
+	var measurementProfileRepo repository.MeasurementProfileRepository
+	if db != nil {
+		measurementProfileRepo = repository.NewPostgreSQLMeasurementProfileRepository(db)
+		log.Println("Measurement profile repository initialized")
+	}
+
+	// 採寸テンプレートリポジトリ: PostgreSQLを使用（品目ごとの採寸項目・正常範囲・アラート閾値）
+	var measurementTemplateRepo repository.MeasurementTemplateRepository
+	if db != nil {
+		measurementTemplateRepo = repository.NewPostgreSQLMeasurementTemplateRepository(db)
+		log.Println("Measurement template repository initialized")
+	}
+
+	// 価格表リポジトリ: PostgreSQLを使用（価格計算エンジン）
+	var priceBookRepo repository.PriceBookRepository
+	if db != nil {
+		priceBookRepo = repository.NewPostgreSQLPriceBookRepository(db)
+		log.Println("Price book repository initialized")
+	}
+
+	// キャンペーンリポジトリ: PostgreSQLを使用（割引・クーポン）
+	var campaignRepo repository.CampaignRepository
+	if db != nil {
+		campaignRepo = repository.NewPostgreSQLCampaignRepository(db)
+		log.Println("Campaign repository initialized")
+	}
+
+	// 注文キャンセル記録リポジトリ
+	var orderCancellationRepo repository.OrderCancellationRepository
+	if db != nil {
+		orderCancellationRepo = repository.NewPostgreSQLOrderCancellationRepository(db)
+		log.Println("Order cancellation repository initialized")
+	}
+
 	// サービス層の依存性注入
 	// アンバサダーサービス（成果報酬管理用）
 	var ambassadorService *service.AmbassadorService
@@ -214,8 +325,25 @@ func main() {
 		log.Println("Ambassador service initialized")
 	}
 
-	// 注文サービス: 監査ログリポジトリとアンバサダーサービスを注入
-	orderService := service.NewOrderService(orderRepo, auditLogRepo, ambassadorService)
+	// 価格計算サービス（注文金額をサーバー側で計算）
+	var pricingService *service.PricingService
+	if priceBookRepo != nil && fabricRepo != nil {
+		pricingService = service.NewPricingService(priceBookRepo, fabricRepo)
+		log.Println("Pricing service initialized")
+	}
+
+	// 割引サービス（キャンペーン・クーポン）
+	var discountService *service.DiscountService
+	if campaignRepo != nil && customerRepo != nil {
+		discountService = service.NewDiscountService(campaignRepo, customerRepo)
+		log.Println("Discount service initialized")
+	}
+
+	// 注文サービス: 監査ログリポジトリ・アンバサダーサービス・価格計算サービス・割引サービスを注入
+	orderService := service.NewOrderService(orderRepo, auditLogRepo, ambassadorService, pricingService, discountService, db)
+
+	// 単位サービス（採寸値・生地の長さの表示単位）
+	unitService := service.NewUnitService(tenantRepo, customerRepo)
 
 	// 生地サービス
 	var fabricService *service.FabricService
@@ -242,6 +370,13 @@ func main() {
 		log.Println("Inventory allocation service initialized")
 	}
 
+	// 在庫レポートサービス（生地ごとの反物の残り・在庫金額をテナントの生地の単位で集計）
+	var inventoryReportService *service.InventoryReportService
+	if fabricRepo != nil && fabricRollRepo != nil {
+		inventoryReportService = service.NewInventoryReportService(fabricRepo, fabricRollRepo, unitService)
+		log.Println("Inventory report service initialized")
+	}
+
 	// Cloud Storageサービス（PDF保存用）
 	var storageService service.StorageService
 	bucketName := os.Getenv("GCS_BUCKET_NAME")
@@ -280,6 +415,20 @@ func main() {
 		log.Println("Compliance service initialized (without history management)")
 	}
 
+	// 注文キャンセルサービス（引当解除・成果報酬取消・発注取消通知書を単一トランザクションで処理）
+	var orderCancellationService *service.OrderCancellationService
+	if orderRepo != nil && orderCancellationRepo != nil && inventoryAllocationService != nil && db != nil {
+		orderCancellationService = service.NewOrderCancellationService(
+			orderRepo,
+			tenantRepo,
+			orderCancellationRepo,
+			inventoryAllocationService,
+			complianceService,
+			db,
+		)
+		log.Println("Order cancellation service initialized")
+	}
+
 	// 税率計算サービス（インボイス制度対応）
 	var taxService *service.TaxCalculationService
 	if tenantRepo != nil {
@@ -294,6 +443,7 @@ func main() {
 			orderRepo,
 			tenantRepo,
 			customerRepo,
+			campaignRepo,
 			storageService,
 			bucketName,
 			taxService,
@@ -301,6 +451,31 @@ func main() {
 		log.Println("Invoice service initialized")
 	}
 
+	// 電子インボイスサービス（JP PINT / Peppol）
+	// アクセスポイント事業者との接続までは、送信内容をローカルディレクトリに保存する代替実装を使用
+	var eInvoiceService *service.EInvoiceService
+	if orderRepo != nil && tenantRepo != nil && customerRepo != nil {
+		peppolOutboxDir := os.Getenv("PEPPOL_OUTBOX_DIR")
+		if peppolOutboxDir == "" {
+			peppolOutboxDir = filepath.Join(os.TempDir(), "tailorcloud-peppol-outbox")
+		}
+		eInvoiceService = service.NewEInvoiceService(
+			orderRepo,
+			tenantRepo,
+			customerRepo,
+			campaignRepo,
+			service.NewFileSystemAccessPoint(peppolOutboxDir),
+		)
+		log.Printf("E-invoice service initialized (Peppol outbox: %s)", peppolOutboxDir)
+	}
+
+	// 見積書サービス（見積→注文変換）
+	var quoteService *service.QuoteService
+	if quoteRepo != nil {
+		quoteService = service.NewQuoteService(quoteRepo, fabricRepo, customerRepo, tenantRepo, orderService, db)
+		log.Println("Quote service initialized")
+	}
+
 	// 診断サービス（Suit-MBTI統合）
 	var diagnosisService *service.DiagnosisService
 	if diagnosisRepo != nil {
@@ -308,6 +483,19 @@ func main() {
 		log.Println("Diagnosis service initialized")
 	}
 
+	// 生地レコメンドサービス（Suit-MBTI統合）
+	var fabricRecommendationService *service.FabricRecommendationService
+	if fabricRecommendationRepo != nil && fabricRepo != nil && customerRepo != nil {
+		fabricRecommendationService = service.NewFabricRecommendationService(
+			fabricRecommendationRepo,
+			fabricRepo,
+			customerRepo,
+			diagnosisService,
+			unitService,
+		)
+		log.Println("Fabric recommendation service initialized")
+	}
+
 	// 予約サービス（Suit-MBTI統合）
 	var appointmentService *service.AppointmentService
 	if appointmentRepo != nil {
@@ -328,17 +516,161 @@ func main() {
 	// 採寸データバリデーションサービス
 	var measurementValidationService *service.MeasurementValidationService
 	if orderRepo != nil {
-		measurementValidationService = service.NewMeasurementValidationService(orderRepo)
+		measurementValidationService = service.NewMeasurementValidationService(orderRepo, measurementProfileRepo, measurementTemplateRepo)
 		log.Println("Measurement validation service initialized")
 	}
 
+	// 採寸プロファイルサービス（採寸の版管理・版を使った注文・採寸値の推移）
+	var measurementProfileService *service.MeasurementProfileService
+	if measurementProfileRepo != nil && orderRepo != nil {
+		measurementProfileService = service.NewMeasurementProfileService(measurementProfileRepo, customerRepo, orderRepo, unitService, db)
+		log.Println("Measurement profile service initialized")
+	}
+
+	// 再注文サービス（過去の注文の採寸データ・補正情報を引き継いで複製）
+	var orderCloneService *service.OrderCloneService
+	if orderRepo != nil {
+		orderCloneService = service.NewOrderCloneService(orderRepo, fabricRepo, orderService, measurementValidationService)
+		log.Println("Order clone service initialized")
+	}
+
+	// 注文一括取込サービス（受注会のCSV/XLSXから顧客とDraft注文を一括作成）
+	var orderImportService *service.OrderImportService
+	if customerRepo != nil && fabricRollRepo != nil && db != nil {
+		orderImportService = service.NewOrderImportService(orderRepo, customerRepo, fabricRollRepo, pricingService, measurementValidationService, db)
+		log.Println("Order import service initialized")
+	}
+
+	// 団体注文サービス（法人顧客宛ての一括請求・生地のまとめ引当・進捗集計）
+	var groupOrderService *service.GroupOrderService
+	if groupOrderRepo != nil && orderRepo != nil && customerRepo != nil && tenantRepo != nil && inventoryAllocationService != nil {
+		groupOrderService = service.NewGroupOrderService(
+			groupOrderRepo,
+			orderRepo,
+			customerRepo,
+			fabricRepo,
+			tenantRepo,
+			fabricAllocationRepo,
+			orderService,
+			inventoryAllocationService,
+			db,
+		)
+		log.Println("Group order service initialized")
+	}
+
+	// 生産計画サービス（工場の生産能力・納期順の割り付け・納期遅延の代替案）
+	var productionScheduleService *service.ProductionScheduleService
+	if factoryCapacityRepo != nil && orderRepo != nil {
+		productionScheduleService = service.NewProductionScheduleService(factoryCapacityRepo, orderRepo)
+		log.Println("Production schedule service initialized")
+	}
+
+	// 製造タスクサービス（作業の開始・中断・完了、工程完了による注文ステータスの進行）
+	var productionTaskService *service.ProductionTaskService
+	if productionTaskRepo != nil && orderRepo != nil {
+		productionTaskService = service.NewProductionTaskService(productionTaskRepo, orderRepo, factoryCapacityRepo, db)
+		log.Println("Production task service initialized")
+	}
+
+	// 品質検品サービス（チェックリストによる合否判定、不合格時の手直しタスク、不良率）
+	var inspectionService *service.InspectionService
+	if inspectionRepo != nil && productionTaskRepo != nil && orderRepo != nil {
+		inspectionService = service.NewInspectionService(inspectionRepo, productionTaskRepo, orderRepo, factoryCapacityRepo, storageService, bucketName, db)
+		log.Println("Inspection service initialized")
+	}
+
+	// 出荷管理サービス（出荷登録、配送業者の追跡による納品完了、納品書・送り状PDF）
+	// 配送業者の追跡APIと接続するまでは追跡を行わず、配達完了は手動で登録する
+	// SHIPMENT_CARRIER_TRACKER=stub の場合のみ、開発用に出荷日から配達日を推定する代替実装で追跡する（納品完了にはしない）
+	var shipmentService *service.ShipmentService
+	if shipmentRepo != nil && orderRepo != nil {
+		var carrierTracker service.CarrierTracker
+		switch value := os.Getenv("SHIPMENT_CARRIER_TRACKER"); value {
+		case "":
+		case "stub":
+			carrierTracker = service.NewStubCarrierTracker()
+			log.Println("WARNING: Using stub carrier tracker (development only)")
+		default:
+			log.Printf("WARNING: Unknown SHIPMENT_CARRIER_TRACKER %q, carrier tracking is disabled", value)
+		}
+
+		shipmentService = service.NewShipmentService(shipmentRepo, orderRepo, inspectionRepo, tenantRepo, customerRepo, carrierTracker, db)
+		if carrierTracker != nil {
+			trackingPollInterval := 30 * time.Minute
+			if value := os.Getenv("SHIPMENT_TRACKING_POLL_INTERVAL"); value != "" {
+				if interval, err := time.ParseDuration(value); err == nil && interval > 0 {
+					trackingPollInterval = interval
+				} else {
+					log.Printf("WARNING: Invalid SHIPMENT_TRACKING_POLL_INTERVAL %q, using default: %s", value, trackingPollInterval)
+				}
+			}
+			shipmentService.StartTrackingPoller(ctx, trackingPollInterval)
+			log.Printf("Shipment service initialized (tracking poll interval: %s)", trackingPollInterval)
+		} else {
+			log.Println("Shipment service initialized (carrier tracking disabled)")
+		}
+	}
+
+	// 型紙サービス（基本ブロックのグレーディング、DXF-AAMA出力、仕様書PDF）
+	var patternService *service.PatternService
+	if orderRepo != nil {
+		patternService = service.NewPatternService(orderRepo, quoteRepo, customerRepo, measurementCorrectionService, unitService)
+		log.Println("Pattern service initialized")
+	}
+
+	// 入金消込サービス（全銀協フォーマット/CSV明細の取込）
+	var bankReconciliationService *service.BankReconciliationService
+	if bankStatementRepo != nil && transactionRepo != nil && customerRepo != nil && taxService != nil && db != nil {
+		bankReconciliationService = service.NewBankReconciliationService(
+			bankStatementRepo,
+			transactionRepo,
+			orderRepo,
+			customerRepo,
+			taxService,
+			db,
+		)
+		log.Println("Bank reconciliation service initialized")
+	}
+
+	// 支払業務サービス（工場支払・成果報酬の総合振込ファイル生成）
+	var payoutService *service.PayoutService
+	if payoutRepo != nil && bankAccountRepo != nil && commissionRepo != nil && taxService != nil && db != nil {
+		payoutService = service.NewPayoutService(
+			payoutRepo,
+			bankAccountRepo,
+			orderRepo,
+			commissionRepo,
+			taxService,
+			db,
+		)
+		log.Println("Payout service initialized")
+	}
+
+	// 会計連携サービス（freee / マネーフォワード / 弥生 仕訳エクスポート）
+	var accountingExportService *service.AccountingExportService
+	if accountMappingRepo != nil && transactionRepo != nil && commissionRepo != nil && ambassadorRepo != nil && payoutRepo != nil && bankAccountRepo != nil && taxService != nil {
+		accountingExportService = service.NewAccountingExportService(
+			accountMappingRepo,
+			orderRepo,
+			customerRepo,
+			transactionRepo,
+			commissionRepo,
+			ambassadorRepo,
+			payoutRepo,
+			bankAccountRepo,
+			taxService,
+		)
+		log.Println("Accounting export service initialized")
+	}
+
 	// ハンドラー
 	orderHandler := handler.NewOrderHandler(orderService)
+	fitCorrectionHandler := handler.NewFitCorrectionHandler(orderService)
 
 	// 生地ハンドラー
 	var fabricHandler *handler.FabricHandler
 	if fabricService != nil {
-		fabricHandler = handler.NewFabricHandler(fabricService)
+		fabricHandler = handler.NewFabricHandler(fabricService, unitService)
 	}
 
 	// アンバサダーハンドラー
@@ -361,7 +693,7 @@ func main() {
 	// 反物（Roll）ハンドラー
 	var fabricRollHandler *handler.FabricRollHandler
 	if fabricRollRepo != nil {
-		fabricRollHandler = handler.NewFabricRollHandler(fabricRollRepo)
+		fabricRollHandler = handler.NewFabricRollHandler(fabricRollRepo, unitService)
 		log.Println("Fabric roll handler initialized")
 	}
 
@@ -372,6 +704,16 @@ func main() {
 		log.Println("Inventory allocation handler initialized")
 	}
 
+	// 在庫レポートハンドラー
+	var inventoryReportHandler *handler.InventoryReportHandler
+	if inventoryReportService != nil {
+		inventoryReportHandler = handler.NewInventoryReportHandler(inventoryReportService)
+		log.Println("Inventory report handler initialized")
+	}
+
+	// 表示単位設定ハンドラー
+	unitHandler := handler.NewUnitHandler(unitService)
+
 	// 請求書ハンドラー（インボイスPDF生成用）
 	var invoiceHandler *handler.InvoiceHandler
 	if invoiceService != nil {
@@ -379,6 +721,41 @@ func main() {
 		log.Println("Invoice handler initialized")
 	}
 
+	// 電子インボイスハンドラー
+	var eInvoiceHandler *handler.EInvoiceHandler
+	if eInvoiceService != nil {
+		eInvoiceHandler = handler.NewEInvoiceHandler(eInvoiceService)
+		log.Println("E-invoice handler initialized")
+	}
+
+	// 見積書ハンドラー
+	var quoteHandler *handler.QuoteHandler
+	if quoteService != nil {
+		quoteHandler = handler.NewQuoteHandler(quoteService)
+		log.Println("Quote handler initialized")
+	}
+
+	// 価格計算ハンドラー
+	var pricingHandler *handler.PricingHandler
+	if pricingService != nil {
+		pricingHandler = handler.NewPricingHandler(pricingService)
+		log.Println("Pricing handler initialized")
+	}
+
+	// 割引・クーポンハンドラー
+	var campaignHandler *handler.CampaignHandler
+	if discountService != nil {
+		campaignHandler = handler.NewCampaignHandler(discountService)
+		log.Println("Campaign handler initialized")
+	}
+
+	// 注文キャンセルハンドラー
+	var orderCancellationHandler *handler.OrderCancellationHandler
+	if orderCancellationService != nil {
+		orderCancellationHandler = handler.NewOrderCancellationHandler(orderCancellationService)
+		log.Println("Order cancellation handler initialized")
+	}
+
 	// 権限ハンドラー
 	var permissionHandler *handler.PermissionHandler
 	if rbacService != nil {
@@ -393,6 +770,13 @@ func main() {
 		log.Println("Diagnosis handler initialized")
 	}
 
+	// 生地レコメンドハンドラー（Suit-MBTI統合）
+	var fabricRecommendationHandler *handler.FabricRecommendationHandler
+	if fabricRecommendationService != nil {
+		fabricRecommendationHandler = handler.NewFabricRecommendationHandler(fabricRecommendationService)
+		log.Println("Fabric recommendation handler initialized")
+	}
+
 	// 予約ハンドラー（Suit-MBTI統合）
 	var appointmentHandler *handler.AppointmentHandler
 	if appointmentService != nil {
@@ -414,6 +798,90 @@ func main() {
 		log.Println("Measurement validation handler initialized")
 	}
 
+	// 採寸プロファイルハンドラー
+	var measurementProfileHandler *handler.MeasurementProfileHandler
+	if measurementProfileService != nil {
+		measurementProfileHandler = handler.NewMeasurementProfileHandler(measurementProfileService)
+		log.Println("Measurement profile handler initialized")
+	}
+
+	// 再注文ハンドラー
+	var orderCloneHandler *handler.OrderCloneHandler
+	if orderCloneService != nil {
+		orderCloneHandler = handler.NewOrderCloneHandler(orderCloneService)
+		log.Println("Order clone handler initialized")
+	}
+
+	// 注文一括取込ハンドラー
+	var orderImportHandler *handler.OrderImportHandler
+	if orderImportService != nil {
+		orderImportHandler = handler.NewOrderImportHandler(orderImportService)
+		log.Println("Order import handler initialized")
+	}
+
+	// 団体注文ハンドラー
+	var groupOrderHandler *handler.GroupOrderHandler
+	if groupOrderService != nil {
+		groupOrderHandler = handler.NewGroupOrderHandler(groupOrderService)
+		log.Println("Group order handler initialized")
+	}
+
+	// 生産計画ハンドラー
+	var productionScheduleHandler *handler.ProductionScheduleHandler
+	if productionScheduleService != nil {
+		productionScheduleHandler = handler.NewProductionScheduleHandler(productionScheduleService)
+		log.Println("Production schedule handler initialized")
+	}
+
+	// 製造タスクハンドラー
+	var productionTaskHandler *handler.ProductionTaskHandler
+	if productionTaskService != nil {
+		productionTaskHandler = handler.NewProductionTaskHandler(productionTaskService)
+		log.Println("Production task handler initialized")
+	}
+
+	// 品質検品ハンドラー
+	var inspectionHandler *handler.InspectionHandler
+	if inspectionService != nil {
+		inspectionHandler = handler.NewInspectionHandler(inspectionService)
+		log.Println("Inspection handler initialized")
+	}
+
+	// 出荷管理ハンドラー
+	var shipmentHandler *handler.ShipmentHandler
+	if shipmentService != nil {
+		shipmentHandler = handler.NewShipmentHandler(shipmentService)
+		log.Println("Shipment handler initialized")
+	}
+
+	// 型紙ハンドラー
+	var patternHandler *handler.PatternHandler
+	if patternService != nil {
+		patternHandler = handler.NewPatternHandler(patternService)
+		log.Println("Pattern handler initialized")
+	}
+
+	// 入金消込ハンドラー
+	var bankReconciliationHandler *handler.BankReconciliationHandler
+	if bankReconciliationService != nil {
+		bankReconciliationHandler = handler.NewBankReconciliationHandler(bankReconciliationService)
+		log.Println("Bank reconciliation handler initialized")
+	}
+
+	// 支払業務ハンドラー
+	var payoutHandler *handler.PayoutHandler
+	if payoutService != nil {
+		payoutHandler = handler.NewPayoutHandler(payoutService)
+		log.Println("Payout handler initialized")
+	}
+
+	// 会計連携ハンドラー
+	var accountingHandler *handler.AccountingHandler
+	if accountingExportService != nil {
+		accountingHandler = handler.NewAccountingHandler(accountingExportService)
+		log.Println("Accounting handler initialized")
+	}
+
 	// 4. Routing
 	mux := http.NewServeMux()
 
@@ -460,6 +928,11 @@ func main() {
 		}
 	}))
 
+	// Fit correction endpoints (体型補正・注文の補正情報)
+	mux.HandleFunc("GET /api/fit-corrections", authChainMiddleware(fitCorrectionHandler.ListFitCorrections))
+	mux.HandleFunc("GET /api/orders/{id}/adjustments", authChainMiddleware(rbacMiddleware.RequireOwnerStaffOrFactoryManager()(fitCorrectionHandler.GetOrderAdjustments)))
+	mux.HandleFunc("PUT /api/orders/{id}/adjustments", authChainMiddleware(rbacMiddleware.RequireOwnerOrStaff()(fitCorrectionHandler.UpdateOrderAdjustments)))
+
 	// Compliance endpoints (PDF生成)
 	// 注意: パスパターンは /api/orders/{id}/generate-document の形式
 	// Go 1.22+ の新しいルーティング機能を使用
@@ -473,6 +946,7 @@ func main() {
 		mux.HandleFunc("GET /api/fabrics", authChainMiddleware(fabricHandler.ListFabrics))
 		mux.HandleFunc("GET /api/fabrics/detail", authChainMiddleware(fabricHandler.GetFabric))
 		mux.HandleFunc("POST /api/fabrics/reserve", authChainMiddleware(fabricHandler.ReserveFabric))
+		mux.HandleFunc("PUT /api/fabrics/{id}/attributes", authChainMiddleware(rbacMiddleware.RequireOwnerOrStaff()(fabricHandler.UpdateFabricAttributes)))
 	}
 
 	// Ambassador endpoints
@@ -507,11 +981,148 @@ func main() {
 		mux.HandleFunc("POST /api/inventory/release", authChainMiddleware(rbacMiddleware.RequireOwnerOrStaff()(inventoryAllocationHandler.ReleaseAllocation)))
 	}
 
+	// Inventory Report (在庫レポート) endpoints
+	if inventoryReportHandler != nil {
+		mux.HandleFunc("GET /api/inventory/report", authChainMiddleware(rbacMiddleware.RequireOwnerStaffOrFactoryManager()(inventoryReportHandler.GetInventoryReport)))
+		mux.HandleFunc("GET /api/inventory/report/pdf", authChainMiddleware(rbacMiddleware.RequireOwnerStaffOrFactoryManager()(inventoryReportHandler.GetInventoryReportPDF)))
+	}
+
+	// Unit Settings (表示単位) endpoints
+	mux.HandleFunc("GET /api/settings/units", authChainMiddleware(rbacMiddleware.RequireOwnerOrStaff()(unitHandler.GetUnitSettings)))
+	mux.HandleFunc("PUT /api/settings/units", authChainMiddleware(rbacMiddleware.RequireOwnerOnly()(unitHandler.UpdateUnitSettings)))
+
 	// Invoice (請求書・インボイス) endpoints
 	if invoiceHandler != nil {
 		mux.HandleFunc("POST /api/orders/{id}/generate-invoice", authChainMiddleware(rbacMiddleware.RequireOwnerOrStaff()(invoiceHandler.GenerateInvoice)))
 	}
 
+	// E-Invoice (電子インボイス・JP PINT) endpoints
+	if eInvoiceHandler != nil {
+		mux.HandleFunc("GET /api/orders/{id}/e-invoice", authChainMiddleware(rbacMiddleware.RequireOwnerOrStaff()(eInvoiceHandler.DownloadEInvoice)))
+		mux.HandleFunc("GET /api/orders/{id}/e-invoice/validate", authChainMiddleware(rbacMiddleware.RequireOwnerOrStaff()(eInvoiceHandler.ValidateEInvoice)))
+		mux.HandleFunc("POST /api/orders/{id}/e-invoice/send", authChainMiddleware(rbacMiddleware.RequireOwnerOnly()(eInvoiceHandler.SendEInvoice)))
+	}
+
+	// Pricing (価格計算) endpoints
+	if pricingHandler != nil {
+		mux.HandleFunc("POST /api/pricing/calculate", authChainMiddleware(rbacMiddleware.RequireOwnerOrStaff()(pricingHandler.CalculatePrice)))
+		mux.HandleFunc("GET /api/pricing/price-book", authChainMiddleware(rbacMiddleware.RequireOwnerOrStaff()(pricingHandler.GetPriceBook)))
+		mux.HandleFunc("PUT /api/pricing/price-book", authChainMiddleware(rbacMiddleware.RequireOwnerOnly()(pricingHandler.UpsertPriceBookEntry)))
+		mux.HandleFunc("GET /api/orders/{id}/price-overrides", authChainMiddleware(rbacMiddleware.RequireOwnerOrStaff()(pricingHandler.GetOrderPriceOverrides)))
+	}
+
+	// Campaign (割引・クーポン) endpoints
+	if campaignHandler != nil {
+		mux.HandleFunc("GET /api/campaigns", authChainMiddleware(rbacMiddleware.RequireOwnerOrStaff()(campaignHandler.ListCampaigns)))
+		mux.HandleFunc("POST /api/campaigns", authChainMiddleware(rbacMiddleware.RequireOwnerOnly()(campaignHandler.CreateCampaign)))
+		mux.HandleFunc("GET /api/campaigns/{id}", authChainMiddleware(rbacMiddleware.RequireOwnerOrStaff()(campaignHandler.GetCampaign)))
+		mux.HandleFunc("PUT /api/campaigns/{id}", authChainMiddleware(rbacMiddleware.RequireOwnerOnly()(campaignHandler.UpdateCampaign)))
+		mux.HandleFunc("POST /api/discounts/preview", authChainMiddleware(rbacMiddleware.RequireOwnerOrStaff()(campaignHandler.PreviewDiscounts)))
+		mux.HandleFunc("GET /api/orders/{id}/discounts", authChainMiddleware(rbacMiddleware.RequireOwnerOrStaff()(campaignHandler.GetOrderDiscounts)))
+	}
+
+	// Order clone (再注文) endpoints
+	if orderCloneHandler != nil {
+		mux.HandleFunc("POST /api/orders/{id}/clone", authChainMiddleware(rbacMiddleware.RequireOwnerOrStaff()(orderCloneHandler.CloneOrder)))
+	}
+
+	// Order import (注文一括取込) endpoints
+	if orderImportHandler != nil {
+		mux.HandleFunc("POST /api/orders/import", authChainMiddleware(rbacMiddleware.RequireOwnerOrStaff()(orderImportHandler.ImportOrders)))
+	}
+
+	// Group order (団体注文) endpoints
+	if groupOrderHandler != nil {
+		mux.HandleFunc("POST /api/group-orders", authChainMiddleware(rbacMiddleware.RequireOwnerOrStaff()(groupOrderHandler.CreateGroupOrder)))
+		mux.HandleFunc("GET /api/group-orders", authChainMiddleware(rbacMiddleware.RequireOwnerOrStaff()(groupOrderHandler.ListGroupOrders)))
+		mux.HandleFunc("GET /api/group-orders/{id}", authChainMiddleware(rbacMiddleware.RequireOwnerOrStaff()(groupOrderHandler.GetGroupOrder)))
+		mux.HandleFunc("POST /api/group-orders/{id}/close", authChainMiddleware(rbacMiddleware.RequireOwnerOrStaff()(groupOrderHandler.CloseGroupOrder)))
+		mux.HandleFunc("POST /api/group-orders/{id}/members", authChainMiddleware(rbacMiddleware.RequireOwnerOrStaff()(groupOrderHandler.AddMember)))
+		mux.HandleFunc("POST /api/group-orders/{id}/allocate", authChainMiddleware(rbacMiddleware.RequireOwnerOrStaff()(groupOrderHandler.AllocateFabric)))
+		mux.HandleFunc("GET /api/group-orders/{id}/invoice", authChainMiddleware(rbacMiddleware.RequireOwnerOrStaff()(groupOrderHandler.GetInvoice)))
+		mux.HandleFunc("GET /api/group-orders/{id}/progress", authChainMiddleware(rbacMiddleware.RequireOwnerOrStaff()(groupOrderHandler.GetProgress)))
+	}
+
+	// Production schedule (生産計画) endpoints
+	// 生産能力・個別設定の変更はオーナーと工場長のみ、計画の閲覧はスタッフも可
+	if productionScheduleHandler != nil {
+		mux.HandleFunc("GET /api/production/capacity", authChainMiddleware(rbacMiddleware.RequireOwnerStaffOrFactoryManager()(productionScheduleHandler.GetCapacity)))
+		mux.HandleFunc("PUT /api/production/capacity", authChainMiddleware(rbacMiddleware.RequireOwnerOrFactoryManager()(productionScheduleHandler.UpdateCapacity)))
+		mux.HandleFunc("GET /api/production/capacity/overrides", authChainMiddleware(rbacMiddleware.RequireOwnerStaffOrFactoryManager()(productionScheduleHandler.ListOverrides)))
+		mux.HandleFunc("POST /api/production/capacity/overrides", authChainMiddleware(rbacMiddleware.RequireOwnerOrFactoryManager()(productionScheduleHandler.SetOverride)))
+		mux.HandleFunc("DELETE /api/production/capacity/overrides/{id}", authChainMiddleware(rbacMiddleware.RequireOwnerOrFactoryManager()(productionScheduleHandler.DeleteOverride)))
+		mux.HandleFunc("GET /api/production/schedule", authChainMiddleware(rbacMiddleware.RequireOwnerStaffOrFactoryManager()(productionScheduleHandler.GetSchedule)))
+	}
+
+	// Production task (製造タスク) endpoints
+	// タスクの作成・割り当て・実績はオーナーと工場長、作業の記録は作業者も可
+	if productionTaskHandler != nil {
+		mux.HandleFunc("POST /api/orders/{id}/production-tasks", authChainMiddleware(rbacMiddleware.RequireOwnerOrFactoryManager()(productionTaskHandler.GenerateTasks)))
+		mux.HandleFunc("GET /api/orders/{id}/production-tasks", authChainMiddleware(rbacMiddleware.RequireOwnerStaffOrFactoryManager()(productionTaskHandler.ListOrderTasks)))
+		mux.HandleFunc("GET /api/production/tasks", authChainMiddleware(rbacMiddleware.RequireProductionFloor()(productionTaskHandler.ListTasks)))
+		mux.HandleFunc("PUT /api/production/tasks/{id}/assign", authChainMiddleware(rbacMiddleware.RequireOwnerOrFactoryManager()(productionTaskHandler.AssignTask)))
+		mux.HandleFunc("POST /api/production/tasks/{id}/start", authChainMiddleware(rbacMiddleware.RequireProductionFloor()(productionTaskHandler.StartTask)))
+		mux.HandleFunc("POST /api/production/tasks/{id}/pause", authChainMiddleware(rbacMiddleware.RequireProductionFloor()(productionTaskHandler.PauseTask)))
+		mux.HandleFunc("POST /api/production/tasks/{id}/complete", authChainMiddleware(rbacMiddleware.RequireProductionFloor()(productionTaskHandler.CompleteTask)))
+		mux.HandleFunc("GET /api/my/tasks", authChainMiddleware(rbacMiddleware.RequireProductionFloor()(productionTaskHandler.ListMyTasks)))
+		mux.HandleFunc("GET /api/production/reports/worker-throughput", authChainMiddleware(rbacMiddleware.RequireOwnerOrFactoryManager()(productionTaskHandler.GetWorkerThroughput)))
+	}
+
+	// Inspection (品質検品) endpoints
+	// チェックリストの設定・不良率はオーナーと工場長、検品の記録・写真の添付は作業者も可
+	if inspectionHandler != nil {
+		mux.HandleFunc("GET /api/inspection-checklists", authChainMiddleware(rbacMiddleware.RequireProductionFloor()(inspectionHandler.ListChecklists)))
+		mux.HandleFunc("GET /api/inspection-checklists/{garment_type}", authChainMiddleware(rbacMiddleware.RequireProductionFloor()(inspectionHandler.GetChecklist)))
+		mux.HandleFunc("PUT /api/inspection-checklists/{garment_type}", authChainMiddleware(rbacMiddleware.RequireOwnerOrFactoryManager()(inspectionHandler.UpdateChecklist)))
+		mux.HandleFunc("POST /api/orders/{id}/inspections", authChainMiddleware(rbacMiddleware.RequireProductionFloor()(inspectionHandler.SubmitInspection)))
+		mux.HandleFunc("GET /api/orders/{id}/inspections", authChainMiddleware(rbacMiddleware.RequireOwnerStaffOrFactoryManager()(inspectionHandler.ListOrderInspections)))
+		mux.HandleFunc("GET /api/inspections/{id}", authChainMiddleware(rbacMiddleware.RequireProductionFloor()(inspectionHandler.GetInspection)))
+		mux.HandleFunc("POST /api/inspections/{id}/photos", authChainMiddleware(rbacMiddleware.RequireProductionFloor()(inspectionHandler.AddPhoto)))
+		mux.HandleFunc("GET /api/production/reports/defect-rates", authChainMiddleware(rbacMiddleware.RequireOwnerOrFactoryManager()(inspectionHandler.GetDefectReport)))
+	}
+
+	// Shipment (出荷管理) endpoints
+	// 出荷登録・配達完了の登録はオーナーと工場長、照会はスタッフも可
+	if shipmentHandler != nil {
+		mux.HandleFunc("POST /api/orders/{id}/shipment", authChainMiddleware(rbacMiddleware.RequireOwnerOrFactoryManager()(shipmentHandler.CreateShipment)))
+		mux.HandleFunc("GET /api/orders/{id}/shipment", authChainMiddleware(rbacMiddleware.RequireOwnerStaffOrFactoryManager()(shipmentHandler.GetOrderShipment)))
+		mux.HandleFunc("GET /api/shipments", authChainMiddleware(rbacMiddleware.RequireOwnerStaffOrFactoryManager()(shipmentHandler.ListShipments)))
+		mux.HandleFunc("GET /api/shipments/{id}", authChainMiddleware(rbacMiddleware.RequireOwnerStaffOrFactoryManager()(shipmentHandler.GetShipment)))
+		mux.HandleFunc("POST /api/shipments/{id}/tracking", authChainMiddleware(rbacMiddleware.RequireOwnerStaffOrFactoryManager()(shipmentHandler.RefreshTracking)))
+		mux.HandleFunc("POST /api/shipments/{id}/deliver", authChainMiddleware(rbacMiddleware.RequireOwnerOrFactoryManager()(shipmentHandler.ConfirmDelivery)))
+		mux.HandleFunc("GET /api/shipments/{id}/packing-slip", authChainMiddleware(rbacMiddleware.RequireOwnerStaffOrFactoryManager()(shipmentHandler.DownloadPackingSlip)))
+		mux.HandleFunc("GET /api/shipments/{id}/label", authChainMiddleware(rbacMiddleware.RequireOwnerStaffOrFactoryManager()(shipmentHandler.DownloadShippingLabel)))
+	}
+
+	// Pattern (型紙) endpoints
+	// 工場がCAD裁断機に取り込むDXFと仕様書を出力（工場長も可）
+	if patternHandler != nil {
+		mux.HandleFunc("GET /api/orders/{id}/pattern", authChainMiddleware(rbacMiddleware.RequireOwnerStaffOrFactoryManager()(patternHandler.GetPattern)))
+		mux.HandleFunc("GET /api/orders/{id}/pattern/dxf", authChainMiddleware(rbacMiddleware.RequireOwnerStaffOrFactoryManager()(patternHandler.DownloadDXF)))
+		mux.HandleFunc("GET /api/orders/{id}/pattern/tech-sheet", authChainMiddleware(rbacMiddleware.RequireOwnerStaffOrFactoryManager()(patternHandler.DownloadTechSheet)))
+	}
+
+	// Order cancellation (注文キャンセル) endpoints
+	if orderCancellationHandler != nil {
+		mux.HandleFunc("POST /api/orders/{id}/cancel", authChainMiddleware(rbacMiddleware.RequireOwnerOrStaff()(orderCancellationHandler.CancelOrder)))
+		mux.HandleFunc("GET /api/orders/{id}/cancellation", authChainMiddleware(rbacMiddleware.RequireOwnerOrStaff()(orderCancellationHandler.GetCancellation)))
+	}
+
+	// Quote (見積書) endpoints
+	if quoteHandler != nil {
+		mux.HandleFunc("POST /api/quotes", authChainMiddleware(rbacMiddleware.RequireOwnerOrStaff()(quoteHandler.CreateQuote)))
+		mux.HandleFunc("GET /api/quotes", authChainMiddleware(rbacMiddleware.RequireOwnerOrStaff()(quoteHandler.ListQuotes)))
+		mux.HandleFunc("GET /api/quotes/{id}", authChainMiddleware(rbacMiddleware.RequireOwnerOrStaff()(quoteHandler.GetQuote)))
+		mux.HandleFunc("GET /api/quotes/{id}/versions", authChainMiddleware(rbacMiddleware.RequireOwnerOrStaff()(quoteHandler.GetQuoteVersions)))
+		mux.HandleFunc("GET /api/quotes/{id}/pdf", authChainMiddleware(rbacMiddleware.RequireOwnerOrStaff()(quoteHandler.DownloadQuotePDF)))
+		mux.HandleFunc("POST /api/quotes/{id}/revise", authChainMiddleware(rbacMiddleware.RequireOwnerOrStaff()(quoteHandler.ReviseQuote)))
+		mux.HandleFunc("POST /api/quotes/{id}/send", authChainMiddleware(rbacMiddleware.RequireOwnerOrStaff()(quoteHandler.SendQuote)))
+		mux.HandleFunc("POST /api/quotes/{id}/accept", authChainMiddleware(rbacMiddleware.RequireOwnerOrStaff()(quoteHandler.AcceptQuote)))
+		mux.HandleFunc("POST /api/quotes/{id}/reject", authChainMiddleware(rbacMiddleware.RequireOwnerOrStaff()(quoteHandler.RejectQuote)))
+		mux.HandleFunc("POST /api/quotes/{id}/convert", authChainMiddleware(rbacMiddleware.RequireOwnerOrStaff()(quoteHandler.ConvertQuote)))
+		mux.HandleFunc("GET /api/reports/quote-conversion", authChainMiddleware(rbacMiddleware.RequireOwnerOrStaff()(quoteHandler.GetConversionReport)))
+	}
+
 	// Permission (権限管理) endpoints
 	if permissionHandler != nil {
 		mux.HandleFunc("POST /api/permissions", authChainMiddleware(rbacMiddleware.RequireOwnerOnly()(permissionHandler.CreatePermission)))
@@ -524,6 +1135,17 @@ func main() {
 		mux.HandleFunc("POST /api/diagnoses", authChainMiddleware(diagnosisHandler.CreateDiagnosis))
 		mux.HandleFunc("GET /api/diagnoses/{id}", authChainMiddleware(diagnosisHandler.GetDiagnosis))
 		mux.HandleFunc("GET /api/diagnoses", authChainMiddleware(diagnosisHandler.ListDiagnoses))
+		mux.HandleFunc("GET /api/diagnoses/questions", authChainMiddleware(diagnosisHandler.GetDiagnosisQuestions))
+		mux.HandleFunc("POST /api/diagnoses/score", authChainMiddleware(diagnosisHandler.ScoreDiagnosis))
+		mux.HandleFunc("POST /api/diagnoses/rescore", authChainMiddleware(rbacMiddleware.RequireOwnerOnly()(diagnosisHandler.RescoreDiagnoses)))
+		mux.HandleFunc("POST /api/diagnoses/{id}/rescore", authChainMiddleware(rbacMiddleware.RequireOwnerOnly()(diagnosisHandler.RescoreDiagnosis)))
+	}
+
+	// Fabric Recommendation (生地レコメンド) endpoints (Suit-MBTI統合)
+	if fabricRecommendationHandler != nil {
+		mux.HandleFunc("GET /api/customers/{id}/fabric-recommendations", authChainMiddleware(rbacMiddleware.RequireOwnerOrStaff()(fabricRecommendationHandler.RecommendFabrics)))
+		mux.HandleFunc("GET /api/settings/fabric-recommendation", authChainMiddleware(rbacMiddleware.RequireOwnerOrStaff()(fabricRecommendationHandler.GetWeights)))
+		mux.HandleFunc("PUT /api/settings/fabric-recommendation", authChainMiddleware(rbacMiddleware.RequireOwnerOnly()(fabricRecommendationHandler.UpdateWeights)))
 	}
 
 	// Measurement Correction (自動補正エンジン) endpoints
@@ -535,6 +1157,19 @@ func main() {
 	if measurementValidationHandler != nil {
 		mux.HandleFunc("POST /api/measurements/validate", authChainMiddleware(rbacMiddleware.RequireOwnerOrStaff()(measurementValidationHandler.ValidateMeasurements)))
 		mux.HandleFunc("POST /api/measurements/validate-range", authChainMiddleware(rbacMiddleware.RequireOwnerOrStaff()(measurementValidationHandler.ValidateMeasurementRange)))
+		mux.HandleFunc("GET /api/measurement-templates", authChainMiddleware(rbacMiddleware.RequireOwnerOrStaff()(measurementValidationHandler.ListTemplates)))
+		mux.HandleFunc("GET /api/measurement-templates/{garment_type}", authChainMiddleware(rbacMiddleware.RequireOwnerOrStaff()(measurementValidationHandler.GetTemplate)))
+		mux.HandleFunc("PUT /api/measurement-templates/{garment_type}", authChainMiddleware(rbacMiddleware.RequireOwnerOnly()(measurementValidationHandler.UpdateTemplate)))
+	}
+
+	// Measurement profile (採寸プロファイル) endpoints
+	if measurementProfileHandler != nil {
+		mux.HandleFunc("GET /api/customers/{id}/measurement-profile", authChainMiddleware(rbacMiddleware.RequireOwnerOrStaff()(measurementProfileHandler.GetProfile)))
+		mux.HandleFunc("GET /api/customers/{id}/measurement-profile/chart", authChainMiddleware(rbacMiddleware.RequireOwnerOrStaff()(measurementProfileHandler.GetChart)))
+		mux.HandleFunc("GET /api/customers/{id}/measurement-profile/versions", authChainMiddleware(rbacMiddleware.RequireOwnerOrStaff()(measurementProfileHandler.ListVersions)))
+		mux.HandleFunc("POST /api/customers/{id}/measurement-profile/versions", authChainMiddleware(rbacMiddleware.RequireOwnerOrStaff()(measurementProfileHandler.RecordMeasurements)))
+		mux.HandleFunc("GET /api/customers/{id}/measurement-profile/versions/{version}", authChainMiddleware(rbacMiddleware.RequireOwnerOrStaff()(measurementProfileHandler.GetVersion)))
+		mux.HandleFunc("POST /api/customers/{id}/measurement-profile/versions/{version}/orders", authChainMiddleware(rbacMiddleware.RequireOwnerOrStaff()(measurementProfileHandler.LinkOrder)))
 	}
 
 	// Appointment (予約) endpoints (Suit-MBTI統合)
@@ -546,6 +1181,35 @@ func main() {
 		mux.HandleFunc("DELETE /api/appointments/{id}", authChainMiddleware(rbacMiddleware.RequireOwnerOrStaff()(appointmentHandler.CancelAppointment)))
 	}
 
+	// Bank Reconciliation (入金消込) endpoints
+	if bankReconciliationHandler != nil {
+		mux.HandleFunc("POST /api/bank-statements/import", authChainMiddleware(rbacMiddleware.RequireOwnerOnly()(bankReconciliationHandler.ImportStatement)))
+		mux.HandleFunc("GET /api/bank-statements/suspense", authChainMiddleware(rbacMiddleware.RequireOwnerOnly()(bankReconciliationHandler.ListSuspense)))
+		mux.HandleFunc("GET /api/bank-statements/proposals", authChainMiddleware(rbacMiddleware.RequireOwnerOnly()(bankReconciliationHandler.GetProposals)))
+		mux.HandleFunc("POST /api/bank-statements/{id}/confirm", authChainMiddleware(rbacMiddleware.RequireOwnerOnly()(bankReconciliationHandler.ConfirmMatch)))
+		mux.HandleFunc("POST /api/bank-statements/{id}/ignore", authChainMiddleware(rbacMiddleware.RequireOwnerOnly()(bankReconciliationHandler.IgnoreEntry)))
+	}
+
+	// Payout (支払業務・総合振込) endpoints
+	if payoutHandler != nil {
+		mux.HandleFunc("POST /api/bank-accounts", authChainMiddleware(rbacMiddleware.RequireOwnerOnly()(payoutHandler.RegisterBankAccount)))
+		mux.HandleFunc("GET /api/bank-accounts", authChainMiddleware(rbacMiddleware.RequireOwnerOnly()(payoutHandler.ListBankAccounts)))
+		mux.HandleFunc("POST /api/payouts", authChainMiddleware(rbacMiddleware.RequireOwnerOnly()(payoutHandler.CreatePayoutBatch)))
+		mux.HandleFunc("GET /api/payouts", authChainMiddleware(rbacMiddleware.RequireOwnerOnly()(payoutHandler.ListPayoutBatches)))
+		mux.HandleFunc("GET /api/payouts/{id}", authChainMiddleware(rbacMiddleware.RequireOwnerOnly()(payoutHandler.GetPayoutBatch)))
+		mux.HandleFunc("GET /api/payouts/{id}/file", authChainMiddleware(rbacMiddleware.RequireOwnerOnly()(payoutHandler.DownloadTransferFile)))
+		mux.HandleFunc("POST /api/payouts/{id}/confirm", authChainMiddleware(rbacMiddleware.RequireOwnerOnly()(payoutHandler.ConfirmPayoutBatch)))
+		mux.HandleFunc("POST /api/payouts/{id}/cancel", authChainMiddleware(rbacMiddleware.RequireOwnerOnly()(payoutHandler.CancelPayoutBatch)))
+	}
+
+	// Accounting (会計連携・仕訳エクスポート) endpoints
+	if accountingHandler != nil {
+		mux.HandleFunc("GET /api/accounting/account-mappings", authChainMiddleware(rbacMiddleware.RequireOwnerOnly()(accountingHandler.GetAccountMappings)))
+		mux.HandleFunc("PUT /api/accounting/account-mappings", authChainMiddleware(rbacMiddleware.RequireOwnerOnly()(accountingHandler.UpdateAccountMapping)))
+		mux.HandleFunc("GET /api/accounting/journal", authChainMiddleware(rbacMiddleware.RequireOwnerOnly()(accountingHandler.GetJournal)))
+		mux.HandleFunc("GET /api/accounting/journal/export", authChainMiddleware(rbacMiddleware.RequireOwnerOnly()(accountingHandler.ExportJournal)))
+	}
+
 	// Metrics (メトリクス) endpoint
 	metricsHandler := handler.NewMetricsHandler(metricsCollector)
 	mux.HandleFunc("GET /api/metrics", chainMiddleware(metricsHandler.GetMetrics))
@@ -0,0 +1,167 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AccountingSoftware 仕訳エクスポート先の会計ソフト
+type AccountingSoftware string
+
+const (
+	AccountingSoftwareFreee        AccountingSoftware = "FREEE"        // freee会計
+	AccountingSoftwareMoneyForward AccountingSoftware = "MONEYFORWARD" // マネーフォワード クラウド会計
+	AccountingSoftwareYayoi        AccountingSoftware = "YAYOI"        // 弥生会計
+)
+
+// IsValid 会計ソフトが有効かチェック
+func (s AccountingSoftware) IsValid() bool {
+	switch s {
+	case AccountingSoftwareFreee, AccountingSoftwareMoneyForward, AccountingSoftwareYayoi:
+		return true
+	default:
+		return false
+	}
+}
+
+// AccountKey 仕訳で使用する勘定科目の論理キー
+// 実際の科目名・科目コードはテナントごとの AccountMapping で決まる
+type AccountKey string
+
+const (
+	AccountKeyAccountsReceivable AccountKey = "ACCOUNTS_RECEIVABLE" // 売掛金
+	AccountKeySales              AccountKey = "SALES"               // 売上高
+	AccountKeyBankDeposit        AccountKey = "BANK_DEPOSIT"        // 普通預金
+	AccountKeyOutsourcingCost    AccountKey = "OUTSOURCING_COST"    // 外注加工費（工場への下請代金）
+	AccountKeyAccountsPayable    AccountKey = "ACCOUNTS_PAYABLE"    // 買掛金
+	AccountKeyCommissionExpense  AccountKey = "COMMISSION_EXPENSE"  // 支払手数料（アンバサダー成果報酬）
+	AccountKeyAccruedExpenses    AccountKey = "ACCRUED_EXPENSES"    // 未払金
+)
+
+// defaultAccountNames 勘定科目の既定名（会計ソフトの標準科目名）
+var defaultAccountNames = map[AccountKey]string{
+	AccountKeyAccountsReceivable: "売掛金",
+	AccountKeySales:              "売上高",
+	AccountKeyBankDeposit:        "普通預金",
+	AccountKeyOutsourcingCost:    "外注加工費",
+	AccountKeyAccountsPayable:    "買掛金",
+	AccountKeyCommissionExpense:  "支払手数料",
+	AccountKeyAccruedExpenses:    "未払金",
+}
+
+// AllAccountKeys 全ての勘定科目キー（表示順）
+func AllAccountKeys() []AccountKey {
+	return []AccountKey{
+		AccountKeyAccountsReceivable,
+		AccountKeySales,
+		AccountKeyBankDeposit,
+		AccountKeyOutsourcingCost,
+		AccountKeyAccountsPayable,
+		AccountKeyCommissionExpense,
+		AccountKeyAccruedExpenses,
+	}
+}
+
+// IsValid 勘定科目キーが有効かチェック
+func (k AccountKey) IsValid() bool {
+	_, ok := defaultAccountNames[k]
+	return ok
+}
+
+// AccountMapping テナントごとの勘定科目マッピング
+type AccountMapping struct {
+	ID             string     `json:"id" db:"id"`
+	TenantID       string     `json:"tenant_id" db:"tenant_id"`
+	AccountKey     AccountKey `json:"account_key" db:"account_key"`
+	AccountName    string     `json:"account_name" db:"account_name"`         // 勘定科目名
+	SubAccountName string     `json:"sub_account_name" db:"sub_account_name"` // 補助科目名
+	AccountCode    string     `json:"account_code" db:"account_code"`         // 科目コード（会計ソフト側）
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// NewAccountMapping 新しい勘定科目マッピングを作成
+func NewAccountMapping(tenantID string, key AccountKey, accountName string) *AccountMapping {
+	now := time.Now()
+	return &AccountMapping{
+		ID:          uuid.New().String(),
+		TenantID:    tenantID,
+		AccountKey:  key,
+		AccountName: accountName,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// DefaultAccountMapping 既定の勘定科目マッピング（テナント未設定時に使用）
+func DefaultAccountMapping(tenantID string, key AccountKey) *AccountMapping {
+	return &AccountMapping{
+		TenantID:    tenantID,
+		AccountKey:  key,
+		AccountName: defaultAccountNames[key],
+	}
+}
+
+// JournalTaxCategory 仕訳の税区分
+// 会計ソフトごとの表記は出力時に変換する
+type JournalTaxCategory string
+
+const (
+	JournalTaxCategorySales10          JournalTaxCategory = "SALES_10"           // 課税売上10%
+	JournalTaxCategorySalesReduced8    JournalTaxCategory = "SALES_REDUCED_8"    // 課税売上8%（軽減）
+	JournalTaxCategoryPurchase10       JournalTaxCategory = "PURCHASE_10"        // 課税仕入10%
+	JournalTaxCategoryPurchaseReduced8 JournalTaxCategory = "PURCHASE_REDUCED_8" // 課税仕入8%（軽減）
+	JournalTaxCategoryNotApplicable    JournalTaxCategory = "NOT_APPLICABLE"     // 対象外
+)
+
+// SalesTaxCategory 税率に対応する売上の税区分
+func SalesTaxCategory(rate TaxRate) JournalTaxCategory {
+	if rate == TaxRateReduced {
+		return JournalTaxCategorySalesReduced8
+	}
+	return JournalTaxCategorySales10
+}
+
+// PurchaseTaxCategory 税率に対応する仕入の税区分
+func PurchaseTaxCategory(rate TaxRate) JournalTaxCategory {
+	if rate == TaxRateReduced {
+		return JournalTaxCategoryPurchaseReduced8
+	}
+	return JournalTaxCategoryPurchase10
+}
+
+// JournalSourceType 仕訳の発生元
+type JournalSourceType string
+
+const (
+	JournalSourceInvoice           JournalSourceType = "INVOICE"            // 請求（売上計上）
+	JournalSourcePayment           JournalSourceType = "PAYMENT"            // 顧客からの入金
+	JournalSourceCommission        JournalSourceType = "COMMISSION"         // 成果報酬の計上
+	JournalSourceCommissionPayment JournalSourceType = "COMMISSION_PAYMENT" // 成果報酬の支払
+	JournalSourceFactoryPayable    JournalSourceType = "FACTORY_PAYABLE"    // 下請代金の計上
+	JournalSourceFactoryPayment    JournalSourceType = "FACTORY_PAYMENT"    // 下請代金の支払
+)
+
+// JournalLine 仕訳の借方・貸方の一方
+type JournalLine struct {
+	AccountKey     AccountKey         `json:"account_key"`
+	AccountName    string             `json:"account_name"`
+	SubAccountName string             `json:"sub_account_name,omitempty"`
+	AccountCode    string             `json:"account_code,omitempty"`
+	Amount         int64              `json:"amount"` // 税込金額（円）
+	TaxCategory    JournalTaxCategory `json:"tax_category"`
+	TaxAmount      int64              `json:"tax_amount"` // うち消費税額（円）
+}
+
+// JournalEntry 仕訳（1行 = 借方1科目・貸方1科目）
+type JournalEntry struct {
+	EntryNo      int               `json:"entry_no"` // 伝票番号（エクスポート内の連番）
+	Date         time.Time         `json:"date"`
+	Debit        JournalLine       `json:"debit"`
+	Credit       JournalLine       `json:"credit"`
+	Counterparty string            `json:"counterparty"` // 取引先
+	Description  string            `json:"description"`  // 摘要
+	SourceType   JournalSourceType `json:"source_type"`
+	SourceID     string            `json:"source_id"`
+}
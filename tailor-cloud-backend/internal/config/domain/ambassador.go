@@ -54,6 +54,7 @@ type CommissionStatus string
 const (
 	CommissionStatusPending   CommissionStatus = "Pending"   // 未確定（注文が確定していない）
 	CommissionStatusApproved  CommissionStatus = "Approved"  // 確定（支払い待ち）
+	CommissionStatusScheduled CommissionStatus = "Scheduled" // 振込予定（支払バッチ作成済み）
 	CommissionStatusPaid      CommissionStatus = "Paid"      // 支払済み
 	CommissionStatusCancelled CommissionStatus = "Cancelled" // キャンセル（注文がキャンセルされた場合）
 )
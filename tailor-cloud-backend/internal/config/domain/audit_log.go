@@ -38,6 +38,7 @@ const (
 	AuditActionView     AuditAction = "VIEW"     // 閲覧（契約書PDF閲覧など）
 	AuditActionConfirm  AuditAction = "CONFIRM"  // 確定（注文確定など）
 	AuditActionStatusChange AuditAction = "STATUS_CHANGE" // ステータス変更
+	AuditActionCancel   AuditAction = "CANCEL"   // キャンセル（注文キャンセルなど）
 )
 
 // ComplianceDocumentViewLog 契約書閲覧ログ
@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BankStatementEntry 入出金明細（1取引分）
+// 全銀協フォーマットまたはCSVから取り込んだ入金データ
+// 消込されていない入金は仮受（サスペンス）として残る
+type BankStatementEntry struct {
+	ID               string                   `json:"id" db:"id"`
+	TenantID         string                   `json:"tenant_id" db:"tenant_id"`
+	ImportID         string                   `json:"import_id" db:"import_id"`                   // 取込バッチID（同一ファイル内の明細をまとめる）
+	SourceFormat     BankStatementFormat      `json:"source_format" db:"source_format"`           // 取込元フォーマット
+	ReferenceNo      string                   `json:"reference_no" db:"reference_no"`             // 照会番号
+	TransactionDate  time.Time                `json:"transaction_date" db:"transaction_date"`     // 勘定日
+	Amount           int64                    `json:"amount" db:"amount"`                         // 入金額（円）
+	PayerName        string                   `json:"payer_name" db:"payer_name"`                 // 振込依頼人名（カナ）
+	PayerCode        string                   `json:"payer_code" db:"payer_code"`                 // 振込依頼人コード
+	RemitterBankName string                   `json:"remitter_bank_name" db:"remitter_bank_name"` // 仕向銀行名
+	Description      string                   `json:"description" db:"description"`               // 摘要内容 / EDI情報
+	Status           BankStatementEntryStatus `json:"status" db:"status"`
+	MatchedOrderID   string                   `json:"matched_order_id,omitempty" db:"matched_order_id"` // 消込先の注文ID
+	TransactionID    string                   `json:"transaction_id,omitempty" db:"transaction_id"`     // 作成された入金Transaction
+	ReconciledBy     string                   `json:"reconciled_by,omitempty" db:"reconciled_by"`
+	ReconciledAt     *time.Time               `json:"reconciled_at,omitempty" db:"reconciled_at"`
+	CreatedAt        time.Time                `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time                `json:"updated_at" db:"updated_at"`
+}
+
+// BankStatementFormat 入出金明細の取込フォーマット
+type BankStatementFormat string
+
+const (
+	BankStatementFormatZengin BankStatementFormat = "ZENGIN" // 全銀協 入出金取引明細（固定長200バイト）
+	BankStatementFormatCSV    BankStatementFormat = "CSV"    // インターネットバンキングのCSVエクスポート
+)
+
+// BankStatementEntryStatus 入出金明細の消込ステータス
+type BankStatementEntryStatus string
+
+const (
+	BankStatementEntryStatusUnmatched BankStatementEntryStatus = "UNMATCHED" // 未消込（仮受）
+	BankStatementEntryStatusMatched   BankStatementEntryStatus = "MATCHED"   // 消込済み
+	BankStatementEntryStatusIgnored   BankStatementEntryStatus = "IGNORED"   // 対象外（手数料戻し等）
+)
+
+// IsValid ステータスが有効かチェック
+func (s BankStatementEntryStatus) IsValid() bool {
+	switch s {
+	case BankStatementEntryStatusUnmatched, BankStatementEntryStatusMatched, BankStatementEntryStatusIgnored:
+		return true
+	default:
+		return false
+	}
+}
+
+// NewBankStatementEntry 新しい入出金明細を作成
+func NewBankStatementEntry(tenantID, importID string, format BankStatementFormat) *BankStatementEntry {
+	now := time.Now()
+	return &BankStatementEntry{
+		ID:           uuid.New().String(),
+		TenantID:     tenantID,
+		ImportID:     importID,
+		SourceFormat: format,
+		Status:       BankStatementEntryStatusUnmatched,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+}
+
+// MarkMatched 消込済みにする
+func (e *BankStatementEntry) MarkMatched(orderID, transactionID, userID string) {
+	now := time.Now()
+	e.Status = BankStatementEntryStatusMatched
+	e.MatchedOrderID = orderID
+	e.TransactionID = transactionID
+	e.ReconciledBy = userID
+	e.ReconciledAt = &now
+	e.UpdatedAt = now
+}
+
+// InvoiceReference 振込依頼人名に付記してもらう請求書番号
+// 注文ID（UUID）の先頭8文字を大文字化したもの（振込依頼人名欄は半角カナ・英数字のため）
+func InvoiceReference(orderID string) string {
+	ref := strings.ToUpper(strings.ReplaceAll(orderID, "-", ""))
+	if len(ref) > 8 {
+		ref = ref[:8]
+	}
+	return ref
+}
+
+// PaymentMethodBankTransfer 銀行振込による入金
+const PaymentMethodBankTransfer = "bank_transfer"
@@ -29,6 +29,7 @@ type DocumentType string
 const (
 	DocumentTypeInitial    DocumentType = "INITIAL"    // 初回発注書
 	DocumentTypeAmendment  DocumentType = "AMENDMENT"  // 修正発注書
+	DocumentTypeCancellation DocumentType = "CANCELLATION" // 発注取消通知書
 )
 
 // IsInitial 初回発注書かどうか
@@ -41,6 +42,11 @@ func (d *ComplianceDocument) IsAmendment() bool {
 	return d.DocumentType == DocumentTypeAmendment
 }
 
+// IsCancellation 発注取消通知書かどうか
+func (d *ComplianceDocument) IsCancellation() bool {
+	return d.DocumentType == DocumentTypeCancellation
+}
+
 // HasParent 親文書があるかどうか
 func (d *ComplianceDocument) HasParent() bool {
 	return d.ParentDocumentID != nil && *d.ParentDocumentID != ""
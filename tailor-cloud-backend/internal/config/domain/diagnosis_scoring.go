@@ -0,0 +1,272 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// CurrentDiagnosisAlgorithmVersion 新しい診断の採点に使う設問・重みのバージョン
+const CurrentDiagnosisAlgorithmVersion = "suit-mbti-v1"
+
+// DiagnosisArchetypes アーキタイプの一覧（得点が同じ場合はこの順で優先）
+var DiagnosisArchetypes = []Archetype{
+	ArchetypeClassic,
+	ArchetypeModern,
+	ArchetypeElegant,
+	ArchetypeSporty,
+	ArchetypeCasual,
+}
+
+// DiagnosisOption 設問の選択肢と採点の重み
+type DiagnosisOption struct {
+	Value      string                `json:"value"`
+	Label      string                `json:"label"`
+	Weights    map[Archetype]float64 `json:"-"` // アーキタイプごとの加点
+	PlanWeight float64               `json:"-"` // プランの加点（正はオーセンティック、負はベストバリュー寄り）
+}
+
+// DiagnosisQuestion 診断の設問
+type DiagnosisQuestion struct {
+	ID      string             `json:"id"`
+	Text    string             `json:"text"`
+	Options []*DiagnosisOption `json:"options"`
+}
+
+// DiagnosisQuestionBank バージョン付きの設問と採点の重み
+// 重みはサーバー側だけで保持し、設問の取得APIには選択肢のみを返す
+type DiagnosisQuestionBank struct {
+	Version       string               `json:"version"`
+	Questions     []*DiagnosisQuestion `json:"questions"`
+	PlanThreshold float64              `json:"-"` // プランの得点がこの値以上ならオーセンティック
+}
+
+// DiagnosisQuestionBanks 設問・重みのバージョンの一覧（過去の回答の再採点用に古いバージョンも残す）
+var DiagnosisQuestionBanks = map[string]*DiagnosisQuestionBank{
+	"suit-mbti-v1": {
+		Version:       "suit-mbti-v1",
+		PlanThreshold: 1.0,
+		Questions: []*DiagnosisQuestion{
+			{
+				ID:   "occasion",
+				Text: "スーツを最もよく着る場面は？",
+				Options: []*DiagnosisOption{
+					{Value: "business", Label: "商談・会議", Weights: map[Archetype]float64{ArchetypeClassic: 2, ArchetypeElegant: 1}},
+					{Value: "ceremony", Label: "式典・パーティー", Weights: map[Archetype]float64{ArchetypeElegant: 2, ArchetypeClassic: 1}},
+					{Value: "creative", Label: "クリエイティブな職場", Weights: map[Archetype]float64{ArchetypeModern: 2, ArchetypeCasual: 1}},
+					{Value: "active", Label: "外回り・出張", Weights: map[Archetype]float64{ArchetypeSporty: 2, ArchetypeModern: 0.5}},
+					{Value: "weekend", Label: "休日・カジュアルな集まり", Weights: map[Archetype]float64{ArchetypeCasual: 2, ArchetypeModern: 0.5}},
+				},
+			},
+			{
+				ID:   "silhouette",
+				Text: "好みのシルエットは？",
+				Options: []*DiagnosisOption{
+					{Value: "structured", Label: "肩のしっかりした構築的なシルエット", Weights: map[Archetype]float64{ArchetypeClassic: 2}},
+					{Value: "slim", Label: "細身でシャープなシルエット", Weights: map[Archetype]float64{ArchetypeModern: 2, ArchetypeSporty: 0.5}},
+					{Value: "draped", Label: "ドレープの美しい柔らかなシルエット", Weights: map[Archetype]float64{ArchetypeElegant: 2}},
+					{Value: "athletic", Label: "動きやすく体に沿うシルエット", Weights: map[Archetype]float64{ArchetypeSporty: 2}},
+					{Value: "relaxed", Label: "ゆとりのあるリラックスしたシルエット", Weights: map[Archetype]float64{ArchetypeCasual: 2}},
+				},
+			},
+			{
+				ID:   "color",
+				Text: "よく選ぶ色は？",
+				Options: []*DiagnosisOption{
+					{Value: "navy_grey", Label: "ネイビー・グレー", Weights: map[Archetype]float64{ArchetypeClassic: 2}},
+					{Value: "monotone", Label: "ブラック・チャコールなどのモノトーン", Weights: map[Archetype]float64{ArchetypeModern: 2}},
+					{Value: "deep", Label: "ボルドー・ミッドナイトブルーなどの深い色", Weights: map[Archetype]float64{ArchetypeElegant: 2, ArchetypeClassic: 0.5}},
+					{Value: "bright", Label: "明るいブルー・ライトグレー", Weights: map[Archetype]float64{ArchetypeSporty: 1.5, ArchetypeCasual: 1}},
+					{Value: "earth", Label: "ブラウン・ベージュ・オリーブ", Weights: map[Archetype]float64{ArchetypeCasual: 2}},
+				},
+			},
+			{
+				ID:   "pattern",
+				Text: "柄の好みは？",
+				Options: []*DiagnosisOption{
+					{Value: "solid", Label: "無地", Weights: map[Archetype]float64{ArchetypeModern: 1.5, ArchetypeClassic: 1}},
+					{Value: "stripe", Label: "ストライプ", Weights: map[Archetype]float64{ArchetypeClassic: 2}},
+					{Value: "check", Label: "チェック", Weights: map[Archetype]float64{ArchetypeCasual: 1.5, ArchetypeClassic: 0.5}},
+					{Value: "texture", Label: "織り柄・光沢のある生地", Weights: map[Archetype]float64{ArchetypeElegant: 2}},
+				},
+			},
+			{
+				ID:   "movement",
+				Text: "スーツを着ている日の過ごし方は？",
+				Options: []*DiagnosisOption{
+					{Value: "desk", Label: "デスクワーク中心", Weights: map[Archetype]float64{ArchetypeClassic: 1, ArchetypeModern: 1}},
+					{Value: "meetings", Label: "人と会うことが多い", Weights: map[Archetype]float64{ArchetypeElegant: 1, ArchetypeClassic: 1}},
+					{Value: "walking", Label: "よく歩く・移動が多い", Weights: map[Archetype]float64{ArchetypeSporty: 2}},
+					{Value: "travel", Label: "出張・長時間の移動", Weights: map[Archetype]float64{ArchetypeSporty: 1, ArchetypeCasual: 1}},
+				},
+			},
+			{
+				ID:   "impression",
+				Text: "スーツで与えたい印象は？",
+				Options: []*DiagnosisOption{
+					{Value: "trust", Label: "信頼感", Weights: map[Archetype]float64{ArchetypeClassic: 2}},
+					{Value: "innovative", Label: "先進性", Weights: map[Archetype]float64{ArchetypeModern: 2}},
+					{Value: "refined", Label: "品格", Weights: map[Archetype]float64{ArchetypeElegant: 2}},
+					{Value: "energetic", Label: "活力", Weights: map[Archetype]float64{ArchetypeSporty: 2}},
+					{Value: "approachable", Label: "親しみやすさ", Weights: map[Archetype]float64{ArchetypeCasual: 2}},
+				},
+			},
+			{
+				ID:   "fabric_priority",
+				Text: "生地選びで最も重視することは？",
+				Options: []*DiagnosisOption{
+					{Value: "price", Label: "価格", PlanWeight: -2},
+					{Value: "durability", Label: "丈夫さ・シワになりにくさ", Weights: map[Archetype]float64{ArchetypeSporty: 0.5}, PlanWeight: -1},
+					{Value: "mill", Label: "生地ブランド・産地", Weights: map[Archetype]float64{ArchetypeElegant: 0.5, ArchetypeClassic: 0.5}, PlanWeight: 2},
+					{Value: "hand_feel", Label: "風合い・着心地", Weights: map[Archetype]float64{ArchetypeElegant: 0.5}, PlanWeight: 1.5},
+				},
+			},
+			{
+				ID:   "budget",
+				Text: "スーツ1着の予算は？",
+				Options: []*DiagnosisOption{
+					{Value: "under_80k", Label: "8万円未満", PlanWeight: -2},
+					{Value: "80k_150k", Label: "8万〜15万円", PlanWeight: 0},
+					{Value: "over_150k", Label: "15万円以上", PlanWeight: 2},
+				},
+			},
+			{
+				ID:   "frequency",
+				Text: "スーツを着る頻度は？",
+				Options: []*DiagnosisOption{
+					{Value: "daily", Label: "ほぼ毎日", Weights: map[Archetype]float64{ArchetypeClassic: 0.5}, PlanWeight: -1},
+					{Value: "weekly", Label: "週に数回", PlanWeight: 0},
+					{Value: "occasionally", Label: "特別な日だけ", Weights: map[Archetype]float64{ArchetypeElegant: 0.5}, PlanWeight: 1},
+				},
+			},
+		},
+	},
+}
+
+// DiagnosisQuestionBankFor 設問・重みのバージョンを取得（空の場合は現行のバージョン）
+func DiagnosisQuestionBankFor(version string) (*DiagnosisQuestionBank, error) {
+	if version == "" {
+		version = CurrentDiagnosisAlgorithmVersion
+	}
+	bank, ok := DiagnosisQuestionBanks[version]
+	if !ok {
+		return nil, fmt.Errorf("invalid algorithm_version: %s", version)
+	}
+	return bank, nil
+}
+
+// DiagnosisAnswer 設問への回答
+type DiagnosisAnswer struct {
+	QuestionID string `json:"question_id"`
+	Option     string `json:"option"`
+}
+
+// ScoredDiagnosisResult サーバー側で採点した診断結果（Diagnosis.DiagnosisResult に保存）
+type ScoredDiagnosisResult struct {
+	AlgorithmVersion string             `json:"algorithm_version"`
+	Archetype        Archetype          `json:"archetype"`
+	PlanType         PlanType           `json:"plan_type"`
+	Scores           map[string]float64 `json:"scores"`     // アーキタイプごとの得点の割合（%、キーは小文字のアーキタイプ）
+	PlanScore        float64            `json:"plan_score"` // プランの得点（PlanThreshold 以上でオーセンティック）
+	Answers          []DiagnosisAnswer  `json:"answers"`    // 再採点用の回答
+	ScoredAt         time.Time          `json:"scored_at"`
+}
+
+// Score 回答を採点してアーキタイプ・プランを決める（全設問に1つずつ回答が必要）
+func (b *DiagnosisQuestionBank) Score(answers []DiagnosisAnswer, scoredAt time.Time) (*ScoredDiagnosisResult, error) {
+	selected := make(map[string]string, len(answers))
+	for _, answer := range answers {
+		if b.question(answer.QuestionID) == nil {
+			return nil, fmt.Errorf("invalid answers: unknown question %s", answer.QuestionID)
+		}
+		if _, ok := selected[answer.QuestionID]; ok {
+			return nil, fmt.Errorf("invalid answers: duplicate answer for %s", answer.QuestionID)
+		}
+		selected[answer.QuestionID] = answer.Option
+	}
+
+	raw := make(map[Archetype]float64, len(DiagnosisArchetypes))
+	planScore := 0.0
+	for _, question := range b.Questions {
+		value, ok := selected[question.ID]
+		if !ok {
+			return nil, fmt.Errorf("invalid answers: %s is required", question.ID)
+		}
+		option := question.option(value)
+		if option == nil {
+			return nil, fmt.Errorf("invalid answers: unknown option %s for %s", value, question.ID)
+		}
+		for archetype, weight := range option.Weights {
+			raw[archetype] += weight
+		}
+		planScore += option.PlanWeight
+	}
+
+	result := &ScoredDiagnosisResult{
+		AlgorithmVersion: b.Version,
+		Archetype:        DiagnosisArchetypes[0],
+		PlanType:         PlanTypeBestValue,
+		Scores:           make(map[string]float64, len(DiagnosisArchetypes)),
+		PlanScore:        planScore,
+		Answers:          answers,
+		ScoredAt:         scoredAt,
+	}
+	total := 0.0
+	for _, archetype := range DiagnosisArchetypes {
+		total += raw[archetype]
+		if raw[archetype] > raw[result.Archetype] {
+			result.Archetype = archetype
+		}
+	}
+	for _, archetype := range DiagnosisArchetypes {
+		share := 0.0
+		if total > 0 {
+			share = math.Round(raw[archetype]/total*1000) / 10
+		}
+		result.Scores[strings.ToLower(string(archetype))] = share
+	}
+	if planScore >= b.PlanThreshold {
+		result.PlanType = PlanTypeAuthentic
+	}
+	return result, nil
+}
+
+// question 設問をIDで探す（未定義の場合はnil）
+func (b *DiagnosisQuestionBank) question(id string) *DiagnosisQuestion {
+	for _, question := range b.Questions {
+		if question.ID == id {
+			return question
+		}
+	}
+	return nil
+}
+
+// option 選択肢を値で探す（未定義の場合はnil）
+func (q *DiagnosisQuestion) option(value string) *DiagnosisOption {
+	for _, option := range q.Options {
+		if option.Value == value {
+			return option
+		}
+	}
+	return nil
+}
+
+// DiagnosisAnswers 保存された診断結果から再採点用の回答を取り出す
+// サーバー側で採点していない診断（回答が保存されていない）はエラー
+func DiagnosisAnswers(diagnosisResult json.RawMessage) ([]DiagnosisAnswer, error) {
+	var result struct {
+		Answers []DiagnosisAnswer `json:"answers"`
+	}
+	if trimmed := bytes.TrimSpace(diagnosisResult); len(trimmed) > 0 && !bytes.Equal(trimmed, []byte("null")) {
+		if err := json.Unmarshal(trimmed, &result); err != nil {
+			return nil, fmt.Errorf("invalid diagnosis_result: %w", err)
+		}
+	}
+	if len(result.Answers) == 0 {
+		return nil, fmt.Errorf("invalid diagnosis_result: no answers recorded")
+	}
+	return result.Answers, nil
+}
@@ -0,0 +1,146 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DiscountType 割引種別
+type DiscountType string
+
+const (
+	DiscountTypePercentage  DiscountType = "PERCENTAGE"   // 定率割引（value = 割引率%）
+	DiscountTypeFixedAmount DiscountType = "FIXED_AMOUNT" // 定額割引（value = 割引額・円）
+)
+
+// IsValid 割引種別が有効かチェック
+func (t DiscountType) IsValid() bool {
+	switch t {
+	case DiscountTypePercentage, DiscountTypeFixedAmount:
+		return true
+	default:
+		return false
+	}
+}
+
+// Campaign キャンペーン・クーポン
+// Codeが空のキャンペーンは条件を満たす注文に自動適用、Codeがある場合はクーポンコード入力時のみ適用
+type Campaign struct {
+	ID                 string       `json:"id" db:"id"`
+	TenantID           string       `json:"tenant_id" db:"tenant_id"`
+	Name               string       `json:"name" db:"name"`
+	Code               string       `json:"code" db:"code"` // クーポンコード（大文字で保存）
+	DiscountType       DiscountType `json:"discount_type" db:"discount_type"`
+	Value              int64        `json:"value" db:"value"`                             // 割引率（%）または割引額（円）
+	EligibleArchetypes []Archetype  `json:"eligible_archetypes" db:"eligible_archetypes"` // 空の場合は全アーキタイプ
+	EligiblePlanTypes  []PlanType   `json:"eligible_plan_types" db:"eligible_plan_types"` // 空の場合は全プラン
+	EligibleFabricIDs  []string     `json:"eligible_fabric_ids" db:"eligible_fabric_ids"` // 空の場合は全生地
+	StartsAt           time.Time    `json:"starts_at" db:"starts_at"`
+	EndsAt             *time.Time   `json:"ends_at" db:"ends_at"`                       // nilの場合は無期限
+	PerCustomerLimit   int          `json:"per_customer_limit" db:"per_customer_limit"` // 顧客ごとの利用回数上限（0 = 無制限）
+	Stackable          bool         `json:"stackable" db:"stackable"`                   // 他の併用可能な割引と併用できるか
+	Active             bool         `json:"active" db:"active"`
+	CreatedAt          time.Time    `json:"created_at" db:"created_at"`
+	UpdatedAt          time.Time    `json:"updated_at" db:"updated_at"`
+}
+
+// NewCampaign 新しいキャンペーンを作成
+func NewCampaign(tenantID, name, code string, discountType DiscountType, value int64, startsAt time.Time) *Campaign {
+	now := time.Now()
+	return &Campaign{
+		ID:                 uuid.New().String(),
+		TenantID:           tenantID,
+		Name:               name,
+		Code:               NormalizeCouponCode(code),
+		DiscountType:       discountType,
+		Value:              value,
+		EligibleArchetypes: make([]Archetype, 0),
+		EligiblePlanTypes:  make([]PlanType, 0),
+		EligibleFabricIDs:  make([]string, 0),
+		StartsAt:           startsAt,
+		Active:             true,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}
+}
+
+// NormalizeCouponCode クーポンコードを比較用に正規化（前後の空白除去・大文字化）
+func NormalizeCouponCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// IsAutomatic クーポンコード不要で自動適用されるキャンペーンか
+func (c *Campaign) IsAutomatic() bool {
+	return c.Code == ""
+}
+
+// IsActiveAt 指定日時に有効期間内か
+func (c *Campaign) IsActiveAt(t time.Time) bool {
+	if !c.Active {
+		return false
+	}
+	if t.Before(c.StartsAt) {
+		return false
+	}
+	if c.EndsAt != nil && !t.Before(*c.EndsAt) {
+		return false
+	}
+	return true
+}
+
+// DiscountFor 税抜金額に対する割引額を計算（円未満切り捨て、金額を超えない）
+func (c *Campaign) DiscountFor(amount int64) int64 {
+	var discount int64
+	switch c.DiscountType {
+	case DiscountTypePercentage:
+		discount = amount * c.Value / 100
+	case DiscountTypeFixedAmount:
+		discount = c.Value
+	}
+	if discount > amount {
+		discount = amount
+	}
+	if discount < 0 {
+		discount = 0
+	}
+	return discount
+}
+
+// OrderDiscount 注文の割引明細
+// 注文に適用した割引を明示的な明細行として保持し、顧客ごとの利用回数の集計にも使用する
+type OrderDiscount struct {
+	ID          string    `json:"id" db:"id"`
+	TenantID    string    `json:"tenant_id" db:"tenant_id"`
+	OrderID     string    `json:"order_id" db:"order_id"`
+	CustomerID  string    `json:"customer_id" db:"customer_id"`
+	CampaignID  string    `json:"campaign_id" db:"campaign_id"`
+	Code        string    `json:"code" db:"code"`               // 利用したクーポンコード（自動適用の場合は空）
+	Description string    `json:"description" db:"description"` // 明細に表示する名称
+	Amount      int64     `json:"amount" db:"amount"`           // 割引額（税抜・円、正の値）
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
+
+// NewOrderDiscount 割引明細を作成
+func NewOrderDiscount(campaign *Campaign, customerID string, amount int64) *OrderDiscount {
+	return &OrderDiscount{
+		ID:          uuid.New().String(),
+		TenantID:    campaign.TenantID,
+		CampaignID:  campaign.ID,
+		CustomerID:  customerID,
+		Code:        campaign.Code,
+		Description: campaign.Name,
+		Amount:      amount,
+		CreatedAt:   time.Now(),
+	}
+}
+
+// TotalDiscountAmount 割引明細の合計額
+func TotalDiscountAmount(discounts []*OrderDiscount) int64 {
+	var total int64
+	for _, d := range discounts {
+		total += d.Amount
+	}
+	return total
+}
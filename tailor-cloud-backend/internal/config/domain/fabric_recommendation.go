@@ -0,0 +1,398 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FabricColorFamily 生地の色系統
+type FabricColorFamily string
+
+const (
+	FabricColorNavy  FabricColorFamily = "navy"  // ネイビー
+	FabricColorGrey  FabricColorFamily = "grey"  // グレー
+	FabricColorBlack FabricColorFamily = "black" // ブラック
+	FabricColorBlue  FabricColorFamily = "blue"  // ブルー
+	FabricColorBrown FabricColorFamily = "brown" // ブラウン
+	FabricColorGreen FabricColorFamily = "green" // グリーン・オリーブ
+	FabricColorBeige FabricColorFamily = "beige" // ベージュ
+)
+
+// FabricPattern 生地の柄
+type FabricPattern string
+
+const (
+	FabricPatternSolid       FabricPattern = "solid"       // 無地
+	FabricPatternStripe      FabricPattern = "stripe"      // ストライプ
+	FabricPatternCheck       FabricPattern = "check"       // チェック
+	FabricPatternHerringbone FabricPattern = "herringbone" // ヘリンボーン
+	FabricPatternTexture     FabricPattern = "texture"     // 織り柄・光沢
+)
+
+// FabricSeason 生地のシーズン
+type FabricSeason string
+
+const (
+	FabricSeasonAll          FabricSeason = "all_season"    // オールシーズン
+	FabricSeasonSpringSummer FabricSeason = "spring_summer" // 春夏
+	FabricSeasonAutumnWinter FabricSeason = "autumn_winter" // 秋冬
+)
+
+// 目付（g/m）の区分の境界
+const (
+	fabricLightWeightMax  = 250 // 250g/m未満は軽量
+	fabricMediumWeightMax = 300 // 300g/m以下は中肉、超えると厚手
+)
+
+// 属性ごとの選択肢と表示名（レコメンドの理由に使う）
+var (
+	fabricColorFamilyLabels = map[FabricColorFamily]string{
+		FabricColorNavy: "ネイビー", FabricColorGrey: "グレー", FabricColorBlack: "ブラック", FabricColorBlue: "ブルー",
+		FabricColorBrown: "ブラウン", FabricColorGreen: "グリーン", FabricColorBeige: "ベージュ",
+	}
+	fabricPatternLabels = map[FabricPattern]string{
+		FabricPatternSolid: "無地", FabricPatternStripe: "ストライプ", FabricPatternCheck: "チェック",
+		FabricPatternHerringbone: "ヘリンボーン", FabricPatternTexture: "織り柄",
+	}
+	fabricSeasonLabels = map[FabricSeason]string{
+		FabricSeasonAll: "オールシーズン", FabricSeasonSpringSummer: "春夏", FabricSeasonAutumnWinter: "秋冬",
+	}
+	fabricWeightLabels = map[string]string{
+		"light": "軽量", "medium": "中肉", "heavy": "厚手",
+	}
+	fabricAttributeLabels = map[string]string{
+		"color_family": "色", "pattern": "柄", "weight": "目付", "season": "シーズン", "formality": "フォーマル度",
+	}
+	archetypeLabels = map[Archetype]string{
+		ArchetypeClassic: "クラシック", ArchetypeModern: "モダン", ArchetypeElegant: "エレガント",
+		ArchetypeSporty: "スポーティ", ArchetypeCasual: "カジュアル",
+	}
+)
+
+// FabricAttributes 生地の属性（未設定の属性はレコメンドの加点なし）
+type FabricAttributes struct {
+	ColorFamily FabricColorFamily `json:"color_family,omitempty"`
+	Pattern     FabricPattern     `json:"pattern,omitempty"`
+	Weight      int               `json:"weight,omitempty"` // 目付（g/m）
+	Season      FabricSeason      `json:"season,omitempty"`
+	Formality   int               `json:"formality,omitempty"` // フォーマル度（1: カジュアル 〜 5: フォーマル）
+}
+
+// Validate 生地の属性をチェック
+func (a FabricAttributes) Validate() error {
+	if a.ColorFamily != "" {
+		if _, ok := fabricColorFamilyLabels[a.ColorFamily]; !ok {
+			return fmt.Errorf("invalid color_family: %s", a.ColorFamily)
+		}
+	}
+	if a.Pattern != "" {
+		if _, ok := fabricPatternLabels[a.Pattern]; !ok {
+			return fmt.Errorf("invalid pattern: %s", a.Pattern)
+		}
+	}
+	if a.Weight < 0 {
+		return fmt.Errorf("invalid weight: must not be negative")
+	}
+	if a.Season != "" {
+		if _, ok := fabricSeasonLabels[a.Season]; !ok {
+			return fmt.Errorf("invalid season: %s", a.Season)
+		}
+	}
+	if a.Formality != 0 && (a.Formality < 1 || a.Formality > 5) {
+		return fmt.Errorf("invalid formality: must be between 1 and 5")
+	}
+	return nil
+}
+
+// WeightBand 目付の区分（light / medium / heavy、未設定は空）
+func (a FabricAttributes) WeightBand() string {
+	switch {
+	case a.Weight <= 0:
+		return ""
+	case a.Weight < fabricLightWeightMax:
+		return "light"
+	case a.Weight <= fabricMediumWeightMax:
+		return "medium"
+	default:
+		return "heavy"
+	}
+}
+
+// Keys 重みの参照に使う「属性:値」のキーの一覧（未設定の属性は含めない）
+func (a FabricAttributes) Keys() []string {
+	keys := make([]string, 0, 5)
+	if a.ColorFamily != "" {
+		keys = append(keys, "color_family:"+string(a.ColorFamily))
+	}
+	if a.Pattern != "" {
+		keys = append(keys, "pattern:"+string(a.Pattern))
+	}
+	if band := a.WeightBand(); band != "" {
+		keys = append(keys, "weight:"+band)
+	}
+	if a.Season != "" {
+		keys = append(keys, "season:"+string(a.Season))
+	}
+	if a.Formality != 0 {
+		keys = append(keys, "formality:"+strconv.Itoa(a.Formality))
+	}
+	return keys
+}
+
+// fabricAttributeKeyLabel 「属性:値」のキーの表示名（未定義のキーは ok = false）
+func fabricAttributeKeyLabel(key string) (attribute string, value string, ok bool) {
+	name, raw, found := strings.Cut(key, ":")
+	if !found {
+		return "", "", false
+	}
+	attribute, ok = fabricAttributeLabels[name]
+	if !ok {
+		return "", "", false
+	}
+	switch name {
+	case "color_family":
+		value, ok = fabricColorFamilyLabels[FabricColorFamily(raw)]
+	case "pattern":
+		value, ok = fabricPatternLabels[FabricPattern(raw)]
+	case "weight":
+		value, ok = fabricWeightLabels[raw]
+	case "season":
+		value, ok = fabricSeasonLabels[FabricSeason(raw)]
+	case "formality":
+		level, err := strconv.Atoi(raw)
+		ok = err == nil && level >= 1 && level <= 5
+		value = raw
+	}
+	return attribute, value, ok
+}
+
+// SeasonAt 日付のシーズン（3〜8月は春夏、9〜2月は秋冬）
+func SeasonAt(t time.Time) FabricSeason {
+	if month := t.Month(); month >= time.March && month <= time.August {
+		return FabricSeasonSpringSummer
+	}
+	return FabricSeasonAutumnWinter
+}
+
+// FabricRecommendationWeights 生地レコメンドの重み（テナントごとに調整可能）
+type FabricRecommendationWeights struct {
+	TenantID     string                           `json:"tenant_id"`
+	Archetypes   map[Archetype]map[string]float64 `json:"archetypes"`    // アーキタイプごとの「属性:値」の重み（未登録のアーキタイプは既定の重み）
+	PlanWeight   float64                          `json:"plan_weight"`   // プランに合う価格帯への加点（最大）
+	SeasonWeight float64                          `json:"season_weight"` // 今の季節に合う生地への加点
+	UpdatedAt    time.Time                        `json:"updated_at"`
+	UpdatedBy    string                           `json:"updated_by,omitempty"`
+}
+
+// 重みの上限（絶対値）
+const maxFabricRecommendationWeight = 10
+
+// DefaultFabricRecommendationWeights 既定の生地レコメンドの重み
+func DefaultFabricRecommendationWeights(tenantID string) *FabricRecommendationWeights {
+	return &FabricRecommendationWeights{
+		TenantID: tenantID,
+		Archetypes: map[Archetype]map[string]float64{
+			ArchetypeClassic: {
+				"color_family:navy": 3, "color_family:grey": 3, "color_family:brown": 1,
+				"pattern:stripe": 2, "pattern:solid": 1.5, "pattern:herringbone": 1.5,
+				"weight:medium": 1, "weight:heavy": 0.5,
+				"formality:5": 2, "formality:4": 1.5, "formality:3": 0.5,
+			},
+			ArchetypeModern: {
+				"color_family:black": 3, "color_family:grey": 2, "color_family:navy": 1.5,
+				"pattern:solid": 3, "pattern:texture": 1,
+				"weight:light": 1, "weight:medium": 0.5,
+				"formality:4": 1, "formality:3": 1,
+			},
+			ArchetypeElegant: {
+				"color_family:navy": 2, "color_family:black": 2, "color_family:blue": 1,
+				"pattern:texture": 3, "pattern:herringbone": 1, "pattern:solid": 1,
+				"weight:light": 0.5, "weight:medium": 1,
+				"formality:5": 2.5, "formality:4": 1.5,
+			},
+			ArchetypeSporty: {
+				"color_family:blue": 3, "color_family:grey": 1.5, "color_family:navy": 1,
+				"pattern:solid": 1, "pattern:check": 1,
+				"weight:light": 2, "weight:medium": 0.5,
+				"season:all_season": 1.5,
+				"formality:3":       1.5, "formality:2": 1,
+			},
+			ArchetypeCasual: {
+				"color_family:brown": 3, "color_family:beige": 3, "color_family:green": 2.5,
+				"pattern:check": 3, "pattern:herringbone": 1.5,
+				"weight:heavy": 1, "weight:medium": 0.5,
+				"formality:1": 2, "formality:2": 2, "formality:3": 0.5,
+			},
+		},
+		PlanWeight:   2,
+		SeasonWeight: 1.5,
+	}
+}
+
+// Validate 重みをチェック（アーキタイプ・「属性:値」のキー・重みの範囲）
+func (w *FabricRecommendationWeights) Validate() error {
+	for archetype, weights := range w.Archetypes {
+		if !archetype.IsValid() {
+			return fmt.Errorf("invalid archetype: %s", archetype)
+		}
+		for key, weight := range weights {
+			if _, _, ok := fabricAttributeKeyLabel(key); !ok {
+				return fmt.Errorf("invalid attribute key for %s: %s", archetype, key)
+			}
+			if math.IsNaN(weight) || math.Abs(weight) > maxFabricRecommendationWeight {
+				return fmt.Errorf("invalid weight for %s %s: must be between -%d and %d", archetype, key, maxFabricRecommendationWeight, maxFabricRecommendationWeight)
+			}
+		}
+	}
+	if w.PlanWeight < 0 || w.PlanWeight > maxFabricRecommendationWeight {
+		return fmt.Errorf("invalid plan_weight: must be between 0 and %d", maxFabricRecommendationWeight)
+	}
+	if w.SeasonWeight < 0 || w.SeasonWeight > maxFabricRecommendationWeight {
+		return fmt.Errorf("invalid season_weight: must be between 0 and %d", maxFabricRecommendationWeight)
+	}
+	return nil
+}
+
+// For アーキタイプの属性の重み（テナントで未登録の場合は既定の重み）
+func (w *FabricRecommendationWeights) For(archetype Archetype) map[string]float64 {
+	if weights, ok := w.Archetypes[archetype]; ok {
+		return weights
+	}
+	return DefaultFabricRecommendationWeights(w.TenantID).Archetypes[archetype]
+}
+
+// FabricRecommendationCriteria 生地レコメンドの条件
+type FabricRecommendationCriteria struct {
+	Archetype Archetype
+	PlanType  PlanType
+	Budget    int64        // スーツ1着分の生地代の上限（円、0は上限なし）
+	Season    FabricSeason // 提案する季節
+	Limit     int
+}
+
+// FabricRecommendation おすすめの生地
+type FabricRecommendation struct {
+	Fabric         *Fabric  `json:"fabric"`
+	Score          float64  `json:"score"`
+	SuitFabricCost int64    `json:"suit_fabric_cost"` // スーツ1着分（最小発注数量）の生地代（円）
+	Reasons        []string `json:"reasons"`
+}
+
+// fabricReason 加点の理由と加点
+type fabricReason struct {
+	text  string
+	score float64
+}
+
+// SuitFabricCost スーツ1着分（最小発注数量）の生地代（円）
+func (f *Fabric) SuitFabricCost() int64 {
+	return int64(math.Round(float64(f.Price) * f.MinimumOrder))
+}
+
+// RecommendFabrics 在庫のある生地（StockStatusAvailable）を予算で絞り込み、
+// アーキタイプの属性の重み・プランに合う価格帯・季節で採点しておすすめ順に並べる
+func RecommendFabrics(fabrics []*Fabric, weights *FabricRecommendationWeights, criteria FabricRecommendationCriteria) []*FabricRecommendation {
+	candidates := make([]*Fabric, 0, len(fabrics))
+	var minPrice, maxPrice int64
+	for _, fabric := range fabrics {
+		if fabric.StockStatus != StockStatusAvailable {
+			continue
+		}
+		if criteria.Budget > 0 && fabric.SuitFabricCost() > criteria.Budget {
+			continue
+		}
+		if len(candidates) == 0 || fabric.Price < minPrice {
+			minPrice = fabric.Price
+		}
+		if len(candidates) == 0 || fabric.Price > maxPrice {
+			maxPrice = fabric.Price
+		}
+		candidates = append(candidates, fabric)
+	}
+
+	archetypeWeights := weights.For(criteria.Archetype)
+	recommendations := make([]*FabricRecommendation, 0, len(candidates))
+	for _, fabric := range candidates {
+		reasons := make([]fabricReason, 0, 7)
+		score := 0.0
+		for _, key := range fabric.Attributes.Keys() {
+			weight := archetypeWeights[key]
+			score += weight
+			if weight > 0 {
+				attribute, value, _ := fabricAttributeKeyLabel(key)
+				reasons = append(reasons, fabricReason{
+					text:  fmt.Sprintf("%sに合う%s（%s）", archetypeLabels[criteria.Archetype], attribute, value),
+					score: weight,
+				})
+			}
+		}
+
+		// 価格帯: 候補の中での価格の位置（0: 最安 〜 1: 最高）
+		position := 0.5
+		if maxPrice > minPrice {
+			position = float64(fabric.Price-minPrice) / float64(maxPrice-minPrice)
+		}
+		planScore := weights.PlanWeight * (1 - position)
+		planReason := "ベストバリュープラン向けの手頃な価格帯"
+		if criteria.PlanType == PlanTypeAuthentic {
+			planScore = weights.PlanWeight * position
+			planReason = "オーセンティックプラン向けの上質な価格帯"
+		}
+		score += planScore
+		if maxPrice > minPrice && planScore >= weights.PlanWeight/2 && planScore > 0 {
+			reasons = append(reasons, fabricReason{text: planReason, score: planScore})
+		}
+
+		if criteria.Season != "" && (fabric.Attributes.Season == criteria.Season || fabric.Attributes.Season == FabricSeasonAll) {
+			score += weights.SeasonWeight
+			if weights.SeasonWeight > 0 {
+				reasons = append(reasons, fabricReason{
+					text:  fmt.Sprintf("今の季節（%s）に合う生地", fabricSeasonLabels[criteria.Season]),
+					score: weights.SeasonWeight,
+				})
+			}
+		}
+
+		if criteria.Budget > 0 {
+			reasons = append(reasons, fabricReason{text: fmt.Sprintf("予算内（スーツ1着分の生地代 %d円）", fabric.SuitFabricCost())})
+		}
+
+		// 理由は加点の大きい順
+		sort.SliceStable(reasons, func(i, j int) bool { return reasons[i].score > reasons[j].score })
+		texts := make([]string, 0, len(reasons))
+		for _, reason := range reasons {
+			texts = append(texts, reason.text)
+		}
+
+		recommendations = append(recommendations, &FabricRecommendation{
+			Fabric:         fabric,
+			Score:          math.Round(score*100) / 100,
+			SuitFabricCost: fabric.SuitFabricCost(),
+			Reasons:        texts,
+		})
+	}
+
+	// 得点の高い順（同点はプランに合う価格順、さらに生地名順）
+	sort.SliceStable(recommendations, func(i, j int) bool {
+		a, b := recommendations[i], recommendations[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.Fabric.Price != b.Fabric.Price {
+			if criteria.PlanType == PlanTypeAuthentic {
+				return a.Fabric.Price > b.Fabric.Price
+			}
+			return a.Fabric.Price < b.Fabric.Price
+		}
+		return a.Fabric.Name < b.Fabric.Name
+	})
+
+	if criteria.Limit > 0 && len(recommendations) > criteria.Limit {
+		recommendations = recommendations[:criteria.Limit]
+	}
+	return recommendations
+}
@@ -22,6 +22,7 @@ type FabricRoll struct {
 	InitialLength float64          `json:"initial_length"` // 初期長さ（メートル）
 	CurrentLength float64          `json:"current_length"` // 現在の残り長さ（メートル）
 	Width         *float64         `json:"width,omitempty"` // 幅（センチメートル、オプション）
+	LengthUnit    FabricUnit       `json:"length_unit"`     // 初期長さ・現在の長さの単位（保存はメートル）
 	SupplierLotNo *string          `json:"supplier_lot_no,omitempty"` // 仕入先ロット番号
 	ReceivedAt    *time.Time       `json:"received_at,omitempty"`     // 入荷日
 	Location      *string          `json:"location,omitempty"`        // 保管場所
@@ -0,0 +1,274 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+// FitCorrectionType 体型補正の種類
+type FitCorrectionType string
+
+const (
+	FitCorrectionErect            FitCorrectionType = "ERECT"             // 反身
+	FitCorrectionStooped          FitCorrectionType = "STOOPED"           // 屈身
+	FitCorrectionSquareShoulders  FitCorrectionType = "SQUARE_SHOULDERS"  // いかり肩
+	FitCorrectionSlopingShoulders FitCorrectionType = "SLOPING_SHOULDERS" // なで肩
+	FitCorrectionProminentSeat    FitCorrectionType = "PROMINENT_SEAT"    // 出尻
+	FitCorrectionProminentBelly   FitCorrectionType = "PROMINENT_BELLY"   // 出腹
+)
+
+// OrderAdjustmentsVersion 補正情報（OrderDetails.Adjustments）のスキーマのバージョン
+const OrderAdjustmentsVersion = 1
+
+// orderAdjustmentMeasurementLimit 仕上がり寸法の個別の加減の上限（cm）
+const orderAdjustmentMeasurementLimit = 10.0
+
+// FitCorrectionEffect 体型補正が仕上がり寸法に与える影響（補正量1cmあたりの加減）
+type FitCorrectionEffect struct {
+	Key    string  `json:"key"`    // 仕上がり寸法の項目（FinalMeasurement のJSONキー・型紙の補正項目）
+	Factor float64 `json:"factor"` // 補正量1cmあたりの加減（cm）
+}
+
+// FitCorrectionDefinition 体型補正のカタログ項目
+type FitCorrectionDefinition struct {
+	Type          FitCorrectionType     `json:"type"`
+	Label         string                `json:"label"`
+	Description   string                `json:"description"`
+	MinAmount     float64               `json:"min_amount"`     // 補正量の下限（cm）
+	MaxAmount     float64               `json:"max_amount"`     // 補正量の上限（cm）
+	DefaultAmount float64               `json:"default_amount"` // 補正量の既定値（cm）
+	Effects       []FitCorrectionEffect `json:"effects"`
+	ConflictsWith []FitCorrectionType   `json:"conflicts_with,omitempty"` // 同時に選べない補正
+}
+
+// FitCorrectionCatalog 体型補正のカタログ（表示順）
+var FitCorrectionCatalog = []*FitCorrectionDefinition{
+	{
+		Type:          FitCorrectionErect,
+		Label:         "反身",
+		Description:   "胸を反らせた姿勢。前丈を伸ばして後丈を詰め、身幅にゆとりを加える",
+		MinAmount:     0.5,
+		MaxAmount:     3.0,
+		DefaultAmount: 1.0,
+		Effects: []FitCorrectionEffect{
+			{Key: "front_balance", Factor: 1.0},
+			{Key: "back_balance", Factor: -1.0},
+			{Key: "half_chest", Factor: 0.5},
+		},
+		ConflictsWith: []FitCorrectionType{FitCorrectionStooped},
+	},
+	{
+		Type:          FitCorrectionStooped,
+		Label:         "屈身",
+		Description:   "背中が丸く前かがみの姿勢。後丈を伸ばして前丈を詰める",
+		MinAmount:     0.5,
+		MaxAmount:     3.0,
+		DefaultAmount: 1.0,
+		Effects: []FitCorrectionEffect{
+			{Key: "back_balance", Factor: 1.0},
+			{Key: "front_balance", Factor: -1.0},
+		},
+		ConflictsWith: []FitCorrectionType{FitCorrectionErect},
+	},
+	{
+		Type:          FitCorrectionSquareShoulders,
+		Label:         "いかり肩",
+		Description:   "肩の傾斜が小さい体型。肩先を上げる",
+		MinAmount:     0.5,
+		MaxAmount:     2.5,
+		DefaultAmount: 1.0,
+		Effects: []FitCorrectionEffect{
+			{Key: "shoulder_slope", Factor: 1.0},
+		},
+		ConflictsWith: []FitCorrectionType{FitCorrectionSlopingShoulders},
+	},
+	{
+		Type:          FitCorrectionSlopingShoulders,
+		Label:         "なで肩",
+		Description:   "肩の傾斜が大きい体型。肩先を下げる",
+		MinAmount:     0.5,
+		MaxAmount:     2.5,
+		DefaultAmount: 1.0,
+		Effects: []FitCorrectionEffect{
+			{Key: "shoulder_slope", Factor: -1.0},
+		},
+		ConflictsWith: []FitCorrectionType{FitCorrectionSquareShoulders},
+	},
+	{
+		Type:          FitCorrectionProminentSeat,
+		Label:         "出尻",
+		Description:   "尻の張りが大きい体型。後パンツの股ぐりを伸ばして後中心を上げ、ヒップにゆとりを加える",
+		MinAmount:     0.5,
+		MaxAmount:     3.0,
+		DefaultAmount: 1.5,
+		Effects: []FitCorrectionEffect{
+			{Key: "seat", Factor: 1.0},
+			{Key: "hip", Factor: 0.5},
+		},
+	},
+	{
+		Type:          FitCorrectionProminentBelly,
+		Label:         "出腹",
+		Description:   "腹部が前に出た体型。前パンツのウエストを上げ、上着の前丈を伸ばす",
+		MinAmount:     1.0,
+		MaxAmount:     5.0,
+		DefaultAmount: 2.0,
+		Effects: []FitCorrectionEffect{
+			{Key: "belly", Factor: 1.0},
+			{Key: "front_balance", Factor: 0.5},
+		},
+	},
+}
+
+// FitCorrectionDefinitionFor 体型補正のカタログ項目（未定義の場合はnil）
+func FitCorrectionDefinitionFor(correctionType FitCorrectionType) *FitCorrectionDefinition {
+	for _, definition := range FitCorrectionCatalog {
+		if definition.Type == correctionType {
+			return definition
+		}
+	}
+	return nil
+}
+
+// FitCorrection 注文で選択した体型補正
+type FitCorrection struct {
+	Type   FitCorrectionType `json:"type"`
+	Amount float64           `json:"amount"` // 補正量（cm、未指定の場合はカタログの既定値）
+	Notes  string            `json:"notes,omitempty"`
+}
+
+// OrderAdjustments 注文の補正情報（OrderDetails.Adjustments のスキーマ）
+type OrderAdjustments struct {
+	Version        int                `json:"version"`
+	FitCorrections []FitCorrection    `json:"fit_corrections"`
+	Measurements   map[string]float64 `json:"measurements,omitempty"` // 仕上がり寸法の個別の加減（cm）
+	Notes          string             `json:"notes,omitempty"`
+}
+
+// ParseOrderAdjustments 補正情報をスキーマに沿ってパースし、バリデーション
+// 未定義のフィールドはエラーとする（空の場合は補正なし）
+func ParseOrderAdjustments(raw json.RawMessage) (*OrderAdjustments, error) {
+	adjustments := &OrderAdjustments{}
+	if trimmed := bytes.TrimSpace(raw); len(trimmed) > 0 && !bytes.Equal(trimmed, []byte("null")) {
+		decoder := json.NewDecoder(bytes.NewReader(trimmed))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(adjustments); err != nil {
+			return nil, fmt.Errorf("invalid adjustments: %w", err)
+		}
+	}
+	if err := adjustments.Validate(); err != nil {
+		return nil, err
+	}
+	return adjustments, nil
+}
+
+// ReadOrderAdjustments 保存済みの補正情報を読み込む
+// スキーマ導入前の自由形式の補正情報（versionのないJSON）は、内容をメモ（notes）に移したv1として扱う
+func ReadOrderAdjustments(raw json.RawMessage) (*OrderAdjustments, error) {
+	adjustments, err := ParseOrderAdjustments(raw)
+	if err == nil {
+		return adjustments, nil
+	}
+
+	var legacy interface{}
+	if json.Unmarshal(raw, &legacy) != nil {
+		return nil, err
+	}
+	if fields, ok := legacy.(map[string]interface{}); ok {
+		if _, versioned := fields["version"]; versioned {
+			return nil, err
+		}
+	}
+
+	notes, ok := legacy.(string)
+	if !ok {
+		var compact bytes.Buffer
+		if json.Compact(&compact, raw) != nil {
+			return nil, err
+		}
+		notes = compact.String()
+	}
+	return &OrderAdjustments{
+		Version:        OrderAdjustmentsVersion,
+		FitCorrections: []FitCorrection{},
+		Notes:          notes,
+	}, nil
+}
+
+// Validate 補正情報をバリデーション（補正量の既定値・スキーマのバージョンを設定）
+func (a *OrderAdjustments) Validate() error {
+	if a.Version == 0 {
+		a.Version = OrderAdjustmentsVersion
+	}
+	if a.Version != OrderAdjustmentsVersion {
+		return fmt.Errorf("invalid adjustments: unsupported version %d", a.Version)
+	}
+	if a.FitCorrections == nil {
+		a.FitCorrections = []FitCorrection{}
+	}
+
+	selected := make(map[FitCorrectionType]bool, len(a.FitCorrections))
+	for i := range a.FitCorrections {
+		correction := &a.FitCorrections[i]
+		definition := FitCorrectionDefinitionFor(correction.Type)
+		if definition == nil {
+			return fmt.Errorf("invalid adjustments: unknown fit correction type %s", correction.Type)
+		}
+		if selected[correction.Type] {
+			return fmt.Errorf("invalid adjustments: duplicate fit correction %s", correction.Type)
+		}
+		selected[correction.Type] = true
+
+		if correction.Amount == 0 {
+			correction.Amount = definition.DefaultAmount
+		}
+		if correction.Amount < definition.MinAmount || correction.Amount > definition.MaxAmount {
+			return fmt.Errorf("invalid adjustments: %s amount must be between %.1f and %.1f cm", correction.Type, definition.MinAmount, definition.MaxAmount)
+		}
+	}
+	for _, correction := range a.FitCorrections {
+		for _, conflict := range FitCorrectionDefinitionFor(correction.Type).ConflictsWith {
+			if selected[conflict] {
+				return fmt.Errorf("invalid adjustments: %s cannot be combined with %s", correction.Type, conflict)
+			}
+		}
+	}
+
+	for key, amount := range a.Measurements {
+		if !IsPatternMeasurement(key) {
+			return fmt.Errorf("invalid adjustments: unknown measurement %s", key)
+		}
+		if math.Abs(amount) > orderAdjustmentMeasurementLimit {
+			return fmt.Errorf("invalid adjustments: %s adjustment must be within ±%.0f cm", key, orderAdjustmentMeasurementLimit)
+		}
+	}
+	return nil
+}
+
+// MeasurementDeltas 体型補正と個別の加減を合計した仕上がり寸法の加減（cm、0.1cm単位）
+func (a *OrderAdjustments) MeasurementDeltas() map[string]float64 {
+	deltas := make(map[string]float64)
+	for _, correction := range a.FitCorrections {
+		definition := FitCorrectionDefinitionFor(correction.Type)
+		if definition == nil {
+			continue
+		}
+		for _, effect := range definition.Effects {
+			deltas[effect.Key] += effect.Factor * correction.Amount
+		}
+	}
+	for key, amount := range a.Measurements {
+		deltas[key] += amount
+	}
+	for key, delta := range deltas {
+		delta = math.Round(delta*10) / 10
+		if delta == 0 {
+			delete(deltas, key)
+			continue
+		}
+		deltas[key] = delta
+	}
+	return deltas
+}
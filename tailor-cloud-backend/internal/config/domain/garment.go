@@ -0,0 +1,90 @@
+package domain
+
+import "strings"
+
+// GarmentType 品目（仕立てる衣服の種類）
+type GarmentType string
+
+const (
+	GarmentTypeSuit     GarmentType = "SUIT"     // スーツ（ジャケット＋パンツ）
+	GarmentTypeJacket   GarmentType = "JACKET"   // ジャケット
+	GarmentTypeTrousers GarmentType = "TROUSERS" // パンツ（スラックス）
+	GarmentTypeVest     GarmentType = "VEST"     // ベスト
+	GarmentTypeCoat     GarmentType = "COAT"     // コート
+	GarmentTypeShirt    GarmentType = "SHIRT"    // シャツ
+)
+
+// IsValid 品目が有効かチェック
+func (g GarmentType) IsValid() bool {
+	switch g {
+	case GarmentTypeSuit, GarmentTypeJacket, GarmentTypeTrousers, GarmentTypeVest, GarmentTypeCoat, GarmentTypeShirt:
+		return true
+	default:
+		return false
+	}
+}
+
+// Label 品目の日本語名（請求書・帳票の表示用）
+func (g GarmentType) Label() string {
+	switch g {
+	case GarmentTypeSuit:
+		return "スーツ"
+	case GarmentTypeJacket:
+		return "ジャケット"
+	case GarmentTypeTrousers:
+		return "パンツ"
+	case GarmentTypeVest:
+		return "ベスト"
+	case GarmentTypeCoat:
+		return "コート"
+	case GarmentTypeShirt:
+		return "シャツ"
+	default:
+		return string(g)
+	}
+}
+
+// garmentTypeLabels 品目の表記ゆれ（取込ファイル・画面入力用）
+var garmentTypeLabels = map[string]GarmentType{
+	"スーツ":    GarmentTypeSuit,
+	"ジャケット":  GarmentTypeJacket,
+	"上着":     GarmentTypeJacket,
+	"パンツ":    GarmentTypeTrousers,
+	"スラックス":  GarmentTypeTrousers,
+	"トラウザーズ": GarmentTypeTrousers,
+	"ズボン":    GarmentTypeTrousers,
+	"ベスト":    GarmentTypeVest,
+	"ジレ":     GarmentTypeVest,
+	"コート":    GarmentTypeCoat,
+	"シャツ":    GarmentTypeShirt,
+}
+
+// ParseGarmentType 品目の表記（英語コードまたは日本語名）から品目を判定
+func ParseGarmentType(s string) (GarmentType, bool) {
+	s = strings.TrimSpace(s)
+	if g := GarmentType(strings.ToUpper(s)); g.IsValid() {
+		return g, true
+	}
+	if g, ok := garmentTypeLabels[s]; ok {
+		return g, true
+	}
+	return "", false
+}
+
+// StandardFabricLength 品目ごとの標準用尺（メートル、シングル幅の目安）
+func (g GarmentType) StandardFabricLength() float64 {
+	switch g {
+	case GarmentTypeJacket:
+		return 2.0
+	case GarmentTypeTrousers:
+		return 1.3
+	case GarmentTypeVest:
+		return 0.8
+	case GarmentTypeCoat:
+		return 3.0
+	case GarmentTypeShirt:
+		return 2.2
+	default:
+		return DefaultFabricLength
+	}
+}
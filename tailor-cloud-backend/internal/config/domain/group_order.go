@@ -0,0 +1,155 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// GroupOrderStatus 団体注文ステータス
+type GroupOrderStatus string
+
+const (
+	GroupOrderStatusOpen   GroupOrderStatus = "OPEN"   // 受付中（着用者の追加が可能）
+	GroupOrderStatusClosed GroupOrderStatus = "CLOSED" // 締切（着用者の追加不可）
+)
+
+// IsValid 団体注文ステータスが有効かチェック
+func (s GroupOrderStatus) IsValid() bool {
+	return s == GroupOrderStatusOpen || s == GroupOrderStatusClosed
+}
+
+// GroupOrderPrice 団体注文の価格取り決め（品目ごとの税抜単価）
+type GroupOrderPrice struct {
+	GarmentType  GarmentType `json:"garment_type"`
+	UnitPrice    int64       `json:"unit_price"`    // 税抜単価（円）
+	FabricLength float64     `json:"fabric_length"` // 1着あたりの用尺（メートル、0の場合は品目の標準用尺）
+}
+
+// RequiredFabricLength 1着あたりの用尺（メートル）
+func (p *GroupOrderPrice) RequiredFabricLength() float64 {
+	if p.FabricLength > 0 {
+		return p.FabricLength
+	}
+	return p.GarmentType.StandardFabricLength()
+}
+
+// GroupOrder 団体注文（法人・団体のユニフォーム契約等）
+// 支払者は1つの法人顧客、着用者ごとの注文（採寸データは着用者ごと）を束ね、
+// 生地と価格の取り決めを共有する。請求は法人顧客宛てにまとめて行う
+type GroupOrder struct {
+	ID                  string             `json:"id" db:"id"`
+	TenantID            string             `json:"tenant_id" db:"tenant_id"`
+	Name                string             `json:"name" db:"name"`                                   // 団体注文名（例: "○○株式会社 2026年度制服"）
+	CorporateCustomerID string             `json:"corporate_customer_id" db:"corporate_customer_id"` // 支払者（法人顧客）
+	FabricID            string             `json:"fabric_id" db:"fabric_id"`                         // 共通の生地
+	Prices              []*GroupOrderPrice `json:"prices" db:"prices"`                               // 品目ごとの価格取り決め
+	DeliveryDate        time.Time          `json:"delivery_date" db:"delivery_date"`                 // 共通の納期
+	Status              GroupOrderStatus   `json:"status" db:"status"`
+	Notes               string             `json:"notes" db:"notes"`
+	CreatedAt           time.Time          `json:"created_at" db:"created_at"`
+	UpdatedAt           time.Time          `json:"updated_at" db:"updated_at"`
+	CreatedBy           string             `json:"created_by" db:"created_by"`
+}
+
+// NewGroupOrder 新しい団体注文を作成（受付中）
+func NewGroupOrder(tenantID, name, corporateCustomerID, fabricID string, prices []*GroupOrderPrice, deliveryDate time.Time, createdBy string) *GroupOrder {
+	now := time.Now()
+	return &GroupOrder{
+		ID:                  uuid.New().String(),
+		TenantID:            tenantID,
+		Name:                name,
+		CorporateCustomerID: corporateCustomerID,
+		FabricID:            fabricID,
+		Prices:              prices,
+		DeliveryDate:        deliveryDate,
+		Status:              GroupOrderStatusOpen,
+		CreatedAt:           now,
+		UpdatedAt:           now,
+		CreatedBy:           createdBy,
+	}
+}
+
+// PriceFor 品目の価格取り決めを取得
+func (g *GroupOrder) PriceFor(garmentType GarmentType) (*GroupOrderPrice, error) {
+	for _, price := range g.Prices {
+		if price.GarmentType == garmentType {
+			return price, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid garment_type: %s is not in the price agreement", garmentType)
+}
+
+// ValidateGroupOrderPrices 価格取り決めを検証（品目の重複・単価・用尺）
+func ValidateGroupOrderPrices(prices []*GroupOrderPrice) error {
+	if len(prices) == 0 {
+		return fmt.Errorf("prices is required")
+	}
+	seen := make(map[GarmentType]bool, len(prices))
+	for _, price := range prices {
+		if !price.GarmentType.IsValid() {
+			return fmt.Errorf("invalid garment_type: %s", price.GarmentType)
+		}
+		if seen[price.GarmentType] {
+			return fmt.Errorf("invalid prices: duplicate garment_type %s", price.GarmentType)
+		}
+		seen[price.GarmentType] = true
+		if price.UnitPrice <= 0 {
+			return fmt.Errorf("invalid prices: unit_price for %s must be greater than 0", price.GarmentType)
+		}
+		if price.FabricLength < 0 {
+			return fmt.Errorf("invalid prices: fabric_length for %s must not be negative", price.GarmentType)
+		}
+	}
+	return nil
+}
+
+// GroupOrderMember 団体注文の着用者ごとの注文（進捗表示用）
+type GroupOrderMember struct {
+	OrderID      string      `json:"order_id"`
+	CustomerID   string      `json:"customer_id"`
+	CustomerName string      `json:"customer_name"`
+	GarmentType  GarmentType `json:"garment_type"`
+	Status       OrderStatus `json:"status"`
+	TotalAmount  int64       `json:"total_amount"`
+	DeliveryDate time.Time   `json:"delivery_date"`
+}
+
+// GroupOrderProgress 団体注文の進捗（ステータスごとの着数）
+type GroupOrderProgress struct {
+	GroupOrderID      string              `json:"group_order_id"`
+	TotalGarments     int                 `json:"total_garments"`     // キャンセルを含む全着数
+	ActiveGarments    int                 `json:"active_garments"`    // キャンセルを除く着数
+	CompletedGarments int                 `json:"completed_garments"` // 納品完了・支払い完了の着数
+	CompletionPercent int                 `json:"completion_percent"` // 完了率（%、キャンセルを除く着数に対する割合）
+	ByStatus          map[OrderStatus]int `json:"by_status"`          // ステータスごとの着数
+	ByGarmentType     map[GarmentType]int `json:"by_garment_type"`    // 品目ごとの着数（キャンセルを除く）
+	Members           []*GroupOrderMember `json:"members"`
+}
+
+// SummarizeGroupOrderProgress 着用者ごとの注文から団体注文の進捗を集計
+func SummarizeGroupOrderProgress(groupOrderID string, members []*GroupOrderMember) *GroupOrderProgress {
+	progress := &GroupOrderProgress{
+		GroupOrderID:  groupOrderID,
+		ByStatus:      make(map[OrderStatus]int),
+		ByGarmentType: make(map[GarmentType]int),
+		Members:       members,
+	}
+	for _, member := range members {
+		progress.TotalGarments++
+		progress.ByStatus[member.Status]++
+		if member.Status == OrderStatusCancelled {
+			continue
+		}
+		progress.ActiveGarments++
+		progress.ByGarmentType[member.GarmentType]++
+		if member.Status == OrderStatusDelivered || member.Status == OrderStatusPaid {
+			progress.CompletedGarments++
+		}
+	}
+	if progress.ActiveGarments > 0 {
+		progress.CompletionPercent = progress.CompletedGarments * 100 / progress.ActiveGarments
+	}
+	return progress
+}
@@ -0,0 +1,431 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// InspectionItemType 検品項目の種別
+type InspectionItemType string
+
+const (
+	InspectionItemTypeMeasurement InspectionItemType = "MEASUREMENT" // 寸法（仕上がり寸法との差を許容差で判定）
+	InspectionItemTypeStitching   InspectionItemType = "STITCHING"   // 縫製（縫い目・まつり・ボタン付け等）
+	InspectionItemTypePressing    InspectionItemType = "PRESSING"    // プレス・仕上げ
+	InspectionItemTypeVisual      InspectionItemType = "VISUAL"      // 外観（汚れ・傷・柄合わせ等）
+)
+
+// IsValid 検品項目の種別が有効かチェック
+func (t InspectionItemType) IsValid() bool {
+	switch t {
+	case InspectionItemTypeMeasurement, InspectionItemTypeStitching, InspectionItemTypePressing, InspectionItemTypeVisual:
+		return true
+	default:
+		return false
+	}
+}
+
+// DefaultProcess 不合格時に責任工程とする既定の製造工程
+// 寸法は裁断、それ以外は縫製（プレス・仕上げを含む）
+func (t InspectionItemType) DefaultProcess() ProductionProcess {
+	if t == InspectionItemTypeMeasurement {
+		return ProductionProcessCutting
+	}
+	return ProductionProcessSewing
+}
+
+// InspectionChecklistItem 検品チェックリストの項目
+type InspectionChecklistItem struct {
+	Code             string             `json:"code"`                        // 項目コード（チェックリスト内で一意）
+	Label            string             `json:"label"`                       // 表示名
+	Type             InspectionItemType `json:"type"`                        // 種別
+	MeasurementField string             `json:"measurement_field,omitempty"` // MEASUREMENT: 仕上がり寸法の項目（例: "jacket_length"）
+	Tolerance        float64            `json:"tolerance,omitempty"`         // MEASUREMENT: 許容差（±cm）
+	Process          ProductionProcess  `json:"process,omitempty"`           // 不合格時の責任工程（未設定は種別の既定）
+}
+
+// ResponsibleProcess 不合格時の責任工程
+func (i *InspectionChecklistItem) ResponsibleProcess() ProductionProcess {
+	if i.Process != "" {
+		return i.Process
+	}
+	return i.Type.DefaultProcess()
+}
+
+// InspectionChecklist 品目ごとの検品チェックリスト（テナントごと）
+type InspectionChecklist struct {
+	TenantID    string                     `json:"tenant_id" db:"tenant_id"`
+	GarmentType GarmentType                `json:"garment_type" db:"garment_type"`
+	Items       []*InspectionChecklistItem `json:"items" db:"items"`
+	IsDefault   bool                       `json:"is_default"` // 未設定のため既定のチェックリストを返した場合
+	UpdatedAt   time.Time                  `json:"updated_at" db:"updated_at"`
+	UpdatedBy   string                     `json:"updated_by" db:"updated_by"`
+}
+
+// Validate チェックリストを検証（項目コードの重複・種別・寸法項目の許容差）
+func (c *InspectionChecklist) Validate() error {
+	if !c.GarmentType.IsValid() {
+		return fmt.Errorf("invalid garment_type: %s", c.GarmentType)
+	}
+	if len(c.Items) == 0 {
+		return fmt.Errorf("items is required")
+	}
+	seen := make(map[string]bool, len(c.Items))
+	for _, item := range c.Items {
+		if item.Code == "" {
+			return fmt.Errorf("invalid items: code is required")
+		}
+		if seen[item.Code] {
+			return fmt.Errorf("invalid items: duplicate code %s", item.Code)
+		}
+		seen[item.Code] = true
+		if !item.Type.IsValid() {
+			return fmt.Errorf("invalid items: unknown type %s for %s", item.Type, item.Code)
+		}
+		if item.Process != "" && !item.Process.IsValid() {
+			return fmt.Errorf("invalid items: unknown process %s for %s", item.Process, item.Code)
+		}
+		if item.Type == InspectionItemTypeMeasurement {
+			if item.MeasurementField == "" {
+				return fmt.Errorf("invalid items: measurement_field is required for %s", item.Code)
+			}
+			if item.Tolerance <= 0 {
+				return fmt.Errorf("invalid items: tolerance for %s must be greater than 0", item.Code)
+			}
+		}
+	}
+	return nil
+}
+
+// 既定のチェックリストの共通項目（縫製・プレス・外観）
+var defaultWorkmanshipItems = []*InspectionChecklistItem{
+	{Code: "stitching_seams", Label: "縫い目（目飛び・パッカリング）", Type: InspectionItemTypeStitching},
+	{Code: "stitching_buttons", Label: "ボタン付け・ボタンホール", Type: InspectionItemTypeStitching},
+	{Code: "pressing_finish", Label: "プレス・アイロン仕上げ", Type: InspectionItemTypePressing},
+	{Code: "visual_surface", Label: "汚れ・傷・柄合わせ", Type: InspectionItemTypeVisual},
+}
+
+// 品目ごとの既定の寸法項目（仕上がり寸法の項目と許容差）
+var defaultMeasurementItems = map[GarmentType][]*InspectionChecklistItem{
+	GarmentTypeSuit: {
+		{Code: "jacket_length", Label: "着丈", Type: InspectionItemTypeMeasurement, MeasurementField: "jacket_length", Tolerance: 0.5},
+		{Code: "sleeve_length", Label: "袖丈", Type: InspectionItemTypeMeasurement, MeasurementField: "sleeve_length", Tolerance: 0.5},
+		{Code: "chest", Label: "胸囲", Type: InspectionItemTypeMeasurement, MeasurementField: "chest", Tolerance: 1.0},
+		{Code: "waist", Label: "ウエスト", Type: InspectionItemTypeMeasurement, MeasurementField: "waist", Tolerance: 1.0},
+		{Code: "hip", Label: "ヒップ", Type: InspectionItemTypeMeasurement, MeasurementField: "hip", Tolerance: 1.0},
+		{Code: "hem", Label: "裾幅", Type: InspectionItemTypeMeasurement, MeasurementField: "hem", Tolerance: 0.5},
+	},
+	GarmentTypeJacket: {
+		{Code: "jacket_length", Label: "着丈", Type: InspectionItemTypeMeasurement, MeasurementField: "jacket_length", Tolerance: 0.5},
+		{Code: "sleeve_length", Label: "袖丈", Type: InspectionItemTypeMeasurement, MeasurementField: "sleeve_length", Tolerance: 0.5},
+		{Code: "chest", Label: "胸囲", Type: InspectionItemTypeMeasurement, MeasurementField: "chest", Tolerance: 1.0},
+	},
+	GarmentTypeTrousers: {
+		{Code: "waist", Label: "ウエスト", Type: InspectionItemTypeMeasurement, MeasurementField: "waist", Tolerance: 1.0},
+		{Code: "hip", Label: "ヒップ", Type: InspectionItemTypeMeasurement, MeasurementField: "hip", Tolerance: 1.0},
+		{Code: "thigh", Label: "わたり", Type: InspectionItemTypeMeasurement, MeasurementField: "thigh", Tolerance: 0.5},
+		{Code: "hem", Label: "裾幅", Type: InspectionItemTypeMeasurement, MeasurementField: "hem", Tolerance: 0.5},
+	},
+	GarmentTypeVest: {
+		{Code: "chest", Label: "胸囲", Type: InspectionItemTypeMeasurement, MeasurementField: "chest", Tolerance: 1.0},
+		{Code: "waist", Label: "ウエスト", Type: InspectionItemTypeMeasurement, MeasurementField: "waist", Tolerance: 1.0},
+	},
+	GarmentTypeCoat: {
+		{Code: "jacket_length", Label: "着丈", Type: InspectionItemTypeMeasurement, MeasurementField: "jacket_length", Tolerance: 1.0},
+		{Code: "sleeve_length", Label: "袖丈", Type: InspectionItemTypeMeasurement, MeasurementField: "sleeve_length", Tolerance: 0.5},
+		{Code: "chest", Label: "胸囲", Type: InspectionItemTypeMeasurement, MeasurementField: "chest", Tolerance: 1.0},
+	},
+	GarmentTypeShirt: {
+		{Code: "jacket_length", Label: "着丈", Type: InspectionItemTypeMeasurement, MeasurementField: "jacket_length", Tolerance: 1.0},
+		{Code: "sleeve_length", Label: "袖丈", Type: InspectionItemTypeMeasurement, MeasurementField: "sleeve_length", Tolerance: 0.5},
+		{Code: "chest", Label: "胸囲", Type: InspectionItemTypeMeasurement, MeasurementField: "chest", Tolerance: 1.0},
+	},
+}
+
+// DefaultInspectionChecklist 品目の既定の検品チェックリスト（寸法項目 + 縫製・プレス・外観）
+func DefaultInspectionChecklist(tenantID string, garmentType GarmentType) *InspectionChecklist {
+	items := make([]*InspectionChecklistItem, 0, len(defaultMeasurementItems[garmentType])+len(defaultWorkmanshipItems))
+	for _, item := range defaultMeasurementItems[garmentType] {
+		copied := *item
+		items = append(items, &copied)
+	}
+	for _, item := range defaultWorkmanshipItems {
+		copied := *item
+		items = append(items, &copied)
+	}
+	return &InspectionChecklist{
+		TenantID:    tenantID,
+		GarmentType: garmentType,
+		Items:       items,
+		IsDefault:   true,
+	}
+}
+
+// InspectionItemInput 検品項目ごとの入力
+type InspectionItemInput struct {
+	Code     string   `json:"code"`
+	Measured *float64 `json:"measured,omitempty"` // MEASUREMENT: 実測値（cm）
+	Passed   *bool    `json:"passed,omitempty"`   // 寸法以外（寸法で仕上がり寸法がない場合を含む）: 合否
+	Note     string   `json:"note,omitempty"`
+}
+
+// InspectionItemResult 検品項目ごとの結果
+type InspectionItemResult struct {
+	Code                string             `json:"code"`
+	Label               string             `json:"label"`
+	Type                InspectionItemType `json:"type"`
+	Process             ProductionProcess  `json:"process"` // 責任工程
+	Passed              bool               `json:"passed"`
+	Expected            *float64           `json:"expected,omitempty"`  // 仕上がり寸法（cm）
+	Measured            *float64           `json:"measured,omitempty"`  // 実測値（cm）
+	Deviation           *float64           `json:"deviation,omitempty"` // 実測値 - 仕上がり寸法（cm）
+	Tolerance           float64            `json:"tolerance,omitempty"`
+	ResponsibleWorkerID string             `json:"responsible_worker_id,omitempty"` // 責任工程の作業者（不合格の場合）
+	Note                string             `json:"note,omitempty"`
+}
+
+// InspectionPhoto 検品写真
+type InspectionPhoto struct {
+	ItemCode   string    `json:"item_code,omitempty"` // 対象の検品項目（空の場合は全体）
+	URL        string    `json:"url"`
+	UploadedAt time.Time `json:"uploaded_at"`
+	UploadedBy string    `json:"uploaded_by"`
+}
+
+// Inspection 検品記録
+type Inspection struct {
+	ID                 string                       `json:"id" db:"id"`
+	TenantID           string                       `json:"tenant_id" db:"tenant_id"`
+	OrderID            string                       `json:"order_id" db:"order_id"`
+	GarmentType        GarmentType                  `json:"garment_type" db:"garment_type"`
+	InspectorID        string                       `json:"inspector_id" db:"inspector_id"`
+	Passed             bool                         `json:"passed" db:"passed"`
+	DefectCount        int                          `json:"defect_count" db:"defect_count"` // 不合格の項目数
+	Results            []*InspectionItemResult      `json:"results" db:"results"`
+	Photos             []*InspectionPhoto           `json:"photos" db:"photos"`
+	ResponsibleWorkers map[ProductionProcess]string `json:"responsible_workers" db:"responsible_workers"` // 工程ごとの作業者（検品時点で最後に完了した製造タスクの担当者）
+	ReworkTaskID       string                       `json:"rework_task_id,omitempty" db:"rework_task_id"` // 不合格時に作成した手直しタスク
+	Notes              string                       `json:"notes" db:"notes"`
+	CreatedAt          time.Time                    `json:"created_at" db:"created_at"`
+}
+
+// NewInspection 新しい検品記録を作成
+func NewInspection(tenantID, orderID string, garmentType GarmentType, inspectorID string) *Inspection {
+	return &Inspection{
+		ID:                 uuid.New().String(),
+		TenantID:           tenantID,
+		OrderID:            orderID,
+		GarmentType:        garmentType,
+		InspectorID:        inspectorID,
+		Results:            make([]*InspectionItemResult, 0),
+		Photos:             make([]*InspectionPhoto, 0),
+		ResponsibleWorkers: make(map[ProductionProcess]string),
+		CreatedAt:          time.Now(),
+	}
+}
+
+// Evaluate チェックリストの全項目について入力を判定し、結果を設定する
+// 寸法項目は仕上がり寸法（expected）との差が許容差以内で合格。仕上がり寸法がない場合は入力の合否を使う
+func (i *Inspection) Evaluate(checklist *InspectionChecklist, expected map[string]float64, inputs []*InspectionItemInput) error {
+	inputByCode := make(map[string]*InspectionItemInput, len(inputs))
+	for _, input := range inputs {
+		inputByCode[input.Code] = input
+	}
+	for _, input := range inputs {
+		if !checklistHasItem(checklist, input.Code) {
+			return fmt.Errorf("invalid results: unknown item %s", input.Code)
+		}
+	}
+
+	results := make([]*InspectionItemResult, 0, len(checklist.Items))
+	defects := 0
+	for _, item := range checklist.Items {
+		input, ok := inputByCode[item.Code]
+		if !ok {
+			return fmt.Errorf("invalid results: item %s is missing", item.Code)
+		}
+
+		result := &InspectionItemResult{
+			Code:    item.Code,
+			Label:   item.Label,
+			Type:    item.Type,
+			Process: item.ResponsibleProcess(),
+			Note:    input.Note,
+		}
+
+		target, hasTarget := expected[item.MeasurementField]
+		switch {
+		case item.Type == InspectionItemTypeMeasurement && hasTarget:
+			if input.Measured == nil {
+				return fmt.Errorf("invalid results: measured is required for %s", item.Code)
+			}
+			deviation := math.Round((*input.Measured-target)*10) / 10
+			result.Expected = &target
+			result.Measured = input.Measured
+			result.Deviation = &deviation
+			result.Tolerance = item.Tolerance
+			result.Passed = math.Abs(deviation) <= item.Tolerance
+		default:
+			if input.Passed == nil {
+				return fmt.Errorf("invalid results: passed is required for %s", item.Code)
+			}
+			result.Measured = input.Measured
+			result.Tolerance = item.Tolerance
+			result.Passed = *input.Passed
+		}
+
+		if !result.Passed {
+			defects++
+			result.ResponsibleWorkerID = i.ResponsibleWorkers[result.Process]
+		}
+		results = append(results, result)
+	}
+
+	i.Results = results
+	i.DefectCount = defects
+	i.Passed = defects == 0
+	return nil
+}
+
+// checklistHasItem チェックリストに項目コードがあるか
+func checklistHasItem(checklist *InspectionChecklist, code string) bool {
+	for _, item := range checklist.Items {
+		if item.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// DefectRate 不良率の集計単位
+type DefectRate struct {
+	Key               string `json:"key"`                // 工場（テナント）ID・工程・作業者ID
+	Inspections       int    `json:"inspections"`        // 対象の検品数
+	FailedInspections int    `json:"failed_inspections"` // 不合格の検品数
+	Defects           int    `json:"defects"`            // 不合格の項目数
+	DefectRatePercent int    `json:"defect_rate_percent"`
+}
+
+// DefectItemCount 検品項目ごとの不合格数
+type DefectItemCount struct {
+	Code    string `json:"code"`
+	Label   string `json:"label"`
+	Defects int    `json:"defects"`
+}
+
+// DefectRateReport 不良率レポート
+// 工場はテナント単位（注文には工場の紐付けがないため）
+type DefectRateReport struct {
+	TenantID  string             `json:"tenant_id"`
+	From      time.Time          `json:"from"`
+	To        time.Time          `json:"to"`
+	Factory   *DefectRate        `json:"factory"`
+	ByProcess []*DefectRate      `json:"by_process"`
+	ByWorker  []*DefectRate      `json:"by_worker"`
+	TopItems  []*DefectItemCount `json:"top_items"` // 不合格の多い検品項目
+}
+
+// SummarizeDefectRates 検品記録から工場・工程・作業者ごとの不良率を集計
+// 工程・作業者の母数は、その工程（作業者が担当した工程）を含む検品の数
+func SummarizeDefectRates(tenantID string, inspections []*Inspection) *DefectRateReport {
+	report := &DefectRateReport{
+		TenantID: tenantID,
+		Factory:  &DefectRate{Key: tenantID},
+	}
+
+	byProcess := make(map[string]*DefectRate)
+	byWorker := make(map[string]*DefectRate)
+	items := make(map[string]*DefectItemCount)
+	rate := func(rates map[string]*DefectRate, key string) *DefectRate {
+		if rates[key] == nil {
+			rates[key] = &DefectRate{Key: key}
+		}
+		return rates[key]
+	}
+
+	for _, inspection := range inspections {
+		report.Factory.Inspections++
+		report.Factory.Defects += inspection.DefectCount
+		if !inspection.Passed {
+			report.Factory.FailedInspections++
+		}
+
+		failedProcesses := make(map[ProductionProcess]int)
+		for _, result := range inspection.Results {
+			if result.Passed {
+				continue
+			}
+			failedProcesses[result.Process]++
+			if items[result.Code] == nil {
+				items[result.Code] = &DefectItemCount{Code: result.Code, Label: result.Label}
+			}
+			items[result.Code].Defects++
+		}
+
+		for _, process := range ProductionProcesses {
+			processRate := rate(byProcess, string(process))
+			processRate.Inspections++
+			if failedProcesses[process] > 0 {
+				processRate.FailedInspections++
+				processRate.Defects += failedProcesses[process]
+			}
+
+			workerID := inspection.ResponsibleWorkers[process]
+			if workerID == "" {
+				continue
+			}
+			workerRate := rate(byWorker, workerID)
+			workerRate.Inspections++
+			if failedProcesses[process] > 0 {
+				workerRate.FailedInspections++
+				workerRate.Defects += failedProcesses[process]
+			}
+		}
+	}
+
+	report.Factory.DefectRatePercent = defectRatePercent(report.Factory)
+	for _, process := range ProductionProcesses {
+		if processRate := byProcess[string(process)]; processRate != nil {
+			processRate.DefectRatePercent = defectRatePercent(processRate)
+			report.ByProcess = append(report.ByProcess, processRate)
+		}
+	}
+	report.ByWorker = make([]*DefectRate, 0, len(byWorker))
+	for _, workerRate := range byWorker {
+		workerRate.DefectRatePercent = defectRatePercent(workerRate)
+		report.ByWorker = append(report.ByWorker, workerRate)
+	}
+	sort.Slice(report.ByWorker, func(a, b int) bool {
+		if report.ByWorker[a].DefectRatePercent != report.ByWorker[b].DefectRatePercent {
+			return report.ByWorker[a].DefectRatePercent > report.ByWorker[b].DefectRatePercent
+		}
+		return report.ByWorker[a].Key < report.ByWorker[b].Key
+	})
+	report.TopItems = make([]*DefectItemCount, 0, len(items))
+	for _, item := range items {
+		report.TopItems = append(report.TopItems, item)
+	}
+	sort.Slice(report.TopItems, func(a, b int) bool {
+		if report.TopItems[a].Defects != report.TopItems[b].Defects {
+			return report.TopItems[a].Defects > report.TopItems[b].Defects
+		}
+		return report.TopItems[a].Code < report.TopItems[b].Code
+	})
+	if report.ByProcess == nil {
+		report.ByProcess = make([]*DefectRate, 0)
+	}
+
+	return report
+}
+
+// defectRatePercent 不良率（%）= 不合格の検品数 / 検品数
+func defectRatePercent(rate *DefectRate) int {
+	if rate.Inspections == 0 {
+		return 0
+	}
+	return rate.FailedInspections * 100 / rate.Inspections
+}
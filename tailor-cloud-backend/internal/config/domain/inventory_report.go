@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// InventoryReportLine 生地ごとの在庫集計（長さ・単価はレポートの単位）
+type InventoryReportLine struct {
+	FabricID        string  `json:"fabric_id"`
+	FabricName      string  `json:"fabric_name"`
+	StockAmount     float64 `json:"stock_amount"`     // 生地マスタの在庫数量
+	RollCount       int     `json:"roll_count"`       // 残りのある反物の本数（利用可能・引当済み）
+	RollLength      float64 `json:"roll_length"`      // 反物の残り長さの合計
+	AvailableLength float64 `json:"available_length"` // 引当可能な長さ（利用可能な反物の残り）
+	DamagedLength   float64 `json:"damaged_length"`   // 破損した反物の残り長さ
+	UnitPrice       int64   `json:"unit_price"`       // 生地単価（円/length_unit）
+	StockValue      int64   `json:"stock_value"`      // 在庫金額（反物の残り長さ×単価、円）
+}
+
+// InventoryReport 生地在庫レポート
+type InventoryReport struct {
+	TenantID        string                 `json:"tenant_id"`
+	LengthUnit      FabricUnit             `json:"length_unit"`
+	GeneratedAt     time.Time              `json:"generated_at"`
+	Lines           []*InventoryReportLine `json:"lines"`
+	TotalRolls      int                    `json:"total_rolls"`
+	TotalLength     float64                `json:"total_length"`
+	AvailableLength float64                `json:"available_length"`
+	TotalValue      int64                  `json:"total_value"`
+}
+
+// BuildInventoryReport 生地と反物から在庫レポートを作成（保存値のメートルで集計し、unit に換算）
+func BuildInventoryReport(tenantID string, fabrics []*Fabric, rollsByFabric map[string][]*FabricRoll, unit FabricUnit, now time.Time) *InventoryReport {
+	if !unit.IsValid() {
+		unit = FabricUnitMeter
+	}
+	report := &InventoryReport{
+		TenantID:    tenantID,
+		LengthUnit:  unit,
+		GeneratedAt: now,
+		Lines:       make([]*InventoryReportLine, 0, len(fabrics)),
+	}
+
+	var totalLength, availableLength float64
+	for _, fabric := range fabrics {
+		var rollLength, available, damaged float64
+		rollCount := 0
+		for _, roll := range rollsByFabric[fabric.ID] {
+			switch roll.Status {
+			case FabricRollStatusAvailable:
+				available += roll.CurrentLength
+				rollLength += roll.CurrentLength
+				rollCount++
+			case FabricRollStatusAllocated:
+				rollLength += roll.CurrentLength
+				rollCount++
+			case FabricRollStatusDamaged:
+				damaged += roll.CurrentLength
+			}
+		}
+
+		line := &InventoryReportLine{
+			FabricID:        fabric.ID,
+			FabricName:      fabric.Name,
+			StockAmount:     unit.FromMeters(fabric.StockAmount),
+			RollCount:       rollCount,
+			RollLength:      unit.FromMeters(rollLength),
+			AvailableLength: unit.FromMeters(available),
+			DamagedLength:   unit.FromMeters(damaged),
+			UnitPrice:       unit.PriceFromMeter(fabric.Price),
+			StockValue:      int64(math.Round(rollLength * float64(fabric.Price))),
+		}
+		report.Lines = append(report.Lines, line)
+
+		report.TotalRolls += rollCount
+		report.TotalValue += line.StockValue
+		totalLength += rollLength
+		availableLength += available
+	}
+	report.TotalLength = unit.FromMeters(totalLength)
+	report.AvailableLength = unit.FromMeters(availableLength)
+	return report
+}
@@ -0,0 +1,129 @@
+package domain
+
+import (
+	"sort"
+	"strconv"
+	"time"
+)
+
+// CurrencyJPY 請求通貨（日本円）
+const CurrencyJPY = "JPY"
+
+// Invoice 適格請求書（インボイス）のデータモデル
+// PDF・電子インボイス（JP PINT）などの出力形式に依存しない請求内容を表す
+type Invoice struct {
+	ID                   string                `json:"id"` // 請求書番号（注文ID）
+	OrderID              string                `json:"order_id"`
+	TenantID             string                `json:"tenant_id"`
+	IssueDate            time.Time             `json:"issue_date"`
+	DueDate              time.Time             `json:"due_date"`
+	DeliveryDate         time.Time             `json:"delivery_date"`
+	Currency             string                `json:"currency"`
+	Seller               InvoiceParty          `json:"seller"`
+	Buyer                InvoiceParty          `json:"buyer"`
+	Lines                []*InvoiceLine        `json:"lines"`
+	Allowances           []*InvoiceAllowance   `json:"allowances"` // 値引き（割引・クーポン）
+	TaxSubtotals         []*InvoiceTaxSubtotal `json:"tax_subtotals"`
+	LineExtensionAmount  int64                 `json:"line_extension_amount"`  // 明細合計（値引き前の税抜合計）
+	AllowanceTotalAmount int64                 `json:"allowance_total_amount"` // 値引き合計
+	TaxExclusiveAmount   int64                 `json:"tax_exclusive_amount"`   // 税抜合計（値引き後）
+	TaxAmount            int64                 `json:"tax_amount"`             // 消費税合計
+	TaxInclusiveAmount   int64                 `json:"tax_inclusive_amount"`   // 税込合計（請求額）
+}
+
+// InvoiceParty 請求書の当事者（売り手・買い手）
+type InvoiceParty struct {
+	Name               string `json:"name"`
+	Address            string `json:"address,omitempty"`
+	Email              string `json:"email,omitempty"`
+	RegistrationNumber string `json:"registration_number,omitempty"` // 適格請求書発行事業者登録番号（T番号）
+}
+
+// InvoiceLine 請求明細
+type InvoiceLine struct {
+	ID          string  `json:"id"`
+	Description string  `json:"description"`
+	Quantity    int64   `json:"quantity"`
+	UnitPrice   int64   `json:"unit_price"` // 税抜単価（円）
+	Amount      int64   `json:"amount"`     // 税抜金額（円）
+	TaxRate     TaxRate `json:"tax_rate"`
+}
+
+// InvoiceAllowance 請求書レベルの値引き
+// 値引き後の金額に対して消費税を計算するため、税率ごとに値引き額を保持する
+type InvoiceAllowance struct {
+	Reason  string  `json:"reason"`
+	Amount  int64   `json:"amount"` // 値引き額（税抜・円、正の値）
+	TaxRate TaxRate `json:"tax_rate"`
+}
+
+// InvoiceTaxSubtotal 税率ごとの合計（適格請求書の記載事項）
+type InvoiceTaxSubtotal struct {
+	TaxRate       TaxRate `json:"tax_rate"`
+	TaxableAmount int64   `json:"taxable_amount"` // 税率ごとの税抜合計
+	TaxAmount     int64   `json:"tax_amount"`     // 税率ごとの消費税額
+}
+
+// AddLine 請求明細を追加
+func (inv *Invoice) AddLine(description string, quantity int64, unitPrice int64, taxRate TaxRate) *InvoiceLine {
+	line := &InvoiceLine{
+		ID:          strconv.Itoa(len(inv.Lines) + 1),
+		Description: description,
+		Quantity:    quantity,
+		UnitPrice:   unitPrice,
+		Amount:      quantity * unitPrice,
+		TaxRate:     taxRate,
+	}
+	inv.Lines = append(inv.Lines, line)
+	return line
+}
+
+// AddAllowance 値引きを追加
+func (inv *Invoice) AddAllowance(reason string, amount int64, taxRate TaxRate) *InvoiceAllowance {
+	allowance := &InvoiceAllowance{
+		Reason:  reason,
+		Amount:  amount,
+		TaxRate: taxRate,
+	}
+	inv.Allowances = append(inv.Allowances, allowance)
+	return allowance
+}
+
+// CalculateTotals 税率ごとの合計と請求額を計算
+// インボイス制度の端数処理ルール: 消費税の端数処理は1請求書につき税率ごとに1回
+// 値引きは対象の税率の課税標準から控除する
+func (inv *Invoice) CalculateTotals(roundingMethod TaxRoundingMethod) {
+	taxable := make(map[TaxRate]int64)
+	inv.LineExtensionAmount = 0
+	for _, line := range inv.Lines {
+		taxable[line.TaxRate] += line.Amount
+		inv.LineExtensionAmount += line.Amount
+	}
+	inv.AllowanceTotalAmount = 0
+	for _, allowance := range inv.Allowances {
+		taxable[allowance.TaxRate] -= allowance.Amount
+		inv.AllowanceTotalAmount += allowance.Amount
+	}
+
+	rates := make([]TaxRate, 0, len(taxable))
+	for rate := range taxable {
+		rates = append(rates, rate)
+	}
+	// 標準税率 → 軽減税率の順
+	sort.Slice(rates, func(i, j int) bool { return rates[i] > rates[j] })
+
+	inv.TaxSubtotals = make([]*InvoiceTaxSubtotal, 0, len(rates))
+	inv.TaxExclusiveAmount = 0
+	inv.TaxAmount = 0
+	for _, rate := range rates {
+		subtotal := &InvoiceTaxSubtotal{
+			TaxRate:       rate,
+			TaxableAmount: taxable[rate],
+			TaxAmount:     CalculateTax(taxable[rate], rate, roundingMethod),
+		}
+		inv.TaxSubtotals = append(inv.TaxSubtotals, subtotal)
+		inv.TaxExclusiveAmount += subtotal.TaxableAmount
+		inv.TaxAmount += subtotal.TaxAmount
+	}
+	inv.TaxInclusiveAmount = inv.TaxExclusiveAmount + inv.TaxAmount
+}
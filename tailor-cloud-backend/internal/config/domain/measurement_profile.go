@@ -0,0 +1,241 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MeasurementField 採寸項目（採寸データのJSONキーと表示名）
+type MeasurementField struct {
+	Key   string `json:"key"`
+	Label string `json:"label"`
+}
+
+// MeasurementFields 採寸データの標準項目（表示順）
+var MeasurementFields = []MeasurementField{
+	{Key: "height", Label: "身長"},
+	{Key: "bust", Label: "バスト"},
+	{Key: "waist", Label: "ウエスト"},
+	{Key: "hip", Label: "ヒップ"},
+	{Key: "thigh", Label: "太もも"},
+	{Key: "knee", Label: "膝"},
+	{Key: "calf", Label: "ふくらはぎ"},
+	{Key: "ob", Label: "OB"},
+	{Key: "jacket_length", Label: "ジャケット長"},
+	{Key: "sleeve", Label: "袖長"},
+	{Key: "chest", Label: "胸囲"},
+	{Key: "shoulder_width", Label: "肩幅"},
+	{Key: "back_length", Label: "背丈"},
+	{Key: "neck", Label: "ネック"},
+	{Key: "yoke", Label: "ヨーク"},
+	{Key: "cuff", Label: "カフス"},
+	{Key: "rise", Label: "股上"},
+	{Key: "inseam", Label: "股下"},
+	{Key: "outseam", Label: "総丈"},
+}
+
+// MeasurementFieldLabel 採寸項目の表示名（標準項目以外はキーをそのまま返す）
+func MeasurementFieldLabel(key string) string {
+	for _, field := range MeasurementFields {
+		if field.Key == key {
+			return field.Label
+		}
+	}
+	return key
+}
+
+// MeasurementValues 採寸データ（JSON）から数値の項目を取り出す
+// 数値以外の項目（メモなど）は無視する
+func MeasurementValues(measurements json.RawMessage) (map[string]float64, error) {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(measurements, &raw); err != nil {
+		return nil, fmt.Errorf("invalid measurements: %w", err)
+	}
+
+	values := make(map[string]float64, len(raw))
+	for key, value := range raw {
+		if number, ok := value.(float64); ok {
+			values[key] = number
+		}
+	}
+	return values, nil
+}
+
+// MeasurementProfile 顧客の採寸プロファイル（顧客ごとに1件、採寸のたびに版を追加する）
+type MeasurementProfile struct {
+	ID             string                     `json:"id" db:"id"`
+	TenantID       string                     `json:"tenant_id" db:"tenant_id"`
+	CustomerID     string                     `json:"customer_id" db:"customer_id"`
+	LatestVersion  int                        `json:"latest_version" db:"latest_version"`     // 最新版の版番号
+	BodyShapeNotes string                     `json:"body_shape_notes" db:"body_shape_notes"` // 最新版の体型メモ
+	Latest         *MeasurementProfileVersion `json:"latest,omitempty" db:"-"`                // 最新版
+	CreatedAt      time.Time                  `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time                  `json:"updated_at" db:"updated_at"`
+}
+
+// MeasurementProfileVersion 採寸プロファイルの版（ある時点の採寸スナップショット、変更しない）
+type MeasurementProfileVersion struct {
+	ID             string          `json:"id" db:"id"`
+	ProfileID      string          `json:"profile_id" db:"profile_id"`
+	TenantID       string          `json:"tenant_id" db:"tenant_id"`
+	CustomerID     string          `json:"customer_id" db:"customer_id"`
+	Version        int             `json:"version" db:"version"`                   // 1から始まる版番号
+	Measurements   json.RawMessage `json:"measurements" db:"measurements"`         // 採寸データ（注文の採寸データと同じ形式）
+	BodyShapeNotes string          `json:"body_shape_notes" db:"body_shape_notes"` // 体型メモ（反り身・なで肩など）
+	Notes          string          `json:"notes" db:"notes"`
+	MeasuredBy     string          `json:"measured_by" db:"measured_by"` // 採寸者
+	MeasuredAt     time.Time       `json:"measured_at" db:"measured_at"` // 採寸日時
+	OrderIDs       []string        `json:"order_ids" db:"-"`             // この版の採寸データを使った注文
+	CreatedAt      time.Time       `json:"created_at" db:"created_at"`
+}
+
+// NewMeasurementProfile 新しい採寸プロファイルを作成（版なし）
+func NewMeasurementProfile(tenantID, customerID string) *MeasurementProfile {
+	now := time.Now()
+	return &MeasurementProfile{
+		ID:         uuid.New().String(),
+		TenantID:   tenantID,
+		CustomerID: customerID,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+}
+
+// NextVersion 採寸スナップショットを次の版として追加し、プロファイルの最新版を更新する
+func (p *MeasurementProfile) NextVersion(measurements json.RawMessage, bodyShapeNotes, notes, measuredBy string, measuredAt time.Time) *MeasurementProfileVersion {
+	now := time.Now()
+	version := &MeasurementProfileVersion{
+		ID:             uuid.New().String(),
+		ProfileID:      p.ID,
+		TenantID:       p.TenantID,
+		CustomerID:     p.CustomerID,
+		Version:        p.LatestVersion + 1,
+		Measurements:   measurements,
+		BodyShapeNotes: bodyShapeNotes,
+		Notes:          notes,
+		MeasuredBy:     measuredBy,
+		MeasuredAt:     measuredAt,
+		OrderIDs:       []string{},
+		CreatedAt:      now,
+	}
+
+	p.LatestVersion = version.Version
+	p.BodyShapeNotes = bodyShapeNotes
+	p.Latest = version
+	p.UpdatedAt = now
+	return version
+}
+
+// Validate 採寸プロファイルの版を検証
+func (v *MeasurementProfileVersion) Validate() error {
+	if len(v.Measurements) == 0 {
+		return fmt.Errorf("measurements is required")
+	}
+	values, err := MeasurementValues(v.Measurements)
+	if err != nil {
+		return err
+	}
+	if len(values) == 0 {
+		return fmt.Errorf("invalid measurements: at least one numeric measurement is required")
+	}
+	for key, value := range values {
+		if value <= 0 {
+			return fmt.Errorf("invalid measurements: %s must be greater than 0", key)
+		}
+	}
+	if v.MeasuredBy == "" {
+		return fmt.Errorf("measured_by is required")
+	}
+	if v.MeasuredAt.IsZero() {
+		return fmt.Errorf("measured_at is required")
+	}
+	return nil
+}
+
+// MeasurementPoint 採寸項目の推移の1点
+type MeasurementPoint struct {
+	Version    int       `json:"version"`
+	MeasuredAt time.Time `json:"measured_at"`
+	Value      float64   `json:"value"`
+}
+
+// MeasurementSeries 採寸項目ごとの推移
+type MeasurementSeries struct {
+	Field  string             `json:"field"`
+	Label  string             `json:"label"`
+	Points []MeasurementPoint `json:"points"`
+	Change float64            `json:"change"` // 最初の版から最新の版までの変化量（チャートの単位）
+}
+
+// MeasurementChart 採寸値の推移（グラフ表示用）
+type MeasurementChart struct {
+	CustomerID string               `json:"customer_id"`
+	Versions   int                  `json:"versions"`
+	Unit       MeasurementUnit      `json:"unit"` // 採寸値の単位
+	Series     []*MeasurementSeries `json:"series"`
+}
+
+// BuildMeasurementChart 採寸プロファイルの版（古い順）から採寸項目ごとの推移を作成
+// fields を指定した場合はその項目のみ。標準項目を表示順に並べ、それ以外の項目はキー順に続ける
+func BuildMeasurementChart(customerID string, versions []*MeasurementProfileVersion, fields []string) (*MeasurementChart, error) {
+	wanted := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		wanted[field] = true
+	}
+
+	seriesByField := make(map[string]*MeasurementSeries)
+	for _, version := range versions {
+		values, err := MeasurementValues(version.Measurements)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse measurement profile version %d: %w", version.Version, err)
+		}
+		for key, value := range values {
+			if len(wanted) > 0 && !wanted[key] {
+				continue
+			}
+			series, ok := seriesByField[key]
+			if !ok {
+				series = &MeasurementSeries{Field: key, Label: MeasurementFieldLabel(key), Points: []MeasurementPoint{}}
+				seriesByField[key] = series
+			}
+			series.Points = append(series.Points, MeasurementPoint{
+				Version:    version.Version,
+				MeasuredAt: version.MeasuredAt,
+				Value:      value,
+			})
+		}
+	}
+
+	chart := &MeasurementChart{
+		CustomerID: customerID,
+		Versions:   len(versions),
+		Unit:       MeasurementUnitCM,
+		Series:     make([]*MeasurementSeries, 0, len(seriesByField)),
+	}
+	for _, field := range MeasurementFields {
+		if series, ok := seriesByField[field.Key]; ok {
+			chart.Series = append(chart.Series, series)
+			delete(seriesByField, field.Key)
+		}
+	}
+	others := make([]string, 0, len(seriesByField))
+	for key := range seriesByField {
+		others = append(others, key)
+	}
+	sort.Strings(others)
+	for _, key := range others {
+		chart.Series = append(chart.Series, seriesByField[key])
+	}
+
+	for _, series := range chart.Series {
+		first := series.Points[0].Value
+		last := series.Points[len(series.Points)-1].Value
+		series.Change = math.Round((last-first)*10) / 10
+	}
+	return chart, nil
+}
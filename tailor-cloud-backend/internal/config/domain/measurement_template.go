@@ -0,0 +1,239 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// MeasurementUnit 採寸値の単位
+type MeasurementUnit string
+
+const (
+	MeasurementUnitCM   MeasurementUnit = "cm"   // センチメートル
+	MeasurementUnitMM   MeasurementUnit = "mm"   // ミリメートル
+	MeasurementUnitInch MeasurementUnit = "inch" // インチ
+)
+
+// IsValid 単位が有効かチェック
+func (u MeasurementUnit) IsValid() bool {
+	switch u {
+	case MeasurementUnitCM, MeasurementUnitMM, MeasurementUnitInch:
+		return true
+	default:
+		return false
+	}
+}
+
+// 採寸値の変化アラートの既定の閾値（前回採寸との差）
+const (
+	DefaultChangeWarningThreshold = 5.0  // 警告
+	DefaultChangeErrorThreshold   = 10.0 // エラー
+)
+
+// measurementFieldKeyPattern 採寸項目のキー（採寸データのJSONキー）
+var measurementFieldKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// MeasurementTemplateField 採寸テンプレートの項目
+type MeasurementTemplateField struct {
+	Key                  string          `json:"key"`                              // 採寸データのJSONキー（例: "neck"）
+	Label                string          `json:"label"`                            // 表示名
+	Unit                 MeasurementUnit `json:"unit"`                             // 単位（未設定はcm）
+	Min                  float64         `json:"min"`                              // 正常範囲の下限
+	Max                  float64         `json:"max"`                              // 正常範囲の上限
+	Required             bool            `json:"required"`                         // 必須項目
+	ChangeThreshold      float64         `json:"change_threshold,omitempty"`       // 前回との差の警告閾値（未設定はテンプレートの既定）
+	ChangeErrorThreshold float64         `json:"change_error_threshold,omitempty"` // 前回との差のエラー閾値（未設定はテンプレートの既定）
+}
+
+// MeasurementTemplate 品目ごとの採寸テンプレート（テナントごと）
+// 採寸項目・単位・正常範囲・必須項目と、前回採寸との差のアラート閾値を定める
+type MeasurementTemplate struct {
+	TenantID               string                      `json:"tenant_id" db:"tenant_id"`
+	GarmentType            GarmentType                 `json:"garment_type" db:"garment_type"`
+	Fields                 []*MeasurementTemplateField `json:"fields" db:"fields"`
+	ChangeWarningThreshold float64                     `json:"change_warning_threshold" db:"change_warning_threshold"` // 前回との差の警告閾値（既定: 5）
+	ChangeErrorThreshold   float64                     `json:"change_error_threshold" db:"change_error_threshold"`     // 前回との差のエラー閾値（既定: 10）
+	IsDefault              bool                        `json:"is_default"`                                             // 未設定のため既定のテンプレートを返した場合
+	UpdatedAt              time.Time                   `json:"updated_at" db:"updated_at"`
+	UpdatedBy              string                      `json:"updated_by" db:"updated_by"`
+}
+
+// Validate 採寸テンプレートを検証（未設定の単位・閾値は既定値で補う）
+func (t *MeasurementTemplate) Validate() error {
+	if !t.GarmentType.IsValid() {
+		return fmt.Errorf("invalid garment_type: %s", t.GarmentType)
+	}
+	if len(t.Fields) == 0 {
+		return fmt.Errorf("fields is required")
+	}
+	if t.ChangeWarningThreshold == 0 {
+		t.ChangeWarningThreshold = DefaultChangeWarningThreshold
+	}
+	if t.ChangeErrorThreshold == 0 {
+		t.ChangeErrorThreshold = DefaultChangeErrorThreshold
+	}
+	if t.ChangeWarningThreshold < 0 || t.ChangeErrorThreshold < t.ChangeWarningThreshold {
+		return fmt.Errorf("invalid change thresholds: error threshold must be greater than or equal to warning threshold")
+	}
+
+	seen := make(map[string]bool, len(t.Fields))
+	for _, field := range t.Fields {
+		if !measurementFieldKeyPattern.MatchString(field.Key) {
+			return fmt.Errorf("invalid fields: key %q must be lowercase letters, digits and underscores", field.Key)
+		}
+		if seen[field.Key] {
+			return fmt.Errorf("invalid fields: duplicate key %s", field.Key)
+		}
+		seen[field.Key] = true
+		if field.Label == "" {
+			return fmt.Errorf("invalid fields: label is required for %s", field.Key)
+		}
+		if field.Unit == "" {
+			field.Unit = MeasurementUnitCM
+		}
+		if !field.Unit.IsValid() {
+			return fmt.Errorf("invalid fields: unknown unit %s for %s", field.Unit, field.Key)
+		}
+		if field.Min < 0 || field.Max <= field.Min {
+			return fmt.Errorf("invalid fields: range for %s must satisfy 0 <= min < max", field.Key)
+		}
+		if field.ChangeThreshold < 0 || field.ChangeErrorThreshold < 0 {
+			return fmt.Errorf("invalid fields: change thresholds for %s must not be negative", field.Key)
+		}
+	}
+	return nil
+}
+
+// Field キーに対応する採寸項目（テンプレートにない場合は nil）
+func (t *MeasurementTemplate) Field(key string) *MeasurementTemplateField {
+	for _, field := range t.Fields {
+		if field.Key == key {
+			return field
+		}
+	}
+	return nil
+}
+
+// ChangeThresholds 採寸項目の前回との差の閾値（警告, エラー）
+// 項目ごとの設定がない場合はテンプレートの既定値を使う
+func (t *MeasurementTemplate) ChangeThresholds(key string) (float64, float64) {
+	warning, errorThreshold := t.ChangeWarningThreshold, t.ChangeErrorThreshold
+	if warning <= 0 {
+		warning = DefaultChangeWarningThreshold
+	}
+	if errorThreshold <= 0 {
+		errorThreshold = DefaultChangeErrorThreshold
+	}
+	if field := t.Field(key); field != nil {
+		if field.ChangeThreshold > 0 {
+			warning = field.ChangeThreshold
+		}
+		if field.ChangeErrorThreshold > 0 {
+			errorThreshold = field.ChangeErrorThreshold
+		}
+	}
+	if errorThreshold < warning {
+		errorThreshold = warning
+	}
+	return warning, errorThreshold
+}
+
+// Unit 採寸項目の単位（テンプレートにない項目はcm）
+func (t *MeasurementTemplate) Unit(key string) MeasurementUnit {
+	if field := t.Field(key); field != nil && field.Unit != "" {
+		return field.Unit
+	}
+	return MeasurementUnitCM
+}
+
+// ValuesInFieldUnits cmに正規化した採寸値を項目の単位（正常範囲・閾値の単位）に換算
+// テンプレートにない項目・cmの項目・長さではない項目はそのまま返す
+func (t *MeasurementTemplate) ValuesInFieldUnits(values map[string]float64) map[string]float64 {
+	converted := make(map[string]float64, len(values))
+	for key, value := range values {
+		if unit := t.Unit(key); unit != MeasurementUnitCM && !nonLengthMeasurementKeys[key] {
+			value = unit.FromCentimeters(value)
+		}
+		converted[key] = value
+	}
+	return converted
+}
+
+// Label 採寸項目の表示名（テンプレートにない項目は標準項目の表示名）
+func (t *MeasurementTemplate) Label(key string) string {
+	if field := t.Field(key); field != nil && field.Label != "" {
+		return field.Label
+	}
+	return MeasurementFieldLabel(key)
+}
+
+// standardMeasurementTemplateFields 既定のテンプレートで使う採寸項目（cm、正常範囲）
+var standardMeasurementTemplateFields = map[string]MeasurementTemplateField{
+	"height":         {Key: "height", Label: "身長", Min: 50, Max: 250},
+	"bust":           {Key: "bust", Label: "バスト", Min: 50, Max: 200},
+	"waist":          {Key: "waist", Label: "ウエスト", Min: 40, Max: 150},
+	"hip":            {Key: "hip", Label: "ヒップ", Min: 50, Max: 200},
+	"thigh":          {Key: "thigh", Label: "太もも", Min: 25, Max: 100},
+	"knee":           {Key: "knee", Label: "膝", Min: 20, Max: 70},
+	"calf":           {Key: "calf", Label: "ふくらはぎ", Min: 15, Max: 70},
+	"ob":             {Key: "ob", Label: "OB", Min: 50, Max: 200},
+	"chest":          {Key: "chest", Label: "胸囲", Min: 50, Max: 200},
+	"shoulder_width": {Key: "shoulder_width", Label: "肩幅", Min: 30, Max: 65},
+	"back_length":    {Key: "back_length", Label: "背丈", Min: 30, Max: 70},
+	"jacket_length":  {Key: "jacket_length", Label: "ジャケット長", Min: 40, Max: 130},
+	"sleeve":         {Key: "sleeve", Label: "袖長", Min: 40, Max: 100},
+	"neck":           {Key: "neck", Label: "ネック", Min: 25, Max: 60},
+	"yoke":           {Key: "yoke", Label: "ヨーク", Min: 30, Max: 65},
+	"cuff":           {Key: "cuff", Label: "カフス", Min: 12, Max: 35},
+	"rise":           {Key: "rise", Label: "股上", Min: 15, Max: 45},
+	"inseam":         {Key: "inseam", Label: "股下", Min: 50, Max: 100},
+	"outseam":        {Key: "outseam", Label: "総丈", Min: 70, Max: 130},
+}
+
+// defaultMeasurementTemplateKeys 品目ごとの既定の採寸項目（表示順、* は必須）
+var defaultMeasurementTemplateKeys = map[GarmentType][]string{
+	GarmentTypeSuit:     {"height*", "bust*", "waist*", "hip*", "ob", "chest", "shoulder_width", "back_length", "jacket_length", "sleeve", "thigh", "knee", "calf", "rise", "inseam"},
+	GarmentTypeJacket:   {"height*", "bust*", "waist*", "hip", "ob", "chest", "shoulder_width", "back_length", "jacket_length", "sleeve"},
+	GarmentTypeTrousers: {"height", "waist*", "hip*", "thigh", "knee", "calf", "rise", "inseam*", "outseam"},
+	GarmentTypeVest:     {"height", "bust*", "waist*", "ob", "back_length"},
+	GarmentTypeCoat:     {"height*", "bust*", "waist", "hip*", "shoulder_width", "back_length", "jacket_length", "sleeve"},
+	GarmentTypeShirt:    {"height", "neck*", "bust*", "waist*", "shoulder_width", "yoke", "back_length", "sleeve*", "cuff*"},
+}
+
+// genericMeasurementTemplateKeys 品目未設定の注文に使う採寸項目（必須項目なし）
+var genericMeasurementTemplateKeys = []string{
+	"height", "bust", "waist", "hip", "thigh", "knee", "calf", "ob", "jacket_length", "sleeve", "chest",
+	"shoulder_width", "back_length", "neck", "yoke", "cuff", "rise", "inseam", "outseam",
+}
+
+// DefaultMeasurementTemplate 品目の既定の採寸テンプレート
+// 品目が未設定の場合は全項目を任意とした汎用のテンプレートを返す
+func DefaultMeasurementTemplate(tenantID string, garmentType GarmentType) *MeasurementTemplate {
+	keys, ok := defaultMeasurementTemplateKeys[garmentType]
+	if !ok {
+		keys = genericMeasurementTemplateKeys
+	}
+
+	fields := make([]*MeasurementTemplateField, 0, len(keys))
+	for _, key := range keys {
+		required := false
+		if key[len(key)-1] == '*' {
+			key = key[:len(key)-1]
+			required = true
+		}
+		field := standardMeasurementTemplateFields[key]
+		field.Unit = MeasurementUnitCM
+		field.Required = required
+		fields = append(fields, &field)
+	}
+
+	return &MeasurementTemplate{
+		TenantID:               tenantID,
+		GarmentType:            garmentType,
+		Fields:                 fields,
+		ChangeWarningThreshold: DefaultChangeWarningThreshold,
+		ChangeErrorThreshold:   DefaultChangeErrorThreshold,
+		IsDefault:              true,
+	}
+}
@@ -40,6 +40,8 @@ type Tenant struct {
 	Address                 string             `json:"address" firestore:"address" db:"address"`
 	InvoiceRegistrationNo   string             `json:"invoice_registration_no" firestore:"invoice_registration_no" db:"invoice_registration_no"` // インボイス登録番号（T番号）
 	TaxRoundingMethod       TaxRoundingMethod  `json:"tax_rounding_method" firestore:"tax_rounding_method" db:"tax_rounding_method"`               // 端数処理方法
+	MeasurementUnit         MeasurementUnit    `json:"measurement_unit" firestore:"measurement_unit" db:"measurement_unit"`                      // 採寸値の表示単位（既定: cm）
+	FabricUnit              FabricUnit         `json:"fabric_unit" firestore:"fabric_unit" db:"fabric_unit"`                                     // 生地の長さ・単価の表示単位（既定: m）
 	CreatedAt               time.Time          `json:"created_at" firestore:"created_at" db:"created_at"`
 	UpdatedAt               time.Time          `json:"updated_at" firestore:"updated_at" db:"updated_at"`
 }
@@ -67,12 +69,16 @@ type Order struct {
 	TaxRate           TaxRate            `json:"tax_rate" firestore:"tax_rate" db:"tax_rate"`             // 消費税率（0.10 = 10%, 0.08 = 8%）
 	TaxExcludedAmount *int64             `json:"tax_excluded_amount" firestore:"tax_excluded_amount" db:"tax_excluded_amount"` // 税抜金額（明示的な場合）
 	InvoiceIssuedAt   *time.Time         `json:"invoice_issued_at" firestore:"invoice_issued_at" db:"invoice_issued_at"`       // 請求書発行日時
+	PaidAt            *time.Time         `json:"paid_at,omitempty" firestore:"paid_at,omitempty" db:"paid_at"`                 // 入金完了日時（請求額の全額の入金を確認した日時、納品前の入金を含む）
 	PaymentDueDate    time.Time          `json:"payment_due_date" firestore:"payment_due_date" db:"payment_due_date"`          // 支払期日（下請法60日ルール準拠）
 	DeliveryDate      time.Time          `json:"delivery_date" firestore:"delivery_date" db:"delivery_date"`                   // 納期
 	Details           *OrderDetails      `json:"details" firestore:"details" db:"details"`
 	CreatedAt         time.Time          `json:"created_at" firestore:"created_at" db:"created_at"`
 	UpdatedAt         time.Time          `json:"updated_at" firestore:"updated_at" db:"updated_at"`
 	CreatedBy         string             `json:"created_by" firestore:"created_by" db:"created_by"` // ユーザーID
+	SourceOrderID     string             `json:"source_order_id,omitempty" firestore:"source_order_id,omitempty" db:"source_order_id"` // 複製元の注文ID（再注文の場合）
+	GarmentType       GarmentType        `json:"garment_type,omitempty" firestore:"garment_type,omitempty" db:"garment_type"`           // 品目（未設定の場合は空）
+	GroupOrderID      string             `json:"group_order_id,omitempty" firestore:"group_order_id,omitempty" db:"group_order_id"`     // 団体注文ID（法人・団体注文の着用者ごとの注文の場合）
 }
 
 // OrderStatus 注文ステータス
@@ -107,6 +113,7 @@ type Customer struct {
 	Name      string    `json:"name" firestore:"name"`
 	Email     string    `json:"email" firestore:"email"`
 	Phone     string    `json:"phone" firestore:"phone"`
+	MeasurementUnit MeasurementUnit `json:"measurement_unit,omitempty" firestore:"measurement_unit"` // 採寸値の表示単位（未設定はテナントの設定）
 	CreatedAt time.Time `json:"created_at" firestore:"created_at"`
 	UpdatedAt time.Time `json:"updated_at" firestore:"updated_at"`
 }
@@ -118,10 +125,13 @@ type Fabric struct {
 	SupplierID   string      `json:"supplier_id" firestore:"supplier_id" db:"supplier_id"`
 	Name         string      `json:"name" firestore:"name" db:"name"`
 	StockAmount  float64     `json:"stock_amount" firestore:"stock_amount" db:"stock_amount"` // 在庫数量（メートル）
-	Price        int64       `json:"price" firestore:"price" db:"price"`                      // 単価（円/メートル）
+	Price        int64       `json:"price" firestore:"price" db:"price"`                      // 単価（円/メートル、表示単位に換算した場合は円/length_unit）
+	LengthUnit   FabricUnit  `json:"length_unit" firestore:"-" db:"-"`                        // 在庫数量・最小発注数量・単価の単位
+	SupplierPriceUnit FabricUnit `json:"supplier_price_unit" firestore:"price_unit" db:"price_unit"` // 仕入先の単価の単位（輸入生地はヤード）
 	StockStatus  StockStatus `json:"stock_status" firestore:"stock_status" db:"stock_status"` // 在庫ステータス（計算フィールド）
 	ImageURL     string      `json:"image_url" firestore:"image_url" db:"image_url"`          // 生地画像URL（UI表示用）
 	MinimumOrder float64     `json:"minimum_order" firestore:"minimum_order" db:"minimum_order"` // 最小発注数量（デフォルト3.2m = スーツ1着分）
+	Attributes   FabricAttributes `json:"attributes" firestore:"attributes" db:"-"`           // 色系統・柄・目付・シーズン・フォーマル度（生地レコメンド用）
 	CreatedAt    time.Time   `json:"created_at" firestore:"created_at" db:"created_at"`
 	UpdatedAt    time.Time   `json:"updated_at" firestore:"updated_at" db:"updated_at"`
 }
@@ -151,6 +161,7 @@ func (f *Fabric) CalculateStockStatus() {
 // 決済トランザクション（Phase 3で使用）
 type Transaction struct {
 	ID            string           `json:"id" db:"id"`
+	TenantID      string           `json:"tenant_id" db:"tenant_id"`
 	OrderID       string           `json:"order_id" db:"order_id"`
 	Status        TransactionStatus `json:"status" db:"status"`
 	PaymentMethod string           `json:"payment_method" db:"payment_method"`
@@ -168,6 +179,21 @@ const (
 	TransactionStatusFailed    TransactionStatus = "Failed"
 )
 
+// NewTransaction 新しい取引を作成
+func NewTransaction(tenantID, orderID, paymentMethod string, amount int64, status TransactionStatus) *Transaction {
+	now := time.Now()
+	return &Transaction{
+		ID:            uuid.New().String(),
+		TenantID:      tenantID,
+		OrderID:       orderID,
+		Status:        status,
+		PaymentMethod: paymentMethod,
+		Amount:        amount,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
 // ComplianceDocument は compliance.go で定義されています（履歴管理対応版）
 
 // NewOrder 新しい注文を作成
@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CancellationFeePolicy 注文キャンセル時のキャンセル料規定
+// 到達した製造工程に応じて、注文金額（税抜）に対する料率でキャンセル料を算定する
+type CancellationFeePolicy struct {
+	Stage       OrderStatus `json:"stage"`        // キャンセル時点の注文ステータス
+	Code        string      `json:"code"`         // 規定コード
+	RatePercent int64       `json:"rate_percent"` // キャンセル料率（%）
+	Description string      `json:"description"`
+}
+
+// DefaultCancellationFeePolicies 製造工程ごとのキャンセル料規定
+// 発送済み以降（Shipped / Delivered / Paid）はキャンセル不可
+var DefaultCancellationFeePolicies = []CancellationFeePolicy{
+	{Stage: OrderStatusDraft, Code: "NO_FEE", RatePercent: 0, Description: "接客中のためキャンセル料なし"},
+	{Stage: OrderStatusConfirmed, Code: "NO_FEE", RatePercent: 0, Description: "製造着手前のためキャンセル料なし"},
+	{Stage: OrderStatusMaterialSecured, Code: "MATERIAL", RatePercent: 30, Description: "生地確保済みのため生地代相当"},
+	{Stage: OrderStatusCutting, Code: "CUTTING", RatePercent: 60, Description: "裁断着手済み"},
+	{Stage: OrderStatusSewing, Code: "SEWING", RatePercent: 80, Description: "縫製着手済み"},
+	{Stage: OrderStatusInspection, Code: "FULL", RatePercent: 100, Description: "製造完了（検品中）のため全額"},
+}
+
+// CancellationFeePolicyFor 注文ステータスに対応するキャンセル料規定を取得
+func CancellationFeePolicyFor(stage OrderStatus) (*CancellationFeePolicy, error) {
+	for i := range DefaultCancellationFeePolicies {
+		if DefaultCancellationFeePolicies[i].Stage == stage {
+			policy := DefaultCancellationFeePolicies[i]
+			return &policy, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid order status for cancellation: %s", stage)
+}
+
+// FeeFor 注文金額（税抜）に対するキャンセル料を計算（円未満切り捨て）
+func (p *CancellationFeePolicy) FeeFor(orderAmount int64) int64 {
+	return orderAmount * p.RatePercent / 100
+}
+
+// OrderCancellation 注文キャンセル記録
+type OrderCancellation struct {
+	ID                   string      `json:"id" db:"id"`
+	TenantID             string      `json:"tenant_id" db:"tenant_id"`
+	OrderID              string      `json:"order_id" db:"order_id"`
+	Reason               string      `json:"reason" db:"reason"`
+	Stage                OrderStatus `json:"stage" db:"stage"` // キャンセル時点の注文ステータス
+	FeePolicyCode        string      `json:"fee_policy_code" db:"fee_policy_code"`
+	FeeRatePercent       int64       `json:"fee_rate_percent" db:"fee_rate_percent"`
+	OrderAmount          int64       `json:"order_amount" db:"order_amount"` // 注文金額（税抜）
+	FeeAmount            int64       `json:"fee_amount" db:"fee_amount"`     // キャンセル料（税抜）
+	ReleasedAllocations  int         `json:"released_allocations" db:"released_allocations"`
+	ReleasedLength       float64     `json:"released_length" db:"released_length"` // 在庫に戻した長さ（メートル）
+	CancelledCommissions int         `json:"cancelled_commissions" db:"cancelled_commissions"`
+	ComplianceDocumentID string      `json:"compliance_document_id" db:"compliance_document_id"` // 発注取消通知書（発注書未発行の場合は空）
+	CancelledBy          string      `json:"cancelled_by" db:"cancelled_by"`
+	CancelledAt          time.Time   `json:"cancelled_at" db:"cancelled_at"`
+}
+
+// NewOrderCancellation 注文キャンセル記録を作成
+func NewOrderCancellation(order *Order, policy *CancellationFeePolicy, reason, cancelledBy string) *OrderCancellation {
+	return &OrderCancellation{
+		ID:             uuid.New().String(),
+		TenantID:       order.TenantID,
+		OrderID:        order.ID,
+		Reason:         reason,
+		Stage:          order.Status,
+		FeePolicyCode:  policy.Code,
+		FeeRatePercent: policy.RatePercent,
+		OrderAmount:    order.TotalAmount,
+		FeeAmount:      policy.FeeFor(order.TotalAmount),
+		CancelledBy:    cancelledBy,
+		CancelledAt:    time.Now(),
+	}
+}
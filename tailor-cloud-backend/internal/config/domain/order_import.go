@@ -0,0 +1,32 @@
+package domain
+
+import "strings"
+
+// OrderImportFormat 注文一括取込のファイル形式
+type OrderImportFormat string
+
+const (
+	OrderImportFormatCSV  OrderImportFormat = "CSV"  // CSV（UTF-8 / Shift_JIS）
+	OrderImportFormatXLSX OrderImportFormat = "XLSX" // Excelブック（先頭シートのみ）
+)
+
+// IsValid ファイル形式が有効かチェック
+func (f OrderImportFormat) IsValid() bool {
+	return f == OrderImportFormatCSV || f == OrderImportFormatXLSX
+}
+
+// DetectOrderImportFormat ファイル名と内容からファイル形式を判定
+// XLSXはZIPアーカイブのため、先頭のシグネチャ（PK）で判定する
+func DetectOrderImportFormat(fileName string, data []byte) OrderImportFormat {
+	lower := strings.ToLower(fileName)
+	switch {
+	case strings.HasSuffix(lower, ".xlsx"):
+		return OrderImportFormatXLSX
+	case strings.HasSuffix(lower, ".csv"):
+		return OrderImportFormatCSV
+	case len(data) >= 4 && string(data[:4]) == "PK\x03\x04":
+		return OrderImportFormatXLSX
+	default:
+		return OrderImportFormatCSV
+	}
+}
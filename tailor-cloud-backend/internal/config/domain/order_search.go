@@ -0,0 +1,252 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// OrderSortField 注文検索の並び替え項目
+type OrderSortField string
+
+const (
+	OrderSortCreatedAt    OrderSortField = "created_at"    // 作成日時
+	OrderSortDeliveryDate OrderSortField = "delivery_date" // 納期
+	OrderSortTotalAmount  OrderSortField = "total_amount"  // 金額
+)
+
+// IsValid 並び替え項目が有効かチェック
+func (f OrderSortField) IsValid() bool {
+	switch f {
+	case OrderSortCreatedAt, OrderSortDeliveryDate, OrderSortTotalAmount:
+		return true
+	default:
+		return false
+	}
+}
+
+// SortOrder 並び順
+type SortOrder string
+
+const (
+	SortOrderAsc  SortOrder = "asc"
+	SortOrderDesc SortOrder = "desc"
+)
+
+// IsValid 並び順が有効かチェック
+func (o SortOrder) IsValid() bool {
+	return o == SortOrderAsc || o == SortOrderDesc
+}
+
+// OrderSearchFilter 注文検索条件
+// 空の条件は絞り込みに使用しない。並び替えは同値の場合に注文IDで順序を確定させる
+type OrderSearchFilter struct {
+	TenantID     string
+	Statuses     []OrderStatus
+	CustomerID   string
+	FabricID     string
+	CreatedBy    string
+	GroupOrderID string
+	DeliveryFrom *time.Time // 納期の開始（この日時を含む）
+	DeliveryTo   *time.Time // 納期の終了（この日時を含まない）
+	MinAmount    *int64     // 金額の下限（税抜、この金額を含む）
+	MaxAmount    *int64     // 金額の上限（税抜、この金額を含む）
+	PaymentDueBy *time.Time // 支払期日の上限（この日時を含む）
+	PostedFrom   *time.Time // 計上期間の開始（請求書発行日または納期がこの日時以降、この日時を含む）
+	PostedTo     *time.Time // 計上期間の終了（この日時を含まない）
+	SortBy       OrderSortField
+	SortOrder    SortOrder
+	Cursor       *OrderCursor // 前ページの最後の注文の位置（nilの場合は先頭から）
+	Limit        int
+}
+
+// Normalize 既定値を補完し、検索条件を検証
+func (f *OrderSearchFilter) Normalize() error {
+	if f.TenantID == "" {
+		return fmt.Errorf("tenant_id is required")
+	}
+	if f.SortBy == "" {
+		f.SortBy = OrderSortCreatedAt
+	}
+	if !f.SortBy.IsValid() {
+		return fmt.Errorf("invalid sort: %s", f.SortBy)
+	}
+	if f.SortOrder == "" {
+		f.SortOrder = SortOrderDesc
+	}
+	if !f.SortOrder.IsValid() {
+		return fmt.Errorf("invalid order: %s", f.SortOrder)
+	}
+	for _, status := range f.Statuses {
+		if !isKnownOrderStatus(status) {
+			return fmt.Errorf("invalid status: %s", status)
+		}
+	}
+	if f.DeliveryFrom != nil && f.DeliveryTo != nil && !f.DeliveryFrom.Before(*f.DeliveryTo) {
+		return fmt.Errorf("invalid delivery date range: delivery_from must be before delivery_to")
+	}
+	if f.PostedFrom != nil && f.PostedTo != nil && !f.PostedFrom.Before(*f.PostedTo) {
+		return fmt.Errorf("invalid posting period: from must be before to")
+	}
+	if f.MinAmount != nil && f.MaxAmount != nil && *f.MinAmount > *f.MaxAmount {
+		return fmt.Errorf("invalid amount range: min_amount must not exceed max_amount")
+	}
+	if f.Cursor != nil && (f.Cursor.SortBy != f.SortBy || f.Cursor.SortOrder != f.SortOrder) {
+		return fmt.Errorf("invalid cursor: sort order does not match")
+	}
+	pagination := NewPagination(1, f.Limit)
+	f.Limit = pagination.PageSize
+	return nil
+}
+
+// Matches 注文が検索条件（並び替え・カーソルを除く）に一致するか
+// データストア側で絞り込めない条件の判定に使用する
+func (f *OrderSearchFilter) Matches(order *Order) bool {
+	if order.TenantID != f.TenantID {
+		return false
+	}
+	if len(f.Statuses) > 0 {
+		matched := false
+		for _, status := range f.Statuses {
+			if order.Status == status {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if f.CustomerID != "" && order.CustomerID != f.CustomerID {
+		return false
+	}
+	if f.FabricID != "" && order.FabricID != f.FabricID {
+		return false
+	}
+	if f.CreatedBy != "" && order.CreatedBy != f.CreatedBy {
+		return false
+	}
+	if f.GroupOrderID != "" && order.GroupOrderID != f.GroupOrderID {
+		return false
+	}
+	if f.DeliveryFrom != nil && order.DeliveryDate.Before(*f.DeliveryFrom) {
+		return false
+	}
+	if f.DeliveryTo != nil && !order.DeliveryDate.Before(*f.DeliveryTo) {
+		return false
+	}
+	if f.MinAmount != nil && order.TotalAmount < *f.MinAmount {
+		return false
+	}
+	if f.MaxAmount != nil && order.TotalAmount > *f.MaxAmount {
+		return false
+	}
+	if f.PaymentDueBy != nil && order.PaymentDueDate.After(*f.PaymentDueBy) {
+		return false
+	}
+	if (f.PostedFrom != nil || f.PostedTo != nil) && !f.postedInPeriod(order.DeliveryDate) &&
+		(order.InvoiceIssuedAt == nil || !f.postedInPeriod(*order.InvoiceIssuedAt)) {
+		return false
+	}
+	return true
+}
+
+// postedInPeriod 日時が計上期間内か
+func (f *OrderSearchFilter) postedInPeriod(t time.Time) bool {
+	if f.PostedFrom != nil && t.Before(*f.PostedFrom) {
+		return false
+	}
+	if f.PostedTo != nil && !t.Before(*f.PostedTo) {
+		return false
+	}
+	return true
+}
+
+// isKnownOrderStatus 定義済みの注文ステータスか
+func isKnownOrderStatus(status OrderStatus) bool {
+	switch status {
+	case OrderStatusDraft, OrderStatusConfirmed, OrderStatusMaterialSecured, OrderStatusCutting,
+		OrderStatusSewing, OrderStatusInspection, OrderStatusShipped, OrderStatusDelivered,
+		OrderStatusPaid, OrderStatusCancelled:
+		return true
+	default:
+		return false
+	}
+}
+
+// OrderCursor 注文検索のカーソル（前ページの最後の注文の並び替えキー）
+// クライアントには不透明な文字列として渡す
+type OrderCursor struct {
+	SortBy    OrderSortField `json:"s"`
+	SortOrder SortOrder      `json:"o"`
+	Value     string         `json:"v"` // 並び替え項目の値（日時はRFC3339Nano、金額は整数）
+	OrderID   string         `json:"id"`
+}
+
+// NewOrderCursor 注文の位置を表すカーソルを作成
+func NewOrderCursor(order *Order, sortBy OrderSortField, sortOrder SortOrder) *OrderCursor {
+	cursor := &OrderCursor{SortBy: sortBy, SortOrder: sortOrder, OrderID: order.ID}
+	switch sortBy {
+	case OrderSortDeliveryDate:
+		cursor.Value = order.DeliveryDate.UTC().Format(time.RFC3339Nano)
+	case OrderSortTotalAmount:
+		cursor.Value = strconv.FormatInt(order.TotalAmount, 10)
+	default:
+		cursor.Value = order.CreatedAt.UTC().Format(time.RFC3339Nano)
+	}
+	return cursor
+}
+
+// Encode カーソルを文字列に変換
+func (c *OrderCursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeOrderCursor 文字列からカーソルを復元
+func DecodeOrderCursor(s string) (*OrderCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+	var cursor OrderCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+	if !cursor.SortBy.IsValid() || !cursor.SortOrder.IsValid() || cursor.OrderID == "" {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+	if _, err := cursor.SortValue(); err != nil {
+		return nil, err
+	}
+	return &cursor, nil
+}
+
+// SortValue 並び替え項目の値を型付きで取得（time.Time または int64）
+func (c *OrderCursor) SortValue() (interface{}, error) {
+	if c.SortBy == OrderSortTotalAmount {
+		amount, err := strconv.ParseInt(c.Value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cursor: %w", err)
+		}
+		return amount, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, c.Value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+	return t, nil
+}
+
+// NewCursorPagination カーソル方式のページネーション情報を作成
+// カーソル方式ではPageは使用しない（常に0）
+func NewCursorPagination(pageSize, total int, nextCursor string) Pagination {
+	return Pagination{
+		PageSize:   pageSize,
+		Total:      total,
+		NextCursor: nextCursor,
+		HasMore:    nextCursor != "",
+	}
+}
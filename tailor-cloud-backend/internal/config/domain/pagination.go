@@ -5,6 +5,10 @@ type Pagination struct {
 	Page     int `json:"page"`     // 現在のページ（1始まり）
 	PageSize int `json:"page_size"` // 1ページあたりの件数
 	Total    int `json:"total"`    // 全件数
+
+	// カーソル方式のページネーション（注文検索など）
+	NextCursor string `json:"next_cursor,omitempty"` // 次ページ取得用のカーソル
+	HasMore    bool   `json:"has_more"`              // 次ページがあるか
 }
 
 // PaginatedResponse ページネーション付きレスポンス
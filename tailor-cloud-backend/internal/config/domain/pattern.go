@@ -0,0 +1,561 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// PatternMaterial 型紙パーツの素材区分（裁断する生地）
+type PatternMaterial string
+
+const (
+	PatternMaterialShell     PatternMaterial = "SHELL"     // 表地
+	PatternMaterialLining    PatternMaterial = "LINING"    // 裏地
+	PatternMaterialInterface PatternMaterial = "INTERFACE" // 芯地
+)
+
+// patternMaxGradeRatio 基本ブロックからのグレーディング量の上限（基本寸法に対する比率）
+// これを超える場合は基本ブロックが体型に合わないため、手作業での型紙作成が必要
+const patternMaxGradeRatio = 0.25
+
+// patternMeasurementLabels 型紙に使う仕上がり寸法の項目名（FinalMeasurement のJSONキー）
+var patternMeasurementLabels = map[string]string{
+	"jacket_length":  "着丈",
+	"sleeve_length":  "袖丈",
+	"shoulder_width": "肩幅",
+	"chest":          "胸囲",
+	"half_chest":     "身幅",
+	"waist":          "ウエスト",
+	"hip":            "ヒップ",
+	"thigh":          "わたり",
+	"knee":           "膝",
+	"calf":           "ふくらはぎ",
+	"hem":            "裾幅",
+	"rise":           "股上",
+	"inseam":         "股下",
+	"neck":           "首回り",
+	"front_balance":  "前丈バランス",
+	"back_balance":   "後丈バランス",
+	"shoulder_slope": "肩傾斜",
+	"seat":           "尻ぐせ",
+	"belly":          "腹ぐせ",
+}
+
+// patternCorrectionKeys 体型補正でのみ使う型紙の項目（基本寸法を0とした加減、cm）
+// 仕上がり寸法には含まれず、体型補正（FitCorrection）から設定する
+var patternCorrectionKeys = map[string]bool{
+	"front_balance":  true,
+	"back_balance":   true,
+	"shoulder_slope": true,
+	"seat":           true,
+	"belly":          true,
+}
+
+// PatternMeasurementKeys 仕上がり寸法の項目の表示順（仕様書用）
+var PatternMeasurementKeys = []string{
+	"jacket_length", "sleeve_length", "shoulder_width", "chest", "half_chest",
+	"waist", "hip", "thigh", "knee", "calf", "hem", "rise", "inseam", "neck",
+	"front_balance", "back_balance", "shoulder_slope", "seat", "belly",
+}
+
+// PatternMeasurementLabel 仕上がり寸法の項目名（未定義の項目はキーをそのまま返す）
+func PatternMeasurementLabel(key string) string {
+	if label, ok := patternMeasurementLabels[key]; ok {
+		return label
+	}
+	return key
+}
+
+// IsPatternMeasurement 型紙に使う仕上がり寸法の項目か
+func IsPatternMeasurement(key string) bool {
+	_, ok := patternMeasurementLabels[key]
+	return ok
+}
+
+// IsPatternCorrection 体型補正でのみ使う型紙の項目か
+func IsPatternCorrection(key string) bool {
+	return patternCorrectionKeys[key]
+}
+
+// PatternGradeRule グレーディングルール（仕上がり寸法が基本寸法から1cm変わったときの点の移動量、cm）
+type PatternGradeRule struct {
+	Key string  `json:"key"` // 仕上がり寸法の項目（FinalMeasurement のJSONキー）
+	DX  float64 `json:"dx"`
+	DY  float64 `json:"dy"`
+}
+
+// PatternBlockPoint 基本ブロックの点（cm、原点は左上・Yは下向き）
+type PatternBlockPoint struct {
+	X     float64            `json:"x"`
+	Y     float64            `json:"y"`
+	Curve bool               `json:"curve,omitempty"` // カーブ点（falseは角・ターンポイント）
+	Notch bool               `json:"notch,omitempty"` // 合印
+	Rules []PatternGradeRule `json:"rules,omitempty"`
+}
+
+// PatternBlockPiece 基本ブロックの型紙パーツ
+type PatternBlockPiece struct {
+	Code      string               `json:"code"`
+	Name      string               `json:"name"`
+	Quantity  int                  `json:"quantity"` // 裁断枚数
+	Material  PatternMaterial      `json:"material"`
+	Outline   []PatternBlockPoint  `json:"outline"`   // 出来上がり線（時計回り、閉じた多角形）
+	Grainline [2]PatternBlockPoint `json:"grainline"` // 地の目線
+}
+
+// PatternBlock 品目ごとの基本ブロック（基本寸法で作図した型紙一式）
+type PatternBlock struct {
+	Code             string               `json:"code"`
+	GarmentType      GarmentType          `json:"garment_type"`
+	BaseMeasurements map[string]float64   `json:"base_measurements"` // 基本寸法（仕上がり寸法）
+	Pieces           []*PatternBlockPiece `json:"pieces"`
+}
+
+// PatternPoint グレーディング後の点（cm）
+type PatternPoint struct {
+	X     float64 `json:"x"`
+	Y     float64 `json:"y"`
+	Curve bool    `json:"curve,omitempty"`
+	Notch bool    `json:"notch,omitempty"`
+}
+
+// PatternPiece グレーディング後の型紙パーツ
+type PatternPiece struct {
+	Code      string          `json:"code"`
+	Name      string          `json:"name"`
+	BlockCode string          `json:"block_code"`
+	Quantity  int             `json:"quantity"`
+	Material  PatternMaterial `json:"material"`
+	Outline   []PatternPoint  `json:"outline"`
+	Grainline [2]PatternPoint `json:"grainline"`
+	Width     float64         `json:"width"`  // 外接矩形の幅（cm）
+	Height    float64         `json:"height"` // 外接矩形の高さ（cm）
+}
+
+// GradedPattern 注文の仕上がり寸法でグレーディングした型紙
+type GradedPattern struct {
+	OrderID             string             `json:"order_id"`
+	GarmentType         GarmentType        `json:"garment_type"`
+	Measurements        map[string]float64 `json:"measurements"`         // グレーディングに使った仕上がり寸法（フィット補正込み）
+	Adjustments         map[string]float64 `json:"adjustments"`          // フィット補正（仕上がり寸法への加減、cm）
+	Grades              map[string]float64 `json:"grades"`               // 基本寸法からのグレーディング量（cm）
+	MissingMeasurements []string           `json:"missing_measurements"` // 仕上がり寸法がなく基本寸法のままの項目
+	Pieces              []*PatternPiece    `json:"pieces"`
+	GeneratedAt         time.Time          `json:"generated_at"`
+}
+
+// NewGradedPattern 仕上がり寸法とフィット補正から品目の基本ブロックをグレーディング
+func NewGradedPattern(orderID string, garmentType GarmentType, measurements, adjustments map[string]float64) (*GradedPattern, error) {
+	if garmentType == "" {
+		garmentType = GarmentTypeSuit
+	}
+	blocks := PatternBlocksFor(garmentType)
+	if len(blocks) == 0 {
+		return nil, fmt.Errorf("invalid garment type: %s", garmentType)
+	}
+
+	// フィット補正を仕上がり寸法に反映
+	values := make(map[string]float64, len(measurements))
+	for key, value := range measurements {
+		if IsPatternMeasurement(key) && value > 0 {
+			values[key] = value
+		}
+	}
+	applied := make(map[string]float64)
+	for key, amount := range adjustments {
+		if !IsPatternMeasurement(key) {
+			return nil, fmt.Errorf("invalid adjustment: unknown measurement %s", key)
+		}
+		if amount == 0 {
+			continue
+		}
+		// 体型補正の項目は基本寸法0からの加減のため、仕上がり寸法がなくてもよい
+		if _, ok := values[key]; !ok && !IsPatternCorrection(key) {
+			return nil, fmt.Errorf("invalid adjustment: no final measurement for %s", key)
+		}
+		values[key] = roundPatternValue(values[key] + amount)
+		applied[key] = amount
+	}
+
+	pattern := &GradedPattern{
+		OrderID:             orderID,
+		GarmentType:         garmentType,
+		Measurements:        values,
+		Adjustments:         applied,
+		Grades:              make(map[string]float64),
+		MissingMeasurements: []string{},
+		GeneratedAt:         time.Now(),
+	}
+	missing := make(map[string]bool)
+	for _, block := range blocks {
+		for key, base := range block.BaseMeasurements {
+			value, ok := values[key]
+			if !ok {
+				missing[key] = true
+				continue
+			}
+			grade := roundPatternValue(value - base)
+			if math.Abs(grade) > base*patternMaxGradeRatio {
+				return nil, fmt.Errorf("invalid measurements: %s %.1fcm is too far from the base block (%.1fcm)", PatternMeasurementLabel(key), value, base)
+			}
+			pattern.Grades[key] = grade
+		}
+		pattern.Pieces = append(pattern.Pieces, block.Grade(values)...)
+	}
+	for key := range patternCorrectionKeys {
+		if value, ok := values[key]; ok {
+			pattern.Grades[key] = value
+		}
+	}
+	for key := range missing {
+		pattern.MissingMeasurements = append(pattern.MissingMeasurements, key)
+	}
+	sort.Strings(pattern.MissingMeasurements)
+	return pattern, nil
+}
+
+// Grade 仕上がり寸法で基本ブロックの型紙パーツをグレーディング
+// 仕上がり寸法がない項目は基本寸法のまま、体型補正の項目は補正量をそのまま移動量に使う
+func (b *PatternBlock) Grade(measurements map[string]float64) []*PatternPiece {
+	grades := make(map[string]float64, len(b.BaseMeasurements))
+	for key, base := range b.BaseMeasurements {
+		if value, ok := measurements[key]; ok {
+			grades[key] = value - base
+		}
+	}
+	for key := range patternCorrectionKeys {
+		if value, ok := measurements[key]; ok {
+			grades[key] = value
+		}
+	}
+	gradePoint := func(p PatternBlockPoint) PatternPoint {
+		x, y := p.X, p.Y
+		for _, rule := range p.Rules {
+			x += rule.DX * grades[rule.Key]
+			y += rule.DY * grades[rule.Key]
+		}
+		return PatternPoint{X: roundPatternValue(x), Y: roundPatternValue(y), Curve: p.Curve, Notch: p.Notch}
+	}
+
+	pieces := make([]*PatternPiece, 0, len(b.Pieces))
+	for _, blockPiece := range b.Pieces {
+		piece := &PatternPiece{
+			Code:      blockPiece.Code,
+			Name:      blockPiece.Name,
+			BlockCode: b.Code,
+			Quantity:  blockPiece.Quantity,
+			Material:  blockPiece.Material,
+			Outline:   make([]PatternPoint, 0, len(blockPiece.Outline)),
+			Grainline: [2]PatternPoint{gradePoint(blockPiece.Grainline[0]), gradePoint(blockPiece.Grainline[1])},
+		}
+		minX, minY, maxX, maxY := math.Inf(1), math.Inf(1), math.Inf(-1), math.Inf(-1)
+		for _, blockPoint := range blockPiece.Outline {
+			p := gradePoint(blockPoint)
+			piece.Outline = append(piece.Outline, p)
+			minX, maxX = math.Min(minX, p.X), math.Max(maxX, p.X)
+			minY, maxY = math.Min(minY, p.Y), math.Max(maxY, p.Y)
+		}
+		if len(piece.Outline) > 0 {
+			piece.Width = roundPatternValue(maxX - minX)
+			piece.Height = roundPatternValue(maxY - minY)
+		}
+		pieces = append(pieces, piece)
+	}
+	return pieces
+}
+
+// PatternBlocksFor 品目の基本ブロック（スーツはジャケットとパンツ）
+func PatternBlocksFor(garmentType GarmentType) []*PatternBlock {
+	switch garmentType {
+	case GarmentTypeSuit:
+		return []*PatternBlock{jacketPatternBlock(GarmentTypeJacket, "JACKET", 0), trousersPatternBlock()}
+	case GarmentTypeJacket:
+		return []*PatternBlock{jacketPatternBlock(GarmentTypeJacket, "JACKET", 0)}
+	case GarmentTypeCoat:
+		// コートはジャケットのブロックを20cm長く作図（着丈のグレーディングはジャケットと共通）
+		return []*PatternBlock{jacketPatternBlock(GarmentTypeCoat, "COAT", 20)}
+	case GarmentTypeTrousers:
+		return []*PatternBlock{trousersPatternBlock()}
+	case GarmentTypeVest:
+		return []*PatternBlock{vestPatternBlock()}
+	case GarmentTypeShirt:
+		return []*PatternBlock{shirtPatternBlock()}
+	default:
+		return nil
+	}
+}
+
+// bp 基本ブロックの点（角）
+func bp(x, y float64, rules ...PatternGradeRule) PatternBlockPoint {
+	return PatternBlockPoint{X: x, Y: y, Rules: rules}
+}
+
+// bc 基本ブロックの点（カーブ）
+func bc(x, y float64, rules ...PatternGradeRule) PatternBlockPoint {
+	return PatternBlockPoint{X: x, Y: y, Curve: true, Rules: rules}
+}
+
+// bn 基本ブロックの点（合印つきの角）
+func bn(x, y float64, rules ...PatternGradeRule) PatternBlockPoint {
+	return PatternBlockPoint{X: x, Y: y, Notch: true, Rules: rules}
+}
+
+// gr グレーディングルール
+func gr(key string, dx, dy float64) PatternGradeRule {
+	return PatternGradeRule{Key: key, DX: dx, DY: dy}
+}
+
+// jacketPatternBlock ジャケット（コート）の基本ブロック
+// 身幅のグレーディングは後身頃4割・前身頃（脇身込み）6割で配分
+func jacketPatternBlock(garmentType GarmentType, code string, extraLength float64) *PatternBlock {
+	hem := 75.0 + extraLength
+	return &PatternBlock{
+		Code:        code,
+		GarmentType: garmentType,
+		BaseMeasurements: map[string]float64{
+			"half_chest":     51.0,
+			"jacket_length":  75.0,
+			"shoulder_width": 45.0,
+			"sleeve_length":  61.0,
+		},
+		Pieces: []*PatternBlockPiece{
+			{
+				Code: code + "-BACK", Name: "後身頃", Quantity: 2, Material: PatternMaterialShell,
+				Outline: []PatternBlockPoint{
+					bp(0, 0, gr("back_balance", 0, -1)),
+					bc(8.5, -2.2, gr("back_balance", 0, -1)),
+					bp(22.5, 2.5, gr("shoulder_width", 0.5, 0), gr("back_balance", 0, -1), gr("shoulder_slope", 0, -1)),
+					bc(21.5, 14, gr("shoulder_width", 0.5, 0), gr("half_chest", 0.2, 0.1)),
+					bn(24.5, 24, gr("half_chest", 0.4, 0.1)),
+					bn(23, 44, gr("half_chest", 0.4, 0), gr("jacket_length", 0, 0.4)),
+					bp(24.5, hem, gr("half_chest", 0.4, 0), gr("jacket_length", 0, 1)),
+					bp(1, hem, gr("jacket_length", 0, 1)),
+					bc(1.5, 44, gr("jacket_length", 0, 0.4)),
+				},
+				Grainline: [2]PatternBlockPoint{bp(10, 15), bp(10, hem-15, gr("jacket_length", 0, 1))},
+			},
+			{
+				Code: code + "-FRONT", Name: "前身頃", Quantity: 2, Material: PatternMaterialShell,
+				Outline: []PatternBlockPoint{
+					bp(0, 8, gr("front_balance", 0, -1)),
+					bc(8, -2, gr("front_balance", 0, -1)),
+					bp(22, 3, gr("shoulder_width", 0.5, 0), gr("front_balance", 0, -1), gr("shoulder_slope", 0, -1)),
+					bc(25, 16, gr("shoulder_width", 0.3, 0), gr("half_chest", 0.3, 0.1)),
+					bn(30, 24, gr("half_chest", 0.6, 0.1)),
+					bn(29, 44, gr("half_chest", 0.6, 0), gr("jacket_length", 0, 0.4)),
+					bp(31, hem, gr("half_chest", 0.6, 0), gr("jacket_length", 0, 1)),
+					bc(0, hem+2, gr("jacket_length", 0, 1)),
+					bn(0, 44, gr("jacket_length", 0, 0.4)),
+				},
+				Grainline: [2]PatternBlockPoint{bp(12, 15), bp(12, hem-15, gr("jacket_length", 0, 1))},
+			},
+			{
+				Code: code + "-TOP-SLEEVE", Name: "外袖", Quantity: 2, Material: PatternMaterialShell,
+				Outline: []PatternBlockPoint{
+					bn(0, 12),
+					bc(18, 0, gr("half_chest", 0.15, 0)),
+					bn(36, 10, gr("half_chest", 0.3, 0)),
+					bp(32, 61, gr("half_chest", 0.2, 0), gr("sleeve_length", 0, 1)),
+					bp(4, 61, gr("sleeve_length", 0, 1)),
+				},
+				Grainline: [2]PatternBlockPoint{bp(18, 5, gr("half_chest", 0.15, 0)), bp(18, 55, gr("half_chest", 0.15, 0), gr("sleeve_length", 0, 1))},
+			},
+			{
+				Code: code + "-UNDER-SLEEVE", Name: "内袖", Quantity: 2, Material: PatternMaterialShell,
+				Outline: []PatternBlockPoint{
+					bn(0, 12),
+					bc(24, 8, gr("half_chest", 0.2, 0)),
+					bp(22, 61, gr("half_chest", 0.1, 0), gr("sleeve_length", 0, 1)),
+					bp(3, 61, gr("sleeve_length", 0, 1)),
+				},
+				Grainline: [2]PatternBlockPoint{bp(12, 14), bp(12, 55, gr("sleeve_length", 0, 1))},
+			},
+			{
+				Code: code + "-LINING-BODY", Name: "胴裏", Quantity: 2, Material: PatternMaterialLining,
+				Outline: []PatternBlockPoint{
+					bp(0, 0, gr("back_balance", 0, -1)),
+					bp(24.5, 2.5, gr("shoulder_width", 0.5, 0), gr("back_balance", 0, -1), gr("shoulder_slope", 0, -1)),
+					bn(26, 24, gr("half_chest", 0.4, 0.1)),
+					bp(25.5, hem-1, gr("half_chest", 0.4, 0), gr("jacket_length", 0, 1)),
+					bp(0, hem-1, gr("jacket_length", 0, 1)),
+				},
+				Grainline: [2]PatternBlockPoint{bp(10, 15), bp(10, hem-15, gr("jacket_length", 0, 1))},
+			},
+		},
+	}
+}
+
+// trousersPatternBlock パンツの基本ブロック
+// ウエスト・ヒップは前後それぞれ1/4、裾幅は前後パンツとも左右に1/2ずつ配分
+func trousersPatternBlock() *PatternBlock {
+	return &PatternBlock{
+		Code:        "TROUSERS",
+		GarmentType: GarmentTypeTrousers,
+		BaseMeasurements: map[string]float64{
+			"waist":  84.0,
+			"hip":    100.0,
+			"rise":   27.0,
+			"inseam": 78.0,
+			"hem":    21.0,
+		},
+		Pieces: []*PatternBlockPiece{
+			{
+				Code: "TROUSERS-FRONT", Name: "前パンツ", Quantity: 2, Material: PatternMaterialShell,
+				Outline: []PatternBlockPoint{
+					bp(0, 0, gr("belly", 0, -1)),
+					bp(21, 0, gr("waist", 0.25, 0)),
+					bn(24, 18, gr("hip", 0.25, 0), gr("rise", 0, 0.7)),
+					bn(22, 62, gr("hip", 0.1, 0), gr("hem", 0.5, 0), gr("rise", 0, 1), gr("inseam", 0, 0.45)),
+					bp(20.5, 105, gr("hem", 0.5, 0), gr("rise", 0, 1), gr("inseam", 0, 1)),
+					bp(0.5, 105, gr("hem", -0.5, 0), gr("rise", 0, 1), gr("inseam", 0, 1)),
+					bn(1, 62, gr("hem", -0.5, 0), gr("rise", 0, 1), gr("inseam", 0, 0.45)),
+					bp(-4.5, 27, gr("hip", -0.05, 0), gr("rise", 0, 1)),
+					bc(0, 18, gr("rise", 0, 0.7)),
+				},
+				Grainline: [2]PatternBlockPoint{bp(10.5, 10), bp(10.5, 95, gr("rise", 0, 1), gr("inseam", 0, 1))},
+			},
+			{
+				Code: "TROUSERS-BACK", Name: "後パンツ", Quantity: 2, Material: PatternMaterialShell,
+				Outline: []PatternBlockPoint{
+					bp(2.5, -2.5, gr("seat", 0.3, -1)),
+					bp(24, 0, gr("waist", 0.25, 0)),
+					bn(27, 18, gr("hip", 0.25, 0), gr("rise", 0, 0.7)),
+					bn(24.5, 62, gr("hip", 0.1, 0), gr("hem", 0.5, 0), gr("rise", 0, 1), gr("inseam", 0, 0.45)),
+					bp(22.5, 105, gr("hem", 0.5, 0), gr("rise", 0, 1), gr("inseam", 0, 1)),
+					bp(0.5, 105, gr("hem", -0.5, 0), gr("rise", 0, 1), gr("inseam", 0, 1)),
+					bn(0, 62, gr("hem", -0.5, 0), gr("rise", 0, 1), gr("inseam", 0, 0.45)),
+					bp(-9, 28, gr("hip", -0.1, 0), gr("rise", 0, 1), gr("seat", -0.5, 0)),
+					bc(-1, 18, gr("rise", 0, 0.7)),
+				},
+				Grainline: [2]PatternBlockPoint{bp(11.5, 10), bp(11.5, 95, gr("rise", 0, 1), gr("inseam", 0, 1))},
+			},
+			{
+				Code: "TROUSERS-WAISTBAND", Name: "ベルト", Quantity: 1, Material: PatternMaterialShell,
+				Outline: []PatternBlockPoint{
+					bp(0, 0),
+					bp(90, 0, gr("waist", 1, 0)),
+					bp(90, 8, gr("waist", 1, 0)),
+					bp(0, 8),
+				},
+				Grainline: [2]PatternBlockPoint{bp(5, 4), bp(85, 4, gr("waist", 1, 0))},
+			},
+		},
+	}
+}
+
+// vestPatternBlock ベストの基本ブロック（丈は着丈から15cm短い位置で作図）
+func vestPatternBlock() *PatternBlock {
+	return &PatternBlock{
+		Code:        "VEST",
+		GarmentType: GarmentTypeVest,
+		BaseMeasurements: map[string]float64{
+			"half_chest":    51.0,
+			"jacket_length": 75.0,
+		},
+		Pieces: []*PatternBlockPiece{
+			{
+				Code: "VEST-FRONT", Name: "前身頃", Quantity: 2, Material: PatternMaterialShell,
+				Outline: []PatternBlockPoint{
+					bp(0, 22, gr("front_balance", 0, -1)),
+					bp(8, -2, gr("front_balance", 0, -1)),
+					bc(14, 20, gr("half_chest", 0.2, 0.1)),
+					bn(27, 27, gr("half_chest", 0.55, 0.1)),
+					bp(26, 56, gr("half_chest", 0.55, 0), gr("jacket_length", 0, 0.8)),
+					bp(0, 62, gr("jacket_length", 0, 0.8)),
+				},
+				Grainline: [2]PatternBlockPoint{bp(10, 25), bp(10, 55, gr("jacket_length", 0, 0.8))},
+			},
+			{
+				Code: "VEST-BACK", Name: "後身頃", Quantity: 2, Material: PatternMaterialLining,
+				Outline: []PatternBlockPoint{
+					bp(0, 0, gr("back_balance", 0, -1)),
+					bp(8, -2, gr("back_balance", 0, -1)),
+					bc(18, 18, gr("half_chest", 0.2, 0.1)),
+					bn(23, 27, gr("half_chest", 0.45, 0.1)),
+					bp(22, 58, gr("half_chest", 0.45, 0), gr("jacket_length", 0, 0.8)),
+					bp(1, 58, gr("jacket_length", 0, 0.8)),
+				},
+				Grainline: [2]PatternBlockPoint{bp(10, 10), bp(10, 50, gr("jacket_length", 0, 0.8))},
+			},
+		},
+	}
+}
+
+// shirtPatternBlock シャツの基本ブロック（衿は首回りの長さで作図）
+func shirtPatternBlock() *PatternBlock {
+	return &PatternBlock{
+		Code:        "SHIRT",
+		GarmentType: GarmentTypeShirt,
+		BaseMeasurements: map[string]float64{
+			"half_chest":     51.0,
+			"jacket_length":  75.0,
+			"shoulder_width": 45.0,
+			"sleeve_length":  61.0,
+			"neck":           40.0,
+		},
+		Pieces: []*PatternBlockPiece{
+			{
+				Code: "SHIRT-FRONT", Name: "前身頃", Quantity: 2, Material: PatternMaterialShell,
+				Outline: []PatternBlockPoint{
+					bp(0, 7, gr("neck", 0, 0.15), gr("front_balance", 0, -1)),
+					bc(7, 0, gr("neck", 0.15, 0), gr("front_balance", 0, -1)),
+					bp(22, 4, gr("shoulder_width", 0.5, 0), gr("front_balance", 0, -1), gr("shoulder_slope", 0, -1)),
+					bn(27, 25, gr("half_chest", 0.5, 0.1)),
+					bp(27, 78, gr("half_chest", 0.5, 0), gr("jacket_length", 0, 1)),
+					bc(0, 82, gr("jacket_length", 0, 1)),
+				},
+				Grainline: [2]PatternBlockPoint{bp(12, 15), bp(12, 70, gr("jacket_length", 0, 1))},
+			},
+			{
+				Code: "SHIRT-BACK", Name: "後身頃", Quantity: 1, Material: PatternMaterialShell,
+				Outline: []PatternBlockPoint{
+					bp(0, 0, gr("back_balance", 0, -1), gr("shoulder_slope", 0, -1)),
+					bp(45, 0, gr("shoulder_width", 1, 0), gr("back_balance", 0, -1), gr("shoulder_slope", 0, -1)),
+					bn(54, 22, gr("half_chest", 1, 0.1)),
+					bp(54, 82, gr("half_chest", 1, 0), gr("jacket_length", 0, 1)),
+					bp(0, 82, gr("jacket_length", 0, 1)),
+				},
+				Grainline: [2]PatternBlockPoint{bp(27, 10, gr("half_chest", 0.5, 0)), bp(27, 75, gr("half_chest", 0.5, 0), gr("jacket_length", 0, 1))},
+			},
+			{
+				Code: "SHIRT-SLEEVE", Name: "袖", Quantity: 2, Material: PatternMaterialShell,
+				Outline: []PatternBlockPoint{
+					bn(0, 14),
+					bc(22, 0, gr("half_chest", 0.2, 0)),
+					bn(44, 14, gr("half_chest", 0.4, 0)),
+					bp(35, 56, gr("half_chest", 0.3, 0), gr("sleeve_length", 0, 1)),
+					bp(9, 56, gr("half_chest", 0.1, 0), gr("sleeve_length", 0, 1)),
+				},
+				Grainline: [2]PatternBlockPoint{bp(22, 6, gr("half_chest", 0.2, 0)), bp(22, 50, gr("half_chest", 0.2, 0), gr("sleeve_length", 0, 1))},
+			},
+			{
+				Code: "SHIRT-COLLAR", Name: "衿", Quantity: 2, Material: PatternMaterialShell,
+				Outline: []PatternBlockPoint{
+					bp(0, 0),
+					bn(20, 0, gr("neck", 0.5, 0)),
+					bp(40, 0, gr("neck", 1, 0)),
+					bp(40, 4, gr("neck", 1, 0)),
+					bp(0, 4),
+				},
+				Grainline: [2]PatternBlockPoint{bp(3, 2), bp(37, 2, gr("neck", 1, 0))},
+			},
+			{
+				Code: "SHIRT-COLLAR-INTERFACE", Name: "衿芯", Quantity: 1, Material: PatternMaterialInterface,
+				Outline: []PatternBlockPoint{
+					bp(0, 0),
+					bp(40, 0, gr("neck", 1, 0)),
+					bp(40, 4, gr("neck", 1, 0)),
+					bp(0, 4),
+				},
+				Grainline: [2]PatternBlockPoint{bp(3, 2), bp(37, 2, gr("neck", 1, 0))},
+			},
+		},
+	}
+}
+
+// roundPatternValue 型紙の寸法を0.1mm単位に丸める
+func roundPatternValue(value float64) float64 {
+	return math.Round(value*100) / 100
+}
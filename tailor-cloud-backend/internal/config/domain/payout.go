@@ -0,0 +1,189 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BankAccount 振込用の銀行口座
+// 振込元（自社）・工場・アンバサダーの口座を同一テーブルで管理する
+type BankAccount struct {
+	ID                string           `json:"id" db:"id"`
+	TenantID          string           `json:"tenant_id" db:"tenant_id"`
+	OwnerType         BankAccountOwner `json:"owner_type" db:"owner_type"`
+	OwnerID           string           `json:"owner_id" db:"owner_id"`                       // 工場テナントID / アンバサダーID（自社口座の場合はテナントID）
+	BankCode          string           `json:"bank_code" db:"bank_code"`                     // 金融機関コード（4桁）
+	BankNameKana      string           `json:"bank_name_kana" db:"bank_name_kana"`           // 金融機関名（半角カナ）
+	BranchCode        string           `json:"branch_code" db:"branch_code"`                 // 支店コード（3桁）
+	BranchNameKana    string           `json:"branch_name_kana" db:"branch_name_kana"`       // 支店名（半角カナ）
+	AccountType       BankAccountType  `json:"account_type" db:"account_type"`               // 預金種目
+	AccountNumber     string           `json:"account_number" db:"account_number"`           // 口座番号（7桁）
+	AccountHolderKana string           `json:"account_holder_kana" db:"account_holder_kana"` // 口座名義（半角カナ）
+	ClientCode        string           `json:"client_code,omitempty" db:"client_code"`       // 振込依頼人コード（委託者コード、自社口座のみ）
+	IsDefault         bool             `json:"is_default" db:"is_default"`
+	CreatedAt         time.Time        `json:"created_at" db:"created_at"`
+	UpdatedAt         time.Time        `json:"updated_at" db:"updated_at"`
+}
+
+// BankAccountOwner 口座の所有者種別
+type BankAccountOwner string
+
+const (
+	BankAccountOwnerTenant     BankAccountOwner = "TENANT"     // 自社（振込元）
+	BankAccountOwnerFactory    BankAccountOwner = "FACTORY"    // 縫製工場（支払先）
+	BankAccountOwnerAmbassador BankAccountOwner = "AMBASSADOR" // アンバサダー（支払先）
+)
+
+// IsValid 所有者種別が有効かチェック
+func (o BankAccountOwner) IsValid() bool {
+	switch o {
+	case BankAccountOwnerTenant, BankAccountOwnerFactory, BankAccountOwnerAmbassador:
+		return true
+	default:
+		return false
+	}
+}
+
+// BankAccountType 預金種目（全銀協コード）
+type BankAccountType string
+
+const (
+	BankAccountTypeOrdinary BankAccountType = "1" // 普通
+	BankAccountTypeChecking BankAccountType = "2" // 当座
+	BankAccountTypeSavings  BankAccountType = "4" // 貯蓄
+)
+
+// IsValid 預金種目が有効かチェック
+func (t BankAccountType) IsValid() bool {
+	switch t {
+	case BankAccountTypeOrdinary, BankAccountTypeChecking, BankAccountTypeSavings:
+		return true
+	default:
+		return false
+	}
+}
+
+// NewBankAccount 新しい銀行口座を作成
+func NewBankAccount(tenantID string, ownerType BankAccountOwner, ownerID string) *BankAccount {
+	now := time.Now()
+	return &BankAccount{
+		ID:          uuid.New().String(),
+		TenantID:    tenantID,
+		OwnerType:   ownerType,
+		OwnerID:     ownerID,
+		AccountType: BankAccountTypeOrdinary,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// PayoutBatch 支払バッチ（総合振込1ファイル分）
+type PayoutBatch struct {
+	ID           string            `json:"id" db:"id"`
+	TenantID     string            `json:"tenant_id" db:"tenant_id"`
+	DueBy        time.Time         `json:"due_by" db:"due_by"`               // 対象とする支払期日（この日までに期日が来るもの）
+	TransferDate time.Time         `json:"transfer_date" db:"transfer_date"` // 振込指定日
+	Status       PayoutBatchStatus `json:"status" db:"status"`
+	ItemCount    int               `json:"item_count" db:"item_count"`
+	TotalAmount  int64             `json:"total_amount" db:"total_amount"` // 振込合計金額（円）
+	FileHash     string            `json:"file_hash" db:"file_hash"`       // 振込ファイルのSHA256（改ざん検知）
+	CreatedBy    string            `json:"created_by" db:"created_by"`
+	ConfirmedBy  string            `json:"confirmed_by,omitempty" db:"confirmed_by"`
+	ConfirmedAt  *time.Time        `json:"confirmed_at,omitempty" db:"confirmed_at"`
+	Items        []*PayoutItem     `json:"items,omitempty" db:"-"`
+	CreatedAt    time.Time         `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time         `json:"updated_at" db:"updated_at"`
+}
+
+// PayoutBatchStatus 支払バッチステータス
+type PayoutBatchStatus string
+
+const (
+	PayoutBatchStatusScheduled PayoutBatchStatus = "SCHEDULED" // 振込予定（ファイル作成済み）
+	PayoutBatchStatusPaid      PayoutBatchStatus = "PAID"      // 振込完了
+	PayoutBatchStatusCancelled PayoutBatchStatus = "CANCELLED" // 取消
+)
+
+// PayoutItem 支払明細（振込1件分）
+type PayoutItem struct {
+	ID                string           `json:"id" db:"id"`
+	BatchID           string           `json:"batch_id" db:"batch_id"`
+	TenantID          string           `json:"tenant_id" db:"tenant_id"`
+	ItemType          PayoutItemType   `json:"item_type" db:"item_type"`
+	ReferenceID       string           `json:"reference_id" db:"reference_id"` // 注文ID / 成果報酬ID
+	DueDate           time.Time        `json:"due_date" db:"due_date"`
+	Amount            int64            `json:"amount" db:"amount"` // 振込金額（円）
+	BankAccountID     string           `json:"bank_account_id" db:"bank_account_id"`
+	BankCode          string           `json:"bank_code" db:"bank_code"` // 以下、振込時点の口座情報のスナップショット
+	BankNameKana      string           `json:"bank_name_kana" db:"bank_name_kana"`
+	BranchCode        string           `json:"branch_code" db:"branch_code"`
+	BranchNameKana    string           `json:"branch_name_kana" db:"branch_name_kana"`
+	AccountType       BankAccountType  `json:"account_type" db:"account_type"`
+	AccountNumber     string           `json:"account_number" db:"account_number"`
+	AccountHolderKana string           `json:"account_holder_kana" db:"account_holder_kana"`
+	Status            PayoutItemStatus `json:"status" db:"status"`
+	CreatedAt         time.Time        `json:"created_at" db:"created_at"`
+	UpdatedAt         time.Time        `json:"updated_at" db:"updated_at"`
+}
+
+// PayoutItemType 支払明細の種別
+type PayoutItemType string
+
+const (
+	PayoutItemTypeFactoryPayment PayoutItemType = "FACTORY_PAYMENT" // 工場への下請代金（Order.PaymentDueDate）
+	PayoutItemTypeCommission     PayoutItemType = "COMMISSION"      // アンバサダー成果報酬（Approved）
+)
+
+// PayoutItemStatus 支払明細ステータス
+type PayoutItemStatus string
+
+const (
+	PayoutItemStatusScheduled PayoutItemStatus = "SCHEDULED" // 振込予定
+	PayoutItemStatusPaid      PayoutItemStatus = "PAID"      // 振込完了
+	PayoutItemStatusCancelled PayoutItemStatus = "CANCELLED" // 取消（再度バッチ対象になる）
+)
+
+// NewPayoutBatch 新しい支払バッチを作成
+func NewPayoutBatch(tenantID string, dueBy, transferDate time.Time, createdBy string) *PayoutBatch {
+	now := time.Now()
+	return &PayoutBatch{
+		ID:           uuid.New().String(),
+		TenantID:     tenantID,
+		DueBy:        dueBy,
+		TransferDate: transferDate,
+		Status:       PayoutBatchStatusScheduled,
+		CreatedBy:    createdBy,
+		Items:        make([]*PayoutItem, 0),
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+}
+
+// AddItem 支払明細を追加（口座情報をスナップショットとして保持）
+func (b *PayoutBatch) AddItem(itemType PayoutItemType, referenceID string, dueDate time.Time, amount int64, account *BankAccount) *PayoutItem {
+	item := &PayoutItem{
+		ID:                uuid.New().String(),
+		BatchID:           b.ID,
+		TenantID:          b.TenantID,
+		ItemType:          itemType,
+		ReferenceID:       referenceID,
+		DueDate:           dueDate,
+		Amount:            amount,
+		BankAccountID:     account.ID,
+		BankCode:          account.BankCode,
+		BankNameKana:      account.BankNameKana,
+		BranchCode:        account.BranchCode,
+		BranchNameKana:    account.BranchNameKana,
+		AccountType:       account.AccountType,
+		AccountNumber:     account.AccountNumber,
+		AccountHolderKana: account.AccountHolderKana,
+		Status:            PayoutItemStatusScheduled,
+		CreatedAt:         b.CreatedAt,
+		UpdatedAt:         b.CreatedAt,
+	}
+	b.Items = append(b.Items, item)
+	b.ItemCount = len(b.Items)
+	b.TotalAmount += amount
+	return item
+}
@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultFabricLength 用尺の既定値（メートル、スーツ1着分）
+const DefaultFabricLength = 3.2
+
+// PriceBookItemType 価格表の項目種別
+type PriceBookItemType string
+
+const (
+	PriceBookItemTypeBase   PriceBookItemType = "BASE"   // 仕立て代（プランごとの基本価格、codeはPlanType）
+	PriceBookItemTypeOption PriceBookItemType = "OPTION" // オプション加算（ラペル・裏地・ボタン等）
+	PriceBookItemTypeRush   PriceBookItemType = "RUSH"   // 特急料金（納期までの日数で適用）
+)
+
+// IsValid 価格表の項目種別が有効かチェック
+func (t PriceBookItemType) IsValid() bool {
+	switch t {
+	case PriceBookItemTypeBase, PriceBookItemTypeOption, PriceBookItemTypeRush:
+		return true
+	default:
+		return false
+	}
+}
+
+// PriceBookEntry 価格表（テナントごと）
+type PriceBookEntry struct {
+	ID           string            `json:"id" db:"id"`
+	TenantID     string            `json:"tenant_id" db:"tenant_id"`
+	ItemType     PriceBookItemType `json:"item_type" db:"item_type"`
+	Code         string            `json:"code" db:"code"`                     // BASE: PlanType / OPTION: オプションコード / RUSH: 特急区分コード
+	Category     string            `json:"category" db:"category"`             // オプション分類（LAPEL, LINING, BUTTON 等）
+	Name         string            `json:"name" db:"name"`                     // 明細に表示する名称
+	Price        int64             `json:"price" db:"price"`                   // 税抜価格（円）
+	LeadTimeDays int               `json:"lead_time_days" db:"lead_time_days"` // RUSH: 納期までの日数がこの値未満の場合に適用
+	Active       bool              `json:"active" db:"active"`
+	CreatedAt    time.Time         `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time         `json:"updated_at" db:"updated_at"`
+}
+
+// NewPriceBookEntry 新しい価格表項目を作成
+func NewPriceBookEntry(tenantID string, itemType PriceBookItemType, code, name string, price int64) *PriceBookEntry {
+	now := time.Now()
+	return &PriceBookEntry{
+		ID:        uuid.New().String(),
+		TenantID:  tenantID,
+		ItemType:  itemType,
+		Code:      code,
+		Name:      name,
+		Price:     price,
+		Active:    true,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// PriceComponent 価格内訳の構成要素
+type PriceComponent string
+
+const (
+	PriceComponentBase   PriceComponent = "BASE"   // 仕立て代
+	PriceComponentFabric PriceComponent = "FABRIC" // 生地代（単価 × 用尺）
+	PriceComponentOption PriceComponent = "OPTION" // オプション加算
+	PriceComponentRush   PriceComponent = "RUSH"   // 特急料金
+)
+
+// PriceLine 価格内訳の1行
+type PriceLine struct {
+	Component   PriceComponent `json:"component"`
+	Code        string         `json:"code"`
+	Description string         `json:"description"`
+	Quantity    float64        `json:"quantity"`
+	UnitPrice   int64          `json:"unit_price"` // 税抜単価（円）
+	Amount      int64          `json:"amount"`     // 税抜金額（円）
+}
+
+// PriceBreakdown サーバー側で計算した価格内訳
+type PriceBreakdown struct {
+	PlanType     PlanType     `json:"plan_type"`
+	FabricID     string       `json:"fabric_id"`
+	FabricLength float64      `json:"fabric_length"`
+	Lines        []*PriceLine `json:"lines"`
+	TotalAmount  int64        `json:"total_amount"` // 税抜合計（円）
+	CalculatedAt time.Time    `json:"calculated_at"`
+}
+
+// AddLine 価格内訳に行を追加し、合計を再計算
+func (b *PriceBreakdown) AddLine(line *PriceLine) {
+	b.Lines = append(b.Lines, line)
+	b.TotalAmount += line.Amount
+}
+
+// OrderPriceOverride 価格の手動上書き記録
+// クライアント指定の金額が計算価格と異なる注文を、権限者が理由付きで承認した記録
+type OrderPriceOverride struct {
+	ID             string          `json:"id" db:"id"`
+	TenantID       string          `json:"tenant_id" db:"tenant_id"`
+	OrderID        string          `json:"order_id" db:"order_id"`
+	ComputedAmount int64           `json:"computed_amount" db:"computed_amount"` // 計算価格（税抜）
+	OverrideAmount int64           `json:"override_amount" db:"override_amount"` // 採用した金額（税抜）
+	Reason         string          `json:"reason" db:"reason"`
+	Breakdown      *PriceBreakdown `json:"breakdown" db:"breakdown"`
+	AuthorizedBy   string          `json:"authorized_by" db:"authorized_by"`
+	CreatedAt      time.Time       `json:"created_at" db:"created_at"`
+}
+
+// NewOrderPriceOverride 価格上書き記録を作成
+func NewOrderPriceOverride(tenantID, orderID string, breakdown *PriceBreakdown, overrideAmount int64, reason, authorizedBy string) *OrderPriceOverride {
+	return &OrderPriceOverride{
+		ID:             uuid.New().String(),
+		TenantID:       tenantID,
+		OrderID:        orderID,
+		ComputedAmount: breakdown.TotalAmount,
+		OverrideAmount: overrideAmount,
+		Reason:         reason,
+		Breakdown:      breakdown,
+		AuthorizedBy:   authorizedBy,
+		CreatedAt:      time.Now(),
+	}
+}
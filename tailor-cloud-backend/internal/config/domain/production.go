@@ -0,0 +1,277 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProductionProcess 製造工程
+type ProductionProcess string
+
+const (
+	ProductionProcessCutting    ProductionProcess = "CUTTING"    // 裁断
+	ProductionProcessSewing     ProductionProcess = "SEWING"     // 縫製
+	ProductionProcessInspection ProductionProcess = "INSPECTION" // 検品
+)
+
+// ProductionProcesses 製造工程（実施順）
+var ProductionProcesses = []ProductionProcess{
+	ProductionProcessCutting,
+	ProductionProcessSewing,
+	ProductionProcessInspection,
+}
+
+// IsValid 製造工程が有効かチェック
+func (p ProductionProcess) IsValid() bool {
+	switch p {
+	case ProductionProcessCutting, ProductionProcessSewing, ProductionProcessInspection:
+		return true
+	default:
+		return false
+	}
+}
+
+// OrderStatus 工程に対応する注文ステータス
+func (p ProductionProcess) OrderStatus() OrderStatus {
+	switch p {
+	case ProductionProcessCutting:
+		return OrderStatusCutting
+	case ProductionProcessSewing:
+		return OrderStatusSewing
+	default:
+		return OrderStatusInspection
+	}
+}
+
+// RemainingProcesses 注文ステータスから残りの製造工程を取得
+// 製造対象外のステータス（Draft・発送済み以降・キャンセル）の場合は空
+func RemainingProcesses(status OrderStatus) []ProductionProcess {
+	switch status {
+	case OrderStatusConfirmed, OrderStatusMaterialSecured, OrderStatusCutting:
+		return ProductionProcesses
+	case OrderStatusSewing:
+		return ProductionProcesses[1:]
+	case OrderStatusInspection:
+		return ProductionProcesses[2:]
+	default:
+		return nil
+	}
+}
+
+// DefaultStandardMinutes 品目・工程ごとの標準作業時間（分）の既定値
+// 工場ごとの標準時間が未設定の品目・工程に使用する
+var DefaultStandardMinutes = map[GarmentType]map[ProductionProcess]int{
+	GarmentTypeSuit:     {ProductionProcessCutting: 90, ProductionProcessSewing: 1200, ProductionProcessInspection: 30},
+	GarmentTypeJacket:   {ProductionProcessCutting: 60, ProductionProcessSewing: 840, ProductionProcessInspection: 20},
+	GarmentTypeTrousers: {ProductionProcessCutting: 30, ProductionProcessSewing: 360, ProductionProcessInspection: 15},
+	GarmentTypeVest:     {ProductionProcessCutting: 20, ProductionProcessSewing: 240, ProductionProcessInspection: 10},
+	GarmentTypeCoat:     {ProductionProcessCutting: 90, ProductionProcessSewing: 1080, ProductionProcessInspection: 30},
+	GarmentTypeShirt:    {ProductionProcessCutting: 20, ProductionProcessSewing: 180, ProductionProcessInspection: 10},
+}
+
+// StandardTime 品目・工程ごとの標準作業時間
+type StandardTime struct {
+	GarmentType GarmentType       `json:"garment_type"`
+	Process     ProductionProcess `json:"process"`
+	Minutes     int               `json:"minutes"` // 1着あたりの作業時間（分）
+}
+
+// FactoryCapacity 工場の生産能力
+// 1日の生産能力（分）= 作業者数 × 稼働時間 × 60。1着は同じ日に1人の作業者が担当する前提で計画する
+type FactoryCapacity struct {
+	TenantID        string          `json:"tenant_id" db:"tenant_id"`
+	Headcount       int             `json:"headcount" db:"headcount"`               // 作業者数
+	HoursPerDay     float64         `json:"hours_per_day" db:"hours_per_day"`       // 1日の稼働時間
+	WorkingWeekdays []time.Weekday  `json:"working_weekdays" db:"working_weekdays"` // 稼働曜日（0 = 日曜）
+	ShippingDays    int             `json:"shipping_days" db:"shipping_days"`       // 検品完了から納品までの日数
+	StandardTimes   []*StandardTime `json:"standard_times" db:"standard_times"`     // 品目・工程ごとの標準作業時間（未設定は既定値）
+	UpdatedAt       time.Time       `json:"updated_at" db:"updated_at"`
+	UpdatedBy       string          `json:"updated_by" db:"updated_by"`
+}
+
+// Validate 生産能力の設定を検証
+func (c *FactoryCapacity) Validate() error {
+	if c.Headcount <= 0 {
+		return fmt.Errorf("invalid headcount: must be greater than 0")
+	}
+	if c.HoursPerDay <= 0 || c.HoursPerDay > 24 {
+		return fmt.Errorf("invalid hours_per_day: must be between 0 and 24")
+	}
+	if len(c.WorkingWeekdays) == 0 {
+		return fmt.Errorf("working_weekdays is required")
+	}
+	for _, weekday := range c.WorkingWeekdays {
+		if weekday < time.Sunday || weekday > time.Saturday {
+			return fmt.Errorf("invalid working_weekdays: %d", weekday)
+		}
+	}
+	if c.ShippingDays < 0 {
+		return fmt.Errorf("invalid shipping_days: must not be negative")
+	}
+	for _, st := range c.StandardTimes {
+		if !st.GarmentType.IsValid() {
+			return fmt.Errorf("invalid garment_type: %s", st.GarmentType)
+		}
+		if !st.Process.IsValid() {
+			return fmt.Errorf("invalid process: %s", st.Process)
+		}
+		if st.Minutes <= 0 {
+			return fmt.Errorf("invalid standard_times: minutes for %s/%s must be greater than 0", st.GarmentType, st.Process)
+		}
+	}
+	return nil
+}
+
+// StandardMinutes 品目・工程の標準作業時間（分）
+// 品目が未設定の注文はスーツとして扱う
+func (c *FactoryCapacity) StandardMinutes(garmentType GarmentType, process ProductionProcess) int {
+	if garmentType == "" {
+		garmentType = GarmentTypeSuit
+	}
+	for _, st := range c.StandardTimes {
+		if st.GarmentType == garmentType && st.Process == process {
+			return st.Minutes
+		}
+	}
+	return DefaultStandardMinutes[garmentType][process]
+}
+
+// IsWorkingDay 稼働曜日か
+func (c *FactoryCapacity) IsWorkingDay(weekday time.Weekday) bool {
+	for _, w := range c.WorkingWeekdays {
+		if w == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+// DayCapacity 指定日の生産能力
+// overrideがある場合は休日・残業（休日出勤を含む）の設定を優先する
+func (c *FactoryCapacity) DayCapacity(day time.Time, override *CapacityOverride) (headcount int, hoursPerDay float64) {
+	if override != nil {
+		if override.Type == CapacityOverrideTypeHoliday {
+			return 0, 0
+		}
+		headcount, hoursPerDay = c.Headcount, c.HoursPerDay
+		if override.Headcount > 0 {
+			headcount = override.Headcount
+		}
+		if override.HoursPerDay > 0 {
+			hoursPerDay = override.HoursPerDay
+		}
+		return headcount, hoursPerDay
+	}
+	if !c.IsWorkingDay(day.Weekday()) {
+		return 0, 0
+	}
+	return c.Headcount, c.HoursPerDay
+}
+
+// CapacityOverrideType 生産能力の個別設定の種別
+type CapacityOverrideType string
+
+const (
+	CapacityOverrideTypeHoliday  CapacityOverrideType = "HOLIDAY"  // 休業日（稼働なし）
+	CapacityOverrideTypeOvertime CapacityOverrideType = "OVERTIME" // 残業・休日出勤（稼働時間・作業者数を上書き）
+)
+
+// IsValid 個別設定の種別が有効かチェック
+func (t CapacityOverrideType) IsValid() bool {
+	return t == CapacityOverrideTypeHoliday || t == CapacityOverrideTypeOvertime
+}
+
+// CapacityOverride 日ごとの生産能力の個別設定（祝日・夏季休業・残業・休日出勤）
+type CapacityOverride struct {
+	ID          string               `json:"id" db:"id"`
+	TenantID    string               `json:"tenant_id" db:"tenant_id"`
+	Date        time.Time            `json:"date" db:"date"` // 対象日（日付のみ）
+	Type        CapacityOverrideType `json:"type" db:"type"`
+	Headcount   int                  `json:"headcount" db:"headcount"`         // 作業者数（0の場合は通常の作業者数）
+	HoursPerDay float64              `json:"hours_per_day" db:"hours_per_day"` // 稼働時間（0の場合は通常の稼働時間）
+	Note        string               `json:"note" db:"note"`
+	CreatedAt   time.Time            `json:"created_at" db:"created_at"`
+	CreatedBy   string               `json:"created_by" db:"created_by"`
+}
+
+// NewCapacityOverride 生産能力の個別設定を作成
+func NewCapacityOverride(tenantID string, date time.Time, overrideType CapacityOverrideType, createdBy string) *CapacityOverride {
+	return &CapacityOverride{
+		ID:        uuid.New().String(),
+		TenantID:  tenantID,
+		Date:      ProductionDay(date),
+		Type:      overrideType,
+		CreatedAt: time.Now(),
+		CreatedBy: createdBy,
+	}
+}
+
+// ProductionDay 日時を生産計画の日付（ローカル時刻の0時）に変換
+func ProductionDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+}
+
+// ScheduledTask 生産計画の工程（ガントチャートの1本のバー）
+type ScheduledTask struct {
+	Process   ProductionProcess `json:"process"`
+	StartDate time.Time         `json:"start_date"`
+	EndDate   time.Time         `json:"end_date"` // この日を含む
+	Minutes   int               `json:"minutes"`
+}
+
+// ScheduleSuggestionType 納期遅延時の代替案の種別
+type ScheduleSuggestionType string
+
+const (
+	ScheduleSuggestionDelayDelivery ScheduleSuggestionType = "DELAY_DELIVERY" // 納期の変更
+	ScheduleSuggestionOvertime      ScheduleSuggestionType = "OVERTIME"       // 残業・休日出勤
+	ScheduleSuggestionAddHeadcount  ScheduleSuggestionType = "ADD_HEADCOUNT"  // 作業者の増員
+)
+
+// ScheduleSuggestion 納期遅延時の代替案
+type ScheduleSuggestion struct {
+	Type                  ScheduleSuggestionType `json:"type"`
+	Message               string                 `json:"message"`
+	SuggestedDeliveryDate *time.Time             `json:"suggested_delivery_date,omitempty"` // DELAY_DELIVERY
+	OvertimeMinutes       int                    `json:"overtime_minutes,omitempty"`        // OVERTIME: 納期までに必要な追加稼働時間（分）
+	AdditionalHeadcount   int                    `json:"additional_headcount,omitempty"`    // ADD_HEADCOUNT: 納期までに必要な増員数
+}
+
+// ScheduledOrder 生産計画上の注文
+type ScheduledOrder struct {
+	OrderID        string                `json:"order_id"`
+	CustomerID     string                `json:"customer_id"`
+	GarmentType    GarmentType           `json:"garment_type"`
+	Status         OrderStatus           `json:"status"`
+	DeliveryDate   time.Time             `json:"delivery_date"`
+	Deadline       time.Time             `json:"deadline"`                  // 検品完了の期限（納期 - 発送日数）
+	CompletionDate *time.Time            `json:"completion_date,omitempty"` // 検品完了予定日（計画期間内に収まらない場合はnil）
+	Late           bool                  `json:"late"`
+	LateDays       int                   `json:"late_days"`
+	Tasks          []*ScheduledTask      `json:"tasks"`
+	Suggestions    []*ScheduleSuggestion `json:"suggestions"`
+}
+
+// ScheduleDay 日ごとの負荷
+type ScheduleDay struct {
+	Date               time.Time            `json:"date"`
+	CapacityMinutes    int                  `json:"capacity_minutes"`
+	AllocatedMinutes   int                  `json:"allocated_minutes"`
+	UtilizationPercent int                  `json:"utilization_percent"`
+	OverrideType       CapacityOverrideType `json:"override_type,omitempty"`
+}
+
+// ProductionSchedule 生産計画（ガントチャート）
+type ProductionSchedule struct {
+	TenantID          string            `json:"tenant_id"`
+	From              time.Time         `json:"from"`
+	To                time.Time         `json:"to"` // 表示期間の終了日（この日を含む）
+	GeneratedAt       time.Time         `json:"generated_at"`
+	Days              []*ScheduleDay    `json:"days"`
+	Orders            []*ScheduledOrder `json:"orders"`
+	LateOrders        int               `json:"late_orders"`
+	UnscheduledOrders int               `json:"unscheduled_orders"` // 計画期間内に収まらない注文数
+}
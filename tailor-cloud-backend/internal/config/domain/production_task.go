@@ -0,0 +1,216 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProductionTaskStatus 製造タスクのステータス
+type ProductionTaskStatus string
+
+const (
+	ProductionTaskStatusPending    ProductionTaskStatus = "PENDING"     // 未着手
+	ProductionTaskStatusInProgress ProductionTaskStatus = "IN_PROGRESS" // 作業中
+	ProductionTaskStatusPaused     ProductionTaskStatus = "PAUSED"      // 中断
+	ProductionTaskStatusCompleted  ProductionTaskStatus = "COMPLETED"   // 完了
+)
+
+// IsValid 製造タスクのステータスが有効かチェック
+func (s ProductionTaskStatus) IsValid() bool {
+	switch s {
+	case ProductionTaskStatusPending, ProductionTaskStatusInProgress, ProductionTaskStatusPaused, ProductionTaskStatusCompleted:
+		return true
+	default:
+		return false
+	}
+}
+
+// ProductionTask 製造タスク（注文・工程ごとの作業単位）
+// 作業者が開始・中断・完了を記録し、作業時間（中断中を除く）を積算する
+type ProductionTask struct {
+	ID              string               `json:"id" db:"id"`
+	TenantID        string               `json:"tenant_id" db:"tenant_id"`
+	OrderID         string               `json:"order_id" db:"order_id"`
+	GarmentType     GarmentType          `json:"garment_type" db:"garment_type"`
+	Process         ProductionProcess    `json:"process" db:"process"`
+	Status          ProductionTaskStatus `json:"status" db:"status"`
+	AssignedTo      string               `json:"assigned_to,omitempty" db:"assigned_to"`     // 担当作業者（ユーザーID）
+	StandardMinutes int                  `json:"standard_minutes" db:"standard_minutes"`     // 標準作業時間（分）
+	WorkedSeconds   int64                `json:"worked_seconds" db:"worked_seconds"`         // 積算作業時間（秒、作業中の区間を除く）
+	StartedAt       *time.Time           `json:"started_at,omitempty" db:"started_at"`       // 最初に開始した日時
+	ResumedAt       *time.Time           `json:"resumed_at,omitempty" db:"resumed_at"`       // 作業中の区間の開始日時
+	CompletedAt     *time.Time           `json:"completed_at,omitempty" db:"completed_at"`   // 完了日時
+	InspectionID    string               `json:"inspection_id,omitempty" db:"inspection_id"` // 検品不合格による手直しタスクの場合、起点の検品ID
+	CreatedAt       time.Time            `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time            `json:"updated_at" db:"updated_at"`
+}
+
+// NewProductionTask 新しい製造タスクを作成（未着手）
+func NewProductionTask(tenantID, orderID string, garmentType GarmentType, process ProductionProcess, standardMinutes int) *ProductionTask {
+	now := time.Now()
+	return &ProductionTask{
+		ID:              uuid.New().String(),
+		TenantID:        tenantID,
+		OrderID:         orderID,
+		GarmentType:     garmentType,
+		Process:         process,
+		Status:          ProductionTaskStatusPending,
+		StandardMinutes: standardMinutes,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+}
+
+// Start 作業を開始（未着手・中断から）。担当者が未設定の場合は開始した作業者を担当者にする
+func (t *ProductionTask) Start(workerID string, now time.Time) error {
+	if t.Status != ProductionTaskStatusPending && t.Status != ProductionTaskStatusPaused {
+		return fmt.Errorf("invalid task status: cannot start %s task", t.Status)
+	}
+	if t.AssignedTo == "" {
+		t.AssignedTo = workerID
+	}
+	if t.StartedAt == nil {
+		t.StartedAt = &now
+	}
+	t.ResumedAt = &now
+	t.Status = ProductionTaskStatusInProgress
+	t.UpdatedAt = now
+	return nil
+}
+
+// Pause 作業を中断し、作業中の区間の時間を積算する
+func (t *ProductionTask) Pause(now time.Time) error {
+	if t.Status != ProductionTaskStatusInProgress {
+		return fmt.Errorf("invalid task status: cannot pause %s task", t.Status)
+	}
+	t.accumulate(now)
+	t.Status = ProductionTaskStatusPaused
+	t.UpdatedAt = now
+	return nil
+}
+
+// Complete 作業を完了（作業中・中断から）
+func (t *ProductionTask) Complete(now time.Time) error {
+	if t.Status != ProductionTaskStatusInProgress && t.Status != ProductionTaskStatusPaused {
+		return fmt.Errorf("invalid task status: cannot complete %s task", t.Status)
+	}
+	t.accumulate(now)
+	t.Status = ProductionTaskStatusCompleted
+	t.CompletedAt = &now
+	t.UpdatedAt = now
+	return nil
+}
+
+// accumulate 作業中の区間の時間を積算して区間を閉じる
+func (t *ProductionTask) accumulate(now time.Time) {
+	if t.ResumedAt != nil && now.After(*t.ResumedAt) {
+		t.WorkedSeconds += int64(now.Sub(*t.ResumedAt).Seconds())
+	}
+	t.ResumedAt = nil
+}
+
+// WorkedMinutes 作業時間（分）。作業中の場合は現在までの区間を含む
+func (t *ProductionTask) WorkedMinutes(now time.Time) int {
+	seconds := t.WorkedSeconds
+	if t.Status == ProductionTaskStatusInProgress && t.ResumedAt != nil && now.After(*t.ResumedAt) {
+		seconds += int64(now.Sub(*t.ResumedAt).Seconds())
+	}
+	return int(seconds / 60)
+}
+
+// ProductionTaskFilter 製造タスクの検索条件
+type ProductionTaskFilter struct {
+	TenantID   string
+	OrderID    string
+	AssignedTo string
+	Process    ProductionProcess
+	Statuses   []ProductionTaskStatus
+}
+
+// NextOrderStatusAfter 工程の完了後の注文ステータス
+// 検品の完了後は発送（出荷登録）まで検品中のままとする
+func NextOrderStatusAfter(process ProductionProcess) OrderStatus {
+	switch process {
+	case ProductionProcessCutting:
+		return OrderStatusSewing
+	default:
+		return OrderStatusInspection
+	}
+}
+
+// ProductionStatusRank 製造工程上の注文ステータスの順序（製造対象外のステータスは-1）
+func ProductionStatusRank(status OrderStatus) int {
+	switch status {
+	case OrderStatusConfirmed:
+		return 0
+	case OrderStatusMaterialSecured:
+		return 1
+	case OrderStatusCutting:
+		return 2
+	case OrderStatusSewing:
+		return 3
+	case OrderStatusInspection:
+		return 4
+	default:
+		return -1
+	}
+}
+
+// WorkerThroughput 作業者ごとの実績
+type WorkerThroughput struct {
+	WorkerID          string                    `json:"worker_id"`
+	CompletedTasks    int                       `json:"completed_tasks"`
+	ByProcess         map[ProductionProcess]int `json:"by_process"`         // 工程ごとの完了タスク数
+	WorkedMinutes     int                       `json:"worked_minutes"`     // 作業時間の合計（分）
+	StandardMinutes   int                       `json:"standard_minutes"`   // 標準作業時間の合計（分）
+	EfficiencyPercent int                       `json:"efficiency_percent"` // 能率（%）= 標準作業時間 / 作業時間
+}
+
+// WorkerThroughputReport 作業者別の実績レポート
+type WorkerThroughputReport struct {
+	TenantID       string              `json:"tenant_id"`
+	From           time.Time           `json:"from"`
+	To             time.Time           `json:"to"`
+	TotalCompleted int                 `json:"total_completed"`
+	Workers        []*WorkerThroughput `json:"workers"`
+}
+
+// SummarizeWorkerThroughput 完了した製造タスクを作業者ごとに集計（完了タスク数の多い順）
+func SummarizeWorkerThroughput(tasks []*ProductionTask) []*WorkerThroughput {
+	byWorker := make(map[string]*WorkerThroughput)
+	workers := make([]*WorkerThroughput, 0)
+	for _, task := range tasks {
+		if task.Status != ProductionTaskStatusCompleted || task.AssignedTo == "" {
+			continue
+		}
+		worker, ok := byWorker[task.AssignedTo]
+		if !ok {
+			worker = &WorkerThroughput{
+				WorkerID:  task.AssignedTo,
+				ByProcess: make(map[ProductionProcess]int),
+			}
+			byWorker[task.AssignedTo] = worker
+			workers = append(workers, worker)
+		}
+		worker.CompletedTasks++
+		worker.ByProcess[task.Process]++
+		worker.WorkedMinutes += int(task.WorkedSeconds / 60)
+		worker.StandardMinutes += task.StandardMinutes
+	}
+
+	for _, worker := range workers {
+		if worker.WorkedMinutes > 0 {
+			worker.EfficiencyPercent = worker.StandardMinutes * 100 / worker.WorkedMinutes
+		}
+	}
+	sort.SliceStable(workers, func(i, j int) bool {
+		if workers[i].CompletedTasks != workers[j].CompletedTasks {
+			return workers[i].CompletedTasks > workers[j].CompletedTasks
+		}
+		return workers[i].WorkerID < workers[j].WorkerID
+	})
+	return workers
+}
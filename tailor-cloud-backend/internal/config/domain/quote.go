@@ -0,0 +1,145 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// QuoteValidityDays 見積書の有効期間（日）
+const QuoteValidityDays = 30
+
+// Quote 見積書モデル
+// 注文（Draft）の前段階。改訂は新しいバージョンとして保存し、旧バージョンは Superseded になる
+type Quote struct {
+	ID               string        `json:"id" db:"id"`
+	TenantID         string        `json:"tenant_id" db:"tenant_id"`
+	QuoteGroupID     string        `json:"quote_group_id" db:"quote_group_id"` // 全バージョン共通のID（初版のID）
+	Version          int           `json:"version" db:"version"`
+	CustomerID       string        `json:"customer_id" db:"customer_id"`
+	FabricID         string        `json:"fabric_id" db:"fabric_id"`
+	FabricLength     float64       `json:"fabric_length" db:"fabric_length"` // 用尺（メートル）
+	PlanType         PlanType      `json:"plan_type" db:"plan_type"`
+	Status           QuoteStatus   `json:"status" db:"status"`
+	Lines            []*QuoteLine  `json:"lines" db:"lines"`
+	TotalAmount      int64         `json:"total_amount" db:"total_amount"` // 税抜金額（円）
+	TaxRate          TaxRate       `json:"tax_rate" db:"tax_rate"`
+	Details          *OrderDetails `json:"details" db:"details"`             // 採寸データ・補正情報（注文へ引き継ぐ）
+	DeliveryDate     *time.Time    `json:"delivery_date" db:"delivery_date"` // 希望納期
+	Notes            string        `json:"notes" db:"notes"`
+	ValidUntil       time.Time     `json:"valid_until" db:"valid_until"`
+	AcceptedAt       *time.Time    `json:"accepted_at" db:"accepted_at"`
+	ConvertedOrderID string        `json:"converted_order_id" db:"converted_order_id"`
+	CreatedAt        time.Time     `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time     `json:"updated_at" db:"updated_at"`
+	CreatedBy        string        `json:"created_by" db:"created_by"`
+}
+
+// QuoteStatus 見積ステータス
+type QuoteStatus string
+
+const (
+	QuoteStatusDraft      QuoteStatus = "Draft"      // 作成中
+	QuoteStatusSent       QuoteStatus = "Sent"       // 提示済み（顧客の回答待ち）
+	QuoteStatusAccepted   QuoteStatus = "Accepted"   // 顧客承諾
+	QuoteStatusRejected   QuoteStatus = "Rejected"   // 顧客辞退
+	QuoteStatusExpired    QuoteStatus = "Expired"    // 有効期限切れ
+	QuoteStatusSuperseded QuoteStatus = "Superseded" // 改訂により旧版
+	QuoteStatusConverted  QuoteStatus = "Converted"  // 注文へ変換済み
+)
+
+// IsValid 見積ステータスが有効かチェック
+func (s QuoteStatus) IsValid() bool {
+	switch s {
+	case QuoteStatusDraft, QuoteStatusSent, QuoteStatusAccepted, QuoteStatusRejected,
+		QuoteStatusExpired, QuoteStatusSuperseded, QuoteStatusConverted:
+		return true
+	default:
+		return false
+	}
+}
+
+// QuoteLineType 見積明細の種別
+type QuoteLineType string
+
+const (
+	QuoteLineTypeBase   QuoteLineType = "BASE"   // 仕立て代（プラン基本価格）
+	QuoteLineTypeFabric QuoteLineType = "FABRIC" // 生地代
+	QuoteLineTypeOption QuoteLineType = "OPTION" // オプション（裏地・ボタン等）
+	QuoteLineTypeOther  QuoteLineType = "OTHER"  // その他（特急料金等）
+)
+
+// IsValid 見積明細の種別が有効かチェック
+func (t QuoteLineType) IsValid() bool {
+	switch t {
+	case QuoteLineTypeBase, QuoteLineTypeFabric, QuoteLineTypeOption, QuoteLineTypeOther:
+		return true
+	default:
+		return false
+	}
+}
+
+// QuoteLine 見積明細
+type QuoteLine struct {
+	LineType    QuoteLineType `json:"line_type"`
+	Code        string        `json:"code,omitempty"` // オプションコード等
+	Description string        `json:"description"`
+	Quantity    float64       `json:"quantity"`   // 数量（生地はメートル）
+	UnitPrice   int64         `json:"unit_price"` // 税抜単価（円）
+	Amount      int64         `json:"amount"`     // 税抜金額（円）
+}
+
+// NewQuote 新しい見積書を作成（初版・Draftステータス）
+func NewQuote(tenantID, customerID, fabricID, createdBy string) *Quote {
+	now := time.Now()
+	id := uuid.New().String()
+	return &Quote{
+		ID:           id,
+		TenantID:     tenantID,
+		QuoteGroupID: id,
+		Version:      1,
+		CustomerID:   customerID,
+		FabricID:     fabricID,
+		Status:       QuoteStatusDraft,
+		TaxRate:      TaxRateStandard,
+		ValidUntil:   now.AddDate(0, 0, QuoteValidityDays),
+		CreatedAt:    now,
+		UpdatedAt:    now,
+		CreatedBy:    createdBy,
+	}
+}
+
+// NewRevision 見積書の改訂版を作成（バージョンを上げ、有効期限を再設定）
+// 明細は改訂内容で組み直すため空にする
+func (q *Quote) NewRevision(createdBy string) *Quote {
+	now := time.Now()
+	revision := *q
+	revision.ID = uuid.New().String()
+	revision.Version = q.Version + 1
+	revision.Status = QuoteStatusDraft
+	revision.Lines = nil
+	revision.TotalAmount = 0
+	revision.ValidUntil = now.AddDate(0, 0, QuoteValidityDays)
+	revision.AcceptedAt = nil
+	revision.ConvertedOrderID = ""
+	revision.CreatedAt = now
+	revision.UpdatedAt = now
+	revision.CreatedBy = createdBy
+	return &revision
+}
+
+// AddLine 見積明細を追加し、合計金額を再計算
+func (q *Quote) AddLine(line *QuoteLine) {
+	q.Lines = append(q.Lines, line)
+	q.TotalAmount += line.Amount
+}
+
+// IsOpen 顧客の回答待ち（承諾・辞退が可能）か
+func (q *Quote) IsOpen() bool {
+	return q.Status == QuoteStatusDraft || q.Status == QuoteStatusSent
+}
+
+// IsExpired 有効期限切れか（回答待ちの見積のみ対象）
+func (q *Quote) IsExpired(now time.Time) bool {
+	return q.IsOpen() && now.After(q.ValidUntil)
+}
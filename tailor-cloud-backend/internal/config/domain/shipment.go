@@ -0,0 +1,260 @@
+package domain
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SubcontractPaymentTermDays 下請法: 給付の受領日から支払期日までの上限日数
+const SubcontractPaymentTermDays = 60
+
+// Carrier 配送業者
+type Carrier string
+
+const (
+	CarrierYamato    Carrier = "YAMATO"     // ヤマト運輸
+	CarrierSagawa    Carrier = "SAGAWA"     // 佐川急便
+	CarrierJapanPost Carrier = "JAPAN_POST" // 日本郵便
+)
+
+// IsValid 配送業者が有効かチェック
+func (c Carrier) IsValid() bool {
+	switch c {
+	case CarrierYamato, CarrierSagawa, CarrierJapanPost:
+		return true
+	default:
+		return false
+	}
+}
+
+// Label 配送業者の表示名
+func (c Carrier) Label() string {
+	switch c {
+	case CarrierYamato:
+		return "ヤマト運輸"
+	case CarrierSagawa:
+		return "佐川急便"
+	case CarrierJapanPost:
+		return "日本郵便"
+	default:
+		return string(c)
+	}
+}
+
+var (
+	digitsTrackingNumber   = regexp.MustCompile(`^[0-9]+$`)
+	japanPostInternational = regexp.MustCompile(`^[A-Z]{2}[0-9]{9}[A-Z]{2}$`)
+)
+
+// NormalizeTrackingNumber 伝票番号からハイフン・空白を除き、英字を大文字にする
+func NormalizeTrackingNumber(trackingNumber string) string {
+	replacer := strings.NewReplacer("-", "", " ", "", "　", "", "ー", "")
+	return strings.ToUpper(replacer.Replace(strings.TrimSpace(trackingNumber)))
+}
+
+// ValidateTrackingNumber 配送業者ごとの伝票番号の形式をチェック（正規化済みの番号）
+// ヤマト運輸: 12桁、佐川急便: 10〜12桁、日本郵便: 11〜13桁（国際郵便は英字2桁+数字9桁+英字2桁）
+func (c Carrier) ValidateTrackingNumber(trackingNumber string) error {
+	if trackingNumber == "" {
+		return fmt.Errorf("tracking_number is required")
+	}
+
+	valid := false
+	switch c {
+	case CarrierYamato:
+		valid = digitsTrackingNumber.MatchString(trackingNumber) && len(trackingNumber) == 12
+	case CarrierSagawa:
+		valid = digitsTrackingNumber.MatchString(trackingNumber) && len(trackingNumber) >= 10 && len(trackingNumber) <= 12
+	case CarrierJapanPost:
+		valid = (digitsTrackingNumber.MatchString(trackingNumber) && len(trackingNumber) >= 11 && len(trackingNumber) <= 13) ||
+			japanPostInternational.MatchString(trackingNumber)
+	default:
+		return fmt.Errorf("invalid carrier: %s", c)
+	}
+
+	if !valid {
+		return fmt.Errorf("invalid tracking_number for %s: %s", c, trackingNumber)
+	}
+	return nil
+}
+
+// TrackingURL 配送業者の荷物追跡ページのURL
+func (c Carrier) TrackingURL(trackingNumber string) string {
+	number := url.QueryEscape(trackingNumber)
+	switch c {
+	case CarrierYamato:
+		return "https://toi.kuronekoyamato.co.jp/cgi-bin/tneko?number00=1&number01=" + number
+	case CarrierSagawa:
+		return "https://k2k.sagawa-exp.co.jp/p/web/okurijosearch.do?okurijoNo=" + number
+	case CarrierJapanPost:
+		return "https://trackings.post.japanpost.jp/services/srv/search/direct?reqCodeNo1=" + number
+	default:
+		return ""
+	}
+}
+
+// ShipmentStatus 出荷（配送）ステータス
+type ShipmentStatus string
+
+const (
+	ShipmentStatusShipped        ShipmentStatus = "SHIPPED"          // 出荷済み（配送業者への引き渡し）
+	ShipmentStatusInTransit      ShipmentStatus = "IN_TRANSIT"       // 輸送中
+	ShipmentStatusOutForDelivery ShipmentStatus = "OUT_FOR_DELIVERY" // 配達中
+	ShipmentStatusDelivered      ShipmentStatus = "DELIVERED"        // 配達完了
+	ShipmentStatusException      ShipmentStatus = "EXCEPTION"        // 持ち戻り・住所不明などの配送異常
+)
+
+// IsValid 出荷ステータスが有効かチェック
+func (s ShipmentStatus) IsValid() bool {
+	switch s {
+	case ShipmentStatusShipped, ShipmentStatusInTransit, ShipmentStatusOutForDelivery, ShipmentStatusDelivered, ShipmentStatusException:
+		return true
+	default:
+		return false
+	}
+}
+
+// Label 出荷ステータスの表示名
+func (s ShipmentStatus) Label() string {
+	switch s {
+	case ShipmentStatusShipped:
+		return "出荷済み"
+	case ShipmentStatusInTransit:
+		return "輸送中"
+	case ShipmentStatusOutForDelivery:
+		return "配達中"
+	case ShipmentStatusDelivered:
+		return "配達完了"
+	case ShipmentStatusException:
+		return "配送異常"
+	default:
+		return string(s)
+	}
+}
+
+// ActiveShipmentStatuses 配達完了前（追跡対象）の出荷ステータス
+var ActiveShipmentStatuses = []ShipmentStatus{
+	ShipmentStatusShipped,
+	ShipmentStatusInTransit,
+	ShipmentStatusOutForDelivery,
+	ShipmentStatusException,
+}
+
+// Shipment 出荷記録（注文ごとに1件、複数口の場合は個口数で管理）
+type Shipment struct {
+	ID                  string         `json:"id" db:"id"`
+	TenantID            string         `json:"tenant_id" db:"tenant_id"`
+	OrderID             string         `json:"order_id" db:"order_id"`
+	Carrier             Carrier        `json:"carrier" db:"carrier"`
+	TrackingNumber      string         `json:"tracking_number" db:"tracking_number"` // 伝票番号（正規化済み）
+	TrackingURL         string         `json:"tracking_url" db:"-"`                  // 配送業者の追跡ページ
+	PackageCount        int            `json:"package_count" db:"package_count"`     // 個口数
+	ShippedAt           time.Time      `json:"shipped_at" db:"shipped_at"`           // 出荷日
+	Status              ShipmentStatus `json:"status" db:"status"`
+	RecipientName       string         `json:"recipient_name" db:"recipient_name"`
+	RecipientPostalCode string         `json:"recipient_postal_code" db:"recipient_postal_code"`
+	RecipientAddress    string         `json:"recipient_address" db:"recipient_address"`
+	RecipientPhone      string         `json:"recipient_phone" db:"recipient_phone"`
+	Notes               string         `json:"notes" db:"notes"`
+	DeliveredAt         *time.Time     `json:"delivered_at,omitempty" db:"delivered_at"`       // 実際の配達日時（支払期日の起算日）
+	LastTrackedAt       *time.Time     `json:"last_tracked_at,omitempty" db:"last_tracked_at"` // 最後に追跡情報を取得した日時
+	TrackingDetail      string         `json:"tracking_detail,omitempty" db:"tracking_detail"` // 最新の追跡情報（配送業者の表記）
+	CreatedAt           time.Time      `json:"created_at" db:"created_at"`
+	UpdatedAt           time.Time      `json:"updated_at" db:"updated_at"`
+	CreatedBy           string         `json:"created_by" db:"created_by"`
+}
+
+// NewShipment 新しい出荷記録を作成（出荷済み）
+func NewShipment(tenantID, orderID string, carrier Carrier, trackingNumber string, packageCount int, shippedAt time.Time, createdBy string) *Shipment {
+	now := time.Now()
+	return &Shipment{
+		ID:             uuid.New().String(),
+		TenantID:       tenantID,
+		OrderID:        orderID,
+		Carrier:        carrier,
+		TrackingNumber: trackingNumber,
+		TrackingURL:    carrier.TrackingURL(trackingNumber),
+		PackageCount:   packageCount,
+		ShippedAt:      shippedAt,
+		Status:         ShipmentStatusShipped,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+		CreatedBy:      createdBy,
+	}
+}
+
+// Validate 出荷記録を検証
+func (s *Shipment) Validate() error {
+	if !s.Carrier.IsValid() {
+		return fmt.Errorf("invalid carrier: %s", s.Carrier)
+	}
+	if err := s.Carrier.ValidateTrackingNumber(s.TrackingNumber); err != nil {
+		return err
+	}
+	if s.PackageCount < 1 || s.PackageCount > 99 {
+		return fmt.Errorf("invalid package_count: must be between 1 and 99")
+	}
+	if s.ShippedAt.IsZero() {
+		return fmt.Errorf("shipped_at is required")
+	}
+	if s.RecipientName == "" {
+		return fmt.Errorf("recipient_name is required")
+	}
+	if s.RecipientAddress == "" {
+		return fmt.Errorf("recipient_address is required")
+	}
+	return nil
+}
+
+// ApplyTracking 追跡結果を反映する。配達完了になった場合は true を返す
+// 配達完了後の出荷記録は変更しない
+func (s *Shipment) ApplyTracking(status ShipmentStatus, deliveredAt *time.Time, detail string, now time.Time) (bool, error) {
+	if s.Status == ShipmentStatusDelivered {
+		return false, fmt.Errorf("invalid shipment status: shipment is already delivered")
+	}
+	if !status.IsValid() {
+		return false, fmt.Errorf("invalid shipment status: %s", status)
+	}
+
+	s.LastTrackedAt = &now
+	s.TrackingDetail = detail
+	s.UpdatedAt = now
+	if status != ShipmentStatusDelivered {
+		s.Status = status
+		return false, nil
+	}
+
+	delivered := now
+	if deliveredAt != nil {
+		delivered = *deliveredAt
+	}
+	if delivered.Before(s.ShippedAt) {
+		return false, fmt.Errorf("invalid delivered_at: before shipped_at")
+	}
+	s.Status = ShipmentStatusDelivered
+	s.DeliveredAt = &delivered
+	return true, nil
+}
+
+// PaymentDueDateAfterDelivery 実際の受領日から支払期日を決める
+// 受領日から60日以内に支払う必要があるため、合意済みの支払期日がそれより後の場合は前倒しする
+func PaymentDueDateAfterDelivery(agreedDueDate, deliveredAt time.Time) time.Time {
+	deadline := time.Date(deliveredAt.Year(), deliveredAt.Month(), deliveredAt.Day(), 0, 0, 0, 0, deliveredAt.Location()).
+		AddDate(0, 0, SubcontractPaymentTermDays)
+	if agreedDueDate.IsZero() || agreedDueDate.After(deadline) {
+		return deadline
+	}
+	return agreedDueDate
+}
+
+// ShipmentFilter 出荷記録の検索条件
+type ShipmentFilter struct {
+	TenantID string
+	Carrier  Carrier
+	Statuses []ShipmentStatus
+}
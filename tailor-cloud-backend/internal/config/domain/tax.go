@@ -56,6 +56,24 @@ func CalculateTaxIncludedAmount(taxExcludedAmount int64, taxRate TaxRate, roundi
 	return taxExcludedAmount + taxAmount
 }
 
+// CalculateTaxFromIncluded 税込金額に含まれる消費税額を計算
+// taxIncludedAmount: 税込金額
+// taxRate: 消費税率
+// roundingMethod: 端数処理方法
+func CalculateTaxFromIncluded(taxIncludedAmount int64, taxRate TaxRate, roundingMethod TaxRoundingMethod) int64 {
+	// 税額 = 税込金額 × 税率 / (1 + 税率)
+	taxAmount := float64(taxIncludedAmount) * float64(taxRate) / (1 + float64(taxRate))
+
+	switch roundingMethod {
+	case TaxRoundingMethodRoundDown:
+		return int64(math.Floor(taxAmount))
+	case TaxRoundingMethodRoundUp:
+		return int64(math.Ceil(taxAmount))
+	default:
+		return int64(math.Round(taxAmount))
+	}
+}
+
 // ParseTaxRate 税率文字列をパース
 func ParseTaxRate(rateStr string) (TaxRate, error) {
 	switch rateStr {
@@ -0,0 +1,290 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+// FabricUnit 生地の長さの単位（在庫・反物の長さ・用尺・生地単価）
+type FabricUnit string
+
+const (
+	FabricUnitMeter FabricUnit = "m"  // メートル
+	FabricUnitYard  FabricUnit = "yd" // ヤード（輸入生地）
+)
+
+// IsValid 単位が有効かチェック
+func (u FabricUnit) IsValid() bool {
+	switch u {
+	case FabricUnitMeter, FabricUnitYard:
+		return true
+	default:
+		return false
+	}
+}
+
+// 単位の換算係数
+const (
+	centimetersPerInch = 2.54
+	metersPerYard      = 0.9144
+)
+
+// MeasurementUnitKey 採寸データ（JSON）の単位のキー
+const MeasurementUnitKey = "unit"
+
+// nonLengthMeasurementKeys 採寸データのうち長さではない項目（単位の換算をしない）
+var nonLengthMeasurementKeys = map[string]bool{
+	"weight": true, // 体重（kg）
+	"age":    true,
+}
+
+// centimeters 採寸値の単位あたりのセンチメートル
+func (u MeasurementUnit) centimeters() float64 {
+	switch u {
+	case MeasurementUnitMM:
+		return 0.1
+	case MeasurementUnitInch:
+		return centimetersPerInch
+	default:
+		return 1
+	}
+}
+
+// precision 表示する採寸値の丸め単位
+func (u MeasurementUnit) precision() float64 {
+	switch u {
+	case MeasurementUnitMM:
+		return 1
+	case MeasurementUnitInch:
+		return 0.01
+	default:
+		return 0.1
+	}
+}
+
+// FormatCentimeters センチメートルの値をこの単位に換算して書式化（PDF出力用、signed の場合は符号を付ける）
+func (u MeasurementUnit) FormatCentimeters(value float64, signed bool) string {
+	decimals := 1
+	switch u {
+	case MeasurementUnitMM:
+		decimals = 0
+	case MeasurementUnitInch:
+		decimals = 2
+	}
+	format := "%.*f"
+	if signed {
+		format = "%+.*f"
+	}
+	return fmt.Sprintf(format, decimals, u.FromCentimeters(value))
+}
+
+// ToCentimeters 採寸値をセンチメートルに換算（0.01cm単位）
+func (u MeasurementUnit) ToCentimeters(value float64) float64 {
+	return roundUnitValue(value*u.centimeters(), 0.01)
+}
+
+// FromCentimeters センチメートルの採寸値をこの単位に換算（表示用に丸める）
+func (u MeasurementUnit) FromCentimeters(value float64) float64 {
+	return roundUnitValue(value/u.centimeters(), u.precision())
+}
+
+// meters 生地の長さの単位あたりのメートル
+func (u FabricUnit) meters() float64 {
+	if u == FabricUnitYard {
+		return metersPerYard
+	}
+	return 1
+}
+
+// ToMeters 生地の長さをメートルに換算（1mm単位）
+func (u FabricUnit) ToMeters(value float64) float64 {
+	return roundUnitValue(value*u.meters(), 0.001)
+}
+
+// FromMeters メートルの生地の長さをこの単位に換算（表示用に0.01単位で丸める）
+func (u FabricUnit) FromMeters(value float64) float64 {
+	return roundUnitValue(value/u.meters(), 0.01)
+}
+
+// PriceToMeter この単位あたりの生地単価（円）を円/メートルに換算
+func (u FabricUnit) PriceToMeter(price int64) int64 {
+	return int64(math.Round(float64(price) / u.meters()))
+}
+
+// PriceFromMeter 円/メートルの生地単価をこの単位あたりの単価（円）に換算
+func (u FabricUnit) PriceFromMeter(price int64) int64 {
+	return int64(math.Round(float64(price) * u.meters()))
+}
+
+// UnitPreference 表示単位の設定（テナントの既定値を顧客の設定で上書き）
+type UnitPreference struct {
+	MeasurementUnit MeasurementUnit `json:"measurement_unit"` // 採寸値・仕上がり寸法
+	FabricUnit      FabricUnit      `json:"fabric_unit"`      // 生地の長さ・生地単価
+}
+
+// DefaultUnitPreference 表示単位の既定値（cm・m）
+func DefaultUnitPreference() UnitPreference {
+	return UnitPreference{MeasurementUnit: MeasurementUnitCM, FabricUnit: FabricUnitMeter}
+}
+
+// Validate 表示単位の設定をバリデーション
+func (p UnitPreference) Validate() error {
+	if !p.MeasurementUnit.IsValid() {
+		return fmt.Errorf("invalid measurement_unit: %s", p.MeasurementUnit)
+	}
+	if !p.FabricUnit.IsValid() {
+		return fmt.Errorf("invalid fabric_unit: %s", p.FabricUnit)
+	}
+	return nil
+}
+
+// ResolveUnitPreference テナント・顧客の設定から表示単位を決める
+// 採寸値は顧客の設定を優先し、生地の単位はテナントの設定に従う（未設定は既定値）
+func ResolveUnitPreference(tenant *Tenant, customer *Customer) UnitPreference {
+	preference := DefaultUnitPreference()
+	if tenant != nil {
+		if tenant.MeasurementUnit.IsValid() {
+			preference.MeasurementUnit = tenant.MeasurementUnit
+		}
+		if tenant.FabricUnit.IsValid() {
+			preference.FabricUnit = tenant.FabricUnit
+		}
+	}
+	if customer != nil && customer.MeasurementUnit.IsValid() {
+		preference.MeasurementUnit = customer.MeasurementUnit
+	}
+	return preference
+}
+
+// NormalizeMeasurementData 採寸データの長さの項目をセンチメートルに正規化し、単位（"unit": "cm"）を明示する
+// 単位の指定がない採寸データはセンチメートルとみなす。仕上がり寸法など入れ子の項目も換算する
+func NormalizeMeasurementData(data json.RawMessage) (json.RawMessage, error) {
+	if len(data) == 0 {
+		return data, nil
+	}
+	return convertMeasurementData(data, MeasurementUnitCM, func(unit MeasurementUnit, value float64) float64 {
+		return unit.ToCentimeters(value)
+	})
+}
+
+// ConvertMeasurementData センチメートルに正規化した採寸データを表示用の単位に換算
+func ConvertMeasurementData(data json.RawMessage, unit MeasurementUnit) (json.RawMessage, error) {
+	if len(data) == 0 || unit == MeasurementUnitCM {
+		return data, nil
+	}
+	if !unit.IsValid() {
+		return nil, fmt.Errorf("invalid measurement unit: %s", unit)
+	}
+	return convertMeasurementData(data, unit, func(_ MeasurementUnit, value float64) float64 {
+		return unit.FromCentimeters(value)
+	})
+}
+
+// convertMeasurementData 採寸データ（JSONオブジェクト）の長さの項目を換算し、単位を to に設定する
+func convertMeasurementData(data json.RawMessage, to MeasurementUnit, convert func(from MeasurementUnit, value float64) float64) (json.RawMessage, error) {
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("invalid measurements: %w", err)
+	}
+	if fields == nil {
+		return data, nil
+	}
+
+	from := MeasurementUnitCM
+	if raw, ok := fields[MeasurementUnitKey]; ok {
+		unit, _ := raw.(string)
+		from = MeasurementUnit(unit)
+		if !from.IsValid() {
+			return nil, fmt.Errorf("invalid measurement unit: %v", raw)
+		}
+	}
+	delete(fields, MeasurementUnitKey)
+
+	converted := convertMeasurementValue(fields, func(value float64) float64 { return convert(from, value) }).(map[string]interface{})
+	converted[MeasurementUnitKey] = string(to)
+	encoded, err := json.Marshal(converted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal measurements: %w", err)
+	}
+	return encoded, nil
+}
+
+// convertMeasurementValue 採寸データの値を再帰的に換算（長さではない項目・文字列はそのまま）
+func convertMeasurementValue(value interface{}, convert func(float64) float64) interface{} {
+	switch v := value.(type) {
+	case float64:
+		return convert(v)
+	case map[string]interface{}:
+		for key, item := range v {
+			if nonLengthMeasurementKeys[key] {
+				continue
+			}
+			v[key] = convertMeasurementValue(item, convert)
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = convertMeasurementValue(item, convert)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// ConvertUnit 版の採寸データを表示用の単位に換算
+func (v *MeasurementProfileVersion) ConvertUnit(unit MeasurementUnit) error {
+	converted, err := ConvertMeasurementData(v.Measurements, unit)
+	if err != nil {
+		return err
+	}
+	v.Measurements = converted
+	return nil
+}
+
+// InUnit 生地の在庫数量・最小発注数量・単価を表示用の単位に換算したコピー
+func (f *Fabric) InUnit(unit FabricUnit) *Fabric {
+	converted := *f
+	converted.LengthUnit = unit
+	if unit == FabricUnitMeter || !unit.IsValid() {
+		converted.LengthUnit = FabricUnitMeter
+		return &converted
+	}
+	converted.StockAmount = unit.FromMeters(f.StockAmount)
+	converted.MinimumOrder = unit.FromMeters(f.MinimumOrder)
+	converted.Price = unit.PriceFromMeter(f.Price)
+	return &converted
+}
+
+// InUnit 反物の長さを表示用の単位に換算したコピー（幅はセンチメートルのまま）
+func (r *FabricRoll) InUnit(unit FabricUnit) *FabricRoll {
+	converted := *r
+	converted.LengthUnit = unit
+	if unit == FabricUnitMeter || !unit.IsValid() {
+		converted.LengthUnit = FabricUnitMeter
+		return &converted
+	}
+	converted.InitialLength = unit.FromMeters(r.InitialLength)
+	converted.CurrentLength = unit.FromMeters(r.CurrentLength)
+	return &converted
+}
+
+// NormalizePrice 仕入先の単位あたりの単価を円/メートルに正規化
+func (f *Fabric) NormalizePrice(unit FabricUnit) {
+	if !unit.IsValid() {
+		unit = FabricUnitMeter
+	}
+	f.SupplierPriceUnit = unit
+	f.LengthUnit = FabricUnitMeter
+	f.Price = unit.PriceToMeter(f.Price)
+}
+
+// roundUnitValue 換算した値を step 単位に丸める
+func roundUnitValue(value, step float64) float64 {
+	if step >= 1 {
+		return math.Round(value/step) * step
+	}
+	scale := math.Round(1 / step)
+	return math.Round(value*scale) / scale
+}
@@ -0,0 +1,204 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"tailor-cloud/backend/internal/config/domain"
+	"tailor-cloud/backend/internal/middleware"
+	"tailor-cloud/backend/internal/service"
+)
+
+// AccountingHandler 会計連携ハンドラー
+type AccountingHandler struct {
+	accountingExportService *service.AccountingExportService
+}
+
+// NewAccountingHandler AccountingHandlerのコンストラクタ
+func NewAccountingHandler(accountingExportService *service.AccountingExportService) *AccountingHandler {
+	return &AccountingHandler{
+		accountingExportService: accountingExportService,
+	}
+}
+
+// GetAccountMappings GET /api/accounting/account-mappings - 勘定科目マッピングを取得
+func (h *AccountingHandler) GetAccountMappings(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	mappings, err := h.accountingExportService.GetAccountMappings(r.Context(), authUser.TenantID)
+	if err != nil {
+		http.Error(w, "Failed to get account mappings: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"account_mappings": mappings,
+		"total":            len(mappings),
+	})
+}
+
+// UpdateAccountMapping PUT /api/accounting/account-mappings - 勘定科目マッピングを登録・更新
+func (h *AccountingHandler) UpdateAccountMapping(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req service.AccountMappingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+	req.TenantID = authUser.TenantID
+
+	mapping, err := h.accountingExportService.UpdateAccountMapping(r.Context(), &req)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if strings.Contains(err.Error(), "invalid") || strings.Contains(err.Error(), "required") {
+			statusCode = http.StatusBadRequest
+		}
+		http.Error(w, "Failed to update account mapping: "+err.Error(), statusCode)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(mapping)
+}
+
+// GetJournal GET /api/accounting/journal?from=YYYY-MM-DD&to=YYYY-MM-DD - 期間内の仕訳を取得（プレビュー）
+func (h *AccountingHandler) GetJournal(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	period, ok := parseJournalPeriod(w, r, authUser.TenantID)
+	if !ok {
+		return
+	}
+
+	entries, err := h.accountingExportService.BuildJournalEntries(r.Context(), period)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if strings.Contains(err.Error(), "invalid") || strings.Contains(err.Error(), "required") {
+			statusCode = http.StatusBadRequest
+		}
+		http.Error(w, "Failed to build journal entries: "+err.Error(), statusCode)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"entries": entries,
+		"total":   len(entries),
+	})
+}
+
+// ExportJournal GET /api/accounting/journal/export?software=FREEE|MONEYFORWARD|YAYOI&from=...&to=... - 仕訳CSVをダウンロード
+func (h *AccountingHandler) ExportJournal(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	period, ok := parseJournalPeriod(w, r, authUser.TenantID)
+	if !ok {
+		return
+	}
+
+	software := domain.AccountingSoftware(strings.ToUpper(r.URL.Query().Get("software")))
+	data, filename, err := h.accountingExportService.ExportJournal(r.Context(), &service.ExportJournalRequest{
+		JournalPeriodRequest: *period,
+		Software:             software,
+	})
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if strings.Contains(err.Error(), "invalid") || strings.Contains(err.Error(), "required") {
+			statusCode = http.StatusBadRequest
+		}
+		http.Error(w, "Failed to export journal: "+err.Error(), statusCode)
+		return
+	}
+
+	charset := "Shift_JIS"
+	if software == domain.AccountingSoftwareFreee {
+		charset = "UTF-8"
+	}
+	w.Header().Set("Content-Type", "text/csv; charset="+charset)
+	w.Header().Set("Content-Disposition", "attachment; filename=\""+filename+"\"")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// parseJournalPeriod クエリパラメータ from / to（YYYY-MM-DD）を解析
+func parseJournalPeriod(w http.ResponseWriter, r *http.Request, tenantID string) (*service.JournalPeriodRequest, bool) {
+	from, err := time.Parse("2006-01-02", r.URL.Query().Get("from"))
+	if err != nil {
+		http.Error(w, "Invalid from format. Use YYYY-MM-DD", http.StatusBadRequest)
+		return nil, false
+	}
+	to, err := time.Parse("2006-01-02", r.URL.Query().Get("to"))
+	if err != nil {
+		http.Error(w, "Invalid to format. Use YYYY-MM-DD", http.StatusBadRequest)
+		return nil, false
+	}
+
+	return &service.JournalPeriodRequest{
+		TenantID: tenantID,
+		From:     from,
+		To:       to,
+	}, true
+}
@@ -0,0 +1,246 @@
+package handler
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"tailor-cloud/backend/internal/config/domain"
+	"tailor-cloud/backend/internal/middleware"
+	"tailor-cloud/backend/internal/service"
+)
+
+// 入出金明細ファイルの最大サイズ（10MB）
+const maxStatementFileSize = 10 << 20
+
+// BankReconciliationHandler 入金消込ハンドラー
+type BankReconciliationHandler struct {
+	reconciliationService *service.BankReconciliationService
+}
+
+// NewBankReconciliationHandler BankReconciliationHandlerのコンストラクタ
+func NewBankReconciliationHandler(reconciliationService *service.BankReconciliationService) *BankReconciliationHandler {
+	return &BankReconciliationHandler{
+		reconciliationService: reconciliationService,
+	}
+}
+
+// ImportStatement POST /api/bank-statements/import - 入出金明細を取り込み、消込候補を返す
+// multipart/form-data: file（明細ファイル）, format（ZENGIN / CSV）
+func (h *BankReconciliationHandler) ImportStatement(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	if err := r.ParseMultipartForm(maxStatementFileSize); err != nil {
+		http.Error(w, "Invalid multipart form: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		http.Error(w, "file is required", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, maxStatementFileSize))
+	if err != nil {
+		http.Error(w, "Failed to read file: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	format := domain.BankStatementFormat(strings.ToUpper(r.FormValue("format")))
+	if format == "" {
+		format = domain.BankStatementFormatZengin
+	}
+
+	resp, err := h.reconciliationService.ImportStatement(r.Context(), &service.ImportStatementRequest{
+		TenantID: authUser.TenantID,
+		Format:   format,
+		Data:     data,
+	})
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if strings.Contains(err.Error(), "invalid") || strings.Contains(err.Error(), "required") {
+			statusCode = http.StatusBadRequest
+		}
+		http.Error(w, "Failed to import bank statement: "+err.Error(), statusCode)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// ListSuspense GET /api/bank-statements/suspense - 未消込の入金（仮受）一覧を取得
+func (h *BankReconciliationHandler) ListSuspense(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	entries, err := h.reconciliationService.ListSuspense(r.Context(), authUser.TenantID)
+	if err != nil {
+		http.Error(w, "Failed to list suspense entries: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var total int64
+	for _, entry := range entries {
+		total += entry.Amount
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"entries":      entries,
+		"total":        len(entries),
+		"total_amount": total,
+	})
+}
+
+// GetProposals GET /api/bank-statements/proposals - 未消込の入金に対する消込候補を取得（消込画面用）
+func (h *BankReconciliationHandler) GetProposals(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	proposals, err := h.reconciliationService.ProposeMatches(r.Context(), authUser.TenantID)
+	if err != nil {
+		http.Error(w, "Failed to propose matches: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"proposals": proposals,
+		"total":     len(proposals),
+	})
+}
+
+// ConfirmMatch POST /api/bank-statements/{id}/confirm - 消込を確定し入金Transactionを作成
+func (h *BankReconciliationHandler) ConfirmMatch(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req service.ConfirmMatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if id := r.PathValue("id"); id != "" {
+		req.EntryID = id
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+	req.TenantID = authUser.TenantID
+	req.UserID = authUser.ID
+
+	resp, err := h.reconciliationService.ConfirmMatch(r.Context(), &req)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if strings.Contains(err.Error(), "not found") {
+			statusCode = http.StatusNotFound
+		} else if strings.Contains(err.Error(), "already") {
+			statusCode = http.StatusConflict
+		} else if strings.Contains(err.Error(), "unauthorized") {
+			statusCode = http.StatusForbidden
+		} else if strings.Contains(err.Error(), "invalid") || strings.Contains(err.Error(), "required") {
+			statusCode = http.StatusBadRequest
+		}
+		http.Error(w, "Failed to confirm match: "+err.Error(), statusCode)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// IgnoreEntry POST /api/bank-statements/{id}/ignore - 入金を消込対象外にする
+func (h *BankReconciliationHandler) IgnoreEntry(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	entryID := r.PathValue("id")
+	if entryID == "" {
+		http.Error(w, "entry_id is required", http.StatusBadRequest)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	if err := h.reconciliationService.IgnoreEntry(r.Context(), entryID, authUser.TenantID); err != nil {
+		statusCode := http.StatusInternalServerError
+		if strings.Contains(err.Error(), "not found") {
+			statusCode = http.StatusNotFound
+		} else if strings.Contains(err.Error(), "already") {
+			statusCode = http.StatusConflict
+		}
+		http.Error(w, "Failed to ignore entry: "+err.Error(), statusCode)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
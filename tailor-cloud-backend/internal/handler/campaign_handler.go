@@ -0,0 +1,253 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"tailor-cloud/backend/internal/middleware"
+	"tailor-cloud/backend/internal/service"
+)
+
+// CampaignHandler 割引・クーポン・キャンペーンハンドラー
+type CampaignHandler struct {
+	discountService *service.DiscountService
+}
+
+// NewCampaignHandler CampaignHandlerのコンストラクタ
+func NewCampaignHandler(discountService *service.DiscountService) *CampaignHandler {
+	return &CampaignHandler{
+		discountService: discountService,
+	}
+}
+
+// CreateCampaign POST /api/campaigns - キャンペーン・クーポンを作成
+func (h *CampaignHandler) CreateCampaign(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req service.CampaignRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+	req.TenantID = authUser.TenantID
+
+	campaign, err := h.discountService.CreateCampaign(r.Context(), &req)
+	if err != nil {
+		writeCampaignError(w, "Failed to create campaign: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(campaign)
+}
+
+// ListCampaigns GET /api/campaigns - キャンペーン・クーポン一覧を取得
+func (h *CampaignHandler) ListCampaigns(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	campaigns, err := h.discountService.ListCampaigns(r.Context(), authUser.TenantID)
+	if err != nil {
+		http.Error(w, "Failed to list campaigns: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"campaigns": campaigns,
+		"total":     len(campaigns),
+	})
+}
+
+// GetCampaign GET /api/campaigns/{id} - キャンペーン・クーポンを取得
+func (h *CampaignHandler) GetCampaign(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	campaignID := r.PathValue("id")
+	if campaignID == "" {
+		http.Error(w, "campaign_id is required", http.StatusBadRequest)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	campaign, err := h.discountService.GetCampaign(r.Context(), campaignID, authUser.TenantID)
+	if err != nil {
+		writeCampaignError(w, "Failed to get campaign: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(campaign)
+}
+
+// UpdateCampaign PUT /api/campaigns/{id} - キャンペーン・クーポンを更新
+func (h *CampaignHandler) UpdateCampaign(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	campaignID := r.PathValue("id")
+	if campaignID == "" {
+		http.Error(w, "campaign_id is required", http.StatusBadRequest)
+		return
+	}
+
+	var req service.CampaignRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+	req.ID = campaignID
+	req.TenantID = authUser.TenantID
+
+	campaign, err := h.discountService.UpdateCampaign(r.Context(), &req)
+	if err != nil {
+		writeCampaignError(w, "Failed to update campaign: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(campaign)
+}
+
+// PreviewDiscounts POST /api/discounts/preview - 注文に適用される割引を確認（保存しない）
+func (h *CampaignHandler) PreviewDiscounts(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req service.DiscountRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+	req.TenantID = authUser.TenantID
+
+	result, err := h.discountService.EvaluateDiscounts(r.Context(), &req)
+	if err != nil {
+		writeCampaignError(w, "Failed to evaluate discounts: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(result)
+}
+
+// GetOrderDiscounts GET /api/orders/{id}/discounts - 注文の割引明細を取得
+func (h *CampaignHandler) GetOrderDiscounts(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	orderID := r.PathValue("id")
+	if orderID == "" {
+		http.Error(w, "order_id is required", http.StatusBadRequest)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	discounts, err := h.discountService.GetOrderDiscounts(r.Context(), orderID, authUser.TenantID)
+	if err != nil {
+		http.Error(w, "Failed to get order discounts: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"discounts": discounts,
+		"total":     len(discounts),
+	})
+}
+
+// writeCampaignError エラー内容に応じたステータスコードでエラーを返す
+func writeCampaignError(w http.ResponseWriter, prefix string, err error) {
+	statusCode := http.StatusInternalServerError
+	if strings.Contains(err.Error(), "not found") {
+		statusCode = http.StatusNotFound
+	} else if strings.Contains(err.Error(), "invalid") || strings.Contains(err.Error(), "required") {
+		statusCode = http.StatusBadRequest
+	}
+	http.Error(w, prefix+err.Error(), statusCode)
+}
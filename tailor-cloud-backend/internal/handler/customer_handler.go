@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"strings"
 
+	"tailor-cloud/backend/internal/config/domain"
 	"tailor-cloud/backend/internal/middleware"
 	"tailor-cloud/backend/internal/service"
 )
@@ -23,9 +24,10 @@ func NewCustomerHandler(customerService *service.CustomerService) *CustomerHandl
 
 // CreateCustomerRequest 顧客作成リクエスト
 type CreateCustomerRequest struct {
-	Name  string `json:"name"`
-	Email string `json:"email"`
-	Phone string `json:"phone"`
+	Name            string `json:"name"`
+	Email           string `json:"email"`
+	Phone           string `json:"phone"`
+	MeasurementUnit string `json:"measurement_unit"` // 採寸値の表示単位（cm / mm / inch、未指定はテナントの設定）
 }
 
 // CreateCustomer POST /api/customers - 顧客を登録
@@ -54,15 +56,20 @@ func (h *CustomerHandler) CreateCustomer(w http.ResponseWriter, r *http.Request)
 
 	// サービス層で作成
 	serviceReq := &service.CreateCustomerRequest{
-		TenantID: authUser.TenantID,
-		Name:     req.Name,
-		Email:    req.Email,
-		Phone:    req.Phone,
+		TenantID:        authUser.TenantID,
+		Name:            req.Name,
+		Email:           req.Email,
+		Phone:           req.Phone,
+		MeasurementUnit: domain.MeasurementUnit(req.MeasurementUnit),
 	}
 
 	customer, err := h.customerService.CreateCustomer(r.Context(), serviceReq)
 	if err != nil {
-		http.Error(w, "Failed to create customer: "+err.Error(), http.StatusInternalServerError)
+		statusCode := http.StatusInternalServerError
+		if strings.Contains(err.Error(), "invalid") || strings.Contains(err.Error(), "required") {
+			statusCode = http.StatusBadRequest
+		}
+		http.Error(w, "Failed to create customer: "+err.Error(), statusCode)
 		return
 	}
 
@@ -172,9 +179,10 @@ func (h *CustomerHandler) ListCustomers(w http.ResponseWriter, r *http.Request)
 
 // UpdateCustomerRequest 顧客更新リクエスト
 type UpdateCustomerRequest struct {
-	Name  string `json:"name"`
-	Email string `json:"email"`
-	Phone string `json:"phone"`
+	Name            string `json:"name"`
+	Email           string `json:"email"`
+	Phone           string `json:"phone"`
+	MeasurementUnit string `json:"measurement_unit"` // 採寸値の表示単位（cm / mm / inch、未指定はテナントの設定）
 }
 
 // UpdateCustomer PUT /api/customers/{id} - 顧客を更新
@@ -222,11 +230,12 @@ func (h *CustomerHandler) UpdateCustomer(w http.ResponseWriter, r *http.Request)
 
 	// サービス層で更新
 	serviceReq := &service.UpdateCustomerRequest{
-		TenantID:   authUser.TenantID,
-		CustomerID: customerID,
-		Name:       req.Name,
-		Email:      req.Email,
-		Phone:      req.Phone,
+		TenantID:        authUser.TenantID,
+		CustomerID:      customerID,
+		Name:            req.Name,
+		Email:           req.Email,
+		Phone:           req.Phone,
+		MeasurementUnit: domain.MeasurementUnit(req.MeasurementUnit),
 	}
 
 	customer, err := h.customerService.UpdateCustomer(r.Context(), serviceReq)
@@ -234,6 +243,8 @@ func (h *CustomerHandler) UpdateCustomer(w http.ResponseWriter, r *http.Request)
 		statusCode := http.StatusInternalServerError
 		if strings.Contains(err.Error(), "not found") {
 			statusCode = http.StatusNotFound
+		} else if strings.Contains(err.Error(), "invalid") {
+			statusCode = http.StatusBadRequest
 		}
 		http.Error(w, "Failed to update customer: "+err.Error(), statusCode)
 		return
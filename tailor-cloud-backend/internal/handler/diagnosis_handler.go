@@ -216,3 +216,167 @@ func (h *DiagnosisHandler) ListDiagnoses(w http.ResponseWriter, r *http.Request)
 	})
 }
 
+
+// GetDiagnosisQuestions GET /api/diagnoses/questions - 診断の設問を取得
+// クエリパラメータ version で設問のバージョンを指定（未指定は現行バージョン）
+func (h *DiagnosisHandler) GetDiagnosisQuestions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	bank, err := h.diagnosisService.GetQuestionBank(r.URL.Query().Get("version"))
+	if err != nil {
+		writeDiagnosisError(w, "Failed to get diagnosis questions: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(bank)
+}
+
+// ScoreDiagnosisRequest 回答による診断リクエスト
+type ScoreDiagnosisRequest struct {
+	UserID           string                   `json:"user_id"`
+	AlgorithmVersion string                   `json:"algorithm_version,omitempty"`
+	Answers          []domain.DiagnosisAnswer `json:"answers"`
+}
+
+// ScoreDiagnosis POST /api/diagnoses/score - 回答をサーバー側で採点して診断を作成
+func (h *DiagnosisHandler) ScoreDiagnosis(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req ScoreDiagnosisRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	diagnosis, err := h.diagnosisService.ScoreDiagnosis(r.Context(), &service.ScoreDiagnosisRequest{
+		UserID:           req.UserID,
+		TenantID:         authUser.TenantID,
+		AlgorithmVersion: req.AlgorithmVersion,
+		Answers:          req.Answers,
+	})
+	if err != nil {
+		writeDiagnosisError(w, "Failed to score diagnosis: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(diagnosis)
+}
+
+// RescoreDiagnosisRequest 再採点リクエスト
+type RescoreDiagnosisRequest struct {
+	AlgorithmVersion string `json:"algorithm_version,omitempty"`
+}
+
+// RescoreDiagnosis POST /api/diagnoses/{id}/rescore - 保存された回答で診断を再採点
+func (h *DiagnosisHandler) RescoreDiagnosis(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	diagnosisID := r.PathValue("id")
+	if diagnosisID == "" {
+		http.Error(w, "diagnosis_id is required", http.StatusBadRequest)
+		return
+	}
+
+	// リクエストボディは任意（未指定は現行バージョン）
+	var req RescoreDiagnosisRequest
+	if r.ContentLength > 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	result, err := h.diagnosisService.RescoreDiagnosis(r.Context(), diagnosisID, authUser.TenantID, req.AlgorithmVersion)
+	if err != nil {
+		writeDiagnosisError(w, "Failed to rescore diagnosis: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(result)
+}
+
+// RescoreDiagnoses POST /api/diagnoses/rescore - テナントの診断をすべて再採点
+func (h *DiagnosisHandler) RescoreDiagnoses(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// リクエストボディは任意（未指定は現行バージョン）
+	var req RescoreDiagnosisRequest
+	if r.ContentLength > 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	summary, err := h.diagnosisService.RescoreTenantDiagnoses(r.Context(), authUser.TenantID, req.AlgorithmVersion)
+	if err != nil {
+		writeDiagnosisError(w, "Failed to rescore diagnoses: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(summary)
+}
+
+// writeDiagnosisError エラー内容に応じたステータスコードでエラーを返す
+func writeDiagnosisError(w http.ResponseWriter, prefix string, err error) {
+	statusCode := http.StatusInternalServerError
+	if strings.Contains(err.Error(), "not found") {
+		statusCode = http.StatusNotFound
+	} else if strings.Contains(err.Error(), "invalid") || strings.Contains(err.Error(), "required") {
+		statusCode = http.StatusBadRequest
+	}
+	http.Error(w, prefix+err.Error(), statusCode)
+}
@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"tailor-cloud/backend/internal/middleware"
+	"tailor-cloud/backend/internal/service"
+)
+
+// EInvoiceHandler 電子インボイス（JP PINT / Peppol）ハンドラー
+type EInvoiceHandler struct {
+	eInvoiceService *service.EInvoiceService
+}
+
+// NewEInvoiceHandler EInvoiceHandlerのコンストラクタ
+func NewEInvoiceHandler(eInvoiceService *service.EInvoiceService) *EInvoiceHandler {
+	return &EInvoiceHandler{
+		eInvoiceService: eInvoiceService,
+	}
+}
+
+// DownloadEInvoice GET /api/orders/{id}/e-invoice - JP PINT UBL 2.1 XMLをダウンロード
+// 検証エラーがある場合は 422 で違反内容を返す
+func (h *EInvoiceHandler) DownloadEInvoice(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	orderID := r.PathValue("id")
+	if orderID == "" {
+		http.Error(w, "order_id is required", http.StatusBadRequest)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	doc, err := h.eInvoiceService.GenerateJPPINT(r.Context(), orderID, authUser.TenantID)
+	if err != nil {
+		writeEInvoiceError(w, "Failed to generate e-invoice: ", err)
+		return
+	}
+
+	if !doc.Valid() {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"valid":      false,
+			"violations": doc.Violations,
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/xml; charset=UTF-8")
+	w.Header().Set("Content-Disposition", "attachment; filename=\""+doc.Filename+"\"")
+	w.WriteHeader(http.StatusOK)
+	w.Write(doc.XML)
+}
+
+// ValidateEInvoice GET /api/orders/{id}/e-invoice/validate - 電子インボイスの検証結果を取得
+func (h *EInvoiceHandler) ValidateEInvoice(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	orderID := r.PathValue("id")
+	if orderID == "" {
+		http.Error(w, "order_id is required", http.StatusBadRequest)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	doc, err := h.eInvoiceService.GenerateJPPINT(r.Context(), orderID, authUser.TenantID)
+	if err != nil {
+		writeEInvoiceError(w, "Failed to generate e-invoice: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"valid":      doc.Valid(),
+		"violations": doc.Violations,
+		"invoice":    doc.Invoice,
+	})
+}
+
+// SendEInvoice POST /api/orders/{id}/e-invoice/send - Peppolアクセスポイントへ送信
+func (h *EInvoiceHandler) SendEInvoice(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	orderID := r.PathValue("id")
+	if orderID == "" {
+		http.Error(w, "order_id is required", http.StatusBadRequest)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	resp, err := h.eInvoiceService.SendJPPINT(r.Context(), orderID, authUser.TenantID)
+	if err != nil {
+		writeEInvoiceError(w, "Failed to send e-invoice: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// writeEInvoiceError エラー内容に応じたステータスコードで返す
+func writeEInvoiceError(w http.ResponseWriter, prefix string, err error) {
+	statusCode := http.StatusInternalServerError
+	if strings.Contains(err.Error(), "not found") {
+		statusCode = http.StatusNotFound
+	} else if strings.Contains(err.Error(), "invalid e-invoice") {
+		statusCode = http.StatusUnprocessableEntity
+	} else if strings.Contains(err.Error(), "invalid") {
+		statusCode = http.StatusBadRequest
+	}
+	http.Error(w, prefix+err.Error(), statusCode)
+}
@@ -13,12 +13,14 @@ import (
 // FabricHandler 生地ハンドラー
 type FabricHandler struct {
 	fabricService *service.FabricService
+	unitService   *service.UnitService // 表示単位（nilの場合はm）
 }
 
 // NewFabricHandler FabricHandlerのコンストラクタ
-func NewFabricHandler(fabricService *service.FabricService) *FabricHandler {
+func NewFabricHandler(fabricService *service.FabricService, unitService *service.UnitService) *FabricHandler {
 	return &FabricHandler{
 		fabricService: fabricService,
+		unitService:   unitService,
 	}
 }
 
@@ -84,10 +86,18 @@ func (h *FabricHandler) ListFabrics(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	
+	// テナントの表示単位に換算
+	unit := h.unitService.GetPreference(r.Context(), tenantID, "").FabricUnit
+	converted := make([]*domain.Fabric, 0, len(fabrics))
+	for _, fabric := range fabrics {
+		converted = append(converted, fabric.InUnit(unit))
+	}
+	
 	// レスポンス
 	response := map[string]interface{}{
-		"fabrics": fabrics,
-		"total":   len(fabrics),
+		"fabrics":     converted,
+		"total":       len(fabrics),
+		"length_unit": unit,
 	}
 	
 	w.Header().Set("Content-Type", "application/json")
@@ -148,14 +158,15 @@ func (h *FabricHandler) GetFabric(w http.ResponseWriter, r *http.Request) {
 	
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(fabric)
+	json.NewEncoder(w).Encode(fabric.InUnit(h.unitService.GetPreference(r.Context(), tenantID, "").FabricUnit))
 }
 
 // ReserveFabricRequest 生地確保リクエスト
 type ReserveFabricRequest struct {
-	FabricID string  `json:"fabric_id"`
-	TenantID string  `json:"tenant_id"`
-	Amount   float64 `json:"amount"` // 確保したい数量（メートル）
+	FabricID   string  `json:"fabric_id"`
+	TenantID   string  `json:"tenant_id"`
+	Amount     float64 `json:"amount"`                // 確保したい数量（length_unit の単位）
+	LengthUnit string  `json:"length_unit,omitempty"` // 数量の単位（m / yd、未指定はm）
 }
 
 // ReserveFabric POST /api/fabrics/{fabric_id}/reserve - 生地を確保（発注フロー開始）
@@ -200,12 +211,17 @@ func (h *FabricHandler) ReserveFabric(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "amount must be greater than 0", http.StatusBadRequest)
 		return
 	}
+	amount, err := normalizeFabricLength(req.Amount, req.LengthUnit)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 	
 	// サービス層で確保
 	reserveReq := &service.ReserveFabricRequest{
 		FabricID: req.FabricID,
 		TenantID: tenantID,
-		Amount:   req.Amount,
+		Amount:   amount,
 	}
 	
 	if err := h.fabricService.ReserveFabric(r.Context(), reserveReq); err != nil {
@@ -230,3 +246,54 @@ func (h *FabricHandler) ReserveFabric(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
+// UpdateFabricAttributes PUT /api/fabrics/{id}/attributes - 生地の属性（生地レコメンド用）を更新
+func (h *FabricHandler) UpdateFabricAttributes(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	fabricID := r.PathValue("id")
+	if fabricID == "" {
+		http.Error(w, "fabric_id is required", http.StatusBadRequest)
+		return
+	}
+
+	var attributes domain.FabricAttributes
+	if err := json.NewDecoder(r.Body).Decode(&attributes); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	fabric, err := h.fabricService.UpdateFabricAttributes(r.Context(), &service.UpdateFabricAttributesRequest{
+		FabricID:   fabricID,
+		TenantID:   authUser.TenantID,
+		Attributes: attributes,
+		UpdatedBy:  authUser.ID,
+	})
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if strings.Contains(err.Error(), "not found") {
+			statusCode = http.StatusNotFound
+		} else if strings.Contains(err.Error(), "invalid") || strings.Contains(err.Error(), "required") {
+			statusCode = http.StatusBadRequest
+		}
+		http.Error(w, "Failed to update fabric attributes: "+err.Error(), statusCode)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(fabric.InUnit(h.unitService.GetPreference(r.Context(), authUser.TenantID, "").FabricUnit))
+}
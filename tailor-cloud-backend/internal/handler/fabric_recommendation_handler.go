@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"tailor-cloud/backend/internal/config/domain"
+	"tailor-cloud/backend/internal/middleware"
+	"tailor-cloud/backend/internal/service"
+)
+
+// FabricRecommendationHandler 生地レコメンドハンドラー
+type FabricRecommendationHandler struct {
+	recommendationService *service.FabricRecommendationService
+}
+
+// NewFabricRecommendationHandler FabricRecommendationHandlerのコンストラクタ
+func NewFabricRecommendationHandler(recommendationService *service.FabricRecommendationService) *FabricRecommendationHandler {
+	return &FabricRecommendationHandler{
+		recommendationService: recommendationService,
+	}
+}
+
+// RecommendFabrics GET /api/customers/{id}/fabric-recommendations - 顧客へのおすすめの生地を取得
+// クエリパラメータ plan_type（未指定は診断のプラン）、budget（スーツ1着分の生地代の上限、円）、limit
+func (h *FabricRecommendationHandler) RecommendFabrics(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	customerID := r.PathValue("id")
+	if customerID == "" {
+		http.Error(w, "customer_id is required", http.StatusBadRequest)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	req := &service.RecommendFabricsRequest{
+		TenantID:   authUser.TenantID,
+		CustomerID: customerID,
+		PlanType:   domain.PlanType(r.URL.Query().Get("plan_type")),
+	}
+	if budgetStr := r.URL.Query().Get("budget"); budgetStr != "" {
+		budget, err := strconv.ParseInt(budgetStr, 10, 64)
+		if err != nil {
+			http.Error(w, "invalid budget: "+budgetStr, http.StatusBadRequest)
+			return
+		}
+		req.Budget = budget
+	}
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if parsed, err := strconv.Atoi(limitStr); err == nil && parsed > 0 {
+			req.Limit = parsed
+		}
+	}
+
+	result, err := h.recommendationService.RecommendFabrics(r.Context(), req)
+	if err != nil {
+		writeFabricRecommendationError(w, "Failed to recommend fabrics: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(result)
+}
+
+// GetWeights GET /api/settings/fabric-recommendation - 生地レコメンドの重みを取得
+func (h *FabricRecommendationHandler) GetWeights(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	weights, err := h.recommendationService.GetWeights(r.Context(), authUser.TenantID)
+	if err != nil {
+		writeFabricRecommendationError(w, "Failed to get fabric recommendation weights: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(weights)
+}
+
+// UpdateWeights PUT /api/settings/fabric-recommendation - 生地レコメンドの重みを更新
+func (h *FabricRecommendationHandler) UpdateWeights(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req service.UpdateFabricRecommendationWeightsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+	req.TenantID = authUser.TenantID
+	req.UpdatedBy = authUser.ID
+
+	weights, err := h.recommendationService.UpdateWeights(r.Context(), &req)
+	if err != nil {
+		writeFabricRecommendationError(w, "Failed to update fabric recommendation weights: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(weights)
+}
+
+// writeFabricRecommendationError エラー内容に応じたステータスコードでエラーを返す
+func writeFabricRecommendationError(w http.ResponseWriter, prefix string, err error) {
+	statusCode := http.StatusInternalServerError
+	if strings.Contains(err.Error(), "not found") {
+		statusCode = http.StatusNotFound
+	} else if strings.Contains(err.Error(), "invalid") || strings.Contains(err.Error(), "required") {
+		statusCode = http.StatusBadRequest
+	}
+	http.Error(w, prefix+err.Error(), statusCode)
+}
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"strings"
 	"time"
@@ -10,17 +11,20 @@ import (
 	"tailor-cloud/backend/internal/config/domain"
 	"tailor-cloud/backend/internal/middleware"
 	"tailor-cloud/backend/internal/repository"
+	"tailor-cloud/backend/internal/service"
 )
 
 // FabricRollHandler 反物（Roll）管理ハンドラー
 type FabricRollHandler struct {
-	rollRepo repository.FabricRollRepository
+	rollRepo    repository.FabricRollRepository
+	unitService *service.UnitService // 表示単位（nilの場合はm）
 }
 
 // NewFabricRollHandler FabricRollHandlerのコンストラクタ
-func NewFabricRollHandler(rollRepo repository.FabricRollRepository) *FabricRollHandler {
+func NewFabricRollHandler(rollRepo repository.FabricRollRepository, unitService *service.UnitService) *FabricRollHandler {
 	return &FabricRollHandler{
-		rollRepo: rollRepo,
+		rollRepo:    rollRepo,
+		unitService: unitService,
 	}
 }
 
@@ -29,7 +33,9 @@ type CreateFabricRollRequest struct {
 	FabricID      string   `json:"fabric_id"`
 	RollNumber    string   `json:"roll_number"`
 	InitialLength float64  `json:"initial_length"`
+	LengthUnit    string   `json:"length_unit,omitempty"` // 長さの単位（m / yd、未指定はm）
 	Width         *float64 `json:"width,omitempty"`
+	WidthUnit     string   `json:"width_unit,omitempty"` // 幅の単位（cm / mm / inch、未指定はcm）
 	SupplierLotNo *string  `json:"supplier_lot_no,omitempty"`
 	ReceivedAt    *string  `json:"received_at,omitempty"` // ISO 8601形式
 	Location      *string  `json:"location,omitempty"`
@@ -62,6 +68,18 @@ func (h *FabricRollHandler) CreateFabricRoll(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	// 長さはm、幅はcmに正規化して保存
+	initialLength, err := normalizeFabricLength(req.InitialLength, req.LengthUnit)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	width, err := normalizeFabricWidth(req.Width, req.WidthUnit)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	// 認証済みユーザー情報をコンテキストから取得
 	authUser, err := middleware.GetUserFromContext(r.Context())
 	if err != nil {
@@ -79,9 +97,9 @@ func (h *FabricRollHandler) CreateFabricRoll(w http.ResponseWriter, r *http.Requ
 		TenantID:      authUser.TenantID,
 		FabricID:      req.FabricID,
 		RollNumber:    req.RollNumber,
-		InitialLength: req.InitialLength,
-		CurrentLength: req.InitialLength, // 初期は初期長さと同じ
-		Width:         req.Width,
+		InitialLength: initialLength,
+		CurrentLength: initialLength, // 初期は初期長さと同じ
+		Width:         width,
 		SupplierLotNo: req.SupplierLotNo,
 		Location:      req.Location,
 		Status:        domain.FabricRollStatusAvailable,
@@ -106,7 +124,7 @@ func (h *FabricRollHandler) CreateFabricRoll(w http.ResponseWriter, r *http.Requ
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(roll)
+	json.NewEncoder(w).Encode(roll.InUnit(h.fabricUnit(r, authUser.TenantID)))
 }
 
 // GetFabricRoll GET /api/fabric-rolls/{id} - 反物詳細を取得
@@ -159,7 +177,7 @@ func (h *FabricRollHandler) GetFabricRoll(w http.ResponseWriter, r *http.Request
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(roll)
+	json.NewEncoder(w).Encode(roll.InUnit(h.fabricUnit(r, authUser.TenantID)))
 }
 
 // ListFabricRolls GET /api/fabric-rolls - 反物一覧を取得
@@ -200,9 +218,17 @@ func (h *FabricRollHandler) ListFabricRolls(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	// テナントの表示単位に換算
+	unit := h.fabricUnit(r, authUser.TenantID)
+	converted := make([]*domain.FabricRoll, 0, len(rolls))
+	for _, roll := range rolls {
+		converted = append(converted, roll.InUnit(unit))
+	}
+
 	response := map[string]interface{}{
-		"rolls": rolls,
-		"total": len(rolls),
+		"rolls":       converted,
+		"total":       len(rolls),
+		"length_unit": unit,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -214,6 +240,7 @@ func (h *FabricRollHandler) ListFabricRolls(w http.ResponseWriter, r *http.Reque
 type UpdateFabricRollRequest struct {
 	RollNumber    *string  `json:"roll_number,omitempty"`
 	Width         *float64 `json:"width,omitempty"`
+	WidthUnit     string   `json:"width_unit,omitempty"` // 幅の単位（cm / mm / inch、未指定はcm）
 	Location      *string  `json:"location,omitempty"`
 	Status        *string  `json:"status,omitempty"`
 	Notes         *string  `json:"notes,omitempty"`
@@ -278,7 +305,12 @@ func (h *FabricRollHandler) UpdateFabricRoll(w http.ResponseWriter, r *http.Requ
 		roll.RollNumber = *req.RollNumber
 	}
 	if req.Width != nil {
-		roll.Width = req.Width
+		width, err := normalizeFabricWidth(req.Width, req.WidthUnit)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		roll.Width = width
 	}
 	if req.Location != nil {
 		roll.Location = req.Location
@@ -297,6 +329,35 @@ func (h *FabricRollHandler) UpdateFabricRoll(w http.ResponseWriter, r *http.Requ
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(roll)
+	json.NewEncoder(w).Encode(roll.InUnit(h.fabricUnit(r, authUser.TenantID)))
+}
+
+// fabricUnit テナントの生地の表示単位
+func (h *FabricRollHandler) fabricUnit(r *http.Request, tenantID string) domain.FabricUnit {
+	return h.unitService.GetPreference(r.Context(), tenantID, "").FabricUnit
+}
+
+// normalizeFabricLength 生地の長さをメートルに正規化（単位の指定がない場合はm）
+func normalizeFabricLength(length float64, unit string) (float64, error) {
+	if unit == "" {
+		return length, nil
+	}
+	fabricUnit := domain.FabricUnit(unit)
+	if !fabricUnit.IsValid() {
+		return 0, fmt.Errorf("invalid length_unit: %s", unit)
+	}
+	return fabricUnit.ToMeters(length), nil
 }
 
+// normalizeFabricWidth 生地の幅をセンチメートルに正規化（単位の指定がない場合はcm）
+func normalizeFabricWidth(width *float64, unit string) (*float64, error) {
+	if width == nil || unit == "" {
+		return width, nil
+	}
+	measurementUnit := domain.MeasurementUnit(unit)
+	if !measurementUnit.IsValid() {
+		return nil, fmt.Errorf("invalid width_unit: %s", unit)
+	}
+	normalized := measurementUnit.ToCentimeters(*width)
+	return &normalized, nil
+}
@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"tailor-cloud/backend/internal/config/domain"
+	"tailor-cloud/backend/internal/middleware"
+	"tailor-cloud/backend/internal/service"
+)
+
+// FitCorrectionHandler 体型補正ハンドラー
+type FitCorrectionHandler struct {
+	orderService *service.OrderService
+}
+
+// NewFitCorrectionHandler FitCorrectionHandlerのコンストラクタ
+func NewFitCorrectionHandler(orderService *service.OrderService) *FitCorrectionHandler {
+	return &FitCorrectionHandler{
+		orderService: orderService,
+	}
+}
+
+// ListFitCorrections GET /api/fit-corrections - 体型補正のカタログを取得
+func (h *FitCorrectionHandler) ListFitCorrections(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"version":         domain.OrderAdjustmentsVersion,
+		"fit_corrections": domain.FitCorrectionCatalog,
+	})
+}
+
+// GetOrderAdjustments GET /api/orders/{id}/adjustments - 注文の補正情報を取得
+func (h *FitCorrectionHandler) GetOrderAdjustments(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	result, err := h.orderService.GetAdjustments(r.Context(), r.PathValue("id"), authUser.TenantID)
+	if err != nil {
+		writeFitCorrectionError(w, "Failed to get adjustments: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(result)
+}
+
+// UpdateOrderAdjustments PUT /api/orders/{id}/adjustments - 注文の補正情報（体型補正・仕上がり寸法の加減）を更新
+// リクエストボディは補正情報そのもの（{"fit_corrections": [...], "measurements": {...}}）
+func (h *FitCorrectionHandler) UpdateOrderAdjustments(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	result, err := h.orderService.UpdateAdjustments(r.Context(), &service.UpdateOrderAdjustmentsRequest{
+		OrderID:     r.PathValue("id"),
+		TenantID:    authUser.TenantID,
+		Adjustments: body,
+		UserID:      authUser.ID,
+		IPAddress:   extractIPAddress(r),
+		UserAgent:   r.UserAgent(),
+	})
+	if err != nil {
+		writeFitCorrectionError(w, "Failed to update adjustments: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(result)
+}
+
+// writeFitCorrectionError エラー内容に応じたステータスコードでエラーを返す
+func writeFitCorrectionError(w http.ResponseWriter, prefix string, err error) {
+	statusCode := http.StatusInternalServerError
+	if strings.Contains(err.Error(), "not found") {
+		statusCode = http.StatusNotFound
+	} else if strings.Contains(err.Error(), "unauthorized") {
+		statusCode = http.StatusForbidden
+	} else if strings.Contains(err.Error(), "invalid") || strings.Contains(err.Error(), "required") {
+		statusCode = http.StatusBadRequest
+	}
+	http.Error(w, prefix+err.Error(), statusCode)
+}
@@ -0,0 +1,365 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"tailor-cloud/backend/internal/config/domain"
+	"tailor-cloud/backend/internal/middleware"
+	"tailor-cloud/backend/internal/service"
+)
+
+// GroupOrderHandler 団体注文（法人・ユニフォーム契約）ハンドラー
+type GroupOrderHandler struct {
+	groupOrderService *service.GroupOrderService
+}
+
+// NewGroupOrderHandler GroupOrderHandlerのコンストラクタ
+func NewGroupOrderHandler(groupOrderService *service.GroupOrderService) *GroupOrderHandler {
+	return &GroupOrderHandler{
+		groupOrderService: groupOrderService,
+	}
+}
+
+// CreateGroupOrder POST /api/group-orders - 団体注文を作成
+func (h *GroupOrderHandler) CreateGroupOrder(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req service.CreateGroupOrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+	req.TenantID = authUser.TenantID
+	req.CreatedBy = authUser.ID
+
+	groupOrder, err := h.groupOrderService.CreateGroupOrder(r.Context(), &req)
+	if err != nil {
+		writeGroupOrderError(w, "Failed to create group order: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(groupOrder)
+}
+
+// ListGroupOrders GET /api/group-orders - 団体注文一覧を取得（status で絞り込み可）
+func (h *GroupOrderHandler) ListGroupOrders(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	status := domain.GroupOrderStatus(strings.ToUpper(r.URL.Query().Get("status")))
+	groupOrders, err := h.groupOrderService.ListGroupOrders(r.Context(), authUser.TenantID, status)
+	if err != nil {
+		writeGroupOrderError(w, "Failed to list group orders: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"group_orders": groupOrders,
+		"total":        len(groupOrders),
+	})
+}
+
+// GetGroupOrder GET /api/group-orders/{id} - 団体注文を取得
+func (h *GroupOrderHandler) GetGroupOrder(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	groupOrderID := r.PathValue("id")
+	if groupOrderID == "" {
+		http.Error(w, "group_order_id is required", http.StatusBadRequest)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	groupOrder, err := h.groupOrderService.GetGroupOrder(r.Context(), groupOrderID, authUser.TenantID)
+	if err != nil {
+		writeGroupOrderError(w, "Failed to get group order: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(groupOrder)
+}
+
+// CloseGroupOrder POST /api/group-orders/{id}/close - 団体注文の受付を締め切る
+func (h *GroupOrderHandler) CloseGroupOrder(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	groupOrderID := r.PathValue("id")
+	if groupOrderID == "" {
+		http.Error(w, "group_order_id is required", http.StatusBadRequest)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	groupOrder, err := h.groupOrderService.CloseGroupOrder(r.Context(), groupOrderID, authUser.TenantID)
+	if err != nil {
+		writeGroupOrderError(w, "Failed to close group order: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(groupOrder)
+}
+
+// AddMember POST /api/group-orders/{id}/members - 着用者の注文を追加（Draft）
+func (h *GroupOrderHandler) AddMember(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	groupOrderID := r.PathValue("id")
+	if groupOrderID == "" {
+		http.Error(w, "group_order_id is required", http.StatusBadRequest)
+		return
+	}
+
+	var req service.AddGroupOrderMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+	req.GroupOrderID = groupOrderID
+	req.TenantID = authUser.TenantID
+	req.CreatedBy = authUser.ID
+	req.CreatedByRole = domain.UserRole(authUser.Role)
+	req.IPAddress = extractIPAddress(r)
+	req.UserAgent = r.UserAgent()
+
+	order, err := h.groupOrderService.AddMember(r.Context(), &req)
+	if err != nil {
+		writeGroupOrderError(w, "Failed to add group order member: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(order)
+}
+
+// AllocateFabric POST /api/group-orders/{id}/allocate - 着用者全員分の生地をまとめて引当
+func (h *GroupOrderHandler) AllocateFabric(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	groupOrderID := r.PathValue("id")
+	if groupOrderID == "" {
+		http.Error(w, "group_order_id is required", http.StatusBadRequest)
+		return
+	}
+
+	var req service.AllocateGroupFabricRequest
+	if r.ContentLength > 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+	req.GroupOrderID = groupOrderID
+	req.TenantID = authUser.TenantID
+
+	resp, err := h.groupOrderService.AllocateFabric(r.Context(), &req)
+	if err != nil {
+		writeGroupOrderError(w, "Failed to allocate fabric: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// GetInvoice GET /api/group-orders/{id}/invoice - 法人宛ての一括請求書を取得
+// format=jppint の場合は電子インボイス（JP PINT UBL 2.1 XML）をダウンロード（検証エラーは 422）
+func (h *GroupOrderHandler) GetInvoice(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	groupOrderID := r.PathValue("id")
+	if groupOrderID == "" {
+		http.Error(w, "group_order_id is required", http.StatusBadRequest)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	if strings.EqualFold(r.URL.Query().Get("format"), "jppint") {
+		doc, err := h.groupOrderService.GenerateJPPINT(r.Context(), groupOrderID, authUser.TenantID)
+		if err != nil {
+			writeGroupOrderError(w, "Failed to generate e-invoice: ", err)
+			return
+		}
+
+		if !doc.Valid() {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"valid":      false,
+				"violations": doc.Violations,
+			})
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/xml; charset=UTF-8")
+		w.Header().Set("Content-Disposition", "attachment; filename=\""+doc.Filename+"\"")
+		w.WriteHeader(http.StatusOK)
+		w.Write(doc.XML)
+		return
+	}
+
+	invoice, err := h.groupOrderService.BuildInvoice(r.Context(), groupOrderID, authUser.TenantID)
+	if err != nil {
+		writeGroupOrderError(w, "Failed to build invoice: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(invoice)
+}
+
+// GetProgress GET /api/group-orders/{id}/progress - 団体注文の進捗（ステータスごとの着数）を取得
+func (h *GroupOrderHandler) GetProgress(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	groupOrderID := r.PathValue("id")
+	if groupOrderID == "" {
+		http.Error(w, "group_order_id is required", http.StatusBadRequest)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	progress, err := h.groupOrderService.GetProgress(r.Context(), groupOrderID, authUser.TenantID)
+	if err != nil {
+		writeGroupOrderError(w, "Failed to get group order progress: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(progress)
+}
+
+// writeGroupOrderError エラー内容に応じたステータスコードでエラーを返す
+func writeGroupOrderError(w http.ResponseWriter, prefix string, err error) {
+	statusCode := http.StatusInternalServerError
+	if strings.Contains(err.Error(), "not found") {
+		statusCode = http.StatusNotFound
+	} else if strings.Contains(err.Error(), "invalid group order status") || strings.Contains(err.Error(), "insufficient inventory") {
+		statusCode = http.StatusConflict
+	} else if strings.Contains(err.Error(), "unauthorized") {
+		statusCode = http.StatusForbidden
+	} else if strings.Contains(err.Error(), "invalid") || strings.Contains(err.Error(), "required") {
+		statusCode = http.StatusBadRequest
+	}
+	http.Error(w, prefix+err.Error(), statusCode)
+}
@@ -2,8 +2,10 @@ package handler
 
 import (
 	"encoding/json"
+	"fmt"
 	"net"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -29,6 +31,7 @@ type CreateOrderRequest struct {
 	TenantID     string `json:"tenant_id"`
 	CustomerID   string `json:"customer_id"`
 	FabricID     string `json:"fabric_id"`
+	GarmentType  string `json:"garment_type"` // 品目（SUIT, JACKET, TROUSERS, VEST, COAT, SHIRT、省略可）
 	TotalAmount  int64  `json:"total_amount"`
 	DeliveryDate string `json:"delivery_date"` // ISO 8601形式 (例: "2025-12-31T00:00:00Z")
 	Details      struct {
@@ -37,6 +40,15 @@ type CreateOrderRequest struct {
 		Description     string          `json:"description"` // 給付の内容（コンプライアンス用）
 	} `json:"details"`
 	CreatedBy string `json:"created_by"`
+
+	// 価格計算用（サーバー側で計算した金額と total_amount を照合する）
+	PlanType            domain.PlanType `json:"plan_type"`
+	FabricLength        float64         `json:"fabric_length"`
+	OptionCodes         []string        `json:"option_codes"`
+	PriceOverrideReason string          `json:"price_override_reason"` // 計算価格と異なる金額で作成する場合の理由（Ownerのみ）
+
+	// 割引（total_amount は割引前の金額）
+	CouponCodes []string `json:"coupon_codes"`
 }
 
 // CreateOrder POST /api/orders - 注文を作成
@@ -89,6 +101,7 @@ func (h *OrderHandler) CreateOrder(w http.ResponseWriter, r *http.Request) {
 		TenantID:     tenantID,
 		CustomerID:   req.CustomerID,
 		FabricID:     req.FabricID,
+		GarmentType:  domain.GarmentType(req.GarmentType),
 		TotalAmount:  req.TotalAmount,
 		DeliveryDate: deliveryDate,
 		Details: &domain.OrderDetails{
@@ -99,12 +112,25 @@ func (h *OrderHandler) CreateOrder(w http.ResponseWriter, r *http.Request) {
 		CreatedBy: authUser.ID, // 認証済みユーザーIDを使用
 		IPAddress: extractIPAddress(r),
 		UserAgent: r.UserAgent(),
+
+		PlanType:            req.PlanType,
+		FabricLength:        req.FabricLength,
+		OptionCodes:         req.OptionCodes,
+		PriceOverrideReason: req.PriceOverrideReason,
+		CreatedByRole:       domain.UserRole(authUser.Role),
+		CouponCodes:         req.CouponCodes,
 	}
 
 	// サービス層で注文を作成
 	order, err := h.orderService.CreateOrder(r.Context(), serviceReq)
 	if err != nil {
-		http.Error(w, "Failed to create order: "+err.Error(), http.StatusInternalServerError)
+		statusCode := http.StatusInternalServerError
+		if strings.Contains(err.Error(), "unauthorized") {
+			statusCode = http.StatusForbidden
+		} else if strings.Contains(err.Error(), "not found") || strings.Contains(err.Error(), "invalid") || strings.Contains(err.Error(), "required") {
+			statusCode = http.StatusBadRequest
+		}
+		http.Error(w, "Failed to create order: "+err.Error(), statusCode)
 		return
 	}
 
@@ -214,28 +240,135 @@ func (h *OrderHandler) GetOrder(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(order)
 }
 
-// ListOrders GET /api/orders?tenant_id={tenant_id} - 注文一覧を取得
+// ListOrders GET /api/orders - 注文を検索（カーソル方式のページネーション）
+// 絞り込み: status（カンマ区切りで複数指定可）, customer_id, fabric_id, created_by, group_order_id,
+// delivery_from, delivery_to（YYYY-MM-DD または RFC3339、delivery_to の日付指定は当日を含む）,
+// min_amount, max_amount
+// 並び替え: sort（created_at, delivery_date, total_amount）, order（asc, desc）
+// ページネーション: cursor（前回レスポンスの next_cursor）, limit（最大100）
 func (h *OrderHandler) ListOrders(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
-	tenantID := r.URL.Query().Get("tenant_id")
-	if tenantID == "" {
-		http.Error(w, "tenant_id is required", http.StatusBadRequest)
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "tenant_id is required", http.StatusBadRequest)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	filter, err := parseOrderSearchFilter(r)
+	if err != nil {
+		http.Error(w, "Invalid query parameter: "+err.Error(), http.StatusBadRequest)
 		return
 	}
+	filter.TenantID = authUser.TenantID
 
-	orders, err := h.orderService.ListOrders(r.Context(), tenantID)
+	result, err := h.orderService.SearchOrders(r.Context(), filter)
 	if err != nil {
-		http.Error(w, "Failed to list orders: "+err.Error(), http.StatusInternalServerError)
+		statusCode := http.StatusInternalServerError
+		if strings.Contains(err.Error(), "invalid") || strings.Contains(err.Error(), "required") {
+			statusCode = http.StatusBadRequest
+		}
+		http.Error(w, "Failed to list orders: "+err.Error(), statusCode)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(orders)
+	json.NewEncoder(w).Encode(result)
+}
+
+// parseOrderSearchFilter クエリパラメータから注文検索条件を構築
+func parseOrderSearchFilter(r *http.Request) (*domain.OrderSearchFilter, error) {
+	query := r.URL.Query()
+	filter := &domain.OrderSearchFilter{
+		CustomerID:   query.Get("customer_id"),
+		FabricID:     query.Get("fabric_id"),
+		CreatedBy:    query.Get("created_by"),
+		GroupOrderID: query.Get("group_order_id"),
+		SortBy:       domain.OrderSortField(query.Get("sort")),
+		SortOrder:    domain.SortOrder(strings.ToLower(query.Get("order"))),
+	}
+
+	if statuses := query.Get("status"); statuses != "" {
+		for _, status := range strings.Split(statuses, ",") {
+			if status = strings.TrimSpace(status); status != "" {
+				filter.Statuses = append(filter.Statuses, domain.OrderStatus(status))
+			}
+		}
+	}
+
+	if value := query.Get("delivery_from"); value != "" {
+		from, _, err := parseDateParam(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid delivery_from: %w", err)
+		}
+		filter.DeliveryFrom = &from
+	}
+	if value := query.Get("delivery_to"); value != "" {
+		to, dateOnly, err := parseDateParam(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid delivery_to: %w", err)
+		}
+		if dateOnly {
+			to = to.AddDate(0, 0, 1) // 日付指定の場合は当日を含める
+		}
+		filter.DeliveryTo = &to
+	}
+
+	for _, param := range []struct {
+		name   string
+		target **int64
+	}{
+		{"min_amount", &filter.MinAmount},
+		{"max_amount", &filter.MaxAmount},
+	} {
+		if value := query.Get(param.name); value != "" {
+			amount, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s: %w", param.name, err)
+			}
+			*param.target = &amount
+		}
+	}
+
+	if value := query.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid limit: %w", err)
+		}
+		filter.Limit = limit
+	}
+
+	if value := query.Get("cursor"); value != "" {
+		cursor, err := domain.DecodeOrderCursor(value)
+		if err != nil {
+			return nil, err
+		}
+		filter.Cursor = cursor
+	}
+
+	return filter, nil
+}
+
+// parseDateParam 日付パラメータをパース（YYYY-MM-DD または RFC3339）
+// 日付のみの指定かどうかも返す
+func parseDateParam(value string) (time.Time, bool, error) {
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, true, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	return t, false, nil
 }
 
 // extractIPAddress HTTPリクエストからIPアドレスを抽出
@@ -0,0 +1,335 @@
+package handler
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"tailor-cloud/backend/internal/config/domain"
+	"tailor-cloud/backend/internal/middleware"
+	"tailor-cloud/backend/internal/service"
+)
+
+// maxInspectionPhotoSize 検品写真の最大サイズ（10MB）
+const maxInspectionPhotoSize = 10 << 20
+
+// InspectionHandler 品質検品ハンドラー
+type InspectionHandler struct {
+	inspectionService *service.InspectionService
+}
+
+// NewInspectionHandler InspectionHandlerのコンストラクタ
+func NewInspectionHandler(inspectionService *service.InspectionService) *InspectionHandler {
+	return &InspectionHandler{
+		inspectionService: inspectionService,
+	}
+}
+
+// ListChecklists GET /api/inspection-checklists - 設定済みの検品チェックリスト一覧を取得
+func (h *InspectionHandler) ListChecklists(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	checklists, err := h.inspectionService.ListChecklists(r.Context(), authUser.TenantID)
+	if err != nil {
+		writeInspectionError(w, "Failed to list inspection checklists: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"checklists": checklists,
+		"total":      len(checklists),
+	})
+}
+
+// GetChecklist GET /api/inspection-checklists/{garment_type} - 品目の検品チェックリストを取得
+// 未設定の場合は既定のチェックリスト（is_default: true）を返す
+func (h *InspectionHandler) GetChecklist(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	garmentType := domain.GarmentType(strings.ToUpper(r.PathValue("garment_type")))
+	checklist, err := h.inspectionService.GetChecklist(r.Context(), authUser.TenantID, garmentType)
+	if err != nil {
+		writeInspectionError(w, "Failed to get inspection checklist: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(checklist)
+}
+
+// UpdateChecklist PUT /api/inspection-checklists/{garment_type} - 品目の検品チェックリストを登録・更新
+func (h *InspectionHandler) UpdateChecklist(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req service.UpdateInspectionChecklistRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+	req.TenantID = authUser.TenantID
+	req.UpdatedBy = authUser.ID
+	req.GarmentType = domain.GarmentType(strings.ToUpper(r.PathValue("garment_type")))
+
+	checklist, err := h.inspectionService.UpdateChecklist(r.Context(), &req)
+	if err != nil {
+		writeInspectionError(w, "Failed to update inspection checklist: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(checklist)
+}
+
+// SubmitInspection POST /api/orders/{id}/inspections - 検品結果を登録
+// 不合格の場合は手直しタスクを作成し、注文を縫製へ戻す
+func (h *InspectionHandler) SubmitInspection(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 検品記録は認証済みユーザーのみ（検品者IDが必要）
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	var req service.SubmitInspectionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	req.OrderID = r.PathValue("id")
+	req.TenantID = authUser.TenantID
+	req.InspectorID = authUser.ID
+	req.IPAddress = extractIPAddress(r)
+	req.UserAgent = r.UserAgent()
+
+	resp, err := h.inspectionService.SubmitInspection(r.Context(), &req)
+	if err != nil {
+		writeInspectionError(w, "Failed to submit inspection: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// ListOrderInspections GET /api/orders/{id}/inspections - 注文の検品記録を取得
+func (h *InspectionHandler) ListOrderInspections(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	inspections, err := h.inspectionService.ListOrderInspections(r.Context(), r.PathValue("id"), authUser.TenantID)
+	if err != nil {
+		writeInspectionError(w, "Failed to list inspections: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"inspections": inspections,
+		"total":       len(inspections),
+	})
+}
+
+// GetInspection GET /api/inspections/{id} - 検品記録を取得
+func (h *InspectionHandler) GetInspection(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	inspection, err := h.inspectionService.GetInspection(r.Context(), r.PathValue("id"), authUser.TenantID)
+	if err != nil {
+		writeInspectionError(w, "Failed to get inspection: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(inspection)
+}
+
+// AddPhoto POST /api/inspections/{id}/photos - 検品写真を添付（multipart: file, item_code）
+func (h *InspectionHandler) AddPhoto(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 写真の添付は認証済みユーザーのみ
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	if err := r.ParseMultipartForm(maxInspectionPhotoSize); err != nil {
+		http.Error(w, "Invalid multipart form: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		http.Error(w, "file is required", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, maxInspectionPhotoSize))
+	if err != nil {
+		http.Error(w, "Failed to read file: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	photo, err := h.inspectionService.AddPhoto(r.Context(), &service.AddInspectionPhotoRequest{
+		InspectionID: r.PathValue("id"),
+		TenantID:     authUser.TenantID,
+		UploadedBy:   authUser.ID,
+		ItemCode:     r.FormValue("item_code"),
+		Data:         data,
+	})
+	if err != nil {
+		writeInspectionError(w, "Failed to add inspection photo: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(photo)
+}
+
+// GetDefectReport GET /api/production/reports/defect-rates?from=2026-10-01&to=2026-11-01 - 工場・工程・作業者ごとの不良率
+// from を含み to を含まない。未指定の場合は直近30日
+func (h *InspectionHandler) GetDefectReport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	today := domain.ProductionDay(time.Now())
+	to := today.AddDate(0, 0, 1)
+	if value := r.URL.Query().Get("to"); value != "" {
+		to, err = time.Parse("2006-01-02", value)
+		if err != nil {
+			http.Error(w, "Invalid to (expected YYYY-MM-DD)", http.StatusBadRequest)
+			return
+		}
+	}
+	from := to.AddDate(0, 0, -30)
+	if value := r.URL.Query().Get("from"); value != "" {
+		from, err = time.Parse("2006-01-02", value)
+		if err != nil {
+			http.Error(w, "Invalid from (expected YYYY-MM-DD)", http.StatusBadRequest)
+			return
+		}
+	}
+
+	report, err := h.inspectionService.GetDefectReport(r.Context(), authUser.TenantID, from, to)
+	if err != nil {
+		writeInspectionError(w, "Failed to get defect report: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
+
+// writeInspectionError エラー内容に応じたステータスコードでエラーを返す
+func writeInspectionError(w http.ResponseWriter, prefix string, err error) {
+	statusCode := http.StatusInternalServerError
+	if strings.Contains(err.Error(), "not found") {
+		statusCode = http.StatusNotFound
+	} else if strings.Contains(err.Error(), "invalid task status") || strings.Contains(err.Error(), "invalid order status") {
+		statusCode = http.StatusConflict
+	} else if strings.Contains(err.Error(), "unauthorized") {
+		statusCode = http.StatusForbidden
+	} else if strings.Contains(err.Error(), "not configured") {
+		statusCode = http.StatusServiceUnavailable
+	} else if strings.Contains(err.Error(), "invalid") || strings.Contains(err.Error(), "required") {
+		statusCode = http.StatusBadRequest
+	}
+	http.Error(w, prefix+err.Error(), statusCode)
+}
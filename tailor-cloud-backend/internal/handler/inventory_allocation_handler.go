@@ -25,8 +25,9 @@ func NewInventoryAllocationHandler(allocationService *service.InventoryAllocatio
 type AllocateInventoryRequest struct {
 	OrderID        string  `json:"order_id"`
 	FabricID       string  `json:"fabric_id"`
-	RequiredLength float64 `json:"required_length"` // 必要な長さ（メートル）
-	Strategy       string  `json:"strategy,omitempty"` // FIFO, LIFO, BEST_FIT
+	RequiredLength float64 `json:"required_length"`       // 必要な長さ（length_unit の単位）
+	LengthUnit     string  `json:"length_unit,omitempty"` // 長さの単位（m / yd、未指定はm）
+	Strategy       string  `json:"strategy,omitempty"`    // FIFO, LIFO, BEST_FIT
 }
 
 // AllocateInventory POST /api/inventory/allocate - 在庫を引当
@@ -54,6 +55,11 @@ func (h *InventoryAllocationHandler) AllocateInventory(w http.ResponseWriter, r
 		http.Error(w, "required_length must be greater than 0", http.StatusBadRequest)
 		return
 	}
+	requiredLength, err := normalizeFabricLength(req.RequiredLength, req.LengthUnit)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 
 	// 認証済みユーザー情報をコンテキストから取得
 	authUser, err := middleware.GetUserFromContext(r.Context())
@@ -77,7 +83,7 @@ func (h *InventoryAllocationHandler) AllocateInventory(w http.ResponseWriter, r
 		TenantID:       authUser.TenantID,
 		OrderID:        req.OrderID,
 		FabricID:       req.FabricID,
-		RequiredLength: req.RequiredLength,
+		RequiredLength: requiredLength,
 		Strategy:       strategy,
 	}
 
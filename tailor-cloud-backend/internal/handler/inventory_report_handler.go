@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"tailor-cloud/backend/internal/config/domain"
+	"tailor-cloud/backend/internal/middleware"
+	"tailor-cloud/backend/internal/service"
+)
+
+// InventoryReportHandler 生地在庫レポートハンドラー
+type InventoryReportHandler struct {
+	reportService *service.InventoryReportService
+}
+
+// NewInventoryReportHandler InventoryReportHandlerのコンストラクタ
+func NewInventoryReportHandler(reportService *service.InventoryReportService) *InventoryReportHandler {
+	return &InventoryReportHandler{
+		reportService: reportService,
+	}
+}
+
+// GetInventoryReport GET /api/inventory/report - 生地在庫レポートを取得
+// クエリパラメータ unit（m / yd）で単位を指定（未指定はテナントの生地の単位）
+func (h *InventoryReportHandler) GetInventoryReport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	report, err := h.reportService.GetReport(r.Context(), authUser.TenantID, domain.FabricUnit(r.URL.Query().Get("unit")))
+	if err != nil {
+		writeInventoryReportError(w, "Failed to get inventory report: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(report)
+}
+
+// GetInventoryReportPDF GET /api/inventory/report/pdf - 生地在庫レポートPDFをダウンロード
+func (h *InventoryReportHandler) GetInventoryReportPDF(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	pdfData, err := h.reportService.GenerateReportPDF(r.Context(), authUser.TenantID, domain.FabricUnit(r.URL.Query().Get("unit")))
+	if err != nil {
+		writeInventoryReportError(w, "Failed to generate inventory report: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="inventory_report_%s.pdf"`, time.Now().Format("20060102")))
+	w.WriteHeader(http.StatusOK)
+	w.Write(pdfData)
+}
+
+// writeInventoryReportError エラー内容に応じたステータスコードでエラーを返す
+func writeInventoryReportError(w http.ResponseWriter, prefix string, err error) {
+	statusCode := http.StatusInternalServerError
+	if strings.Contains(err.Error(), "invalid") || strings.Contains(err.Error(), "required") {
+		statusCode = http.StatusBadRequest
+	}
+	http.Error(w, prefix+err.Error(), statusCode)
+}
@@ -3,6 +3,7 @@ package handler
 import (
 	"encoding/json"
 	"net/http"
+	"strings"
 
 	"tailor-cloud/backend/internal/middleware"
 	"tailor-cloud/backend/internal/service"
@@ -78,7 +79,11 @@ func (h *MeasurementCorrectionHandler) ConvertToFinalMeasurements(w http.Respons
 
 	response, err := h.correctionService.ConvertToFinalMeasurements(r.Context(), serviceReq)
 	if err != nil {
-		http.Error(w, "Failed to convert measurements: "+err.Error(), http.StatusInternalServerError)
+		statusCode := http.StatusInternalServerError
+		if strings.Contains(err.Error(), "invalid measurement unit") {
+			statusCode = http.StatusBadRequest
+		}
+		http.Error(w, "Failed to convert measurements: "+err.Error(), statusCode)
 		return
 	}
 
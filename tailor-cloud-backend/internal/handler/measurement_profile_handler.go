@@ -0,0 +1,242 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"tailor-cloud/backend/internal/middleware"
+	"tailor-cloud/backend/internal/service"
+)
+
+// MeasurementProfileHandler 顧客の採寸プロファイルハンドラー
+type MeasurementProfileHandler struct {
+	profileService *service.MeasurementProfileService
+}
+
+// NewMeasurementProfileHandler MeasurementProfileHandlerのコンストラクタ
+func NewMeasurementProfileHandler(profileService *service.MeasurementProfileService) *MeasurementProfileHandler {
+	return &MeasurementProfileHandler{
+		profileService: profileService,
+	}
+}
+
+// GetProfile GET /api/customers/{id}/measurement-profile - 採寸プロファイルを最新版とともに取得
+func (h *MeasurementProfileHandler) GetProfile(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	profile, err := h.profileService.GetProfile(r.Context(), r.PathValue("id"), authUser.TenantID)
+	if err != nil {
+		writeMeasurementProfileError(w, "Failed to get measurement profile: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(profile)
+}
+
+// RecordMeasurements POST /api/customers/{id}/measurement-profile/versions - 採寸データを新しい版として記録
+func (h *MeasurementProfileHandler) RecordMeasurements(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 採寸の記録は認証済みユーザーのみ（採寸者IDが必要）
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	var req service.RecordMeasurementsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	req.TenantID = authUser.TenantID
+	req.CustomerID = r.PathValue("id")
+	req.MeasuredBy = authUser.ID
+	req.IPAddress = extractIPAddress(r)
+	req.UserAgent = r.UserAgent()
+
+	profile, err := h.profileService.RecordMeasurements(r.Context(), &req)
+	if err != nil {
+		writeMeasurementProfileError(w, "Failed to record measurements: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(profile)
+}
+
+// ListVersions GET /api/customers/{id}/measurement-profile/versions - 採寸プロファイルの版を古い順に取得
+func (h *MeasurementProfileHandler) ListVersions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	versions, err := h.profileService.ListVersions(r.Context(), r.PathValue("id"), authUser.TenantID)
+	if err != nil {
+		writeMeasurementProfileError(w, "Failed to list measurement profile versions: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"versions": versions,
+		"total":    len(versions),
+	})
+}
+
+// GetVersion GET /api/customers/{id}/measurement-profile/versions/{version} - 採寸プロファイルの版を取得
+func (h *MeasurementProfileHandler) GetVersion(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	version, err := strconv.Atoi(r.PathValue("version"))
+	if err != nil {
+		http.Error(w, "Invalid version", http.StatusBadRequest)
+		return
+	}
+
+	profileVersion, err := h.profileService.GetVersion(r.Context(), r.PathValue("id"), authUser.TenantID, version)
+	if err != nil {
+		writeMeasurementProfileError(w, "Failed to get measurement profile version: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(profileVersion)
+}
+
+// LinkOrder POST /api/customers/{id}/measurement-profile/versions/{version}/orders - 版を使った注文を記録
+func (h *MeasurementProfileHandler) LinkOrder(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	version, err := strconv.Atoi(r.PathValue("version"))
+	if err != nil {
+		http.Error(w, "Invalid version", http.StatusBadRequest)
+		return
+	}
+
+	var req service.LinkMeasurementOrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	req.TenantID = authUser.TenantID
+	req.CustomerID = r.PathValue("id")
+	req.Version = version
+	req.UserID = authUser.ID
+
+	profileVersion, err := h.profileService.LinkOrder(r.Context(), &req)
+	if err != nil {
+		writeMeasurementProfileError(w, "Failed to link order: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(profileVersion)
+}
+
+// GetChart GET /api/customers/{id}/measurement-profile/chart - 採寸値の推移を取得
+// クエリパラメータ: fields（カンマ区切りの採寸項目、省略時は全項目）
+func (h *MeasurementProfileHandler) GetChart(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	var fields []string
+	for _, field := range strings.Split(r.URL.Query().Get("fields"), ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			fields = append(fields, field)
+		}
+	}
+
+	chart, err := h.profileService.GetChart(r.Context(), r.PathValue("id"), authUser.TenantID, fields)
+	if err != nil {
+		writeMeasurementProfileError(w, "Failed to get measurement chart: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(chart)
+}
+
+// writeMeasurementProfileError 採寸プロファイルのエラーをHTTPステータスに変換
+func writeMeasurementProfileError(w http.ResponseWriter, prefix string, err error) {
+	statusCode := http.StatusInternalServerError
+	if strings.Contains(err.Error(), "not found") {
+		statusCode = http.StatusNotFound
+	} else if strings.Contains(err.Error(), "unauthorized") {
+		statusCode = http.StatusForbidden
+	} else if strings.Contains(err.Error(), "not configured") {
+		statusCode = http.StatusServiceUnavailable
+	} else if strings.Contains(err.Error(), "invalid") || strings.Contains(err.Error(), "required") {
+		statusCode = http.StatusBadRequest
+	}
+	http.Error(w, prefix+err.Error(), statusCode)
+}
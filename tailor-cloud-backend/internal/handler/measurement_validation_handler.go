@@ -3,7 +3,9 @@ package handler
 import (
 	"encoding/json"
 	"net/http"
+	"strings"
 
+	"tailor-cloud/backend/internal/config/domain"
 	"tailor-cloud/backend/internal/middleware"
 	"tailor-cloud/backend/internal/service"
 )
@@ -23,6 +25,7 @@ func NewMeasurementValidationHandler(validationService *service.MeasurementValid
 // ValidateMeasurementsRequest APIリクエスト
 type ValidateMeasurementsRequest struct {
 	CustomerID         string          `json:"customer_id"`
+	GarmentType        domain.GarmentType `json:"garment_type"`
 	CurrentMeasurements json.RawMessage `json:"current_measurements"`
 }
 
@@ -67,12 +70,13 @@ func (h *MeasurementValidationHandler) ValidateMeasurements(w http.ResponseWrite
 	serviceReq := &service.ValidateMeasurementsRequest{
 		CustomerID:         req.CustomerID,
 		TenantID:           tenantID,
+		GarmentType:        req.GarmentType,
 		CurrentMeasurements: req.CurrentMeasurements,
 	}
 
 	response, err := h.validationService.ValidateMeasurements(r.Context(), serviceReq)
 	if err != nil {
-		http.Error(w, "Failed to validate measurements: "+err.Error(), http.StatusInternalServerError)
+		writeMeasurementValidationError(w, "Failed to validate measurements: ", err)
 		return
 	}
 
@@ -89,7 +93,8 @@ func (h *MeasurementValidationHandler) ValidateMeasurementRange(w http.ResponseW
 	}
 
 	var req struct {
-		Measurements json.RawMessage `json:"measurements"`
+		GarmentType  domain.GarmentType `json:"garment_type"` // 採寸テンプレートの品目（未設定の場合は汎用のテンプレート）
+		Measurements json.RawMessage    `json:"measurements"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
@@ -101,8 +106,18 @@ func (h *MeasurementValidationHandler) ValidateMeasurementRange(w http.ResponseW
 		return
 	}
 
+	// 認証済みユーザー情報をコンテキストから取得（テナントの採寸テンプレートを使う）
+	tenantID := r.URL.Query().Get("tenant_id")
+	if authUser, err := middleware.GetUserFromContext(r.Context()); err == nil {
+		tenantID = authUser.TenantID
+	}
+
 	// サービス層で範囲バリデーション
-	alerts := h.validationService.ValidateMeasurementRange(req.Measurements)
+	alerts, err := h.validationService.ValidateMeasurementRange(r.Context(), tenantID, req.GarmentType, req.Measurements)
+	if err != nil {
+		writeMeasurementValidationError(w, "Failed to validate measurement range: ", err)
+		return
+	}
 
 	response := map[string]interface{}{
 		"is_valid": len(alerts) == 0,
@@ -114,3 +129,114 @@ func (h *MeasurementValidationHandler) ValidateMeasurementRange(w http.ResponseW
 	json.NewEncoder(w).Encode(response)
 }
 
+
+// ListTemplates GET /api/measurement-templates - 設定済みの採寸テンプレート一覧を取得
+func (h *MeasurementValidationHandler) ListTemplates(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	templates, err := h.validationService.ListTemplates(r.Context(), authUser.TenantID)
+	if err != nil {
+		writeMeasurementValidationError(w, "Failed to list measurement templates: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"templates": templates,
+		"total":     len(templates),
+	})
+}
+
+// GetTemplate GET /api/measurement-templates/{garment_type} - 品目の採寸テンプレートを取得（未設定の場合は既定）
+func (h *MeasurementValidationHandler) GetTemplate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	garmentType := domain.GarmentType(strings.ToUpper(r.PathValue("garment_type")))
+	if !garmentType.IsValid() {
+		http.Error(w, "Invalid garment_type: "+r.PathValue("garment_type"), http.StatusBadRequest)
+		return
+	}
+
+	template, err := h.validationService.GetTemplate(r.Context(), authUser.TenantID, garmentType)
+	if err != nil {
+		writeMeasurementValidationError(w, "Failed to get measurement template: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(template)
+}
+
+// UpdateTemplate PUT /api/measurement-templates/{garment_type} - 品目の採寸テンプレートを登録・更新
+func (h *MeasurementValidationHandler) UpdateTemplate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// テンプレートの変更は認証済みユーザーのみ
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	var req service.UpdateMeasurementTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	req.TenantID = authUser.TenantID
+	req.GarmentType = domain.GarmentType(strings.ToUpper(r.PathValue("garment_type")))
+	req.UpdatedBy = authUser.ID
+
+	template, err := h.validationService.UpdateTemplate(r.Context(), &req)
+	if err != nil {
+		writeMeasurementValidationError(w, "Failed to update measurement template: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(template)
+}
+
+// writeMeasurementValidationError 採寸バリデーション・採寸テンプレートのエラーをHTTPステータスに変換
+func writeMeasurementValidationError(w http.ResponseWriter, prefix string, err error) {
+	statusCode := http.StatusInternalServerError
+	if strings.Contains(err.Error(), "not found") {
+		statusCode = http.StatusNotFound
+	} else if strings.Contains(err.Error(), "not configured") {
+		statusCode = http.StatusServiceUnavailable
+	} else if strings.Contains(err.Error(), "invalid") || strings.Contains(err.Error(), "required") || strings.Contains(err.Error(), "failed to parse") {
+		statusCode = http.StatusBadRequest
+	}
+	http.Error(w, prefix+err.Error(), statusCode)
+}
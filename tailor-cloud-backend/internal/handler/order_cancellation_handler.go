@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"tailor-cloud/backend/internal/middleware"
+	"tailor-cloud/backend/internal/service"
+)
+
+// OrderCancellationHandler 注文キャンセルハンドラー
+type OrderCancellationHandler struct {
+	cancellationService *service.OrderCancellationService
+}
+
+// NewOrderCancellationHandler OrderCancellationHandlerのコンストラクタ
+func NewOrderCancellationHandler(cancellationService *service.OrderCancellationService) *OrderCancellationHandler {
+	return &OrderCancellationHandler{
+		cancellationService: cancellationService,
+	}
+}
+
+// CancelOrder POST /api/orders/{id}/cancel - 注文をキャンセル
+func (h *OrderCancellationHandler) CancelOrder(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	orderID := r.PathValue("id")
+	if orderID == "" {
+		http.Error(w, "order_id is required", http.StatusBadRequest)
+		return
+	}
+
+	var req service.CancelOrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+	req.OrderID = orderID
+	req.TenantID = authUser.TenantID
+	req.UserID = authUser.ID
+	req.IPAddress = extractIPAddress(r)
+	req.UserAgent = r.UserAgent()
+
+	resp, err := h.cancellationService.CancelOrder(r.Context(), &req)
+	if err != nil {
+		writeCancellationError(w, "Failed to cancel order: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// GetCancellation GET /api/orders/{id}/cancellation - 注文のキャンセル記録を取得
+func (h *OrderCancellationHandler) GetCancellation(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	orderID := r.PathValue("id")
+	if orderID == "" {
+		http.Error(w, "order_id is required", http.StatusBadRequest)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	cancellation, err := h.cancellationService.GetCancellation(r.Context(), orderID, authUser.TenantID)
+	if err != nil {
+		writeCancellationError(w, "Failed to get order cancellation: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(cancellation)
+}
+
+// writeCancellationError エラー内容に応じたステータスコードでエラーを返す
+func writeCancellationError(w http.ResponseWriter, prefix string, err error) {
+	statusCode := http.StatusInternalServerError
+	if strings.Contains(err.Error(), "not found") {
+		statusCode = http.StatusNotFound
+	} else if strings.Contains(err.Error(), "unauthorized") {
+		statusCode = http.StatusForbidden
+	} else if strings.Contains(err.Error(), "invalid order status") || strings.Contains(err.Error(), "already been cut") {
+		statusCode = http.StatusConflict
+	} else if strings.Contains(err.Error(), "invalid") || strings.Contains(err.Error(), "required") {
+		statusCode = http.StatusBadRequest
+	}
+	http.Error(w, prefix+err.Error(), statusCode)
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"tailor-cloud/backend/internal/config/domain"
+	"tailor-cloud/backend/internal/middleware"
+	"tailor-cloud/backend/internal/service"
+)
+
+// OrderCloneHandler 再注文ハンドラー
+type OrderCloneHandler struct {
+	cloneService *service.OrderCloneService
+}
+
+// NewOrderCloneHandler OrderCloneHandlerのコンストラクタ
+func NewOrderCloneHandler(cloneService *service.OrderCloneService) *OrderCloneHandler {
+	return &OrderCloneHandler{
+		cloneService: cloneService,
+	}
+}
+
+// CloneOrder POST /api/orders/{id}/clone - 過去の注文を複製してDraft注文を作成
+func (h *OrderCloneHandler) CloneOrder(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	orderID := r.PathValue("id")
+	if orderID == "" {
+		http.Error(w, "order_id is required", http.StatusBadRequest)
+		return
+	}
+
+	var req service.CloneOrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+	req.SourceOrderID = orderID
+	req.TenantID = authUser.TenantID
+	req.CreatedBy = authUser.ID
+	req.CreatedByRole = domain.UserRole(authUser.Role)
+	req.IPAddress = extractIPAddress(r)
+	req.UserAgent = r.UserAgent()
+
+	resp, err := h.cloneService.CloneOrder(r.Context(), &req)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if strings.Contains(err.Error(), "unauthorized") {
+			statusCode = http.StatusForbidden
+		} else if strings.Contains(err.Error(), "source order") && strings.Contains(err.Error(), "not found") {
+			statusCode = http.StatusNotFound
+		} else if strings.Contains(err.Error(), "not found") || strings.Contains(err.Error(), "invalid") || strings.Contains(err.Error(), "required") {
+			statusCode = http.StatusBadRequest
+		}
+		http.Error(w, "Failed to clone order: "+err.Error(), statusCode)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(resp)
+}
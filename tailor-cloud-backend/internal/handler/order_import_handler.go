@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"tailor-cloud/backend/internal/config/domain"
+	"tailor-cloud/backend/internal/middleware"
+	"tailor-cloud/backend/internal/service"
+)
+
+// 注文取込ファイルの最大サイズ（10MB）
+const maxOrderImportFileSize = 10 << 20
+
+// OrderImportHandler 注文一括取込ハンドラー
+type OrderImportHandler struct {
+	orderImportService *service.OrderImportService
+}
+
+// NewOrderImportHandler OrderImportHandlerのコンストラクタ
+func NewOrderImportHandler(orderImportService *service.OrderImportService) *OrderImportHandler {
+	return &OrderImportHandler{
+		orderImportService: orderImportService,
+	}
+}
+
+// ImportOrders POST /api/orders/import - 受注会の注文をCSV/XLSXから一括取込
+// multipart/form-data: file（取込ファイル）, format（CSV / XLSX、省略時は自動判定）, dry_run（"false"の場合のみ確定、既定はドライラン）
+func (h *OrderImportHandler) ImportOrders(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	if err := r.ParseMultipartForm(maxOrderImportFileSize); err != nil {
+		http.Error(w, "Invalid multipart form: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	file, fileHeader, err := r.FormFile("file")
+	if err != nil {
+		http.Error(w, "file is required", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, maxOrderImportFileSize))
+	if err != nil {
+		http.Error(w, "Failed to read file: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.orderImportService.ImportOrders(r.Context(), &service.ImportOrdersRequest{
+		TenantID:  authUser.TenantID,
+		FileName:  fileHeader.Filename,
+		Format:    domain.OrderImportFormat(strings.ToUpper(r.FormValue("format"))),
+		Data:      data,
+		DryRun:    !strings.EqualFold(r.FormValue("dry_run"), "false"),
+		CreatedBy: authUser.ID,
+		IPAddress: extractIPAddress(r),
+		UserAgent: r.UserAgent(),
+	})
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if strings.Contains(err.Error(), "invalid") || strings.Contains(err.Error(), "required") {
+			statusCode = http.StatusBadRequest
+		}
+		http.Error(w, "Failed to import orders: "+err.Error(), statusCode)
+		return
+	}
+
+	// 確定時: 作成済みは201、エラー行があり確定できなかった場合は422（行ごとのエラーを返す）
+	statusCode := http.StatusOK
+	if !resp.DryRun {
+		if resp.Committed {
+			statusCode = http.StatusCreated
+		} else {
+			statusCode = http.StatusUnprocessableEntity
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(resp)
+}
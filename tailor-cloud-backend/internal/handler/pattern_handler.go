@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"tailor-cloud/backend/internal/middleware"
+	"tailor-cloud/backend/internal/service"
+)
+
+// PatternHandler 型紙ハンドラー
+type PatternHandler struct {
+	patternService *service.PatternService
+}
+
+// NewPatternHandler PatternHandlerのコンストラクタ
+func NewPatternHandler(patternService *service.PatternService) *PatternHandler {
+	return &PatternHandler{
+		patternService: patternService,
+	}
+}
+
+// GetPattern GET /api/orders/{id}/pattern - 注文の仕上がり寸法でグレーディングした型紙を取得
+func (h *PatternHandler) GetPattern(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	result, err := h.patternService.GeneratePattern(r.Context(), r.PathValue("id"), authUser.TenantID)
+	if err != nil {
+		writePatternError(w, "Failed to generate pattern: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(result)
+}
+
+// DownloadDXF GET /api/orders/{id}/pattern/dxf - 型紙をDXF-AAMA形式でダウンロード（CAD裁断機用）
+func (h *PatternHandler) DownloadDXF(w http.ResponseWriter, r *http.Request) {
+	h.download(w, r, h.patternService.ExportDXF, "application/dxf", "pattern_%s.dxf", "Failed to export pattern: ")
+}
+
+// DownloadTechSheet GET /api/orders/{id}/pattern/tech-sheet - 仕様書PDFをダウンロード
+func (h *PatternHandler) DownloadTechSheet(w http.ResponseWriter, r *http.Request) {
+	h.download(w, r, h.patternService.GenerateTechSheetPDF, "application/pdf", "tech_sheet_%s.pdf", "Failed to generate tech sheet: ")
+}
+
+// download 型紙ファイル・仕様書のダウンロードの共通処理
+func (h *PatternHandler) download(
+	w http.ResponseWriter,
+	r *http.Request,
+	generate func(ctx context.Context, orderID, tenantID string) ([]byte, error),
+	contentType string,
+	fileNameFormat string,
+	errorPrefix string,
+) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	orderID := r.PathValue("id")
+	data, err := generate(r.Context(), orderID, authUser.TenantID)
+	if err != nil {
+		writePatternError(w, errorPrefix, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="`+fileNameFormat+`"`, orderID))
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// writePatternError エラー内容に応じたステータスコードでエラーを返す
+func writePatternError(w http.ResponseWriter, prefix string, err error) {
+	statusCode := http.StatusInternalServerError
+	if strings.Contains(err.Error(), "not found") {
+		statusCode = http.StatusNotFound
+	} else if strings.Contains(err.Error(), "unauthorized") {
+		statusCode = http.StatusForbidden
+	} else if strings.Contains(err.Error(), "not configured") {
+		statusCode = http.StatusServiceUnavailable
+	} else if strings.Contains(err.Error(), "invalid") || strings.Contains(err.Error(), "required") {
+		statusCode = http.StatusBadRequest
+	}
+	http.Error(w, prefix+err.Error(), statusCode)
+}
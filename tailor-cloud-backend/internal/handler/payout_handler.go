@@ -0,0 +1,334 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"tailor-cloud/backend/internal/middleware"
+	"tailor-cloud/backend/internal/service"
+)
+
+// PayoutHandler 支払業務ハンドラー（総合振込）
+type PayoutHandler struct {
+	payoutService *service.PayoutService
+}
+
+// NewPayoutHandler PayoutHandlerのコンストラクタ
+func NewPayoutHandler(payoutService *service.PayoutService) *PayoutHandler {
+	return &PayoutHandler{
+		payoutService: payoutService,
+	}
+}
+
+// CreatePayoutBatchRequest 支払バッチ作成リクエスト
+type CreatePayoutBatchRequest struct {
+	DueBy        string `json:"due_by"`                  // YYYY-MM-DD
+	TransferDate string `json:"transfer_date,omitempty"` // YYYY-MM-DD（省略時はdue_by）
+}
+
+// RegisterBankAccount POST /api/bank-accounts - 振込用の銀行口座を登録
+func (h *PayoutHandler) RegisterBankAccount(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req service.BankAccountRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+	req.TenantID = authUser.TenantID
+
+	account, err := h.payoutService.RegisterBankAccount(r.Context(), &req)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if strings.Contains(err.Error(), "invalid") || strings.Contains(err.Error(), "required") {
+			statusCode = http.StatusBadRequest
+		}
+		http.Error(w, "Failed to register bank account: "+err.Error(), statusCode)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(account)
+}
+
+// ListBankAccounts GET /api/bank-accounts - 銀行口座一覧を取得
+func (h *PayoutHandler) ListBankAccounts(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	accounts, err := h.payoutService.ListBankAccounts(r.Context(), authUser.TenantID)
+	if err != nil {
+		http.Error(w, "Failed to list bank accounts: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"bank_accounts": accounts,
+		"total":         len(accounts),
+	})
+}
+
+// CreatePayoutBatch POST /api/payouts - 期日までの支払義務から支払バッチを作成
+func (h *PayoutHandler) CreatePayoutBatch(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req CreatePayoutBatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	dueBy, err := time.Parse("2006-01-02", req.DueBy)
+	if err != nil {
+		http.Error(w, "Invalid due_by format. Use YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+	var transferDate time.Time
+	if req.TransferDate != "" {
+		transferDate, err = time.Parse("2006-01-02", req.TransferDate)
+		if err != nil {
+			http.Error(w, "Invalid transfer_date format. Use YYYY-MM-DD", http.StatusBadRequest)
+			return
+		}
+	}
+
+	resp, err := h.payoutService.CreatePayoutBatch(r.Context(), &service.CreatePayoutBatchRequest{
+		TenantID:     authUser.TenantID,
+		DueBy:        dueBy,
+		TransferDate: transferDate,
+		UserID:       authUser.ID,
+	})
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if strings.Contains(err.Error(), "no payable items") {
+			statusCode = http.StatusNotFound
+		} else if strings.Contains(err.Error(), "invalid") || strings.Contains(err.Error(), "required") {
+			statusCode = http.StatusBadRequest
+		}
+		http.Error(w, "Failed to create payout batch: "+err.Error(), statusCode)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// ListPayoutBatches GET /api/payouts - 支払バッチ一覧を取得
+func (h *PayoutHandler) ListPayoutBatches(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+
+	batches, err := h.payoutService.ListPayoutBatches(r.Context(), authUser.TenantID, limit, offset)
+	if err != nil {
+		http.Error(w, "Failed to list payout batches: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"batches": batches,
+		"total":   len(batches),
+	})
+}
+
+// GetPayoutBatch GET /api/payouts/{id} - 支払バッチを明細付きで取得
+func (h *PayoutHandler) GetPayoutBatch(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	batchID := r.PathValue("id")
+	if batchID == "" {
+		http.Error(w, "batch_id is required", http.StatusBadRequest)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	batch, err := h.payoutService.GetPayoutBatch(r.Context(), batchID, authUser.TenantID)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if strings.Contains(err.Error(), "not found") {
+			statusCode = http.StatusNotFound
+		}
+		http.Error(w, "Failed to get payout batch: "+err.Error(), statusCode)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(batch)
+}
+
+// DownloadTransferFile GET /api/payouts/{id}/file - 総合振込ファイル（Shift_JIS）をダウンロード
+func (h *PayoutHandler) DownloadTransferFile(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	batchID := r.PathValue("id")
+	if batchID == "" {
+		http.Error(w, "batch_id is required", http.StatusBadRequest)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	file, filename, err := h.payoutService.GetTransferFile(r.Context(), batchID, authUser.TenantID)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if strings.Contains(err.Error(), "not found") {
+			statusCode = http.StatusNotFound
+		} else if strings.Contains(err.Error(), "cancelled") || strings.Contains(err.Error(), "mismatch") {
+			statusCode = http.StatusConflict
+		}
+		http.Error(w, "Failed to get transfer file: "+err.Error(), statusCode)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=Shift_JIS")
+	w.Header().Set("Content-Disposition", "attachment; filename=\""+filename+"\"")
+	w.WriteHeader(http.StatusOK)
+	w.Write(file)
+}
+
+// ConfirmPayoutBatch POST /api/payouts/{id}/confirm - 振込完了を確定
+func (h *PayoutHandler) ConfirmPayoutBatch(w http.ResponseWriter, r *http.Request) {
+	h.closePayoutBatch(w, r, false)
+}
+
+// CancelPayoutBatch POST /api/payouts/{id}/cancel - 支払バッチを取消
+func (h *PayoutHandler) CancelPayoutBatch(w http.ResponseWriter, r *http.Request) {
+	h.closePayoutBatch(w, r, true)
+}
+
+// closePayoutBatch 振込完了・取消の共通処理
+func (h *PayoutHandler) closePayoutBatch(w http.ResponseWriter, r *http.Request, cancel bool) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	batchID := r.PathValue("id")
+	if batchID == "" {
+		http.Error(w, "batch_id is required", http.StatusBadRequest)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	closeFunc := h.payoutService.ConfirmPayoutBatch
+	if cancel {
+		closeFunc = h.payoutService.CancelPayoutBatch
+	}
+
+	batch, err := closeFunc(r.Context(), batchID, authUser.TenantID, authUser.ID)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if strings.Contains(err.Error(), "not found") {
+			statusCode = http.StatusNotFound
+		} else if strings.Contains(err.Error(), "already") {
+			statusCode = http.StatusConflict
+		}
+		http.Error(w, "Failed to update payout batch: "+err.Error(), statusCode)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(batch)
+}
@@ -0,0 +1,195 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"tailor-cloud/backend/internal/config/domain"
+	"tailor-cloud/backend/internal/middleware"
+	"tailor-cloud/backend/internal/service"
+)
+
+// PricingHandler 価格計算ハンドラー
+type PricingHandler struct {
+	pricingService *service.PricingService
+}
+
+// NewPricingHandler PricingHandlerのコンストラクタ
+func NewPricingHandler(pricingService *service.PricingService) *PricingHandler {
+	return &PricingHandler{
+		pricingService: pricingService,
+	}
+}
+
+// CalculatePriceRequest 価格計算リクエスト
+type CalculatePriceRequest struct {
+	PlanType     domain.PlanType `json:"plan_type"`
+	FabricID     string          `json:"fabric_id"`
+	FabricLength float64         `json:"fabric_length"`
+	OptionCodes  []string        `json:"option_codes"`
+	DeliveryDate string          `json:"delivery_date"` // YYYY-MM-DD
+}
+
+// CalculatePrice POST /api/pricing/calculate - 価格内訳を計算
+func (h *PricingHandler) CalculatePrice(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req CalculatePriceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	deliveryDate, err := time.Parse("2006-01-02", req.DeliveryDate)
+	if err != nil {
+		http.Error(w, "Invalid delivery_date format. Use YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+
+	breakdown, err := h.pricingService.CalculatePrice(r.Context(), &service.PriceRequest{
+		TenantID:     authUser.TenantID,
+		PlanType:     req.PlanType,
+		FabricID:     req.FabricID,
+		FabricLength: req.FabricLength,
+		OptionCodes:  req.OptionCodes,
+		DeliveryDate: deliveryDate,
+	})
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if strings.Contains(err.Error(), "not found") || strings.Contains(err.Error(), "invalid") || strings.Contains(err.Error(), "required") {
+			statusCode = http.StatusBadRequest
+		}
+		http.Error(w, "Failed to calculate price: "+err.Error(), statusCode)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(breakdown)
+}
+
+// GetPriceBook GET /api/pricing/price-book - 価格表を取得
+func (h *PricingHandler) GetPriceBook(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	entries, err := h.pricingService.GetPriceBook(r.Context(), authUser.TenantID)
+	if err != nil {
+		http.Error(w, "Failed to get price book: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"entries": entries,
+		"total":   len(entries),
+	})
+}
+
+// UpsertPriceBookEntry PUT /api/pricing/price-book - 価格表項目を登録・更新
+func (h *PricingHandler) UpsertPriceBookEntry(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req service.PriceBookEntryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+	req.TenantID = authUser.TenantID
+
+	entry, err := h.pricingService.UpsertPriceBookEntry(r.Context(), &req)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if strings.Contains(err.Error(), "invalid") || strings.Contains(err.Error(), "required") {
+			statusCode = http.StatusBadRequest
+		}
+		http.Error(w, "Failed to update price book: "+err.Error(), statusCode)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(entry)
+}
+
+// GetOrderPriceOverrides GET /api/orders/{id}/price-overrides - 注文の価格上書き記録を取得
+func (h *PricingHandler) GetOrderPriceOverrides(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	orderID := r.PathValue("id")
+	if orderID == "" {
+		http.Error(w, "order_id is required", http.StatusBadRequest)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	overrides, err := h.pricingService.GetOrderPriceOverrides(r.Context(), orderID, authUser.TenantID)
+	if err != nil {
+		http.Error(w, "Failed to get price overrides: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"overrides": overrides,
+		"total":     len(overrides),
+	})
+}
@@ -0,0 +1,274 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"tailor-cloud/backend/internal/config/domain"
+	"tailor-cloud/backend/internal/middleware"
+	"tailor-cloud/backend/internal/service"
+)
+
+// ProductionScheduleHandler 生産計画（工場の生産能力・ガントチャート）ハンドラー
+type ProductionScheduleHandler struct {
+	productionScheduleService *service.ProductionScheduleService
+}
+
+// NewProductionScheduleHandler ProductionScheduleHandlerのコンストラクタ
+func NewProductionScheduleHandler(productionScheduleService *service.ProductionScheduleService) *ProductionScheduleHandler {
+	return &ProductionScheduleHandler{
+		productionScheduleService: productionScheduleService,
+	}
+}
+
+// GetCapacity GET /api/production/capacity - 工場の生産能力を取得
+func (h *ProductionScheduleHandler) GetCapacity(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	capacity, err := h.productionScheduleService.GetCapacity(r.Context(), authUser.TenantID)
+	if err != nil {
+		writeProductionScheduleError(w, "Failed to get factory capacity: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(capacity)
+}
+
+// UpdateCapacity PUT /api/production/capacity - 工場の生産能力を登録・更新
+func (h *ProductionScheduleHandler) UpdateCapacity(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req service.UpdateFactoryCapacityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+	req.TenantID = authUser.TenantID
+	req.UpdatedBy = authUser.ID
+
+	capacity, err := h.productionScheduleService.UpdateCapacity(r.Context(), &req)
+	if err != nil {
+		writeProductionScheduleError(w, "Failed to update factory capacity: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(capacity)
+}
+
+// ListOverrides GET /api/production/capacity/overrides?from=2026-10-01&to=2026-12-31 - 個別設定一覧を取得
+// from・to 未指定の場合は今日から90日分
+func (h *ProductionScheduleHandler) ListOverrides(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	from := time.Now()
+	if value := r.URL.Query().Get("from"); value != "" {
+		from, err = time.Parse("2006-01-02", value)
+		if err != nil {
+			http.Error(w, "Invalid from (expected YYYY-MM-DD)", http.StatusBadRequest)
+			return
+		}
+	}
+	to := from.AddDate(0, 0, 90)
+	if value := r.URL.Query().Get("to"); value != "" {
+		to, err = time.Parse("2006-01-02", value)
+		if err != nil {
+			http.Error(w, "Invalid to (expected YYYY-MM-DD)", http.StatusBadRequest)
+			return
+		}
+	}
+
+	overrides, err := h.productionScheduleService.ListOverrides(r.Context(), authUser.TenantID, from, to)
+	if err != nil {
+		writeProductionScheduleError(w, "Failed to list capacity overrides: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"overrides": overrides,
+		"total":     len(overrides),
+	})
+}
+
+// SetOverride POST /api/production/capacity/overrides - 個別設定（休業日・残業・休日出勤）を登録
+// 同じ日の設定がある場合は置き換える
+func (h *ProductionScheduleHandler) SetOverride(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var body struct {
+		service.SetCapacityOverrideRequest
+		Date string `json:"date"` // YYYY-MM-DD
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	req := body.SetCapacityOverrideRequest
+	if body.Date != "" {
+		date, err := time.Parse("2006-01-02", body.Date)
+		if err != nil {
+			http.Error(w, "Invalid date (expected YYYY-MM-DD)", http.StatusBadRequest)
+			return
+		}
+		req.Date = date
+	}
+	req.Type = domain.CapacityOverrideType(strings.ToUpper(string(req.Type)))
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+	req.TenantID = authUser.TenantID
+	req.CreatedBy = authUser.ID
+
+	override, err := h.productionScheduleService.SetOverride(r.Context(), &req)
+	if err != nil {
+		writeProductionScheduleError(w, "Failed to set capacity override: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(override)
+}
+
+// DeleteOverride DELETE /api/production/capacity/overrides/{id} - 個別設定を削除
+func (h *ProductionScheduleHandler) DeleteOverride(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	if err := h.productionScheduleService.DeleteOverride(r.Context(), r.PathValue("id"), authUser.TenantID); err != nil {
+		writeProductionScheduleError(w, "Failed to delete capacity override: ", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetSchedule GET /api/production/schedule?from=2026-10-19&days=30 - 生産計画（ガントチャート）を取得
+// from 未指定の場合は今日から、days 未指定の場合は30日分
+func (h *ProductionScheduleHandler) GetSchedule(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	from := time.Now()
+	if value := r.URL.Query().Get("from"); value != "" {
+		from, err = time.Parse("2006-01-02", value)
+		if err != nil {
+			http.Error(w, "Invalid from (expected YYYY-MM-DD)", http.StatusBadRequest)
+			return
+		}
+	}
+	days := 0
+	if value := r.URL.Query().Get("days"); value != "" {
+		days, err = strconv.Atoi(value)
+		if err != nil {
+			http.Error(w, "Invalid days", http.StatusBadRequest)
+			return
+		}
+	}
+
+	schedule, err := h.productionScheduleService.GetSchedule(r.Context(), authUser.TenantID, from, days)
+	if err != nil {
+		writeProductionScheduleError(w, "Failed to get production schedule: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(schedule)
+}
+
+// writeProductionScheduleError エラー内容に応じたステータスコードで応答
+func writeProductionScheduleError(w http.ResponseWriter, prefix string, err error) {
+	statusCode := http.StatusInternalServerError
+	if strings.Contains(err.Error(), "not found") {
+		statusCode = http.StatusNotFound
+	} else if strings.Contains(err.Error(), "unauthorized") {
+		statusCode = http.StatusForbidden
+	} else if strings.Contains(err.Error(), "invalid") || strings.Contains(err.Error(), "required") {
+		statusCode = http.StatusBadRequest
+	}
+	http.Error(w, prefix+err.Error(), statusCode)
+}
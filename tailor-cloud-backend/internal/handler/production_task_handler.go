@@ -0,0 +1,313 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"tailor-cloud/backend/internal/config/domain"
+	"tailor-cloud/backend/internal/middleware"
+	"tailor-cloud/backend/internal/service"
+)
+
+// ProductionTaskHandler 製造タスク（作業者の工程管理）ハンドラー
+type ProductionTaskHandler struct {
+	productionTaskService *service.ProductionTaskService
+}
+
+// NewProductionTaskHandler ProductionTaskHandlerのコンストラクタ
+func NewProductionTaskHandler(productionTaskService *service.ProductionTaskService) *ProductionTaskHandler {
+	return &ProductionTaskHandler{
+		productionTaskService: productionTaskService,
+	}
+}
+
+// GenerateTasks POST /api/orders/{id}/production-tasks - 注文の残りの工程の製造タスクを作成
+func (h *ProductionTaskHandler) GenerateTasks(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	tasks, err := h.productionTaskService.GenerateTasks(r.Context(), r.PathValue("id"), authUser.TenantID)
+	if err != nil {
+		writeProductionTaskError(w, "Failed to generate production tasks: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"tasks": tasks,
+		"total": len(tasks),
+	})
+}
+
+// ListOrderTasks GET /api/orders/{id}/production-tasks - 注文の製造タスクを取得
+func (h *ProductionTaskHandler) ListOrderTasks(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	tasks, err := h.productionTaskService.ListOrderTasks(r.Context(), r.PathValue("id"), authUser.TenantID)
+	if err != nil {
+		writeProductionTaskError(w, "Failed to list production tasks: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"tasks": tasks,
+		"total": len(tasks),
+	})
+}
+
+// ListTasks GET /api/production/tasks?status=PENDING,PAUSED&process=SEWING&assigned_to=xxx - 製造タスクを検索
+func (h *ProductionTaskHandler) ListTasks(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	query := r.URL.Query()
+	tasks, err := h.productionTaskService.ListTasks(r.Context(), &domain.ProductionTaskFilter{
+		TenantID:   authUser.TenantID,
+		AssignedTo: query.Get("assigned_to"),
+		Process:    domain.ProductionProcess(strings.ToUpper(query.Get("process"))),
+		Statuses:   parseProductionTaskStatuses(query.Get("status")),
+	})
+	if err != nil {
+		writeProductionTaskError(w, "Failed to list production tasks: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"tasks": tasks,
+		"total": len(tasks),
+	})
+}
+
+// ListMyTasks GET /api/my/tasks?status=PENDING,IN_PROGRESS - ログイン中の作業者の担当タスクを取得
+// status 未指定の場合は未完了のタスク
+func (h *ProductionTaskHandler) ListMyTasks(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 担当タスクは認証済みユーザーのみ（tenant_id クエリでの代替は不可）
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	tasks, err := h.productionTaskService.ListMyTasks(r.Context(), authUser.TenantID, authUser.ID, parseProductionTaskStatuses(r.URL.Query().Get("status")))
+	if err != nil {
+		writeProductionTaskError(w, "Failed to list my tasks: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"tasks": tasks,
+		"total": len(tasks),
+	})
+}
+
+// AssignTask PUT /api/production/tasks/{id}/assign - 担当作業者を割り当て
+func (h *ProductionTaskHandler) AssignTask(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req service.AssignProductionTaskRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+	req.TaskID = r.PathValue("id")
+	req.TenantID = authUser.TenantID
+
+	task, err := h.productionTaskService.AssignTask(r.Context(), &req)
+	if err != nil {
+		writeProductionTaskError(w, "Failed to assign production task: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(task)
+}
+
+// StartTask POST /api/production/tasks/{id}/start - 作業を開始
+func (h *ProductionTaskHandler) StartTask(w http.ResponseWriter, r *http.Request) {
+	h.handleAction(w, r, h.productionTaskService.StartTask, "Failed to start production task: ")
+}
+
+// PauseTask POST /api/production/tasks/{id}/pause - 作業を中断
+func (h *ProductionTaskHandler) PauseTask(w http.ResponseWriter, r *http.Request) {
+	h.handleAction(w, r, h.productionTaskService.PauseTask, "Failed to pause production task: ")
+}
+
+// CompleteTask POST /api/production/tasks/{id}/complete - 作業を完了
+func (h *ProductionTaskHandler) CompleteTask(w http.ResponseWriter, r *http.Request) {
+	h.handleAction(w, r, h.productionTaskService.CompleteTask, "Failed to complete production task: ")
+}
+
+// GetWorkerThroughput GET /api/production/reports/worker-throughput?from=2026-10-01&to=2026-11-01 - 作業者別の実績
+// from を含み to を含まない。未指定の場合は直近30日
+func (h *ProductionTaskHandler) GetWorkerThroughput(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	today := domain.ProductionDay(time.Now())
+	to := today.AddDate(0, 0, 1)
+	if value := r.URL.Query().Get("to"); value != "" {
+		to, err = time.Parse("2006-01-02", value)
+		if err != nil {
+			http.Error(w, "Invalid to (expected YYYY-MM-DD)", http.StatusBadRequest)
+			return
+		}
+	}
+	from := to.AddDate(0, 0, -30)
+	if value := r.URL.Query().Get("from"); value != "" {
+		from, err = time.Parse("2006-01-02", value)
+		if err != nil {
+			http.Error(w, "Invalid from (expected YYYY-MM-DD)", http.StatusBadRequest)
+			return
+		}
+	}
+
+	report, err := h.productionTaskService.GetWorkerThroughput(r.Context(), authUser.TenantID, from, to)
+	if err != nil {
+		writeProductionTaskError(w, "Failed to get worker throughput: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
+
+// handleAction 作業の開始・中断・完了の共通処理
+func (h *ProductionTaskHandler) handleAction(
+	w http.ResponseWriter,
+	r *http.Request,
+	action func(ctx context.Context, req *service.ProductionTaskActionRequest) (*service.ProductionTaskActionResponse, error),
+	errorPrefix string,
+) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 作業記録は認証済みユーザーのみ（作業者IDが必要）
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	resp, err := action(r.Context(), &service.ProductionTaskActionRequest{
+		TaskID:    r.PathValue("id"),
+		TenantID:  authUser.TenantID,
+		WorkerID:  authUser.ID,
+		Role:      domain.UserRole(authUser.Role),
+		IPAddress: extractIPAddress(r),
+		UserAgent: r.UserAgent(),
+	})
+	if err != nil {
+		writeProductionTaskError(w, errorPrefix, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// parseProductionTaskStatuses カンマ区切りのステータスを解析
+func parseProductionTaskStatuses(value string) []domain.ProductionTaskStatus {
+	statuses := make([]domain.ProductionTaskStatus, 0)
+	for _, part := range strings.Split(value, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			statuses = append(statuses, domain.ProductionTaskStatus(strings.ToUpper(part)))
+		}
+	}
+	return statuses
+}
+
+// writeProductionTaskError エラー内容に応じたステータスコードで応答
+func writeProductionTaskError(w http.ResponseWriter, prefix string, err error) {
+	statusCode := http.StatusInternalServerError
+	if strings.Contains(err.Error(), "not found") {
+		statusCode = http.StatusNotFound
+	} else if strings.Contains(err.Error(), "invalid task status") || strings.Contains(err.Error(), "invalid order status") {
+		statusCode = http.StatusConflict
+	} else if strings.Contains(err.Error(), "unauthorized") {
+		statusCode = http.StatusForbidden
+	} else if strings.Contains(err.Error(), "invalid") || strings.Contains(err.Error(), "required") {
+		statusCode = http.StatusBadRequest
+	}
+	http.Error(w, prefix+err.Error(), statusCode)
+}
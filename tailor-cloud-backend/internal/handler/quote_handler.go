@@ -0,0 +1,395 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"tailor-cloud/backend/internal/config/domain"
+	"tailor-cloud/backend/internal/middleware"
+	"tailor-cloud/backend/internal/service"
+)
+
+// QuoteHandler 見積書ハンドラー
+type QuoteHandler struct {
+	quoteService *service.QuoteService
+}
+
+// NewQuoteHandler QuoteHandlerのコンストラクタ
+func NewQuoteHandler(quoteService *service.QuoteService) *QuoteHandler {
+	return &QuoteHandler{
+		quoteService: quoteService,
+	}
+}
+
+// CreateQuote POST /api/quotes - 見積書を作成
+func (h *QuoteHandler) CreateQuote(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req service.CreateQuoteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID, ID: req.CreatedBy}
+	}
+	req.TenantID = authUser.TenantID
+	req.CreatedBy = authUser.ID
+
+	quote, err := h.quoteService.CreateQuote(r.Context(), &req)
+	if err != nil {
+		writeQuoteError(w, "Failed to create quote: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(quote)
+}
+
+// ListQuotes GET /api/quotes - 見積書一覧を取得（?status= で絞り込み）
+func (h *QuoteHandler) ListQuotes(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	status := domain.QuoteStatus(r.URL.Query().Get("status"))
+	quotes, err := h.quoteService.ListQuotes(r.Context(), authUser.TenantID, status)
+	if err != nil {
+		writeQuoteError(w, "Failed to list quotes: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"quotes": quotes,
+		"total":  len(quotes),
+	})
+}
+
+// GetQuote GET /api/quotes/{id} - 見積書を取得
+func (h *QuoteHandler) GetQuote(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	quoteID := r.PathValue("id")
+	if quoteID == "" {
+		http.Error(w, "quote_id is required", http.StatusBadRequest)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	quote, err := h.quoteService.GetQuote(r.Context(), quoteID, authUser.TenantID)
+	if err != nil {
+		writeQuoteError(w, "Failed to get quote: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(quote)
+}
+
+// GetQuoteVersions GET /api/quotes/{id}/versions - 見積書の改訂履歴を取得
+func (h *QuoteHandler) GetQuoteVersions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	quoteID := r.PathValue("id")
+	if quoteID == "" {
+		http.Error(w, "quote_id is required", http.StatusBadRequest)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	versions, err := h.quoteService.GetQuoteVersions(r.Context(), quoteID, authUser.TenantID)
+	if err != nil {
+		writeQuoteError(w, "Failed to get quote versions: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"versions": versions,
+		"total":    len(versions),
+	})
+}
+
+// ReviseQuote POST /api/quotes/{id}/revise - 見積書を改訂（新バージョンを作成）
+func (h *QuoteHandler) ReviseQuote(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req service.ReviseQuoteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	req.QuoteID = r.PathValue("id")
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID, ID: req.CreatedBy}
+	}
+	req.TenantID = authUser.TenantID
+	req.CreatedBy = authUser.ID
+
+	quote, err := h.quoteService.ReviseQuote(r.Context(), &req)
+	if err != nil {
+		writeQuoteError(w, "Failed to revise quote: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(quote)
+}
+
+// SendQuote POST /api/quotes/{id}/send - 見積書を顧客へ提示済みにする
+func (h *QuoteHandler) SendQuote(w http.ResponseWriter, r *http.Request) {
+	h.changeQuoteStatus(w, r, h.quoteService.SendQuote)
+}
+
+// AcceptQuote POST /api/quotes/{id}/accept - 顧客の承諾を記録
+func (h *QuoteHandler) AcceptQuote(w http.ResponseWriter, r *http.Request) {
+	h.changeQuoteStatus(w, r, h.quoteService.AcceptQuote)
+}
+
+// RejectQuote POST /api/quotes/{id}/reject - 顧客の辞退を記録
+func (h *QuoteHandler) RejectQuote(w http.ResponseWriter, r *http.Request) {
+	h.changeQuoteStatus(w, r, h.quoteService.RejectQuote)
+}
+
+// changeQuoteStatus 見積ステータス変更の共通処理
+func (h *QuoteHandler) changeQuoteStatus(
+	w http.ResponseWriter,
+	r *http.Request,
+	change func(ctx context.Context, quoteID string, tenantID string) (*domain.Quote, error),
+) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	quoteID := r.PathValue("id")
+	if quoteID == "" {
+		http.Error(w, "quote_id is required", http.StatusBadRequest)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	quote, err := change(r.Context(), quoteID, authUser.TenantID)
+	if err != nil {
+		writeQuoteError(w, "Failed to update quote: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(quote)
+}
+
+// ConvertQuote POST /api/quotes/{id}/convert - 承諾済みの見積書を注文（Draft）に変換
+func (h *QuoteHandler) ConvertQuote(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req service.ConvertQuoteRequest
+	// ボディは任意（納期の上書きのみ）
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	req.QuoteID = r.PathValue("id")
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID, ID: req.CreatedBy}
+	}
+	req.TenantID = authUser.TenantID
+	req.CreatedBy = authUser.ID
+	req.IPAddress = extractIPAddress(r)
+	req.UserAgent = r.UserAgent()
+
+	resp, err := h.quoteService.ConvertToOrder(r.Context(), &req)
+	if err != nil {
+		writeQuoteError(w, "Failed to convert quote: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// DownloadQuotePDF GET /api/quotes/{id}/pdf - 見積書PDFをダウンロード
+func (h *QuoteHandler) DownloadQuotePDF(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	quoteID := r.PathValue("id")
+	if quoteID == "" {
+		http.Error(w, "quote_id is required", http.StatusBadRequest)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	pdfData, err := h.quoteService.GenerateQuotePDF(r.Context(), quoteID, authUser.TenantID)
+	if err != nil {
+		writeQuoteError(w, "Failed to generate quote PDF: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="quote_%s.pdf"`, quoteID))
+	w.WriteHeader(http.StatusOK)
+	w.Write(pdfData)
+}
+
+// GetConversionReport GET /api/reports/quote-conversion?from=YYYY-MM-DD&to=YYYY-MM-DD - 見積→注文の変換率
+func (h *QuoteHandler) GetConversionReport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	from, err := time.Parse("2006-01-02", r.URL.Query().Get("from"))
+	if err != nil {
+		http.Error(w, "Invalid from format. Use YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+	to, err := time.Parse("2006-01-02", r.URL.Query().Get("to"))
+	if err != nil {
+		http.Error(w, "Invalid to format. Use YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+
+	// to は当日を含める
+	report, err := h.quoteService.GetConversionReport(r.Context(), authUser.TenantID, from, to.AddDate(0, 0, 1))
+	if err != nil {
+		writeQuoteError(w, "Failed to get quote conversion report: ", err)
+		return
+	}
+	report.To = to
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(report)
+}
+
+// writeQuoteError エラー内容に応じたステータスコードで返す
+func writeQuoteError(w http.ResponseWriter, prefix string, err error) {
+	statusCode := http.StatusInternalServerError
+	if strings.Contains(err.Error(), "not found") {
+		statusCode = http.StatusNotFound
+	} else if strings.Contains(err.Error(), "invalid quote status") {
+		statusCode = http.StatusConflict
+	} else if strings.Contains(err.Error(), "invalid") || strings.Contains(err.Error(), "required") {
+		statusCode = http.StatusBadRequest
+	}
+	http.Error(w, prefix+err.Error(), statusCode)
+}
@@ -0,0 +1,325 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"tailor-cloud/backend/internal/config/domain"
+	"tailor-cloud/backend/internal/middleware"
+	"tailor-cloud/backend/internal/service"
+)
+
+// ShipmentHandler 出荷管理ハンドラー
+type ShipmentHandler struct {
+	shipmentService *service.ShipmentService
+}
+
+// NewShipmentHandler ShipmentHandlerのコンストラクタ
+func NewShipmentHandler(shipmentService *service.ShipmentService) *ShipmentHandler {
+	return &ShipmentHandler{
+		shipmentService: shipmentService,
+	}
+}
+
+// CreateShipment POST /api/orders/{id}/shipment - 出荷を登録し、注文を発送済みにする
+func (h *ShipmentHandler) CreateShipment(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var body struct {
+		service.CreateShipmentRequest
+		ShippedAt string `json:"shipped_at"` // YYYY-MM-DD（未指定の場合は当日）
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	req := body.CreateShipmentRequest
+	if body.ShippedAt != "" {
+		shippedAt, err := time.ParseInLocation("2006-01-02", body.ShippedAt, time.Local)
+		if err != nil {
+			http.Error(w, "Invalid shipped_at (expected YYYY-MM-DD)", http.StatusBadRequest)
+			return
+		}
+		req.ShippedAt = shippedAt
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+	req.OrderID = r.PathValue("id")
+	req.TenantID = authUser.TenantID
+	req.CreatedBy = authUser.ID
+	req.Carrier = domain.Carrier(strings.ToUpper(string(req.Carrier)))
+	req.IPAddress = extractIPAddress(r)
+	req.UserAgent = r.UserAgent()
+
+	resp, err := h.shipmentService.CreateShipment(r.Context(), &req)
+	if err != nil {
+		writeShipmentError(w, "Failed to create shipment: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// GetOrderShipment GET /api/orders/{id}/shipment - 注文の出荷記録を取得
+func (h *ShipmentHandler) GetOrderShipment(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	shipment, err := h.shipmentService.GetOrderShipment(r.Context(), r.PathValue("id"), authUser.TenantID)
+	if err != nil {
+		writeShipmentError(w, "Failed to get shipment: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(shipment)
+}
+
+// ListShipments GET /api/shipments?status=SHIPPED,IN_TRANSIT&carrier=YAMATO - 出荷記録を検索
+func (h *ShipmentHandler) ListShipments(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	query := r.URL.Query()
+	filter := &domain.ShipmentFilter{
+		TenantID: authUser.TenantID,
+		Carrier:  domain.Carrier(strings.ToUpper(query.Get("carrier"))),
+	}
+	for _, value := range strings.Split(query.Get("status"), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			filter.Statuses = append(filter.Statuses, domain.ShipmentStatus(strings.ToUpper(value)))
+		}
+	}
+
+	shipments, err := h.shipmentService.ListShipments(r.Context(), filter)
+	if err != nil {
+		writeShipmentError(w, "Failed to list shipments: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"shipments": shipments,
+		"total":     len(shipments),
+	})
+}
+
+// GetShipment GET /api/shipments/{id} - 出荷記録を取得
+func (h *ShipmentHandler) GetShipment(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	shipment, err := h.shipmentService.GetShipment(r.Context(), r.PathValue("id"), authUser.TenantID)
+	if err != nil {
+		writeShipmentError(w, "Failed to get shipment: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(shipment)
+}
+
+// RefreshTracking POST /api/shipments/{id}/tracking - 配送業者から追跡情報を取得して反映
+// 配達完了の場合は注文を納品完了にする
+func (h *ShipmentHandler) RefreshTracking(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	resp, err := h.shipmentService.RefreshTracking(r.Context(), r.PathValue("id"), authUser.TenantID)
+	if err != nil {
+		writeShipmentError(w, "Failed to refresh shipment tracking: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// ConfirmDelivery POST /api/shipments/{id}/deliver - 配達完了を手動で登録（手渡し・追跡できない場合）
+// delivered_at は RFC3339 または YYYY-MM-DD（未指定の場合は現在日時）
+func (h *ShipmentHandler) ConfirmDelivery(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 配達完了の登録は認証済みユーザーのみ（登録者を監査ログに記録）
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	var body struct {
+		service.ConfirmDeliveryRequest
+		DeliveredAt string `json:"delivered_at"`
+	}
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	req := body.ConfirmDeliveryRequest
+	if body.DeliveredAt != "" {
+		deliveredAt, err := time.Parse(time.RFC3339, body.DeliveredAt)
+		if err != nil {
+			deliveredAt, err = time.ParseInLocation("2006-01-02", body.DeliveredAt, time.Local)
+		}
+		if err != nil {
+			http.Error(w, "Invalid delivered_at (expected RFC3339 or YYYY-MM-DD)", http.StatusBadRequest)
+			return
+		}
+		req.DeliveredAt = deliveredAt
+	}
+	req.ShipmentID = r.PathValue("id")
+	req.TenantID = authUser.TenantID
+	req.UserID = authUser.ID
+	req.IPAddress = extractIPAddress(r)
+	req.UserAgent = r.UserAgent()
+
+	resp, err := h.shipmentService.ConfirmDelivery(r.Context(), &req)
+	if err != nil {
+		writeShipmentError(w, "Failed to confirm delivery: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// DownloadPackingSlip GET /api/shipments/{id}/packing-slip - 納品書PDFをダウンロード
+func (h *ShipmentHandler) DownloadPackingSlip(w http.ResponseWriter, r *http.Request) {
+	h.downloadPDF(w, r, h.shipmentService.GeneratePackingSlipPDF, "packing_slip", "Failed to generate packing slip: ")
+}
+
+// DownloadShippingLabel GET /api/shipments/{id}/label - 送り状PDFをダウンロード（1個口につき1ページ）
+func (h *ShipmentHandler) DownloadShippingLabel(w http.ResponseWriter, r *http.Request) {
+	h.downloadPDF(w, r, h.shipmentService.GenerateShippingLabelPDF, "shipping_label", "Failed to generate shipping label: ")
+}
+
+// downloadPDF 納品書・送り状PDFのダウンロードの共通処理
+func (h *ShipmentHandler) downloadPDF(
+	w http.ResponseWriter,
+	r *http.Request,
+	generate func(ctx context.Context, shipmentID, tenantID string) ([]byte, error),
+	filePrefix string,
+	errorPrefix string,
+) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 認証済みユーザー情報をコンテキストから取得
+	authUser, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		tenantID := r.URL.Query().Get("tenant_id")
+		if tenantID == "" {
+			http.Error(w, "Authentication required or tenant_id must be provided", http.StatusUnauthorized)
+			return
+		}
+		authUser = &middleware.AuthUser{TenantID: tenantID}
+	}
+
+	shipmentID := r.PathValue("id")
+	pdfData, err := generate(r.Context(), shipmentID, authUser.TenantID)
+	if err != nil {
+		writeShipmentError(w, errorPrefix, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s_%s.pdf"`, filePrefix, shipmentID))
+	w.WriteHeader(http.StatusOK)
+	w.Write(pdfData)
+}
+
+// writeShipmentError エラー内容に応じたステータスコードでエラーを返す
+func writeShipmentError(w http.ResponseWriter, prefix string, err error) {
+	statusCode := http.StatusInternalServerError
+	if strings.Contains(err.Error(), "not found") {
+		statusCode = http.StatusNotFound
+	} else if strings.Contains(err.Error(), "invalid shipment status") || strings.Contains(err.Error(), "invalid order status") {
+		statusCode = http.StatusConflict
+	} else if strings.Contains(err.Error(), "unauthorized") {
+		statusCode = http.StatusForbidden
+	} else if strings.Contains(err.Error(), "not configured") {
+		statusCode = http.StatusServiceUnavailable
+	} else if strings.Contains(err.Error(), "failed to track shipment") {
+		statusCode = http.StatusBadGateway
+	} else if strings.Contains(err.Error(), "invalid") || strings.Contains(err.Error(), "required") {
+		statusCode = http.StatusBadRequest
+	}
+	http.Error(w, prefix+err.Error(), statusCode)
+}
@@ -67,6 +67,21 @@ func (m *RBACMiddleware) RequireOwnerOnly() func(http.HandlerFunc) http.HandlerF
 	return m.RequireRole(domain.RoleOwner)
 }
 
+// RequireOwnerOrFactoryManager OwnerまたはFactory_Managerロールを要求
+func (m *RBACMiddleware) RequireOwnerOrFactoryManager() func(http.HandlerFunc) http.HandlerFunc {
+	return m.RequireRole(domain.RoleOwner, domain.RoleFactoryManager)
+}
+
+// RequireOwnerStaffOrFactoryManager Owner・Staff・Factory_Managerロールのいずれかを要求
+func (m *RBACMiddleware) RequireOwnerStaffOrFactoryManager() func(http.HandlerFunc) http.HandlerFunc {
+	return m.RequireRole(domain.RoleOwner, domain.RoleStaff, domain.RoleFactoryManager)
+}
+
+// RequireProductionFloor 製造現場のロール（Owner・Factory_Manager・Worker）を要求
+func (m *RBACMiddleware) RequireProductionFloor() func(http.HandlerFunc) http.HandlerFunc {
+	return m.RequireRole(domain.RoleOwner, domain.RoleFactoryManager, domain.RoleWorker)
+}
+
 // CheckTenantAccess テナントアクセスチェック
 // リクエストのtenant_idが、ユーザーのtenant_idと一致しているかチェック
 func CheckTenantAccess(r *http.Request, requestedTenantID string) error {
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	_ "github.com/lib/pq"
+	"tailor-cloud/backend/internal/config/domain"
+)
+
+// AccountMappingRepository 勘定科目マッピングリポジトリインターフェース
+type AccountMappingRepository interface {
+	Upsert(ctx context.Context, mapping *domain.AccountMapping) error
+	GetByTenantID(ctx context.Context, tenantID string) ([]*domain.AccountMapping, error)
+}
+
+// PostgreSQLAccountMappingRepository PostgreSQLを使った勘定科目マッピングリポジトリ実装
+type PostgreSQLAccountMappingRepository struct {
+	db *sql.DB
+}
+
+// NewPostgreSQLAccountMappingRepository PostgreSQLAccountMappingRepositoryのコンストラクタ
+func NewPostgreSQLAccountMappingRepository(db *sql.DB) AccountMappingRepository {
+	return &PostgreSQLAccountMappingRepository{
+		db: db,
+	}
+}
+
+// Upsert 勘定科目マッピングを登録・更新（テナント×科目キーで一意）
+func (r *PostgreSQLAccountMappingRepository) Upsert(ctx context.Context, mapping *domain.AccountMapping) error {
+	query := `
+		INSERT INTO account_mappings (
+			id, tenant_id, account_key, account_name, sub_account_name, account_code, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (tenant_id, account_key) DO UPDATE SET
+			account_name = EXCLUDED.account_name,
+			sub_account_name = EXCLUDED.sub_account_name,
+			account_code = EXCLUDED.account_code,
+			updated_at = EXCLUDED.updated_at
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		mapping.ID,
+		mapping.TenantID,
+		string(mapping.AccountKey),
+		mapping.AccountName,
+		nullIfEmpty(mapping.SubAccountName),
+		nullIfEmpty(mapping.AccountCode),
+		mapping.CreatedAt,
+		mapping.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to upsert account mapping: %w", err)
+	}
+
+	return nil
+}
+
+// GetByTenantID テナントの勘定科目マッピング一覧を取得
+func (r *PostgreSQLAccountMappingRepository) GetByTenantID(ctx context.Context, tenantID string) ([]*domain.AccountMapping, error) {
+	query := `
+		SELECT id, tenant_id, account_key, account_name, sub_account_name, account_code, created_at, updated_at
+		FROM account_mappings
+		WHERE tenant_id = $1
+		ORDER BY account_key
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query account mappings: %w", err)
+	}
+	defer rows.Close()
+
+	mappings := make([]*domain.AccountMapping, 0)
+	for rows.Next() {
+		var mapping domain.AccountMapping
+		var accountKey string
+		var subAccountName, accountCode sql.NullString
+
+		err := rows.Scan(
+			&mapping.ID,
+			&mapping.TenantID,
+			&accountKey,
+			&mapping.AccountName,
+			&subAccountName,
+			&accountCode,
+			&mapping.CreatedAt,
+			&mapping.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan account mapping: %w", err)
+		}
+
+		mapping.AccountKey = domain.AccountKey(accountKey)
+		mapping.SubAccountName = subAccountName.String
+		mapping.AccountCode = accountCode.String
+		mappings = append(mappings, &mapping)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating account mappings: %w", err)
+	}
+
+	return mappings, nil
+}
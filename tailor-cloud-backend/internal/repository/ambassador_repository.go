@@ -27,6 +27,7 @@ type CommissionRepository interface {
 	GetByOrderID(ctx context.Context, orderID string) (*domain.Commission, error)
 	GetByAmbassadorID(ctx context.Context, ambassadorID string, limit, offset int) ([]*domain.Commission, error)
 	GetByTenantID(ctx context.Context, tenantID string, limit, offset int) ([]*domain.Commission, error)
+	GetByPeriod(ctx context.Context, tenantID string, from, to time.Time) ([]*domain.Commission, error)
 	UpdateStatus(ctx context.Context, commissionID string, status domain.CommissionStatus) error
 }
 
@@ -525,6 +526,62 @@ func (r *PostgreSQLCommissionRepository) GetByTenantID(ctx context.Context, tena
 	return commissions, nil
 }
 
+// GetByPeriod 期間内（from以上to未満）に発生した成果報酬を件数の上限なしで取得
+func (r *PostgreSQLCommissionRepository) GetByPeriod(ctx context.Context, tenantID string, from, to time.Time) ([]*domain.Commission, error) {
+	query := `
+		SELECT 
+			id, order_id, ambassador_id, tenant_id,
+			order_amount, commission_rate, commission_amount,
+			status, paid_at, created_at, updated_at
+		FROM commissions
+		WHERE tenant_id = $1 AND created_at >= $2 AND created_at < $3
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query commissions: %w", err)
+	}
+	defer rows.Close()
+
+	commissions := make([]*domain.Commission, 0)
+	for rows.Next() {
+		var commission domain.Commission
+		var statusStr string
+		var paidAt sql.NullTime
+
+		err := rows.Scan(
+			&commission.ID,
+			&commission.OrderID,
+			&commission.AmbassadorID,
+			&commission.TenantID,
+			&commission.OrderAmount,
+			&commission.CommissionRate,
+			&commission.CommissionAmount,
+			&statusStr,
+			&paidAt,
+			&commission.CreatedAt,
+			&commission.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan commission: %w", err)
+		}
+
+		commission.Status = domain.CommissionStatus(statusStr)
+		if paidAt.Valid {
+			commission.PaidAt = &paidAt.Time
+		}
+
+		commissions = append(commissions, &commission)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating commissions: %w", err)
+	}
+
+	return commissions, nil
+}
+
 // UpdateStatus 成果報酬ステータスを更新
 func (r *PostgreSQLCommissionRepository) UpdateStatus(ctx context.Context, commissionID string, status domain.CommissionStatus) error {
 	query := `
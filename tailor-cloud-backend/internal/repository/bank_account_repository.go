@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	_ "github.com/lib/pq"
+	"tailor-cloud/backend/internal/config/domain"
+)
+
+// BankAccountRepository 銀行口座リポジトリインターフェース
+type BankAccountRepository interface {
+	Create(ctx context.Context, account *domain.BankAccount) error
+	GetByID(ctx context.Context, accountID string, tenantID string) (*domain.BankAccount, error)
+	GetByOwner(ctx context.Context, tenantID string, ownerType domain.BankAccountOwner, ownerID string) (*domain.BankAccount, error)
+	GetDefault(ctx context.Context, tenantID string, ownerType domain.BankAccountOwner) (*domain.BankAccount, error)
+	GetByTenantID(ctx context.Context, tenantID string) ([]*domain.BankAccount, error)
+}
+
+// PostgreSQLBankAccountRepository PostgreSQLを使った銀行口座リポジトリ実装
+type PostgreSQLBankAccountRepository struct {
+	db *sql.DB
+}
+
+// NewPostgreSQLBankAccountRepository PostgreSQLBankAccountRepositoryのコンストラクタ
+func NewPostgreSQLBankAccountRepository(db *sql.DB) BankAccountRepository {
+	return &PostgreSQLBankAccountRepository{
+		db: db,
+	}
+}
+
+const bankAccountColumns = `
+	id, tenant_id, owner_type, owner_id, bank_code, bank_name_kana,
+	branch_code, branch_name_kana, account_type, account_number,
+	account_holder_kana, client_code, is_default, created_at, updated_at
+`
+
+// Create 銀行口座を登録
+func (r *PostgreSQLBankAccountRepository) Create(ctx context.Context, account *domain.BankAccount) error {
+	query := `
+		INSERT INTO bank_accounts (` + bankAccountColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		account.ID,
+		account.TenantID,
+		string(account.OwnerType),
+		account.OwnerID,
+		account.BankCode,
+		account.BankNameKana,
+		account.BranchCode,
+		account.BranchNameKana,
+		string(account.AccountType),
+		account.AccountNumber,
+		account.AccountHolderKana,
+		nullIfEmpty(account.ClientCode),
+		account.IsDefault,
+		account.CreatedAt,
+		account.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create bank account: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID 口座IDで取得（テナントIDもチェック）
+func (r *PostgreSQLBankAccountRepository) GetByID(ctx context.Context, accountID string, tenantID string) (*domain.BankAccount, error) {
+	query := `SELECT ` + bankAccountColumns + ` FROM bank_accounts WHERE id = $1 AND tenant_id = $2`
+
+	account, err := scanBankAccount(r.db.QueryRowContext(ctx, query, accountID, tenantID))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("bank account not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bank account: %w", err)
+	}
+
+	return account, nil
+}
+
+// GetByOwner 所有者（工場・アンバサダー）の口座を取得（既定口座を優先）
+func (r *PostgreSQLBankAccountRepository) GetByOwner(ctx context.Context, tenantID string, ownerType domain.BankAccountOwner, ownerID string) (*domain.BankAccount, error) {
+	query := `
+		SELECT ` + bankAccountColumns + `
+		FROM bank_accounts
+		WHERE tenant_id = $1 AND owner_type = $2 AND owner_id = $3
+		ORDER BY is_default DESC, created_at DESC
+		LIMIT 1
+	`
+
+	account, err := scanBankAccount(r.db.QueryRowContext(ctx, query, tenantID, string(ownerType), ownerID))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("bank account not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bank account: %w", err)
+	}
+
+	return account, nil
+}
+
+// GetDefault 所有者種別ごとの既定口座を取得
+func (r *PostgreSQLBankAccountRepository) GetDefault(ctx context.Context, tenantID string, ownerType domain.BankAccountOwner) (*domain.BankAccount, error) {
+	query := `
+		SELECT ` + bankAccountColumns + `
+		FROM bank_accounts
+		WHERE tenant_id = $1 AND owner_type = $2 AND is_default = TRUE
+		ORDER BY created_at DESC
+		LIMIT 1
+	`
+
+	account, err := scanBankAccount(r.db.QueryRowContext(ctx, query, tenantID, string(ownerType)))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("default bank account not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get default bank account: %w", err)
+	}
+
+	return account, nil
+}
+
+// GetByTenantID テナントの口座一覧を取得
+func (r *PostgreSQLBankAccountRepository) GetByTenantID(ctx context.Context, tenantID string) ([]*domain.BankAccount, error) {
+	query := `SELECT ` + bankAccountColumns + ` FROM bank_accounts WHERE tenant_id = $1 ORDER BY owner_type, created_at`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query bank accounts: %w", err)
+	}
+	defer rows.Close()
+
+	accounts := make([]*domain.BankAccount, 0)
+	for rows.Next() {
+		account, err := scanBankAccount(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan bank account: %w", err)
+		}
+		accounts = append(accounts, account)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating bank accounts: %w", err)
+	}
+
+	return accounts, nil
+}
+
+// scanBankAccount 1行分の銀行口座をスキャン
+func scanBankAccount(row rowScanner) (*domain.BankAccount, error) {
+	var account domain.BankAccount
+	var ownerType, accountType string
+	var bankNameKana, branchNameKana, clientCode sql.NullString
+
+	err := row.Scan(
+		&account.ID,
+		&account.TenantID,
+		&ownerType,
+		&account.OwnerID,
+		&account.BankCode,
+		&bankNameKana,
+		&account.BranchCode,
+		&branchNameKana,
+		&accountType,
+		&account.AccountNumber,
+		&account.AccountHolderKana,
+		&clientCode,
+		&account.IsDefault,
+		&account.CreatedAt,
+		&account.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	account.OwnerType = domain.BankAccountOwner(ownerType)
+	account.AccountType = domain.BankAccountType(accountType)
+	account.BankNameKana = bankNameKana.String
+	account.BranchNameKana = branchNameKana.String
+	account.ClientCode = clientCode.String
+
+	return &account, nil
+}
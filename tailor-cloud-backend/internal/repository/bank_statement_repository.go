@@ -0,0 +1,217 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	_ "github.com/lib/pq"
+	"tailor-cloud/backend/internal/config/domain"
+)
+
+// BankStatementRepository 入出金明細リポジトリインターフェース
+type BankStatementRepository interface {
+	Create(ctx context.Context, entry *domain.BankStatementEntry) error
+	GetByID(ctx context.Context, entryID string, tenantID string) (*domain.BankStatementEntry, error)
+	GetByTenantID(ctx context.Context, tenantID string, status domain.BankStatementEntryStatus) ([]*domain.BankStatementEntry, error)
+	GetByImportID(ctx context.Context, importID string, tenantID string) ([]*domain.BankStatementEntry, error)
+	UpdateStatus(ctx context.Context, entryID string, tenantID string, status domain.BankStatementEntryStatus) error
+}
+
+// PostgreSQLBankStatementRepository PostgreSQLを使った入出金明細リポジトリ実装
+type PostgreSQLBankStatementRepository struct {
+	db *sql.DB
+}
+
+// NewPostgreSQLBankStatementRepository PostgreSQLBankStatementRepositoryのコンストラクタ
+func NewPostgreSQLBankStatementRepository(db *sql.DB) BankStatementRepository {
+	return &PostgreSQLBankStatementRepository{
+		db: db,
+	}
+}
+
+const bankStatementEntryColumns = `
+	id, tenant_id, import_id, source_format, reference_no, transaction_date,
+	amount, payer_name, payer_code, remitter_bank_name, description,
+	status, matched_order_id, transaction_id, reconciled_by, reconciled_at,
+	created_at, updated_at
+`
+
+// Create 入出金明細を作成
+func (r *PostgreSQLBankStatementRepository) Create(ctx context.Context, entry *domain.BankStatementEntry) error {
+	query := `
+		INSERT INTO bank_statement_entries (` + bankStatementEntryColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		entry.ID,
+		entry.TenantID,
+		entry.ImportID,
+		string(entry.SourceFormat),
+		entry.ReferenceNo,
+		entry.TransactionDate,
+		entry.Amount,
+		entry.PayerName,
+		entry.PayerCode,
+		entry.RemitterBankName,
+		entry.Description,
+		string(entry.Status),
+		nullIfEmpty(entry.MatchedOrderID),
+		nullIfEmpty(entry.TransactionID),
+		nullIfEmpty(entry.ReconciledBy),
+		entry.ReconciledAt,
+		entry.CreatedAt,
+		entry.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create bank statement entry: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID 入出金明細IDで取得（テナントIDもチェック）
+func (r *PostgreSQLBankStatementRepository) GetByID(ctx context.Context, entryID string, tenantID string) (*domain.BankStatementEntry, error) {
+	query := `SELECT ` + bankStatementEntryColumns + ` FROM bank_statement_entries WHERE id = $1 AND tenant_id = $2`
+
+	entry, err := scanBankStatementEntry(r.db.QueryRowContext(ctx, query, entryID, tenantID))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("bank statement entry not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bank statement entry: %w", err)
+	}
+
+	return entry, nil
+}
+
+// GetByTenantID テナントIDで入出金明細一覧を取得（statusが空の場合は全件）
+func (r *PostgreSQLBankStatementRepository) GetByTenantID(ctx context.Context, tenantID string, status domain.BankStatementEntryStatus) ([]*domain.BankStatementEntry, error) {
+	query := `SELECT ` + bankStatementEntryColumns + ` FROM bank_statement_entries WHERE tenant_id = $1`
+	args := []interface{}{tenantID}
+
+	if status != "" {
+		query += " AND status = $2"
+		args = append(args, string(status))
+	}
+	query += " ORDER BY transaction_date DESC, created_at DESC"
+
+	return r.queryEntries(ctx, query, args...)
+}
+
+// GetByImportID 取込バッチIDで入出金明細一覧を取得
+func (r *PostgreSQLBankStatementRepository) GetByImportID(ctx context.Context, importID string, tenantID string) ([]*domain.BankStatementEntry, error) {
+	query := `SELECT ` + bankStatementEntryColumns + ` FROM bank_statement_entries WHERE import_id = $1 AND tenant_id = $2 ORDER BY transaction_date ASC`
+	return r.queryEntries(ctx, query, importID, tenantID)
+}
+
+// UpdateStatus 入出金明細のステータスを更新
+func (r *PostgreSQLBankStatementRepository) UpdateStatus(ctx context.Context, entryID string, tenantID string, status domain.BankStatementEntryStatus) error {
+	query := `
+		UPDATE bank_statement_entries SET
+			status = $3,
+			updated_at = $4
+		WHERE id = $1 AND tenant_id = $2
+	`
+
+	result, err := r.db.ExecContext(ctx, query, entryID, tenantID, string(status), time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to update bank statement entry status: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("bank statement entry not found or tenant_id mismatch")
+	}
+
+	return nil
+}
+
+// queryEntries 入出金明細一覧を取得する共通処理
+func (r *PostgreSQLBankStatementRepository) queryEntries(ctx context.Context, query string, args ...interface{}) ([]*domain.BankStatementEntry, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query bank statement entries: %w", err)
+	}
+	defer rows.Close()
+
+	entries := make([]*domain.BankStatementEntry, 0)
+	for rows.Next() {
+		entry, err := scanBankStatementEntry(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan bank statement entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating bank statement entries: %w", err)
+	}
+
+	return entries, nil
+}
+
+// rowScanner *sql.Row と *sql.Rows の共通インターフェース
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanBankStatementEntry 1行分の入出金明細をスキャン
+func scanBankStatementEntry(row rowScanner) (*domain.BankStatementEntry, error) {
+	var entry domain.BankStatementEntry
+	var sourceFormat, status string
+	var referenceNo, payerName, payerCode, remitterBankName, description sql.NullString
+	var matchedOrderID, transactionID, reconciledBy sql.NullString
+	var reconciledAt sql.NullTime
+
+	err := row.Scan(
+		&entry.ID,
+		&entry.TenantID,
+		&entry.ImportID,
+		&sourceFormat,
+		&referenceNo,
+		&entry.TransactionDate,
+		&entry.Amount,
+		&payerName,
+		&payerCode,
+		&remitterBankName,
+		&description,
+		&status,
+		&matchedOrderID,
+		&transactionID,
+		&reconciledBy,
+		&reconciledAt,
+		&entry.CreatedAt,
+		&entry.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	entry.SourceFormat = domain.BankStatementFormat(sourceFormat)
+	entry.Status = domain.BankStatementEntryStatus(status)
+	entry.ReferenceNo = referenceNo.String
+	entry.PayerName = payerName.String
+	entry.PayerCode = payerCode.String
+	entry.RemitterBankName = remitterBankName.String
+	entry.Description = description.String
+	entry.MatchedOrderID = matchedOrderID.String
+	entry.TransactionID = transactionID.String
+	entry.ReconciledBy = reconciledBy.String
+	if reconciledAt.Valid {
+		entry.ReconciledAt = &reconciledAt.Time
+	}
+
+	return &entry, nil
+}
+
+// nullIfEmpty 空文字列をNULLとして扱う
+func nullIfEmpty(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
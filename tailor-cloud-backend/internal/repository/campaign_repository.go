@@ -0,0 +1,359 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	_ "github.com/lib/pq"
+	"tailor-cloud/backend/internal/config/domain"
+)
+
+// CampaignRepository キャンペーン・クーポンリポジトリインターフェース
+type CampaignRepository interface {
+	Create(ctx context.Context, campaign *domain.Campaign) error
+	GetByID(ctx context.Context, campaignID string, tenantID string) (*domain.Campaign, error)
+	GetByTenantID(ctx context.Context, tenantID string) ([]*domain.Campaign, error)
+	GetActiveByTenantID(ctx context.Context, tenantID string) ([]*domain.Campaign, error)
+	Update(ctx context.Context, campaign *domain.Campaign) error
+	CountRedemptions(ctx context.Context, campaignID string, customerID string) (int, error)
+	CreateOrderDiscount(ctx context.Context, discount *domain.OrderDiscount) error
+	GetOrderDiscounts(ctx context.Context, orderID string, tenantID string) ([]*domain.OrderDiscount, error)
+}
+
+// PostgreSQLCampaignRepository PostgreSQLを使ったキャンペーン・クーポンリポジトリ実装
+type PostgreSQLCampaignRepository struct {
+	db *sql.DB
+}
+
+// NewPostgreSQLCampaignRepository PostgreSQLCampaignRepositoryのコンストラクタ
+func NewPostgreSQLCampaignRepository(db *sql.DB) CampaignRepository {
+	return &PostgreSQLCampaignRepository{
+		db: db,
+	}
+}
+
+const campaignColumns = `
+	id, tenant_id, name, code, discount_type, value,
+	eligible_archetypes, eligible_plan_types, eligible_fabric_ids,
+	starts_at, ends_at, per_customer_limit, stackable, active, created_at, updated_at
+`
+
+// Create キャンペーンを作成
+func (r *PostgreSQLCampaignRepository) Create(ctx context.Context, campaign *domain.Campaign) error {
+	query := `
+		INSERT INTO campaigns (` + campaignColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+	`
+
+	archetypesJSON, planTypesJSON, fabricIDsJSON, err := marshalCampaignEligibility(campaign)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, query,
+		campaign.ID,
+		campaign.TenantID,
+		campaign.Name,
+		nullIfEmpty(campaign.Code),
+		string(campaign.DiscountType),
+		campaign.Value,
+		archetypesJSON,
+		planTypesJSON,
+		fabricIDsJSON,
+		campaign.StartsAt,
+		campaign.EndsAt,
+		campaign.PerCustomerLimit,
+		campaign.Stackable,
+		campaign.Active,
+		campaign.CreatedAt,
+		campaign.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create campaign: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID キャンペーンを取得
+func (r *PostgreSQLCampaignRepository) GetByID(ctx context.Context, campaignID string, tenantID string) (*domain.Campaign, error) {
+	query := `SELECT ` + campaignColumns + ` FROM campaigns WHERE id = $1 AND tenant_id = $2`
+
+	campaign, err := scanCampaign(r.db.QueryRowContext(ctx, query, campaignID, tenantID))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("campaign not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get campaign: %w", err)
+	}
+
+	return campaign, nil
+}
+
+// GetByTenantID テナントのキャンペーン一覧を取得（無効なキャンペーンも含む）
+func (r *PostgreSQLCampaignRepository) GetByTenantID(ctx context.Context, tenantID string) ([]*domain.Campaign, error) {
+	query := `SELECT ` + campaignColumns + ` FROM campaigns WHERE tenant_id = $1 ORDER BY starts_at DESC, created_at DESC`
+	return r.queryCampaigns(ctx, query, tenantID)
+}
+
+// GetActiveByTenantID テナントの有効なキャンペーンを取得（有効期間の判定はサービス層で行う）
+func (r *PostgreSQLCampaignRepository) GetActiveByTenantID(ctx context.Context, tenantID string) ([]*domain.Campaign, error) {
+	query := `SELECT ` + campaignColumns + ` FROM campaigns WHERE tenant_id = $1 AND active = TRUE ORDER BY starts_at ASC, created_at ASC`
+	return r.queryCampaigns(ctx, query, tenantID)
+}
+
+// Update キャンペーンを更新
+func (r *PostgreSQLCampaignRepository) Update(ctx context.Context, campaign *domain.Campaign) error {
+	query := `
+		UPDATE campaigns SET
+			name = $3,
+			code = $4,
+			discount_type = $5,
+			value = $6,
+			eligible_archetypes = $7,
+			eligible_plan_types = $8,
+			eligible_fabric_ids = $9,
+			starts_at = $10,
+			ends_at = $11,
+			per_customer_limit = $12,
+			stackable = $13,
+			active = $14,
+			updated_at = $15
+		WHERE id = $1 AND tenant_id = $2
+	`
+
+	archetypesJSON, planTypesJSON, fabricIDsJSON, err := marshalCampaignEligibility(campaign)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx, query,
+		campaign.ID,
+		campaign.TenantID,
+		campaign.Name,
+		nullIfEmpty(campaign.Code),
+		string(campaign.DiscountType),
+		campaign.Value,
+		archetypesJSON,
+		planTypesJSON,
+		fabricIDsJSON,
+		campaign.StartsAt,
+		campaign.EndsAt,
+		campaign.PerCustomerLimit,
+		campaign.Stackable,
+		campaign.Active,
+		campaign.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update campaign: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("campaign not found")
+	}
+
+	return nil
+}
+
+// CountRedemptions 顧客がキャンペーンを利用した回数（割引を適用した注文数）を取得
+// キャンセル済みの注文、および注文が存在しない割引明細は利用回数に含めない
+func (r *PostgreSQLCampaignRepository) CountRedemptions(ctx context.Context, campaignID string, customerID string) (int, error) {
+	query := `
+		SELECT COUNT(DISTINCT d.order_id)
+		FROM order_discounts d
+		JOIN orders o ON o.id = d.order_id
+		WHERE d.campaign_id = $1 AND d.customer_id = $2
+		  AND o.status <> 'Cancelled'
+	`
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, campaignID, customerID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count campaign redemptions: %w", err)
+	}
+
+	return count, nil
+}
+
+// CreateOrderDiscount 注文の割引明細を作成
+func (r *PostgreSQLCampaignRepository) CreateOrderDiscount(ctx context.Context, discount *domain.OrderDiscount) error {
+	query := `
+		INSERT INTO order_discounts (
+			id, tenant_id, order_id, customer_id, campaign_id, code, description, amount, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		discount.ID,
+		discount.TenantID,
+		discount.OrderID,
+		discount.CustomerID,
+		discount.CampaignID,
+		nullIfEmpty(discount.Code),
+		discount.Description,
+		discount.Amount,
+		discount.CreatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create order discount: %w", err)
+	}
+
+	return nil
+}
+
+// GetOrderDiscounts 注文の割引明細を取得
+func (r *PostgreSQLCampaignRepository) GetOrderDiscounts(ctx context.Context, orderID string, tenantID string) ([]*domain.OrderDiscount, error) {
+	query := `
+		SELECT id, tenant_id, order_id, customer_id, campaign_id, code, description, amount, created_at
+		FROM order_discounts
+		WHERE order_id = $1 AND tenant_id = $2
+		ORDER BY created_at ASC, id ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, orderID, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query order discounts: %w", err)
+	}
+	defer rows.Close()
+
+	discounts := make([]*domain.OrderDiscount, 0)
+	for rows.Next() {
+		var discount domain.OrderDiscount
+		var code sql.NullString
+
+		err := rows.Scan(
+			&discount.ID,
+			&discount.TenantID,
+			&discount.OrderID,
+			&discount.CustomerID,
+			&discount.CampaignID,
+			&code,
+			&discount.Description,
+			&discount.Amount,
+			&discount.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan order discount: %w", err)
+		}
+
+		discount.Code = code.String
+		discounts = append(discounts, &discount)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating order discounts: %w", err)
+	}
+
+	return discounts, nil
+}
+
+// queryCampaigns キャンペーン一覧を取得する共通処理
+func (r *PostgreSQLCampaignRepository) queryCampaigns(ctx context.Context, query string, args ...interface{}) ([]*domain.Campaign, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query campaigns: %w", err)
+	}
+	defer rows.Close()
+
+	campaigns := make([]*domain.Campaign, 0)
+	for rows.Next() {
+		campaign, err := scanCampaign(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan campaign: %w", err)
+		}
+		campaigns = append(campaigns, campaign)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating campaigns: %w", err)
+	}
+
+	return campaigns, nil
+}
+
+// scanCampaign 1行分のキャンペーンをスキャン
+func scanCampaign(row rowScanner) (*domain.Campaign, error) {
+	var campaign domain.Campaign
+	var code sql.NullString
+	var discountType string
+	var archetypesJSON, planTypesJSON, fabricIDsJSON []byte
+	var endsAt sql.NullTime
+
+	err := row.Scan(
+		&campaign.ID,
+		&campaign.TenantID,
+		&campaign.Name,
+		&code,
+		&discountType,
+		&campaign.Value,
+		&archetypesJSON,
+		&planTypesJSON,
+		&fabricIDsJSON,
+		&campaign.StartsAt,
+		&endsAt,
+		&campaign.PerCustomerLimit,
+		&campaign.Stackable,
+		&campaign.Active,
+		&campaign.CreatedAt,
+		&campaign.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	campaign.Code = code.String
+	campaign.DiscountType = domain.DiscountType(discountType)
+	if endsAt.Valid {
+		campaign.EndsAt = &endsAt.Time
+	}
+
+	if err := json.Unmarshal(archetypesJSON, &campaign.EligibleArchetypes); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal eligible archetypes: %w", err)
+	}
+	if err := json.Unmarshal(planTypesJSON, &campaign.EligiblePlanTypes); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal eligible plan types: %w", err)
+	}
+	if err := json.Unmarshal(fabricIDsJSON, &campaign.EligibleFabricIDs); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal eligible fabric ids: %w", err)
+	}
+
+	return &campaign, nil
+}
+
+// marshalCampaignEligibility 対象条件をJSONに変換（nilは空配列として保存）
+func marshalCampaignEligibility(campaign *domain.Campaign) ([]byte, []byte, []byte, error) {
+	archetypes := campaign.EligibleArchetypes
+	if archetypes == nil {
+		archetypes = make([]domain.Archetype, 0)
+	}
+	planTypes := campaign.EligiblePlanTypes
+	if planTypes == nil {
+		planTypes = make([]domain.PlanType, 0)
+	}
+	fabricIDs := campaign.EligibleFabricIDs
+	if fabricIDs == nil {
+		fabricIDs = make([]string, 0)
+	}
+
+	archetypesJSON, err := json.Marshal(archetypes)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to marshal eligible archetypes: %w", err)
+	}
+	planTypesJSON, err := json.Marshal(planTypes)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to marshal eligible plan types: %w", err)
+	}
+	fabricIDsJSON, err := json.Marshal(fabricIDs)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to marshal eligible fabric ids: %w", err)
+	}
+
+	return archetypesJSON, planTypesJSON, fabricIDsJSON, nil
+}
@@ -19,6 +19,7 @@ type CustomerRepository interface {
 	Search(ctx context.Context, tenantID string, keyword string) ([]*domain.Customer, error)
 	Update(ctx context.Context, customer *domain.Customer) error
 	Delete(ctx context.Context, customerID string, tenantID string) error
+	GetPreferredArchetype(ctx context.Context, customerID string, tenantID string) (domain.Archetype, error)
 }
 
 // PostgreSQLCustomerRepository PostgreSQLを使った顧客リポジトリ実装
@@ -49,8 +50,8 @@ func (r *PostgreSQLCustomerRepository) Create(ctx context.Context, customer *dom
 	
 	query := `
 		INSERT INTO customers (
-			id, tenant_id, name, email, phone, created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+			id, tenant_id, name, email, phone, measurement_unit, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 	`
 	
 	_, err := r.db.ExecContext(ctx, query,
@@ -59,6 +60,7 @@ func (r *PostgreSQLCustomerRepository) Create(ctx context.Context, customer *dom
 		customer.Name,
 		customer.Email,
 		customer.Phone,
+		nullableMeasurementUnit(customer.MeasurementUnit),
 		customer.CreatedAt,
 		customer.UpdatedAt,
 	)
@@ -74,13 +76,13 @@ func (r *PostgreSQLCustomerRepository) Create(ctx context.Context, customer *dom
 func (r *PostgreSQLCustomerRepository) GetByID(ctx context.Context, customerID string, tenantID string) (*domain.Customer, error) {
 	query := `
 		SELECT 
-			id, tenant_id, name, email, phone, created_at, updated_at
+			id, tenant_id, name, email, phone, measurement_unit, created_at, updated_at
 		FROM customers
 		WHERE id = $1 AND tenant_id = $2
 	`
 	
 	var customer domain.Customer
-	var email, phone sql.NullString
+	var email, phone, measurementUnit sql.NullString
 	
 	err := r.db.QueryRowContext(ctx, query, customerID, tenantID).Scan(
 		&customer.ID,
@@ -88,6 +90,7 @@ func (r *PostgreSQLCustomerRepository) GetByID(ctx context.Context, customerID s
 		&customer.Name,
 		&email,
 		&phone,
+		&measurementUnit,
 		&customer.CreatedAt,
 		&customer.UpdatedAt,
 	)
@@ -105,6 +108,7 @@ func (r *PostgreSQLCustomerRepository) GetByID(ctx context.Context, customerID s
 	if phone.Valid {
 		customer.Phone = phone.String
 	}
+	customer.MeasurementUnit = domain.MeasurementUnit(measurementUnit.String)
 	
 	return &customer, nil
 }
@@ -113,7 +117,7 @@ func (r *PostgreSQLCustomerRepository) GetByID(ctx context.Context, customerID s
 func (r *PostgreSQLCustomerRepository) GetByTenantID(ctx context.Context, tenantID string) ([]*domain.Customer, error) {
 	query := `
 		SELECT 
-			id, tenant_id, name, email, phone, created_at, updated_at
+			id, tenant_id, name, email, phone, measurement_unit, created_at, updated_at
 		FROM customers
 		WHERE tenant_id = $1
 		ORDER BY created_at DESC
@@ -128,7 +132,7 @@ func (r *PostgreSQLCustomerRepository) GetByTenantID(ctx context.Context, tenant
 	var customers []*domain.Customer
 	for rows.Next() {
 		var customer domain.Customer
-		var email, phone sql.NullString
+		var email, phone, measurementUnit sql.NullString
 		
 		err := rows.Scan(
 			&customer.ID,
@@ -136,6 +140,7 @@ func (r *PostgreSQLCustomerRepository) GetByTenantID(ctx context.Context, tenant
 			&customer.Name,
 			&email,
 			&phone,
+			&measurementUnit,
 			&customer.CreatedAt,
 			&customer.UpdatedAt,
 		)
@@ -149,6 +154,7 @@ func (r *PostgreSQLCustomerRepository) GetByTenantID(ctx context.Context, tenant
 		if phone.Valid {
 			customer.Phone = phone.String
 		}
+		customer.MeasurementUnit = domain.MeasurementUnit(measurementUnit.String)
 		
 		customers = append(customers, &customer)
 	}
@@ -164,7 +170,7 @@ func (r *PostgreSQLCustomerRepository) GetByTenantID(ctx context.Context, tenant
 func (r *PostgreSQLCustomerRepository) Search(ctx context.Context, tenantID string, keyword string) ([]*domain.Customer, error) {
 	query := `
 		SELECT 
-			id, tenant_id, name, email, phone, created_at, updated_at
+			id, tenant_id, name, email, phone, measurement_unit, created_at, updated_at
 		FROM customers
 		WHERE tenant_id = $1
 		  AND (
@@ -185,7 +191,7 @@ func (r *PostgreSQLCustomerRepository) Search(ctx context.Context, tenantID stri
 	var customers []*domain.Customer
 	for rows.Next() {
 		var customer domain.Customer
-		var email, phone sql.NullString
+		var email, phone, measurementUnit sql.NullString
 		
 		err := rows.Scan(
 			&customer.ID,
@@ -193,6 +199,7 @@ func (r *PostgreSQLCustomerRepository) Search(ctx context.Context, tenantID stri
 			&customer.Name,
 			&email,
 			&phone,
+			&measurementUnit,
 			&customer.CreatedAt,
 			&customer.UpdatedAt,
 		)
@@ -206,6 +213,7 @@ func (r *PostgreSQLCustomerRepository) Search(ctx context.Context, tenantID stri
 		if phone.Valid {
 			customer.Phone = phone.String
 		}
+		customer.MeasurementUnit = domain.MeasurementUnit(measurementUnit.String)
 		
 		customers = append(customers, &customer)
 	}
@@ -223,7 +231,7 @@ func (r *PostgreSQLCustomerRepository) Update(ctx context.Context, customer *dom
 	
 	query := `
 		UPDATE customers
-		SET name = $3, email = $4, phone = $5, updated_at = $6
+		SET name = $3, email = $4, phone = $5, measurement_unit = $6, updated_at = $7
 		WHERE id = $1 AND tenant_id = $2
 	`
 	
@@ -233,6 +241,7 @@ func (r *PostgreSQLCustomerRepository) Update(ctx context.Context, customer *dom
 		customer.Name,
 		customer.Email,
 		customer.Phone,
+		nullableMeasurementUnit(customer.MeasurementUnit),
 		customer.UpdatedAt,
 	)
 	
@@ -276,3 +285,28 @@ func (r *PostgreSQLCustomerRepository) Delete(ctx context.Context, customerID st
 	return nil
 }
 
+// GetPreferredArchetype 顧客の好みのアーキタイプ（Suit-MBTI診断結果）を取得
+// 未診断の場合は空文字を返す
+func (r *PostgreSQLCustomerRepository) GetPreferredArchetype(ctx context.Context, customerID string, tenantID string) (domain.Archetype, error) {
+	query := `
+		SELECT preferred_archetype
+		FROM customers
+		WHERE id = $1 AND tenant_id = $2
+	`
+
+	var archetype sql.NullString
+	err := r.db.QueryRowContext(ctx, query, customerID, tenantID).Scan(&archetype)
+	if err == sql.ErrNoRows {
+		return "", fmt.Errorf("customer not found")
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get preferred archetype: %w", err)
+	}
+
+	return domain.Archetype(archetype.String), nil
+}
+
+// nullableMeasurementUnit 顧客の採寸値の表示単位（未設定はNULL = テナントの設定に従う）
+func nullableMeasurementUnit(unit domain.MeasurementUnit) sql.NullString {
+	return sql.NullString{String: string(unit), Valid: unit != ""}
+}
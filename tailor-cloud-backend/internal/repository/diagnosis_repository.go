@@ -18,6 +18,7 @@ type DiagnosisRepository interface {
 	GetByUserID(ctx context.Context, userID string, tenantID string) ([]*domain.Diagnosis, error)
 	GetByTenantID(ctx context.Context, tenantID string, limit, offset int) ([]*domain.Diagnosis, error)
 	List(ctx context.Context, tenantID string, filter DiagnosisFilter) ([]*domain.Diagnosis, error)
+	Update(ctx context.Context, diagnosis *domain.Diagnosis) error
 }
 
 // DiagnosisFilter 診断フィルター
@@ -76,6 +77,39 @@ func (r *PostgreSQLDiagnosisRepository) Create(ctx context.Context, diagnosis *d
 	return nil
 }
 
+// Update 診断結果（アーキタイプ・プラン・診断結果詳細）を更新（再採点用）
+func (r *PostgreSQLDiagnosisRepository) Update(ctx context.Context, diagnosis *domain.Diagnosis) error {
+	diagnosis.UpdatedAt = time.Now()
+
+	query := `
+		UPDATE diagnoses
+		SET archetype = $3, plan_type = $4, diagnosis_result = $5, updated_at = $6
+		WHERE id = $1 AND tenant_id = $2
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		diagnosis.ID,
+		diagnosis.TenantID,
+		string(diagnosis.Archetype),
+		string(diagnosis.PlanType),
+		diagnosis.DiagnosisResult,
+		diagnosis.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update diagnosis: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("diagnosis not found")
+	}
+
+	return nil
+}
+
 // GetByID 診断IDで取得（テナントIDもチェック）
 func (r *PostgreSQLDiagnosisRepository) GetByID(ctx context.Context, diagnosisID string, tenantID string) (*domain.Diagnosis, error) {
 	query := `
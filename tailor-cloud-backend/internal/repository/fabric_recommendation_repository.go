@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	_ "github.com/lib/pq"
+	"tailor-cloud/backend/internal/config/domain"
+)
+
+// FabricRecommendationRepository 生地レコメンドの重みリポジトリインターフェース
+type FabricRecommendationRepository interface {
+	GetWeights(ctx context.Context, tenantID string) (*domain.FabricRecommendationWeights, error)
+	UpsertWeights(ctx context.Context, weights *domain.FabricRecommendationWeights) error
+}
+
+// PostgreSQLFabricRecommendationRepository PostgreSQLを使った生地レコメンドの重みリポジトリ実装
+type PostgreSQLFabricRecommendationRepository struct {
+	db *sql.DB
+}
+
+// NewPostgreSQLFabricRecommendationRepository PostgreSQLFabricRecommendationRepositoryのコンストラクタ
+func NewPostgreSQLFabricRecommendationRepository(db *sql.DB) FabricRecommendationRepository {
+	return &PostgreSQLFabricRecommendationRepository{
+		db: db,
+	}
+}
+
+// GetWeights テナントの生地レコメンドの重みを取得
+func (r *PostgreSQLFabricRecommendationRepository) GetWeights(ctx context.Context, tenantID string) (*domain.FabricRecommendationWeights, error) {
+	query := `
+		SELECT tenant_id, archetypes, plan_weight, season_weight, updated_at, updated_by
+		FROM fabric_recommendation_weights
+		WHERE tenant_id = $1
+	`
+
+	var weights domain.FabricRecommendationWeights
+	var archetypesJSON []byte
+
+	err := r.db.QueryRowContext(ctx, query, tenantID).Scan(
+		&weights.TenantID,
+		&archetypesJSON,
+		&weights.PlanWeight,
+		&weights.SeasonWeight,
+		&weights.UpdatedAt,
+		&weights.UpdatedBy,
+	)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("fabric recommendation weights not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fabric recommendation weights: %w", err)
+	}
+
+	weights.Archetypes = make(map[domain.Archetype]map[string]float64)
+	if len(archetypesJSON) > 0 {
+		if err := json.Unmarshal(archetypesJSON, &weights.Archetypes); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal archetype weights: %w", err)
+		}
+	}
+
+	return &weights, nil
+}
+
+// UpsertWeights テナントの生地レコメンドの重みを登録・更新
+func (r *PostgreSQLFabricRecommendationRepository) UpsertWeights(ctx context.Context, weights *domain.FabricRecommendationWeights) error {
+	query := `
+		INSERT INTO fabric_recommendation_weights (
+			tenant_id, archetypes, plan_weight, season_weight, updated_at, updated_by
+		) VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (tenant_id) DO UPDATE SET
+			archetypes = EXCLUDED.archetypes,
+			plan_weight = EXCLUDED.plan_weight,
+			season_weight = EXCLUDED.season_weight,
+			updated_at = EXCLUDED.updated_at,
+			updated_by = EXCLUDED.updated_by
+	`
+
+	archetypes := weights.Archetypes
+	if archetypes == nil {
+		archetypes = make(map[domain.Archetype]map[string]float64)
+	}
+	archetypesJSON, err := json.Marshal(archetypes)
+	if err != nil {
+		return fmt.Errorf("failed to marshal archetype weights: %w", err)
+	}
+
+	_, err = r.db.ExecContext(ctx, query,
+		weights.TenantID,
+		archetypesJSON,
+		weights.PlanWeight,
+		weights.SeasonWeight,
+		weights.UpdatedAt,
+		weights.UpdatedBy,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to upsert fabric recommendation weights: %w", err)
+	}
+
+	return nil
+}
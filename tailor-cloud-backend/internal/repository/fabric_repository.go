@@ -16,6 +16,8 @@ type FabricRepository interface {
 	GetAll(ctx context.Context, tenantID string, filters *FabricFilters) ([]*domain.Fabric, error)
 	Search(ctx context.Context, tenantID string, keyword string) ([]*domain.Fabric, error)
 	UpdateStock(ctx context.Context, fabricID string, stockAmount float64) error
+	GetAttributes(ctx context.Context, tenantID string, fabricID string) (*domain.FabricAttributes, error)
+	UpsertAttributes(ctx context.Context, tenantID string, fabricID string, attributes domain.FabricAttributes, updatedBy string) error
 }
 
 // FabricFilters 生地フィルター
@@ -40,22 +42,31 @@ func NewPostgreSQLFabricRepository(db *sql.DB) FabricRepository {
 func (r *PostgreSQLFabricRepository) GetByID(ctx context.Context, fabricID string) (*domain.Fabric, error) {
 	query := `
 		SELECT 
-			id, supplier_id, name, stock_amount, price,
+			id, supplier_id, name, stock_amount, price, price_unit,
 			image_url, minimum_order,
+			color_family, pattern, weight, season, formality,
 			created_at, updated_at
 		FROM fabrics
 		WHERE id = $1
 	`
 	
 	var fabric domain.Fabric
+	var priceUnit sql.NullString
+	var attrs fabricAttributeColumns
 	err := r.db.QueryRowContext(ctx, query, fabricID).Scan(
 		&fabric.ID,
 		&fabric.SupplierID,
 		&fabric.Name,
 		&fabric.StockAmount,
 		&fabric.Price,
+		&priceUnit,
 		&fabric.ImageURL,
 		&fabric.MinimumOrder,
+		&attrs.colorFamily,
+		&attrs.pattern,
+		&attrs.weight,
+		&attrs.season,
+		&attrs.formality,
 		&fabric.CreatedAt,
 		&fabric.UpdatedAt,
 	)
@@ -66,6 +77,10 @@ func (r *PostgreSQLFabricRepository) GetByID(ctx context.Context, fabricID strin
 	if err != nil {
 		return nil, fmt.Errorf("failed to get fabric: %w", err)
 	}
+	fabric.Attributes = attrs.toDomain()
+	
+	// 単価を円/メートルに正規化（輸入生地はヤード単価で登録されている）
+	fabric.NormalizePrice(domain.FabricUnit(priceUnit.String))
 	
 	// 在庫ステータスを計算
 	fabric.CalculateStockStatus()
@@ -76,17 +91,24 @@ func (r *PostgreSQLFabricRepository) GetByID(ctx context.Context, fabricID strin
 // GetAll 生地一覧を取得（フィルター対応）
 func (r *PostgreSQLFabricRepository) GetAll(ctx context.Context, tenantID string, filters *FabricFilters) ([]*domain.Fabric, error) {
 	// クエリ構築
+	// 生地の属性はテナント別の設定を優先し、未設定の生地はカタログの既定値を使う
 	query := `
 		SELECT 
-			id, supplier_id, name, stock_amount, price,
-			image_url, minimum_order,
-			created_at, updated_at
-		FROM fabrics
+			f.id, f.supplier_id, f.name, f.stock_amount, f.price, f.price_unit,
+			f.image_url, f.minimum_order,
+			CASE WHEN ta.fabric_id IS NULL THEN f.color_family ELSE ta.color_family END,
+			CASE WHEN ta.fabric_id IS NULL THEN f.pattern ELSE ta.pattern END,
+			CASE WHEN ta.fabric_id IS NULL THEN f.weight ELSE ta.weight END,
+			CASE WHEN ta.fabric_id IS NULL THEN f.season ELSE ta.season END,
+			CASE WHEN ta.fabric_id IS NULL THEN f.formality ELSE ta.formality END,
+			f.created_at, f.updated_at
+		FROM fabrics f
+		LEFT JOIN tenant_fabric_attributes ta ON ta.fabric_id = f.id AND ta.tenant_id = $1
 		WHERE 1=1
 	`
 	
-	args := []interface{}{}
-	argIndex := 1
+	args := []interface{}{tenantID}
+	argIndex := 2
 	
 	// テナントIDフィルター（将来のマルチテナント対応）
 	// 現時点では、fabricsテーブルにtenant_idが無いため、全件取得
@@ -94,7 +116,7 @@ func (r *PostgreSQLFabricRepository) GetAll(ctx context.Context, tenantID string
 	
 	// 検索キーワードフィルター
 	if filters != nil && filters.Search != "" {
-		query += fmt.Sprintf(" AND name ILIKE $%d", argIndex)
+		query += fmt.Sprintf(" AND f.name ILIKE $%d", argIndex)
 		args = append(args, "%"+filters.Search+"%")
 		argIndex++
 	}
@@ -107,11 +129,11 @@ func (r *PostgreSQLFabricRepository) GetAll(ctx context.Context, tenantID string
 		for _, status := range filters.Status {
 			switch status {
 			case domain.StockStatusAvailable:
-				statusConditions = append(statusConditions, fmt.Sprintf("stock_amount > 3.2"))
+				statusConditions = append(statusConditions, fmt.Sprintf("f.stock_amount > 3.2"))
 			case domain.StockStatusLimited:
-				statusConditions = append(statusConditions, fmt.Sprintf("stock_amount > 0 AND stock_amount <= 3.2"))
+				statusConditions = append(statusConditions, fmt.Sprintf("f.stock_amount > 0 AND f.stock_amount <= 3.2"))
 			case domain.StockStatusSoldOut:
-				statusConditions = append(statusConditions, "stock_amount = 0")
+				statusConditions = append(statusConditions, "f.stock_amount = 0")
 			}
 		}
 		if len(statusConditions) > 0 {
@@ -119,7 +141,7 @@ func (r *PostgreSQLFabricRepository) GetAll(ctx context.Context, tenantID string
 		}
 	}
 	
-	query += " ORDER BY name ASC"
+	query += " ORDER BY f.name ASC"
 	
 	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
@@ -131,6 +153,8 @@ func (r *PostgreSQLFabricRepository) GetAll(ctx context.Context, tenantID string
 	
 	for rows.Next() {
 		var fabric domain.Fabric
+		var priceUnit sql.NullString
+		var attrs fabricAttributeColumns
 		
 		err := rows.Scan(
 			&fabric.ID,
@@ -138,8 +162,14 @@ func (r *PostgreSQLFabricRepository) GetAll(ctx context.Context, tenantID string
 			&fabric.Name,
 			&fabric.StockAmount,
 			&fabric.Price,
+			&priceUnit,
 			&fabric.ImageURL,
 			&fabric.MinimumOrder,
+			&attrs.colorFamily,
+			&attrs.pattern,
+			&attrs.weight,
+			&attrs.season,
+			&attrs.formality,
 			&fabric.CreatedAt,
 			&fabric.UpdatedAt,
 		)
@@ -147,6 +177,10 @@ func (r *PostgreSQLFabricRepository) GetAll(ctx context.Context, tenantID string
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan fabric: %w", err)
 		}
+		fabric.Attributes = attrs.toDomain()
+		
+		// 単価を円/メートルに正規化
+		fabric.NormalizePrice(domain.FabricUnit(priceUnit.String))
 		
 		// 在庫ステータスを計算
 		fabric.CalculateStockStatus()
@@ -200,3 +234,93 @@ func (r *PostgreSQLFabricRepository) UpdateStock(ctx context.Context, fabricID s
 	return nil
 }
 
+// GetAttributes テナント別の生地の属性を取得（未設定の場合はnil）
+func (r *PostgreSQLFabricRepository) GetAttributes(ctx context.Context, tenantID string, fabricID string) (*domain.FabricAttributes, error) {
+	query := `
+		SELECT color_family, pattern, weight, season, formality
+		FROM tenant_fabric_attributes
+		WHERE tenant_id = $1 AND fabric_id = $2
+	`
+
+	var attrs fabricAttributeColumns
+	err := r.db.QueryRowContext(ctx, query, tenantID, fabricID).Scan(
+		&attrs.colorFamily,
+		&attrs.pattern,
+		&attrs.weight,
+		&attrs.season,
+		&attrs.formality,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fabric attributes: %w", err)
+	}
+
+	attributes := attrs.toDomain()
+	return &attributes, nil
+}
+
+// UpsertAttributes テナント別の生地の属性（色系統・柄・目付・シーズン・フォーマル度）を登録・更新
+func (r *PostgreSQLFabricRepository) UpsertAttributes(ctx context.Context, tenantID string, fabricID string, attributes domain.FabricAttributes, updatedBy string) error {
+	query := `
+		INSERT INTO tenant_fabric_attributes (
+			tenant_id, fabric_id, color_family, pattern, weight, season, formality, updated_at, updated_by
+		)
+		SELECT $1, id, $3, $4, $5, $6, $7, CURRENT_TIMESTAMP, $8
+		FROM fabrics
+		WHERE id = $2
+		ON CONFLICT (tenant_id, fabric_id) DO UPDATE SET
+			color_family = EXCLUDED.color_family,
+			pattern = EXCLUDED.pattern,
+			weight = EXCLUDED.weight,
+			season = EXCLUDED.season,
+			formality = EXCLUDED.formality,
+			updated_at = EXCLUDED.updated_at,
+			updated_by = EXCLUDED.updated_by
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		tenantID,
+		fabricID,
+		sql.NullString{String: string(attributes.ColorFamily), Valid: attributes.ColorFamily != ""},
+		sql.NullString{String: string(attributes.Pattern), Valid: attributes.Pattern != ""},
+		sql.NullInt64{Int64: int64(attributes.Weight), Valid: attributes.Weight > 0},
+		sql.NullString{String: string(attributes.Season), Valid: attributes.Season != ""},
+		sql.NullInt64{Int64: int64(attributes.Formality), Valid: attributes.Formality > 0},
+		updatedBy,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to upsert fabric attributes: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("fabric not found: %s", fabricID)
+	}
+
+	return nil
+}
+
+// fabricAttributeColumns 生地の属性のカラム（未設定はNULL）
+type fabricAttributeColumns struct {
+	colorFamily sql.NullString
+	pattern     sql.NullString
+	weight      sql.NullInt64
+	season      sql.NullString
+	formality   sql.NullInt64
+}
+
+// toDomain 生地の属性に変換
+func (c fabricAttributeColumns) toDomain() domain.FabricAttributes {
+	return domain.FabricAttributes{
+		ColorFamily: domain.FabricColorFamily(c.colorFamily.String),
+		Pattern:     domain.FabricPattern(c.pattern.String),
+		Weight:      int(c.weight.Int64),
+		Season:      domain.FabricSeason(c.season.String),
+		Formality:   int(c.formality.Int64),
+	}
+}
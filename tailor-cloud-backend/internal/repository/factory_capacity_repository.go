@@ -0,0 +1,225 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	_ "github.com/lib/pq"
+	"tailor-cloud/backend/internal/config/domain"
+)
+
+// FactoryCapacityRepository 工場の生産能力リポジトリインターフェース
+type FactoryCapacityRepository interface {
+	Get(ctx context.Context, tenantID string) (*domain.FactoryCapacity, error)
+	Upsert(ctx context.Context, capacity *domain.FactoryCapacity) error
+	ListOverrides(ctx context.Context, tenantID string, from, to time.Time) ([]*domain.CapacityOverride, error)
+	UpsertOverride(ctx context.Context, override *domain.CapacityOverride) error
+	DeleteOverride(ctx context.Context, overrideID string, tenantID string) error
+}
+
+// PostgreSQLFactoryCapacityRepository PostgreSQLを使った工場の生産能力リポジトリ実装
+type PostgreSQLFactoryCapacityRepository struct {
+	db *sql.DB
+}
+
+// NewPostgreSQLFactoryCapacityRepository PostgreSQLFactoryCapacityRepositoryのコンストラクタ
+func NewPostgreSQLFactoryCapacityRepository(db *sql.DB) FactoryCapacityRepository {
+	return &PostgreSQLFactoryCapacityRepository{
+		db: db,
+	}
+}
+
+const factoryCapacityColumns = `
+	tenant_id, headcount, hours_per_day, working_weekdays, shipping_days,
+	standard_times, updated_at, updated_by
+`
+
+const capacityOverrideColumns = `
+	id, tenant_id, date, type, headcount, hours_per_day, note, created_at, created_by
+`
+
+// Get テナントの生産能力を取得
+func (r *PostgreSQLFactoryCapacityRepository) Get(ctx context.Context, tenantID string) (*domain.FactoryCapacity, error) {
+	query := `SELECT ` + factoryCapacityColumns + ` FROM factory_capacities WHERE tenant_id = $1`
+
+	var capacity domain.FactoryCapacity
+	var weekdaysJSON, standardTimesJSON []byte
+
+	err := r.db.QueryRowContext(ctx, query, tenantID).Scan(
+		&capacity.TenantID,
+		&capacity.Headcount,
+		&capacity.HoursPerDay,
+		&weekdaysJSON,
+		&capacity.ShippingDays,
+		&standardTimesJSON,
+		&capacity.UpdatedAt,
+		&capacity.UpdatedBy,
+	)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("factory capacity not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get factory capacity: %w", err)
+	}
+
+	if err := json.Unmarshal(weekdaysJSON, &capacity.WorkingWeekdays); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal working weekdays: %w", err)
+	}
+	capacity.StandardTimes = make([]*domain.StandardTime, 0)
+	if len(standardTimesJSON) > 0 {
+		if err := json.Unmarshal(standardTimesJSON, &capacity.StandardTimes); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal standard times: %w", err)
+		}
+	}
+
+	return &capacity, nil
+}
+
+// Upsert テナントの生産能力を登録・更新
+func (r *PostgreSQLFactoryCapacityRepository) Upsert(ctx context.Context, capacity *domain.FactoryCapacity) error {
+	query := `
+		INSERT INTO factory_capacities (` + factoryCapacityColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (tenant_id) DO UPDATE SET
+			headcount = EXCLUDED.headcount,
+			hours_per_day = EXCLUDED.hours_per_day,
+			working_weekdays = EXCLUDED.working_weekdays,
+			shipping_days = EXCLUDED.shipping_days,
+			standard_times = EXCLUDED.standard_times,
+			updated_at = EXCLUDED.updated_at,
+			updated_by = EXCLUDED.updated_by
+	`
+
+	weekdaysJSON, err := json.Marshal(capacity.WorkingWeekdays)
+	if err != nil {
+		return fmt.Errorf("failed to marshal working weekdays: %w", err)
+	}
+	standardTimes := capacity.StandardTimes
+	if standardTimes == nil {
+		standardTimes = make([]*domain.StandardTime, 0)
+	}
+	standardTimesJSON, err := json.Marshal(standardTimes)
+	if err != nil {
+		return fmt.Errorf("failed to marshal standard times: %w", err)
+	}
+
+	_, err = r.db.ExecContext(ctx, query,
+		capacity.TenantID,
+		capacity.Headcount,
+		capacity.HoursPerDay,
+		weekdaysJSON,
+		capacity.ShippingDays,
+		standardTimesJSON,
+		capacity.UpdatedAt,
+		capacity.UpdatedBy,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to upsert factory capacity: %w", err)
+	}
+
+	return nil
+}
+
+// ListOverrides 期間内の個別設定を日付順に取得（from・toを含む）
+func (r *PostgreSQLFactoryCapacityRepository) ListOverrides(ctx context.Context, tenantID string, from, to time.Time) ([]*domain.CapacityOverride, error) {
+	query := `
+		SELECT ` + capacityOverrideColumns + `
+		FROM capacity_overrides
+		WHERE tenant_id = $1 AND date >= $2 AND date <= $3
+		ORDER BY date ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID, from.Format("2006-01-02"), to.Format("2006-01-02"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query capacity overrides: %w", err)
+	}
+	defer rows.Close()
+
+	overrides := make([]*domain.CapacityOverride, 0)
+	for rows.Next() {
+		var override domain.CapacityOverride
+		var overrideType string
+		var note sql.NullString
+
+		if err := rows.Scan(
+			&override.ID,
+			&override.TenantID,
+			&override.Date,
+			&overrideType,
+			&override.Headcount,
+			&override.HoursPerDay,
+			&note,
+			&override.CreatedAt,
+			&override.CreatedBy,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan capacity override: %w", err)
+		}
+		// DATE型はUTCの0時として読み込まれるため、生産計画の日付に揃える
+		override.Date = domain.ProductionDay(override.Date)
+		override.Type = domain.CapacityOverrideType(overrideType)
+		override.Note = note.String
+		overrides = append(overrides, &override)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating capacity overrides: %w", err)
+	}
+
+	return overrides, nil
+}
+
+// UpsertOverride 個別設定を登録（同じ日の設定がある場合は置き換える）
+func (r *PostgreSQLFactoryCapacityRepository) UpsertOverride(ctx context.Context, override *domain.CapacityOverride) error {
+	query := `
+		INSERT INTO capacity_overrides (` + capacityOverrideColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (tenant_id, date) DO UPDATE SET
+			type = EXCLUDED.type,
+			headcount = EXCLUDED.headcount,
+			hours_per_day = EXCLUDED.hours_per_day,
+			note = EXCLUDED.note,
+			created_at = EXCLUDED.created_at,
+			created_by = EXCLUDED.created_by
+		RETURNING id
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		override.ID,
+		override.TenantID,
+		override.Date.Format("2006-01-02"),
+		string(override.Type),
+		override.Headcount,
+		override.HoursPerDay,
+		nullIfEmpty(override.Note),
+		override.CreatedAt,
+		override.CreatedBy,
+	).Scan(&override.ID)
+	if err != nil {
+		return fmt.Errorf("failed to upsert capacity override: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteOverride 個別設定を削除
+func (r *PostgreSQLFactoryCapacityRepository) DeleteOverride(ctx context.Context, overrideID string, tenantID string) error {
+	query := `DELETE FROM capacity_overrides WHERE id = $1 AND tenant_id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, overrideID, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to delete capacity override: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("capacity override not found or tenant_id mismatch")
+	}
+
+	return nil
+}
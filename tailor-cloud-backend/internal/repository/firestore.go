@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 
 	"tailor-cloud/backend/internal/config/domain"
@@ -13,10 +14,13 @@ import (
 // OrderRepository 注文リポジトリインターフェース
 type OrderRepository interface {
 	Create(ctx context.Context, order *domain.Order) error
+	CreateInTx(ctx context.Context, tx *sql.Tx, order *domain.Order) error
 	GetByID(ctx context.Context, orderID string) (*domain.Order, error)
 	GetByTenantID(ctx context.Context, tenantID string) ([]*domain.Order, error)
 	GetByTenantIDWithPagination(ctx context.Context, tenantID string, page, pageSize int) ([]*domain.Order, error)
 	CountByTenantID(ctx context.Context, tenantID string) (int, error)
+	Search(ctx context.Context, filter *domain.OrderSearchFilter) ([]*domain.Order, error)
+	CountBySearch(ctx context.Context, filter *domain.OrderSearchFilter) (int, error)
 	Update(ctx context.Context, order *domain.Order) error
 	UpdateStatus(ctx context.Context, orderID string, status domain.OrderStatus) error
 }
@@ -46,6 +50,11 @@ func (r *FirestoreOrderRepository) Create(ctx context.Context, order *domain.Ord
 	return nil
 }
 
+// CreateInTx トランザクション内で注文を作成（FirestoreはPostgreSQLのトランザクションに参加できない）
+func (r *FirestoreOrderRepository) CreateInTx(ctx context.Context, tx *sql.Tx, order *domain.Order) error {
+	return fmt.Errorf("failed to create order in firestore: sql transactions are not supported")
+}
+
 // GetByID 注文IDで取得
 func (r *FirestoreOrderRepository) GetByID(ctx context.Context, orderID string) (*domain.Order, error) {
 	docRef := r.client.Collection("orders").Doc(orderID)
@@ -168,3 +177,108 @@ func (r *FirestoreOrderRepository) UpdateStatus(ctx context.Context, orderID str
 
 	return nil
 }
+
+// Search 条件に一致する注文を検索（カーソル方式のページネーション）
+// 次ページの有無を判定するため、最大で filter.Limit+1 件を返す
+// 複合インデックスの組み合わせを抑えるため、等価条件のみクエリで絞り込み、
+// 納期・金額の範囲条件はメモリ上で判定する
+func (r *FirestoreOrderRepository) Search(ctx context.Context, filter *domain.OrderSearchFilter) ([]*domain.Order, error) {
+	direction := firestore.Desc
+	if filter.SortOrder == domain.SortOrderAsc {
+		direction = firestore.Asc
+	}
+
+	query := r.buildSearchQuery(filter).
+		OrderBy(string(filter.SortBy), direction).
+		OrderBy("id", direction)
+
+	// 前ページの最後の注文の次から取得
+	if filter.Cursor != nil {
+		value, err := filter.Cursor.SortValue()
+		if err != nil {
+			return nil, err
+		}
+		query = query.StartAfter(value, filter.Cursor.OrderID)
+	}
+
+	iter := query.Documents(ctx)
+	defer iter.Stop()
+
+	orders := make([]*domain.Order, 0)
+	for len(orders) <= filter.Limit {
+		doc, err := iter.Next()
+		if err != nil {
+			if err == iterator.Done {
+				break
+			}
+			return nil, fmt.Errorf("failed to search orders: %w", err)
+		}
+
+		var order domain.Order
+		if err := doc.DataTo(&order); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal order: %w", err)
+		}
+
+		if filter.Matches(&order) {
+			orders = append(orders, &order)
+		}
+	}
+
+	return orders, nil
+}
+
+// CountBySearch 条件に一致する注文数を取得（カーソルは考慮しない）
+func (r *FirestoreOrderRepository) CountBySearch(ctx context.Context, filter *domain.OrderSearchFilter) (int, error) {
+	iter := r.buildSearchQuery(filter).Documents(ctx)
+	defer iter.Stop()
+
+	count := 0
+	for {
+		doc, err := iter.Next()
+		if err != nil {
+			if err == iterator.Done {
+				break
+			}
+			return 0, fmt.Errorf("failed to count orders: %w", err)
+		}
+
+		var order domain.Order
+		if err := doc.DataTo(&order); err != nil {
+			return 0, fmt.Errorf("failed to unmarshal order: %w", err)
+		}
+
+		if filter.Matches(&order) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// buildSearchQuery 検索条件のうち等価条件をFirestoreクエリに変換
+func (r *FirestoreOrderRepository) buildSearchQuery(filter *domain.OrderSearchFilter) firestore.Query {
+	query := r.client.Collection("orders").Where("tenant_id", "==", filter.TenantID)
+
+	// in演算子は最大30件まで（超える場合はメモリ上で判定）
+	if len(filter.Statuses) > 0 && len(filter.Statuses) <= 30 {
+		statuses := make([]string, 0, len(filter.Statuses))
+		for _, status := range filter.Statuses {
+			statuses = append(statuses, string(status))
+		}
+		query = query.Where("status", "in", statuses)
+	}
+	if filter.CustomerID != "" {
+		query = query.Where("customer_id", "==", filter.CustomerID)
+	}
+	if filter.FabricID != "" {
+		query = query.Where("fabric_id", "==", filter.FabricID)
+	}
+	if filter.CreatedBy != "" {
+		query = query.Where("created_by", "==", filter.CreatedBy)
+	}
+	if filter.GroupOrderID != "" {
+		query = query.Where("group_order_id", "==", filter.GroupOrderID)
+	}
+
+	return query
+}
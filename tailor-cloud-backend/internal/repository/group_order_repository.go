@@ -0,0 +1,189 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	_ "github.com/lib/pq"
+	"tailor-cloud/backend/internal/config/domain"
+)
+
+// GroupOrderRepository 団体注文リポジトリインターフェース
+type GroupOrderRepository interface {
+	Create(ctx context.Context, groupOrder *domain.GroupOrder) error
+	GetByID(ctx context.Context, groupOrderID string, tenantID string) (*domain.GroupOrder, error)
+	GetByTenantID(ctx context.Context, tenantID string, status domain.GroupOrderStatus) ([]*domain.GroupOrder, error)
+	Update(ctx context.Context, groupOrder *domain.GroupOrder) error
+}
+
+// PostgreSQLGroupOrderRepository PostgreSQLを使った団体注文リポジトリ実装
+type PostgreSQLGroupOrderRepository struct {
+	db *sql.DB
+}
+
+// NewPostgreSQLGroupOrderRepository PostgreSQLGroupOrderRepositoryのコンストラクタ
+func NewPostgreSQLGroupOrderRepository(db *sql.DB) GroupOrderRepository {
+	return &PostgreSQLGroupOrderRepository{
+		db: db,
+	}
+}
+
+const groupOrderColumns = `
+	id, tenant_id, name, corporate_customer_id, fabric_id, prices,
+	delivery_date, status, notes, created_at, updated_at, created_by
+`
+
+// Create 団体注文を作成
+func (r *PostgreSQLGroupOrderRepository) Create(ctx context.Context, groupOrder *domain.GroupOrder) error {
+	query := `
+		INSERT INTO group_orders (` + groupOrderColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`
+
+	pricesJSON, err := json.Marshal(groupOrder.Prices)
+	if err != nil {
+		return fmt.Errorf("failed to marshal group order prices: %w", err)
+	}
+
+	_, err = r.db.ExecContext(ctx, query,
+		groupOrder.ID,
+		groupOrder.TenantID,
+		groupOrder.Name,
+		groupOrder.CorporateCustomerID,
+		groupOrder.FabricID,
+		pricesJSON,
+		groupOrder.DeliveryDate,
+		string(groupOrder.Status),
+		nullIfEmpty(groupOrder.Notes),
+		groupOrder.CreatedAt,
+		groupOrder.UpdatedAt,
+		groupOrder.CreatedBy,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create group order: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID 団体注文IDで取得（テナントIDもチェック）
+func (r *PostgreSQLGroupOrderRepository) GetByID(ctx context.Context, groupOrderID string, tenantID string) (*domain.GroupOrder, error) {
+	query := `SELECT ` + groupOrderColumns + ` FROM group_orders WHERE id = $1 AND tenant_id = $2`
+
+	groupOrder, err := scanGroupOrder(r.db.QueryRowContext(ctx, query, groupOrderID, tenantID))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("group order not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get group order: %w", err)
+	}
+
+	return groupOrder, nil
+}
+
+// GetByTenantID テナントIDで団体注文一覧を取得（statusが空の場合は全件）
+func (r *PostgreSQLGroupOrderRepository) GetByTenantID(ctx context.Context, tenantID string, status domain.GroupOrderStatus) ([]*domain.GroupOrder, error) {
+	query := `SELECT ` + groupOrderColumns + ` FROM group_orders WHERE tenant_id = $1`
+	args := []interface{}{tenantID}
+
+	if status != "" {
+		query += " AND status = $2"
+		args = append(args, string(status))
+	}
+	query += " ORDER BY created_at DESC"
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query group orders: %w", err)
+	}
+	defer rows.Close()
+
+	groupOrders := make([]*domain.GroupOrder, 0)
+	for rows.Next() {
+		groupOrder, err := scanGroupOrder(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan group order: %w", err)
+		}
+		groupOrders = append(groupOrders, groupOrder)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating group orders: %w", err)
+	}
+
+	return groupOrders, nil
+}
+
+// Update 団体注文の名称・ステータス・備考を更新
+// 生地と価格の取り決めは作成済みの着用者の注文の前提となるため更新しない
+func (r *PostgreSQLGroupOrderRepository) Update(ctx context.Context, groupOrder *domain.GroupOrder) error {
+	query := `
+		UPDATE group_orders SET
+			name = $3,
+			status = $4,
+			notes = $5,
+			updated_at = $6
+		WHERE id = $1 AND tenant_id = $2
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		groupOrder.ID,
+		groupOrder.TenantID,
+		groupOrder.Name,
+		string(groupOrder.Status),
+		nullIfEmpty(groupOrder.Notes),
+		groupOrder.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update group order: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("group order not found or tenant_id mismatch")
+	}
+
+	return nil
+}
+
+// scanGroupOrder 1行分の団体注文をスキャン
+func scanGroupOrder(row rowScanner) (*domain.GroupOrder, error) {
+	var groupOrder domain.GroupOrder
+	var status string
+	var pricesJSON []byte
+	var notes sql.NullString
+
+	err := row.Scan(
+		&groupOrder.ID,
+		&groupOrder.TenantID,
+		&groupOrder.Name,
+		&groupOrder.CorporateCustomerID,
+		&groupOrder.FabricID,
+		&pricesJSON,
+		&groupOrder.DeliveryDate,
+		&status,
+		&notes,
+		&groupOrder.CreatedAt,
+		&groupOrder.UpdatedAt,
+		&groupOrder.CreatedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	groupOrder.Status = domain.GroupOrderStatus(status)
+	groupOrder.Notes = notes.String
+	groupOrder.Prices = make([]*domain.GroupOrderPrice, 0)
+	if len(pricesJSON) > 0 {
+		if err := json.Unmarshal(pricesJSON, &groupOrder.Prices); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal group order prices: %w", err)
+		}
+	}
+
+	return &groupOrder, nil
+}
@@ -0,0 +1,289 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	_ "github.com/lib/pq"
+	"tailor-cloud/backend/internal/config/domain"
+)
+
+// InspectionRepository 検品チェックリスト・検品記録リポジトリインターフェース
+// 検品記録の作成は手直しタスク・注文ステータスと同じトランザクションで行うため、サービス側で実施する
+type InspectionRepository interface {
+	GetChecklist(ctx context.Context, tenantID string, garmentType domain.GarmentType) (*domain.InspectionChecklist, error)
+	ListChecklists(ctx context.Context, tenantID string) ([]*domain.InspectionChecklist, error)
+	UpsertChecklist(ctx context.Context, checklist *domain.InspectionChecklist) error
+	GetByID(ctx context.Context, inspectionID string, tenantID string) (*domain.Inspection, error)
+	ListByOrderID(ctx context.Context, orderID string, tenantID string) ([]*domain.Inspection, error)
+	GetBetween(ctx context.Context, tenantID string, from, to time.Time) ([]*domain.Inspection, error)
+	AddPhoto(ctx context.Context, inspectionID string, tenantID string, photo *domain.InspectionPhoto) error
+}
+
+// PostgreSQLInspectionRepository PostgreSQLを使った検品リポジトリ実装
+type PostgreSQLInspectionRepository struct {
+	db *sql.DB
+}
+
+// NewPostgreSQLInspectionRepository PostgreSQLInspectionRepositoryのコンストラクタ
+func NewPostgreSQLInspectionRepository(db *sql.DB) InspectionRepository {
+	return &PostgreSQLInspectionRepository{
+		db: db,
+	}
+}
+
+const inspectionChecklistColumns = `
+	tenant_id, garment_type, items, updated_at, updated_by
+`
+
+const inspectionColumns = `
+	id, tenant_id, order_id, garment_type, inspector_id, passed, defect_count,
+	results, photos, responsible_workers, rework_task_id, notes, created_at
+`
+
+// GetChecklist テナント・品目の検品チェックリストを取得
+func (r *PostgreSQLInspectionRepository) GetChecklist(ctx context.Context, tenantID string, garmentType domain.GarmentType) (*domain.InspectionChecklist, error) {
+	query := `SELECT ` + inspectionChecklistColumns + ` FROM inspection_checklists WHERE tenant_id = $1 AND garment_type = $2`
+
+	checklist, err := scanInspectionChecklist(r.db.QueryRowContext(ctx, query, tenantID, string(garmentType)))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("inspection checklist not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inspection checklist: %w", err)
+	}
+
+	return checklist, nil
+}
+
+// ListChecklists テナントの検品チェックリストを品目順に取得
+func (r *PostgreSQLInspectionRepository) ListChecklists(ctx context.Context, tenantID string) ([]*domain.InspectionChecklist, error) {
+	query := `SELECT ` + inspectionChecklistColumns + ` FROM inspection_checklists WHERE tenant_id = $1 ORDER BY garment_type ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query inspection checklists: %w", err)
+	}
+	defer rows.Close()
+
+	checklists := make([]*domain.InspectionChecklist, 0)
+	for rows.Next() {
+		checklist, err := scanInspectionChecklist(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan inspection checklist: %w", err)
+		}
+		checklists = append(checklists, checklist)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating inspection checklists: %w", err)
+	}
+
+	return checklists, nil
+}
+
+// UpsertChecklist テナント・品目の検品チェックリストを登録・更新
+func (r *PostgreSQLInspectionRepository) UpsertChecklist(ctx context.Context, checklist *domain.InspectionChecklist) error {
+	query := `
+		INSERT INTO inspection_checklists (` + inspectionChecklistColumns + `)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (tenant_id, garment_type) DO UPDATE SET
+			items = EXCLUDED.items,
+			updated_at = EXCLUDED.updated_at,
+			updated_by = EXCLUDED.updated_by
+	`
+
+	itemsJSON, err := json.Marshal(checklist.Items)
+	if err != nil {
+		return fmt.Errorf("failed to marshal checklist items: %w", err)
+	}
+
+	_, err = r.db.ExecContext(ctx, query,
+		checklist.TenantID,
+		string(checklist.GarmentType),
+		itemsJSON,
+		checklist.UpdatedAt,
+		nullIfEmpty(checklist.UpdatedBy),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to upsert inspection checklist: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID 検品IDで取得（テナントIDもチェック）
+func (r *PostgreSQLInspectionRepository) GetByID(ctx context.Context, inspectionID string, tenantID string) (*domain.Inspection, error) {
+	query := `SELECT ` + inspectionColumns + ` FROM inspections WHERE id = $1 AND tenant_id = $2`
+
+	inspection, err := scanInspection(r.db.QueryRowContext(ctx, query, inspectionID, tenantID))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("inspection not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inspection: %w", err)
+	}
+
+	return inspection, nil
+}
+
+// ListByOrderID 注文の検品記録を検品日時順に取得
+func (r *PostgreSQLInspectionRepository) ListByOrderID(ctx context.Context, orderID string, tenantID string) ([]*domain.Inspection, error) {
+	query := `
+		SELECT ` + inspectionColumns + `
+		FROM inspections
+		WHERE order_id = $1 AND tenant_id = $2
+		ORDER BY created_at ASC
+	`
+
+	return r.queryInspections(ctx, query, orderID, tenantID)
+}
+
+// GetBetween 期間内の検品記録を取得（fromを含み、toを含まない）
+func (r *PostgreSQLInspectionRepository) GetBetween(ctx context.Context, tenantID string, from, to time.Time) ([]*domain.Inspection, error) {
+	query := `
+		SELECT ` + inspectionColumns + `
+		FROM inspections
+		WHERE tenant_id = $1 AND created_at >= $2 AND created_at < $3
+		ORDER BY created_at ASC
+	`
+
+	return r.queryInspections(ctx, query, tenantID, from, to)
+}
+
+// AddPhoto 検品記録に写真を追加
+func (r *PostgreSQLInspectionRepository) AddPhoto(ctx context.Context, inspectionID string, tenantID string, photo *domain.InspectionPhoto) error {
+	query := `
+		UPDATE inspections SET photos = photos || $3::jsonb
+		WHERE id = $1 AND tenant_id = $2
+	`
+
+	photoJSON, err := json.Marshal([]*domain.InspectionPhoto{photo})
+	if err != nil {
+		return fmt.Errorf("failed to marshal inspection photo: %w", err)
+	}
+
+	result, err := r.db.ExecContext(ctx, query, inspectionID, tenantID, photoJSON)
+	if err != nil {
+		return fmt.Errorf("failed to add inspection photo: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("inspection not found or tenant_id mismatch")
+	}
+
+	return nil
+}
+
+// queryInspections 検品記録の一覧を取得
+func (r *PostgreSQLInspectionRepository) queryInspections(ctx context.Context, query string, args ...interface{}) ([]*domain.Inspection, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query inspections: %w", err)
+	}
+	defer rows.Close()
+
+	inspections := make([]*domain.Inspection, 0)
+	for rows.Next() {
+		inspection, err := scanInspection(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan inspection: %w", err)
+		}
+		inspections = append(inspections, inspection)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating inspections: %w", err)
+	}
+
+	return inspections, nil
+}
+
+// scanInspectionChecklist 1行分の検品チェックリストをスキャン
+func scanInspectionChecklist(row rowScanner) (*domain.InspectionChecklist, error) {
+	var checklist domain.InspectionChecklist
+	var garmentType string
+	var itemsJSON []byte
+	var updatedBy sql.NullString
+
+	err := row.Scan(
+		&checklist.TenantID,
+		&garmentType,
+		&itemsJSON,
+		&checklist.UpdatedAt,
+		&updatedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	checklist.GarmentType = domain.GarmentType(garmentType)
+	checklist.UpdatedBy = updatedBy.String
+	checklist.Items = make([]*domain.InspectionChecklistItem, 0)
+	if len(itemsJSON) > 0 {
+		if err := json.Unmarshal(itemsJSON, &checklist.Items); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal checklist items: %w", err)
+		}
+	}
+
+	return &checklist, nil
+}
+
+// scanInspection 1行分の検品記録をスキャン
+func scanInspection(row rowScanner) (*domain.Inspection, error) {
+	var inspection domain.Inspection
+	var garmentType string
+	var resultsJSON, photosJSON, workersJSON []byte
+	var reworkTaskID, notes sql.NullString
+
+	err := row.Scan(
+		&inspection.ID,
+		&inspection.TenantID,
+		&inspection.OrderID,
+		&garmentType,
+		&inspection.InspectorID,
+		&inspection.Passed,
+		&inspection.DefectCount,
+		&resultsJSON,
+		&photosJSON,
+		&workersJSON,
+		&reworkTaskID,
+		&notes,
+		&inspection.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	inspection.GarmentType = domain.GarmentType(garmentType)
+	inspection.ReworkTaskID = reworkTaskID.String
+	inspection.Notes = notes.String
+
+	inspection.Results = make([]*domain.InspectionItemResult, 0)
+	if len(resultsJSON) > 0 {
+		if err := json.Unmarshal(resultsJSON, &inspection.Results); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal inspection results: %w", err)
+		}
+	}
+	inspection.Photos = make([]*domain.InspectionPhoto, 0)
+	if len(photosJSON) > 0 {
+		if err := json.Unmarshal(photosJSON, &inspection.Photos); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal inspection photos: %w", err)
+		}
+	}
+	inspection.ResponsibleWorkers = make(map[domain.ProductionProcess]string)
+	if len(workersJSON) > 0 {
+		if err := json.Unmarshal(workersJSON, &inspection.ResponsibleWorkers); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal responsible workers: %w", err)
+		}
+	}
+
+	return &inspection, nil
+}
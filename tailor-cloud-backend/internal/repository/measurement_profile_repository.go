@@ -0,0 +1,257 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	_ "github.com/lib/pq"
+	"tailor-cloud/backend/internal/config/domain"
+)
+
+// MeasurementProfileRepository 採寸プロファイルリポジトリインターフェース
+// 版の追加はプロファイルの最新版番号と同じトランザクションで行うため、サービス側で実施する
+type MeasurementProfileRepository interface {
+	GetByCustomerID(ctx context.Context, customerID string, tenantID string) (*domain.MeasurementProfile, error)
+	GetVersion(ctx context.Context, customerID string, tenantID string, version int) (*domain.MeasurementProfileVersion, error)
+	GetLatestVersion(ctx context.Context, customerID string, tenantID string) (*domain.MeasurementProfileVersion, error)
+	ListVersions(ctx context.Context, customerID string, tenantID string) ([]*domain.MeasurementProfileVersion, error)
+	ListLatestMeasurements(ctx context.Context, tenantID string, limit int) ([]json.RawMessage, error)
+	LinkOrder(ctx context.Context, versionID string, orderID string, tenantID string, linkedBy string) error
+}
+
+// PostgreSQLMeasurementProfileRepository PostgreSQLを使った採寸プロファイルリポジトリ実装
+type PostgreSQLMeasurementProfileRepository struct {
+	db *sql.DB
+}
+
+// NewPostgreSQLMeasurementProfileRepository PostgreSQLMeasurementProfileRepositoryのコンストラクタ
+func NewPostgreSQLMeasurementProfileRepository(db *sql.DB) MeasurementProfileRepository {
+	return &PostgreSQLMeasurementProfileRepository{
+		db: db,
+	}
+}
+
+const measurementProfileColumns = `
+	id, tenant_id, customer_id, latest_version, body_shape_notes, created_at, updated_at
+`
+
+// 版の列（この版を使った注文IDを紐付け順に集約）
+const measurementProfileVersionColumns = `
+	v.id, v.profile_id, v.tenant_id, v.customer_id, v.version, v.measurements,
+	v.body_shape_notes, v.notes, v.measured_by, v.measured_at, v.created_at,
+	COALESCE((
+		SELECT json_agg(mpo.order_id ORDER BY mpo.linked_at)
+		FROM measurement_profile_orders mpo
+		WHERE mpo.version_id = v.id
+	), '[]')
+`
+
+// GetByCustomerID 顧客の採寸プロファイルを取得
+func (r *PostgreSQLMeasurementProfileRepository) GetByCustomerID(ctx context.Context, customerID string, tenantID string) (*domain.MeasurementProfile, error) {
+	query := `SELECT ` + measurementProfileColumns + ` FROM measurement_profiles WHERE customer_id = $1 AND tenant_id = $2`
+
+	profile, err := scanMeasurementProfile(r.db.QueryRowContext(ctx, query, customerID, tenantID))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("measurement profile not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get measurement profile: %w", err)
+	}
+
+	return profile, nil
+}
+
+// GetVersion 顧客の採寸プロファイルの指定した版を取得
+func (r *PostgreSQLMeasurementProfileRepository) GetVersion(ctx context.Context, customerID string, tenantID string, version int) (*domain.MeasurementProfileVersion, error) {
+	query := `
+		SELECT ` + measurementProfileVersionColumns + `
+		FROM measurement_profile_versions v
+		WHERE v.customer_id = $1 AND v.tenant_id = $2 AND v.version = $3
+	`
+
+	profileVersion, err := scanMeasurementProfileVersion(r.db.QueryRowContext(ctx, query, customerID, tenantID, version))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("measurement profile version not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get measurement profile version: %w", err)
+	}
+
+	return profileVersion, nil
+}
+
+// GetLatestVersion 顧客の採寸プロファイルの最新版を取得
+func (r *PostgreSQLMeasurementProfileRepository) GetLatestVersion(ctx context.Context, customerID string, tenantID string) (*domain.MeasurementProfileVersion, error) {
+	query := `
+		SELECT ` + measurementProfileVersionColumns + `
+		FROM measurement_profile_versions v
+		WHERE v.customer_id = $1 AND v.tenant_id = $2
+		ORDER BY v.version DESC
+		LIMIT 1
+	`
+
+	profileVersion, err := scanMeasurementProfileVersion(r.db.QueryRowContext(ctx, query, customerID, tenantID))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("measurement profile version not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest measurement profile version: %w", err)
+	}
+
+	return profileVersion, nil
+}
+
+// ListVersions 顧客の採寸プロファイルの版を古い順に取得
+func (r *PostgreSQLMeasurementProfileRepository) ListVersions(ctx context.Context, customerID string, tenantID string) ([]*domain.MeasurementProfileVersion, error) {
+	query := `
+		SELECT ` + measurementProfileVersionColumns + `
+		FROM measurement_profile_versions v
+		WHERE v.customer_id = $1 AND v.tenant_id = $2
+		ORDER BY v.version ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, customerID, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query measurement profile versions: %w", err)
+	}
+	defer rows.Close()
+
+	versions := make([]*domain.MeasurementProfileVersion, 0)
+	for rows.Next() {
+		profileVersion, err := scanMeasurementProfileVersion(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan measurement profile version: %w", err)
+		}
+		versions = append(versions, profileVersion)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating measurement profile versions: %w", err)
+	}
+
+	return versions, nil
+}
+
+// ListLatestMeasurements テナントの顧客ごとの最新の採寸データを採寸日の新しい順に取得
+// 採寸値の統計的な妥当性チェック（テナントの顧客の傾向）に使用する
+func (r *PostgreSQLMeasurementProfileRepository) ListLatestMeasurements(ctx context.Context, tenantID string, limit int) ([]json.RawMessage, error) {
+	query := `
+		SELECT measurements
+		FROM (
+			SELECT DISTINCT ON (customer_id) measurements, measured_at
+			FROM measurement_profile_versions
+			WHERE tenant_id = $1
+			ORDER BY customer_id, version DESC
+		) latest
+		ORDER BY measured_at DESC
+		LIMIT $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query latest measurements: %w", err)
+	}
+	defer rows.Close()
+
+	measurements := make([]json.RawMessage, 0)
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, fmt.Errorf("failed to scan latest measurements: %w", err)
+		}
+		measurements = append(measurements, json.RawMessage(data))
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating latest measurements: %w", err)
+	}
+
+	return measurements, nil
+}
+
+// LinkOrder 注文が使った採寸プロファイルの版を記録（紐付け済みの場合は付け替える）
+func (r *PostgreSQLMeasurementProfileRepository) LinkOrder(ctx context.Context, versionID string, orderID string, tenantID string, linkedBy string) error {
+	query := `
+		INSERT INTO measurement_profile_orders (order_id, version_id, tenant_id, linked_at, linked_by)
+		VALUES ($1, $2, $3, NOW(), $4)
+		ON CONFLICT (order_id) DO UPDATE SET
+			version_id = EXCLUDED.version_id,
+			linked_at = EXCLUDED.linked_at,
+			linked_by = EXCLUDED.linked_by
+		WHERE measurement_profile_orders.tenant_id = EXCLUDED.tenant_id
+	`
+
+	result, err := r.db.ExecContext(ctx, query, orderID, versionID, tenantID, nullIfEmpty(linkedBy))
+	if err != nil {
+		return fmt.Errorf("failed to link order to measurement profile version: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("measurement profile order link not found or tenant_id mismatch")
+	}
+
+	return nil
+}
+
+// scanMeasurementProfile 1行分の採寸プロファイルをスキャン
+func scanMeasurementProfile(row rowScanner) (*domain.MeasurementProfile, error) {
+	var profile domain.MeasurementProfile
+	var bodyShapeNotes sql.NullString
+
+	err := row.Scan(
+		&profile.ID,
+		&profile.TenantID,
+		&profile.CustomerID,
+		&profile.LatestVersion,
+		&bodyShapeNotes,
+		&profile.CreatedAt,
+		&profile.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	profile.BodyShapeNotes = bodyShapeNotes.String
+	return &profile, nil
+}
+
+// scanMeasurementProfileVersion 1行分の採寸プロファイルの版をスキャン
+func scanMeasurementProfileVersion(row rowScanner) (*domain.MeasurementProfileVersion, error) {
+	var profileVersion domain.MeasurementProfileVersion
+	var measurements, orderIDs []byte
+	var bodyShapeNotes, notes sql.NullString
+
+	err := row.Scan(
+		&profileVersion.ID,
+		&profileVersion.ProfileID,
+		&profileVersion.TenantID,
+		&profileVersion.CustomerID,
+		&profileVersion.Version,
+		&measurements,
+		&bodyShapeNotes,
+		&notes,
+		&profileVersion.MeasuredBy,
+		&profileVersion.MeasuredAt,
+		&profileVersion.CreatedAt,
+		&orderIDs,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	profileVersion.Measurements = json.RawMessage(measurements)
+	profileVersion.BodyShapeNotes = bodyShapeNotes.String
+	profileVersion.Notes = notes.String
+	profileVersion.OrderIDs = []string{}
+	if err := json.Unmarshal(orderIDs, &profileVersion.OrderIDs); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal order_ids: %w", err)
+	}
+
+	return &profileVersion, nil
+}
@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	_ "github.com/lib/pq"
+	"tailor-cloud/backend/internal/config/domain"
+)
+
+// MeasurementTemplateRepository 採寸テンプレートリポジトリインターフェース
+type MeasurementTemplateRepository interface {
+	Get(ctx context.Context, tenantID string, garmentType domain.GarmentType) (*domain.MeasurementTemplate, error)
+	List(ctx context.Context, tenantID string) ([]*domain.MeasurementTemplate, error)
+	Upsert(ctx context.Context, template *domain.MeasurementTemplate) error
+}
+
+// PostgreSQLMeasurementTemplateRepository PostgreSQLを使った採寸テンプレートリポジトリ実装
+type PostgreSQLMeasurementTemplateRepository struct {
+	db *sql.DB
+}
+
+// NewPostgreSQLMeasurementTemplateRepository PostgreSQLMeasurementTemplateRepositoryのコンストラクタ
+func NewPostgreSQLMeasurementTemplateRepository(db *sql.DB) MeasurementTemplateRepository {
+	return &PostgreSQLMeasurementTemplateRepository{
+		db: db,
+	}
+}
+
+const measurementTemplateColumns = `
+	tenant_id, garment_type, fields, change_warning_threshold, change_error_threshold, updated_at, updated_by
+`
+
+// Get テナント・品目の採寸テンプレートを取得
+func (r *PostgreSQLMeasurementTemplateRepository) Get(ctx context.Context, tenantID string, garmentType domain.GarmentType) (*domain.MeasurementTemplate, error) {
+	query := `SELECT ` + measurementTemplateColumns + ` FROM measurement_templates WHERE tenant_id = $1 AND garment_type = $2`
+
+	template, err := scanMeasurementTemplate(r.db.QueryRowContext(ctx, query, tenantID, string(garmentType)))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("measurement template not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get measurement template: %w", err)
+	}
+
+	return template, nil
+}
+
+// List テナントで設定済みの採寸テンプレートを取得
+func (r *PostgreSQLMeasurementTemplateRepository) List(ctx context.Context, tenantID string) ([]*domain.MeasurementTemplate, error) {
+	query := `SELECT ` + measurementTemplateColumns + ` FROM measurement_templates WHERE tenant_id = $1 ORDER BY garment_type ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query measurement templates: %w", err)
+	}
+	defer rows.Close()
+
+	templates := make([]*domain.MeasurementTemplate, 0)
+	for rows.Next() {
+		template, err := scanMeasurementTemplate(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan measurement template: %w", err)
+		}
+		templates = append(templates, template)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating measurement templates: %w", err)
+	}
+
+	return templates, nil
+}
+
+// Upsert テナント・品目の採寸テンプレートを登録・更新
+func (r *PostgreSQLMeasurementTemplateRepository) Upsert(ctx context.Context, template *domain.MeasurementTemplate) error {
+	query := `
+		INSERT INTO measurement_templates (` + measurementTemplateColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (tenant_id, garment_type) DO UPDATE SET
+			fields = EXCLUDED.fields,
+			change_warning_threshold = EXCLUDED.change_warning_threshold,
+			change_error_threshold = EXCLUDED.change_error_threshold,
+			updated_at = EXCLUDED.updated_at,
+			updated_by = EXCLUDED.updated_by
+	`
+
+	fieldsJSON, err := json.Marshal(template.Fields)
+	if err != nil {
+		return fmt.Errorf("failed to marshal measurement template fields: %w", err)
+	}
+
+	_, err = r.db.ExecContext(ctx, query,
+		template.TenantID,
+		string(template.GarmentType),
+		fieldsJSON,
+		template.ChangeWarningThreshold,
+		template.ChangeErrorThreshold,
+		template.UpdatedAt,
+		nullIfEmpty(template.UpdatedBy),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to upsert measurement template: %w", err)
+	}
+
+	return nil
+}
+
+// scanMeasurementTemplate 1行分の採寸テンプレートをスキャン
+func scanMeasurementTemplate(row rowScanner) (*domain.MeasurementTemplate, error) {
+	var template domain.MeasurementTemplate
+	var garmentType string
+	var fieldsJSON []byte
+	var updatedBy sql.NullString
+
+	err := row.Scan(
+		&template.TenantID,
+		&garmentType,
+		&fieldsJSON,
+		&template.ChangeWarningThreshold,
+		&template.ChangeErrorThreshold,
+		&template.UpdatedAt,
+		&updatedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	template.GarmentType = domain.GarmentType(garmentType)
+	template.UpdatedBy = updatedBy.String
+	template.Fields = make([]*domain.MeasurementTemplateField, 0)
+	if len(fieldsJSON) > 0 {
+		if err := json.Unmarshal(fieldsJSON, &template.Fields); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal measurement template fields: %w", err)
+		}
+	}
+
+	return &template, nil
+}
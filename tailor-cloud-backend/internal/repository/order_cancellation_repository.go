@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	_ "github.com/lib/pq"
+	"tailor-cloud/backend/internal/config/domain"
+)
+
+// OrderCancellationRepository 注文キャンセル記録リポジトリインターフェース
+// 記録の作成は注文キャンセルと同一トランザクションで行うため、サービス層が担当する
+type OrderCancellationRepository interface {
+	GetByOrderID(ctx context.Context, orderID string, tenantID string) (*domain.OrderCancellation, error)
+}
+
+// PostgreSQLOrderCancellationRepository PostgreSQLを使った注文キャンセル記録リポジトリ実装
+type PostgreSQLOrderCancellationRepository struct {
+	db *sql.DB
+}
+
+// NewPostgreSQLOrderCancellationRepository PostgreSQLOrderCancellationRepositoryのコンストラクタ
+func NewPostgreSQLOrderCancellationRepository(db *sql.DB) OrderCancellationRepository {
+	return &PostgreSQLOrderCancellationRepository{
+		db: db,
+	}
+}
+
+// GetByOrderID 注文のキャンセル記録を取得
+func (r *PostgreSQLOrderCancellationRepository) GetByOrderID(ctx context.Context, orderID string, tenantID string) (*domain.OrderCancellation, error) {
+	query := `
+		SELECT
+			id, tenant_id, order_id, reason, stage, fee_policy_code, fee_rate_percent,
+			order_amount, fee_amount, released_allocations, released_length,
+			cancelled_commissions, compliance_document_id, cancelled_by, cancelled_at
+		FROM order_cancellations
+		WHERE order_id = $1 AND tenant_id = $2
+	`
+
+	var cancellation domain.OrderCancellation
+	var stage string
+	var complianceDocumentID sql.NullString
+
+	err := r.db.QueryRowContext(ctx, query, orderID, tenantID).Scan(
+		&cancellation.ID,
+		&cancellation.TenantID,
+		&cancellation.OrderID,
+		&cancellation.Reason,
+		&stage,
+		&cancellation.FeePolicyCode,
+		&cancellation.FeeRatePercent,
+		&cancellation.OrderAmount,
+		&cancellation.FeeAmount,
+		&cancellation.ReleasedAllocations,
+		&cancellation.ReleasedLength,
+		&cancellation.CancelledCommissions,
+		&complianceDocumentID,
+		&cancellation.CancelledBy,
+		&cancellation.CancelledAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("order cancellation not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order cancellation: %w", err)
+	}
+
+	cancellation.Stage = domain.OrderStatus(stage)
+	if complianceDocumentID.Valid {
+		cancellation.ComplianceDocumentID = complianceDocumentID.String
+	}
+
+	return &cancellation, nil
+}
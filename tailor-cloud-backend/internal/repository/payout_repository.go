@@ -0,0 +1,259 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	_ "github.com/lib/pq"
+	"tailor-cloud/backend/internal/config/domain"
+)
+
+// PayoutRepository 支払バッチリポジトリインターフェース
+// バッチ作成・確定はPayoutService内のトランザクションで行うため、ここでは参照系のみを提供
+type PayoutRepository interface {
+	GetBatchByID(ctx context.Context, batchID string, tenantID string) (*domain.PayoutBatch, error)
+	GetBatchesByTenantID(ctx context.Context, tenantID string, limit, offset int) ([]*domain.PayoutBatch, error)
+	GetActiveReferenceIDs(ctx context.Context, tenantID string, itemType domain.PayoutItemType) (map[string]bool, error)
+	GetPaidBatchesByTransferDate(ctx context.Context, tenantID string, from, to time.Time) ([]*domain.PayoutBatch, error)
+}
+
+// PostgreSQLPayoutRepository PostgreSQLを使った支払バッチリポジトリ実装
+type PostgreSQLPayoutRepository struct {
+	db *sql.DB
+}
+
+// NewPostgreSQLPayoutRepository PostgreSQLPayoutRepositoryのコンストラクタ
+func NewPostgreSQLPayoutRepository(db *sql.DB) PayoutRepository {
+	return &PostgreSQLPayoutRepository{
+		db: db,
+	}
+}
+
+const payoutBatchColumns = `
+	id, tenant_id, due_by, transfer_date, status, item_count, total_amount,
+	file_hash, created_by, confirmed_by, confirmed_at, created_at, updated_at
+`
+
+// GetBatchByID 支払バッチを明細付きで取得
+func (r *PostgreSQLPayoutRepository) GetBatchByID(ctx context.Context, batchID string, tenantID string) (*domain.PayoutBatch, error) {
+	query := `SELECT ` + payoutBatchColumns + ` FROM payout_batches WHERE id = $1 AND tenant_id = $2`
+
+	batch, err := scanPayoutBatch(r.db.QueryRowContext(ctx, query, batchID, tenantID))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("payout batch not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payout batch: %w", err)
+	}
+
+	items, err := r.getItemsByBatchID(ctx, batch.ID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	batch.Items = items
+
+	return batch, nil
+}
+
+// GetBatchesByTenantID 支払バッチ一覧を取得（明細なし）
+func (r *PostgreSQLPayoutRepository) GetBatchesByTenantID(ctx context.Context, tenantID string, limit, offset int) ([]*domain.PayoutBatch, error) {
+	query := `
+		SELECT ` + payoutBatchColumns + `
+		FROM payout_batches
+		WHERE tenant_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query payout batches: %w", err)
+	}
+	defer rows.Close()
+
+	batches := make([]*domain.PayoutBatch, 0)
+	for rows.Next() {
+		batch, err := scanPayoutBatch(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan payout batch: %w", err)
+		}
+		batches = append(batches, batch)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating payout batches: %w", err)
+	}
+
+	return batches, nil
+}
+
+// GetPaidBatchesByTransferDate 振込指定日が期間内（from以上to未満）の振込完了バッチを明細付きで取得
+func (r *PostgreSQLPayoutRepository) GetPaidBatchesByTransferDate(ctx context.Context, tenantID string, from, to time.Time) ([]*domain.PayoutBatch, error) {
+	query := `
+		SELECT ` + payoutBatchColumns + `
+		FROM payout_batches
+		WHERE tenant_id = $1 AND status = $2 AND transfer_date >= $3 AND transfer_date < $4
+		ORDER BY transfer_date ASC, created_at ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID, string(domain.PayoutBatchStatusPaid), from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query payout batches: %w", err)
+	}
+	defer rows.Close()
+
+	batches := make([]*domain.PayoutBatch, 0)
+	for rows.Next() {
+		batch, err := scanPayoutBatch(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan payout batch: %w", err)
+		}
+		batches = append(batches, batch)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating payout batches: %w", err)
+	}
+
+	for _, batch := range batches {
+		items, err := r.getItemsByBatchID(ctx, batch.ID, tenantID)
+		if err != nil {
+			return nil, err
+		}
+		batch.Items = items
+	}
+
+	return batches, nil
+}
+
+// GetActiveReferenceIDs 振込予定・振込済みの支払明細が参照しているIDを取得
+// 同じ支払義務を二重にバッチへ含めないために使用
+func (r *PostgreSQLPayoutRepository) GetActiveReferenceIDs(ctx context.Context, tenantID string, itemType domain.PayoutItemType) (map[string]bool, error) {
+	query := `
+		SELECT reference_id
+		FROM payout_items
+		WHERE tenant_id = $1 AND item_type = $2 AND status IN ($3, $4)
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID, string(itemType),
+		string(domain.PayoutItemStatusScheduled), string(domain.PayoutItemStatusPaid))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query payout items: %w", err)
+	}
+	defer rows.Close()
+
+	ids := make(map[string]bool)
+	for rows.Next() {
+		var referenceID string
+		if err := rows.Scan(&referenceID); err != nil {
+			return nil, fmt.Errorf("failed to scan payout item: %w", err)
+		}
+		ids[referenceID] = true
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating payout items: %w", err)
+	}
+
+	return ids, nil
+}
+
+// getItemsByBatchID バッチの支払明細を取得
+func (r *PostgreSQLPayoutRepository) getItemsByBatchID(ctx context.Context, batchID string, tenantID string) ([]*domain.PayoutItem, error) {
+	query := `
+		SELECT
+			id, batch_id, tenant_id, item_type, reference_id, due_date, amount,
+			bank_account_id, bank_code, bank_name_kana, branch_code, branch_name_kana,
+			account_type, account_number, account_holder_kana, status, created_at, updated_at
+		FROM payout_items
+		WHERE batch_id = $1 AND tenant_id = $2
+		ORDER BY created_at ASC, id ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, batchID, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query payout items: %w", err)
+	}
+	defer rows.Close()
+
+	items := make([]*domain.PayoutItem, 0)
+	for rows.Next() {
+		var item domain.PayoutItem
+		var itemType, accountType, status string
+		var bankNameKana, branchNameKana sql.NullString
+
+		err := rows.Scan(
+			&item.ID,
+			&item.BatchID,
+			&item.TenantID,
+			&itemType,
+			&item.ReferenceID,
+			&item.DueDate,
+			&item.Amount,
+			&item.BankAccountID,
+			&item.BankCode,
+			&bankNameKana,
+			&item.BranchCode,
+			&branchNameKana,
+			&accountType,
+			&item.AccountNumber,
+			&item.AccountHolderKana,
+			&status,
+			&item.CreatedAt,
+			&item.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan payout item: %w", err)
+		}
+
+		item.ItemType = domain.PayoutItemType(itemType)
+		item.AccountType = domain.BankAccountType(accountType)
+		item.Status = domain.PayoutItemStatus(status)
+		item.BankNameKana = bankNameKana.String
+		item.BranchNameKana = branchNameKana.String
+		items = append(items, &item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating payout items: %w", err)
+	}
+
+	return items, nil
+}
+
+// scanPayoutBatch 1行分の支払バッチをスキャン
+func scanPayoutBatch(row rowScanner) (*domain.PayoutBatch, error) {
+	var batch domain.PayoutBatch
+	var status string
+	var fileHash, confirmedBy sql.NullString
+	var confirmedAt sql.NullTime
+
+	err := row.Scan(
+		&batch.ID,
+		&batch.TenantID,
+		&batch.DueBy,
+		&batch.TransferDate,
+		&status,
+		&batch.ItemCount,
+		&batch.TotalAmount,
+		&fileHash,
+		&batch.CreatedBy,
+		&confirmedBy,
+		&confirmedAt,
+		&batch.CreatedAt,
+		&batch.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	batch.Status = domain.PayoutBatchStatus(status)
+	batch.FileHash = fileHash.String
+	batch.ConfirmedBy = confirmedBy.String
+	if confirmedAt.Valid {
+		batch.ConfirmedAt = &confirmedAt.Time
+	}
+
+	return &batch, nil
+}
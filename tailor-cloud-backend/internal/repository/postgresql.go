@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	_ "github.com/lib/pq" // PostgreSQL driver
@@ -26,20 +27,40 @@ func NewPostgreSQLOrderRepository(db *sql.DB) OrderRepository {
 
 // Create 注文を作成
 func (r *PostgreSQLOrderRepository) Create(ctx context.Context, order *domain.Order) error {
+	if err := insertOrder(ctx, r.db, order); err != nil {
+		return fmt.Errorf("failed to create order in postgresql: %w", err)
+	}
+	return nil
+}
+
+// CreateInTx トランザクション内で注文を作成（価格上書き・割引明細・見積の変換などと同時にコミットする場合）
+func (r *PostgreSQLOrderRepository) CreateInTx(ctx context.Context, tx *sql.Tx, order *domain.Order) error {
+	if err := insertOrder(ctx, tx, order); err != nil {
+		return fmt.Errorf("failed to create order in postgresql: %w", err)
+	}
+	return nil
+}
+
+// sqlExecer *sql.DB と *sql.Tx に共通の実行メソッド
+type sqlExecer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// insertOrder 注文を1行挿入
+func insertOrder(ctx context.Context, exec sqlExecer, order *domain.Order) error {
 	query := `
 		INSERT INTO orders (
 			id, tenant_id, customer_id, fabric_id, status,
 			compliance_doc_url, compliance_doc_hash,
 			total_amount, payment_due_date, delivery_date,
 			measurement_data, adjustments, description,
-			created_at, updated_at, created_by
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+			created_at, updated_at, created_by, source_order_id, garment_type, group_order_id
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
 	`
 	
 	// OrderDetailsのJSONデータを準備
 	var measurementDataJSON, adjustmentsJSON []byte
-	var err error
-	
+	description := ""
 	if order.Details != nil {
 		if order.Details.MeasurementData != nil {
 			measurementDataJSON = order.Details.MeasurementData
@@ -47,14 +68,10 @@ func (r *PostgreSQLOrderRepository) Create(ctx context.Context, order *domain.Or
 		if order.Details.Adjustments != nil {
 			adjustmentsJSON = order.Details.Adjustments
 		}
-	}
-	
-	description := ""
-	if order.Details != nil {
 		description = order.Details.Description
 	}
 	
-	_, err = r.db.ExecContext(ctx, query,
+	_, err := exec.ExecContext(ctx, query,
 		order.ID,
 		order.TenantID,
 		order.CustomerID,
@@ -71,52 +88,18 @@ func (r *PostgreSQLOrderRepository) Create(ctx context.Context, order *domain.Or
 		order.CreatedAt,
 		order.UpdatedAt,
 		order.CreatedBy,
+		nullIfEmpty(order.SourceOrderID),
+		nullIfEmpty(string(order.GarmentType)),
+		nullIfEmpty(order.GroupOrderID),
 	)
-	
-	if err != nil {
-		return fmt.Errorf("failed to create order in postgresql: %w", err)
-	}
-	
-	return nil
+	return err
 }
 
 // GetByID 注文IDで取得
 func (r *PostgreSQLOrderRepository) GetByID(ctx context.Context, orderID string) (*domain.Order, error) {
-	query := `
-		SELECT 
-			id, tenant_id, customer_id, fabric_id, status,
-			compliance_doc_url, compliance_doc_hash,
-			total_amount, payment_due_date, delivery_date,
-			measurement_data, adjustments, description,
-			created_at, updated_at, created_by
-		FROM orders
-		WHERE id = $1
-	`
-	
-	var order domain.Order
-	var statusStr string
-	var measurementDataJSON, adjustmentsJSON sql.NullString
-	var description sql.NullString
-	
-	err := r.db.QueryRowContext(ctx, query, orderID).Scan(
-		&order.ID,
-		&order.TenantID,
-		&order.CustomerID,
-		&order.FabricID,
-		&statusStr,
-		&order.ComplianceDocURL,
-		&order.ComplianceDocHash,
-		&order.TotalAmount,
-		&order.PaymentDueDate,
-		&order.DeliveryDate,
-		&measurementDataJSON,
-		&adjustmentsJSON,
-		&description,
-		&order.CreatedAt,
-		&order.UpdatedAt,
-		&order.CreatedBy,
-	)
+	query := `SELECT ` + orderColumns + ` FROM orders WHERE id = $1`
 	
+	order, err := scanOrder(r.db.QueryRowContext(ctx, query, orderID))
 	if err == sql.ErrNoRows {
 		return nil, fmt.Errorf("order not found: %s", orderID)
 	}
@@ -124,24 +107,7 @@ func (r *PostgreSQLOrderRepository) GetByID(ctx context.Context, orderID string)
 		return nil, fmt.Errorf("failed to get order from postgresql: %w", err)
 	}
 	
-	order.Status = domain.OrderStatus(statusStr)
-	
-	// OrderDetailsを構築
-	if measurementDataJSON.Valid || adjustmentsJSON.Valid || description.Valid {
-		order.Details = &domain.OrderDetails{}
-		
-		if measurementDataJSON.Valid {
-			order.Details.MeasurementData = json.RawMessage(measurementDataJSON.String)
-		}
-		if adjustmentsJSON.Valid {
-			order.Details.Adjustments = json.RawMessage(adjustmentsJSON.String)
-		}
-		if description.Valid {
-			order.Details.Description = description.String
-		}
-	}
-	
-	return &order, nil
+	return order, nil
 }
 
 // GetByTenantID テナントIDで注文一覧を取得（後方互換性のため残す）
@@ -164,14 +130,7 @@ func (r *PostgreSQLOrderRepository) GetByTenantIDWithPagination(ctx context.Cont
 		pageSize = 100
 	}
 	
-	query := `
-		SELECT 
-			id, tenant_id, customer_id, fabric_id, status,
-			compliance_doc_url, compliance_doc_hash,
-			total_amount, payment_due_date, delivery_date,
-			measurement_data, adjustments, description,
-			created_at, updated_at, created_by
-		FROM orders
+	query := `SELECT ` + orderColumns + ` FROM orders
 		WHERE tenant_id = $1
 		ORDER BY created_at DESC
 		LIMIT $2 OFFSET $3
@@ -186,52 +145,12 @@ func (r *PostgreSQLOrderRepository) GetByTenantIDWithPagination(ctx context.Cont
 	orders := make([]*domain.Order, 0)
 	
 	for rows.Next() {
-		var order domain.Order
-		var statusStr string
-		var measurementDataJSON, adjustmentsJSON sql.NullString
-		var description sql.NullString
-		
-		err := rows.Scan(
-			&order.ID,
-			&order.TenantID,
-			&order.CustomerID,
-			&order.FabricID,
-			&statusStr,
-			&order.ComplianceDocURL,
-			&order.ComplianceDocHash,
-			&order.TotalAmount,
-			&order.PaymentDueDate,
-			&order.DeliveryDate,
-			&measurementDataJSON,
-			&adjustmentsJSON,
-			&description,
-			&order.CreatedAt,
-			&order.UpdatedAt,
-			&order.CreatedBy,
-		)
-		
+		order, err := scanOrder(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan order: %w", err)
 		}
 		
-		order.Status = domain.OrderStatus(statusStr)
-		
-		// OrderDetailsを構築
-		if measurementDataJSON.Valid || adjustmentsJSON.Valid || description.Valid {
-			order.Details = &domain.OrderDetails{}
-			
-			if measurementDataJSON.Valid {
-				order.Details.MeasurementData = json.RawMessage(measurementDataJSON.String)
-			}
-			if adjustmentsJSON.Valid {
-				order.Details.Adjustments = json.RawMessage(adjustmentsJSON.String)
-			}
-			if description.Valid {
-				order.Details.Description = description.String
-			}
-		}
-		
-		orders = append(orders, &order)
+		orders = append(orders, order)
 	}
 	
 	if err = rows.Err(); err != nil {
@@ -284,7 +203,8 @@ func (r *PostgreSQLOrderRepository) Update(ctx context.Context, order *domain.Or
 			measurement_data = $10,
 			adjustments = $11,
 			description = $12,
-			updated_at = $13
+			updated_at = $13,
+			garment_type = $15
 		WHERE id = $1 AND tenant_id = $14
 	`
 	
@@ -319,6 +239,7 @@ func (r *PostgreSQLOrderRepository) Update(ctx context.Context, order *domain.Or
 		description,
 		order.UpdatedAt,
 		order.TenantID, // WHERE句でテナントIDを確認
+		nullIfEmpty(string(order.GarmentType)),
 	)
 	
 	if err != nil {
@@ -354,3 +275,201 @@ func (r *PostgreSQLOrderRepository) UpdateStatus(ctx context.Context, orderID st
 	return nil
 }
 
+
+// Search 条件に一致する注文を検索（カーソル方式のページネーション）
+// 次ページの有無を判定するため、最大で filter.Limit+1 件を返す
+func (r *PostgreSQLOrderRepository) Search(ctx context.Context, filter *domain.OrderSearchFilter) ([]*domain.Order, error) {
+	conditions, args := buildOrderSearchConditions(filter)
+
+	// 並び替え項目はenumで検証済みのため、そのままカラム名として使用する
+	sortColumn := string(filter.SortBy)
+	direction, comparator := "DESC", "<"
+	if filter.SortOrder == domain.SortOrderAsc {
+		direction, comparator = "ASC", ">"
+	}
+
+	// キーセット方式: 前ページの最後の注文より後ろの行のみを取得
+	if filter.Cursor != nil {
+		value, err := filter.Cursor.SortValue()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, value, filter.Cursor.OrderID)
+		conditions = append(conditions, fmt.Sprintf("(%s, id) %s ($%d, $%d)", sortColumn, comparator, len(args)-1, len(args)))
+	}
+
+	args = append(args, filter.Limit+1)
+	query := `SELECT ` + orderColumns + ` FROM orders
+		WHERE ` + strings.Join(conditions, " AND ") + fmt.Sprintf(`
+		ORDER BY %s %s, id %s
+		LIMIT $%d
+	`, sortColumn, direction, direction, len(args))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search orders: %w", err)
+	}
+	defer rows.Close()
+
+	orders := make([]*domain.Order, 0)
+	for rows.Next() {
+		order, err := scanOrder(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan order: %w", err)
+		}
+		orders = append(orders, order)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating orders: %w", err)
+	}
+
+	return orders, nil
+}
+
+// CountBySearch 条件に一致する注文数を取得（カーソルは考慮しない）
+func (r *PostgreSQLOrderRepository) CountBySearch(ctx context.Context, filter *domain.OrderSearchFilter) (int, error) {
+	conditions, args := buildOrderSearchConditions(filter)
+	query := `SELECT COUNT(*) FROM orders WHERE ` + strings.Join(conditions, " AND ")
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count orders: %w", err)
+	}
+
+	return count, nil
+}
+
+// buildOrderSearchConditions 検索条件からWHERE句の条件とパラメータを構築
+func buildOrderSearchConditions(filter *domain.OrderSearchFilter) ([]string, []interface{}) {
+	conditions := []string{"tenant_id = $1"}
+	args := []interface{}{filter.TenantID}
+
+	addCondition := func(format string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(format, len(args)))
+	}
+
+	if len(filter.Statuses) > 0 {
+		placeholders := make([]string, 0, len(filter.Statuses))
+		for _, status := range filter.Statuses {
+			args = append(args, string(status))
+			placeholders = append(placeholders, fmt.Sprintf("$%d", len(args)))
+		}
+		conditions = append(conditions, "status IN ("+strings.Join(placeholders, ", ")+")")
+	}
+	if filter.CustomerID != "" {
+		addCondition("customer_id = $%d", filter.CustomerID)
+	}
+	if filter.FabricID != "" {
+		addCondition("fabric_id = $%d", filter.FabricID)
+	}
+	if filter.CreatedBy != "" {
+		addCondition("created_by = $%d", filter.CreatedBy)
+	}
+	if filter.GroupOrderID != "" {
+		addCondition("group_order_id = $%d", filter.GroupOrderID)
+	}
+	if filter.DeliveryFrom != nil {
+		addCondition("delivery_date >= $%d", *filter.DeliveryFrom)
+	}
+	if filter.DeliveryTo != nil {
+		addCondition("delivery_date < $%d", *filter.DeliveryTo)
+	}
+	if filter.MinAmount != nil {
+		addCondition("total_amount >= $%d", *filter.MinAmount)
+	}
+	if filter.MaxAmount != nil {
+		addCondition("total_amount <= $%d", *filter.MaxAmount)
+	}
+	if filter.PaymentDueBy != nil {
+		addCondition("payment_due_date <= $%d", *filter.PaymentDueBy)
+	}
+	if filter.PostedFrom != nil || filter.PostedTo != nil {
+		// 納期または請求書発行日のいずれかが計上期間内
+		var delivery, invoice []string
+		if filter.PostedFrom != nil {
+			args = append(args, *filter.PostedFrom)
+			delivery = append(delivery, fmt.Sprintf("delivery_date >= $%d", len(args)))
+			invoice = append(invoice, fmt.Sprintf("invoice_issued_at >= $%d", len(args)))
+		}
+		if filter.PostedTo != nil {
+			args = append(args, *filter.PostedTo)
+			delivery = append(delivery, fmt.Sprintf("delivery_date < $%d", len(args)))
+			invoice = append(invoice, fmt.Sprintf("invoice_issued_at < $%d", len(args)))
+		}
+		conditions = append(conditions, "(("+strings.Join(delivery, " AND ")+") OR ("+strings.Join(invoice, " AND ")+"))")
+	}
+
+	return conditions, args
+}
+
+// orderColumns 注文テーブルの取得カラム（scanOrderと同じ順序）
+const orderColumns = `
+	id, tenant_id, customer_id, fabric_id, status,
+	compliance_doc_url, compliance_doc_hash,
+	total_amount, payment_due_date, delivery_date,
+	measurement_data, adjustments, description,
+	created_at, updated_at, created_by, source_order_id, garment_type, group_order_id, paid_at
+`
+
+// scanOrder 注文の1行をスキャン
+func scanOrder(row rowScanner) (*domain.Order, error) {
+	var order domain.Order
+	var statusStr string
+	var measurementDataJSON, adjustmentsJSON sql.NullString
+	var description sql.NullString
+	var sourceOrderID, garmentType, groupOrderID sql.NullString
+	var paidAt sql.NullTime
+
+	err := row.Scan(
+		&order.ID,
+		&order.TenantID,
+		&order.CustomerID,
+		&order.FabricID,
+		&statusStr,
+		&order.ComplianceDocURL,
+		&order.ComplianceDocHash,
+		&order.TotalAmount,
+		&order.PaymentDueDate,
+		&order.DeliveryDate,
+		&measurementDataJSON,
+		&adjustmentsJSON,
+		&description,
+		&order.CreatedAt,
+		&order.UpdatedAt,
+		&order.CreatedBy,
+		&sourceOrderID,
+		&garmentType,
+		&groupOrderID,
+		&paidAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	order.Status = domain.OrderStatus(statusStr)
+	order.SourceOrderID = sourceOrderID.String
+	order.GarmentType = domain.GarmentType(garmentType.String)
+	order.GroupOrderID = groupOrderID.String
+	if paidAt.Valid {
+		order.PaidAt = &paidAt.Time
+	}
+
+	// OrderDetailsを構築
+	if measurementDataJSON.Valid || adjustmentsJSON.Valid || description.Valid {
+		order.Details = &domain.OrderDetails{}
+
+		if measurementDataJSON.Valid {
+			order.Details.MeasurementData = json.RawMessage(measurementDataJSON.String)
+		}
+		if adjustmentsJSON.Valid {
+			order.Details.Adjustments = json.RawMessage(adjustmentsJSON.String)
+		}
+		if description.Valid {
+			order.Details.Description = description.String
+		}
+	}
+
+	return &order, nil
+}
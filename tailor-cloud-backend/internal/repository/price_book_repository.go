@@ -0,0 +1,206 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	_ "github.com/lib/pq"
+	"tailor-cloud/backend/internal/config/domain"
+)
+
+// PriceBookRepository 価格表リポジトリインターフェース
+type PriceBookRepository interface {
+	Upsert(ctx context.Context, entry *domain.PriceBookEntry) error
+	GetByTenantID(ctx context.Context, tenantID string) ([]*domain.PriceBookEntry, error)
+	GetActiveByTenantID(ctx context.Context, tenantID string) ([]*domain.PriceBookEntry, error)
+	CreateOverride(ctx context.Context, override *domain.OrderPriceOverride) error
+	GetOverridesByOrderID(ctx context.Context, orderID string, tenantID string) ([]*domain.OrderPriceOverride, error)
+}
+
+// PostgreSQLPriceBookRepository PostgreSQLを使った価格表リポジトリ実装
+type PostgreSQLPriceBookRepository struct {
+	db *sql.DB
+}
+
+// NewPostgreSQLPriceBookRepository PostgreSQLPriceBookRepositoryのコンストラクタ
+func NewPostgreSQLPriceBookRepository(db *sql.DB) PriceBookRepository {
+	return &PostgreSQLPriceBookRepository{
+		db: db,
+	}
+}
+
+const priceBookEntryColumns = `
+	id, tenant_id, item_type, code, category, name, price, lead_time_days, active, created_at, updated_at
+`
+
+// Upsert 価格表項目を登録・更新（テナント×種別×コードで一意）
+func (r *PostgreSQLPriceBookRepository) Upsert(ctx context.Context, entry *domain.PriceBookEntry) error {
+	query := `
+		INSERT INTO price_book_entries (` + priceBookEntryColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (tenant_id, item_type, code) DO UPDATE SET
+			category = EXCLUDED.category,
+			name = EXCLUDED.name,
+			price = EXCLUDED.price,
+			lead_time_days = EXCLUDED.lead_time_days,
+			active = EXCLUDED.active,
+			updated_at = EXCLUDED.updated_at
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		entry.ID,
+		entry.TenantID,
+		string(entry.ItemType),
+		entry.Code,
+		nullIfEmpty(entry.Category),
+		entry.Name,
+		entry.Price,
+		entry.LeadTimeDays,
+		entry.Active,
+		entry.CreatedAt,
+		entry.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to upsert price book entry: %w", err)
+	}
+
+	return nil
+}
+
+// GetByTenantID テナントの価格表を取得（無効な項目も含む）
+func (r *PostgreSQLPriceBookRepository) GetByTenantID(ctx context.Context, tenantID string) ([]*domain.PriceBookEntry, error) {
+	query := `SELECT ` + priceBookEntryColumns + ` FROM price_book_entries WHERE tenant_id = $1 ORDER BY item_type, category, code`
+	return r.queryEntries(ctx, query, tenantID)
+}
+
+// GetActiveByTenantID テナントの有効な価格表項目を取得
+func (r *PostgreSQLPriceBookRepository) GetActiveByTenantID(ctx context.Context, tenantID string) ([]*domain.PriceBookEntry, error) {
+	query := `SELECT ` + priceBookEntryColumns + ` FROM price_book_entries WHERE tenant_id = $1 AND active = TRUE ORDER BY item_type, category, code`
+	return r.queryEntries(ctx, query, tenantID)
+}
+
+// queryEntries 価格表項目一覧を取得する共通処理
+func (r *PostgreSQLPriceBookRepository) queryEntries(ctx context.Context, query string, args ...interface{}) ([]*domain.PriceBookEntry, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query price book entries: %w", err)
+	}
+	defer rows.Close()
+
+	entries := make([]*domain.PriceBookEntry, 0)
+	for rows.Next() {
+		var entry domain.PriceBookEntry
+		var itemType string
+		var category sql.NullString
+
+		err := rows.Scan(
+			&entry.ID,
+			&entry.TenantID,
+			&itemType,
+			&entry.Code,
+			&category,
+			&entry.Name,
+			&entry.Price,
+			&entry.LeadTimeDays,
+			&entry.Active,
+			&entry.CreatedAt,
+			&entry.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan price book entry: %w", err)
+		}
+
+		entry.ItemType = domain.PriceBookItemType(itemType)
+		entry.Category = category.String
+		entries = append(entries, &entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating price book entries: %w", err)
+	}
+
+	return entries, nil
+}
+
+// CreateOverride 価格上書き記録を作成
+func (r *PostgreSQLPriceBookRepository) CreateOverride(ctx context.Context, override *domain.OrderPriceOverride) error {
+	query := `
+		INSERT INTO order_price_overrides (
+			id, tenant_id, order_id, computed_amount, override_amount, reason, breakdown, authorized_by, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	breakdownJSON, err := json.Marshal(override.Breakdown)
+	if err != nil {
+		return fmt.Errorf("failed to marshal price breakdown: %w", err)
+	}
+
+	_, err = r.db.ExecContext(ctx, query,
+		override.ID,
+		override.TenantID,
+		override.OrderID,
+		override.ComputedAmount,
+		override.OverrideAmount,
+		override.Reason,
+		breakdownJSON,
+		override.AuthorizedBy,
+		override.CreatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create order price override: %w", err)
+	}
+
+	return nil
+}
+
+// GetOverridesByOrderID 注文の価格上書き記録を取得
+func (r *PostgreSQLPriceBookRepository) GetOverridesByOrderID(ctx context.Context, orderID string, tenantID string) ([]*domain.OrderPriceOverride, error) {
+	query := `
+		SELECT id, tenant_id, order_id, computed_amount, override_amount, reason, breakdown, authorized_by, created_at
+		FROM order_price_overrides
+		WHERE order_id = $1 AND tenant_id = $2
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, orderID, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query order price overrides: %w", err)
+	}
+	defer rows.Close()
+
+	overrides := make([]*domain.OrderPriceOverride, 0)
+	for rows.Next() {
+		var override domain.OrderPriceOverride
+		var breakdownJSON []byte
+
+		err := rows.Scan(
+			&override.ID,
+			&override.TenantID,
+			&override.OrderID,
+			&override.ComputedAmount,
+			&override.OverrideAmount,
+			&override.Reason,
+			&breakdownJSON,
+			&override.AuthorizedBy,
+			&override.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan order price override: %w", err)
+		}
+
+		if err := json.Unmarshal(breakdownJSON, &override.Breakdown); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal price breakdown: %w", err)
+		}
+		overrides = append(overrides, &override)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating order price overrides: %w", err)
+	}
+
+	return overrides, nil
+}
@@ -0,0 +1,231 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	_ "github.com/lib/pq"
+	"tailor-cloud/backend/internal/config/domain"
+)
+
+// ProductionTaskRepository 製造タスクリポジトリインターフェース
+type ProductionTaskRepository interface {
+	Create(ctx context.Context, task *domain.ProductionTask) error
+	GetByID(ctx context.Context, taskID string, tenantID string) (*domain.ProductionTask, error)
+	List(ctx context.Context, filter *domain.ProductionTaskFilter) ([]*domain.ProductionTask, error)
+	GetCompletedBetween(ctx context.Context, tenantID string, from, to time.Time) ([]*domain.ProductionTask, error)
+	UpdateAssignee(ctx context.Context, task *domain.ProductionTask) error
+}
+
+// PostgreSQLProductionTaskRepository PostgreSQLを使った製造タスクリポジトリ実装
+type PostgreSQLProductionTaskRepository struct {
+	db *sql.DB
+}
+
+// NewPostgreSQLProductionTaskRepository PostgreSQLProductionTaskRepositoryのコンストラクタ
+func NewPostgreSQLProductionTaskRepository(db *sql.DB) ProductionTaskRepository {
+	return &PostgreSQLProductionTaskRepository{
+		db: db,
+	}
+}
+
+const productionTaskColumns = `
+	id, tenant_id, order_id, garment_type, process, status, assigned_to,
+	standard_minutes, worked_seconds, started_at, resumed_at, completed_at,
+	inspection_id, created_at, updated_at
+`
+
+// Create 製造タスクを作成
+func (r *PostgreSQLProductionTaskRepository) Create(ctx context.Context, task *domain.ProductionTask) error {
+	query := `
+		INSERT INTO production_tasks (` + productionTaskColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		task.ID,
+		task.TenantID,
+		task.OrderID,
+		string(task.GarmentType),
+		string(task.Process),
+		string(task.Status),
+		nullIfEmpty(task.AssignedTo),
+		task.StandardMinutes,
+		task.WorkedSeconds,
+		task.StartedAt,
+		task.ResumedAt,
+		task.CompletedAt,
+		nullIfEmpty(task.InspectionID),
+		task.CreatedAt,
+		task.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create production task: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID 製造タスクIDで取得（テナントIDもチェック）
+func (r *PostgreSQLProductionTaskRepository) GetByID(ctx context.Context, taskID string, tenantID string) (*domain.ProductionTask, error) {
+	query := `SELECT ` + productionTaskColumns + ` FROM production_tasks WHERE id = $1 AND tenant_id = $2`
+
+	task, err := scanProductionTask(r.db.QueryRowContext(ctx, query, taskID, tenantID))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("production task not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get production task: %w", err)
+	}
+
+	return task, nil
+}
+
+// List 検索条件に一致する製造タスクを作成日時順に取得
+func (r *PostgreSQLProductionTaskRepository) List(ctx context.Context, filter *domain.ProductionTaskFilter) ([]*domain.ProductionTask, error) {
+	conditions := []string{"tenant_id = $1"}
+	args := []interface{}{filter.TenantID}
+
+	addCondition := func(format string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(format, len(args)))
+	}
+
+	if filter.OrderID != "" {
+		addCondition("order_id = $%d", filter.OrderID)
+	}
+	if filter.AssignedTo != "" {
+		addCondition("assigned_to = $%d", filter.AssignedTo)
+	}
+	if filter.Process != "" {
+		addCondition("process = $%d", string(filter.Process))
+	}
+	if len(filter.Statuses) > 0 {
+		placeholders := make([]string, 0, len(filter.Statuses))
+		for _, status := range filter.Statuses {
+			args = append(args, string(status))
+			placeholders = append(placeholders, fmt.Sprintf("$%d", len(args)))
+		}
+		conditions = append(conditions, "status IN ("+strings.Join(placeholders, ", ")+")")
+	}
+
+	query := `SELECT ` + productionTaskColumns + ` FROM production_tasks WHERE ` +
+		strings.Join(conditions, " AND ") + ` ORDER BY created_at ASC`
+
+	return r.queryProductionTasks(ctx, query, args...)
+}
+
+// GetCompletedBetween 期間内に完了した製造タスクを取得（fromを含み、toを含まない）
+func (r *PostgreSQLProductionTaskRepository) GetCompletedBetween(ctx context.Context, tenantID string, from, to time.Time) ([]*domain.ProductionTask, error) {
+	query := `
+		SELECT ` + productionTaskColumns + `
+		FROM production_tasks
+		WHERE tenant_id = $1 AND status = 'COMPLETED' AND completed_at >= $2 AND completed_at < $3
+		ORDER BY completed_at ASC
+	`
+
+	return r.queryProductionTasks(ctx, query, tenantID, from, to)
+}
+
+// UpdateAssignee 担当作業者を更新
+func (r *PostgreSQLProductionTaskRepository) UpdateAssignee(ctx context.Context, task *domain.ProductionTask) error {
+	query := `
+		UPDATE production_tasks SET
+			assigned_to = $3,
+			updated_at = $4
+		WHERE id = $1 AND tenant_id = $2
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		task.ID,
+		task.TenantID,
+		nullIfEmpty(task.AssignedTo),
+		task.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update production task: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("production task not found or tenant_id mismatch")
+	}
+
+	return nil
+}
+
+// queryProductionTasks 製造タスクの一覧を取得
+func (r *PostgreSQLProductionTaskRepository) queryProductionTasks(ctx context.Context, query string, args ...interface{}) ([]*domain.ProductionTask, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query production tasks: %w", err)
+	}
+	defer rows.Close()
+
+	tasks := make([]*domain.ProductionTask, 0)
+	for rows.Next() {
+		task, err := scanProductionTask(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan production task: %w", err)
+		}
+		tasks = append(tasks, task)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating production tasks: %w", err)
+	}
+
+	return tasks, nil
+}
+
+// scanProductionTask 1行分の製造タスクをスキャン
+func scanProductionTask(row rowScanner) (*domain.ProductionTask, error) {
+	var task domain.ProductionTask
+	var garmentType, process, status string
+	var assignedTo, inspectionID sql.NullString
+	var startedAt, resumedAt, completedAt sql.NullTime
+
+	err := row.Scan(
+		&task.ID,
+		&task.TenantID,
+		&task.OrderID,
+		&garmentType,
+		&process,
+		&status,
+		&assignedTo,
+		&task.StandardMinutes,
+		&task.WorkedSeconds,
+		&startedAt,
+		&resumedAt,
+		&completedAt,
+		&inspectionID,
+		&task.CreatedAt,
+		&task.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	task.GarmentType = domain.GarmentType(garmentType)
+	task.Process = domain.ProductionProcess(process)
+	task.Status = domain.ProductionTaskStatus(status)
+	task.AssignedTo = assignedTo.String
+	task.InspectionID = inspectionID.String
+	if startedAt.Valid {
+		task.StartedAt = &startedAt.Time
+	}
+	if resumedAt.Valid {
+		task.ResumedAt = &resumedAt.Time
+	}
+	if completedAt.Valid {
+		task.CompletedAt = &completedAt.Time
+	}
+
+	return &task, nil
+}
@@ -0,0 +1,286 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	_ "github.com/lib/pq"
+	"tailor-cloud/backend/internal/config/domain"
+)
+
+// QuoteRepository 見積書リポジトリインターフェース
+type QuoteRepository interface {
+	Create(ctx context.Context, quote *domain.Quote) error
+	CreateInTx(ctx context.Context, tx *sql.Tx, quote *domain.Quote) error
+	GetByID(ctx context.Context, quoteID string, tenantID string) (*domain.Quote, error)
+	GetByTenantID(ctx context.Context, tenantID string, status domain.QuoteStatus) ([]*domain.Quote, error)
+	GetVersions(ctx context.Context, quoteGroupID string, tenantID string) ([]*domain.Quote, error)
+	GetByCreatedPeriod(ctx context.Context, tenantID string, from, to time.Time) ([]*domain.Quote, error)
+	Update(ctx context.Context, quote *domain.Quote, fromStatus domain.QuoteStatus) error
+	UpdateInTx(ctx context.Context, tx *sql.Tx, quote *domain.Quote, fromStatus domain.QuoteStatus) error
+}
+
+// PostgreSQLQuoteRepository PostgreSQLを使った見積書リポジトリ実装
+type PostgreSQLQuoteRepository struct {
+	db *sql.DB
+}
+
+// NewPostgreSQLQuoteRepository PostgreSQLQuoteRepositoryのコンストラクタ
+func NewPostgreSQLQuoteRepository(db *sql.DB) QuoteRepository {
+	return &PostgreSQLQuoteRepository{
+		db: db,
+	}
+}
+
+const quoteColumns = `
+	id, tenant_id, quote_group_id, version, customer_id, fabric_id, fabric_length,
+	plan_type, status, lines, total_amount, tax_rate,
+	measurement_data, adjustments, description, delivery_date, notes,
+	valid_until, accepted_at, converted_order_id, created_at, updated_at, created_by
+`
+
+// Create 見積書を作成
+func (r *PostgreSQLQuoteRepository) Create(ctx context.Context, quote *domain.Quote) error {
+	return insertQuote(ctx, r.db, quote)
+}
+
+// CreateInTx トランザクション内で見積書を作成（改訂で旧バージョンの置き換えと同時にコミットする場合）
+func (r *PostgreSQLQuoteRepository) CreateInTx(ctx context.Context, tx *sql.Tx, quote *domain.Quote) error {
+	return insertQuote(ctx, tx, quote)
+}
+
+// insertQuote 見積書を1行挿入
+func insertQuote(ctx context.Context, exec sqlExecer, quote *domain.Quote) error {
+	query := `
+		INSERT INTO quotes (` + quoteColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23)
+	`
+
+	linesJSON, err := json.Marshal(quote.Lines)
+	if err != nil {
+		return fmt.Errorf("failed to marshal quote lines: %w", err)
+	}
+
+	var measurementDataJSON, adjustmentsJSON []byte
+	description := ""
+	if quote.Details != nil {
+		measurementDataJSON = quote.Details.MeasurementData
+		adjustmentsJSON = quote.Details.Adjustments
+		description = quote.Details.Description
+	}
+
+	_, err = exec.ExecContext(ctx, query,
+		quote.ID,
+		quote.TenantID,
+		quote.QuoteGroupID,
+		quote.Version,
+		quote.CustomerID,
+		quote.FabricID,
+		quote.FabricLength,
+		nullIfEmpty(string(quote.PlanType)),
+		string(quote.Status),
+		linesJSON,
+		quote.TotalAmount,
+		float64(quote.TaxRate),
+		measurementDataJSON,
+		adjustmentsJSON,
+		nullIfEmpty(description),
+		quote.DeliveryDate,
+		nullIfEmpty(quote.Notes),
+		quote.ValidUntil,
+		quote.AcceptedAt,
+		nullIfEmpty(quote.ConvertedOrderID),
+		quote.CreatedAt,
+		quote.UpdatedAt,
+		quote.CreatedBy,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create quote: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID 見積書IDで取得（テナントIDもチェック）
+func (r *PostgreSQLQuoteRepository) GetByID(ctx context.Context, quoteID string, tenantID string) (*domain.Quote, error) {
+	query := `SELECT ` + quoteColumns + ` FROM quotes WHERE id = $1 AND tenant_id = $2`
+
+	quote, err := scanQuote(r.db.QueryRowContext(ctx, query, quoteID, tenantID))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("quote not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quote: %w", err)
+	}
+
+	return quote, nil
+}
+
+// GetByTenantID テナントIDで見積書一覧を取得（statusが空の場合は全件）
+func (r *PostgreSQLQuoteRepository) GetByTenantID(ctx context.Context, tenantID string, status domain.QuoteStatus) ([]*domain.Quote, error) {
+	query := `SELECT ` + quoteColumns + ` FROM quotes WHERE tenant_id = $1`
+	args := []interface{}{tenantID}
+
+	if status != "" {
+		query += " AND status = $2"
+		args = append(args, string(status))
+	}
+	query += " ORDER BY created_at DESC"
+
+	return r.queryQuotes(ctx, query, args...)
+}
+
+// GetVersions 見積書の全バージョンを取得（バージョン昇順）
+func (r *PostgreSQLQuoteRepository) GetVersions(ctx context.Context, quoteGroupID string, tenantID string) ([]*domain.Quote, error) {
+	query := `SELECT ` + quoteColumns + ` FROM quotes WHERE quote_group_id = $1 AND tenant_id = $2 ORDER BY version ASC`
+	return r.queryQuotes(ctx, query, quoteGroupID, tenantID)
+}
+
+// GetByCreatedPeriod 期間内に作成された見積書を取得（from以上、to未満）
+func (r *PostgreSQLQuoteRepository) GetByCreatedPeriod(ctx context.Context, tenantID string, from, to time.Time) ([]*domain.Quote, error) {
+	query := `SELECT ` + quoteColumns + ` FROM quotes WHERE tenant_id = $1 AND created_at >= $2 AND created_at < $3 ORDER BY created_at ASC`
+	return r.queryQuotes(ctx, query, tenantID, from, to)
+}
+
+// Update 見積書のステータス・承諾日時・変換先注文を更新
+// 明細・金額はバージョンごとのスナップショットのため更新しない（改訂は新バージョンで行う）
+// 同時に別のリクエストでステータスが変更されないよう、変更前のステータス（fromStatus）のままの場合のみ更新する
+func (r *PostgreSQLQuoteRepository) Update(ctx context.Context, quote *domain.Quote, fromStatus domain.QuoteStatus) error {
+	return updateQuote(ctx, r.db, quote, fromStatus)
+}
+
+// UpdateInTx トランザクション内で見積書を更新（注文への変換・改訂と同時にコミットする場合）
+func (r *PostgreSQLQuoteRepository) UpdateInTx(ctx context.Context, tx *sql.Tx, quote *domain.Quote, fromStatus domain.QuoteStatus) error {
+	return updateQuote(ctx, tx, quote, fromStatus)
+}
+
+// updateQuote 変更前のステータスを条件に見積書を更新
+func updateQuote(ctx context.Context, exec sqlExecer, quote *domain.Quote, fromStatus domain.QuoteStatus) error {
+	query := `
+		UPDATE quotes SET
+			status = $3,
+			accepted_at = $4,
+			converted_order_id = $5,
+			updated_at = $6
+		WHERE id = $1 AND tenant_id = $2 AND status = $7
+	`
+
+	result, err := exec.ExecContext(ctx, query,
+		quote.ID,
+		quote.TenantID,
+		string(quote.Status),
+		quote.AcceptedAt,
+		nullIfEmpty(quote.ConvertedOrderID),
+		quote.UpdatedAt,
+		string(fromStatus),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update quote: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("invalid quote status: quote was modified by another request")
+	}
+
+	return nil
+}
+
+// queryQuotes 見積書一覧を取得する共通処理
+func (r *PostgreSQLQuoteRepository) queryQuotes(ctx context.Context, query string, args ...interface{}) ([]*domain.Quote, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query quotes: %w", err)
+	}
+	defer rows.Close()
+
+	quotes := make([]*domain.Quote, 0)
+	for rows.Next() {
+		quote, err := scanQuote(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan quote: %w", err)
+		}
+		quotes = append(quotes, quote)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating quotes: %w", err)
+	}
+
+	return quotes, nil
+}
+
+// scanQuote 1行分の見積書をスキャン
+func scanQuote(row rowScanner) (*domain.Quote, error) {
+	var quote domain.Quote
+	var status string
+	var planType, description, notes, convertedOrderID sql.NullString
+	var linesJSON []byte
+	var measurementDataJSON, adjustmentsJSON sql.NullString
+	var taxRate float64
+	var deliveryDate, acceptedAt sql.NullTime
+
+	err := row.Scan(
+		&quote.ID,
+		&quote.TenantID,
+		&quote.QuoteGroupID,
+		&quote.Version,
+		&quote.CustomerID,
+		&quote.FabricID,
+		&quote.FabricLength,
+		&planType,
+		&status,
+		&linesJSON,
+		&quote.TotalAmount,
+		&taxRate,
+		&measurementDataJSON,
+		&adjustmentsJSON,
+		&description,
+		&deliveryDate,
+		&notes,
+		&quote.ValidUntil,
+		&acceptedAt,
+		&convertedOrderID,
+		&quote.CreatedAt,
+		&quote.UpdatedAt,
+		&quote.CreatedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	quote.PlanType = domain.PlanType(planType.String)
+	quote.Status = domain.QuoteStatus(status)
+	quote.TaxRate = domain.TaxRate(taxRate)
+	quote.Notes = notes.String
+	quote.ConvertedOrderID = convertedOrderID.String
+	if deliveryDate.Valid {
+		quote.DeliveryDate = &deliveryDate.Time
+	}
+	if acceptedAt.Valid {
+		quote.AcceptedAt = &acceptedAt.Time
+	}
+
+	if len(linesJSON) > 0 {
+		if err := json.Unmarshal(linesJSON, &quote.Lines); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal quote lines: %w", err)
+		}
+	}
+
+	quote.Details = &domain.OrderDetails{Description: description.String}
+	if measurementDataJSON.Valid {
+		quote.Details.MeasurementData = json.RawMessage(measurementDataJSON.String)
+	}
+	if adjustmentsJSON.Valid {
+		quote.Details.Adjustments = json.RawMessage(adjustmentsJSON.String)
+	}
+
+	return &quote, nil
+}
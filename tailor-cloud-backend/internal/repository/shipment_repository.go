@@ -0,0 +1,215 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	_ "github.com/lib/pq"
+	"tailor-cloud/backend/internal/config/domain"
+)
+
+// ShipmentRepository 出荷記録リポジトリインターフェース
+// 出荷記録の作成・配達完了は注文ステータスと同じトランザクションで行うため、サービス側で実施する
+type ShipmentRepository interface {
+	GetByID(ctx context.Context, shipmentID string, tenantID string) (*domain.Shipment, error)
+	GetByOrderID(ctx context.Context, orderID string, tenantID string) (*domain.Shipment, error)
+	List(ctx context.Context, filter *domain.ShipmentFilter) ([]*domain.Shipment, error)
+	ListActive(ctx context.Context, limit int) ([]*domain.Shipment, error)
+	UpdateTracking(ctx context.Context, shipment *domain.Shipment) error
+}
+
+// PostgreSQLShipmentRepository PostgreSQLを使った出荷記録リポジトリ実装
+type PostgreSQLShipmentRepository struct {
+	db *sql.DB
+}
+
+// NewPostgreSQLShipmentRepository PostgreSQLShipmentRepositoryのコンストラクタ
+func NewPostgreSQLShipmentRepository(db *sql.DB) ShipmentRepository {
+	return &PostgreSQLShipmentRepository{
+		db: db,
+	}
+}
+
+const shipmentColumns = `
+	id, tenant_id, order_id, carrier, tracking_number, package_count, shipped_at,
+	status, recipient_name, recipient_postal_code, recipient_address, recipient_phone,
+	notes, delivered_at, last_tracked_at, tracking_detail, created_at, updated_at, created_by
+`
+
+// GetByID 出荷IDで取得（テナントIDもチェック）
+func (r *PostgreSQLShipmentRepository) GetByID(ctx context.Context, shipmentID string, tenantID string) (*domain.Shipment, error) {
+	query := `SELECT ` + shipmentColumns + ` FROM shipments WHERE id = $1 AND tenant_id = $2`
+
+	shipment, err := scanShipment(r.db.QueryRowContext(ctx, query, shipmentID, tenantID))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("shipment not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shipment: %w", err)
+	}
+
+	return shipment, nil
+}
+
+// GetByOrderID 注文の出荷記録を取得
+func (r *PostgreSQLShipmentRepository) GetByOrderID(ctx context.Context, orderID string, tenantID string) (*domain.Shipment, error) {
+	query := `SELECT ` + shipmentColumns + ` FROM shipments WHERE order_id = $1 AND tenant_id = $2`
+
+	shipment, err := scanShipment(r.db.QueryRowContext(ctx, query, orderID, tenantID))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("shipment not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shipment: %w", err)
+	}
+
+	return shipment, nil
+}
+
+// List 検索条件に一致する出荷記録を出荷日の新しい順に取得
+func (r *PostgreSQLShipmentRepository) List(ctx context.Context, filter *domain.ShipmentFilter) ([]*domain.Shipment, error) {
+	conditions := []string{"tenant_id = $1"}
+	args := []interface{}{filter.TenantID}
+
+	if filter.Carrier != "" {
+		args = append(args, string(filter.Carrier))
+		conditions = append(conditions, fmt.Sprintf("carrier = $%d", len(args)))
+	}
+	if len(filter.Statuses) > 0 {
+		placeholders := make([]string, 0, len(filter.Statuses))
+		for _, status := range filter.Statuses {
+			args = append(args, string(status))
+			placeholders = append(placeholders, fmt.Sprintf("$%d", len(args)))
+		}
+		conditions = append(conditions, "status IN ("+strings.Join(placeholders, ", ")+")")
+	}
+
+	query := `SELECT ` + shipmentColumns + ` FROM shipments WHERE ` +
+		strings.Join(conditions, " AND ") + ` ORDER BY shipped_at DESC, created_at DESC`
+
+	return r.queryShipments(ctx, query, args...)
+}
+
+// ListActive 配達完了前の出荷記録を全テナントから取得（追跡情報の取得が古い順）
+// 配送業者の追跡情報の定期取得に使用する
+func (r *PostgreSQLShipmentRepository) ListActive(ctx context.Context, limit int) ([]*domain.Shipment, error) {
+	query := `
+		SELECT ` + shipmentColumns + `
+		FROM shipments
+		WHERE status <> 'DELIVERED'
+		ORDER BY last_tracked_at ASC NULLS FIRST
+		LIMIT $1
+	`
+
+	return r.queryShipments(ctx, query, limit)
+}
+
+// UpdateTracking 追跡情報（配達完了前のステータス）を更新
+func (r *PostgreSQLShipmentRepository) UpdateTracking(ctx context.Context, shipment *domain.Shipment) error {
+	query := `
+		UPDATE shipments SET
+			status = $3,
+			last_tracked_at = $4,
+			tracking_detail = $5,
+			updated_at = $6
+		WHERE id = $1 AND tenant_id = $2 AND status <> 'DELIVERED'
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		shipment.ID,
+		shipment.TenantID,
+		string(shipment.Status),
+		shipment.LastTrackedAt,
+		nullIfEmpty(shipment.TrackingDetail),
+		shipment.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update shipment tracking: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("shipment not found or already delivered")
+	}
+
+	return nil
+}
+
+// queryShipments 出荷記録の一覧を取得
+func (r *PostgreSQLShipmentRepository) queryShipments(ctx context.Context, query string, args ...interface{}) ([]*domain.Shipment, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query shipments: %w", err)
+	}
+	defer rows.Close()
+
+	shipments := make([]*domain.Shipment, 0)
+	for rows.Next() {
+		shipment, err := scanShipment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan shipment: %w", err)
+		}
+		shipments = append(shipments, shipment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating shipments: %w", err)
+	}
+
+	return shipments, nil
+}
+
+// scanShipment 1行分の出荷記録をスキャン
+func scanShipment(row rowScanner) (*domain.Shipment, error) {
+	var shipment domain.Shipment
+	var carrier, status string
+	var postalCode, phone, notes, trackingDetail, createdBy sql.NullString
+	var deliveredAt, lastTrackedAt sql.NullTime
+
+	err := row.Scan(
+		&shipment.ID,
+		&shipment.TenantID,
+		&shipment.OrderID,
+		&carrier,
+		&shipment.TrackingNumber,
+		&shipment.PackageCount,
+		&shipment.ShippedAt,
+		&status,
+		&shipment.RecipientName,
+		&postalCode,
+		&shipment.RecipientAddress,
+		&phone,
+		&notes,
+		&deliveredAt,
+		&lastTrackedAt,
+		&trackingDetail,
+		&shipment.CreatedAt,
+		&shipment.UpdatedAt,
+		&createdBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	shipment.Carrier = domain.Carrier(carrier)
+	shipment.Status = domain.ShipmentStatus(status)
+	shipment.TrackingURL = shipment.Carrier.TrackingURL(shipment.TrackingNumber)
+	shipment.RecipientPostalCode = postalCode.String
+	shipment.RecipientPhone = phone.String
+	shipment.Notes = notes.String
+	shipment.TrackingDetail = trackingDetail.String
+	shipment.CreatedBy = createdBy.String
+	if deliveredAt.Valid {
+		shipment.DeliveredAt = &deliveredAt.Time
+	}
+	if lastTrackedAt.Valid {
+		shipment.LastTrackedAt = &lastTrackedAt.Time
+	}
+
+	return &shipment, nil
+}
@@ -34,6 +34,7 @@ func (r *PostgreSQLTenantRepository) GetByID(ctx context.Context, tenantID strin
 		SELECT 
 			id, type, legal_name, address,
 			invoice_registration_no, tax_rounding_method,
+			measurement_unit, fabric_unit,
 			created_at, updated_at
 		FROM tenants
 		WHERE id = $1
@@ -41,6 +42,7 @@ func (r *PostgreSQLTenantRepository) GetByID(ctx context.Context, tenantID strin
 	
 	var tenant domain.Tenant
 	var legalName, address, invoiceRegNo, taxRoundingMethod sql.NullString
+	var measurementUnit, fabricUnit sql.NullString
 	var typeStr string
 	
 	err := r.db.QueryRowContext(ctx, query, tenantID).Scan(
@@ -50,6 +52,8 @@ func (r *PostgreSQLTenantRepository) GetByID(ctx context.Context, tenantID strin
 		&address,
 		&invoiceRegNo,
 		&taxRoundingMethod,
+		&measurementUnit,
+		&fabricUnit,
 		&tenant.CreatedAt,
 		&tenant.UpdatedAt,
 	)
@@ -76,6 +80,14 @@ func (r *PostgreSQLTenantRepository) GetByID(ctx context.Context, tenantID strin
 	} else {
 		tenant.TaxRoundingMethod = domain.TaxRoundingMethodHalfUp // デフォルト
 	}
+	tenant.MeasurementUnit = domain.MeasurementUnitCM // デフォルト
+	if measurementUnit.Valid && domain.MeasurementUnit(measurementUnit.String).IsValid() {
+		tenant.MeasurementUnit = domain.MeasurementUnit(measurementUnit.String)
+	}
+	tenant.FabricUnit = domain.FabricUnitMeter // デフォルト
+	if fabricUnit.Valid && domain.FabricUnit(fabricUnit.String).IsValid() {
+		tenant.FabricUnit = domain.FabricUnit(fabricUnit.String)
+	}
 	
 	return &tenant, nil
 }
@@ -90,7 +102,9 @@ func (r *PostgreSQLTenantRepository) Update(ctx context.Context, tenant *domain.
 		    address = $3,
 		    invoice_registration_no = $4,
 		    tax_rounding_method = $5,
-		    updated_at = $6
+		    measurement_unit = $6,
+		    fabric_unit = $7,
+		    updated_at = $8
 		WHERE id = $1
 	`
 	
@@ -100,6 +114,8 @@ func (r *PostgreSQLTenantRepository) Update(ctx context.Context, tenant *domain.
 		tenant.Address,
 		tenant.InvoiceRegistrationNo,
 		tenant.TaxRoundingMethod,
+		tenant.MeasurementUnit,
+		tenant.FabricUnit,
 		tenant.UpdatedAt,
 	)
 	
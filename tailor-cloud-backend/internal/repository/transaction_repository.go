@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	_ "github.com/lib/pq"
+	"tailor-cloud/backend/internal/config/domain"
+)
+
+// TransactionRepository 入金取引リポジトリインターフェース
+type TransactionRepository interface {
+	Create(ctx context.Context, transaction *domain.Transaction) error
+	GetByOrderID(ctx context.Context, orderID string, tenantID string) ([]*domain.Transaction, error)
+	SumCompletedByOrderID(ctx context.Context, orderID string, tenantID string) (int64, error)
+	GetCompletedByPeriod(ctx context.Context, tenantID string, from, to time.Time) ([]*domain.Transaction, error)
+}
+
+// PostgreSQLTransactionRepository PostgreSQLを使った入金取引リポジトリ実装
+type PostgreSQLTransactionRepository struct {
+	db *sql.DB
+}
+
+// NewPostgreSQLTransactionRepository PostgreSQLTransactionRepositoryのコンストラクタ
+func NewPostgreSQLTransactionRepository(db *sql.DB) TransactionRepository {
+	return &PostgreSQLTransactionRepository{
+		db: db,
+	}
+}
+
+// Create 入金取引を作成
+func (r *PostgreSQLTransactionRepository) Create(ctx context.Context, transaction *domain.Transaction) error {
+	query := `
+		INSERT INTO transactions (
+			id, tenant_id, order_id, status, payment_method, amount, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		transaction.ID,
+		transaction.TenantID,
+		transaction.OrderID,
+		string(transaction.Status),
+		transaction.PaymentMethod,
+		transaction.Amount,
+		transaction.CreatedAt,
+		transaction.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetByOrderID 注文IDで入金取引一覧を取得
+func (r *PostgreSQLTransactionRepository) GetByOrderID(ctx context.Context, orderID string, tenantID string) ([]*domain.Transaction, error) {
+	query := `
+		SELECT id, tenant_id, order_id, status, payment_method, amount, created_at, updated_at
+		FROM transactions
+		WHERE order_id = $1 AND tenant_id = $2
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, orderID, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query transactions: %w", err)
+	}
+	defer rows.Close()
+
+	return scanTransactions(rows)
+}
+
+// GetCompletedByPeriod 期間内（from以上to未満）に入金済みとなった取引を取得
+func (r *PostgreSQLTransactionRepository) GetCompletedByPeriod(ctx context.Context, tenantID string, from, to time.Time) ([]*domain.Transaction, error) {
+	query := `
+		SELECT id, tenant_id, order_id, status, payment_method, amount, created_at, updated_at
+		FROM transactions
+		WHERE tenant_id = $1 AND status = $2 AND created_at >= $3 AND created_at < $4
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID, string(domain.TransactionStatusCompleted), from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query transactions: %w", err)
+	}
+	defer rows.Close()
+
+	return scanTransactions(rows)
+}
+
+// scanTransactions 入金取引の行をスキャン
+func scanTransactions(rows *sql.Rows) ([]*domain.Transaction, error) {
+	transactions := make([]*domain.Transaction, 0)
+	for rows.Next() {
+		var transaction domain.Transaction
+		var statusStr string
+
+		err := rows.Scan(
+			&transaction.ID,
+			&transaction.TenantID,
+			&transaction.OrderID,
+			&statusStr,
+			&transaction.PaymentMethod,
+			&transaction.Amount,
+			&transaction.CreatedAt,
+			&transaction.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan transaction: %w", err)
+		}
+
+		transaction.Status = domain.TransactionStatus(statusStr)
+		transactions = append(transactions, &transaction)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating transactions: %w", err)
+	}
+
+	return transactions, nil
+}
+
+// SumCompletedByOrderID 注文ごとの入金済み合計額を取得
+func (r *PostgreSQLTransactionRepository) SumCompletedByOrderID(ctx context.Context, orderID string, tenantID string) (int64, error) {
+	query := `
+		SELECT COALESCE(SUM(amount), 0)
+		FROM transactions
+		WHERE order_id = $1 AND tenant_id = $2 AND status = $3
+	`
+
+	var total int64
+	err := r.db.QueryRowContext(ctx, query, orderID, tenantID, string(domain.TransactionStatusCompleted)).Scan(&total)
+	if err != nil {
+		return 0, fmt.Errorf("failed to sum transactions: %w", err)
+	}
+
+	return total, nil
+}
@@ -0,0 +1,481 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"tailor-cloud/backend/internal/config/domain"
+	"tailor-cloud/backend/internal/repository"
+)
+
+// AccountingExportService 会計連携サービス
+// 請求・入金・成果報酬・下請代金から仕訳を作成し、会計ソフトのインポート形式で出力する
+type AccountingExportService struct {
+	accountMappingRepo repository.AccountMappingRepository
+	orderRepo          repository.OrderRepository
+	customerRepo       repository.CustomerRepository
+	transactionRepo    repository.TransactionRepository
+	commissionRepo     repository.CommissionRepository
+	ambassadorRepo     repository.AmbassadorRepository
+	payoutRepo         repository.PayoutRepository
+	bankAccountRepo    repository.BankAccountRepository
+	taxService         *TaxCalculationService
+}
+
+// NewAccountingExportService AccountingExportServiceのコンストラクタ
+func NewAccountingExportService(
+	accountMappingRepo repository.AccountMappingRepository,
+	orderRepo repository.OrderRepository,
+	customerRepo repository.CustomerRepository,
+	transactionRepo repository.TransactionRepository,
+	commissionRepo repository.CommissionRepository,
+	ambassadorRepo repository.AmbassadorRepository,
+	payoutRepo repository.PayoutRepository,
+	bankAccountRepo repository.BankAccountRepository,
+	taxService *TaxCalculationService,
+) *AccountingExportService {
+	return &AccountingExportService{
+		accountMappingRepo: accountMappingRepo,
+		orderRepo:          orderRepo,
+		customerRepo:       customerRepo,
+		transactionRepo:    transactionRepo,
+		commissionRepo:     commissionRepo,
+		ambassadorRepo:     ambassadorRepo,
+		payoutRepo:         payoutRepo,
+		bankAccountRepo:    bankAccountRepo,
+		taxService:         taxService,
+	}
+}
+
+// 成果報酬は税込の確定額として標準税率で仕訳する
+const commissionTaxRate = domain.TaxRateStandard
+
+// JournalPeriodRequest 仕訳作成リクエスト
+type JournalPeriodRequest struct {
+	TenantID string
+	From     time.Time // 期間開始日（含む）
+	To       time.Time // 期間終了日（含む）
+}
+
+// ExportJournalRequest 仕訳エクスポートリクエスト
+type ExportJournalRequest struct {
+	JournalPeriodRequest
+	Software domain.AccountingSoftware
+}
+
+// AccountMappingRequest 勘定科目マッピング更新リクエスト
+type AccountMappingRequest struct {
+	TenantID       string            `json:"-"`
+	AccountKey     domain.AccountKey `json:"account_key"`
+	AccountName    string            `json:"account_name"`
+	SubAccountName string            `json:"sub_account_name"`
+	AccountCode    string            `json:"account_code"`
+}
+
+// GetAccountMappings テナントの勘定科目マッピングを取得（未設定の科目は既定値で補完）
+func (s *AccountingExportService) GetAccountMappings(ctx context.Context, tenantID string) ([]*domain.AccountMapping, error) {
+	accounts, err := s.resolveAccounts(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	mappings := make([]*domain.AccountMapping, 0, len(accounts))
+	for _, key := range domain.AllAccountKeys() {
+		mappings = append(mappings, accounts[key])
+	}
+	return mappings, nil
+}
+
+// UpdateAccountMapping 勘定科目マッピングを登録・更新
+func (s *AccountingExportService) UpdateAccountMapping(ctx context.Context, req *AccountMappingRequest) (*domain.AccountMapping, error) {
+	if req.TenantID == "" {
+		return nil, fmt.Errorf("tenant_id is required")
+	}
+	if !req.AccountKey.IsValid() {
+		return nil, fmt.Errorf("invalid account_key: %s", req.AccountKey)
+	}
+	if req.AccountName == "" {
+		return nil, fmt.Errorf("account_name is required")
+	}
+
+	mapping := domain.NewAccountMapping(req.TenantID, req.AccountKey, req.AccountName)
+	mapping.SubAccountName = req.SubAccountName
+	mapping.AccountCode = req.AccountCode
+
+	if err := s.accountMappingRepo.Upsert(ctx, mapping); err != nil {
+		return nil, fmt.Errorf("failed to update account mapping: %w", err)
+	}
+
+	return mapping, nil
+}
+
+// ExportJournal 期間内の仕訳を会計ソフトのインポート形式で出力
+func (s *AccountingExportService) ExportJournal(ctx context.Context, req *ExportJournalRequest) ([]byte, string, error) {
+	if !req.Software.IsValid() {
+		return nil, "", fmt.Errorf("invalid software: %s", req.Software)
+	}
+
+	entries, err := s.BuildJournalEntries(ctx, &req.JournalPeriodRequest)
+	if err != nil {
+		return nil, "", err
+	}
+
+	data, err := FormatJournalCSV(req.Software, entries)
+	if err != nil {
+		return nil, "", err
+	}
+
+	filename := fmt.Sprintf("journal_%s_%s_%s.csv",
+		string(req.Software),
+		req.From.Format("20060102"),
+		req.To.Format("20060102"))
+	return data, filename, nil
+}
+
+// BuildJournalEntries 期間内の仕訳を作成
+// 1. 請求（売上計上）: 売掛金 / 売上高
+// 2. 入金: 普通預金 / 売掛金
+// 3. 成果報酬の計上: 支払手数料 / 未払金
+// 4. 下請代金の計上: 外注加工費 / 買掛金
+// 5. 振込完了した支払バッチ: 買掛金・未払金 / 普通預金
+func (s *AccountingExportService) BuildJournalEntries(ctx context.Context, req *JournalPeriodRequest) ([]*domain.JournalEntry, error) {
+	if req.TenantID == "" {
+		return nil, fmt.Errorf("tenant_id is required")
+	}
+	if req.From.IsZero() || req.To.IsZero() {
+		return nil, fmt.Errorf("from and to are required")
+	}
+	if req.To.Before(req.From) {
+		return nil, fmt.Errorf("invalid period: to must be on or after from")
+	}
+
+	accounts, err := s.resolveAccounts(ctx, req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := &journalBuilder{
+		accounts: accounts,
+		from:     req.From,
+		end:      req.To.AddDate(0, 0, 1), // 終了日を含めるため翌日0時未満
+	}
+
+	orders, err := searchAllOrders(ctx, s.orderRepo, journalOrderFilter(req.TenantID, builder.from, builder.end))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list orders: %w", err)
+	}
+	ordersByID := make(map[string]*domain.Order, len(orders))
+	for _, order := range orders {
+		ordersByID[order.ID] = order
+	}
+	names := &counterpartyNames{service: s, tenantID: req.TenantID}
+
+	if err := s.addOrderEntries(ctx, builder, orders, names); err != nil {
+		return nil, err
+	}
+	if err := s.addPaymentEntries(ctx, builder, ordersByID, names); err != nil {
+		return nil, err
+	}
+	if err := s.addCommissionEntries(ctx, builder, names); err != nil {
+		return nil, err
+	}
+	if err := s.addPayoutEntries(ctx, builder, req.TenantID); err != nil {
+		return nil, err
+	}
+
+	return builder.finish(), nil
+}
+
+// journalOrderFilter 仕訳の対象（請求書発行日または納期が期間内の注文）の検索条件
+func journalOrderFilter(tenantID string, from, end time.Time) *domain.OrderSearchFilter {
+	return &domain.OrderSearchFilter{
+		TenantID:   tenantID,
+		PostedFrom: &from,
+		PostedTo:   &end,
+		SortBy:     domain.OrderSortCreatedAt,
+		SortOrder:  domain.SortOrderAsc,
+		Limit:      100, // 1回の検索の最大件数
+	}
+}
+
+// addOrderEntries 請求（売上計上）と下請代金の計上仕訳を追加
+// 売上は請求書発行日（未記録の場合は納期）、下請代金は納品（受領）日で計上する
+func (s *AccountingExportService) addOrderEntries(ctx context.Context, b *journalBuilder, orders []*domain.Order, names *counterpartyNames) error {
+	for _, order := range orders {
+		if order.Status == domain.OrderStatusDraft || order.Status == domain.OrderStatusCancelled {
+			continue
+		}
+
+		invoiceDate := order.DeliveryDate
+		if order.InvoiceIssuedAt != nil {
+			invoiceDate = *order.InvoiceIssuedAt
+		}
+		invoiceInPeriod := b.inPeriod(invoiceDate)
+		payableInPeriod := b.inPeriod(order.DeliveryDate)
+		if !invoiceInPeriod && !payableInPeriod {
+			continue
+		}
+
+		taxResp, err := s.taxService.CalculateTaxForOrder(ctx, order)
+		if err != nil {
+			return fmt.Errorf("failed to calculate tax for order %s: %w", order.ID, err)
+		}
+		reference := domain.InvoiceReference(order.ID)
+
+		if invoiceInPeriod {
+			b.add(&domain.JournalEntry{
+				Date:         invoiceDate,
+				Debit:        b.line(domain.AccountKeyAccountsReceivable, taxResp.TaxIncludedAmount, domain.JournalTaxCategoryNotApplicable, 0),
+				Credit:       b.line(domain.AccountKeySales, taxResp.TaxIncludedAmount, domain.SalesTaxCategory(taxResp.TaxRate), taxResp.TaxAmount),
+				Counterparty: names.customer(ctx, order.CustomerID),
+				Description:  "売上計上 請求書No." + reference,
+				SourceType:   domain.JournalSourceInvoice,
+				SourceID:     order.ID,
+			})
+		}
+
+		if payableInPeriod {
+			b.add(&domain.JournalEntry{
+				Date:         order.DeliveryDate,
+				Debit:        b.line(domain.AccountKeyOutsourcingCost, taxResp.TaxIncludedAmount, domain.PurchaseTaxCategory(taxResp.TaxRate), taxResp.TaxAmount),
+				Credit:       b.line(domain.AccountKeyAccountsPayable, taxResp.TaxIncludedAmount, domain.JournalTaxCategoryNotApplicable, 0),
+				Counterparty: names.factory(ctx),
+				Description:  "下請代金計上 注文No." + reference,
+				SourceType:   domain.JournalSourceFactoryPayable,
+				SourceID:     order.ID,
+			})
+		}
+	}
+
+	return nil
+}
+
+// addPaymentEntries 顧客からの入金仕訳を追加（売掛金の消込）
+func (s *AccountingExportService) addPaymentEntries(ctx context.Context, b *journalBuilder, ordersByID map[string]*domain.Order, names *counterpartyNames) error {
+	transactions, err := s.transactionRepo.GetCompletedByPeriod(ctx, names.tenantID, b.from, b.end)
+	if err != nil {
+		return err
+	}
+
+	for _, transaction := range transactions {
+		// 計上期間外の注文への入金は注文を個別に取得して取引先を解決する
+		order, ok := ordersByID[transaction.OrderID]
+		if !ok {
+			if fetched, err := s.orderRepo.GetByID(ctx, transaction.OrderID); err == nil && fetched.TenantID == names.tenantID {
+				order = fetched
+			}
+			ordersByID[transaction.OrderID] = order
+		}
+		counterparty := ""
+		if order != nil {
+			counterparty = names.customer(ctx, order.CustomerID)
+		}
+
+		b.add(&domain.JournalEntry{
+			Date:         transaction.CreatedAt,
+			Debit:        b.line(domain.AccountKeyBankDeposit, transaction.Amount, domain.JournalTaxCategoryNotApplicable, 0),
+			Credit:       b.line(domain.AccountKeyAccountsReceivable, transaction.Amount, domain.JournalTaxCategoryNotApplicable, 0),
+			Counterparty: counterparty,
+			Description:  "入金 請求書No." + domain.InvoiceReference(transaction.OrderID),
+			SourceType:   domain.JournalSourcePayment,
+			SourceID:     transaction.ID,
+		})
+	}
+
+	return nil
+}
+
+// addCommissionEntries 確定した成果報酬の計上仕訳を追加
+func (s *AccountingExportService) addCommissionEntries(ctx context.Context, b *journalBuilder, names *counterpartyNames) error {
+	commissions, err := s.commissionRepo.GetByPeriod(ctx, names.tenantID, b.from, b.end)
+	if err != nil {
+		return fmt.Errorf("failed to list commissions: %w", err)
+	}
+
+	for _, commission := range commissions {
+		switch commission.Status {
+		case domain.CommissionStatusApproved, domain.CommissionStatusScheduled, domain.CommissionStatusPaid:
+		default:
+			continue
+		}
+		if !b.inPeriod(commission.CreatedAt) || commission.CommissionAmount <= 0 {
+			continue
+		}
+
+		taxAmount, err := s.taxService.CalculateTaxFromIncluded(ctx, names.tenantID, commission.CommissionAmount, commissionTaxRate)
+		if err != nil {
+			return fmt.Errorf("failed to calculate tax for commission %s: %w", commission.ID, err)
+		}
+
+		b.add(&domain.JournalEntry{
+			Date:         commission.CreatedAt,
+			Debit:        b.line(domain.AccountKeyCommissionExpense, commission.CommissionAmount, domain.PurchaseTaxCategory(commissionTaxRate), taxAmount),
+			Credit:       b.line(domain.AccountKeyAccruedExpenses, commission.CommissionAmount, domain.JournalTaxCategoryNotApplicable, 0),
+			Counterparty: names.ambassador(ctx, commission.AmbassadorID),
+			Description:  "成果報酬計上 注文No." + domain.InvoiceReference(commission.OrderID),
+			SourceType:   domain.JournalSourceCommission,
+			SourceID:     commission.ID,
+		})
+	}
+
+	return nil
+}
+
+// addPayoutEntries 振込完了した支払バッチの支払仕訳を追加（振込指定日で計上）
+func (s *AccountingExportService) addPayoutEntries(ctx context.Context, b *journalBuilder, tenantID string) error {
+	batches, err := s.payoutRepo.GetPaidBatchesByTransferDate(ctx, tenantID, b.from, b.end)
+	if err != nil {
+		return err
+	}
+
+	for _, batch := range batches {
+		for _, item := range batch.Items {
+			if item.Status != domain.PayoutItemStatusPaid {
+				continue
+			}
+
+			debitKey := domain.AccountKeyAccountsPayable
+			sourceType := domain.JournalSourceFactoryPayment
+			description := "下請代金支払"
+			if item.ItemType == domain.PayoutItemTypeCommission {
+				debitKey = domain.AccountKeyAccruedExpenses
+				sourceType = domain.JournalSourceCommissionPayment
+				description = "成果報酬支払"
+			}
+
+			b.add(&domain.JournalEntry{
+				Date:         batch.TransferDate,
+				Debit:        b.line(debitKey, item.Amount, domain.JournalTaxCategoryNotApplicable, 0),
+				Credit:       b.line(domain.AccountKeyBankDeposit, item.Amount, domain.JournalTaxCategoryNotApplicable, 0),
+				Counterparty: item.AccountHolderKana,
+				Description:  description,
+				SourceType:   sourceType,
+				SourceID:     item.ID,
+			})
+		}
+	}
+
+	return nil
+}
+
+// resolveAccounts テナントの勘定科目マッピングを取得し、未設定の科目を既定値で補完
+func (s *AccountingExportService) resolveAccounts(ctx context.Context, tenantID string) (map[domain.AccountKey]*domain.AccountMapping, error) {
+	accounts := make(map[domain.AccountKey]*domain.AccountMapping)
+	for _, key := range domain.AllAccountKeys() {
+		accounts[key] = domain.DefaultAccountMapping(tenantID, key)
+	}
+
+	mappings, err := s.accountMappingRepo.GetByTenantID(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	for _, mapping := range mappings {
+		if mapping.AccountKey.IsValid() {
+			accounts[mapping.AccountKey] = mapping
+		}
+	}
+
+	return accounts, nil
+}
+
+// journalBuilder 期間内の仕訳を組み立てる
+type journalBuilder struct {
+	accounts map[domain.AccountKey]*domain.AccountMapping
+	from     time.Time
+	end      time.Time // 期間終了（含まない）
+	entries  []*domain.JournalEntry
+}
+
+// inPeriod 日時が対象期間内か
+func (b *journalBuilder) inPeriod(t time.Time) bool {
+	return !t.Before(b.from) && t.Before(b.end)
+}
+
+// line 勘定科目マッピングを適用した仕訳行を作成
+func (b *journalBuilder) line(key domain.AccountKey, amount int64, category domain.JournalTaxCategory, taxAmount int64) domain.JournalLine {
+	account := b.accounts[key]
+	return domain.JournalLine{
+		AccountKey:     key,
+		AccountName:    account.AccountName,
+		SubAccountName: account.SubAccountName,
+		AccountCode:    account.AccountCode,
+		Amount:         amount,
+		TaxCategory:    category,
+		TaxAmount:      taxAmount,
+	}
+}
+
+// add 仕訳を追加
+func (b *journalBuilder) add(entry *domain.JournalEntry) {
+	b.entries = append(b.entries, entry)
+}
+
+// finish 日付順に並べ、伝票番号を採番
+func (b *journalBuilder) finish() []*domain.JournalEntry {
+	sort.SliceStable(b.entries, func(i, j int) bool {
+		return b.entries[i].Date.Before(b.entries[j].Date)
+	})
+	for i, entry := range b.entries {
+		entry.EntryNo = i + 1
+	}
+	if b.entries == nil {
+		return make([]*domain.JournalEntry, 0)
+	}
+	return b.entries
+}
+
+// counterpartyNames 取引先名の取得（エクスポート中はキャッシュする）
+type counterpartyNames struct {
+	service     *AccountingExportService
+	tenantID    string
+	customers   map[string]string
+	ambassadors map[string]string
+	factoryName *string
+}
+
+// customer 顧客名を取得
+func (n *counterpartyNames) customer(ctx context.Context, customerID string) string {
+	if n.customers == nil {
+		n.customers = make(map[string]string)
+	}
+	if name, ok := n.customers[customerID]; ok {
+		return name
+	}
+	name := ""
+	if customer, err := n.service.customerRepo.GetByID(ctx, customerID, n.tenantID); err == nil {
+		name = customer.Name
+	}
+	n.customers[customerID] = name
+	return name
+}
+
+// ambassador アンバサダー名を取得
+func (n *counterpartyNames) ambassador(ctx context.Context, ambassadorID string) string {
+	if n.ambassadors == nil {
+		n.ambassadors = make(map[string]string)
+	}
+	if name, ok := n.ambassadors[ambassadorID]; ok {
+		return name
+	}
+	name := ""
+	if ambassador, err := n.service.ambassadorRepo.GetByID(ctx, ambassadorID); err == nil {
+		name = ambassador.Name
+	}
+	n.ambassadors[ambassadorID] = name
+	return name
+}
+
+// factory 工場名を取得
+// 注文には工場の紐付けがないため、既定の工場口座の名義を使用する
+func (n *counterpartyNames) factory(ctx context.Context) string {
+	if n.factoryName == nil {
+		name := ""
+		if account, err := n.service.bankAccountRepo.GetDefault(ctx, n.tenantID, domain.BankAccountOwnerFactory); err == nil {
+			name = account.AccountHolderKana
+		}
+		n.factoryName = &name
+	}
+	return *n.factoryName
+}
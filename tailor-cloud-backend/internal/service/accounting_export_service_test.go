@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"tailor-cloud/backend/internal/config/domain"
+)
+
+// TestJournalOrderFilter 計上期間内の注文を最新100件に限らずすべて対象にするテスト
+func TestJournalOrderFilter(t *testing.T) {
+	from := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	repo := &fakeOrderSearchRepository{}
+	for i := 0; i < 130; i++ {
+		// 期間内に納品された古い注文
+		repo.orders = append(repo.orders, &domain.Order{
+			ID:           fmt.Sprintf("april-%03d", i),
+			TenantID:     "tenant-1",
+			Status:       domain.OrderStatusDelivered,
+			DeliveryDate: from.AddDate(0, 0, i%30),
+			CreatedAt:    from.AddDate(0, -2, 0).Add(time.Duration(i) * time.Minute),
+		})
+	}
+	for i := 0; i < 120; i++ {
+		// 期間後に作成・納品される新しい注文
+		repo.orders = append(repo.orders, &domain.Order{
+			ID:           fmt.Sprintf("june-%03d", i),
+			TenantID:     "tenant-1",
+			Status:       domain.OrderStatusSewing,
+			DeliveryDate: end.AddDate(0, 1, 0),
+			CreatedAt:    end.Add(time.Duration(i) * time.Minute),
+		})
+	}
+	invoiced := from.AddDate(0, 0, 10)
+	repo.orders = append(repo.orders,
+		&domain.Order{ID: "invoiced-in-period", TenantID: "tenant-1", Status: domain.OrderStatusPaid, DeliveryDate: from.AddDate(0, -1, 0), InvoiceIssuedAt: &invoiced},
+		&domain.Order{ID: "delivered-at-end", TenantID: "tenant-1", Status: domain.OrderStatusDelivered, DeliveryDate: end},
+	)
+
+	orders, err := searchAllOrders(context.Background(), repo, journalOrderFilter("tenant-1", from, end))
+	if err != nil {
+		t.Fatalf("searchAllOrders returned error: %v", err)
+	}
+	if len(orders) != 131 {
+		t.Fatalf("Expected 131 orders, got %d", len(orders))
+	}
+	for _, order := range orders {
+		if order.ID == "delivered-at-end" || order.DeliveryDate.After(end) {
+			t.Errorf("Unexpected order %s", order.ID)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/transform"
+
+	"tailor-cloud/backend/internal/config/domain"
+)
+
+// 会計ソフトごとの税区分表記
+var journalTaxCategoryLabels = map[domain.AccountingSoftware]map[domain.JournalTaxCategory]string{
+	domain.AccountingSoftwareFreee: {
+		domain.JournalTaxCategorySales10:          "課税売上10%",
+		domain.JournalTaxCategorySalesReduced8:    "課税売上8%（軽）",
+		domain.JournalTaxCategoryPurchase10:       "課対仕入10%",
+		domain.JournalTaxCategoryPurchaseReduced8: "課対仕入8%（軽）",
+		domain.JournalTaxCategoryNotApplicable:    "対象外",
+	},
+	domain.AccountingSoftwareMoneyForward: {
+		domain.JournalTaxCategorySales10:          "課税売上 10%",
+		domain.JournalTaxCategorySalesReduced8:    "課税売上 (軽)8%",
+		domain.JournalTaxCategoryPurchase10:       "課税仕入 10%",
+		domain.JournalTaxCategoryPurchaseReduced8: "課税仕入 (軽)8%",
+		domain.JournalTaxCategoryNotApplicable:    "対象外",
+	},
+	domain.AccountingSoftwareYayoi: {
+		domain.JournalTaxCategorySales10:          "課税売上込10%",
+		domain.JournalTaxCategorySalesReduced8:    "課税売上込軽減8%",
+		domain.JournalTaxCategoryPurchase10:       "課対仕入込10%",
+		domain.JournalTaxCategoryPurchaseReduced8: "課対仕入込軽減8%",
+		domain.JournalTaxCategoryNotApplicable:    "対象外",
+	},
+}
+
+// JournalTaxCategoryLabel 税区分を会計ソフトの表記に変換
+func JournalTaxCategoryLabel(software domain.AccountingSoftware, category domain.JournalTaxCategory) string {
+	if label, ok := journalTaxCategoryLabels[software][category]; ok {
+		return label
+	}
+	return journalTaxCategoryLabels[software][domain.JournalTaxCategoryNotApplicable]
+}
+
+// FormatJournalCSV 仕訳を会計ソフトのインポート形式CSVに変換
+// freee: UTF-8（ヘッダー行あり）
+// マネーフォワード: Shift_JIS（ヘッダー行あり）
+// 弥生: Shift_JIS（ヘッダー行なし、25項目固定）
+func FormatJournalCSV(software domain.AccountingSoftware, entries []*domain.JournalEntry) ([]byte, error) {
+	var records [][]string
+	sjis := true
+
+	switch software {
+	case domain.AccountingSoftwareFreee:
+		records = freeeJournalRecords(entries)
+		sjis = false
+	case domain.AccountingSoftwareMoneyForward:
+		records = moneyForwardJournalRecords(entries)
+	case domain.AccountingSoftwareYayoi:
+		records = yayoiJournalRecords(entries)
+	default:
+		return nil, fmt.Errorf("invalid accounting software: %s", software)
+	}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	writer.UseCRLF = true
+	if err := writer.WriteAll(records); err != nil {
+		return nil, fmt.Errorf("failed to write journal csv: %w", err)
+	}
+
+	if !sjis {
+		return buf.Bytes(), nil
+	}
+
+	// Shift_JISで表現できない文字は「?」に置き換える（取引先名の外字など）
+	encoded, _, err := transform.Bytes(encoding.ReplaceUnsupported(japanese.ShiftJIS.NewEncoder()), buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode journal csv: %w", err)
+	}
+	return encoded, nil
+}
+
+// freeeJournalRecords freee 仕訳帳インポート形式
+func freeeJournalRecords(entries []*domain.JournalEntry) [][]string {
+	software := domain.AccountingSoftwareFreee
+	records := [][]string{{
+		"日付", "伝票番号",
+		"借方勘定科目", "借方補助科目", "借方取引先", "借方税区分", "借方金額", "借方税額",
+		"貸方勘定科目", "貸方補助科目", "貸方取引先", "貸方税区分", "貸方金額", "貸方税額",
+		"摘要",
+	}}
+
+	for _, e := range entries {
+		records = append(records, []string{
+			e.Date.Format("2006/01/02"), strconv.Itoa(e.EntryNo),
+			e.Debit.AccountName, e.Debit.SubAccountName, e.Counterparty,
+			JournalTaxCategoryLabel(software, e.Debit.TaxCategory), formatAmount(e.Debit.Amount), formatAmount(e.Debit.TaxAmount),
+			e.Credit.AccountName, e.Credit.SubAccountName, e.Counterparty,
+			JournalTaxCategoryLabel(software, e.Credit.TaxCategory), formatAmount(e.Credit.Amount), formatAmount(e.Credit.TaxAmount),
+			e.Description,
+		})
+	}
+
+	return records
+}
+
+// moneyForwardJournalRecords マネーフォワード クラウド会計 仕訳帳インポート形式
+func moneyForwardJournalRecords(entries []*domain.JournalEntry) [][]string {
+	software := domain.AccountingSoftwareMoneyForward
+	records := [][]string{{
+		"取引No", "取引日",
+		"借方勘定科目", "借方補助科目", "借方部門", "借方取引先", "借方税区分", "借方インボイス", "借方金額(円)", "借方税額",
+		"貸方勘定科目", "貸方補助科目", "貸方部門", "貸方取引先", "貸方税区分", "貸方インボイス", "貸方金額(円)", "貸方税額",
+		"摘要",
+	}}
+
+	for _, e := range entries {
+		records = append(records, []string{
+			strconv.Itoa(e.EntryNo), e.Date.Format("2006/01/02"),
+			e.Debit.AccountName, e.Debit.SubAccountName, "", e.Counterparty,
+			JournalTaxCategoryLabel(software, e.Debit.TaxCategory), "", formatAmount(e.Debit.Amount), formatAmount(e.Debit.TaxAmount),
+			e.Credit.AccountName, e.Credit.SubAccountName, "", e.Counterparty,
+			JournalTaxCategoryLabel(software, e.Credit.TaxCategory), "", formatAmount(e.Credit.Amount), formatAmount(e.Credit.TaxAmount),
+			e.Description,
+		})
+	}
+
+	return records
+}
+
+// yayoiJournalRecords 弥生会計 仕訳日記帳インポート形式（25項目）
+// 取引先の列がないため摘要に含める
+func yayoiJournalRecords(entries []*domain.JournalEntry) [][]string {
+	software := domain.AccountingSoftwareYayoi
+	records := make([][]string, 0, len(entries))
+
+	for _, e := range entries {
+		description := e.Description
+		if e.Counterparty != "" {
+			description = e.Counterparty + " " + description
+		}
+
+		records = append(records, []string{
+			"2000",                      // 1. 識別フラグ（1行の仕訳）
+			strconv.Itoa(e.EntryNo),     // 2. 伝票No.
+			"",                          // 3. 決算
+			e.Date.Format("2006/01/02"), // 4. 取引日付
+			e.Debit.AccountName,         // 5. 借方勘定科目
+			e.Debit.SubAccountName,      // 6. 借方補助科目
+			"",                          // 7. 借方部門
+			JournalTaxCategoryLabel(software, e.Debit.TaxCategory), // 8. 借方税区分
+			formatAmount(e.Debit.Amount),                           // 9. 借方金額
+			formatAmount(e.Debit.TaxAmount),                        // 10. 借方税金額
+			e.Credit.AccountName,                                   // 11. 貸方勘定科目
+			e.Credit.SubAccountName,                                // 12. 貸方補助科目
+			"",                                                     // 13. 貸方部門
+			JournalTaxCategoryLabel(software, e.Credit.TaxCategory), // 14. 貸方税区分
+			formatAmount(e.Credit.Amount),                           // 15. 貸方金額
+			formatAmount(e.Credit.TaxAmount),                        // 16. 貸方税金額
+			description,                                             // 17. 摘要
+			"",                                                      // 18. 番号
+			"",                                                      // 19. 期日
+			"0",                                                     // 20. タイプ（仕訳データ）
+			"",                                                      // 21. 生成元
+			"",                                                      // 22. 仕訳メモ
+			"0",                                                     // 23. 付箋1
+			"0",                                                     // 24. 付箋2
+			"no",                                                    // 25. 調整
+		})
+	}
+
+	return records
+}
+
+// formatAmount 金額を整数文字列に変換
+func formatAmount(amount int64) string {
+	return strconv.FormatInt(amount, 10)
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/text/encoding/japanese"
+
+	"tailor-cloud/backend/internal/config/domain"
+)
+
+// testJournalEntries テスト用の売上計上仕訳
+func testJournalEntries() []*domain.JournalEntry {
+	builder := &journalBuilder{accounts: map[domain.AccountKey]*domain.AccountMapping{
+		domain.AccountKeyAccountsReceivable: domain.DefaultAccountMapping("tenant-1", domain.AccountKeyAccountsReceivable),
+		domain.AccountKeySales:              {AccountKey: domain.AccountKeySales, AccountName: "売上高", SubAccountName: "オーダースーツ"},
+	}}
+	builder.add(&domain.JournalEntry{
+		Date:         time.Date(2025, 4, 30, 0, 0, 0, 0, time.Local),
+		Debit:        builder.line(domain.AccountKeyAccountsReceivable, 110000, domain.JournalTaxCategoryNotApplicable, 0),
+		Credit:       builder.line(domain.AccountKeySales, 110000, domain.SalesTaxCategory(domain.TaxRateStandard), 10000),
+		Counterparty: "山田商事",
+		Description:  "売上計上 請求書No.1A2B3C4D",
+	})
+	return builder.finish()
+}
+
+// TestFormatJournalCSV_Freee freee形式（UTF-8・ヘッダーあり）のテスト
+func TestFormatJournalCSV_Freee(t *testing.T) {
+	data, err := FormatJournalCSV(domain.AccountingSoftwareFreee, testJournalEntries())
+	if err != nil {
+		t.Fatalf("FormatJournalCSV returned error: %v", err)
+	}
+
+	records, err := csv.NewReader(strings.NewReader(string(data))).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to read csv: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("Expected header + 1 row, got %d", len(records))
+	}
+
+	row := records[1]
+	if row[0] != "2025/04/30" || row[1] != "1" {
+		t.Errorf("Unexpected date/entry no: %v", row[:2])
+	}
+	if row[8] != "売上高" || row[9] != "オーダースーツ" {
+		t.Errorf("Expected account mapping applied to credit line, got %q / %q", row[8], row[9])
+	}
+	if row[11] != "課税売上10%" || row[13] != "10000" {
+		t.Errorf("Unexpected credit tax category/amount: %q / %q", row[11], row[13])
+	}
+	if row[4] != "山田商事" {
+		t.Errorf("Expected counterparty, got %q", row[4])
+	}
+}
+
+// TestFormatJournalCSV_Yayoi 弥生形式（Shift_JIS・25項目・ヘッダーなし）のテスト
+func TestFormatJournalCSV_Yayoi(t *testing.T) {
+	data, err := FormatJournalCSV(domain.AccountingSoftwareYayoi, testJournalEntries())
+	if err != nil {
+		t.Fatalf("FormatJournalCSV returned error: %v", err)
+	}
+
+	decoded, err := japanese.ShiftJIS.NewDecoder().String(string(data))
+	if err != nil {
+		t.Fatalf("output is not valid Shift_JIS: %v", err)
+	}
+	records, err := csv.NewReader(strings.NewReader(decoded)).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to read csv: %v", err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("Expected 1 row without header, got %d", len(records))
+	}
+
+	row := records[0]
+	if len(row) != 25 {
+		t.Fatalf("Expected 25 columns, got %d", len(row))
+	}
+	if row[0] != "2000" || row[4] != "売掛金" || row[13] != "課税売上込10%" {
+		t.Errorf("Unexpected row: %v", row)
+	}
+	if !strings.HasPrefix(row[16], "山田商事 ") {
+		t.Errorf("Expected counterparty in description, got %q", row[16])
+	}
+}
+
+// TestCalculateTaxFromIncluded 税込金額からの内税計算のテスト
+func TestCalculateTaxFromIncluded(t *testing.T) {
+	tests := []struct {
+		amount   int64
+		rate     domain.TaxRate
+		method   domain.TaxRoundingMethod
+		expected int64
+	}{
+		{110000, domain.TaxRateStandard, domain.TaxRoundingMethodHalfUp, 10000},
+		{10800, domain.TaxRateReduced, domain.TaxRoundingMethodHalfUp, 800},
+		{1000, domain.TaxRateStandard, domain.TaxRoundingMethodRoundDown, 90},
+		{1000, domain.TaxRateStandard, domain.TaxRoundingMethodRoundUp, 91},
+	}
+
+	for _, tt := range tests {
+		if got := domain.CalculateTaxFromIncluded(tt.amount, tt.rate, tt.method); got != tt.expected {
+			t.Errorf("CalculateTaxFromIncluded(%d, %v, %s) = %d, expected %d", tt.amount, tt.rate, tt.method, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,628 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"tailor-cloud/backend/internal/config/domain"
+	"tailor-cloud/backend/internal/repository"
+)
+
+// BankReconciliationService 入金消込サービス
+// 全銀協フォーマット/CSVの入出金明細を取り込み、未入金の請求（注文）と照合する
+type BankReconciliationService struct {
+	bankStatementRepo repository.BankStatementRepository
+	transactionRepo   repository.TransactionRepository
+	orderRepo         repository.OrderRepository
+	customerRepo      repository.CustomerRepository
+	taxService        *TaxCalculationService
+	db                *sql.DB // トランザクション管理用
+}
+
+// NewBankReconciliationService BankReconciliationServiceのコンストラクタ
+func NewBankReconciliationService(
+	bankStatementRepo repository.BankStatementRepository,
+	transactionRepo repository.TransactionRepository,
+	orderRepo repository.OrderRepository,
+	customerRepo repository.CustomerRepository,
+	taxService *TaxCalculationService,
+	db *sql.DB,
+) *BankReconciliationService {
+	return &BankReconciliationService{
+		bankStatementRepo: bankStatementRepo,
+		transactionRepo:   transactionRepo,
+		orderRepo:         orderRepo,
+		customerRepo:      customerRepo,
+		taxService:        taxService,
+		db:                db,
+	}
+}
+
+// 照合スコア
+const (
+	matchScoreInvoiceReference = 50 // 請求書番号が振込依頼人名/摘要に含まれる
+	matchScoreAmount           = 40 // 金額が未入金額と一致
+	matchScorePayerExact       = 30 // 振込依頼人名が顧客名と一致
+	matchScorePayerPartial     = 20 // 振込依頼人名が顧客名を含む（またはその逆）
+	matchScoreMinimum          = 40 // 候補として提示する最低スコア
+	matchMaxCandidates         = 3  // 1明細あたりの候補数上限
+)
+
+// ImportStatementRequest 入出金明細取込リクエスト
+type ImportStatementRequest struct {
+	TenantID string
+	Format   domain.BankStatementFormat
+	Data     []byte
+}
+
+// ImportStatementResponse 入出金明細取込レスポンス
+type ImportStatementResponse struct {
+	ImportID  string                    `json:"import_id"`
+	Imported  int                       `json:"imported"`
+	Skipped   int                       `json:"skipped"` // 取込済みのため除外した明細数（照会番号・勘定日・金額が一致）
+	Proposals []*ReconciliationProposal `json:"proposals"`
+}
+
+// ReconciliationProposal 入金1件に対する消込候補
+type ReconciliationProposal struct {
+	Entry      *domain.BankStatementEntry `json:"entry"`
+	Candidates []*MatchCandidate          `json:"candidates"`
+}
+
+// MatchCandidate 消込候補の請求（注文）
+type MatchCandidate struct {
+	OrderID          string   `json:"order_id"`
+	InvoiceReference string   `json:"invoice_reference"`
+	CustomerID       string   `json:"customer_id"`
+	CustomerName     string   `json:"customer_name"`
+	AmountDue        int64    `json:"amount_due"` // 未入金額（税込）
+	Score            int      `json:"score"`
+	Reasons          []string `json:"reasons"`
+}
+
+// openInvoice 未入金の請求（照合用）
+type openInvoice struct {
+	order        *domain.Order
+	customerName string
+	amountDue    int64
+}
+
+// ImportStatement 入出金明細を取り込み、消込候補を返す
+func (s *BankReconciliationService) ImportStatement(ctx context.Context, req *ImportStatementRequest) (*ImportStatementResponse, error) {
+	if req.TenantID == "" {
+		return nil, fmt.Errorf("tenant_id is required")
+	}
+	if len(req.Data) == 0 {
+		return nil, fmt.Errorf("statement file is required")
+	}
+
+	// 1. フォーマットに応じてパース
+	var lines []*ParsedStatementLine
+	var err error
+	switch req.Format {
+	case domain.BankStatementFormatZengin:
+		lines, err = ParseZenginStatement(req.Data)
+	case domain.BankStatementFormatCSV:
+		lines, err = ParseStatementCSV(req.Data)
+	default:
+		return nil, fmt.Errorf("invalid format: %s (must be ZENGIN or CSV)", req.Format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. 入出金明細として1トランザクションで保存（初期状態は未消込＝仮受）
+	// 取込済みの明細（照会番号・勘定日・金額が一致）は二重計上を防ぐためスキップする
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	importID := uuid.New().String()
+	entries := make([]*domain.BankStatementEntry, 0, len(lines))
+	skipped := 0
+	for _, line := range lines {
+		entry := domain.NewBankStatementEntry(req.TenantID, importID, req.Format)
+		entry.ReferenceNo = line.ReferenceNo
+		entry.TransactionDate = line.TransactionDate
+		entry.Amount = line.Amount
+		entry.PayerName = line.PayerName
+		entry.PayerCode = line.PayerCode
+		entry.RemitterBankName = line.RemitterBankName
+		entry.Description = line.Description
+
+		inserted, err := s.insertEntryInTx(ctx, tx, entry)
+		if err != nil {
+			return nil, fmt.Errorf("failed to save bank statement entry: %w", err)
+		}
+		if !inserted {
+			skipped++
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	// 3. 未入金の請求と照合
+	proposals, err := s.proposeMatches(ctx, req.TenantID, entries)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ImportStatementResponse{
+		ImportID:  importID,
+		Imported:  len(entries),
+		Skipped:   skipped,
+		Proposals: proposals,
+	}, nil
+}
+
+// ProposeMatches 未消込の入金（仮受）に対する消込候補を返す
+func (s *BankReconciliationService) ProposeMatches(ctx context.Context, tenantID string) ([]*ReconciliationProposal, error) {
+	entries, err := s.ListSuspense(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return s.proposeMatches(ctx, tenantID, entries)
+}
+
+// ListSuspense 未消込の入金（仮受）一覧を取得
+func (s *BankReconciliationService) ListSuspense(ctx context.Context, tenantID string) ([]*domain.BankStatementEntry, error) {
+	if tenantID == "" {
+		return nil, fmt.Errorf("tenant_id is required")
+	}
+
+	entries, err := s.bankStatementRepo.GetByTenantID(ctx, tenantID, domain.BankStatementEntryStatusUnmatched)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list suspense entries: %w", err)
+	}
+	return entries, nil
+}
+
+// ConfirmMatchRequest 消込確定リクエスト
+type ConfirmMatchRequest struct {
+	TenantID string `json:"-"`
+	EntryID  string `json:"entry_id"`
+	OrderID  string `json:"order_id"`
+	UserID   string `json:"-"`
+}
+
+// ConfirmMatchResponse 消込確定レスポンス
+type ConfirmMatchResponse struct {
+	Transaction *domain.Transaction        `json:"transaction"`
+	Entry       *domain.BankStatementEntry `json:"entry"`
+	AmountDue   int64                      `json:"amount_due"`   // 消込後の残額（0 = 完済）
+	OrderPaid   bool                       `json:"order_paid"`   // 入金累計が請求額に達したか（前払いの場合は注文ステータスは変わらない）
+	OrderStatus domain.OrderStatus         `json:"order_status"` // 消込後の注文ステータス
+}
+
+// ConfirmMatch 消込を確定し、入金Transactionを作成する
+// 入金累計が請求額（税込）に達した納品済みの注文はPaidステータスに変更する
+// 納品前（前払い）の注文は製造工程のステータスを維持する（完済は入金Transactionの累計で判定）
+func (s *BankReconciliationService) ConfirmMatch(ctx context.Context, req *ConfirmMatchRequest) (*ConfirmMatchResponse, error) {
+	if req.TenantID == "" {
+		return nil, fmt.Errorf("tenant_id is required")
+	}
+	if req.EntryID == "" {
+		return nil, fmt.Errorf("entry_id is required")
+	}
+	if req.OrderID == "" {
+		return nil, fmt.Errorf("order_id is required")
+	}
+
+	// 1. 注文を取得し、請求額（税込）を計算
+	order, err := s.orderRepo.GetByID(ctx, req.OrderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+	if order.TenantID != req.TenantID {
+		return nil, fmt.Errorf("unauthorized: tenant_id mismatch")
+	}
+	if !isOpenInvoiceStatus(order.Status) {
+		return nil, fmt.Errorf("invalid order status for reconciliation: %s", order.Status)
+	}
+	invoiceAmount, err := s.invoiceAmount(ctx, order)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := s.bankStatementRepo.GetByID(ctx, req.EntryID, req.TenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bank statement entry: %w", err)
+	}
+
+	// 2. トランザクション開始（二重消込防止のため明細行をロック）
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	currentStatus, err := s.lockEntryInTx(ctx, tx, entry.ID, req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if currentStatus != domain.BankStatementEntryStatusUnmatched {
+		return nil, fmt.Errorf("bank statement entry is already %s", currentStatus)
+	}
+
+	// 3. 入金Transactionを作成
+	transaction := domain.NewTransaction(req.TenantID, order.ID, domain.PaymentMethodBankTransfer, entry.Amount, domain.TransactionStatusCompleted)
+	if err := s.createTransactionInTx(ctx, tx, transaction); err != nil {
+		return nil, err
+	}
+
+	// 4. 明細を消込済みにする
+	entry.MarkMatched(order.ID, transaction.ID, req.UserID)
+	if err := s.markEntryMatchedInTx(ctx, tx, entry); err != nil {
+		return nil, err
+	}
+
+	// 5. 入金累計が請求額に達した注文に入金完了を記録し、納品済みであればPaidに変更
+	//    納品前の注文は納品時にPaidとなる（ShipmentService.markDelivered）
+	paidTotal, err := s.sumCompletedInTx(ctx, tx, order.ID, req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	amountDue := invoiceAmount - paidTotal
+	if amountDue < 0 {
+		amountDue = 0
+	}
+	orderPaid := amountDue == 0
+	orderStatus := order.Status
+	if orderPaid {
+		orderStatus, err = s.recordOrderPaidInTx(ctx, tx, order, time.Now())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// トランザクションコミット
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return &ConfirmMatchResponse{
+		Transaction: transaction,
+		Entry:       entry,
+		AmountDue:   amountDue,
+		OrderPaid:   orderPaid,
+		OrderStatus: orderStatus,
+	}, nil
+}
+
+// IgnoreEntry 入金を消込対象外にする（返金・利息等）
+func (s *BankReconciliationService) IgnoreEntry(ctx context.Context, entryID string, tenantID string) error {
+	entry, err := s.bankStatementRepo.GetByID(ctx, entryID, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to get bank statement entry: %w", err)
+	}
+	if entry.Status != domain.BankStatementEntryStatusUnmatched {
+		return fmt.Errorf("bank statement entry is already %s", entry.Status)
+	}
+
+	return s.bankStatementRepo.UpdateStatus(ctx, entryID, tenantID, domain.BankStatementEntryStatusIgnored)
+}
+
+// proposeMatches 入金ごとに消込候補を計算
+func (s *BankReconciliationService) proposeMatches(ctx context.Context, tenantID string, entries []*domain.BankStatementEntry) ([]*ReconciliationProposal, error) {
+	proposals := make([]*ReconciliationProposal, 0, len(entries))
+	if len(entries) == 0 {
+		return proposals, nil
+	}
+
+	invoices, err := s.listOpenInvoices(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		proposals = append(proposals, &ReconciliationProposal{
+			Entry:      entry,
+			Candidates: scoreCandidates(entry, invoices),
+		})
+	}
+
+	return proposals, nil
+}
+
+// scoreCandidates 金額・振込依頼人名・請求書番号で候補をスコアリング
+func scoreCandidates(entry *domain.BankStatementEntry, invoices []*openInvoice) []*MatchCandidate {
+	payer := NormalizeKana(entry.PayerName)
+	searchText := strings.ToUpper(strings.ReplaceAll(entry.PayerName+" "+entry.Description, " ", ""))
+
+	candidates := make([]*MatchCandidate, 0)
+	for _, inv := range invoices {
+		candidate := &MatchCandidate{
+			OrderID:          inv.order.ID,
+			InvoiceReference: domain.InvoiceReference(inv.order.ID),
+			CustomerID:       inv.order.CustomerID,
+			CustomerName:     inv.customerName,
+			AmountDue:        inv.amountDue,
+			Reasons:          make([]string, 0),
+		}
+
+		if strings.Contains(searchText, candidate.InvoiceReference) {
+			candidate.Score += matchScoreInvoiceReference
+			candidate.Reasons = append(candidate.Reasons, "invoice_reference")
+		}
+		if entry.Amount == inv.amountDue {
+			candidate.Score += matchScoreAmount
+			candidate.Reasons = append(candidate.Reasons, "amount")
+		}
+		// 振込依頼人名に付記された請求書番号は名義照合から除外
+		payerName := strings.ReplaceAll(payer, candidate.InvoiceReference, "")
+		customer := NormalizeKana(inv.customerName)
+		if payerName != "" && customer != "" {
+			if payerName == customer {
+				candidate.Score += matchScorePayerExact
+				candidate.Reasons = append(candidate.Reasons, "payer_name")
+			} else if strings.Contains(payerName, customer) || strings.Contains(customer, payerName) {
+				candidate.Score += matchScorePayerPartial
+				candidate.Reasons = append(candidate.Reasons, "payer_name_partial")
+			}
+		}
+
+		if candidate.Score >= matchScoreMinimum {
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Score > candidates[j].Score
+	})
+	if len(candidates) > matchMaxCandidates {
+		candidates = candidates[:matchMaxCandidates]
+	}
+
+	return candidates
+}
+
+// listOpenInvoices 未入金の請求（注文）一覧を取得
+func (s *BankReconciliationService) listOpenInvoices(ctx context.Context, tenantID string) ([]*openInvoice, error) {
+	orders, err := searchAllOrders(ctx, s.orderRepo, openInvoiceOrderFilter(tenantID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list orders: %w", err)
+	}
+
+	customerNames := make(map[string]string)
+	invoices := make([]*openInvoice, 0)
+	for _, order := range orders {
+		invoiceAmount, err := s.invoiceAmount(ctx, order)
+		if err != nil {
+			return nil, err
+		}
+		paid, err := s.transactionRepo.SumCompletedByOrderID(ctx, order.ID, tenantID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get paid amount: %w", err)
+		}
+		if paid >= invoiceAmount {
+			continue
+		}
+
+		name, ok := customerNames[order.CustomerID]
+		if !ok && order.CustomerID != "" {
+			if customer, err := s.customerRepo.GetByID(ctx, order.CustomerID, tenantID); err == nil {
+				name = customer.Name
+			}
+			customerNames[order.CustomerID] = name
+		}
+
+		invoices = append(invoices, &openInvoice{
+			order:        order,
+			customerName: name,
+			amountDue:    invoiceAmount - paid,
+		})
+	}
+
+	return invoices, nil
+}
+
+// invoiceAmount 注文の請求額（税込）を計算
+func (s *BankReconciliationService) invoiceAmount(ctx context.Context, order *domain.Order) (int64, error) {
+	taxResp, err := s.taxService.CalculateTaxForOrder(ctx, order)
+	if err != nil {
+		return 0, fmt.Errorf("failed to calculate tax: %w", err)
+	}
+	return taxResp.TaxIncludedAmount, nil
+}
+
+// openInvoiceStatuses 入金待ちとなり得る注文ステータス
+// 下書き・支払済み・キャンセルは消込対象外
+var openInvoiceStatuses = []domain.OrderStatus{
+	domain.OrderStatusConfirmed, domain.OrderStatusMaterialSecured, domain.OrderStatusCutting,
+	domain.OrderStatusSewing, domain.OrderStatusInspection, domain.OrderStatusShipped,
+	domain.OrderStatusDelivered,
+}
+
+// isOpenInvoiceStatus 入金待ちとなり得る注文ステータスか
+func isOpenInvoiceStatus(status domain.OrderStatus) bool {
+	for _, open := range openInvoiceStatuses {
+		if status == open {
+			return true
+		}
+	}
+	return false
+}
+
+// openInvoiceOrderFilter 入金待ちとなり得る注文の検索条件
+func openInvoiceOrderFilter(tenantID string) *domain.OrderSearchFilter {
+	return &domain.OrderSearchFilter{
+		TenantID:  tenantID,
+		Statuses:  openInvoiceStatuses,
+		SortBy:    domain.OrderSortCreatedAt,
+		SortOrder: domain.SortOrderAsc,
+		Limit:     100, // 1回の検索の最大件数
+	}
+}
+
+// insertEntryInTx トランザクション内で入出金明細を作成
+// 取込済みの明細と重複する場合は作成せずfalseを返す
+func (s *BankReconciliationService) insertEntryInTx(ctx context.Context, tx *sql.Tx, entry *domain.BankStatementEntry) (bool, error) {
+	query := `
+		INSERT INTO bank_statement_entries (
+			id, tenant_id, import_id, source_format, reference_no, transaction_date,
+			amount, payer_name, payer_code, remitter_bank_name, description,
+			status, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		ON CONFLICT DO NOTHING
+	`
+
+	result, err := tx.ExecContext(ctx, query,
+		entry.ID,
+		entry.TenantID,
+		entry.ImportID,
+		string(entry.SourceFormat),
+		entry.ReferenceNo,
+		entry.TransactionDate,
+		entry.Amount,
+		entry.PayerName,
+		entry.PayerCode,
+		entry.RemitterBankName,
+		entry.Description,
+		string(entry.Status),
+		entry.CreatedAt,
+		entry.UpdatedAt,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to create bank statement entry: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// lockEntryInTx 入出金明細を排他ロックし、現在のステータスを返す
+func (s *BankReconciliationService) lockEntryInTx(ctx context.Context, tx *sql.Tx, entryID string, tenantID string) (domain.BankStatementEntryStatus, error) {
+	query := `
+		SELECT status
+		FROM bank_statement_entries
+		WHERE id = $1 AND tenant_id = $2
+		FOR UPDATE
+	`
+
+	var status string
+	err := tx.QueryRowContext(ctx, query, entryID, tenantID).Scan(&status)
+	if err == sql.ErrNoRows {
+		return "", fmt.Errorf("bank statement entry not found")
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to lock bank statement entry: %w", err)
+	}
+
+	return domain.BankStatementEntryStatus(status), nil
+}
+
+// createTransactionInTx トランザクション内で入金Transactionを作成
+func (s *BankReconciliationService) createTransactionInTx(ctx context.Context, tx *sql.Tx, transaction *domain.Transaction) error {
+	query := `
+		INSERT INTO transactions (
+			id, tenant_id, order_id, status, payment_method, amount, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	_, err := tx.ExecContext(ctx, query,
+		transaction.ID,
+		transaction.TenantID,
+		transaction.OrderID,
+		string(transaction.Status),
+		transaction.PaymentMethod,
+		transaction.Amount,
+		transaction.CreatedAt,
+		transaction.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create transaction: %w", err)
+	}
+
+	return nil
+}
+
+// markEntryMatchedInTx トランザクション内で明細を消込済みに更新
+func (s *BankReconciliationService) markEntryMatchedInTx(ctx context.Context, tx *sql.Tx, entry *domain.BankStatementEntry) error {
+	query := `
+		UPDATE bank_statement_entries SET
+			status = $3,
+			matched_order_id = $4,
+			transaction_id = $5,
+			reconciled_by = $6,
+			reconciled_at = $7,
+			updated_at = $8
+		WHERE id = $1 AND tenant_id = $2
+	`
+
+	_, err := tx.ExecContext(ctx, query,
+		entry.ID,
+		entry.TenantID,
+		string(entry.Status),
+		entry.MatchedOrderID,
+		entry.TransactionID,
+		entry.ReconciledBy,
+		entry.ReconciledAt,
+		entry.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update bank statement entry: %w", err)
+	}
+
+	return nil
+}
+
+// sumCompletedInTx トランザクション内で注文の入金累計を取得
+func (s *BankReconciliationService) sumCompletedInTx(ctx context.Context, tx *sql.Tx, orderID string, tenantID string) (int64, error) {
+	query := `
+		SELECT COALESCE(SUM(amount), 0)
+		FROM transactions
+		WHERE order_id = $1 AND tenant_id = $2 AND status = $3
+	`
+
+	var total int64
+	if err := tx.QueryRowContext(ctx, query, orderID, tenantID, string(domain.TransactionStatusCompleted)).Scan(&total); err != nil {
+		return 0, fmt.Errorf("failed to sum transactions: %w", err)
+	}
+
+	return total, nil
+}
+
+// recordOrderPaidInTx 注文に入金完了日時を記録し、納品済みの注文はPaidに変更（製造中・発送中の注文はステータスを変更しない）
+// 変更後の注文ステータスを返す
+func (s *BankReconciliationService) recordOrderPaidInTx(ctx context.Context, tx *sql.Tx, order *domain.Order, paidAt time.Time) (domain.OrderStatus, error) {
+	query := `
+		UPDATE orders SET
+			paid_at = COALESCE(paid_at, $3),
+			status = CASE WHEN status = $4 THEN $5 ELSE status END,
+			updated_at = $3
+		WHERE id = $1 AND tenant_id = $2 AND status = $6
+		RETURNING status
+	`
+
+	var status string
+	err := tx.QueryRowContext(ctx, query,
+		order.ID,
+		order.TenantID,
+		paidAt,
+		string(domain.OrderStatusDelivered),
+		string(domain.OrderStatusPaid),
+		string(order.Status),
+	).Scan(&status)
+	if err == sql.ErrNoRows {
+		return "", fmt.Errorf("invalid order status: order was modified by another request")
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to record order payment: %w", err)
+	}
+
+	return domain.OrderStatus(status), nil
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"tailor-cloud/backend/internal/config/domain"
+)
+
+// TestOpenInvoiceOrderFilter 入金待ちの注文を最新100件に限らずすべて消込候補にするテスト
+func TestOpenInvoiceOrderFilter(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	repo := &fakeOrderSearchRepository{}
+	for i := 0; i < 110; i++ {
+		// 請求済みで入金待ちの古い注文
+		repo.orders = append(repo.orders, &domain.Order{
+			ID:        fmt.Sprintf("open-%03d", i),
+			TenantID:  "tenant-1",
+			Status:    domain.OrderStatusDelivered,
+			CreatedAt: base.Add(time.Duration(i) * time.Minute),
+		})
+	}
+	for i := 0; i < 150; i++ {
+		// 入金待ちにならない新しい注文
+		status := domain.OrderStatusPaid
+		if i%3 == 0 {
+			status = domain.OrderStatusDraft
+		} else if i%3 == 1 {
+			status = domain.OrderStatusCancelled
+		}
+		repo.orders = append(repo.orders, &domain.Order{
+			ID:        fmt.Sprintf("closed-%03d", i),
+			TenantID:  "tenant-1",
+			Status:    status,
+			CreatedAt: base.AddDate(0, 6, 0).Add(time.Duration(i) * time.Minute),
+		})
+	}
+
+	orders, err := searchAllOrders(context.Background(), repo, openInvoiceOrderFilter("tenant-1"))
+	if err != nil {
+		t.Fatalf("searchAllOrders returned error: %v", err)
+	}
+	if len(orders) != 110 {
+		t.Fatalf("Expected 110 orders, got %d", len(orders))
+	}
+	for _, order := range orders {
+		if !isOpenInvoiceStatus(order.Status) {
+			t.Errorf("Unexpected order %s with status %s", order.ID, order.Status)
+		}
+	}
+}
@@ -400,3 +400,140 @@ func (s *ComplianceService) GenerateAmendmentDocument(
 		AmendmentReason:  req.AmendmentReason,
 	}, nil
 }
+
+// GenerateCancellationNoticeRequest 発注取消通知書生成リクエスト
+type GenerateCancellationNoticeRequest struct {
+	Order        *domain.Order
+	Tenant       *domain.Tenant
+	Cancellation *domain.OrderCancellation
+}
+
+// GenerateCancellationNotice 発注取消通知書を生成
+// PDFの生成・アップロードまでを行い、文書レコードは呼び出し元が
+// 注文キャンセルと同一トランザクションで保存する
+func (s *ComplianceService) GenerateCancellationNotice(ctx context.Context, req *GenerateCancellationNoticeRequest) (*domain.ComplianceDocument, error) {
+	order := req.Order
+	
+	// 1. 親文書（最新の発注書）とバージョン番号を取得
+	var parentDocumentID *string
+	version := 0
+	if s.complianceDocRepo != nil {
+		if latestDoc, err := s.complianceDocRepo.GetLatestByOrderID(ctx, order.ID, order.TenantID); err == nil {
+			parentDocumentID = &latestDoc.ID
+		}
+		version, _ = s.complianceDocRepo.GetVersionByOrderID(ctx, order.ID, order.TenantID)
+	}
+	
+	// 2. PDF生成
+	pdfBytes, err := s.generateCancellationPDF(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate PDF: %w", err)
+	}
+	
+	// 3. PDFのハッシュ値を計算
+	hash := sha256.Sum256(pdfBytes)
+	hashHex := hex.EncodeToString(hash[:])
+	
+	// 4. Cloud Storageにアップロード
+	objectPath := fmt.Sprintf("compliance-docs/%s/%s_cancellation_v%d_%s.pdf",
+		order.TenantID,
+		order.ID,
+		version+1,
+		time.Now().Format("20060102_150405"))
+	
+	var docURL string
+	if s.storageService != nil && s.bucketName != "" {
+		uploadedURL, err := s.storageService.UploadPDF(ctx, s.bucketName, objectPath, pdfBytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to upload PDF to Cloud Storage: %w", err)
+		}
+		docURL = uploadedURL
+	} else {
+		docURL = fmt.Sprintf("gs://%s/%s", s.bucketName, objectPath)
+	}
+	
+	reason := req.Cancellation.Reason
+	now := time.Now()
+	return &domain.ComplianceDocument{
+		ID:               uuid.New().String(),
+		OrderID:          order.ID,
+		DocumentType:     domain.DocumentTypeCancellation,
+		ParentDocumentID: parentDocumentID,
+		PDFURL:           docURL,
+		PDFHash:          hashHex,
+		GeneratedAt:      now,
+		GeneratedBy:      req.Cancellation.CancelledBy,
+		AmendmentReason:  &reason,
+		Version:          version + 1,
+		TenantID:         order.TenantID,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}, nil
+}
+
+// generateCancellationPDF 発注取消通知書PDFを生成
+func (s *ComplianceService) generateCancellationPDF(req *GenerateCancellationNoticeRequest) ([]byte, error) {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetTitle("発注取消通知書", false)
+	pdf.SetAuthor("TailorCloud", false)
+	pdf.AddPage()
+	
+	if err := s.jpFontHelper.RegisterJPFonts(pdf); err != nil {
+		fmt.Printf("WARNING: Failed to register Japanese fonts: %v\n", err)
+	}
+	
+	// タイトル
+	s.jpFontHelper.SetJPFont(pdf, "B", 16)
+	pdf.CellFormat(190, 10, "発注取消通知書", "", 1, "C", false, 0, "")
+	pdf.Ln(5)
+	pdf.SetFont("Arial", "I", 10)
+	pdf.CellFormat(190, 6, "NOTICE OF ORDER CANCELLATION", "", 1, "C", false, 0, "")
+	pdf.Ln(10)
+	
+	row := func(label, value string) {
+		s.jpFontHelper.SetJPFont(pdf, "", 11)
+		pdf.CellFormat(60, 8, label, "", 0, "L", false, 0, "")
+		s.jpFontHelper.SetJPFont(pdf, "B", 11)
+		pdf.CellFormat(130, 8, value, "", 1, "L", false, 0, "")
+		pdf.Ln(2)
+	}
+	
+	order := req.Order
+	cancellation := req.Cancellation
+	
+	if req.Tenant != nil {
+		row("委託をする者の氏名", req.Tenant.LegalName)
+		if req.Tenant.Address != "" {
+			row("住所", req.Tenant.Address)
+		}
+	}
+	if order.Details != nil && order.Details.Description != "" {
+		row("給付の内容", order.Details.Description)
+	}
+	row("報酬の額（税抜）", fmt.Sprintf("¥%s", formatCurrency(cancellation.OrderAmount)))
+	row("取消日", cancellation.CancelledAt.Format("2006年01月02日"))
+	row("取消時点の工程", string(cancellation.Stage))
+	row("取消理由", cancellation.Reason)
+	row("キャンセル料（税抜）", fmt.Sprintf("¥%s（%d%%）", formatCurrency(cancellation.FeeAmount), cancellation.FeeRatePercent))
+	pdf.Ln(5)
+	
+	s.jpFontHelper.SetJPFont(pdf, "", 10)
+	pdf.MultiCell(190, 6, "上記の発注を取り消します。取消時点までに着手された作業に係る費用は、上記キャンセル料として精算します。", "", "L", false)
+	pdf.Ln(5)
+	
+	// 注文番号（Order.IDの末尾8文字）
+	pdf.SetFont("Arial", "", 9)
+	orderIDShort := order.ID
+	if len(orderIDShort) > 8 {
+		orderIDShort = orderIDShort[len(orderIDShort)-8:]
+	}
+	pdf.CellFormat(190, 8, fmt.Sprintf("発注番号: %s", orderIDShort), "", 1, "R", false, 0, "")
+	pdf.CellFormat(190, 8, fmt.Sprintf("発行日時: %s", time.Now().Format("2006年01月02日 15時04分05秒")), "", 1, "R", false, 0, "")
+	
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("failed to output PDF: %w", err)
+	}
+	
+	return buf.Bytes(), nil
+}
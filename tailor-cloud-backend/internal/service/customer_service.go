@@ -24,10 +24,11 @@ func NewCustomerService(customerRepo repository.CustomerRepository, orderRepo re
 
 // CreateCustomerRequest 顧客作成リクエスト
 type CreateCustomerRequest struct {
-	TenantID string
-	Name     string
-	Email    string
-	Phone    string
+	TenantID        string
+	Name            string
+	Email           string
+	Phone           string
+	MeasurementUnit domain.MeasurementUnit // 採寸値の表示単位（空の場合はテナントの設定）
 }
 
 // CreateCustomer 顧客を作成
@@ -38,12 +39,16 @@ func (s *CustomerService) CreateCustomer(ctx context.Context, req *CreateCustome
 	if req.Name == "" {
 		return nil, fmt.Errorf("name is required")
 	}
+	if req.MeasurementUnit != "" && !req.MeasurementUnit.IsValid() {
+		return nil, fmt.Errorf("invalid measurement_unit: %s", req.MeasurementUnit)
+	}
 	
 	customer := &domain.Customer{
-		TenantID: req.TenantID,
-		Name:     req.Name,
-		Email:    req.Email,
-		Phone:    req.Phone,
+		TenantID:        req.TenantID,
+		Name:            req.Name,
+		Email:           req.Email,
+		Phone:           req.Phone,
+		MeasurementUnit: req.MeasurementUnit,
 	}
 	
 	if err := s.customerRepo.Create(ctx, customer); err != nil {
@@ -96,6 +101,7 @@ type UpdateCustomerRequest struct {
 	Name     string
 	Email    string
 	Phone    string
+	MeasurementUnit domain.MeasurementUnit // 採寸値の表示単位（空の場合はテナントの設定）
 }
 
 // UpdateCustomer 顧客を更新
@@ -106,6 +112,9 @@ func (s *CustomerService) UpdateCustomer(ctx context.Context, req *UpdateCustome
 	if req.TenantID == "" {
 		return nil, fmt.Errorf("tenant_id is required")
 	}
+	if req.MeasurementUnit != "" && !req.MeasurementUnit.IsValid() {
+		return nil, fmt.Errorf("invalid measurement_unit: %s", req.MeasurementUnit)
+	}
 	
 	// 既存の顧客を取得
 	customer, err := s.customerRepo.GetByID(ctx, req.CustomerID, req.TenantID)
@@ -117,6 +126,7 @@ func (s *CustomerService) UpdateCustomer(ctx context.Context, req *UpdateCustome
 	customer.Name = req.Name
 	customer.Email = req.Email
 	customer.Phone = req.Phone
+	customer.MeasurementUnit = req.MeasurementUnit
 	
 	if err := s.customerRepo.Update(ctx, customer); err != nil {
 		return nil, fmt.Errorf("failed to update customer: %w", err)
@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
 
 	"tailor-cloud/backend/internal/config/domain"
 	"tailor-cloud/backend/internal/repository"
@@ -63,6 +65,165 @@ func (s *DiagnosisService) CreateDiagnosis(ctx context.Context, req *CreateDiagn
 	return diagnosis, nil
 }
 
+// GetQuestionBank 診断の設問を取得（version が空の場合は現行バージョン）
+func (s *DiagnosisService) GetQuestionBank(version string) (*domain.DiagnosisQuestionBank, error) {
+	return domain.DiagnosisQuestionBankFor(version)
+}
+
+// ScoreDiagnosisRequest 回答から診断を採点・作成するリクエスト
+type ScoreDiagnosisRequest struct {
+	UserID           string
+	TenantID         string
+	AlgorithmVersion string // 空の場合は現行バージョン
+	Answers          []domain.DiagnosisAnswer
+}
+
+// ScoreDiagnosis 回答をサーバー側で採点し、アーキタイプ・プランと採点結果を保存
+func (s *DiagnosisService) ScoreDiagnosis(ctx context.Context, req *ScoreDiagnosisRequest) (*domain.Diagnosis, error) {
+	if req.UserID == "" {
+		return nil, fmt.Errorf("user_id is required")
+	}
+	if req.TenantID == "" {
+		return nil, fmt.Errorf("tenant_id is required")
+	}
+
+	bank, err := domain.DiagnosisQuestionBankFor(req.AlgorithmVersion)
+	if err != nil {
+		return nil, err
+	}
+	result, err := bank.Score(req.Answers, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal diagnosis result: %w", err)
+	}
+
+	diagnosis := domain.NewDiagnosis(
+		req.UserID,
+		req.TenantID,
+		result.Archetype,
+		result.PlanType,
+		resultJSON,
+	)
+
+	if err := s.diagnosisRepo.Create(ctx, diagnosis); err != nil {
+		return nil, fmt.Errorf("failed to create diagnosis: %w", err)
+	}
+
+	return diagnosis, nil
+}
+
+// DiagnosisRescoreResult 再採点の結果
+type DiagnosisRescoreResult struct {
+	Diagnosis                *domain.Diagnosis `json:"diagnosis"`
+	PreviousArchetype        domain.Archetype  `json:"previous_archetype"`
+	PreviousPlanType         domain.PlanType   `json:"previous_plan_type"`
+	PreviousAlgorithmVersion string            `json:"previous_algorithm_version,omitempty"`
+	Changed                  bool              `json:"changed"` // アーキタイプまたはプランが変わったか
+}
+
+// RescoreDiagnosis 保存された回答を指定バージョン（空の場合は現行）で再採点して更新
+func (s *DiagnosisService) RescoreDiagnosis(ctx context.Context, diagnosisID string, tenantID string, version string) (*DiagnosisRescoreResult, error) {
+	bank, err := domain.DiagnosisQuestionBankFor(version)
+	if err != nil {
+		return nil, err
+	}
+
+	diagnosis, err := s.GetDiagnosis(ctx, diagnosisID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.rescore(ctx, diagnosis, bank)
+}
+
+// DiagnosisRescoreSummary テナント全体の再採点の集計
+type DiagnosisRescoreSummary struct {
+	AlgorithmVersion string `json:"algorithm_version"`
+	Rescored         int    `json:"rescored"` // 再採点した診断の件数
+	Changed          int    `json:"changed"`  // アーキタイプまたはプランが変わった件数
+	Skipped          int    `json:"skipped"`  // 回答が保存されていない・回答が新しい設問に合わない診断の件数
+}
+
+// RescoreTenantDiagnoses テナントの診断をすべて指定バージョン（空の場合は現行）で再採点
+func (s *DiagnosisService) RescoreTenantDiagnoses(ctx context.Context, tenantID string, version string) (*DiagnosisRescoreSummary, error) {
+	if tenantID == "" {
+		return nil, fmt.Errorf("tenant_id is required")
+	}
+	bank, err := domain.DiagnosisQuestionBankFor(version)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &DiagnosisRescoreSummary{AlgorithmVersion: bank.Version}
+	const pageSize = 100
+	for offset := 0; ; offset += pageSize {
+		diagnoses, err := s.diagnosisRepo.GetByTenantID(ctx, tenantID, pageSize, offset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get diagnoses by tenant: %w", err)
+		}
+		for _, diagnosis := range diagnoses {
+			result, err := s.rescore(ctx, diagnosis, bank)
+			if err != nil {
+				if strings.Contains(err.Error(), "invalid") {
+					summary.Skipped++
+					continue
+				}
+				return nil, err
+			}
+			summary.Rescored++
+			if result.Changed {
+				summary.Changed++
+			}
+		}
+		if len(diagnoses) < pageSize {
+			break
+		}
+	}
+
+	return summary, nil
+}
+
+// rescore 診断に保存された回答を再採点して更新
+func (s *DiagnosisService) rescore(ctx context.Context, diagnosis *domain.Diagnosis, bank *domain.DiagnosisQuestionBank) (*DiagnosisRescoreResult, error) {
+	answers, err := domain.DiagnosisAnswers(diagnosis.DiagnosisResult)
+	if err != nil {
+		return nil, err
+	}
+	result, err := bank.Score(answers, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal diagnosis result: %w", err)
+	}
+
+	var previous struct {
+		AlgorithmVersion string `json:"algorithm_version"`
+	}
+	_ = json.Unmarshal(diagnosis.DiagnosisResult, &previous)
+
+	rescored := &DiagnosisRescoreResult{
+		PreviousArchetype:        diagnosis.Archetype,
+		PreviousPlanType:         diagnosis.PlanType,
+		PreviousAlgorithmVersion: previous.AlgorithmVersion,
+		Changed:                  diagnosis.Archetype != result.Archetype || diagnosis.PlanType != result.PlanType,
+	}
+
+	diagnosis.Archetype = result.Archetype
+	diagnosis.PlanType = result.PlanType
+	diagnosis.DiagnosisResult = resultJSON
+	if err := s.diagnosisRepo.Update(ctx, diagnosis); err != nil {
+		return nil, fmt.Errorf("failed to update diagnosis: %w", err)
+	}
+	rescored.Diagnosis = diagnosis
+
+	return rescored, nil
+}
+
 // GetDiagnosis 診断を取得
 func (s *DiagnosisService) GetDiagnosis(ctx context.Context, diagnosisID string, tenantID string) (*domain.Diagnosis, error) {
 	if diagnosisID == "" {
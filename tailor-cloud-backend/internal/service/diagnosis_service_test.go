@@ -0,0 +1,148 @@
+package service
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"tailor-cloud/backend/internal/config/domain"
+)
+
+// diagnosisAnswers 設問IDと選択肢の組から回答を作成
+func diagnosisAnswers(pairs ...string) []domain.DiagnosisAnswer {
+	answers := make([]domain.DiagnosisAnswer, 0, len(pairs)/2)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		answers = append(answers, domain.DiagnosisAnswer{QuestionID: pairs[i], Option: pairs[i+1]})
+	}
+	return answers
+}
+
+// TestDiagnosisScore 回答の採点によるアーキタイプ・プランの決定のテスト
+func TestDiagnosisScore(t *testing.T) {
+	bank, err := domain.DiagnosisQuestionBankFor("")
+	if err != nil {
+		t.Fatalf("DiagnosisQuestionBankFor returned error: %v", err)
+	}
+	if bank.Version != domain.CurrentDiagnosisAlgorithmVersion {
+		t.Errorf("version = %s, want %s", bank.Version, domain.CurrentDiagnosisAlgorithmVersion)
+	}
+
+	scoredAt := time.Date(2026, 10, 1, 10, 0, 0, 0, time.UTC)
+	result, err := bank.Score(diagnosisAnswers(
+		"occasion", "business",
+		"silhouette", "structured",
+		"color", "navy_grey",
+		"pattern", "stripe",
+		"movement", "desk",
+		"impression", "trust",
+		"fabric_priority", "mill",
+		"budget", "over_150k",
+		"frequency", "daily",
+	), scoredAt)
+	if err != nil {
+		t.Fatalf("Score returned error: %v", err)
+	}
+	if result.Archetype != domain.ArchetypeClassic || result.PlanType != domain.PlanTypeAuthentic {
+		t.Errorf("result = %s / %s, want Classic / Authentic", result.Archetype, result.PlanType)
+	}
+	if result.PlanScore != 3 || result.Scores["classic"] != 82.8 || result.AlgorithmVersion != "suit-mbti-v1" {
+		t.Errorf("plan score = %v, scores = %v", result.PlanScore, result.Scores)
+	}
+	total := 0.0
+	for _, share := range result.Scores {
+		total += share
+	}
+	if total < 99.8 || total > 100.2 {
+		t.Errorf("total share = %v, want about 100", total)
+	}
+
+	// 同点の場合はアーキタイプの一覧の順（クラシック優先）
+	result, err = bank.Score(diagnosisAnswers(
+		"occasion", "creative",
+		"silhouette", "structured",
+		"color", "monotone",
+		"pattern", "stripe",
+		"movement", "walking",
+		"impression", "energetic",
+		"fabric_priority", "price",
+		"budget", "under_80k",
+		"frequency", "weekly",
+	), scoredAt)
+	if err != nil {
+		t.Fatalf("Score returned error: %v", err)
+	}
+	if result.Archetype != domain.ArchetypeClassic || result.PlanType != domain.PlanTypeBestValue {
+		t.Errorf("tie result = %s / %s, want Classic / Best Value", result.Archetype, result.PlanType)
+	}
+}
+
+// TestDiagnosisScoreInvalidAnswers 不正な回答のエラーのテスト
+func TestDiagnosisScoreInvalidAnswers(t *testing.T) {
+	bank, _ := domain.DiagnosisQuestionBankFor(domain.CurrentDiagnosisAlgorithmVersion)
+	complete := []string{
+		"occasion", "business", "silhouette", "slim", "color", "monotone",
+		"pattern", "solid", "movement", "desk", "impression", "innovative",
+		"fabric_priority", "hand_feel", "budget", "80k_150k", "frequency", "weekly",
+	}
+
+	tests := []struct {
+		name    string
+		answers []domain.DiagnosisAnswer
+		want    string
+	}{
+		{"missing", diagnosisAnswers(complete[:16]...), "frequency is required"},
+		{"duplicate", diagnosisAnswers(append(complete, "color", "earth")...), "duplicate answer for color"},
+		{"unknown question", diagnosisAnswers(append(complete, "shoes", "loafer")...), "unknown question shoes"},
+		{"unknown option", diagnosisAnswers(append(complete[:16], "frequency", "never")...), "unknown option never for frequency"},
+	}
+	for _, tt := range tests {
+		_, err := bank.Score(tt.answers, time.Now())
+		if err == nil || !strings.Contains(err.Error(), tt.want) || !strings.HasPrefix(err.Error(), "invalid answers") {
+			t.Errorf("%s: err = %v, want %q", tt.name, err, tt.want)
+		}
+	}
+
+	if _, err := domain.DiagnosisQuestionBankFor("suit-mbti-v0"); err == nil {
+		t.Error("expected error for unknown algorithm version")
+	}
+}
+
+// TestDiagnosisAnswersRoundTrip 保存した採点結果からの回答の取り出しのテスト
+func TestDiagnosisAnswersRoundTrip(t *testing.T) {
+	bank, _ := domain.DiagnosisQuestionBankFor("")
+	answers := diagnosisAnswers(
+		"occasion", "weekend", "silhouette", "relaxed", "color", "earth",
+		"pattern", "check", "movement", "travel", "impression", "approachable",
+		"fabric_priority", "durability", "budget", "80k_150k", "frequency", "occasionally",
+	)
+	result, err := bank.Score(answers, time.Now())
+	if err != nil {
+		t.Fatalf("Score returned error: %v", err)
+	}
+	if result.Archetype != domain.ArchetypeCasual {
+		t.Errorf("archetype = %s, want Casual", result.Archetype)
+	}
+	stored, _ := json.Marshal(result)
+
+	restored, err := domain.DiagnosisAnswers(stored)
+	if err != nil {
+		t.Fatalf("DiagnosisAnswers returned error: %v", err)
+	}
+	if len(restored) != len(answers) || restored[3] != answers[3] {
+		t.Errorf("restored = %v", restored)
+	}
+
+	// クライアント側で採点した診断（回答なし）は再採点できない
+	for _, legacy := range []string{`{"scores":{"classic":80}}`, `null`, ``} {
+		if _, err := domain.DiagnosisAnswers(json.RawMessage(legacy)); err == nil || !strings.Contains(err.Error(), "no answers recorded") {
+			t.Errorf("DiagnosisAnswers(%q) err = %v", legacy, err)
+		}
+	}
+
+	// 設問の取得APIには重みを含めない
+	questions, _ := json.Marshal(bank)
+	if strings.Contains(string(questions), "weight") || strings.Contains(string(questions), "threshold") {
+		t.Errorf("question bank JSON exposes weights: %s", questions)
+	}
+}
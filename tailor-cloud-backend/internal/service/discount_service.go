@@ -0,0 +1,452 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"tailor-cloud/backend/internal/config/domain"
+	"tailor-cloud/backend/internal/repository"
+)
+
+// DiscountService 割引・クーポン・キャンペーンサービス
+// 割引は注文金額の直接変更ではなく、注文の割引明細（OrderDiscount）として適用する
+type DiscountService struct {
+	campaignRepo repository.CampaignRepository
+	customerRepo repository.CustomerRepository
+}
+
+// NewDiscountService DiscountServiceのコンストラクタ
+func NewDiscountService(campaignRepo repository.CampaignRepository, customerRepo repository.CustomerRepository) *DiscountService {
+	return &DiscountService{
+		campaignRepo: campaignRepo,
+		customerRepo: customerRepo,
+	}
+}
+
+// CampaignRequest キャンペーン作成・更新リクエスト
+type CampaignRequest struct {
+	ID                 string              `json:"-"`
+	TenantID           string              `json:"-"`
+	Name               string              `json:"name"`
+	Code               string              `json:"code"` // 空の場合は自動適用キャンペーン
+	DiscountType       domain.DiscountType `json:"discount_type"`
+	Value              int64               `json:"value"`
+	EligibleArchetypes []domain.Archetype  `json:"eligible_archetypes"`
+	EligiblePlanTypes  []domain.PlanType   `json:"eligible_plan_types"`
+	EligibleFabricIDs  []string            `json:"eligible_fabric_ids"`
+	StartsAt           time.Time           `json:"starts_at"`
+	EndsAt             *time.Time          `json:"ends_at"`
+	PerCustomerLimit   int                 `json:"per_customer_limit"`
+	Stackable          bool                `json:"stackable"`
+	Active             *bool               `json:"active"` // 省略時は有効
+}
+
+// DiscountRequest 割引適用リクエスト
+type DiscountRequest struct {
+	TenantID    string           `json:"tenant_id"`
+	CustomerID  string           `json:"customer_id"`
+	Archetype   domain.Archetype `json:"-"` // 顧客情報から取得
+	PlanType    domain.PlanType  `json:"plan_type"`
+	FabricID    string           `json:"fabric_id"`
+	GrossAmount int64            `json:"gross_amount"` // 割引前の税抜金額
+	CouponCodes []string         `json:"coupon_codes"`
+}
+
+// DiscountResult 割引適用結果
+type DiscountResult struct {
+	GrossAmount    int64                   `json:"gross_amount"`    // 割引前の税抜金額
+	Discounts      []*domain.OrderDiscount `json:"discounts"`       // 割引明細
+	DiscountAmount int64                   `json:"discount_amount"` // 割引合計
+	NetAmount      int64                   `json:"net_amount"`      // 割引後の税抜金額（消費税・成果報酬の計算基準）
+}
+
+// CreateCampaign キャンペーンを作成
+func (s *DiscountService) CreateCampaign(ctx context.Context, req *CampaignRequest) (*domain.Campaign, error) {
+	if err := validateCampaignRequest(req); err != nil {
+		return nil, err
+	}
+
+	campaign := domain.NewCampaign(req.TenantID, req.Name, req.Code, req.DiscountType, req.Value, req.StartsAt)
+	applyCampaignRequest(campaign, req)
+
+	if err := s.campaignRepo.Create(ctx, campaign); err != nil {
+		return nil, err
+	}
+	return campaign, nil
+}
+
+// UpdateCampaign キャンペーンを更新
+func (s *DiscountService) UpdateCampaign(ctx context.Context, req *CampaignRequest) (*domain.Campaign, error) {
+	if req.ID == "" {
+		return nil, fmt.Errorf("campaign_id is required")
+	}
+	if err := validateCampaignRequest(req); err != nil {
+		return nil, err
+	}
+
+	campaign, err := s.campaignRepo.GetByID(ctx, req.ID, req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	campaign.Name = req.Name
+	campaign.Code = domain.NormalizeCouponCode(req.Code)
+	campaign.DiscountType = req.DiscountType
+	campaign.Value = req.Value
+	campaign.StartsAt = req.StartsAt
+	applyCampaignRequest(campaign, req)
+	campaign.UpdatedAt = time.Now()
+
+	if err := s.campaignRepo.Update(ctx, campaign); err != nil {
+		return nil, err
+	}
+	return campaign, nil
+}
+
+// GetCampaign キャンペーンを取得
+func (s *DiscountService) GetCampaign(ctx context.Context, campaignID string, tenantID string) (*domain.Campaign, error) {
+	return s.campaignRepo.GetByID(ctx, campaignID, tenantID)
+}
+
+// ListCampaigns テナントのキャンペーン一覧を取得
+func (s *DiscountService) ListCampaigns(ctx context.Context, tenantID string) ([]*domain.Campaign, error) {
+	if tenantID == "" {
+		return nil, fmt.Errorf("tenant_id is required")
+	}
+	return s.campaignRepo.GetByTenantID(ctx, tenantID)
+}
+
+// validateCampaignRequest キャンペーンの入力値を検証
+func validateCampaignRequest(req *CampaignRequest) error {
+	if req.TenantID == "" {
+		return fmt.Errorf("tenant_id is required")
+	}
+	if req.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if !req.DiscountType.IsValid() {
+		return fmt.Errorf("invalid discount_type: %s", req.DiscountType)
+	}
+	if req.Value <= 0 {
+		return fmt.Errorf("invalid value: must be greater than 0")
+	}
+	if req.DiscountType == domain.DiscountTypePercentage && req.Value > 100 {
+		return fmt.Errorf("invalid value: percentage must not exceed 100")
+	}
+	if req.StartsAt.IsZero() {
+		return fmt.Errorf("starts_at is required")
+	}
+	if req.EndsAt != nil && !req.EndsAt.After(req.StartsAt) {
+		return fmt.Errorf("invalid ends_at: must be after starts_at")
+	}
+	if req.PerCustomerLimit < 0 {
+		return fmt.Errorf("invalid per_customer_limit: must not be negative")
+	}
+	for _, archetype := range req.EligibleArchetypes {
+		if !archetype.IsValid() {
+			return fmt.Errorf("invalid archetype: %s", archetype)
+		}
+	}
+	for _, planType := range req.EligiblePlanTypes {
+		if !planType.IsValid() {
+			return fmt.Errorf("invalid plan_type: %s", planType)
+		}
+	}
+	return nil
+}
+
+// applyCampaignRequest 対象条件・利用条件をキャンペーンに反映
+func applyCampaignRequest(campaign *domain.Campaign, req *CampaignRequest) {
+	campaign.EligibleArchetypes = make([]domain.Archetype, 0, len(req.EligibleArchetypes))
+	campaign.EligibleArchetypes = append(campaign.EligibleArchetypes, req.EligibleArchetypes...)
+	campaign.EligiblePlanTypes = make([]domain.PlanType, 0, len(req.EligiblePlanTypes))
+	campaign.EligiblePlanTypes = append(campaign.EligiblePlanTypes, req.EligiblePlanTypes...)
+	campaign.EligibleFabricIDs = make([]string, 0, len(req.EligibleFabricIDs))
+	campaign.EligibleFabricIDs = append(campaign.EligibleFabricIDs, req.EligibleFabricIDs...)
+	campaign.EndsAt = req.EndsAt
+	campaign.PerCustomerLimit = req.PerCustomerLimit
+	campaign.Stackable = req.Stackable
+	campaign.Active = true
+	if req.Active != nil {
+		campaign.Active = *req.Active
+	}
+}
+
+// EvaluateDiscounts 注文に適用する割引を決定（保存はしない）
+func (s *DiscountService) EvaluateDiscounts(ctx context.Context, req *DiscountRequest) (*DiscountResult, error) {
+	if req.TenantID == "" {
+		return nil, fmt.Errorf("tenant_id is required")
+	}
+	if req.CustomerID == "" {
+		return nil, fmt.Errorf("customer_id is required")
+	}
+	if req.GrossAmount < 0 {
+		return nil, fmt.Errorf("invalid gross_amount: must not be negative")
+	}
+
+	campaigns, err := s.campaignRepo.GetActiveByTenantID(ctx, req.TenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get campaigns: %w", err)
+	}
+
+	archetype, err := s.customerRepo.GetPreferredArchetype(ctx, req.CustomerID, req.TenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get customer archetype: %w", err)
+	}
+	req.Archetype = archetype
+
+	// 利用回数上限のあるキャンペーンのみ集計する
+	redemptions := make(map[string]int)
+	now := time.Now()
+	for _, campaign := range campaigns {
+		if campaign.PerCustomerLimit == 0 || !campaign.IsActiveAt(now) {
+			continue
+		}
+		count, err := s.campaignRepo.CountRedemptions(ctx, campaign.ID, req.CustomerID)
+		if err != nil {
+			return nil, err
+		}
+		redemptions[campaign.ID] = count
+	}
+
+	return ApplyCampaigns(campaigns, req, redemptions, now)
+}
+
+// recordOrderDiscountsInTx トランザクション内で注文の割引明細を保存（注文の作成と同時に保存する）
+// 顧客を排他ロックしてから利用回数を再集計し、同時に作成された注文で利用回数上限を超えないようにする
+func (s *DiscountService) recordOrderDiscountsInTx(ctx context.Context, tx *sql.Tx, order *domain.Order, discounts []*domain.OrderDiscount) error {
+	var customerID string
+	err := tx.QueryRowContext(ctx, `
+		SELECT id FROM customers WHERE id = $1 AND tenant_id = $2 FOR UPDATE
+	`, order.CustomerID, order.TenantID).Scan(&customerID)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("customer not found")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock customer: %w", err)
+	}
+
+	for _, discount := range discounts {
+		var perCustomerLimit int
+		err := tx.QueryRowContext(ctx, `
+			SELECT per_customer_limit FROM campaigns WHERE id = $1 AND tenant_id = $2
+		`, discount.CampaignID, order.TenantID).Scan(&perCustomerLimit)
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("campaign not found")
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get campaign: %w", err)
+		}
+
+		if perCustomerLimit > 0 {
+			var count int
+			err := tx.QueryRowContext(ctx, `
+				SELECT COUNT(DISTINCT d.order_id)
+				FROM order_discounts d
+				JOIN orders o ON o.id = d.order_id
+				WHERE d.campaign_id = $1 AND d.customer_id = $2
+				  AND o.status <> 'Cancelled'
+			`, discount.CampaignID, order.CustomerID).Scan(&count)
+			if err != nil {
+				return fmt.Errorf("failed to count campaign redemptions: %w", err)
+			}
+			if count >= perCustomerLimit {
+				label := discount.Code
+				if label == "" {
+					label = discount.Description
+				}
+				return fmt.Errorf("invalid coupon code: %s has reached the usage limit for the customer", label)
+			}
+		}
+
+		discount.OrderID = order.ID
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO order_discounts (
+				id, tenant_id, order_id, customer_id, campaign_id, code, description, amount, created_at
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		`,
+			discount.ID,
+			discount.TenantID,
+			discount.OrderID,
+			discount.CustomerID,
+			discount.CampaignID,
+			sql.NullString{String: discount.Code, Valid: discount.Code != ""},
+			discount.Description,
+			discount.Amount,
+			discount.CreatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create order discount: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetOrderDiscounts 注文の割引明細を取得
+func (s *DiscountService) GetOrderDiscounts(ctx context.Context, orderID string, tenantID string) ([]*domain.OrderDiscount, error) {
+	return s.campaignRepo.GetOrderDiscounts(ctx, orderID, tenantID)
+}
+
+// ApplyCampaigns キャンペーン・クーポンから注文に適用する割引を決定
+// - 自動適用キャンペーンは条件を満たす場合のみ適用、クーポンは条件を満たさない場合エラー
+// - 併用可能（stackable）な割引同士は全て適用、併用不可の割引は単独で適用
+// - クーポン指定がない場合は割引額が最大となる組み合わせを選択
+// - 定率割引は割引前の金額に対して計算し、割引合計は割引前の金額を超えない
+func ApplyCampaigns(campaigns []*domain.Campaign, req *DiscountRequest, redemptions map[string]int, now time.Time) (*DiscountResult, error) {
+	byCode := make(map[string]*domain.Campaign)
+	for _, campaign := range campaigns {
+		if !campaign.IsAutomatic() {
+			byCode[campaign.Code] = campaign
+		}
+	}
+
+	// 1. 指定されたクーポンの検証
+	requested := make(map[string]bool)
+	coupons := make([]*domain.Campaign, 0, len(req.CouponCodes))
+	for _, raw := range req.CouponCodes {
+		code := domain.NormalizeCouponCode(raw)
+		if code == "" {
+			continue
+		}
+		if requested[code] {
+			return nil, fmt.Errorf("invalid coupon code: %s is duplicated", code)
+		}
+		campaign, ok := byCode[code]
+		if !ok {
+			return nil, fmt.Errorf("invalid coupon code: %s", code)
+		}
+		if reason := campaignIneligibility(campaign, req, redemptions, now); reason != "" {
+			return nil, fmt.Errorf("invalid coupon code: %s %s", code, reason)
+		}
+		requested[code] = true
+		coupons = append(coupons, campaign)
+	}
+
+	// 2. 併用ルールの適用
+	var selected []*domain.Campaign
+	if len(coupons) > 0 {
+		for _, coupon := range coupons {
+			if !coupon.Stackable && len(coupons) > 1 {
+				return nil, fmt.Errorf("invalid coupon combination: %s cannot be combined with other coupons", coupon.Code)
+			}
+		}
+		if !coupons[0].Stackable {
+			selected = coupons
+		} else {
+			selected = append(selected, coupons...)
+			for _, campaign := range campaigns {
+				if campaign.IsAutomatic() && campaign.Stackable && campaignIneligibility(campaign, req, redemptions, now) == "" {
+					selected = append(selected, campaign)
+				}
+			}
+		}
+	} else {
+		stackable := make([]*domain.Campaign, 0)
+		var best []*domain.Campaign
+		var bestAmount int64
+		for _, campaign := range campaigns {
+			if !campaign.IsAutomatic() || campaignIneligibility(campaign, req, redemptions, now) != "" {
+				continue
+			}
+			if campaign.Stackable {
+				stackable = append(stackable, campaign)
+				continue
+			}
+			if amount := campaign.DiscountFor(req.GrossAmount); best == nil || amount > bestAmount {
+				best = []*domain.Campaign{campaign}
+				bestAmount = amount
+			}
+		}
+		if best == nil || totalCampaignDiscount(stackable, req.GrossAmount) >= bestAmount {
+			selected = stackable
+		} else {
+			selected = best
+		}
+	}
+
+	// 3. 割引明細の作成（割引合計は割引前の金額が上限）
+	result := &DiscountResult{
+		GrossAmount: req.GrossAmount,
+		Discounts:   make([]*domain.OrderDiscount, 0, len(selected)),
+	}
+	remaining := req.GrossAmount
+	for _, campaign := range selected {
+		amount := campaign.DiscountFor(req.GrossAmount)
+		if amount > remaining {
+			amount = remaining
+		}
+		if amount == 0 {
+			continue
+		}
+		remaining -= amount
+		result.Discounts = append(result.Discounts, domain.NewOrderDiscount(campaign, req.CustomerID, amount))
+	}
+	result.DiscountAmount = domain.TotalDiscountAmount(result.Discounts)
+	result.NetAmount = req.GrossAmount - result.DiscountAmount
+
+	return result, nil
+}
+
+// campaignIneligibility キャンペーンを適用できない理由を返す（適用可能な場合は空）
+func campaignIneligibility(campaign *domain.Campaign, req *DiscountRequest, redemptions map[string]int, now time.Time) string {
+	if campaign.TenantID != req.TenantID {
+		return "is not available"
+	}
+	if !campaign.IsActiveAt(now) {
+		return "is not within its validity period"
+	}
+	if len(campaign.EligibleArchetypes) > 0 && !containsArchetype(campaign.EligibleArchetypes, req.Archetype) {
+		return "is not applicable to the customer's archetype"
+	}
+	if len(campaign.EligiblePlanTypes) > 0 && !containsPlanType(campaign.EligiblePlanTypes, req.PlanType) {
+		return "is not applicable to the plan"
+	}
+	if len(campaign.EligibleFabricIDs) > 0 && !containsFabricID(campaign.EligibleFabricIDs, req.FabricID) {
+		return "is not applicable to the fabric"
+	}
+	if campaign.PerCustomerLimit > 0 && redemptions[campaign.ID] >= campaign.PerCustomerLimit {
+		return "has reached the usage limit for the customer"
+	}
+	return ""
+}
+
+// totalCampaignDiscount キャンペーンを全て適用した場合の割引額（割引前の金額が上限）
+func totalCampaignDiscount(campaigns []*domain.Campaign, amount int64) int64 {
+	var total int64
+	for _, campaign := range campaigns {
+		total += campaign.DiscountFor(amount)
+	}
+	if total > amount {
+		total = amount
+	}
+	return total
+}
+
+func containsArchetype(list []domain.Archetype, value domain.Archetype) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func containsPlanType(list []domain.PlanType, value domain.PlanType) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFabricID(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
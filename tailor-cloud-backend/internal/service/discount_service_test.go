@@ -0,0 +1,124 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"tailor-cloud/backend/internal/config/domain"
+)
+
+// testCampaigns 自動適用キャンペーンとクーポンの組み合わせ
+func testCampaigns(now time.Time) []*domain.Campaign {
+	start := now.AddDate(0, -1, 0)
+
+	// 自動適用: 全員10%オフ（併用可）
+	autumn := domain.NewCampaign("tenant-1", "秋のキャンペーン", "", domain.DiscountTypePercentage, 10, start)
+	autumn.Stackable = true
+
+	// 自動適用: Authenticプラン限定 15000円オフ（併用不可）
+	authentic := domain.NewCampaign("tenant-1", "オーセンティック特典", "", domain.DiscountTypeFixedAmount, 15000, start)
+	authentic.EligiblePlanTypes = []domain.PlanType{domain.PlanTypeAuthentic}
+
+	// クーポン: 5000円オフ（併用可、1人1回まで）
+	welcome := domain.NewCampaign("tenant-1", "初回クーポン", "welcome", domain.DiscountTypeFixedAmount, 5000, start)
+	welcome.Stackable = true
+	welcome.PerCustomerLimit = 1
+
+	// クーポン: Classic限定 20%オフ（併用不可）
+	classic := domain.NewCampaign("tenant-1", "クラシック20%オフ", "CLASSIC20", domain.DiscountTypePercentage, 20, start)
+	classic.EligibleArchetypes = []domain.Archetype{domain.ArchetypeClassic}
+
+	// クーポン: 期限切れ
+	expired := domain.NewCampaign("tenant-1", "夏のクーポン", "SUMMER", domain.DiscountTypeFixedAmount, 3000, start)
+	end := now.AddDate(0, 0, -1)
+	expired.EndsAt = &end
+
+	return []*domain.Campaign{autumn, authentic, welcome, classic, expired}
+}
+
+// TestApplyCampaigns 割引の適用条件と併用ルールのテスト
+func TestApplyCampaigns(t *testing.T) {
+	now := time.Date(2025, 10, 1, 12, 0, 0, 0, time.Local)
+	campaigns := testCampaigns(now)
+
+	tests := []struct {
+		name         string
+		planType     domain.PlanType
+		archetype    domain.Archetype
+		coupons      []string
+		redemptions  map[string]int
+		wantDiscount int64
+		wantErr      string
+	}{
+		// 100000 × 10% = 10000
+		{"自動適用のみ", domain.PlanTypeBestValue, "", nil, nil, 10000, ""},
+		// 併用不可の15000円 > 併用可の10%（10000円）
+		{"割引額が最大の組み合わせを選択", domain.PlanTypeAuthentic, "", nil, nil, 15000, ""},
+		// クーポン（併用可）5000円 + 自動適用（併用可）10000円
+		{"併用可能なクーポンと自動適用", domain.PlanTypeBestValue, "", []string{" welcome "}, nil, 15000, ""},
+		// 併用不可のクーポンは単独で適用
+		{"併用不可のクーポンは単独", domain.PlanTypeBestValue, domain.ArchetypeClassic, []string{"CLASSIC20"}, nil, 20000, ""},
+		{"併用不可のクーポンは他と組み合わせ不可", domain.PlanTypeBestValue, domain.ArchetypeClassic, []string{"CLASSIC20", "WELCOME"}, nil, 0, "invalid coupon combination"},
+		{"対象アーキタイプ外", domain.PlanTypeBestValue, domain.ArchetypeModern, []string{"CLASSIC20"}, nil, 0, "archetype"},
+		{"利用回数上限", domain.PlanTypeBestValue, "", []string{"WELCOME"}, map[string]int{campaigns[2].ID: 1}, 0, "usage limit"},
+		{"有効期限切れ", domain.PlanTypeBestValue, "", []string{"SUMMER"}, nil, 0, "validity period"},
+		{"存在しないコード", domain.PlanTypeBestValue, "", []string{"UNKNOWN"}, nil, 0, "invalid coupon code"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ApplyCampaigns(campaigns, &DiscountRequest{
+				TenantID:    "tenant-1",
+				CustomerID:  "customer-1",
+				Archetype:   tt.archetype,
+				PlanType:    tt.planType,
+				FabricID:    "fabric-1",
+				GrossAmount: 100000,
+				CouponCodes: tt.coupons,
+			}, tt.redemptions, now)
+
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ApplyCampaigns returned error: %v", err)
+			}
+			if result.DiscountAmount != tt.wantDiscount {
+				t.Errorf("Expected discount %d, got %d", tt.wantDiscount, result.DiscountAmount)
+			}
+			if result.NetAmount != 100000-tt.wantDiscount {
+				t.Errorf("Expected net amount %d, got %d", 100000-tt.wantDiscount, result.NetAmount)
+			}
+		})
+	}
+}
+
+// TestApplyCampaignsCappedAtGross 割引合計が割引前の金額を超えないことのテスト
+func TestApplyCampaignsCappedAtGross(t *testing.T) {
+	now := time.Date(2025, 10, 1, 12, 0, 0, 0, time.Local)
+	big := domain.NewCampaign("tenant-1", "大幅値引き", "", domain.DiscountTypeFixedAmount, 8000, now.AddDate(0, 0, -1))
+	big.Stackable = true
+	half := domain.NewCampaign("tenant-1", "半額", "", domain.DiscountTypePercentage, 50, now.AddDate(0, 0, -1))
+	half.Stackable = true
+
+	result, err := ApplyCampaigns([]*domain.Campaign{big, half}, &DiscountRequest{
+		TenantID:    "tenant-1",
+		CustomerID:  "customer-1",
+		GrossAmount: 10000,
+	}, nil, now)
+	if err != nil {
+		t.Fatalf("ApplyCampaigns returned error: %v", err)
+	}
+
+	// 8000 + 5000 は 10000 を超えるため、2件目は残額の2000円
+	if len(result.Discounts) != 2 || result.Discounts[1].Amount != 2000 {
+		t.Fatalf("Unexpected discounts: %+v", result.Discounts)
+	}
+	if result.NetAmount != 0 {
+		t.Errorf("Expected net amount 0, got %d", result.NetAmount)
+	}
+}
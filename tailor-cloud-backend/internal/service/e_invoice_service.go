@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"tailor-cloud/backend/internal/config/domain"
+	"tailor-cloud/backend/internal/repository"
+)
+
+// EInvoiceService 電子インボイス（JP PINT / Peppol）サービス
+type EInvoiceService struct {
+	orderRepo    repository.OrderRepository
+	tenantRepo   repository.TenantRepository
+	customerRepo repository.CustomerRepository
+	campaignRepo repository.CampaignRepository // 割引明細の取得用（nilの場合は値引きなし）
+	accessPoint  PeppolAccessPoint
+}
+
+// NewEInvoiceService EInvoiceServiceのコンストラクタ
+func NewEInvoiceService(
+	orderRepo repository.OrderRepository,
+	tenantRepo repository.TenantRepository,
+	customerRepo repository.CustomerRepository,
+	campaignRepo repository.CampaignRepository,
+	accessPoint PeppolAccessPoint,
+) *EInvoiceService {
+	return &EInvoiceService{
+		orderRepo:    orderRepo,
+		tenantRepo:   tenantRepo,
+		customerRepo: customerRepo,
+		campaignRepo: campaignRepo,
+		accessPoint:  accessPoint,
+	}
+}
+
+// 明細の既定品名（給付の内容が未入力の場合）
+const defaultInvoiceLineDescription = "オーダースーツ"
+
+// EInvoiceDocument 生成した電子インボイス
+type EInvoiceDocument struct {
+	Invoice    *domain.Invoice `json:"invoice"`
+	XML        []byte          `json:"-"`
+	Filename   string          `json:"filename"`
+	Violations []string        `json:"violations"`
+}
+
+// Valid 検証エラーがないか
+func (d *EInvoiceDocument) Valid() bool {
+	return len(d.Violations) == 0
+}
+
+// SendEInvoiceResponse 電子インボイス送信レスポンス
+type SendEInvoiceResponse struct {
+	InvoiceID string            `json:"invoice_id"`
+	Result    *PeppolSendResult `json:"result"`
+}
+
+// BuildInvoice 注文から請求書データモデルを作成
+func (s *EInvoiceService) BuildInvoice(ctx context.Context, orderID string, tenantID string) (*domain.Invoice, error) {
+	order, err := s.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+	// テナント分離: 他テナントの注文は存在しないものとして扱う
+	if order.TenantID != tenantID {
+		return nil, fmt.Errorf("order not found")
+	}
+	if order.Status == domain.OrderStatusDraft || order.Status == domain.OrderStatusCancelled {
+		return nil, fmt.Errorf("invalid order status for invoice: %s", order.Status)
+	}
+
+	tenant, err := s.tenantRepo.GetByID(ctx, order.TenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	issueDate := time.Now()
+	if order.InvoiceIssuedAt != nil {
+		issueDate = *order.InvoiceIssuedAt
+	}
+
+	invoice := &domain.Invoice{
+		ID:           order.ID,
+		OrderID:      order.ID,
+		TenantID:     order.TenantID,
+		IssueDate:    issueDate,
+		DueDate:      order.PaymentDueDate,
+		DeliveryDate: order.DeliveryDate,
+		Currency:     domain.CurrencyJPY,
+		Seller: domain.InvoiceParty{
+			Name:               tenant.LegalName,
+			Address:            tenant.Address,
+			RegistrationNumber: tenant.InvoiceRegistrationNo,
+		},
+	}
+
+	if order.CustomerID != "" {
+		if customer, err := s.customerRepo.GetByID(ctx, order.CustomerID, order.TenantID); err == nil {
+			invoice.Buyer = domain.InvoiceParty{
+				Name:  customer.Name,
+				Email: customer.Email,
+			}
+		}
+	}
+
+	// 割引明細（注文の金額は割引後のため、明細は割引前の金額 + 値引きとして記載する）
+	discounts := make([]*domain.OrderDiscount, 0)
+	if s.campaignRepo != nil {
+		discounts, err = s.campaignRepo.GetOrderDiscounts(ctx, order.ID, order.TenantID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get order discounts: %w", err)
+		}
+	}
+
+	// 明細（注文1件 = 1明細）
+	taxRate := order.TaxRate
+	if taxRate == 0 {
+		taxRate = domain.TaxRateStandard
+	}
+	taxExcludedAmount := order.TotalAmount
+	if order.TaxExcludedAmount != nil {
+		taxExcludedAmount = *order.TaxExcludedAmount
+	}
+	description := defaultInvoiceLineDescription
+	if order.Details != nil && order.Details.Description != "" {
+		description = order.Details.Description
+	}
+	invoice.AddLine(description, 1, taxExcludedAmount+domain.TotalDiscountAmount(discounts), taxRate)
+	for _, discount := range discounts {
+		invoice.AddAllowance(discount.Description, discount.Amount, taxRate)
+	}
+
+	roundingMethod := tenant.TaxRoundingMethod
+	if roundingMethod == "" {
+		roundingMethod = domain.TaxRoundingMethodHalfUp
+	}
+	invoice.CalculateTotals(roundingMethod)
+
+	return invoice, nil
+}
+
+// GenerateJPPINT 注文の電子インボイス（JP PINT UBL 2.1 XML）を生成し検証する
+func (s *EInvoiceService) GenerateJPPINT(ctx context.Context, orderID string, tenantID string) (*EInvoiceDocument, error) {
+	invoice, err := s.BuildInvoice(ctx, orderID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	ubl := BuildJPPINTInvoice(invoice)
+	xmlBytes, err := MarshalJPPINTInvoice(ubl)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := ValidateJPPINTInvoice(ubl)
+	if violations == nil {
+		violations = make([]string, 0)
+	}
+
+	return &EInvoiceDocument{
+		Invoice:    invoice,
+		XML:        xmlBytes,
+		Filename:   fmt.Sprintf("invoice_%s.xml", domain.InvoiceReference(invoice.ID)),
+		Violations: violations,
+	}, nil
+}
+
+// SendJPPINT 電子インボイスを検証し、Peppolアクセスポイントへ送信
+func (s *EInvoiceService) SendJPPINT(ctx context.Context, orderID string, tenantID string) (*SendEInvoiceResponse, error) {
+	if s.accessPoint == nil {
+		return nil, fmt.Errorf("peppol access point is not configured")
+	}
+
+	doc, err := s.GenerateJPPINT(ctx, orderID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if !doc.Valid() {
+		return nil, fmt.Errorf("invalid e-invoice: %s", strings.Join(doc.Violations, "; "))
+	}
+
+	senderID := ""
+	if reg := doc.Invoice.Seller.RegistrationNumber; jpRegistrationNumberPattern.MatchString(reg) {
+		senderID = peppolSchemeJPCorporate + ":" + reg[1:]
+	}
+
+	result, err := s.accessPoint.Send(ctx, &PeppolDocument{
+		TenantID:    tenantID,
+		InvoiceID:   doc.Invoice.ID,
+		SenderID:    senderID,
+		DocumentXML: doc.XML,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to send e-invoice: %w", err)
+	}
+
+	return &SendEInvoiceResponse{
+		InvoiceID: doc.Invoice.ID,
+		Result:    result,
+	}, nil
+}
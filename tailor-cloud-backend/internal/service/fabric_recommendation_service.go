@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"tailor-cloud/backend/internal/config/domain"
+	"tailor-cloud/backend/internal/repository"
+)
+
+const (
+	defaultFabricRecommendationLimit = 10 // おすすめの生地の既定の件数
+	maxFabricRecommendationLimit     = 50 // おすすめの生地の最大件数
+)
+
+// FabricRecommendationService 生地レコメンドサービス
+// 顧客の最新の診断（アーキタイプ・プラン）と予算から、在庫のある生地を
+// テナントごとのアーキタイプ・属性の重みで採点しておすすめ順に提案する
+type FabricRecommendationService struct {
+	recommendationRepo repository.FabricRecommendationRepository
+	fabricRepo         repository.FabricRepository
+	customerRepo       repository.CustomerRepository
+	diagnosisService   *DiagnosisService // 最新の診断（nilの場合は顧客の好みのアーキタイプのみ）
+	unitService        *UnitService      // 生地の表示単位（nilの場合はm）
+}
+
+// NewFabricRecommendationService FabricRecommendationServiceのコンストラクタ
+func NewFabricRecommendationService(
+	recommendationRepo repository.FabricRecommendationRepository,
+	fabricRepo repository.FabricRepository,
+	customerRepo repository.CustomerRepository,
+	diagnosisService *DiagnosisService,
+	unitService *UnitService,
+) *FabricRecommendationService {
+	return &FabricRecommendationService{
+		recommendationRepo: recommendationRepo,
+		fabricRepo:         fabricRepo,
+		customerRepo:       customerRepo,
+		diagnosisService:   diagnosisService,
+		unitService:        unitService,
+	}
+}
+
+// GetWeights テナントの生地レコメンドの重みを取得（未登録の場合は既定の重み）
+func (s *FabricRecommendationService) GetWeights(ctx context.Context, tenantID string) (*domain.FabricRecommendationWeights, error) {
+	if tenantID == "" {
+		return nil, fmt.Errorf("tenant_id is required")
+	}
+
+	weights, err := s.recommendationRepo.GetWeights(ctx, tenantID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return domain.DefaultFabricRecommendationWeights(tenantID), nil
+		}
+		return nil, err
+	}
+
+	return weights, nil
+}
+
+// UpdateFabricRecommendationWeightsRequest 生地レコメンドの重みの更新リクエスト
+type UpdateFabricRecommendationWeightsRequest struct {
+	TenantID     string                                  `json:"-"`
+	Archetypes   map[domain.Archetype]map[string]float64 `json:"archetypes"` // 指定したアーキタイプの重みを置き換え（未指定のアーキタイプは現在の重み）
+	PlanWeight   *float64                                `json:"plan_weight"`
+	SeasonWeight *float64                                `json:"season_weight"`
+	UpdatedBy    string                                  `json:"-"`
+}
+
+// UpdateWeights テナントの生地レコメンドの重みを登録・更新
+func (s *FabricRecommendationService) UpdateWeights(ctx context.Context, req *UpdateFabricRecommendationWeightsRequest) (*domain.FabricRecommendationWeights, error) {
+	if req.UpdatedBy == "" {
+		return nil, fmt.Errorf("updated_by is required")
+	}
+
+	weights, err := s.GetWeights(ctx, req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if weights.Archetypes == nil {
+		weights.Archetypes = make(map[domain.Archetype]map[string]float64)
+	}
+	for archetype, archetypeWeights := range req.Archetypes {
+		weights.Archetypes[archetype] = archetypeWeights
+	}
+	if req.PlanWeight != nil {
+		weights.PlanWeight = *req.PlanWeight
+	}
+	if req.SeasonWeight != nil {
+		weights.SeasonWeight = *req.SeasonWeight
+	}
+	weights.UpdatedAt = time.Now()
+	weights.UpdatedBy = req.UpdatedBy
+	if err := weights.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.recommendationRepo.UpsertWeights(ctx, weights); err != nil {
+		return nil, err
+	}
+
+	return weights, nil
+}
+
+// RecommendFabricsRequest 生地レコメンドリクエスト
+type RecommendFabricsRequest struct {
+	TenantID   string
+	CustomerID string
+	PlanType   domain.PlanType // 未指定の場合は診断のプラン（診断がない場合はベストバリュー）
+	Budget     int64           // スーツ1着分の生地代の上限（円、0は上限なし）
+	Limit      int
+}
+
+// FabricRecommendationResult 生地レコメンドの結果
+type FabricRecommendationResult struct {
+	CustomerID      string                         `json:"customer_id"`
+	DiagnosisID     string                         `json:"diagnosis_id,omitempty"`
+	Archetype       domain.Archetype               `json:"archetype"`
+	ArchetypeSource string                         `json:"archetype_source"` // diagnosis: 最新の診断 / preferred_archetype: 顧客の好みのアーキタイプ
+	PlanType        domain.PlanType                `json:"plan_type"`
+	Budget          int64                          `json:"budget,omitempty"`
+	Season          domain.FabricSeason            `json:"season"`
+	LengthUnit      domain.FabricUnit              `json:"length_unit"`
+	Recommendations []*domain.FabricRecommendation `json:"recommendations"`
+}
+
+// RecommendFabrics 顧客の最新の診断・プラン・予算から在庫のある生地をおすすめ順に取得
+func (s *FabricRecommendationService) RecommendFabrics(ctx context.Context, req *RecommendFabricsRequest) (*FabricRecommendationResult, error) {
+	if req.TenantID == "" {
+		return nil, fmt.Errorf("tenant_id is required")
+	}
+	if req.CustomerID == "" {
+		return nil, fmt.Errorf("customer_id is required")
+	}
+	if req.PlanType != "" && !req.PlanType.IsValid() {
+		return nil, fmt.Errorf("invalid plan_type: %s", req.PlanType)
+	}
+	if req.Budget < 0 {
+		return nil, fmt.Errorf("invalid budget: must not be negative")
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultFabricRecommendationLimit
+	}
+	if limit > maxFabricRecommendationLimit {
+		limit = maxFabricRecommendationLimit
+	}
+
+	// 顧客の好みのアーキタイプ（顧客がテナントに存在するかの確認を兼ねる）
+	preferred, err := s.customerRepo.GetPreferredArchetype(ctx, req.CustomerID, req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	result := &FabricRecommendationResult{
+		CustomerID: req.CustomerID,
+		Budget:     req.Budget,
+		Season:     domain.SeasonAt(now),
+		LengthUnit: s.unitService.GetPreference(ctx, req.TenantID, "").FabricUnit,
+	}
+
+	// 最新の診断を優先し、診断がない場合は顧客の好みのアーキタイプ
+	if s.diagnosisService != nil {
+		diagnosis, err := s.diagnosisService.GetLatestByUserID(ctx, req.CustomerID, req.TenantID)
+		if err != nil && !strings.Contains(err.Error(), "no diagnosis found") {
+			return nil, err
+		}
+		if diagnosis != nil {
+			result.DiagnosisID = diagnosis.ID
+			result.Archetype = diagnosis.Archetype
+			result.ArchetypeSource = "diagnosis"
+			result.PlanType = diagnosis.PlanType
+		}
+	}
+	if result.Archetype == "" && preferred.IsValid() {
+		result.Archetype = preferred
+		result.ArchetypeSource = "preferred_archetype"
+	}
+	if result.Archetype == "" {
+		return nil, fmt.Errorf("invalid customer: no diagnosis or preferred_archetype")
+	}
+	if req.PlanType != "" {
+		result.PlanType = req.PlanType
+	}
+	if result.PlanType == "" {
+		result.PlanType = domain.PlanTypeBestValue
+	}
+
+	weights, err := s.GetWeights(ctx, req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	fabrics, err := s.fabricRepo.GetAll(ctx, req.TenantID, &repository.FabricFilters{
+		Status: []domain.StockStatus{domain.StockStatusAvailable},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list fabrics: %w", err)
+	}
+
+	result.Recommendations = domain.RecommendFabrics(fabrics, weights, domain.FabricRecommendationCriteria{
+		Archetype: result.Archetype,
+		PlanType:  result.PlanType,
+		Budget:    req.Budget,
+		Season:    result.Season,
+		Limit:     limit,
+	})
+
+	// テナントの表示単位に換算（採点はメートルの単価で行う）
+	for _, recommendation := range result.Recommendations {
+		recommendation.Fabric = recommendation.Fabric.InUnit(result.LengthUnit)
+	}
+
+	return result, nil
+}
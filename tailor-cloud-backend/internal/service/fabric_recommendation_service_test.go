@@ -0,0 +1,148 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"tailor-cloud/backend/internal/config/domain"
+)
+
+// recommendationFabrics レコメンドのテスト用の生地
+func recommendationFabrics() []*domain.Fabric {
+	fabrics := []*domain.Fabric{
+		{ID: "navy-stripe", Name: "ネイビーストライプ", StockAmount: 20, Price: 12000, MinimumOrder: 3.2,
+			Attributes: domain.FabricAttributes{ColorFamily: domain.FabricColorNavy, Pattern: domain.FabricPatternStripe, Weight: 270, Season: domain.FabricSeasonAll, Formality: 5}},
+		{ID: "grey-solid", Name: "グレー無地", StockAmount: 15, Price: 8000, MinimumOrder: 3.2,
+			Attributes: domain.FabricAttributes{ColorFamily: domain.FabricColorGrey, Pattern: domain.FabricPatternSolid, Weight: 240, Season: domain.FabricSeasonSpringSummer, Formality: 4}},
+		{ID: "brown-check", Name: "ブラウンチェック", StockAmount: 10, Price: 6000, MinimumOrder: 3.2,
+			Attributes: domain.FabricAttributes{ColorFamily: domain.FabricColorBrown, Pattern: domain.FabricPatternCheck, Weight: 340, Season: domain.FabricSeasonAutumnWinter, Formality: 2}},
+		{ID: "black-limited", Name: "ブラック無地（残少）", StockAmount: 2, Price: 9000, MinimumOrder: 3.2,
+			Attributes: domain.FabricAttributes{ColorFamily: domain.FabricColorBlack, Pattern: domain.FabricPatternSolid}},
+		{ID: "plain", Name: "属性未設定", StockAmount: 30, Price: 5000, MinimumOrder: 3.2},
+	}
+	for _, fabric := range fabrics {
+		fabric.CalculateStockStatus()
+	}
+	return fabrics
+}
+
+// TestRecommendFabrics アーキタイプ・プラン・予算・季節による生地のおすすめ順のテスト
+func TestRecommendFabrics(t *testing.T) {
+	weights := domain.DefaultFabricRecommendationWeights("tenant-1")
+
+	recommendations := domain.RecommendFabrics(recommendationFabrics(), weights, domain.FabricRecommendationCriteria{
+		Archetype: domain.ArchetypeClassic,
+		PlanType:  domain.PlanTypeAuthentic,
+		Season:    domain.FabricSeasonAutumnWinter,
+	})
+	// 在庫が残り少ない生地（Limited）は除外
+	if len(recommendations) != 4 {
+		t.Fatalf("len = %d, want 4", len(recommendations))
+	}
+	top := recommendations[0]
+	if top.Fabric.ID != "navy-stripe" || top.SuitFabricCost != 38400 {
+		t.Errorf("top = %s (%d yen)", top.Fabric.ID, top.SuitFabricCost)
+	}
+	// 色3 + 柄2 + 中肉1 + フォーマル度2 + 最高価格2 + オールシーズン1.5
+	if top.Score != 11.5 {
+		t.Errorf("top score = %v, want 11.5", top.Score)
+	}
+	if len(top.Reasons) == 0 || top.Reasons[0] != "クラシックに合う色（ネイビー）" {
+		t.Errorf("reasons = %v", top.Reasons)
+	}
+	if recommendations[3].Fabric.ID != "plain" || recommendations[3].Score != 0 {
+		t.Errorf("last = %s (%v)", recommendations[3].Fabric.ID, recommendations[3].Score)
+	}
+
+	// カジュアル・ベストバリュー・予算25,000円（スーツ1着分）
+	recommendations = domain.RecommendFabrics(recommendationFabrics(), weights, domain.FabricRecommendationCriteria{
+		Archetype: domain.ArchetypeCasual,
+		PlanType:  domain.PlanTypeBestValue,
+		Budget:    25000,
+		Season:    domain.FabricSeasonAutumnWinter,
+		Limit:     1,
+	})
+	if len(recommendations) != 1 || recommendations[0].Fabric.ID != "brown-check" {
+		t.Fatalf("casual recommendations = %v", recommendations)
+	}
+	reasons := strings.Join(recommendations[0].Reasons, " / ")
+	if !strings.Contains(reasons, "予算内（スーツ1着分の生地代 19200円）") || !strings.Contains(reasons, "今の季節（秋冬）に合う生地") {
+		t.Errorf("reasons = %s", reasons)
+	}
+}
+
+// TestRecommendFabricsTenantWeights テナントの重みによるおすすめ順の調整のテスト
+func TestRecommendFabricsTenantWeights(t *testing.T) {
+	weights := &domain.FabricRecommendationWeights{
+		TenantID: "tenant-1",
+		Archetypes: map[domain.Archetype]map[string]float64{
+			domain.ArchetypeClassic: {"color_family:grey": 5, "pattern:stripe": -2},
+		},
+	}
+	if err := weights.Validate(); err != nil {
+		t.Fatalf("Validate returned error: %v", err)
+	}
+
+	recommendations := domain.RecommendFabrics(recommendationFabrics(), weights, domain.FabricRecommendationCriteria{
+		Archetype: domain.ArchetypeClassic,
+		PlanType:  domain.PlanTypeBestValue,
+	})
+	if recommendations[0].Fabric.ID != "grey-solid" || recommendations[len(recommendations)-1].Fabric.ID != "navy-stripe" {
+		t.Errorf("order = %s ... %s", recommendations[0].Fabric.ID, recommendations[len(recommendations)-1].Fabric.ID)
+	}
+
+	// 未登録のアーキタイプは既定の重み
+	if got := weights.For(domain.ArchetypeModern)["pattern:solid"]; got != 3 {
+		t.Errorf("default modern weight = %v, want 3", got)
+	}
+
+	invalid := []*domain.FabricRecommendationWeights{
+		{Archetypes: map[domain.Archetype]map[string]float64{"Punk": {"pattern:solid": 1}}},
+		{Archetypes: map[domain.Archetype]map[string]float64{domain.ArchetypeClassic: {"pattern:paisley": 1}}},
+		{Archetypes: map[domain.Archetype]map[string]float64{domain.ArchetypeClassic: {"formality:6": 1}}},
+		{Archetypes: map[domain.Archetype]map[string]float64{domain.ArchetypeClassic: {"pattern:solid": 11}}},
+		{PlanWeight: -1},
+	}
+	for i, w := range invalid {
+		if err := w.Validate(); err == nil || !strings.HasPrefix(err.Error(), "invalid") {
+			t.Errorf("invalid[%d]: err = %v", i, err)
+		}
+	}
+}
+
+// TestFabricAttributes 生地の属性のチェック・目付の区分・季節のテスト
+func TestFabricAttributes(t *testing.T) {
+	attributes := domain.FabricAttributes{ColorFamily: domain.FabricColorNavy, Weight: 250, Formality: 3}
+	if err := attributes.Validate(); err != nil {
+		t.Errorf("Validate returned error: %v", err)
+	}
+	if got := strings.Join(attributes.Keys(), ","); got != "color_family:navy,weight:medium,formality:3" {
+		t.Errorf("keys = %s", got)
+	}
+	if band := (domain.FabricAttributes{Weight: 230}).WeightBand(); band != "light" {
+		t.Errorf("230g/m = %s, want light", band)
+	}
+	if band := (domain.FabricAttributes{Weight: 310}).WeightBand(); band != "heavy" {
+		t.Errorf("310g/m = %s, want heavy", band)
+	}
+
+	for _, invalid := range []domain.FabricAttributes{
+		{ColorFamily: "pink"},
+		{Pattern: "paisley"},
+		{Season: "winter"},
+		{Formality: 6},
+		{Weight: -1},
+	} {
+		if err := invalid.Validate(); err == nil {
+			t.Errorf("expected error for %+v", invalid)
+		}
+	}
+
+	if got := domain.SeasonAt(time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)); got != domain.FabricSeasonSpringSummer {
+		t.Errorf("April = %s", got)
+	}
+	if got := domain.SeasonAt(time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)); got != domain.FabricSeasonAutumnWinter {
+		t.Errorf("October = %s", got)
+	}
+}
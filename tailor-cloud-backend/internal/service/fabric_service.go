@@ -32,6 +32,17 @@ func (s *FabricService) GetFabric(ctx context.Context, req *GetFabricRequest) (*
 	if err != nil {
 		return nil, fmt.Errorf("failed to get fabric: %w", err)
 	}
+
+	// テナント別の属性が登録されていればカタログの既定値より優先する
+	if req.TenantID != "" {
+		attributes, err := s.fabricRepo.GetAttributes(ctx, req.TenantID, req.FabricID)
+		if err != nil {
+			return nil, err
+		}
+		if attributes != nil {
+			fabric.Attributes = *attributes
+		}
+	}
 	
 	return fabric, nil
 }
@@ -119,3 +130,33 @@ func (s *FabricService) ReserveFabric(ctx context.Context, req *ReserveFabricReq
 	return nil
 }
 
+// UpdateFabricAttributesRequest 生地の属性の更新リクエスト
+type UpdateFabricAttributesRequest struct {
+	FabricID   string
+	TenantID   string
+	Attributes domain.FabricAttributes
+	UpdatedBy  string
+}
+
+// UpdateFabricAttributes テナントの生地の属性（色系統・柄・目付・シーズン・フォーマル度）を更新
+// 生地はテナント共通のカタログのため、属性はテナントごとに保存し他テナントのレコメンドには影響しない
+func (s *FabricService) UpdateFabricAttributes(ctx context.Context, req *UpdateFabricAttributesRequest) (*domain.Fabric, error) {
+	if req.FabricID == "" {
+		return nil, fmt.Errorf("fabric_id is required")
+	}
+	if req.TenantID == "" {
+		return nil, fmt.Errorf("tenant_id is required")
+	}
+	if req.UpdatedBy == "" {
+		return nil, fmt.Errorf("updated_by is required")
+	}
+	if err := req.Attributes.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.fabricRepo.UpsertAttributes(ctx, req.TenantID, req.FabricID, req.Attributes, req.UpdatedBy); err != nil {
+		return nil, err
+	}
+
+	return s.GetFabric(ctx, &GetFabricRequest{FabricID: req.FabricID, TenantID: req.TenantID})
+}
@@ -0,0 +1,510 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"tailor-cloud/backend/internal/config/domain"
+	"tailor-cloud/backend/internal/repository"
+)
+
+// GroupOrderService 団体注文サービス
+// 法人・団体のユニフォーム契約: 支払者（法人顧客）1件に対して着用者ごとの注文を束ね、
+// 共通の生地と価格の取り決め、まとめての生地引当、法人宛ての一括請求、進捗集計を提供する
+type GroupOrderService struct {
+	groupOrderRepo             repository.GroupOrderRepository
+	orderRepo                  repository.OrderRepository
+	customerRepo               repository.CustomerRepository
+	fabricRepo                 repository.FabricRepository
+	tenantRepo                 repository.TenantRepository
+	fabricAllocationRepo       repository.FabricAllocationRepository
+	orderService               *OrderService
+	inventoryAllocationService *InventoryAllocationService
+	db                         *sql.DB // トランザクション管理用（まとめ引当）
+}
+
+// NewGroupOrderService GroupOrderServiceのコンストラクタ
+func NewGroupOrderService(
+	groupOrderRepo repository.GroupOrderRepository,
+	orderRepo repository.OrderRepository,
+	customerRepo repository.CustomerRepository,
+	fabricRepo repository.FabricRepository,
+	tenantRepo repository.TenantRepository,
+	fabricAllocationRepo repository.FabricAllocationRepository,
+	orderService *OrderService,
+	inventoryAllocationService *InventoryAllocationService,
+	db *sql.DB,
+) *GroupOrderService {
+	return &GroupOrderService{
+		groupOrderRepo:             groupOrderRepo,
+		orderRepo:                  orderRepo,
+		customerRepo:               customerRepo,
+		fabricRepo:                 fabricRepo,
+		tenantRepo:                 tenantRepo,
+		fabricAllocationRepo:       fabricAllocationRepo,
+		orderService:               orderService,
+		inventoryAllocationService: inventoryAllocationService,
+		db:                         db,
+	}
+}
+
+// CreateGroupOrderRequest 団体注文作成リクエスト
+type CreateGroupOrderRequest struct {
+	TenantID            string                    `json:"-"`
+	Name                string                    `json:"name"`
+	CorporateCustomerID string                    `json:"corporate_customer_id"`
+	FabricID            string                    `json:"fabric_id"`
+	Prices              []*domain.GroupOrderPrice `json:"prices"`
+	DeliveryDate        time.Time                 `json:"delivery_date"`
+	Notes               string                    `json:"notes"`
+	CreatedBy           string                    `json:"-"`
+}
+
+// AddGroupOrderMemberRequest 着用者の注文追加リクエスト
+type AddGroupOrderMemberRequest struct {
+	GroupOrderID  string               `json:"-"`
+	TenantID      string               `json:"-"`
+	CustomerID    string               `json:"customer_id"` // 着用者（顧客）
+	GarmentType   domain.GarmentType   `json:"garment_type"`
+	Details       *domain.OrderDetails `json:"details"` // 着用者ごとの採寸データ・補正情報
+	CreatedBy     string               `json:"-"`
+	CreatedByRole domain.UserRole      `json:"-"`
+	IPAddress     string               `json:"-"`
+	UserAgent     string               `json:"-"`
+}
+
+// AllocateGroupFabricRequest まとめ引当リクエスト
+type AllocateGroupFabricRequest struct {
+	GroupOrderID string             `json:"-"`
+	TenantID     string             `json:"-"`
+	Strategy     AllocationStrategy `json:"strategy"`
+}
+
+// AllocateGroupFabricResponse まとめ引当レスポンス
+type AllocateGroupFabricResponse struct {
+	GroupOrderID    string                     `json:"group_order_id"`
+	FabricID        string                     `json:"fabric_id"`
+	AllocatedOrders int                        `json:"allocated_orders"` // 今回引当した注文数
+	SkippedOrders   int                        `json:"skipped_orders"`   // 引当済み・対象外の注文数
+	TotalRequired   float64                    `json:"total_required"`   // 今回の必要量（メートル）
+	TotalAllocated  float64                    `json:"total_allocated"`  // 今回の引当量（メートル）
+	Allocations     []*domain.FabricAllocation `json:"allocations"`
+}
+
+// CreateGroupOrder 団体注文を作成
+func (s *GroupOrderService) CreateGroupOrder(ctx context.Context, req *CreateGroupOrderRequest) (*domain.GroupOrder, error) {
+	if req.TenantID == "" {
+		return nil, fmt.Errorf("tenant_id is required")
+	}
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+	if req.CorporateCustomerID == "" {
+		return nil, fmt.Errorf("corporate_customer_id is required")
+	}
+	if req.FabricID == "" {
+		return nil, fmt.Errorf("fabric_id is required")
+	}
+	if req.DeliveryDate.IsZero() {
+		return nil, fmt.Errorf("delivery_date is required")
+	}
+	if req.CreatedBy == "" {
+		return nil, fmt.Errorf("created_by is required")
+	}
+	if err := domain.ValidateGroupOrderPrices(req.Prices); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.customerRepo.GetByID(ctx, req.CorporateCustomerID, req.TenantID); err != nil {
+		return nil, fmt.Errorf("corporate customer not found: %w", err)
+	}
+	if _, err := s.fabricRepo.GetByID(ctx, req.FabricID); err != nil {
+		return nil, fmt.Errorf("fabric not found: %w", err)
+	}
+
+	groupOrder := domain.NewGroupOrder(req.TenantID, strings.TrimSpace(req.Name), req.CorporateCustomerID, req.FabricID, req.Prices, req.DeliveryDate, req.CreatedBy)
+	groupOrder.Notes = req.Notes
+
+	if err := s.groupOrderRepo.Create(ctx, groupOrder); err != nil {
+		return nil, fmt.Errorf("failed to create group order: %w", err)
+	}
+
+	return groupOrder, nil
+}
+
+// GetGroupOrder 団体注文を取得
+func (s *GroupOrderService) GetGroupOrder(ctx context.Context, groupOrderID, tenantID string) (*domain.GroupOrder, error) {
+	return s.groupOrderRepo.GetByID(ctx, groupOrderID, tenantID)
+}
+
+// ListGroupOrders 団体注文一覧を取得（statusが空の場合は全件）
+func (s *GroupOrderService) ListGroupOrders(ctx context.Context, tenantID string, status domain.GroupOrderStatus) ([]*domain.GroupOrder, error) {
+	if status != "" && !status.IsValid() {
+		return nil, fmt.Errorf("invalid status: %s", status)
+	}
+	return s.groupOrderRepo.GetByTenantID(ctx, tenantID, status)
+}
+
+// CloseGroupOrder 団体注文の受付を締め切る（以降は着用者を追加できない）
+func (s *GroupOrderService) CloseGroupOrder(ctx context.Context, groupOrderID, tenantID string) (*domain.GroupOrder, error) {
+	groupOrder, err := s.groupOrderRepo.GetByID(ctx, groupOrderID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if groupOrder.Status == domain.GroupOrderStatusClosed {
+		return groupOrder, nil
+	}
+
+	groupOrder.Status = domain.GroupOrderStatusClosed
+	groupOrder.UpdatedAt = time.Now()
+	if err := s.groupOrderRepo.Update(ctx, groupOrder); err != nil {
+		return nil, fmt.Errorf("failed to update group order: %w", err)
+	}
+
+	return groupOrder, nil
+}
+
+// AddMember 着用者の注文を団体注文に追加（Draftステータス）
+// 生地・納期は団体注文の共通設定、金額は品目ごとの価格取り決めを使用する
+func (s *GroupOrderService) AddMember(ctx context.Context, req *AddGroupOrderMemberRequest) (*domain.Order, error) {
+	if req.CustomerID == "" {
+		return nil, fmt.Errorf("customer_id is required")
+	}
+
+	groupOrder, err := s.groupOrderRepo.GetByID(ctx, req.GroupOrderID, req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if groupOrder.Status != domain.GroupOrderStatusOpen {
+		return nil, fmt.Errorf("invalid group order status: %s (members can only be added while OPEN)", groupOrder.Status)
+	}
+
+	price, err := groupOrder.PriceFor(req.GarmentType)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.customerRepo.GetByID(ctx, req.CustomerID, req.TenantID); err != nil {
+		return nil, fmt.Errorf("customer not found: %w", err)
+	}
+
+	return s.orderService.CreateOrder(ctx, &CreateOrderRequest{
+		TenantID:      req.TenantID,
+		CustomerID:    req.CustomerID,
+		FabricID:      groupOrder.FabricID,
+		GarmentType:   req.GarmentType,
+		TotalAmount:   price.UnitPrice,
+		DeliveryDate:  groupOrder.DeliveryDate,
+		Details:       req.Details,
+		CreatedBy:     req.CreatedBy,
+		CreatedByRole: req.CreatedByRole,
+		IPAddress:     req.IPAddress,
+		UserAgent:     req.UserAgent,
+		GroupOrderID:  groupOrder.ID,
+	})
+}
+
+// AllocateFabric 団体注文の生地をまとめて引当
+// 未引当の着用者の注文（キャンセル・裁断以降を除く）を1つのトランザクションで引当し、
+// 在庫が全員分に満たない場合は一部だけ引当することはせず、全体をロールバックする
+func (s *GroupOrderService) AllocateFabric(ctx context.Context, req *AllocateGroupFabricRequest) (*AllocateGroupFabricResponse, error) {
+	if req.Strategy == "" {
+		req.Strategy = AllocationStrategyFIFO
+	}
+
+	groupOrder, err := s.groupOrderRepo.GetByID(ctx, req.GroupOrderID, req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	orders, err := s.listMemberOrders(ctx, groupOrder)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &AllocateGroupFabricResponse{
+		GroupOrderID: groupOrder.ID,
+		FabricID:     groupOrder.FabricID,
+		Allocations:  make([]*domain.FabricAllocation, 0),
+	}
+
+	// 引当対象の注文と必要量を決定
+	type pendingAllocation struct {
+		order  *domain.Order
+		length float64
+	}
+	pending := make([]pendingAllocation, 0, len(orders))
+	for _, order := range orders {
+		if order.Status != domain.OrderStatusDraft && order.Status != domain.OrderStatusConfirmed {
+			resp.SkippedOrders++
+			continue
+		}
+		allocated, err := s.hasActiveAllocation(ctx, order)
+		if err != nil {
+			return nil, err
+		}
+		if allocated {
+			resp.SkippedOrders++
+			continue
+		}
+
+		length := order.GarmentType.StandardFabricLength()
+		if price, err := groupOrder.PriceFor(order.GarmentType); err == nil {
+			length = price.RequiredFabricLength()
+		}
+		pending = append(pending, pendingAllocation{order: order, length: length})
+		resp.TotalRequired += length
+	}
+
+	if len(pending) == 0 {
+		return resp, nil
+	}
+
+	// トランザクション開始（全員分をまとめて引当）
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, p := range pending {
+		allocation, err := s.inventoryAllocationService.AllocateInventoryInTx(ctx, tx, &AllocateInventoryRequest{
+			TenantID:       req.TenantID,
+			OrderID:        p.order.ID,
+			FabricID:       groupOrder.FabricID,
+			RequiredLength: p.length,
+			Strategy:       req.Strategy,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to allocate fabric for group order (total required %.2fm): %w", resp.TotalRequired, err)
+		}
+		resp.Allocations = append(resp.Allocations, allocation.Allocations...)
+		resp.TotalAllocated += allocation.TotalAllocated
+		resp.AllocatedOrders++
+	}
+
+	// トランザクションコミット
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return resp, nil
+}
+
+// BuildInvoice 団体注文の一括請求書（法人顧客宛て）を作成
+// 請求対象は発注確定以降の着用者の注文（Draft・キャンセルを除く）
+func (s *GroupOrderService) BuildInvoice(ctx context.Context, groupOrderID, tenantID string) (*domain.Invoice, error) {
+	groupOrder, err := s.groupOrderRepo.GetByID(ctx, groupOrderID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	tenant, err := s.tenantRepo.GetByID(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	corporate, err := s.customerRepo.GetByID(ctx, groupOrder.CorporateCustomerID, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get corporate customer: %w", err)
+	}
+
+	orders, err := s.listMemberOrders(ctx, groupOrder)
+	if err != nil {
+		return nil, err
+	}
+
+	return BuildGroupOrderInvoice(groupOrder, tenant, corporate, orders, time.Now())
+}
+
+// GenerateJPPINT 団体注文の一括請求書を電子インボイス（JP PINT）として生成し検証する
+func (s *GroupOrderService) GenerateJPPINT(ctx context.Context, groupOrderID, tenantID string) (*EInvoiceDocument, error) {
+	invoice, err := s.BuildInvoice(ctx, groupOrderID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	ubl := BuildJPPINTInvoice(invoice)
+	xmlBytes, err := MarshalJPPINTInvoice(ubl)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := ValidateJPPINTInvoice(ubl)
+	if violations == nil {
+		violations = make([]string, 0)
+	}
+
+	return &EInvoiceDocument{
+		Invoice:    invoice,
+		XML:        xmlBytes,
+		Filename:   fmt.Sprintf("invoice_group_%s.xml", domain.InvoiceReference(invoice.ID)),
+		Violations: violations,
+	}, nil
+}
+
+// GetProgress 団体注文の進捗（ステータスごとの着数・着用者ごとの状況）を取得
+func (s *GroupOrderService) GetProgress(ctx context.Context, groupOrderID, tenantID string) (*domain.GroupOrderProgress, error) {
+	groupOrder, err := s.groupOrderRepo.GetByID(ctx, groupOrderID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	orders, err := s.listMemberOrders(ctx, groupOrder)
+	if err != nil {
+		return nil, err
+	}
+
+	// 着用者名の表示用（取得できない場合は空）
+	names := make(map[string]string)
+	members := make([]*domain.GroupOrderMember, 0, len(orders))
+	for _, order := range orders {
+		name, ok := names[order.CustomerID]
+		if !ok {
+			if customer, err := s.customerRepo.GetByID(ctx, order.CustomerID, tenantID); err == nil {
+				name = customer.Name
+			}
+			names[order.CustomerID] = name
+		}
+		members = append(members, &domain.GroupOrderMember{
+			OrderID:      order.ID,
+			CustomerID:   order.CustomerID,
+			CustomerName: name,
+			GarmentType:  order.GarmentType,
+			Status:       order.Status,
+			TotalAmount:  order.TotalAmount,
+			DeliveryDate: order.DeliveryDate,
+		})
+	}
+
+	return domain.SummarizeGroupOrderProgress(groupOrder.ID, members), nil
+}
+
+// listMemberOrders 団体注文に属する注文をすべて取得（作成日時の昇順）
+func (s *GroupOrderService) listMemberOrders(ctx context.Context, groupOrder *domain.GroupOrder) ([]*domain.Order, error) {
+	filter := &domain.OrderSearchFilter{
+		TenantID:     groupOrder.TenantID,
+		GroupOrderID: groupOrder.ID,
+		SortBy:       domain.OrderSortCreatedAt,
+		SortOrder:    domain.SortOrderAsc,
+		Limit:        100, // 1回の検索の最大件数
+	}
+	orders, err := searchAllOrders(ctx, s.orderRepo, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get group order members: %w", err)
+	}
+	return orders, nil
+}
+
+// searchAllOrders 検索条件に一致する注文をカーソルで順に取得し、全件を返す
+func searchAllOrders(ctx context.Context, orderRepo repository.OrderRepository, filter *domain.OrderSearchFilter) ([]*domain.Order, error) {
+	if err := filter.Normalize(); err != nil {
+		return nil, err
+	}
+
+	orders := make([]*domain.Order, 0)
+	for {
+		page, err := orderRepo.Search(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		if len(page) <= filter.Limit {
+			orders = append(orders, page...)
+			return orders, nil
+		}
+		page = page[:filter.Limit]
+		orders = append(orders, page...)
+		filter.Cursor = domain.NewOrderCursor(page[len(page)-1], filter.SortBy, filter.SortOrder)
+	}
+}
+
+// hasActiveAllocation 注文に有効な生地引当（キャンセル以外）があるか
+func (s *GroupOrderService) hasActiveAllocation(ctx context.Context, order *domain.Order) (bool, error) {
+	allocations, err := s.fabricAllocationRepo.GetByOrderID(ctx, order.ID, order.TenantID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get allocations: %w", err)
+	}
+	for _, allocation := range allocations {
+		if allocation.Status != domain.FabricAllocationStatusCancelled {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// BuildGroupOrderInvoice 着用者ごとの注文から法人宛ての一括請求書を作成
+// 明細は品目・単価・税率ごとにまとめ（数量 = 着数）、消費税は請求書全体で税率ごとに1回計算する
+func BuildGroupOrderInvoice(groupOrder *domain.GroupOrder, tenant *domain.Tenant, corporate *domain.Customer, orders []*domain.Order, issueDate time.Time) (*domain.Invoice, error) {
+	invoice := &domain.Invoice{
+		ID:        groupOrder.ID,
+		OrderID:   groupOrder.ID,
+		TenantID:  groupOrder.TenantID,
+		IssueDate: issueDate,
+		Currency:  domain.CurrencyJPY,
+		Seller: domain.InvoiceParty{
+			Name:               tenant.LegalName,
+			Address:            tenant.Address,
+			RegistrationNumber: tenant.InvoiceRegistrationNo,
+		},
+		Buyer: domain.InvoiceParty{
+			Name:  corporate.Name,
+			Email: corporate.Email,
+		},
+	}
+
+	type lineKey struct {
+		garmentType domain.GarmentType
+		unitPrice   int64
+		taxRate     domain.TaxRate
+	}
+	lines := make(map[lineKey]*domain.InvoiceLine)
+
+	for _, order := range orders {
+		if order.Status == domain.OrderStatusDraft || order.Status == domain.OrderStatusCancelled {
+			continue
+		}
+
+		taxRate := order.TaxRate
+		if taxRate == 0 {
+			taxRate = domain.TaxRateStandard
+		}
+		unitPrice := order.TotalAmount
+		if order.TaxExcludedAmount != nil {
+			unitPrice = *order.TaxExcludedAmount
+		}
+
+		key := lineKey{garmentType: order.GarmentType, unitPrice: unitPrice, taxRate: taxRate}
+		if line, ok := lines[key]; ok {
+			line.Quantity++
+			line.Amount += unitPrice
+		} else {
+			description := defaultInvoiceLineDescription
+			if order.GarmentType != "" {
+				description = order.GarmentType.Label()
+			}
+			lines[key] = invoice.AddLine(fmt.Sprintf("%s（%s）", description, groupOrder.Name), 1, unitPrice, taxRate)
+		}
+
+		// 支払期日・納品日は最も遅い注文に合わせる
+		if order.PaymentDueDate.After(invoice.DueDate) {
+			invoice.DueDate = order.PaymentDueDate
+		}
+		if order.DeliveryDate.After(invoice.DeliveryDate) {
+			invoice.DeliveryDate = order.DeliveryDate
+		}
+	}
+
+	if len(invoice.Lines) == 0 {
+		return nil, fmt.Errorf("invalid group order: no confirmed orders to invoice")
+	}
+
+	roundingMethod := tenant.TaxRoundingMethod
+	if roundingMethod == "" {
+		roundingMethod = domain.TaxRoundingMethodHalfUp
+	}
+	invoice.CalculateTotals(roundingMethod)
+
+	return invoice, nil
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"tailor-cloud/backend/internal/config/domain"
+)
+
+// testGroupOrder スーツ・パンツの価格取り決めを持つ団体注文
+func testGroupOrder() *domain.GroupOrder {
+	return domain.NewGroupOrder("tenant-1", "テスト株式会社 制服", "corp-1", "fabric-1", []*domain.GroupOrderPrice{
+		{GarmentType: domain.GarmentTypeSuit, UnitPrice: 50000},
+		{GarmentType: domain.GarmentTypeTrousers, UnitPrice: 15000, FabricLength: 1.5},
+	}, time.Date(2026, 12, 1, 0, 0, 0, 0, time.Local), "user-1")
+}
+
+// testGroupMemberOrder 着用者の注文
+func testGroupMemberOrder(customerID string, garmentType domain.GarmentType, amount int64, status domain.OrderStatus, deliveryDate time.Time) *domain.Order {
+	order := domain.NewOrder("tenant-1", customerID, "fabric-1", "user-1", amount, deliveryDate)
+	order.GarmentType = garmentType
+	order.Status = status
+	return order
+}
+
+// TestBuildGroupOrderInvoice 一括請求書の明細集約と消費税計算のテスト
+func TestBuildGroupOrderInvoice(t *testing.T) {
+	groupOrder := testGroupOrder()
+	tenant := &domain.Tenant{ID: "tenant-1", LegalName: "テーラー株式会社", InvoiceRegistrationNo: "T1234567890123"}
+	corporate := &domain.Customer{ID: "corp-1", Name: "テスト株式会社"}
+
+	early := time.Date(2026, 11, 20, 0, 0, 0, 0, time.Local)
+	late := time.Date(2026, 12, 1, 0, 0, 0, 0, time.Local)
+	orders := []*domain.Order{
+		testGroupMemberOrder("c-1", domain.GarmentTypeSuit, 50000, domain.OrderStatusConfirmed, early),
+		testGroupMemberOrder("c-2", domain.GarmentTypeSuit, 50000, domain.OrderStatusSewing, late),
+		testGroupMemberOrder("c-3", domain.GarmentTypeTrousers, 15000, domain.OrderStatusDelivered, early),
+		testGroupMemberOrder("c-4", domain.GarmentTypeSuit, 50000, domain.OrderStatusDraft, early),     // 請求対象外
+		testGroupMemberOrder("c-5", domain.GarmentTypeSuit, 50000, domain.OrderStatusCancelled, early), // 請求対象外
+	}
+
+	invoice, err := BuildGroupOrderInvoice(groupOrder, tenant, corporate, orders, time.Date(2026, 12, 5, 0, 0, 0, 0, time.Local))
+	if err != nil {
+		t.Fatalf("BuildGroupOrderInvoice returned error: %v", err)
+	}
+
+	if invoice.Buyer.Name != "テスト株式会社" {
+		t.Errorf("Expected corporate buyer, got %q", invoice.Buyer.Name)
+	}
+	// 品目・単価ごとにまとめる: スーツ × 2、パンツ × 1
+	if len(invoice.Lines) != 2 {
+		t.Fatalf("Expected 2 lines, got %d", len(invoice.Lines))
+	}
+	if invoice.Lines[0].Quantity != 2 || invoice.Lines[0].Amount != 100000 || !strings.HasPrefix(invoice.Lines[0].Description, "スーツ") {
+		t.Errorf("Unexpected suit line: %+v", invoice.Lines[0])
+	}
+	if invoice.Lines[1].Quantity != 1 || invoice.Lines[1].Amount != 15000 {
+		t.Errorf("Unexpected trousers line: %+v", invoice.Lines[1])
+	}
+
+	// 115000 × 10% = 11500（税率ごとに1回計算）
+	if invoice.TaxExclusiveAmount != 115000 || invoice.TaxAmount != 11500 || invoice.TaxInclusiveAmount != 126500 {
+		t.Errorf("Unexpected totals: exclusive=%d tax=%d inclusive=%d", invoice.TaxExclusiveAmount, invoice.TaxAmount, invoice.TaxInclusiveAmount)
+	}
+	if !invoice.DeliveryDate.Equal(late) {
+		t.Errorf("Expected latest delivery date %v, got %v", late, invoice.DeliveryDate)
+	}
+}
+
+// TestBuildGroupOrderInvoiceNoConfirmedOrders 請求対象の注文がない場合のテスト
+func TestBuildGroupOrderInvoiceNoConfirmedOrders(t *testing.T) {
+	orders := []*domain.Order{
+		testGroupMemberOrder("c-1", domain.GarmentTypeSuit, 50000, domain.OrderStatusDraft, time.Now()),
+	}
+	_, err := BuildGroupOrderInvoice(testGroupOrder(), &domain.Tenant{}, &domain.Customer{}, orders, time.Now())
+	if err == nil || !strings.Contains(err.Error(), "no confirmed orders") {
+		t.Errorf("Expected no confirmed orders error, got %v", err)
+	}
+}
+
+// TestSummarizeGroupOrderProgress ステータスごとの着数集計のテスト
+func TestSummarizeGroupOrderProgress(t *testing.T) {
+	members := []*domain.GroupOrderMember{
+		{OrderID: "o-1", GarmentType: domain.GarmentTypeSuit, Status: domain.OrderStatusSewing},
+		{OrderID: "o-2", GarmentType: domain.GarmentTypeSuit, Status: domain.OrderStatusSewing},
+		{OrderID: "o-3", GarmentType: domain.GarmentTypeSuit, Status: domain.OrderStatusDelivered},
+		{OrderID: "o-4", GarmentType: domain.GarmentTypeTrousers, Status: domain.OrderStatusPaid},
+		{OrderID: "o-5", GarmentType: domain.GarmentTypeTrousers, Status: domain.OrderStatusCancelled},
+	}
+
+	progress := domain.SummarizeGroupOrderProgress("group-1", members)
+
+	if progress.TotalGarments != 5 || progress.ActiveGarments != 4 || progress.CompletedGarments != 2 {
+		t.Errorf("Unexpected counts: total=%d active=%d completed=%d", progress.TotalGarments, progress.ActiveGarments, progress.CompletedGarments)
+	}
+	if progress.CompletionPercent != 50 {
+		t.Errorf("Expected 50%% completion, got %d", progress.CompletionPercent)
+	}
+	if progress.ByStatus[domain.OrderStatusSewing] != 2 || progress.ByStatus[domain.OrderStatusCancelled] != 1 {
+		t.Errorf("Unexpected by_status: %v", progress.ByStatus)
+	}
+	if progress.ByGarmentType[domain.GarmentTypeSuit] != 3 || progress.ByGarmentType[domain.GarmentTypeTrousers] != 1 {
+		t.Errorf("Unexpected by_garment_type: %v", progress.ByGarmentType)
+	}
+}
+
+// TestGroupOrderPrices 価格取り決めの検証と用尺のテスト
+func TestGroupOrderPrices(t *testing.T) {
+	groupOrder := testGroupOrder()
+
+	suit, err := groupOrder.PriceFor(domain.GarmentTypeSuit)
+	if err != nil {
+		t.Fatalf("PriceFor returned error: %v", err)
+	}
+	// 用尺未指定の場合は品目の標準用尺
+	if suit.RequiredFabricLength() != domain.DefaultFabricLength {
+		t.Errorf("Expected standard fabric length %.1f, got %.1f", domain.DefaultFabricLength, suit.RequiredFabricLength())
+	}
+	trousers, _ := groupOrder.PriceFor(domain.GarmentTypeTrousers)
+	if trousers.RequiredFabricLength() != 1.5 {
+		t.Errorf("Expected agreed fabric length 1.5, got %.1f", trousers.RequiredFabricLength())
+	}
+	if _, err := groupOrder.PriceFor(domain.GarmentTypeCoat); err == nil {
+		t.Error("Expected error for garment type outside the price agreement")
+	}
+
+	tests := []struct {
+		name   string
+		prices []*domain.GroupOrderPrice
+	}{
+		{"空", nil},
+		{"品目の重複", []*domain.GroupOrderPrice{{GarmentType: domain.GarmentTypeSuit, UnitPrice: 1}, {GarmentType: domain.GarmentTypeSuit, UnitPrice: 2}}},
+		{"単価が0", []*domain.GroupOrderPrice{{GarmentType: domain.GarmentTypeSuit}}},
+		{"不明な品目", []*domain.GroupOrderPrice{{GarmentType: "HAT", UnitPrice: 1}}},
+	}
+	for _, tt := range tests {
+		if err := domain.ValidateGroupOrderPrices(tt.prices); err == nil {
+			t.Errorf("%s: expected validation error", tt.name)
+		}
+	}
+}
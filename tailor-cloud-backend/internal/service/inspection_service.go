@@ -0,0 +1,496 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"tailor-cloud/backend/internal/config/domain"
+	"tailor-cloud/backend/internal/repository"
+)
+
+// InspectionService 品質検品サービス
+// 品目ごとのチェックリストで検品結果を判定・記録し、不合格の場合は手直しタスクを作成して注文を縫製へ戻す
+type InspectionService struct {
+	inspectionRepo repository.InspectionRepository
+	taskRepo       repository.ProductionTaskRepository
+	orderRepo      repository.OrderRepository
+	capacityRepo   repository.FactoryCapacityRepository
+	storageService StorageService // 検品写真の保存（nilの場合は写真を添付できない）
+	bucketName     string
+	db             *sql.DB // トランザクション管理用（検品記録・手直しタスク・注文ステータスの同時更新）
+}
+
+// NewInspectionService InspectionServiceのコンストラクタ
+func NewInspectionService(
+	inspectionRepo repository.InspectionRepository,
+	taskRepo repository.ProductionTaskRepository,
+	orderRepo repository.OrderRepository,
+	capacityRepo repository.FactoryCapacityRepository,
+	storageService StorageService,
+	bucketName string,
+	db *sql.DB,
+) *InspectionService {
+	return &InspectionService{
+		inspectionRepo: inspectionRepo,
+		taskRepo:       taskRepo,
+		orderRepo:      orderRepo,
+		capacityRepo:   capacityRepo,
+		storageService: storageService,
+		bucketName:     bucketName,
+		db:             db,
+	}
+}
+
+// 検品写真として受け付ける画像形式と拡張子
+var inspectionPhotoExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+// UpdateInspectionChecklistRequest 検品チェックリストの更新リクエスト
+type UpdateInspectionChecklistRequest struct {
+	TenantID    string                            `json:"-"`
+	GarmentType domain.GarmentType                `json:"-"`
+	UpdatedBy   string                            `json:"-"`
+	Items       []*domain.InspectionChecklistItem `json:"items"`
+}
+
+// SubmitInspectionRequest 検品結果の登録リクエスト
+type SubmitInspectionRequest struct {
+	OrderID     string                        `json:"-"`
+	TenantID    string                        `json:"-"`
+	InspectorID string                        `json:"-"`
+	Results     []*domain.InspectionItemInput `json:"results"`
+	Notes       string                        `json:"notes"`
+	IPAddress   string                        `json:"-"`
+	UserAgent   string                        `json:"-"`
+}
+
+// SubmitInspectionResponse 検品結果の登録レスポンス
+type SubmitInspectionResponse struct {
+	Inspection       *domain.Inspection     `json:"inspection"`
+	ReworkTask       *domain.ProductionTask `json:"rework_task,omitempty"`       // 不合格時の手直しタスク
+	ReinspectionTask *domain.ProductionTask `json:"reinspection_task,omitempty"` // 不合格時の再検品タスク
+	OrderStatus      domain.OrderStatus     `json:"order_status"`
+}
+
+// AddInspectionPhotoRequest 検品写真の追加リクエスト
+type AddInspectionPhotoRequest struct {
+	InspectionID string
+	TenantID     string
+	UploadedBy   string
+	ItemCode     string // 対象の検品項目（空の場合は全体）
+	Data         []byte
+}
+
+// GetChecklist 品目の検品チェックリストを取得（未設定の場合は既定のチェックリスト）
+func (s *InspectionService) GetChecklist(ctx context.Context, tenantID string, garmentType domain.GarmentType) (*domain.InspectionChecklist, error) {
+	if tenantID == "" {
+		return nil, fmt.Errorf("tenant_id is required")
+	}
+	if !garmentType.IsValid() {
+		return nil, fmt.Errorf("invalid garment_type: %s", garmentType)
+	}
+
+	checklist, err := s.inspectionRepo.GetChecklist(ctx, tenantID, garmentType)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return domain.DefaultInspectionChecklist(tenantID, garmentType), nil
+		}
+		return nil, err
+	}
+	return checklist, nil
+}
+
+// ListChecklists テナントで設定済みの検品チェックリストを取得
+func (s *InspectionService) ListChecklists(ctx context.Context, tenantID string) ([]*domain.InspectionChecklist, error) {
+	if tenantID == "" {
+		return nil, fmt.Errorf("tenant_id is required")
+	}
+	return s.inspectionRepo.ListChecklists(ctx, tenantID)
+}
+
+// UpdateChecklist 品目の検品チェックリストを登録・更新
+func (s *InspectionService) UpdateChecklist(ctx context.Context, req *UpdateInspectionChecklistRequest) (*domain.InspectionChecklist, error) {
+	if req.TenantID == "" {
+		return nil, fmt.Errorf("tenant_id is required")
+	}
+	if req.UpdatedBy == "" {
+		return nil, fmt.Errorf("updated_by is required")
+	}
+
+	checklist := &domain.InspectionChecklist{
+		TenantID:    req.TenantID,
+		GarmentType: req.GarmentType,
+		Items:       req.Items,
+		UpdatedAt:   time.Now(),
+		UpdatedBy:   req.UpdatedBy,
+	}
+	if err := checklist.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.inspectionRepo.UpsertChecklist(ctx, checklist); err != nil {
+		return nil, err
+	}
+	return checklist, nil
+}
+
+// SubmitInspection 検品結果を登録
+// 検品中の注文のみ登録できる。検品者の作業中・中断中の検品タスクは完了にする。
+// 不合格の場合は手直しの縫製タスクと再検品タスクを作成し、注文を縫製へ戻す
+func (s *InspectionService) SubmitInspection(ctx context.Context, req *SubmitInspectionRequest) (*SubmitInspectionResponse, error) {
+	if req.OrderID == "" {
+		return nil, fmt.Errorf("order_id is required")
+	}
+	if req.TenantID == "" {
+		return nil, fmt.Errorf("tenant_id is required")
+	}
+	if req.InspectorID == "" {
+		return nil, fmt.Errorf("inspector_id is required")
+	}
+	if len(req.Results) == 0 {
+		return nil, fmt.Errorf("results is required")
+	}
+
+	order, err := s.getOrder(ctx, req.OrderID, req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != domain.OrderStatusInspection {
+		return nil, fmt.Errorf("invalid order status: %s order cannot be inspected", order.Status)
+	}
+
+	garmentType := order.GarmentType
+	if garmentType == "" {
+		garmentType = domain.GarmentTypeSuit
+	}
+	checklist, err := s.GetChecklist(ctx, req.TenantID, garmentType)
+	if err != nil {
+		return nil, err
+	}
+
+	orderTasks, err := s.taskRepo.List(ctx, &domain.ProductionTaskFilter{TenantID: req.TenantID, OrderID: order.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	inspection := domain.NewInspection(req.TenantID, order.ID, garmentType, req.InspectorID)
+	inspection.Notes = req.Notes
+	inspection.ResponsibleWorkers = responsibleWorkers(orderTasks)
+	var measurementData json.RawMessage
+	if order.Details != nil {
+		measurementData = order.Details.MeasurementData
+	}
+	if err := inspection.Evaluate(checklist, expectedFinalMeasurements(measurementData), req.Results); err != nil {
+		return nil, err
+	}
+
+	resp := &SubmitInspectionResponse{Inspection: inspection, OrderStatus: order.Status}
+	now := inspection.CreatedAt
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// 検品者の作業中・中断中の検品タスクを完了
+	hasPendingInspection := false
+	for _, task := range orderTasks {
+		if task.Process != domain.ProductionProcessInspection {
+			continue
+		}
+		if task.Status == domain.ProductionTaskStatusPending {
+			hasPendingInspection = true
+			continue
+		}
+		if task.Status == domain.ProductionTaskStatusCompleted || task.AssignedTo != req.InspectorID {
+			continue
+		}
+		expected := task.Status
+		if err := task.Complete(now); err != nil {
+			return nil, err
+		}
+		if err := updateProductionTaskInTx(ctx, tx, task, expected); err != nil {
+			return nil, err
+		}
+	}
+
+	if !inspection.Passed {
+		// 手直しタスク（標準作業時間は計画外のため0）。縫製の担当者に割り当てる
+		rework := domain.NewProductionTask(req.TenantID, order.ID, garmentType, domain.ProductionProcessSewing, 0)
+		rework.AssignedTo = inspection.ResponsibleWorkers[domain.ProductionProcessSewing]
+		rework.InspectionID = inspection.ID
+		if err := createProductionTaskInTx(ctx, tx, rework); err != nil {
+			return nil, err
+		}
+		inspection.ReworkTaskID = rework.ID
+		resp.ReworkTask = rework
+
+		// 製造タスクで工程を管理している注文は、手直し後の再検品タスクを用意する
+		if len(orderTasks) > 0 && !hasPendingInspection {
+			capacity, err := loadFactoryCapacity(ctx, s.capacityRepo, req.TenantID)
+			if err != nil {
+				return nil, err
+			}
+			reinspection := domain.NewProductionTask(req.TenantID, order.ID, garmentType, domain.ProductionProcessInspection,
+				capacity.StandardMinutes(garmentType, domain.ProductionProcessInspection))
+			reinspection.InspectionID = inspection.ID
+			if err := createProductionTaskInTx(ctx, tx, reinspection); err != nil {
+				return nil, err
+			}
+			resp.ReinspectionTask = reinspection
+		}
+	}
+
+	if err := s.createInspectionInTx(ctx, tx, inspection); err != nil {
+		return nil, err
+	}
+
+	if !inspection.Passed {
+		oldStatus := order.Status
+		order.Status = domain.OrderStatusSewing
+		order.UpdatedAt = now
+		if err := updateOrderStatusInTx(ctx, tx, order, oldStatus); err != nil {
+			return nil, err
+		}
+
+		auditLog := domain.NewAuditLog(order.TenantID, req.InspectorID, domain.AuditActionStatusChange, "order", order.ID)
+		auditLog.OldValue = s.toJSON(map[string]interface{}{"status": oldStatus})
+		auditLog.NewValue = s.toJSON(map[string]interface{}{
+			"status":         order.Status,
+			"inspection_id":  inspection.ID,
+			"rework_task_id": inspection.ReworkTaskID,
+		})
+		auditLog.ChangedFields = []string{"status"}
+		auditLog.IPAddress = req.IPAddress
+		auditLog.UserAgent = req.UserAgent
+		if err := createAuditLogInTx(ctx, tx, auditLog); err != nil {
+			return nil, err
+		}
+		resp.OrderStatus = order.Status
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return resp, nil
+}
+
+// GetInspection 検品記録を取得
+func (s *InspectionService) GetInspection(ctx context.Context, inspectionID, tenantID string) (*domain.Inspection, error) {
+	if inspectionID == "" {
+		return nil, fmt.Errorf("inspection_id is required")
+	}
+	if tenantID == "" {
+		return nil, fmt.Errorf("tenant_id is required")
+	}
+	return s.inspectionRepo.GetByID(ctx, inspectionID, tenantID)
+}
+
+// ListOrderInspections 注文の検品記録を取得
+func (s *InspectionService) ListOrderInspections(ctx context.Context, orderID, tenantID string) ([]*domain.Inspection, error) {
+	if orderID == "" {
+		return nil, fmt.Errorf("order_id is required")
+	}
+	if tenantID == "" {
+		return nil, fmt.Errorf("tenant_id is required")
+	}
+	return s.inspectionRepo.ListByOrderID(ctx, orderID, tenantID)
+}
+
+// AddPhoto 検品記録に写真を添付（Cloud Storageに保存）
+func (s *InspectionService) AddPhoto(ctx context.Context, req *AddInspectionPhotoRequest) (*domain.InspectionPhoto, error) {
+	if req.InspectionID == "" {
+		return nil, fmt.Errorf("inspection_id is required")
+	}
+	if req.TenantID == "" {
+		return nil, fmt.Errorf("tenant_id is required")
+	}
+	if len(req.Data) == 0 {
+		return nil, fmt.Errorf("file is required")
+	}
+	if s.storageService == nil || s.bucketName == "" {
+		return nil, fmt.Errorf("storage service is not configured")
+	}
+
+	contentType := http.DetectContentType(req.Data)
+	ext, ok := inspectionPhotoExtensions[contentType]
+	if !ok {
+		return nil, fmt.Errorf("invalid file: unsupported image type %s", contentType)
+	}
+
+	inspection, err := s.inspectionRepo.GetByID(ctx, req.InspectionID, req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if req.ItemCode != "" && !inspectionHasResult(inspection, req.ItemCode) {
+		return nil, fmt.Errorf("invalid item_code: %s", req.ItemCode)
+	}
+
+	objectPath := fmt.Sprintf("inspections/%s/%s/%s%s", req.TenantID, inspection.ID, uuid.New().String(), ext)
+	url, err := s.storageService.UploadImage(ctx, s.bucketName, objectPath, req.Data, contentType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload inspection photo: %w", err)
+	}
+
+	photo := &domain.InspectionPhoto{
+		ItemCode:   req.ItemCode,
+		URL:        url,
+		UploadedAt: time.Now(),
+		UploadedBy: req.UploadedBy,
+	}
+	if err := s.inspectionRepo.AddPhoto(ctx, inspection.ID, req.TenantID, photo); err != nil {
+		return nil, err
+	}
+	return photo, nil
+}
+
+// GetDefectReport 期間内（fromを含み、toを含まない）の工場・工程・作業者ごとの不良率を集計
+func (s *InspectionService) GetDefectReport(ctx context.Context, tenantID string, from, to time.Time) (*domain.DefectRateReport, error) {
+	if tenantID == "" {
+		return nil, fmt.Errorf("tenant_id is required")
+	}
+	if !to.After(from) {
+		return nil, fmt.Errorf("invalid period: to must be after from")
+	}
+
+	inspections, err := s.inspectionRepo.GetBetween(ctx, tenantID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	report := domain.SummarizeDefectRates(tenantID, inspections)
+	report.From = from
+	report.To = to
+	return report, nil
+}
+
+// getOrder 注文を取得（テナントIDもチェック）
+func (s *InspectionService) getOrder(ctx context.Context, orderID, tenantID string) (*domain.Order, error) {
+	order, err := s.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+	if order.TenantID != tenantID {
+		return nil, fmt.Errorf("unauthorized: tenant_id mismatch")
+	}
+	return order, nil
+}
+
+// createInspectionInTx トランザクション内で検品記録を作成
+func (s *InspectionService) createInspectionInTx(ctx context.Context, tx *sql.Tx, inspection *domain.Inspection) error {
+	resultsJSON, err := json.Marshal(inspection.Results)
+	if err != nil {
+		return fmt.Errorf("failed to marshal inspection results: %w", err)
+	}
+	photosJSON, err := json.Marshal(inspection.Photos)
+	if err != nil {
+		return fmt.Errorf("failed to marshal inspection photos: %w", err)
+	}
+	workersJSON, err := json.Marshal(inspection.ResponsibleWorkers)
+	if err != nil {
+		return fmt.Errorf("failed to marshal responsible workers: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO inspections (
+			id, tenant_id, order_id, garment_type, inspector_id, passed, defect_count,
+			results, photos, responsible_workers, rework_task_id, notes, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	`,
+		inspection.ID,
+		inspection.TenantID,
+		inspection.OrderID,
+		string(inspection.GarmentType),
+		inspection.InspectorID,
+		inspection.Passed,
+		inspection.DefectCount,
+		resultsJSON,
+		photosJSON,
+		workersJSON,
+		sql.NullString{String: inspection.ReworkTaskID, Valid: inspection.ReworkTaskID != ""},
+		inspection.Notes,
+		inspection.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create inspection: %w", err)
+	}
+	return nil
+}
+
+// toJSON 監査ログ用にJSON文字列に変換
+func (s *InspectionService) toJSON(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf(`{"error": "failed to marshal: %v"}`, err)
+	}
+	return string(data)
+}
+
+// responsibleWorkers 工程ごとに最後に完了した製造タスクの担当者
+func responsibleWorkers(orderTasks []*domain.ProductionTask) map[domain.ProductionProcess]string {
+	workers := make(map[domain.ProductionProcess]string)
+	latest := make(map[domain.ProductionProcess]time.Time)
+	for _, task := range orderTasks {
+		if task.Status != domain.ProductionTaskStatusCompleted || task.AssignedTo == "" || task.CompletedAt == nil {
+			continue
+		}
+		if task.Process == domain.ProductionProcessInspection {
+			continue
+		}
+		if !latest[task.Process].IsZero() && !task.CompletedAt.After(latest[task.Process]) {
+			continue
+		}
+		latest[task.Process] = *task.CompletedAt
+		workers[task.Process] = task.AssignedTo
+	}
+	return workers
+}
+
+// expectedFinalMeasurements 注文の採寸データから仕上がり寸法（項目 → cm）を取得
+// "final_measurements" がある場合はその値、ない場合は採寸データ直下の数値を使う
+func expectedFinalMeasurements(measurementData json.RawMessage) map[string]float64 {
+	expected := make(map[string]float64)
+	if len(measurementData) == 0 {
+		return expected
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(measurementData, &fields); err != nil {
+		return expected
+	}
+	if final, ok := fields["final_measurements"]; ok {
+		var finalFields map[string]json.RawMessage
+		if err := json.Unmarshal(final, &finalFields); err == nil {
+			fields = finalFields
+		}
+	}
+
+	for key, raw := range fields {
+		var value float64
+		if err := json.Unmarshal(raw, &value); err == nil && value > 0 {
+			expected[key] = value
+		}
+	}
+	return expected
+}
+
+// inspectionHasResult 検品記録に項目コードの結果があるか
+func inspectionHasResult(inspection *domain.Inspection, code string) bool {
+	for _, result := range inspection.Results {
+		if result.Code == code {
+			return true
+		}
+	}
+	return false
+}
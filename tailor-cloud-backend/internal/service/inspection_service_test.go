@@ -0,0 +1,242 @@
+package service
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"tailor-cloud/backend/internal/config/domain"
+)
+
+// testInspectionChecklist テスト用のチェックリスト（着丈 ±0.5cm と縫い目）
+func testInspectionChecklist() *domain.InspectionChecklist {
+	return &domain.InspectionChecklist{
+		TenantID:    "tenant-1",
+		GarmentType: domain.GarmentTypeJacket,
+		Items: []*domain.InspectionChecklistItem{
+			{Code: "jacket_length", Label: "着丈", Type: domain.InspectionItemTypeMeasurement, MeasurementField: "jacket_length", Tolerance: 0.5},
+			{Code: "stitching_seams", Label: "縫い目", Type: domain.InspectionItemTypeStitching},
+		},
+	}
+}
+
+func measuredCM(v float64) *float64 { return &v }
+
+func passedFlag(v bool) *bool { return &v }
+
+// TestInspectionEvaluate 許容差による寸法の合否と責任工程の作業者のテスト
+func TestInspectionEvaluate(t *testing.T) {
+	checklist := testInspectionChecklist()
+	expected := map[string]float64{"jacket_length": 74.0}
+
+	inspection := domain.NewInspection("tenant-1", "order-1", domain.GarmentTypeJacket, "inspector-1")
+	inspection.ResponsibleWorkers = map[domain.ProductionProcess]string{
+		domain.ProductionProcessCutting: "cutter-1",
+		domain.ProductionProcessSewing:  "sewer-1",
+	}
+	err := inspection.Evaluate(checklist, expected, []*domain.InspectionItemInput{
+		{Code: "jacket_length", Measured: measuredCM(74.4)},
+		{Code: "stitching_seams", Passed: passedFlag(true)},
+	})
+	if err != nil {
+		t.Fatalf("Evaluate returned error: %v", err)
+	}
+	if !inspection.Passed || inspection.DefectCount != 0 {
+		t.Errorf("Expected pass within tolerance, got passed=%v defects=%d", inspection.Passed, inspection.DefectCount)
+	}
+
+	err = inspection.Evaluate(checklist, expected, []*domain.InspectionItemInput{
+		{Code: "jacket_length", Measured: measuredCM(75.0)},
+		{Code: "stitching_seams", Passed: passedFlag(false), Note: "目飛び"},
+	})
+	if err != nil {
+		t.Fatalf("Evaluate returned error: %v", err)
+	}
+	if inspection.Passed || inspection.DefectCount != 2 {
+		t.Fatalf("Expected 2 defects, got passed=%v defects=%d", inspection.Passed, inspection.DefectCount)
+	}
+	length := inspection.Results[0]
+	if length.Deviation == nil || *length.Deviation != 1.0 {
+		t.Errorf("Expected deviation 1.0, got %v", length.Deviation)
+	}
+	if length.Process != domain.ProductionProcessCutting || length.ResponsibleWorkerID != "cutter-1" {
+		t.Errorf("Expected measurement defect attributed to cutter-1, got %s/%s", length.Process, length.ResponsibleWorkerID)
+	}
+	if inspection.Results[1].ResponsibleWorkerID != "sewer-1" {
+		t.Errorf("Expected stitching defect attributed to sewer-1, got %s", inspection.Results[1].ResponsibleWorkerID)
+	}
+}
+
+// TestInspectionEvaluateInputErrors 入力漏れ・不明な項目のテスト
+func TestInspectionEvaluateInputErrors(t *testing.T) {
+	checklist := testInspectionChecklist()
+	expected := map[string]float64{"jacket_length": 74.0}
+
+	tests := []struct {
+		name   string
+		inputs []*domain.InspectionItemInput
+		want   string
+	}{
+		{
+			name:   "項目の入力漏れ",
+			inputs: []*domain.InspectionItemInput{{Code: "jacket_length", Measured: measuredCM(74.0)}},
+			want:   "stitching_seams is missing",
+		},
+		{
+			name: "実測値なし",
+			inputs: []*domain.InspectionItemInput{
+				{Code: "jacket_length", Passed: passedFlag(true)},
+				{Code: "stitching_seams", Passed: passedFlag(true)},
+			},
+			want: "measured is required",
+		},
+		{
+			name: "不明な項目",
+			inputs: []*domain.InspectionItemInput{
+				{Code: "jacket_length", Measured: measuredCM(74.0)},
+				{Code: "stitching_seams", Passed: passedFlag(true)},
+				{Code: "lining", Passed: passedFlag(true)},
+			},
+			want: "unknown item lining",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inspection := domain.NewInspection("tenant-1", "order-1", domain.GarmentTypeJacket, "inspector-1")
+			err := inspection.Evaluate(checklist, expected, tt.inputs)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+
+	// 仕上がり寸法がない場合は合否の入力で判定する
+	inspection := domain.NewInspection("tenant-1", "order-1", domain.GarmentTypeJacket, "inspector-1")
+	err := inspection.Evaluate(checklist, map[string]float64{}, []*domain.InspectionItemInput{
+		{Code: "jacket_length", Passed: passedFlag(true)},
+		{Code: "stitching_seams", Passed: passedFlag(true)},
+	})
+	if err != nil || !inspection.Passed {
+		t.Errorf("Expected pass without final measurements, got passed=%v err=%v", inspection.Passed, err)
+	}
+}
+
+// TestInspectionChecklistValidate チェックリストの検証のテスト
+func TestInspectionChecklistValidate(t *testing.T) {
+	for _, garmentType := range []domain.GarmentType{domain.GarmentTypeSuit, domain.GarmentTypeTrousers, domain.GarmentTypeShirt} {
+		if err := domain.DefaultInspectionChecklist("tenant-1", garmentType).Validate(); err != nil {
+			t.Errorf("Expected default checklist for %s to be valid, got %v", garmentType, err)
+		}
+	}
+
+	duplicate := testInspectionChecklist()
+	duplicate.Items = append(duplicate.Items, &domain.InspectionChecklistItem{Code: "jacket_length", Type: domain.InspectionItemTypeVisual})
+	if err := duplicate.Validate(); err == nil || !strings.Contains(err.Error(), "duplicate code") {
+		t.Errorf("Expected duplicate code error, got %v", err)
+	}
+
+	noTolerance := testInspectionChecklist()
+	noTolerance.Items[0].Tolerance = 0
+	if err := noTolerance.Validate(); err == nil || !strings.Contains(err.Error(), "tolerance") {
+		t.Errorf("Expected tolerance error, got %v", err)
+	}
+}
+
+// TestExpectedFinalMeasurements 採寸データからの仕上がり寸法の取得のテスト
+func TestExpectedFinalMeasurements(t *testing.T) {
+	nested := expectedFinalMeasurements(json.RawMessage(`{"height": 175, "final_measurements": {"jacket_length": 74.5, "chest": 98, "corrections": []}}`))
+	if len(nested) != 2 || nested["jacket_length"] != 74.5 || nested["chest"] != 98 {
+		t.Errorf("Expected final_measurements values, got %v", nested)
+	}
+
+	flat := expectedFinalMeasurements(json.RawMessage(`{"waist": 82, "note": "x"}`))
+	if len(flat) != 1 || flat["waist"] != 82 {
+		t.Errorf("Expected top-level numeric values, got %v", flat)
+	}
+
+	if got := expectedFinalMeasurements(nil); len(got) != 0 {
+		t.Errorf("Expected empty map for nil data, got %v", got)
+	}
+}
+
+// TestResponsibleWorkers 工程ごとに最後に完了したタスクの担当者のテスト
+func TestResponsibleWorkers(t *testing.T) {
+	first := time.Date(2026, 10, 19, 10, 0, 0, 0, time.Local)
+	later := first.Add(2 * time.Hour)
+	tasks := []*domain.ProductionTask{
+		{Process: domain.ProductionProcessCutting, Status: domain.ProductionTaskStatusCompleted, AssignedTo: "cutter-1", CompletedAt: &first},
+		{Process: domain.ProductionProcessSewing, Status: domain.ProductionTaskStatusCompleted, AssignedTo: "sewer-1", CompletedAt: &later},
+		{Process: domain.ProductionProcessSewing, Status: domain.ProductionTaskStatusCompleted, AssignedTo: "sewer-2", CompletedAt: &first},
+		{Process: domain.ProductionProcessSewing, Status: domain.ProductionTaskStatusInProgress, AssignedTo: "sewer-3"},
+	}
+
+	workers := responsibleWorkers(tasks)
+	if workers[domain.ProductionProcessCutting] != "cutter-1" || workers[domain.ProductionProcessSewing] != "sewer-1" {
+		t.Errorf("Unexpected responsible workers: %v", workers)
+	}
+}
+
+// TestSummarizeDefectRates 工場・工程・作業者ごとの不良率の集計のテスト
+func TestSummarizeDefectRates(t *testing.T) {
+	workers := map[domain.ProductionProcess]string{
+		domain.ProductionProcessCutting: "cutter-1",
+		domain.ProductionProcessSewing:  "sewer-1",
+	}
+	inspections := []*domain.Inspection{
+		{Passed: true, ResponsibleWorkers: workers},
+		{
+			Passed:             false,
+			DefectCount:        2,
+			ResponsibleWorkers: workers,
+			Results: []*domain.InspectionItemResult{
+				{Code: "stitching_seams", Process: domain.ProductionProcessSewing, Passed: false},
+				{Code: "pressing_finish", Process: domain.ProductionProcessSewing, Passed: false},
+				{Code: "jacket_length", Process: domain.ProductionProcessCutting, Passed: true},
+			},
+		},
+		{
+			Passed:             false,
+			DefectCount:        1,
+			ResponsibleWorkers: map[domain.ProductionProcess]string{domain.ProductionProcessSewing: "sewer-2"},
+			Results: []*domain.InspectionItemResult{
+				{Code: "stitching_seams", Process: domain.ProductionProcessSewing, Passed: false},
+			},
+		},
+		{Passed: true, ResponsibleWorkers: workers},
+	}
+
+	report := domain.SummarizeDefectRates("tenant-1", inspections)
+	if report.Factory.Inspections != 4 || report.Factory.FailedInspections != 2 || report.Factory.Defects != 3 || report.Factory.DefectRatePercent != 50 {
+		t.Errorf("Unexpected factory rate: %+v", report.Factory)
+	}
+
+	byProcess := make(map[string]*domain.DefectRate)
+	for _, rate := range report.ByProcess {
+		byProcess[rate.Key] = rate
+	}
+	if sewing := byProcess[string(domain.ProductionProcessSewing)]; sewing == nil || sewing.FailedInspections != 2 || sewing.Defects != 3 {
+		t.Errorf("Unexpected sewing rate: %+v", sewing)
+	}
+	if cutting := byProcess[string(domain.ProductionProcessCutting)]; cutting == nil || cutting.FailedInspections != 0 {
+		t.Errorf("Unexpected cutting rate: %+v", cutting)
+	}
+
+	// sewer-2: 1件中1件不合格（100%）が先頭、sewer-1: 3件中1件不合格（33%）
+	if len(report.ByWorker) != 3 || report.ByWorker[0].Key != "sewer-2" || report.ByWorker[0].DefectRatePercent != 100 {
+		t.Fatalf("Unexpected worker rates: %+v", report.ByWorker)
+	}
+	for _, rate := range report.ByWorker {
+		if rate.Key == "sewer-1" && (rate.Inspections != 3 || rate.DefectRatePercent != 33) {
+			t.Errorf("Unexpected sewer-1 rate: %+v", rate)
+		}
+		if rate.Key == "cutter-1" && rate.FailedInspections != 0 {
+			t.Errorf("Expected no failures for cutter-1, got %+v", rate)
+		}
+	}
+
+	if len(report.TopItems) != 2 || report.TopItems[0].Code != "stitching_seams" || report.TopItems[0].Defects != 2 {
+		t.Errorf("Unexpected top items: %+v", report.TopItems)
+	}
+}
@@ -74,8 +74,29 @@ func (s *InventoryAllocationService) AllocateInventory(ctx context.Context, req
 	}
 	defer tx.Rollback()
 	
+	resp, err := s.AllocateInventoryInTx(ctx, tx, req)
+	if err != nil {
+		return nil, err
+	}
+	
+	// トランザクションコミット
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	
+	return resp, nil
+}
+
+// AllocateInventoryInTx トランザクション内で在庫を引当（反物単位で管理）
+// 団体注文のまとめ引当など、複数の注文の引当を1つのトランザクションで行う場合に使用する
+// 同じトランザクション内の後続の引当は、先行の引当で減った残り長さを前提に反物を選択する
+func (s *InventoryAllocationService) AllocateInventoryInTx(ctx context.Context, tx *sql.Tx, req *AllocateInventoryRequest) (*AllocateInventoryResponse, error) {
+	if req.RequiredLength <= 0 {
+		return nil, fmt.Errorf("required_length must be greater than 0")
+	}
+	
 	// 生地が存在するか確認
-	_, err = s.fabricRepo.GetByID(ctx, req.FabricID)
+	_, err := s.fabricRepo.GetByID(ctx, req.FabricID)
 	if err != nil {
 		return nil, fmt.Errorf("fabric not found: %w", err)
 	}
@@ -146,11 +167,6 @@ func (s *InventoryAllocationService) AllocateInventory(ctx context.Context, req
 		return nil, fmt.Errorf("insufficient inventory: still need %.2fm after allocating from all available rolls", remainingNeeded)
 	}
 	
-	// トランザクションコミット
-	if err := tx.Commit(); err != nil {
-		return nil, fmt.Errorf("failed to commit transaction: %w", err)
-	}
-	
 	return &AllocateInventoryResponse{
 		Allocations:     allocations,
 		TotalAllocated:  totalAllocated,
@@ -408,30 +424,88 @@ func (s *InventoryAllocationService) ReleaseAllocation(ctx context.Context, allo
 	}
 	defer tx.Rollback()
 	
-	// 反物の残り長さを戻す
-	roll, err := s.fabricRollRepo.GetByID(ctx, allocation.FabricRollID, tenantID)
+	if _, err := s.ReleaseAllocationInTx(ctx, tx, allocation); err != nil {
+		return err
+	}
+	
+	// トランザクションコミット
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	
+	return nil
+}
+
+// ReleaseAllocationInTx トランザクション内で引当を解除（反物の残り長さを戻し、引当をキャンセル）
+// 呼び出し元のトランザクションに参加するため、注文キャンセルなど他の更新と同時にコミットできる
+// 解除した場合は true、既にキャンセル済みの場合は false を返す
+func (s *InventoryAllocationService) ReleaseAllocationInTx(ctx context.Context, tx *sql.Tx, allocation *domain.FabricAllocation) (bool, error) {
+	if allocation.Status == domain.FabricAllocationStatusCut {
+		return false, fmt.Errorf("cannot release allocation %s: fabric has already been cut", allocation.ID)
+	}
+	
+	// 引当をキャンセル（既にキャンセル済みの場合は反物を戻さない）
+	allocation.Cancel()
+	result, err := tx.ExecContext(ctx, `
+		UPDATE fabric_allocations
+		SET allocation_status = $3, updated_at = $4
+		WHERE id = $1 AND tenant_id = $2 AND allocation_status NOT IN ('CANCELLED', 'CUT')
+	`, allocation.ID, allocation.TenantID, string(allocation.Status), allocation.UpdatedAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to cancel allocation: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("failed to get roll: %w", err)
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return false, nil
 	}
 	
-	newLength := roll.CurrentLength + allocation.AllocatedLength
-	err = s.updateRollLengthInTx(ctx, tx, roll.ID, tenantID, newLength)
+	// 反物の残り長さを戻す（消費済みの反物は利用可能に戻す）
+	result, err = tx.ExecContext(ctx, `
+		UPDATE fabric_rolls
+		SET current_length = current_length + $3,
+		    status = CASE WHEN status = 'CONSUMED' THEN 'AVAILABLE' ELSE status END,
+		    updated_at = NOW()
+		WHERE id = $1 AND tenant_id = $2
+	`, allocation.FabricRollID, allocation.TenantID, allocation.AllocatedLength)
+	if err != nil {
+		return false, fmt.Errorf("failed to restore roll length: %w", err)
+	}
+	rowsAffected, err = result.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("failed to restore roll length: %w", err)
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return false, fmt.Errorf("fabric roll not found or tenant_id mismatch")
 	}
 	
-	// 引当をキャンセル
-	allocation.Cancel()
-	err = s.fabricAllocationRepo.Update(ctx, allocation)
+	return true, nil
+}
+
+// ReleaseOrderAllocationsInTx トランザクション内で注文の引当を全て解除
+// 裁断済みの引当は生地を消費しているため解除しない
+func (s *InventoryAllocationService) ReleaseOrderAllocationsInTx(ctx context.Context, tx *sql.Tx, orderID string, tenantID string) ([]*domain.FabricAllocation, error) {
+	allocations, err := s.fabricAllocationRepo.GetByOrderID(ctx, orderID, tenantID)
 	if err != nil {
-		return fmt.Errorf("failed to cancel allocation: %w", err)
+		return nil, fmt.Errorf("failed to get allocations: %w", err)
 	}
 	
-	// トランザクションコミット
-	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
+	released := make([]*domain.FabricAllocation, 0, len(allocations))
+	for _, allocation := range allocations {
+		if allocation.Status == domain.FabricAllocationStatusCancelled || allocation.Status == domain.FabricAllocationStatusCut {
+			continue
+		}
+		ok, err := s.ReleaseAllocationInTx(ctx, tx, allocation)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			released = append(released, allocation)
+		}
 	}
 	
-	return nil
+	return released, nil
 }
 
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jung-kurt/gofpdf/v2"
+	"tailor-cloud/backend/internal/config/domain"
+	"tailor-cloud/backend/internal/repository"
+)
+
+// InventoryReportService 生地在庫レポートサービス
+// 生地ごとに反物の残り長さ・引当可能な長さ・在庫金額を集計し、テナントの生地の単位で出力する
+type InventoryReportService struct {
+	fabricRepo   repository.FabricRepository
+	rollRepo     repository.FabricRollRepository
+	unitService  *UnitService  // 生地の表示単位（nilの場合はm）
+	jpFontHelper *JPFontHelper // 日本語フォントヘルパー
+}
+
+// NewInventoryReportService InventoryReportServiceのコンストラクタ
+func NewInventoryReportService(
+	fabricRepo repository.FabricRepository,
+	rollRepo repository.FabricRollRepository,
+	unitService *UnitService,
+) *InventoryReportService {
+	return &InventoryReportService{
+		fabricRepo:   fabricRepo,
+		rollRepo:     rollRepo,
+		unitService:  unitService,
+		jpFontHelper: NewJPFontHelper(GetFontDir()),
+	}
+}
+
+// GetReport 生地在庫レポートを作成（unit が空の場合はテナントの生地の単位）
+func (s *InventoryReportService) GetReport(ctx context.Context, tenantID string, unit domain.FabricUnit) (*domain.InventoryReport, error) {
+	if tenantID == "" {
+		return nil, fmt.Errorf("tenant_id is required")
+	}
+	if unit == "" {
+		unit = s.unitService.GetPreference(ctx, tenantID, "").FabricUnit
+	}
+	if !unit.IsValid() {
+		return nil, fmt.Errorf("invalid length_unit: %s", unit)
+	}
+
+	fabrics, err := s.fabricRepo.GetAll(ctx, tenantID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fabrics: %w", err)
+	}
+	rollsByFabric := make(map[string][]*domain.FabricRoll, len(fabrics))
+	for _, fabric := range fabrics {
+		rolls, err := s.rollRepo.ListByFabricID(ctx, tenantID, fabric.ID, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list fabric rolls: %w", err)
+		}
+		rollsByFabric[fabric.ID] = rolls
+	}
+
+	return domain.BuildInventoryReport(tenantID, fabrics, rollsByFabric, unit, time.Now()), nil
+}
+
+// GenerateReportPDF 生地在庫レポートPDFを生成
+func (s *InventoryReportService) GenerateReportPDF(ctx context.Context, tenantID string, unit domain.FabricUnit) ([]byte, error) {
+	report, err := s.GetReport(ctx, tenantID, unit)
+	if err != nil {
+		return nil, err
+	}
+
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetTitle("在庫レポート", false)
+	pdf.SetAuthor("TailorCloud", false)
+	pdf.AddPage()
+
+	// 日本語フォントを登録
+	if err := s.jpFontHelper.RegisterJPFonts(pdf); err != nil {
+		// フォント登録に失敗した場合は警告を出して続行（英語フォントで代替）
+		fmt.Printf("WARNING: Failed to register Japanese fonts: %v\n", err)
+	}
+
+	// タイトル
+	s.jpFontHelper.SetJPFont(pdf, "B", 16)
+	pdf.CellFormat(190, 10, "在庫レポート", "", 1, "C", false, 0, "")
+	pdf.Ln(2)
+	s.jpFontHelper.SetJPFont(pdf, "", 10)
+	pdf.CellFormat(190, 6, fmt.Sprintf("作成日時: %s　単位: %s", report.GeneratedAt.Format("2006年01月02日 15:04"), report.LengthUnit), "", 1, "R", false, 0, "")
+	pdf.Ln(3)
+
+	// 明細
+	s.jpFontHelper.SetJPFont(pdf, "B", 9)
+	pdf.CellFormat(50, 7, "生地", "1", 0, "C", false, 0, "")
+	pdf.CellFormat(15, 7, "反数", "1", 0, "C", false, 0, "")
+	pdf.CellFormat(25, 7, fmt.Sprintf("残り (%s)", report.LengthUnit), "1", 0, "C", false, 0, "")
+	pdf.CellFormat(25, 7, fmt.Sprintf("引当可能 (%s)", report.LengthUnit), "1", 0, "C", false, 0, "")
+	pdf.CellFormat(20, 7, fmt.Sprintf("破損 (%s)", report.LengthUnit), "1", 0, "C", false, 0, "")
+	pdf.CellFormat(25, 7, fmt.Sprintf("単価 (円/%s)", report.LengthUnit), "1", 0, "C", false, 0, "")
+	pdf.CellFormat(30, 7, "在庫金額", "1", 1, "C", false, 0, "")
+	s.jpFontHelper.SetJPFont(pdf, "", 9)
+	for _, line := range report.Lines {
+		pdf.CellFormat(50, 6, line.FabricName, "1", 0, "L", false, 0, "")
+		pdf.CellFormat(15, 6, fmt.Sprintf("%d", line.RollCount), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(25, 6, fmt.Sprintf("%.2f", line.RollLength), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(25, 6, fmt.Sprintf("%.2f", line.AvailableLength), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(20, 6, fmt.Sprintf("%.2f", line.DamagedLength), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(25, 6, formatCurrency(line.UnitPrice), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(30, 6, formatCurrency(line.StockValue), "1", 1, "R", false, 0, "")
+	}
+
+	// 合計
+	s.jpFontHelper.SetJPFont(pdf, "B", 9)
+	pdf.CellFormat(50, 7, "合計", "1", 0, "L", false, 0, "")
+	pdf.CellFormat(15, 7, fmt.Sprintf("%d", report.TotalRolls), "1", 0, "R", false, 0, "")
+	pdf.CellFormat(25, 7, fmt.Sprintf("%.2f", report.TotalLength), "1", 0, "R", false, 0, "")
+	pdf.CellFormat(25, 7, fmt.Sprintf("%.2f", report.AvailableLength), "1", 0, "R", false, 0, "")
+	pdf.CellFormat(45, 7, "", "1", 0, "R", false, 0, "")
+	pdf.CellFormat(30, 7, formatCurrency(report.TotalValue), "1", 1, "R", false, 0, "")
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("failed to output PDF: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
@@ -19,6 +19,7 @@ type InvoiceService struct {
 	orderRepo    repository.OrderRepository
 	tenantRepo   repository.TenantRepository
 	customerRepo repository.CustomerRepository
+	campaignRepo repository.CampaignRepository // 割引明細の取得用（nilの場合は値引きなし）
 	storageService StorageService
 	bucketName   string
 	taxService   *TaxCalculationService
@@ -29,6 +30,7 @@ func NewInvoiceService(
 	orderRepo repository.OrderRepository,
 	tenantRepo repository.TenantRepository,
 	customerRepo repository.CustomerRepository,
+	campaignRepo repository.CampaignRepository,
 	storageService StorageService,
 	bucketName string,
 	taxService *TaxCalculationService,
@@ -37,6 +39,7 @@ func NewInvoiceService(
 		orderRepo:      orderRepo,
 		tenantRepo:     tenantRepo,
 		customerRepo:   customerRepo,
+		campaignRepo:   campaignRepo,
 		storageService: storageService,
 		bucketName:     bucketName,
 		taxService:     taxService,
@@ -107,8 +110,17 @@ func (s *InvoiceService) GenerateInvoice(ctx context.Context, req *InvoiceReques
 		taxExcludedAmount = *order.TaxExcludedAmount
 	}
 
+	// 割引明細を取得（注文の金額は割引後の金額）
+	discounts := make([]*domain.OrderDiscount, 0)
+	if s.campaignRepo != nil {
+		discounts, err = s.campaignRepo.GetOrderDiscounts(ctx, order.ID, order.TenantID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get order discounts: %w", err)
+		}
+	}
+
 	// PDFを生成
-	pdfBytes, err := s.generateInvoicePDF(order, tenant, customer, taxExcludedAmount, taxAmount, taxRate)
+	pdfBytes, err := s.generateInvoicePDF(order, tenant, customer, discounts, taxExcludedAmount, taxAmount, taxRate)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate invoice PDF: %w", err)
 	}
@@ -147,6 +159,7 @@ func (s *InvoiceService) generateInvoicePDF(
 	order *domain.Order,
 	tenant *domain.Tenant,
 	customer *domain.Customer,
+	discounts []*domain.OrderDiscount,
 	taxExcludedAmount int64,
 	taxAmount int64,
 	taxRate domain.TaxRate,
@@ -230,12 +243,30 @@ func (s *InvoiceService) generateInvoicePDF(
 		description = order.Details.Description
 	}
 	
-	pdf.CellFormat(30, 7, "Order", "1", 0, "L", false, 0, "")
-	pdf.CellFormat(40, 7, description, "1", 0, "L", false, 0, "")
-	pdf.CellFormat(30, 7, fmt.Sprintf("¥%s", formatCurrency(taxExcludedAmount)), "1", 0, "R", false, 0, "")
-	pdf.CellFormat(30, 7, fmt.Sprintf("¥%s (%s)", formatCurrency(taxAmount), domain.FormatTaxRate(taxRate)), "1", 0, "R", false, 0, "")
-	pdf.CellFormat(30, 7, fmt.Sprintf("¥%s", formatCurrency(taxExcludedAmount+taxAmount)), "1", 0, "R", false, 0, "")
-	pdf.Ln(7)
+	if len(discounts) == 0 {
+		pdf.CellFormat(30, 7, "Order", "1", 0, "L", false, 0, "")
+		pdf.CellFormat(40, 7, description, "1", 0, "L", false, 0, "")
+		pdf.CellFormat(30, 7, fmt.Sprintf("¥%s", formatCurrency(taxExcludedAmount)), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(30, 7, fmt.Sprintf("¥%s (%s)", formatCurrency(taxAmount), domain.FormatTaxRate(taxRate)), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(30, 7, fmt.Sprintf("¥%s", formatCurrency(taxExcludedAmount+taxAmount)), "1", 0, "R", false, 0, "")
+		pdf.Ln(7)
+	} else {
+		// 割引がある場合: 割引前の金額と値引き行を記載し、消費税は値引き後の合計に対して1回計算
+		pdf.CellFormat(30, 7, "Order", "1", 0, "L", false, 0, "")
+		pdf.CellFormat(40, 7, description, "1", 0, "L", false, 0, "")
+		pdf.CellFormat(30, 7, fmt.Sprintf("¥%s", formatCurrency(taxExcludedAmount+domain.TotalDiscountAmount(discounts))), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(30, 7, domain.FormatTaxRate(taxRate), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(30, 7, "-", "1", 0, "R", false, 0, "")
+		pdf.Ln(7)
+		for _, discount := range discounts {
+			pdf.CellFormat(30, 7, "Discount", "1", 0, "L", false, 0, "")
+			pdf.CellFormat(40, 7, discount.Description, "1", 0, "L", false, 0, "")
+			pdf.CellFormat(30, 7, fmt.Sprintf("-¥%s", formatCurrency(discount.Amount)), "1", 0, "R", false, 0, "")
+			pdf.CellFormat(30, 7, domain.FormatTaxRate(taxRate), "1", 0, "R", false, 0, "")
+			pdf.CellFormat(30, 7, "-", "1", 0, "R", false, 0, "")
+			pdf.Ln(7)
+		}
+	}
 
 	// 合計行
 	pdf.SetFont("Arial", "B", 10)
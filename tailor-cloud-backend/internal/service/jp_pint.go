@@ -0,0 +1,464 @@
+package service
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"time"
+
+	"tailor-cloud/backend/internal/config/domain"
+)
+
+// JP PINT（Peppol International Invoicing Model - Japan）の識別子
+const (
+	jpPintCustomizationID = "urn:peppol:pint:billing-1@jp-1"
+	jpPintProfileID       = "urn:peppol:bis:billing"
+	ublInvoiceNamespace   = "urn:oasis:names:specification:ubl:schema:xsd:Invoice-2"
+	ublCacNamespace       = "urn:oasis:names:specification:ubl:schema:xsd:CommonAggregateComponents-2"
+	ublCbcNamespace       = "urn:oasis:names:specification:ubl:schema:xsd:CommonBasicComponents-2"
+
+	ublInvoiceTypeCommercial = "380" // 商業請求書
+	ublUnitCodePiece         = "C62" // 個数（one）
+	ublTaxSchemeVAT          = "VAT"
+	ublTaxCategoryStandard   = "S"    // 標準税率
+	ublTaxCategoryReduced    = "AA"   // 軽減税率
+	peppolSchemeJPCorporate  = "0188" // 法人番号
+)
+
+// jpRegistrationNumberPattern 適格請求書発行事業者登録番号（T + 13桁）
+var jpRegistrationNumberPattern = regexp.MustCompile(`^T[0-9]{13}$`)
+
+// UBLInvoice UBL 2.1 Invoice（JP PINT）
+// encoding/xml は名前空間プレフィックスを扱えないため、要素名に cac: / cbc: を含めて出力する
+type UBLInvoice struct {
+	XMLName                 xml.Name             `xml:"Invoice"`
+	Xmlns                   string               `xml:"xmlns,attr"`
+	XmlnsCac                string               `xml:"xmlns:cac,attr"`
+	XmlnsCbc                string               `xml:"xmlns:cbc,attr"`
+	CustomizationID         string               `xml:"cbc:CustomizationID"`
+	ProfileID               string               `xml:"cbc:ProfileID"`
+	ID                      string               `xml:"cbc:ID"`
+	IssueDate               string               `xml:"cbc:IssueDate"`
+	DueDate                 string               `xml:"cbc:DueDate,omitempty"`
+	InvoiceTypeCode         string               `xml:"cbc:InvoiceTypeCode"`
+	DocumentCurrencyCode    string               `xml:"cbc:DocumentCurrencyCode"`
+	BuyerReference          string               `xml:"cbc:BuyerReference,omitempty"`
+	AccountingSupplierParty UBLPartyWrapper      `xml:"cac:AccountingSupplierParty"`
+	AccountingCustomerParty UBLPartyWrapper      `xml:"cac:AccountingCustomerParty"`
+	Delivery                *UBLDelivery         `xml:"cac:Delivery,omitempty"`
+	AllowanceCharges        []UBLAllowanceCharge `xml:"cac:AllowanceCharge"`
+	TaxTotal                UBLTaxTotal          `xml:"cac:TaxTotal"`
+	LegalMonetaryTotal      UBLMonetaryTotal     `xml:"cac:LegalMonetaryTotal"`
+	InvoiceLines            []UBLInvoiceLine     `xml:"cac:InvoiceLine"`
+}
+
+// UBLDelivery 納品情報
+type UBLDelivery struct {
+	ActualDeliveryDate string `xml:"cbc:ActualDeliveryDate"`
+}
+
+// UBLAllowanceCharge 文書レベルの値引き（ChargeIndicator = false）・追加請求
+type UBLAllowanceCharge struct {
+	ChargeIndicator       bool           `xml:"cbc:ChargeIndicator"`
+	AllowanceChargeReason string         `xml:"cbc:AllowanceChargeReason,omitempty"`
+	Amount                UBLAmount      `xml:"cbc:Amount"`
+	TaxCategory           UBLTaxCategory `xml:"cac:TaxCategory"`
+}
+
+// UBLPartyWrapper AccountingSupplierParty / AccountingCustomerParty
+type UBLPartyWrapper struct {
+	Party UBLParty `xml:"cac:Party"`
+}
+
+// UBLParty 当事者
+type UBLParty struct {
+	EndpointID       *UBLIdentifier      `xml:"cbc:EndpointID,omitempty"`
+	PartyName        *UBLPartyName       `xml:"cac:PartyName,omitempty"`
+	PostalAddress    *UBLAddress         `xml:"cac:PostalAddress,omitempty"`
+	PartyTaxScheme   *UBLPartyTaxScheme  `xml:"cac:PartyTaxScheme,omitempty"`
+	PartyLegalEntity UBLPartyLegalEntity `xml:"cac:PartyLegalEntity"`
+	Contact          *UBLContact         `xml:"cac:Contact,omitempty"`
+}
+
+// UBLIdentifier スキームID付きの識別子
+type UBLIdentifier struct {
+	SchemeID string `xml:"schemeID,attr,omitempty"`
+	Value    string `xml:",chardata"`
+}
+
+// UBLPartyName 当事者名
+type UBLPartyName struct {
+	Name string `xml:"cbc:Name"`
+}
+
+// UBLAddress 住所
+type UBLAddress struct {
+	StreetName string     `xml:"cbc:StreetName,omitempty"`
+	Country    UBLCountry `xml:"cac:Country"`
+}
+
+// UBLCountry 国
+type UBLCountry struct {
+	IdentificationCode string `xml:"cbc:IdentificationCode"`
+}
+
+// UBLPartyTaxScheme 登録番号（T番号）
+type UBLPartyTaxScheme struct {
+	CompanyID string       `xml:"cbc:CompanyID"`
+	TaxScheme UBLTaxScheme `xml:"cac:TaxScheme"`
+}
+
+// UBLTaxScheme 税スキーム
+type UBLTaxScheme struct {
+	ID string `xml:"cbc:ID"`
+}
+
+// UBLPartyLegalEntity 法的名称
+type UBLPartyLegalEntity struct {
+	RegistrationName string `xml:"cbc:RegistrationName"`
+}
+
+// UBLContact 連絡先
+type UBLContact struct {
+	ElectronicMail string `xml:"cbc:ElectronicMail,omitempty"`
+}
+
+// UBLAmount 通貨付き金額
+type UBLAmount struct {
+	CurrencyID string `xml:"currencyID,attr"`
+	Value      int64  `xml:",chardata"`
+}
+
+// UBLQuantity 単位付き数量
+type UBLQuantity struct {
+	UnitCode string `xml:"unitCode,attr"`
+	Value    int64  `xml:",chardata"`
+}
+
+// UBLTaxTotal 消費税合計
+type UBLTaxTotal struct {
+	TaxAmount    UBLAmount        `xml:"cbc:TaxAmount"`
+	TaxSubtotals []UBLTaxSubtotal `xml:"cac:TaxSubtotal"`
+}
+
+// UBLTaxSubtotal 税率ごとの合計
+type UBLTaxSubtotal struct {
+	TaxableAmount UBLAmount      `xml:"cbc:TaxableAmount"`
+	TaxAmount     UBLAmount      `xml:"cbc:TaxAmount"`
+	TaxCategory   UBLTaxCategory `xml:"cac:TaxCategory"`
+}
+
+// UBLTaxCategory 税区分
+type UBLTaxCategory struct {
+	ID        string       `xml:"cbc:ID"`
+	Percent   string       `xml:"cbc:Percent"`
+	TaxScheme UBLTaxScheme `xml:"cac:TaxScheme"`
+}
+
+// UBLMonetaryTotal 請求金額合計
+type UBLMonetaryTotal struct {
+	LineExtensionAmount  UBLAmount  `xml:"cbc:LineExtensionAmount"`
+	TaxExclusiveAmount   UBLAmount  `xml:"cbc:TaxExclusiveAmount"`
+	TaxInclusiveAmount   UBLAmount  `xml:"cbc:TaxInclusiveAmount"`
+	AllowanceTotalAmount *UBLAmount `xml:"cbc:AllowanceTotalAmount,omitempty"`
+	PayableAmount        UBLAmount  `xml:"cbc:PayableAmount"`
+}
+
+// UBLInvoiceLine 請求明細
+type UBLInvoiceLine struct {
+	ID                  string      `xml:"cbc:ID"`
+	InvoicedQuantity    UBLQuantity `xml:"cbc:InvoicedQuantity"`
+	LineExtensionAmount UBLAmount   `xml:"cbc:LineExtensionAmount"`
+	Item                UBLItem     `xml:"cac:Item"`
+	Price               UBLPrice    `xml:"cac:Price"`
+}
+
+// UBLItem 品目
+type UBLItem struct {
+	Name                  string         `xml:"cbc:Name"`
+	ClassifiedTaxCategory UBLTaxCategory `xml:"cac:ClassifiedTaxCategory"`
+}
+
+// UBLPrice 単価
+type UBLPrice struct {
+	PriceAmount UBLAmount `xml:"cbc:PriceAmount"`
+}
+
+// BuildJPPINTInvoice 請求書データモデルを JP PINT UBL 2.1 Invoice に変換
+func BuildJPPINTInvoice(inv *domain.Invoice) *UBLInvoice {
+	currency := inv.Currency
+	if currency == "" {
+		currency = domain.CurrencyJPY
+	}
+	amount := func(v int64) UBLAmount { return UBLAmount{CurrencyID: currency, Value: v} }
+
+	doc := &UBLInvoice{
+		Xmlns:                   ublInvoiceNamespace,
+		XmlnsCac:                ublCacNamespace,
+		XmlnsCbc:                ublCbcNamespace,
+		CustomizationID:         jpPintCustomizationID,
+		ProfileID:               jpPintProfileID,
+		ID:                      inv.ID,
+		IssueDate:               formatUBLDate(inv.IssueDate),
+		DueDate:                 formatUBLDate(inv.DueDate),
+		InvoiceTypeCode:         ublInvoiceTypeCommercial,
+		DocumentCurrencyCode:    currency,
+		BuyerReference:          domain.InvoiceReference(inv.OrderID),
+		AccountingSupplierParty: UBLPartyWrapper{Party: buildUBLParty(&inv.Seller)},
+		AccountingCustomerParty: UBLPartyWrapper{Party: buildUBLParty(&inv.Buyer)},
+		TaxTotal: UBLTaxTotal{
+			TaxAmount: amount(inv.TaxAmount),
+		},
+		LegalMonetaryTotal: UBLMonetaryTotal{
+			LineExtensionAmount: amount(inv.LineExtensionAmount),
+			TaxExclusiveAmount:  amount(inv.TaxExclusiveAmount),
+			TaxInclusiveAmount:  amount(inv.TaxInclusiveAmount),
+			PayableAmount:       amount(inv.TaxInclusiveAmount),
+		},
+	}
+
+	if !inv.DeliveryDate.IsZero() {
+		doc.Delivery = &UBLDelivery{ActualDeliveryDate: formatUBLDate(inv.DeliveryDate)}
+	}
+
+	// 値引き（割引・クーポン）は明細ではなく文書レベルの AllowanceCharge として出力する
+	for _, allowance := range inv.Allowances {
+		doc.AllowanceCharges = append(doc.AllowanceCharges, UBLAllowanceCharge{
+			ChargeIndicator:       false,
+			AllowanceChargeReason: allowance.Reason,
+			Amount:                amount(allowance.Amount),
+			TaxCategory:           buildUBLTaxCategory(allowance.TaxRate),
+		})
+	}
+	if len(inv.Allowances) > 0 {
+		allowanceTotal := amount(inv.AllowanceTotalAmount)
+		doc.LegalMonetaryTotal.AllowanceTotalAmount = &allowanceTotal
+	}
+
+	for _, subtotal := range inv.TaxSubtotals {
+		doc.TaxTotal.TaxSubtotals = append(doc.TaxTotal.TaxSubtotals, UBLTaxSubtotal{
+			TaxableAmount: amount(subtotal.TaxableAmount),
+			TaxAmount:     amount(subtotal.TaxAmount),
+			TaxCategory:   buildUBLTaxCategory(subtotal.TaxRate),
+		})
+	}
+
+	for _, line := range inv.Lines {
+		doc.InvoiceLines = append(doc.InvoiceLines, UBLInvoiceLine{
+			ID:                  line.ID,
+			InvoicedQuantity:    UBLQuantity{UnitCode: ublUnitCodePiece, Value: line.Quantity},
+			LineExtensionAmount: amount(line.Amount),
+			Item: UBLItem{
+				Name:                  line.Description,
+				ClassifiedTaxCategory: buildUBLTaxCategory(line.TaxRate),
+			},
+			Price: UBLPrice{PriceAmount: amount(line.UnitPrice)},
+		})
+	}
+
+	return doc
+}
+
+// MarshalJPPINTInvoice UBL Invoice をXMLに変換
+func MarshalJPPINTInvoice(doc *UBLInvoice) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+
+	encoder := xml.NewEncoder(&buf)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return nil, fmt.Errorf("failed to marshal UBL invoice: %w", err)
+	}
+	buf.WriteString("\n")
+
+	return buf.Bytes(), nil
+}
+
+// ValidateJPPINTInvoice JP PINT の必須項目・計算ルールを検証
+// 違反内容の一覧を返す（空であれば有効）
+func ValidateJPPINTInvoice(doc *UBLInvoice) []string {
+	var violations []string
+	fail := func(format string, args ...interface{}) {
+		violations = append(violations, fmt.Sprintf(format, args...))
+	}
+
+	// 文書レベルの必須項目
+	if doc.CustomizationID != jpPintCustomizationID {
+		fail("CustomizationID must be %s", jpPintCustomizationID)
+	}
+	if doc.ProfileID == "" {
+		fail("ProfileID is required")
+	}
+	if doc.ID == "" {
+		fail("invoice ID is required")
+	}
+	if doc.IssueDate == "" {
+		fail("IssueDate is required")
+	}
+	if doc.InvoiceTypeCode == "" {
+		fail("InvoiceTypeCode is required")
+	}
+	if doc.DocumentCurrencyCode != domain.CurrencyJPY {
+		fail("DocumentCurrencyCode must be %s", domain.CurrencyJPY)
+	}
+
+	// 売り手: 名称と登録番号（T番号）は適格請求書の記載事項
+	seller := doc.AccountingSupplierParty.Party
+	if seller.PartyLegalEntity.RegistrationName == "" {
+		fail("seller registration name is required")
+	}
+	if seller.PartyTaxScheme == nil || !jpRegistrationNumberPattern.MatchString(seller.PartyTaxScheme.CompanyID) {
+		fail("seller registration number must be T followed by 13 digits")
+	}
+	if seller.EndpointID == nil || seller.EndpointID.Value == "" {
+		fail("seller EndpointID is required")
+	}
+
+	// 買い手
+	if doc.AccountingCustomerParty.Party.PartyLegalEntity.RegistrationName == "" {
+		fail("buyer registration name is required")
+	}
+
+	// 明細
+	if len(doc.InvoiceLines) == 0 {
+		fail("at least one InvoiceLine is required")
+	}
+	var lineTotal int64
+	lineTotalsByCategory := make(map[string]int64)
+	for _, line := range doc.InvoiceLines {
+		if line.ID == "" {
+			fail("InvoiceLine ID is required")
+		}
+		if line.Item.Name == "" {
+			fail("InvoiceLine %s: item name is required", line.ID)
+		}
+		if line.InvoicedQuantity.Value*line.Price.PriceAmount.Value != line.LineExtensionAmount.Value {
+			fail("InvoiceLine %s: LineExtensionAmount must equal quantity × price", line.ID)
+		}
+		lineTotal += line.LineExtensionAmount.Value
+		lineTotalsByCategory[taxCategoryKey(line.Item.ClassifiedTaxCategory)] += line.LineExtensionAmount.Value
+	}
+
+	// 文書レベルの値引き: 税区分ごとの課税標準から控除する
+	var allowanceTotal int64
+	for _, allowance := range doc.AllowanceCharges {
+		if allowance.ChargeIndicator {
+			fail("document level charges are not supported")
+			continue
+		}
+		if allowance.Amount.Value <= 0 {
+			fail("AllowanceCharge Amount must be greater than 0")
+		}
+		allowanceTotal += allowance.Amount.Value
+		lineTotalsByCategory[taxCategoryKey(allowance.TaxCategory)] -= allowance.Amount.Value
+	}
+
+	// 税率ごとの合計
+	if len(doc.TaxTotal.TaxSubtotals) == 0 {
+		fail("at least one TaxSubtotal is required")
+	}
+	var subtotalTax int64
+	seen := make(map[string]bool)
+	for _, subtotal := range doc.TaxTotal.TaxSubtotals {
+		key := taxCategoryKey(subtotal.TaxCategory)
+		if seen[key] {
+			fail("duplicate TaxSubtotal for tax category %s", key)
+		}
+		seen[key] = true
+		if subtotal.TaxableAmount.Value != lineTotalsByCategory[key] {
+			fail("TaxSubtotal %s: TaxableAmount must equal the sum of line amounts less allowances for the category", key)
+		}
+		subtotalTax += subtotal.TaxAmount.Value
+	}
+	for key := range lineTotalsByCategory {
+		if !seen[key] {
+			fail("TaxSubtotal is missing for tax category %s", key)
+		}
+	}
+	if subtotalTax != doc.TaxTotal.TaxAmount.Value {
+		fail("TaxTotal TaxAmount must equal the sum of TaxSubtotal amounts")
+	}
+
+	// 金額合計
+	total := doc.LegalMonetaryTotal
+	if total.LineExtensionAmount.Value != lineTotal {
+		fail("LineExtensionAmount must equal the sum of InvoiceLine amounts")
+	}
+	var declaredAllowanceTotal int64
+	if total.AllowanceTotalAmount != nil {
+		declaredAllowanceTotal = total.AllowanceTotalAmount.Value
+	}
+	if declaredAllowanceTotal != allowanceTotal {
+		fail("AllowanceTotalAmount must equal the sum of AllowanceCharge amounts")
+	}
+	if total.TaxExclusiveAmount.Value != total.LineExtensionAmount.Value-allowanceTotal {
+		fail("TaxExclusiveAmount must equal LineExtensionAmount - AllowanceTotalAmount")
+	}
+	if total.TaxInclusiveAmount.Value != total.TaxExclusiveAmount.Value+doc.TaxTotal.TaxAmount.Value {
+		fail("TaxInclusiveAmount must equal TaxExclusiveAmount + TaxAmount")
+	}
+	if total.PayableAmount.Value != total.TaxInclusiveAmount.Value {
+		fail("PayableAmount must equal TaxInclusiveAmount")
+	}
+
+	return violations
+}
+
+// buildUBLParty 当事者情報を変換
+func buildUBLParty(party *domain.InvoiceParty) UBLParty {
+	ublParty := UBLParty{
+		PartyLegalEntity: UBLPartyLegalEntity{RegistrationName: party.Name},
+	}
+	if party.Name != "" {
+		ublParty.PartyName = &UBLPartyName{Name: party.Name}
+	}
+	if party.Address != "" {
+		ublParty.PostalAddress = &UBLAddress{
+			StreetName: party.Address,
+			Country:    UBLCountry{IdentificationCode: "JP"},
+		}
+	}
+	if party.RegistrationNumber != "" {
+		ublParty.PartyTaxScheme = &UBLPartyTaxScheme{
+			CompanyID: party.RegistrationNumber,
+			TaxScheme: UBLTaxScheme{ID: ublTaxSchemeVAT},
+		}
+		// Peppol のエンドポイントは T番号の数字部分（法人番号）を使用する
+		if jpRegistrationNumberPattern.MatchString(party.RegistrationNumber) {
+			ublParty.EndpointID = &UBLIdentifier{SchemeID: peppolSchemeJPCorporate, Value: party.RegistrationNumber[1:]}
+		}
+	}
+	if party.Email != "" {
+		ublParty.Contact = &UBLContact{ElectronicMail: party.Email}
+	}
+	return ublParty
+}
+
+// buildUBLTaxCategory 税率を税区分に変換
+func buildUBLTaxCategory(rate domain.TaxRate) UBLTaxCategory {
+	id := ublTaxCategoryStandard
+	if rate == domain.TaxRateReduced {
+		id = ublTaxCategoryReduced
+	}
+	return UBLTaxCategory{
+		ID:        id,
+		Percent:   strconv.FormatInt(int64(math.Round(float64(rate)*100)), 10),
+		TaxScheme: UBLTaxScheme{ID: ublTaxSchemeVAT},
+	}
+}
+
+// taxCategoryKey 税区分の識別キー（区分ID + 税率）
+func taxCategoryKey(category UBLTaxCategory) string {
+	return category.ID + "/" + category.Percent
+}
+
+// formatUBLDate UBLの日付形式（YYYY-MM-DD）、未設定の場合は空
+func formatUBLDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
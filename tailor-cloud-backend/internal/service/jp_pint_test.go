@@ -0,0 +1,141 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"tailor-cloud/backend/internal/config/domain"
+)
+
+// testInvoice 標準税率と軽減税率が混在する請求書
+func testInvoice() *domain.Invoice {
+	invoice := &domain.Invoice{
+		ID:        "1a2b3c4d-0000-0000-0000-000000000000",
+		OrderID:   "1a2b3c4d-0000-0000-0000-000000000000",
+		IssueDate: time.Date(2025, 4, 30, 0, 0, 0, 0, time.Local),
+		DueDate:   time.Date(2025, 5, 31, 0, 0, 0, 0, time.Local),
+		Currency:  domain.CurrencyJPY,
+		Seller: domain.InvoiceParty{
+			Name:               "株式会社テーラー",
+			RegistrationNumber: "T1234567890123",
+		},
+		Buyer: domain.InvoiceParty{Name: "山田太郎"},
+	}
+	invoice.AddLine("オーダースーツ", 1, 100001, domain.TaxRateStandard)
+	invoice.AddLine("ネクタイ", 2, 3000, domain.TaxRateStandard)
+	invoice.AddLine("菓子", 1, 1001, domain.TaxRateReduced)
+	invoice.CalculateTotals(domain.TaxRoundingMethodRoundDown)
+	return invoice
+}
+
+// TestInvoiceCalculateTotals 税率ごとに1回の端数処理のテスト
+func TestInvoiceCalculateTotals(t *testing.T) {
+	invoice := testInvoice()
+
+	if len(invoice.TaxSubtotals) != 2 {
+		t.Fatalf("Expected 2 tax subtotals, got %d", len(invoice.TaxSubtotals))
+	}
+	standard := invoice.TaxSubtotals[0]
+	if standard.TaxRate != domain.TaxRateStandard || standard.TaxableAmount != 106001 || standard.TaxAmount != 10600 {
+		t.Errorf("Unexpected standard subtotal: %+v", standard)
+	}
+	reduced := invoice.TaxSubtotals[1]
+	if reduced.TaxRate != domain.TaxRateReduced || reduced.TaxableAmount != 1001 || reduced.TaxAmount != 80 {
+		t.Errorf("Unexpected reduced subtotal: %+v", reduced)
+	}
+	if invoice.TaxInclusiveAmount != 106001+1001+10600+80 {
+		t.Errorf("Unexpected tax inclusive amount: %d", invoice.TaxInclusiveAmount)
+	}
+}
+
+// TestBuildJPPINTInvoice JP PINT XMLの生成と検証のテスト
+func TestBuildJPPINTInvoice(t *testing.T) {
+	doc := BuildJPPINTInvoice(testInvoice())
+
+	if violations := ValidateJPPINTInvoice(doc); len(violations) != 0 {
+		t.Fatalf("Expected valid invoice, got violations: %v", violations)
+	}
+
+	xmlBytes, err := MarshalJPPINTInvoice(doc)
+	if err != nil {
+		t.Fatalf("MarshalJPPINTInvoice returned error: %v", err)
+	}
+	xmlStr := string(xmlBytes)
+
+	for _, want := range []string{
+		`<Invoice xmlns="urn:oasis:names:specification:ubl:schema:xsd:Invoice-2"`,
+		`<cbc:CustomizationID>urn:peppol:pint:billing-1@jp-1</cbc:CustomizationID>`,
+		`<cbc:EndpointID schemeID="0188">1234567890123</cbc:EndpointID>`,
+		`<cbc:CompanyID>T1234567890123</cbc:CompanyID>`,
+		`<cbc:TaxAmount currencyID="JPY">10680</cbc:TaxAmount>`,
+		`<cbc:ID>AA</cbc:ID>`,
+		`<cbc:Percent>8</cbc:Percent>`,
+		`<cbc:PayableAmount currencyID="JPY">117682</cbc:PayableAmount>`,
+		`<cbc:InvoicedQuantity unitCode="C62">2</cbc:InvoicedQuantity>`,
+	} {
+		if !strings.Contains(xmlStr, want) {
+			t.Errorf("Expected XML to contain %s", want)
+		}
+	}
+}
+
+// TestValidateJPPINTInvoice_Violations 必須項目欠落・計算不一致の検出テスト
+func TestValidateJPPINTInvoice_Violations(t *testing.T) {
+	invoice := testInvoice()
+	invoice.Seller.RegistrationNumber = "1234"
+	doc := BuildJPPINTInvoice(invoice)
+	doc.LegalMonetaryTotal.PayableAmount.Value++
+
+	violations := ValidateJPPINTInvoice(doc)
+	joined := strings.Join(violations, "\n")
+	if !strings.Contains(joined, "registration number") {
+		t.Errorf("Expected registration number violation, got %v", violations)
+	}
+	if !strings.Contains(joined, "PayableAmount") {
+		t.Errorf("Expected PayableAmount violation, got %v", violations)
+	}
+}
+
+// TestBuildJPPINTInvoice_Allowance 値引き（割引・クーポン）を含む請求書のテスト
+func TestBuildJPPINTInvoice_Allowance(t *testing.T) {
+	invoice := testInvoice()
+	invoice.AddAllowance("秋のキャンペーン", 10000, domain.TaxRateStandard)
+	invoice.CalculateTotals(domain.TaxRoundingMethodRoundDown)
+
+	// 標準税率の課税標準は 106001 - 10000 = 96001、消費税は 9600
+	standard := invoice.TaxSubtotals[0]
+	if standard.TaxableAmount != 96001 || standard.TaxAmount != 9600 {
+		t.Errorf("Unexpected standard subtotal: %+v", standard)
+	}
+	if invoice.LineExtensionAmount != 107002 || invoice.AllowanceTotalAmount != 10000 || invoice.TaxExclusiveAmount != 97002 {
+		t.Errorf("Unexpected totals: line=%d allowance=%d exclusive=%d", invoice.LineExtensionAmount, invoice.AllowanceTotalAmount, invoice.TaxExclusiveAmount)
+	}
+
+	doc := BuildJPPINTInvoice(invoice)
+	if violations := ValidateJPPINTInvoice(doc); len(violations) != 0 {
+		t.Fatalf("Expected valid invoice, got violations: %v", violations)
+	}
+
+	xmlBytes, err := MarshalJPPINTInvoice(doc)
+	if err != nil {
+		t.Fatalf("MarshalJPPINTInvoice returned error: %v", err)
+	}
+	xmlStr := string(xmlBytes)
+	for _, want := range []string{
+		`<cbc:ChargeIndicator>false</cbc:ChargeIndicator>`,
+		`<cbc:AllowanceChargeReason>秋のキャンペーン</cbc:AllowanceChargeReason>`,
+		`<cbc:AllowanceTotalAmount currencyID="JPY">10000</cbc:AllowanceTotalAmount>`,
+		`<cbc:PayableAmount currencyID="JPY">106682</cbc:PayableAmount>`,
+	} {
+		if !strings.Contains(xmlStr, want) {
+			t.Errorf("Expected XML to contain %s", want)
+		}
+	}
+
+	// 値引きを明細合計に反映しない場合は違反
+	doc.LegalMonetaryTotal.TaxExclusiveAmount.Value = doc.LegalMonetaryTotal.LineExtensionAmount.Value
+	if violations := strings.Join(ValidateJPPINTInvoice(doc), "\n"); !strings.Contains(violations, "AllowanceTotalAmount") {
+		t.Errorf("Expected allowance violation, got %v", violations)
+	}
+}
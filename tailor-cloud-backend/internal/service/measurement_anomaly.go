@@ -0,0 +1,391 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"tailor-cloud/backend/internal/config/domain"
+)
+
+// 採寸値のチェック種別（ValidationAlert.Check）
+const (
+	measurementCheckRequired   = "required"   // 必須項目の欠落
+	measurementCheckRange      = "range"      // 正常範囲外
+	measurementCheckTypo       = "typo"       // 入力ミスの可能性（修正候補あり）
+	measurementCheckProportion = "proportion" // 身長に対する比率が不自然
+	measurementCheckPopulation = "population" // テナントの顧客の傾向から外れている
+	measurementCheckChange     = "change"     // 前回採寸との差
+)
+
+// 母集団（テナントの顧客ごとの最新の採寸データ）による妥当性チェック
+const (
+	measurementPopulationLimit      = 2000 // 母集団に使う顧客数の上限（採寸日の新しい順）
+	measurementPopulationMinSamples = 30   // 項目ごとに必要な標本数（未満の場合はチェックしない）
+	measurementPopulationSigma      = 3.0  // 予測値からの乖離の許容幅（残差の標準偏差の倍数）
+)
+
+// measurementTypoCorrection 入力ミスの候補（値に掛ける係数と理由）
+type measurementTypoCorrection struct {
+	factor float64
+	reason string
+}
+
+// measurementTypoCorrections よくある入力ミス（8.5と85、インチとセンチの取り違え）
+var measurementTypoCorrections = []measurementTypoCorrection{
+	{factor: 10, reason: "小数点の位置の誤り"},
+	{factor: 0.1, reason: "小数点の位置の誤り"},
+	{factor: 2.54, reason: "インチでの入力"},
+}
+
+// measurementHeightRatios 身長に対する採寸値の比率の目安（成人、cm同士）
+var measurementHeightRatios = map[string][2]float64{
+	"bust":           {0.42, 0.75},
+	"chest":          {0.42, 0.75},
+	"ob":             {0.42, 0.75},
+	"waist":          {0.33, 0.72},
+	"hip":            {0.45, 0.78},
+	"neck":           {0.18, 0.28},
+	"shoulder_width": {0.22, 0.30},
+	"sleeve":         {0.30, 0.40},
+	"inseam":         {0.38, 0.52},
+}
+
+// measurementWaistHipRatio ウエスト÷ヒップの目安
+var measurementWaistHipRatio = [2]float64{0.65, 1.25}
+
+// measurementRegression 採寸項目の母集団の統計（身長に対する単回帰と、身長によらない平均・標準偏差）
+type measurementRegression struct {
+	Samples        int     // 標本数
+	Mean           float64 // 平均
+	StdDev         float64 // 標準偏差
+	HeightSamples  int     // 身長もある標本数
+	Intercept      float64 // 回帰の切片
+	Slope          float64 // 回帰の傾き（身長1cmあたり）
+	ResidualStdDev float64 // 回帰の残差の標準偏差
+}
+
+// expected 身長から予測される値と許容幅の基準（標準偏差）
+func (r *measurementRegression) expected(height float64) (float64, float64, bool) {
+	if height > 0 && r.HeightSamples >= measurementPopulationMinSamples && r.ResidualStdDev > 0 {
+		return r.Intercept + r.Slope*height, r.ResidualStdDev, true
+	}
+	if r.Samples >= measurementPopulationMinSamples && r.StdDev > 0 {
+		return r.Mean, r.StdDev, true
+	}
+	return 0, 0, false
+}
+
+// measurementPopulation 採寸項目ごとの母集団の統計
+type measurementPopulation map[string]*measurementRegression
+
+// buildMeasurementPopulation テナントの顧客の採寸データから採寸項目ごとの統計を作成
+func buildMeasurementPopulation(samples []map[string]float64) measurementPopulation {
+	type sums struct {
+		n, sum, sumSq                float64
+		hn, sumH, sumY, sumHH, sumHY float64
+		sumYY                        float64
+	}
+	acc := make(map[string]*sums)
+	for _, sample := range samples {
+		height, hasHeight := sample["height"]
+		for key, value := range sample {
+			if key == "height" || value <= 0 {
+				continue
+			}
+			s, ok := acc[key]
+			if !ok {
+				s = &sums{}
+				acc[key] = s
+			}
+			s.n++
+			s.sum += value
+			s.sumSq += value * value
+			if hasHeight && height > 0 {
+				s.hn++
+				s.sumH += height
+				s.sumY += value
+				s.sumHH += height * height
+				s.sumHY += height * value
+				s.sumYY += value * value
+			}
+		}
+	}
+
+	population := make(measurementPopulation, len(acc))
+	for key, s := range acc {
+		r := &measurementRegression{Samples: int(s.n), HeightSamples: int(s.hn)}
+		r.Mean = s.sum / s.n
+		if s.n > 1 {
+			r.StdDev = math.Sqrt(math.Max(0, (s.sumSq-s.n*r.Mean*r.Mean)/(s.n-1)))
+		}
+		if s.hn > 2 {
+			sxx := s.sumHH - s.sumH*s.sumH/s.hn
+			sxy := s.sumHY - s.sumH*s.sumY/s.hn
+			syy := s.sumYY - s.sumY*s.sumY/s.hn
+			if sxx > 0 {
+				r.Slope = sxy / sxx
+				r.Intercept = (s.sumY - r.Slope*s.sumH) / s.hn
+				r.ResidualStdDev = math.Sqrt(math.Max(0, (syy-r.Slope*sxy)/(s.hn-2)))
+			}
+		}
+		population[key] = r
+	}
+	return population
+}
+
+// checkMeasurementRange 採寸テンプレートと母集団で採寸データ単体の妥当性をチェック
+// 必須項目・正常範囲に加え、入力ミスの修正候補、身長に対する比率、テナントの顧客の傾向を確認する
+func checkMeasurementRange(template *domain.MeasurementTemplate, measurements json.RawMessage, population measurementPopulation) []ValidationAlert {
+	values, err := domain.MeasurementValues(measurements)
+	if err != nil {
+		return []ValidationAlert{{
+			Field:    "parse_error",
+			Severity: "error",
+			Message:  fmt.Sprintf("Failed to parse measurements: %v", err),
+		}}
+	}
+	return checkMeasurementSet(template, values, population)
+}
+
+// checkMeasurementSet 採寸データ（数値の項目、cm）の妥当性をチェック
+// 値は項目の単位に換算してから正常範囲と比べ、アラートも項目の単位で表示する
+func checkMeasurementSet(template *domain.MeasurementTemplate, values map[string]float64, population measurementPopulation) []ValidationAlert {
+	var alerts []ValidationAlert
+	values = template.ValuesInFieldUnits(values)
+	flagged := make(map[string]bool)
+
+	// 修正候補を反映した値（入力ミスが他の項目のチェックに波及しないようにする）
+	corrected := make(map[string]float64, len(values))
+	for key, value := range values {
+		corrected[key] = value
+	}
+
+	// 1. 必須項目と正常範囲（身長を先に確定させ、他の項目の修正候補の判定に使う）
+	fields := make([]*domain.MeasurementTemplateField, 0, len(template.Fields))
+	for _, field := range template.Fields {
+		if field.Key == "height" {
+			fields = append([]*domain.MeasurementTemplateField{field}, fields...)
+		} else {
+			fields = append(fields, field)
+		}
+	}
+	for _, field := range fields {
+		unit := field.Unit
+		if unit == "" {
+			unit = domain.MeasurementUnitCM
+		}
+
+		value, ok := values[field.Key]
+		if !ok {
+			if field.Required {
+				alerts = append(alerts, ValidationAlert{
+					Field:    field.Key,
+					Unit:     unit,
+					Severity: "error",
+					Check:    measurementCheckRequired,
+					Message:  fmt.Sprintf("%sは必須の採寸項目です", field.Label),
+				})
+			}
+			continue
+		}
+		if value >= field.Min && value <= field.Max {
+			continue
+		}
+
+		flagged[field.Key] = true
+		height := plausibleHeight(template, corrected)
+		if suggestion, reason, ok := suggestTypoCorrection(field, value, height, population); ok {
+			corrected[field.Key] = suggestion
+			alerts = append(alerts, typoAlert(field.Key, field.Label, value, suggestion, reason, unit))
+			continue
+		}
+		alerts = append(alerts, ValidationAlert{
+			Field:    field.Key,
+			Current:  value,
+			Unit:     unit,
+			Severity: "error",
+			Check:    measurementCheckRange,
+			Message:  fmt.Sprintf("%sの値が異常です: %.1f%s（正常範囲: %g-%g%s）", field.Label, value, unit, field.Min, field.Max, unit),
+		})
+	}
+
+	// 2. 身長のインチ入力（範囲内でも、インチとして換算した方が体型の比率に合う場合）
+	if value, ok := values["height"]; ok && !flagged["height"] && template.Unit("height") == domain.MeasurementUnitCM {
+		converted := roundMeasurement(value * 2.54)
+		maxHeight := 250.0
+		if field := template.Field("height"); field != nil {
+			maxHeight = field.Max
+		}
+		if converted <= maxHeight {
+			asIs, asIsTotal := countPlausibleRatios(template, corrected, value)
+			asInch, _ := countPlausibleRatios(template, corrected, converted)
+			if asIsTotal >= 2 && asInch > asIs && asIs*2 < asIsTotal {
+				flagged["height"] = true
+				corrected["height"] = converted
+				alerts = append(alerts, typoAlert("height", template.Label("height"), value, converted, "インチでの入力", domain.MeasurementUnitCM))
+			}
+		}
+	}
+
+	// 3. 身長に対する比率
+	if height := plausibleHeight(template, corrected); height > 0 {
+		for _, key := range measurementKeysInTemplateOrder(template, corrected) {
+			band, ok := measurementHeightRatios[key]
+			if !ok || flagged[key] || template.Unit(key) != domain.MeasurementUnitCM {
+				continue
+			}
+			ratio := corrected[key] / height
+			if ratio < band[0] || ratio > band[1] {
+				flagged[key] = true
+				alerts = append(alerts, ValidationAlert{
+					Field:    key,
+					Current:  corrected[key],
+					Unit:     template.Unit(key),
+					Severity: "warning",
+					Check:    measurementCheckProportion,
+					Message: fmt.Sprintf("%sが身長に対して不自然です（身長比 %.2f、目安 %.2f-%.2f）",
+						template.Label(key), ratio, band[0], band[1]),
+				})
+			}
+		}
+	}
+	if waist, ok := corrected["waist"]; ok && !flagged["waist"] {
+		if hip, ok := corrected["hip"]; ok && !flagged["hip"] && hip > 0 {
+			ratio := waist / hip
+			if ratio < measurementWaistHipRatio[0] || ratio > measurementWaistHipRatio[1] {
+				flagged["waist"] = true
+				alerts = append(alerts, ValidationAlert{
+					Field:    "waist",
+					Current:  waist,
+					Unit:     template.Unit("waist"),
+					Severity: "warning",
+					Check:    measurementCheckProportion,
+					Message: fmt.Sprintf("ウエストとヒップの比率が不自然です（ウエスト÷ヒップ %.2f、目安 %.2f-%.2f）",
+						ratio, measurementWaistHipRatio[0], measurementWaistHipRatio[1]),
+				})
+			}
+		}
+	}
+
+	// 4. テナントの顧客の傾向（同じ身長の顧客からの予測）
+	if len(population) > 0 {
+		height := plausibleHeight(template, corrected)
+		for _, key := range measurementKeysInTemplateOrder(template, corrected) {
+			regression, ok := population[key]
+			if !ok || flagged[key] {
+				continue
+			}
+			expected, sd, ok := regression.expected(height)
+			if !ok {
+				continue
+			}
+			// 母集団の統計はcmのため項目の単位に換算する
+			unit := template.Unit(key)
+			if perUnit := unit.ToCentimeters(1); unit != domain.MeasurementUnitCM && perUnit > 0 {
+				expected, sd = expected/perUnit, sd/perUnit
+			}
+			value := corrected[key]
+			if math.Abs(value-expected) > measurementPopulationSigma*sd {
+				alerts = append(alerts, ValidationAlert{
+					Field:    key,
+					Current:  value,
+					Unit:     unit,
+					Severity: "warning",
+					Check:    measurementCheckPopulation,
+					Message: fmt.Sprintf("%sが当店の顧客の傾向から外れています: %.1f%s（予測: %.1f±%.1f%s）",
+						template.Label(key), value, unit, expected, measurementPopulationSigma*sd, unit),
+				})
+			}
+		}
+	}
+
+	return alerts
+}
+
+// suggestTypoCorrection 正常範囲外の値の修正候補（小数点の位置・インチ入力）
+// 正常範囲内の候補のうち、身長に対する比率の目安に合うものを優先し、予測値（なければ範囲の中央）に最も近いものを選ぶ
+// 身長自体がインチで入力されている場合は比率の目安に合う候補がないため、正常範囲内の候補から選ぶ
+func suggestTypoCorrection(field *domain.MeasurementTemplateField, value, height float64, population measurementPopulation) (float64, string, bool) {
+	target := (field.Min + field.Max) / 2
+	// 身長比の目安・母集団の統計はcmのため、cm以外の項目では使わない
+	inCentimeters := field.Unit == "" || field.Unit == domain.MeasurementUnitCM
+	band, hasBand := measurementHeightRatios[field.Key]
+	hasBand = hasBand && inCentimeters
+	if hasBand && height > 0 {
+		target = (band[0] + band[1]) / 2 * height
+	}
+	if regression, ok := population[field.Key]; ok && inCentimeters {
+		if expected, _, ok := regression.expected(height); ok {
+			target = expected
+		}
+	}
+
+	best, bestReason, bestFits, found := 0.0, "", false, false
+	for _, correction := range measurementTypoCorrections {
+		if correction.factor == 2.54 && field.Unit != "" && field.Unit != domain.MeasurementUnitCM {
+			continue
+		}
+		candidate := roundMeasurement(value * correction.factor)
+		if candidate < field.Min || candidate > field.Max {
+			continue
+		}
+		fits := true
+		if hasBand && height > 0 {
+			ratio := candidate / height
+			fits = ratio >= band[0] && ratio <= band[1]
+		}
+		if !found || (fits && !bestFits) ||
+			(fits == bestFits && math.Abs(candidate-target) < math.Abs(best-target)) {
+			best, bestReason, bestFits, found = candidate, correction.reason, fits, true
+		}
+	}
+	return best, bestReason, found
+}
+
+// countPlausibleRatios 身長を仮定したときに比率の目安に合う項目数と、比率を確認できた項目数
+func countPlausibleRatios(template *domain.MeasurementTemplate, values map[string]float64, height float64) (int, int) {
+	plausible, total := 0, 0
+	for key, band := range measurementHeightRatios {
+		value, ok := values[key]
+		if !ok || key == "height" || template.Unit(key) != domain.MeasurementUnitCM {
+			continue
+		}
+		total++
+		ratio := value / height
+		if ratio >= band[0] && ratio <= band[1] {
+			plausible++
+		}
+	}
+	return plausible, total
+}
+
+// plausibleHeight 比率の判定に使う身長（cm、修正候補を反映した値が正常範囲外の場合は0）
+func plausibleHeight(template *domain.MeasurementTemplate, values map[string]float64) float64 {
+	height, ok := values["height"]
+	if !ok || template.Unit("height") != domain.MeasurementUnitCM {
+		return 0
+	}
+	if field := template.Field("height"); field != nil && (height < field.Min || height > field.Max) {
+		return 0
+	}
+	return height
+}
+
+// typoAlert 入力ミスの可能性のアラート（修正候補つき）
+func typoAlert(key, label string, value, suggestion float64, reason string, unit domain.MeasurementUnit) ValidationAlert {
+	return ValidationAlert{
+		Field:          key,
+		Current:        value,
+		Unit:           unit,
+		Severity:       "error",
+		Check:          measurementCheckTypo,
+		SuggestedValue: &suggestion,
+		Message:        fmt.Sprintf("%sの値%gは入力ミスの可能性があります（%s）。%.1f%sではありませんか", label, value, reason, suggestion, unit),
+	}
+}
+
+// roundMeasurement 採寸値を0.1単位に丸める
+func roundMeasurement(value float64) float64 {
+	return math.Round(value*10) / 10
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"tailor-cloud/backend/internal/config/domain"
 	"tailor-cloud/backend/internal/repository"
 )
 
@@ -35,6 +36,38 @@ type RawMeasurement struct {
 	Knee   float64 `json:"knee"`   // 膝 (cm)
 	Calf   float64 `json:"calf"`   // ふくらはぎ (cm)
 	OB     float64 `json:"ob"`     // OB (Over Bust) - バスト上
+
+	ShoulderWidth float64 `json:"shoulder_width,omitempty"` // 肩幅 (cm)
+	BackLength    float64 `json:"back_length,omitempty"`    // 背丈（首の付け根からウエストまで）(cm)
+	Sleeve        float64 `json:"sleeve,omitempty"`         // 袖長（肩先から手首まで）(cm)
+	Neck          float64 `json:"neck,omitempty"`           // 首回り (cm)
+	Rise          float64 `json:"rise,omitempty"`           // 股上 (cm)
+	Inseam        float64 `json:"inseam,omitempty"`         // 股下 (cm)
+
+	Unit domain.MeasurementUnit `json:"unit,omitempty"` // 採寸値の単位（未指定はcm）
+}
+
+// InCentimeters 採寸値をセンチメートルに換算したコピー
+func (m *RawMeasurement) InCentimeters() (*RawMeasurement, error) {
+	unit := m.Unit
+	if unit == "" {
+		unit = domain.MeasurementUnitCM
+	}
+	if !unit.IsValid() {
+		return nil, fmt.Errorf("invalid measurement unit: %s", m.Unit)
+	}
+
+	converted := *m
+	for _, value := range []*float64{
+		&converted.Height, &converted.Bust, &converted.Waist, &converted.Hip,
+		&converted.Thigh, &converted.Knee, &converted.Calf, &converted.OB,
+		&converted.ShoulderWidth, &converted.BackLength, &converted.Sleeve,
+		&converted.Neck, &converted.Rise, &converted.Inseam,
+	} {
+		*value = unit.ToCentimeters(*value)
+	}
+	converted.Unit = domain.MeasurementUnitCM
+	return &converted, nil
 }
 
 // DiagnosisProfile 診断プロファイル
@@ -55,18 +88,36 @@ type Correction struct {
 
 // FinalMeasurement 仕上がり寸法（製造指示値）
 type FinalMeasurement struct {
-	JacketLength float64      `json:"jacket_length"` // ジャケット長
-	SleeveLength float64      `json:"sleeve_length"` // 袖長
-	Chest        float64      `json:"chest"`         // 胸囲
-	Waist        float64      `json:"waist"`         // ウエスト
-	Hip          float64      `json:"hip"`           // ヒップ
-	Thigh        float64      `json:"thigh"`         // 太もも
-	Knee         float64      `json:"knee"`          // 膝
-	Calf         float64      `json:"calf"`          // ふくらはぎ
-	Hem          float64      `json:"hem"`           // 裾幅
-	Corrections  []Correction `json:"corrections"`   // 適用された補正の履歴
+	JacketLength  float64      `json:"jacket_length"`  // ジャケット長
+	SleeveLength  float64      `json:"sleeve_length"`  // 袖長
+	ShoulderWidth float64      `json:"shoulder_width"` // 肩幅
+	Chest         float64      `json:"chest"`          // 胸囲（ゆとり込み）
+	HalfChest     float64      `json:"half_chest"`     // 胸囲の半分（型紙の身幅）
+	Waist         float64      `json:"waist"`          // ウエスト（パンツ）
+	Hip           float64      `json:"hip"`            // ヒップ
+	Thigh         float64      `json:"thigh"`          // 太もも
+	Knee          float64      `json:"knee"`           // 膝
+	Calf          float64      `json:"calf"`           // ふくらはぎ
+	Hem           float64      `json:"hem"`            // 裾幅
+	Rise          float64      `json:"rise"`           // 股上
+	Inseam        float64      `json:"inseam"`         // 股下
+	Neck          float64      `json:"neck,omitempty"` // シャツの首回り（首回りの採寸がある場合）
+	Corrections   []Correction `json:"corrections"`    // 適用された補正の履歴
 }
 
+// 仕上がり寸法の計算に使うゆとり量・調整量 (cm)
+const (
+	jacketChestBaseEase   = 8.0  // 胸囲の基本ゆとり（診断プロファイルのゆとり量を加算）
+	jacketLengthBelowBack = 31.0 // 背丈からヒップが隠れる位置までの長さ
+	jacketSleeveShirtShow = 1.5  // シャツの袖口を見せる分
+	jacketShoulderEase    = 1.0  // 肩パッド・いせ込みの分
+	trouserWaistEase      = 2.0  // パンツのウエストのゆとり
+	trouserRiseEase       = 1.0  // 座ったときの股上のゆとり
+	trouserInseamBreak    = 1.0  // 裾が靴に掛かる分（ストレートはハーフクッション、テーパードはノークッション）
+	shirtNeckEase         = 1.5  // シャツの首回りのゆとり（指1本分）
+	tightFitEaseReduction = 2.0  // タイトフィットの場合に胸囲のゆとりから減らす量
+)
+
 // ConvertToFinalMeasurementsRequest 変換リクエスト
 type ConvertToFinalMeasurementsRequest struct {
 	RawMeasurements *RawMeasurement `json:"raw_measurements"`
@@ -116,37 +167,24 @@ func (s *MeasurementCorrectionService) ConvertToFinalMeasurements(
 		return nil, fmt.Errorf("failed to get fabric: %w", err)
 	}
 
-	// 3. 初期値を設定（ヌード寸をベースに）
-	final := &FinalMeasurement{
-		Chest:       req.RawMeasurements.Bust,
-		Waist:       req.RawMeasurements.Waist,
-		Hip:         req.RawMeasurements.Hip,
-		Thigh:       req.RawMeasurements.Thigh,
-		Knee:        req.RawMeasurements.Knee,
-		Calf:        req.RawMeasurements.Calf,
-		Corrections: []Correction{},
+	// 3. ヌード寸をcmに換算し、仕上がり寸法を計算
+	if req.RawMeasurements == nil {
+		return nil, fmt.Errorf("raw_measurements is required")
 	}
-
-	// 4. OB差分補正を適用
-	if err := s.applyOBDifferenceCorrection(req.RawMeasurements, final); err != nil {
-		return nil, fmt.Errorf("OB difference correction failed: %w", err)
+	raw, err := req.RawMeasurements.InCentimeters()
+	if err != nil {
+		return nil, err
 	}
-
-	// 5. シルエット計算
-	if err := s.calculateSilhouette(req.RawMeasurements, profile, final); err != nil {
-		return nil, fmt.Errorf("silhouette calculation failed: %w", err)
+	final, err := s.convert(raw, profile)
+	if err != nil {
+		return nil, err
 	}
 
-	// 6. 生地の伸縮性を考慮した補正（将来実装）
+	// 生地の伸縮性を考慮した補正（将来実装）
 	// if err := s.applyFabricStretchCorrection(final, fabric); err != nil {
 	// 	return nil, fmt.Errorf("fabric stretch correction failed: %w", err)
 	// }
 
-	// 7. バリデーション
-	if err := s.validateMeasurements(final, req.RawMeasurements); err != nil {
-		return nil, fmt.Errorf("measurement validation failed: %w", err)
-	}
-
 	// fabric を使用して未使用変数エラーを回避
 	_ = fabric
 
@@ -155,6 +193,203 @@ func (s *MeasurementCorrectionService) ConvertToFinalMeasurements(
 	}, nil
 }
 
+// convert ヌード寸から仕上がり寸法を計算
+// 各ステップで採用した値と理由を Corrections に記録し、工場が数値の根拠を確認できるようにする
+func (s *MeasurementCorrectionService) convert(
+	raw *RawMeasurement,
+	profile *DiagnosisProfile,
+) (*FinalMeasurement, error) {
+	// 1. 初期値を設定（ヌード寸をベースに）
+	final := &FinalMeasurement{
+		Chest:       raw.Bust,
+		Waist:       raw.Waist,
+		Hip:         raw.Hip,
+		Thigh:       raw.Thigh,
+		Knee:        raw.Knee,
+		Calf:        raw.Calf,
+		Corrections: []Correction{},
+	}
+
+	// 2. OB差分補正を適用
+	if err := s.applyOBDifferenceCorrection(raw, final); err != nil {
+		return nil, fmt.Errorf("OB difference correction failed: %w", err)
+	}
+
+	// 3. 胸囲のゆとり・身幅
+	s.applyChestEase(profile, final)
+
+	// 4. ジャケット（着丈・袖丈・肩幅）
+	s.calculateJacketSpec(raw, profile, final)
+
+	// 5. パンツ（ウエスト・股上・股下）
+	s.calculateTrouserSpec(raw, profile, final)
+
+	// 6. シャツ（首回り）
+	s.calculateShirtSpec(raw, final)
+
+	// 7. シルエット計算
+	if err := s.calculateSilhouette(raw, profile, final); err != nil {
+		return nil, fmt.Errorf("silhouette calculation failed: %w", err)
+	}
+
+	// 8. バリデーション
+	if err := s.validateMeasurements(final, raw); err != nil {
+		return nil, fmt.Errorf("measurement validation failed: %w", err)
+	}
+
+	return final, nil
+}
+
+// addCorrection 補正の履歴を追加（値は0.1cm単位に丸める）
+// 推定・計算による値は補正量を0とし、計算式を説明に記録する
+func (final *FinalMeasurement) addCorrection(correctionType string, value, adjustment float64, description string) {
+	final.Corrections = append(final.Corrections, Correction{
+		Type:        correctionType,
+		Value:       roundMeasurement(value),
+		Adjustment:  roundMeasurement(adjustment),
+		Description: description,
+	})
+}
+
+// applyChestEase 胸囲にゆとりを加え、型紙の身幅（胸囲の半分）を計算
+// ロジック: Chest + 8.0cm + Ease（タイトフィットは -2.0cm）
+func (s *MeasurementCorrectionService) applyChestEase(
+	profile *DiagnosisProfile,
+	final *FinalMeasurement,
+) {
+	if final.Chest <= 0 {
+		return
+	}
+
+	ease := jacketChestBaseEase + profile.Ease
+	description := fmt.Sprintf("胸囲ゆとり: %.1fcm (基本%.1fcm + %sのゆとり%.1fcm)", ease, jacketChestBaseEase, profile.Archetype, profile.Ease)
+	if profile.FitPreference == "tight" {
+		ease -= tightFitEaseReduction
+		description = fmt.Sprintf("胸囲ゆとり: %.1fcm (基本%.1fcm + %sのゆとり%.1fcm - タイトフィット%.1fcm)",
+			ease, jacketChestBaseEase, profile.Archetype, profile.Ease, tightFitEaseReduction)
+	}
+	chest := final.Chest
+	final.Chest = roundMeasurement(chest + ease)
+	final.addCorrection("CHEST_EASE", chest, ease, description)
+
+	final.HalfChest = roundMeasurement(final.Chest / 2.0)
+	final.addCorrection("HALF_CHEST", final.Chest, 0,
+		fmt.Sprintf("身幅: %.1fcm (ゆとり込み胸囲%.1fcm/2)", final.HalfChest, final.Chest))
+}
+
+// calculateJacketSpec ジャケットの着丈・袖丈・肩幅を計算
+// 採寸がない項目は身長・バストから推定し、推定であることを記録する
+func (s *MeasurementCorrectionService) calculateJacketSpec(
+	raw *RawMeasurement,
+	profile *DiagnosisProfile,
+	final *FinalMeasurement,
+) {
+	// 着丈: 背丈 + 31.0cm（背丈がない場合は 身長/2 - 13.0cm）
+	if raw.BackLength > 0 {
+		final.JacketLength = roundMeasurement(raw.BackLength + jacketLengthBelowBack)
+		final.addCorrection("JACKET_LENGTH", raw.BackLength, jacketLengthBelowBack,
+			fmt.Sprintf("着丈: %.1fcm (背丈%.1fcm + %.1fcm)", final.JacketLength, raw.BackLength, jacketLengthBelowBack))
+	} else if raw.Height > 0 {
+		final.JacketLength = roundMeasurement(raw.Height/2.0 - 13.0)
+		final.addCorrection("JACKET_LENGTH_ESTIMATED", raw.Height, 0,
+			fmt.Sprintf("着丈（推定）: %.1fcm (身長%.1fcm/2 - 13.0cm、背丈の採寸なし)", final.JacketLength, raw.Height))
+	}
+	// モダンは短め（-1.5cm）、エレガントは長め（+1.0cm）
+	if final.JacketLength > 0 {
+		adjustment := 0.0
+		switch profile.Archetype {
+		case "Modern":
+			adjustment = -1.5
+		case "Elegant":
+			adjustment = 1.0
+		}
+		if adjustment != 0 {
+			length := final.JacketLength
+			final.JacketLength = roundMeasurement(length + adjustment)
+			final.addCorrection("JACKET_LENGTH_ARCHETYPE", length, adjustment,
+				fmt.Sprintf("着丈の%s調整: %.1fcm (%+.1fcm)", profile.Archetype, final.JacketLength, adjustment))
+		}
+	}
+
+	// 袖丈: 袖長 - 1.5cm（シャツの袖口を見せる。袖長がない場合は 身長 × 0.345）
+	if raw.Sleeve > 0 {
+		final.SleeveLength = roundMeasurement(raw.Sleeve - jacketSleeveShirtShow)
+		final.addCorrection("SLEEVE_LENGTH", raw.Sleeve, -jacketSleeveShirtShow,
+			fmt.Sprintf("袖丈: %.1fcm (袖長%.1fcm - シャツの袖口%.1fcm)", final.SleeveLength, raw.Sleeve, jacketSleeveShirtShow))
+	} else if raw.Height > 0 {
+		final.SleeveLength = roundMeasurement(raw.Height*0.345 - jacketSleeveShirtShow)
+		final.addCorrection("SLEEVE_LENGTH_ESTIMATED", raw.Height, 0,
+			fmt.Sprintf("袖丈（推定）: %.1fcm (身長%.1fcm × 0.345 - %.1fcm、袖長の採寸なし)", final.SleeveLength, raw.Height, jacketSleeveShirtShow))
+	}
+
+	// 肩幅: 肩幅 + 1.0cm（肩パッド・いせ込み。肩幅がない場合は バスト/4 + 20.0cm）
+	if raw.ShoulderWidth > 0 {
+		final.ShoulderWidth = roundMeasurement(raw.ShoulderWidth + jacketShoulderEase)
+		final.addCorrection("SHOULDER_WIDTH", raw.ShoulderWidth, jacketShoulderEase,
+			fmt.Sprintf("肩幅: %.1fcm (肩幅%.1fcm + 肩パッド・いせ込み%.1fcm)", final.ShoulderWidth, raw.ShoulderWidth, jacketShoulderEase))
+	} else if raw.Bust > 0 {
+		final.ShoulderWidth = roundMeasurement(raw.Bust/4.0 + 20.0)
+		final.addCorrection("SHOULDER_WIDTH_ESTIMATED", raw.Bust, 0,
+			fmt.Sprintf("肩幅（推定）: %.1fcm (バスト%.1fcm/4 + 20.0cm、肩幅の採寸なし)", final.ShoulderWidth, raw.Bust))
+	}
+}
+
+// calculateTrouserSpec パンツのウエスト・股上・股下を計算
+func (s *MeasurementCorrectionService) calculateTrouserSpec(
+	raw *RawMeasurement,
+	profile *DiagnosisProfile,
+	final *FinalMeasurement,
+) {
+	// ウエスト: ウエスト + 2.0cm
+	if raw.Waist > 0 {
+		final.Waist = roundMeasurement(raw.Waist + trouserWaistEase)
+		final.addCorrection("TROUSER_WAIST", raw.Waist, trouserWaistEase,
+			fmt.Sprintf("パンツのウエスト: %.1fcm (ウエスト%.1fcm + ゆとり%.1fcm)", final.Waist, raw.Waist, trouserWaistEase))
+	}
+
+	// 股上: 股上 + 1.0cm（股上がない場合は ヒップ/4 + 3.0cm）
+	if raw.Rise > 0 {
+		final.Rise = roundMeasurement(raw.Rise + trouserRiseEase)
+		final.addCorrection("TROUSER_RISE", raw.Rise, trouserRiseEase,
+			fmt.Sprintf("股上: %.1fcm (股上%.1fcm + 座りのゆとり%.1fcm)", final.Rise, raw.Rise, trouserRiseEase))
+	} else if raw.Hip > 0 {
+		final.Rise = roundMeasurement(raw.Hip/4.0 + 3.0)
+		final.addCorrection("TROUSER_RISE_ESTIMATED", raw.Hip, 0,
+			fmt.Sprintf("股上（推定）: %.1fcm (ヒップ%.1fcm/4 + 3.0cm、股上の採寸なし)", final.Rise, raw.Hip))
+	}
+
+	// 股下: ストレートは +1.0cm（ハーフクッション）、テーパードは -1.0cm（ノークッション）
+	// 股下がない場合は 身長 × 0.45 から計算
+	inseam, correctionType, source := raw.Inseam, "TROUSER_INSEAM", fmt.Sprintf("股下%.1fcm", raw.Inseam)
+	if inseam <= 0 && raw.Height > 0 {
+		inseam = roundMeasurement(raw.Height * 0.45)
+		correctionType = "TROUSER_INSEAM_ESTIMATED"
+		source = fmt.Sprintf("推定股下%.1fcm（身長%.1fcm × 0.45、股下の採寸なし）", inseam, raw.Height)
+	}
+	if inseam > 0 {
+		adjustment, reason := -trouserInseamBreak, "ノークッション"
+		if profile.Silhouette == "straight" {
+			adjustment, reason = trouserInseamBreak, "ハーフクッション"
+		}
+		final.Inseam = roundMeasurement(inseam + adjustment)
+		final.addCorrection(correctionType, inseam, adjustment,
+			fmt.Sprintf("股下: %.1fcm (%s %+.1fcm %s)", final.Inseam, source, adjustment, reason))
+	}
+}
+
+// calculateShirtSpec シャツの首回りを計算（首回りの採寸がある場合のみ）
+func (s *MeasurementCorrectionService) calculateShirtSpec(
+	raw *RawMeasurement,
+	final *FinalMeasurement,
+) {
+	if raw.Neck <= 0 {
+		return
+	}
+	final.Neck = roundMeasurement(raw.Neck + shirtNeckEase)
+	final.addCorrection("SHIRT_NECK", raw.Neck, shirtNeckEase,
+		fmt.Sprintf("シャツの首回り: %.1fcm (首回り%.1fcm + ゆとり%.1fcm)", final.Neck, raw.Neck, shirtNeckEase))
+}
+
 // applyOBDifferenceCorrection OB差分補正を適用
 // ロジック: IF (OB - Bust >= 20) THEN Add Correction
 func (s *MeasurementCorrectionService) applyOBDifferenceCorrection(
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"testing"
+)
+
+// correctionTypes 補正の履歴を種類ごとにまとめる
+func correctionTypes(corrections []Correction) map[string]Correction {
+	got := make(map[string]Correction)
+	for _, correction := range corrections {
+		got[correction.Type] = correction
+	}
+	return got
+}
+
+// TestConvertFullSuitSpec 採寸データがそろっている場合のスーツの仕上がり寸法のテスト
+func TestConvertFullSuitSpec(t *testing.T) {
+	s := &MeasurementCorrectionService{}
+	raw := &RawMeasurement{
+		Height: 175, Bust: 92, Waist: 80, Hip: 96, Thigh: 56, Knee: 40, Calf: 36, OB: 100,
+		ShoulderWidth: 44, BackLength: 44, Sleeve: 62, Neck: 38, Rise: 26, Inseam: 78,
+	}
+	profile := &DiagnosisProfile{Archetype: "Classic", FitPreference: "relaxed", Silhouette: "tapered", Ease: 3.0}
+
+	final, err := s.convert(raw, profile)
+	if err != nil {
+		t.Fatalf("convert returned error: %v", err)
+	}
+
+	want := map[string][2]float64{
+		"chest":          {final.Chest, 103}, // 92 + 8 + 3
+		"half_chest":     {final.HalfChest, 51.5},
+		"jacket_length":  {final.JacketLength, 75}, // 44 + 31
+		"sleeve_length":  {final.SleeveLength, 60.5},
+		"shoulder_width": {final.ShoulderWidth, 45},
+		"waist":          {final.Waist, 82},
+		"rise":           {final.Rise, 27},
+		"inseam":         {final.Inseam, 77}, // テーパードはノークッション
+		"neck":           {final.Neck, 39.5},
+	}
+	for key, v := range want {
+		if v[0] != v[1] {
+			t.Errorf("%s = %.1f, want %.1f", key, v[0], v[1])
+		}
+	}
+
+	// 各ステップが補正の履歴に残る
+	got := correctionTypes(final.Corrections)
+	for _, correctionType := range []string{
+		"CHEST_EASE", "HALF_CHEST", "JACKET_LENGTH", "SLEEVE_LENGTH", "SHOULDER_WIDTH",
+		"TROUSER_WAIST", "TROUSER_RISE", "TROUSER_INSEAM", "SHIRT_NECK", "SILHOUETTE_TAPERED",
+	} {
+		if _, ok := got[correctionType]; !ok {
+			t.Errorf("missing correction %s in %+v", correctionType, final.Corrections)
+		}
+	}
+	if _, ok := got["OB_DIFFERENCE"]; ok {
+		t.Errorf("OB difference under 20cm should not be corrected")
+	}
+}
+
+// TestConvertEstimatedSpec 採寸がない項目を身長・バスト・ヒップから推定するテスト
+func TestConvertEstimatedSpec(t *testing.T) {
+	s := &MeasurementCorrectionService{}
+	raw := &RawMeasurement{Height: 170, Bust: 90, Waist: 75, Hip: 96, Knee: 40, Calf: 35, OB: 110}
+	profile := &DiagnosisProfile{Archetype: "Modern", FitPreference: "tight", Silhouette: "straight", Ease: 2.0}
+
+	final, err := s.convert(raw, profile)
+	if err != nil {
+		t.Fatalf("convert returned error: %v", err)
+	}
+
+	// OB差分20cm → +10cm、ゆとり 8 + 2 - 2（タイト）
+	if final.Chest != 108 {
+		t.Errorf("chest = %.1f, want 108", final.Chest)
+	}
+	// 170/2 - 13 = 72、モダンは -1.5
+	if final.JacketLength != 70.5 {
+		t.Errorf("jacket_length = %.1f, want 70.5", final.JacketLength)
+	}
+	if final.ShoulderWidth != 42.5 || final.Rise != 27 {
+		t.Errorf("shoulder_width = %.1f, rise = %.1f", final.ShoulderWidth, final.Rise)
+	}
+	// 170 × 0.45 = 76.5、ストレートはハーフクッション
+	if final.Inseam != 77.5 {
+		t.Errorf("inseam = %.1f, want 77.5", final.Inseam)
+	}
+	if final.Neck != 0 {
+		t.Errorf("neck = %.1f, want 0 without neck measurement", final.Neck)
+	}
+
+	got := correctionTypes(final.Corrections)
+	for _, correctionType := range []string{
+		"OB_DIFFERENCE", "JACKET_LENGTH_ESTIMATED", "JACKET_LENGTH_ARCHETYPE", "SLEEVE_LENGTH_ESTIMATED",
+		"SHOULDER_WIDTH_ESTIMATED", "TROUSER_RISE_ESTIMATED", "TROUSER_INSEAM_ESTIMATED", "SILHOUETTE_STRAIGHT",
+	} {
+		if _, ok := got[correctionType]; !ok {
+			t.Errorf("missing correction %s", correctionType)
+		}
+	}
+}
@@ -0,0 +1,354 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"tailor-cloud/backend/internal/config/domain"
+	"tailor-cloud/backend/internal/repository"
+)
+
+// MeasurementProfileService 顧客の採寸プロファイルサービス
+// 採寸のたびに採寸データを版として記録し、採寸者・体型メモ・その版を使った注文と、採寸値の推移を提供する
+type MeasurementProfileService struct {
+	profileRepo  repository.MeasurementProfileRepository
+	customerRepo repository.CustomerRepository // nilの場合は顧客の存在を確認しない
+	orderRepo    repository.OrderRepository
+	unitService  *UnitService // 採寸値の表示単位（nilの場合はcm）
+	db           *sql.DB      // トランザクション管理用（版の追加とプロファイルの最新版番号の同時更新）
+}
+
+// NewMeasurementProfileService MeasurementProfileServiceのコンストラクタ
+func NewMeasurementProfileService(
+	profileRepo repository.MeasurementProfileRepository,
+	customerRepo repository.CustomerRepository,
+	orderRepo repository.OrderRepository,
+	unitService *UnitService,
+	db *sql.DB,
+) *MeasurementProfileService {
+	return &MeasurementProfileService{
+		profileRepo:  profileRepo,
+		customerRepo: customerRepo,
+		orderRepo:    orderRepo,
+		unitService:  unitService,
+		db:           db,
+	}
+}
+
+// RecordMeasurementsRequest 採寸記録リクエスト（新しい版を追加）
+type RecordMeasurementsRequest struct {
+	TenantID       string          `json:"-"`
+	CustomerID     string          `json:"-"`
+	Measurements   json.RawMessage `json:"measurements"` // 単位（"unit"）の指定がない場合はcm
+	BodyShapeNotes string          `json:"body_shape_notes"`
+	Notes          string          `json:"notes"`
+	MeasuredAt     time.Time       `json:"measured_at"` // 未指定の場合は現在日時
+	OrderID        string          `json:"order_id"`    // この採寸データを使う注文（任意）
+	MeasuredBy     string          `json:"-"`           // 認証済みユーザー
+	IPAddress      string          `json:"-"`
+	UserAgent      string          `json:"-"`
+}
+
+// LinkMeasurementOrderRequest 版と注文の紐付けリクエスト
+type LinkMeasurementOrderRequest struct {
+	TenantID   string `json:"-"`
+	CustomerID string `json:"-"`
+	Version    int    `json:"-"`
+	OrderID    string `json:"order_id"`
+	UserID     string `json:"-"`
+}
+
+// RecordMeasurements 採寸データを採寸プロファイルの新しい版として記録
+// プロファイルがない顧客は初回の採寸でプロファイルを作成する
+func (s *MeasurementProfileService) RecordMeasurements(ctx context.Context, req *RecordMeasurementsRequest) (*domain.MeasurementProfile, error) {
+	if req.TenantID == "" {
+		return nil, fmt.Errorf("tenant_id is required")
+	}
+	if req.CustomerID == "" {
+		return nil, fmt.Errorf("customer_id is required")
+	}
+	if s.db == nil {
+		return nil, fmt.Errorf("database is not configured")
+	}
+	if req.MeasuredAt.IsZero() {
+		req.MeasuredAt = time.Now()
+	}
+	if req.MeasuredAt.After(time.Now()) {
+		return nil, fmt.Errorf("invalid measured_at: must not be in the future")
+	}
+
+	if err := s.checkCustomer(ctx, req.CustomerID, req.TenantID); err != nil {
+		return nil, err
+	}
+	if req.OrderID != "" {
+		if err := s.checkOrder(ctx, req.OrderID, req.CustomerID, req.TenantID); err != nil {
+			return nil, err
+		}
+	}
+
+	// 採寸値はcmに正規化して保存
+	measurements, err := domain.NormalizeMeasurementData(req.Measurements)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	profile, err := s.lockProfileInTx(ctx, tx, req.TenantID, req.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+
+	version := profile.NextVersion(measurements, strings.TrimSpace(req.BodyShapeNotes), req.Notes, req.MeasuredBy, req.MeasuredAt)
+	if err := version.Validate(); err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO measurement_profile_versions (
+			id, profile_id, tenant_id, customer_id, version, measurements,
+			body_shape_notes, notes, measured_by, measured_at, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`,
+		version.ID,
+		version.ProfileID,
+		version.TenantID,
+		version.CustomerID,
+		version.Version,
+		[]byte(version.Measurements),
+		version.BodyShapeNotes,
+		version.Notes,
+		version.MeasuredBy,
+		version.MeasuredAt,
+		version.CreatedAt,
+	); err != nil {
+		return nil, fmt.Errorf("failed to create measurement profile version: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE measurement_profiles SET latest_version = $2, body_shape_notes = $3, updated_at = $4
+		WHERE id = $1
+	`, profile.ID, profile.LatestVersion, profile.BodyShapeNotes, profile.UpdatedAt); err != nil {
+		return nil, fmt.Errorf("failed to update measurement profile: %w", err)
+	}
+
+	if req.OrderID != "" {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO measurement_profile_orders (order_id, version_id, tenant_id, linked_at, linked_by)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (order_id) DO UPDATE SET
+				version_id = EXCLUDED.version_id,
+				linked_at = EXCLUDED.linked_at,
+				linked_by = EXCLUDED.linked_by
+		`, req.OrderID, version.ID, req.TenantID, version.CreatedAt, req.MeasuredBy); err != nil {
+			return nil, fmt.Errorf("failed to link order to measurement profile version: %w", err)
+		}
+		version.OrderIDs = []string{req.OrderID}
+	}
+
+	auditLog := domain.NewAuditLog(req.TenantID, req.MeasuredBy, domain.AuditActionCreate, "measurement_profile", profile.ID)
+	auditLog.NewValue = s.toJSON(map[string]interface{}{
+		"customer_id":  req.CustomerID,
+		"version":      version.Version,
+		"measurements": version.Measurements,
+		"order_id":     req.OrderID,
+	})
+	auditLog.ChangedFields = []string{"measurements"}
+	auditLog.IPAddress = req.IPAddress
+	auditLog.UserAgent = req.UserAgent
+	if err := createAuditLogInTx(ctx, tx, auditLog); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	if err := s.toDisplayUnit(ctx, req.TenantID, req.CustomerID, version); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
+
+// GetProfile 顧客の採寸プロファイルを最新版とともに取得
+func (s *MeasurementProfileService) GetProfile(ctx context.Context, customerID, tenantID string) (*domain.MeasurementProfile, error) {
+	if customerID == "" {
+		return nil, fmt.Errorf("customer_id is required")
+	}
+
+	profile, err := s.profileRepo.GetByCustomerID(ctx, customerID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if profile.LatestVersion > 0 {
+		latest, err := s.profileRepo.GetVersion(ctx, customerID, tenantID, profile.LatestVersion)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.toDisplayUnit(ctx, tenantID, customerID, latest); err != nil {
+			return nil, err
+		}
+		profile.Latest = latest
+	}
+	return profile, nil
+}
+
+// GetVersion 採寸プロファイルの指定した版を取得
+func (s *MeasurementProfileService) GetVersion(ctx context.Context, customerID, tenantID string, version int) (*domain.MeasurementProfileVersion, error) {
+	if customerID == "" {
+		return nil, fmt.Errorf("customer_id is required")
+	}
+	if version < 1 {
+		return nil, fmt.Errorf("invalid version: must be 1 or greater")
+	}
+	profileVersion, err := s.profileRepo.GetVersion(ctx, customerID, tenantID, version)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.toDisplayUnit(ctx, tenantID, customerID, profileVersion); err != nil {
+		return nil, err
+	}
+	return profileVersion, nil
+}
+
+// ListVersions 採寸プロファイルの版を古い順に取得
+func (s *MeasurementProfileService) ListVersions(ctx context.Context, customerID, tenantID string) ([]*domain.MeasurementProfileVersion, error) {
+	if customerID == "" {
+		return nil, fmt.Errorf("customer_id is required")
+	}
+	versions, err := s.profileRepo.ListVersions(ctx, customerID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.toDisplayUnit(ctx, tenantID, customerID, versions...); err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
+
+// LinkOrder 注文が使った採寸プロファイルの版を記録
+func (s *MeasurementProfileService) LinkOrder(ctx context.Context, req *LinkMeasurementOrderRequest) (*domain.MeasurementProfileVersion, error) {
+	if req.OrderID == "" {
+		return nil, fmt.Errorf("order_id is required")
+	}
+
+	version, err := s.GetVersion(ctx, req.CustomerID, req.TenantID, req.Version)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkOrder(ctx, req.OrderID, req.CustomerID, req.TenantID); err != nil {
+		return nil, err
+	}
+
+	if err := s.profileRepo.LinkOrder(ctx, version.ID, req.OrderID, req.TenantID, req.UserID); err != nil {
+		return nil, err
+	}
+
+	return s.GetVersion(ctx, req.CustomerID, req.TenantID, req.Version)
+}
+
+// GetChart 採寸値の推移を取得（fields を指定した場合はその採寸項目のみ、顧客の表示単位）
+func (s *MeasurementProfileService) GetChart(ctx context.Context, customerID, tenantID string, fields []string) (*domain.MeasurementChart, error) {
+	versions, err := s.ListVersions(ctx, customerID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("measurement profile not found")
+	}
+	chart, err := domain.BuildMeasurementChart(customerID, versions, fields)
+	if err != nil {
+		return nil, err
+	}
+	chart.Unit = s.unitService.GetPreference(ctx, tenantID, customerID).MeasurementUnit
+	return chart, nil
+}
+
+// toDisplayUnit 版の採寸データを顧客の表示単位に換算
+func (s *MeasurementProfileService) toDisplayUnit(ctx context.Context, tenantID, customerID string, versions ...*domain.MeasurementProfileVersion) error {
+	unit := s.unitService.GetPreference(ctx, tenantID, customerID).MeasurementUnit
+	for _, version := range versions {
+		if err := version.ConvertUnit(unit); err != nil {
+			return fmt.Errorf("failed to convert measurement profile version %d: %w", version.Version, err)
+		}
+	}
+	return nil
+}
+
+// checkCustomer 顧客がテナントに存在するか確認
+func (s *MeasurementProfileService) checkCustomer(ctx context.Context, customerID, tenantID string) error {
+	if s.customerRepo == nil {
+		return nil
+	}
+	if _, err := s.customerRepo.GetByID(ctx, customerID, tenantID); err != nil {
+		return fmt.Errorf("failed to get customer: %w", err)
+	}
+	return nil
+}
+
+// checkOrder 注文が同じテナント・同じ顧客のものか確認
+func (s *MeasurementProfileService) checkOrder(ctx context.Context, orderID, customerID, tenantID string) error {
+	order, err := s.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		return fmt.Errorf("failed to get order: %w", err)
+	}
+	if order.TenantID != tenantID {
+		return fmt.Errorf("unauthorized: tenant_id mismatch")
+	}
+	if order.CustomerID != customerID {
+		return fmt.Errorf("invalid order_id: order belongs to another customer")
+	}
+	return nil
+}
+
+// lockProfileInTx 顧客の採寸プロファイルを行ロックして取得（ない場合は作成）
+// 同じ顧客の採寸が同時に記録されても版番号が重複しないようにする
+func (s *MeasurementProfileService) lockProfileInTx(ctx context.Context, tx *sql.Tx, tenantID, customerID string) (*domain.MeasurementProfile, error) {
+	newProfile := domain.NewMeasurementProfile(tenantID, customerID)
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO measurement_profiles (id, tenant_id, customer_id, latest_version, created_at, updated_at)
+		VALUES ($1, $2, $3, 0, $4, $5)
+		ON CONFLICT (tenant_id, customer_id) DO NOTHING
+	`, newProfile.ID, tenantID, customerID, newProfile.CreatedAt, newProfile.UpdatedAt); err != nil {
+		return nil, fmt.Errorf("failed to create measurement profile: %w", err)
+	}
+
+	profile := &domain.MeasurementProfile{}
+	var bodyShapeNotes sql.NullString
+	err := tx.QueryRowContext(ctx, `
+		SELECT id, tenant_id, customer_id, latest_version, body_shape_notes, created_at, updated_at
+		FROM measurement_profiles
+		WHERE tenant_id = $1 AND customer_id = $2
+		FOR UPDATE
+	`, tenantID, customerID).Scan(
+		&profile.ID,
+		&profile.TenantID,
+		&profile.CustomerID,
+		&profile.LatestVersion,
+		&bodyShapeNotes,
+		&profile.CreatedAt,
+		&profile.UpdatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock measurement profile: %w", err)
+	}
+	profile.BodyShapeNotes = bodyShapeNotes.String
+	return profile, nil
+}
+
+// toJSON 監査ログ用のJSON文字列
+func (s *MeasurementProfileService) toJSON(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf(`{"error": "failed to marshal: %v"}`, err)
+	}
+	return string(data)
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"tailor-cloud/backend/internal/config/domain"
+)
+
+// TestMeasurementProfileNextVersion 採寸の版番号の採番と最新版・体型メモの更新のテスト
+func TestMeasurementProfileNextVersion(t *testing.T) {
+	profile := domain.NewMeasurementProfile("tenant-1", "customer-1")
+	measuredAt := time.Date(2026, 4, 10, 11, 0, 0, 0, time.Local)
+
+	first := profile.NextVersion(json.RawMessage(`{"waist": 82.0}`), "なで肩", "", "staff-1", measuredAt)
+	second := profile.NextVersion(json.RawMessage(`{"waist": 84.5}`), "なで肩・反り身", "", "staff-2", measuredAt.AddDate(0, 6, 0))
+
+	if first.Version != 1 || second.Version != 2 {
+		t.Fatalf("versions = %d, %d, want 1, 2", first.Version, second.Version)
+	}
+	if profile.LatestVersion != 2 || profile.Latest != second {
+		t.Errorf("LatestVersion = %d, Latest = %v", profile.LatestVersion, profile.Latest)
+	}
+	if profile.BodyShapeNotes != "なで肩・反り身" {
+		t.Errorf("BodyShapeNotes = %q", profile.BodyShapeNotes)
+	}
+	if second.ProfileID != profile.ID || second.CustomerID != "customer-1" {
+		t.Errorf("ProfileID = %s, CustomerID = %s", second.ProfileID, second.CustomerID)
+	}
+}
+
+// TestMeasurementProfileVersionValidate 採寸の版の検証のテスト
+func TestMeasurementProfileVersionValidate(t *testing.T) {
+	measuredAt := time.Date(2026, 4, 10, 11, 0, 0, 0, time.Local)
+	tests := []struct {
+		name         string
+		measurements string
+		measuredBy   string
+		wantErr      bool
+	}{
+		{"有効", `{"waist": 82.0, "memo": "ベルト位置低め"}`, "staff-1", false},
+		{"数値の項目なし", `{"memo": "再採寸予定"}`, "staff-1", true},
+		{"0以下の値", `{"waist": 0}`, "staff-1", true},
+		{"JSONでない", `82`, "staff-1", true},
+		{"採寸者なし", `{"waist": 82.0}`, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version := &domain.MeasurementProfileVersion{
+				Measurements: json.RawMessage(tt.measurements),
+				MeasuredBy:   tt.measuredBy,
+				MeasuredAt:   measuredAt,
+			}
+			if err := version.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestBuildMeasurementChart 採寸項目ごとの推移と変化量のテスト
+func TestBuildMeasurementChart(t *testing.T) {
+	measuredAt := time.Date(2025, 4, 10, 11, 0, 0, 0, time.Local)
+	versions := []*domain.MeasurementProfileVersion{
+		{Version: 1, MeasuredAt: measuredAt, Measurements: json.RawMessage(`{"waist": 82.0, "height": 175.0, "neck": 39.0}`)},
+		{Version: 2, MeasuredAt: measuredAt.AddDate(0, 6, 0), Measurements: json.RawMessage(`{"waist": 84.2, "height": 175.0}`)},
+		{Version: 3, MeasuredAt: measuredAt.AddDate(1, 0, 0), Measurements: json.RawMessage(`{"waist": 83.1, "height": 175.5, "neck": 39.5}`)},
+	}
+
+	chart, err := domain.BuildMeasurementChart("customer-1", versions, nil)
+	if err != nil {
+		t.Fatalf("BuildMeasurementChart() error = %v", err)
+	}
+	if chart.Versions != 3 || len(chart.Series) != 3 {
+		t.Fatalf("Versions = %d, Series = %d", chart.Versions, len(chart.Series))
+	}
+
+	// 標準項目を表示順（身長、ウエスト）に並べ、標準項目以外（neck）を後に続ける
+	wantFields := []string{"height", "waist", "neck"}
+	for i, field := range wantFields {
+		if chart.Series[i].Field != field {
+			t.Errorf("Series[%d].Field = %s, want %s", i, chart.Series[i].Field, field)
+		}
+	}
+	waist := chart.Series[1]
+	if waist.Label != "ウエスト" || len(waist.Points) != 3 || waist.Change != 1.1 {
+		t.Errorf("waist = %+v", waist)
+	}
+	neck := chart.Series[2]
+	if len(neck.Points) != 2 || neck.Points[1].Version != 3 || neck.Change != 0.5 {
+		t.Errorf("neck = %+v", neck)
+	}
+
+	filtered, err := domain.BuildMeasurementChart("customer-1", versions, []string{"waist"})
+	if err != nil {
+		t.Fatalf("BuildMeasurementChart(fields) error = %v", err)
+	}
+	if len(filtered.Series) != 1 || filtered.Series[0].Field != "waist" {
+		t.Errorf("filtered series = %+v", filtered.Series)
+	}
+}
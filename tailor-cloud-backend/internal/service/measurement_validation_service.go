@@ -4,93 +4,132 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log"
 	"math"
+	"sort"
+	"strings"
+	"time"
 
+	"tailor-cloud/backend/internal/config/domain"
 	"tailor-cloud/backend/internal/repository"
 )
 
 // MeasurementValidationService 採寸データバリデーションサービス
 // 前回採寸データとの比較、異常値検出を担当
+// 採寸項目・正常範囲・必須項目・前回との差の閾値は品目ごとの採寸テンプレートに従う
 type MeasurementValidationService struct {
-	orderRepo repository.OrderRepository
+	orderRepo    repository.OrderRepository
+	profileRepo  repository.MeasurementProfileRepository  // 採寸プロファイル（nilの場合は注文履歴から前回採寸を探す）
+	templateRepo repository.MeasurementTemplateRepository // 採寸テンプレート（nilの場合は既定のテンプレートを使う）
 }
 
 // NewMeasurementValidationService MeasurementValidationServiceのコンストラクタ
-func NewMeasurementValidationService(orderRepo repository.OrderRepository) *MeasurementValidationService {
+func NewMeasurementValidationService(
+	orderRepo repository.OrderRepository,
+	profileRepo repository.MeasurementProfileRepository,
+	templateRepo repository.MeasurementTemplateRepository,
+) *MeasurementValidationService {
 	return &MeasurementValidationService{
-		orderRepo: orderRepo,
+		orderRepo:    orderRepo,
+		profileRepo:  profileRepo,
+		templateRepo: templateRepo,
 	}
 }
 
-// MeasurementData 採寸データ（JSON形式からパース）
-type MeasurementData struct {
-	Height      *float64 `json:"height"`      // 身長
-	Bust        *float64 `json:"bust"`        // バスト
-	Waist       *float64 `json:"waist"`        // ウエスト
-	Hip         *float64 `json:"hip"`         // ヒップ
-	Thigh       *float64 `json:"thigh"`       // 太もも
-	Knee        *float64 `json:"knee"`         // 膝
-	Calf        *float64 `json:"calf"`         // ふくらはぎ
-	OB          *float64 `json:"ob"`           // OB
-	JacketLength *float64 `json:"jacket_length"` // ジャケット長
-	Sleeve      *float64 `json:"sleeve"`       // 袖長
-	Chest       *float64 `json:"chest"`        // 胸囲
-}
-
 // ValidationAlert バリデーションアラート
 type ValidationAlert struct {
-	Field       string  `json:"field"`        // フィールド名
-	Current     float64 `json:"current"`       // 現在の値
-	Previous    float64 `json:"previous"`     // 前回の値
-	Difference  float64 `json:"difference"`   // 差分（絶対値）
-	Threshold   float64 `json:"threshold"`    // 閾値（5.0cm）
-	Severity    string  `json:"severity"`     // "warning" or "error"
-	Message     string  `json:"message"`      // アラートメッセージ
+	Field      string                 `json:"field"`          // フィールド名
+	Current    float64                `json:"current"`        // 現在の値
+	Previous   float64                `json:"previous"`       // 前回の値
+	Difference float64                `json:"difference"`     // 差分（絶対値）
+	Threshold  float64                `json:"threshold"`      // 閾値（採寸テンプレートの警告閾値）
+	Unit       domain.MeasurementUnit `json:"unit,omitempty"` // 単位
+	Severity   string                 `json:"severity"`       // "warning" or "error"
+	Message    string                 `json:"message"`        // アラートメッセージ
+	// Check チェック種別: required, range, typo（入力ミス）, proportion（身長比）, population（顧客の傾向）, change（前回との差）
+	Check          string   `json:"check,omitempty"`
+	SuggestedValue *float64 `json:"suggested_value,omitempty"` // 入力ミスの場合の修正候補
 }
 
 // ValidateMeasurementsRequest バリデーションリクエスト
 type ValidateMeasurementsRequest struct {
-	CustomerID      string                   `json:"customer_id"`
-	TenantID        string                   `json:"tenant_id"`
-	CurrentMeasurements json.RawMessage     `json:"current_measurements"`
+	CustomerID          string             `json:"customer_id"`
+	TenantID            string             `json:"tenant_id"`
+	GarmentType         domain.GarmentType `json:"garment_type"` // 採寸テンプレートの品目（未設定の場合は汎用のテンプレート）
+	CurrentMeasurements json.RawMessage    `json:"current_measurements"`
 }
 
 // ValidateMeasurementsResponse バリデーションレスポンス
 type ValidateMeasurementsResponse struct {
-	IsValid      bool              `json:"is_valid"`      // バリデーション成功
-	Alerts       []ValidationAlert `json:"alerts"`        // アラート一覧
-	HasWarnings  bool              `json:"has_warnings"`  // 警告があるか
-	HasErrors    bool              `json:"has_errors"`    // エラーがあるか
-	PreviousData *MeasurementData  `json:"previous_data"` // 前回の採寸データ（存在する場合）
+	IsValid            bool              `json:"is_valid"`                       // バリデーション成功
+	Alerts             []ValidationAlert `json:"alerts"`                         // アラート一覧
+	HasWarnings        bool              `json:"has_warnings"`                   // 警告があるか
+	HasErrors          bool              `json:"has_errors"`                     // エラーがあるか
+	PreviousData       json.RawMessage   `json:"previous_data"`                  // 前回の採寸データ（存在する場合）
+	PreviousVersion    int               `json:"previous_version,omitempty"`     // 前回の採寸プロファイルの版番号（採寸プロファイルから取得した場合）
+	PreviousMeasuredAt *time.Time        `json:"previous_measured_at,omitempty"` // 前回の採寸日時（採寸プロファイルから取得した場合）
+}
+
+// UpdateMeasurementTemplateRequest 採寸テンプレート登録・更新リクエスト
+type UpdateMeasurementTemplateRequest struct {
+	TenantID               string                             `json:"-"`
+	GarmentType            domain.GarmentType                 `json:"-"`
+	Fields                 []*domain.MeasurementTemplateField `json:"fields"`
+	ChangeWarningThreshold float64                            `json:"change_warning_threshold"` // 未指定の場合は5
+	ChangeErrorThreshold   float64                            `json:"change_error_threshold"`   // 未指定の場合は10
+	UpdatedBy              string                             `json:"-"`
 }
 
 // ValidateMeasurements 採寸データをバリデーション
-// 前回採寸データとの比較、異常値検出を実行
+// 採寸データ単体の妥当性（範囲・入力ミス・身長比・顧客の傾向）と、前回採寸データとの比較を実行
+// 前回の採寸データがない顧客（初回採寸）も採寸データ単体の妥当性はチェックする
 func (s *MeasurementValidationService) ValidateMeasurements(
 	ctx context.Context,
 	req *ValidateMeasurementsRequest,
 ) (*ValidateMeasurementsResponse, error) {
-	// 1. 現在の採寸データをパース
-	var currentData MeasurementData
-	if err := json.Unmarshal(req.CurrentMeasurements, &currentData); err != nil {
+	// 1. 現在の採寸データをcmに正規化してパース
+	currentMeasurements, err := domain.NormalizeMeasurementData(req.CurrentMeasurements)
+	if err != nil {
+		return nil, err
+	}
+	currentValues, err := domain.MeasurementValues(currentMeasurements)
+	if err != nil {
 		return nil, fmt.Errorf("failed to parse current measurements: %w", err)
 	}
 
-	// 2. 顧客の注文履歴から前回の採寸データを取得
-	previousData, err := s.getPreviousMeasurements(ctx, req.CustomerID, req.TenantID)
+	// 2. 採寸テンプレートと母集団で採寸データ単体をチェック
+	template, err := s.GetTemplate(ctx, req.TenantID, req.GarmentType)
 	if err != nil {
-		// 前回データがない場合は警告のみ（エラーではない）
-		return &ValidateMeasurementsResponse{
-			IsValid:     true,
-			Alerts:      []ValidationAlert{},
-			HasWarnings: false,
-			HasErrors:   false,
-			PreviousData: nil,
-		}, nil
+		return nil, err
 	}
+	alerts := checkMeasurementSet(template, currentValues, s.loadPopulation(ctx, req.TenantID))
 
-	// 3. 前回データと比較してアラートを生成
-	alerts := s.compareMeasurements(currentData, *previousData)
+	response := &ValidateMeasurementsResponse{}
+
+	// 3. 顧客の採寸プロファイルの最新版（なければ注文履歴）から前回の採寸データを取得し、
+	//    採寸テンプレートの閾値で比較してアラートを生成（入力ミスの項目は修正候補のアラートのみ）
+	//    前回データがない場合は比較しない（エラーではない）
+	previousData, previousVersion, err := s.getPreviousMeasurements(ctx, req.CustomerID, req.TenantID)
+	if err == nil {
+		normalizedPrevious, err := domain.NormalizeMeasurementData(previousData)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse previous measurements: %w", err)
+		}
+		previousValues, err := domain.MeasurementValues(normalizedPrevious)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse previous measurements: %w", err)
+		}
+		for _, alert := range s.compareMeasurements(template, currentValues, previousValues) {
+			if !hasTypoAlert(alerts, alert.Field) {
+				alerts = append(alerts, alert)
+			}
+		}
+		response.PreviousData = previousData
+		if previousVersion != nil {
+			response.PreviousVersion = previousVersion.Version
+			response.PreviousMeasuredAt = &previousVersion.MeasuredAt
+		}
+	}
 
 	// 4. レスポンスを構築
 	hasErrors := false
@@ -103,21 +142,43 @@ func (s *MeasurementValidationService) ValidateMeasurements(
 		}
 	}
 
-	return &ValidateMeasurementsResponse{
-		IsValid:      !hasErrors, // エラーがない場合は有効
-		Alerts:       alerts,
-		HasWarnings:  hasWarnings,
-		HasErrors:    hasErrors,
-		PreviousData: previousData,
-	}, nil
+	if alerts == nil {
+		alerts = []ValidationAlert{}
+	}
+	response.IsValid = !hasErrors // エラーがない場合は有効
+	response.Alerts = alerts
+	response.HasWarnings = hasWarnings
+	response.HasErrors = hasErrors
+	return response, nil
 }
 
 // getPreviousMeasurements 顧客の前回採寸データを取得
+// 採寸プロファイルの最新版を使い、プロファイルがない顧客のみ注文履歴から探す
 func (s *MeasurementValidationService) getPreviousMeasurements(
 	ctx context.Context,
 	customerID string,
 	tenantID string,
-) (*MeasurementData, error) {
+) (json.RawMessage, *domain.MeasurementProfileVersion, error) {
+	if s.profileRepo != nil {
+		latest, err := s.profileRepo.GetLatestVersion(ctx, customerID, tenantID)
+		if err == nil {
+			return latest.Measurements, latest, nil
+		}
+		if !strings.Contains(err.Error(), "not found") {
+			return nil, nil, err
+		}
+	}
+
+	data, err := s.getPreviousOrderMeasurements(ctx, customerID, tenantID)
+	return data, nil, err
+}
+
+// getPreviousOrderMeasurements 顧客の注文履歴から前回の採寸データを取得（採寸プロファイルがない顧客）
+func (s *MeasurementValidationService) getPreviousOrderMeasurements(
+	ctx context.Context,
+	customerID string,
+	tenantID string,
+) (json.RawMessage, error) {
 	// 顧客の注文履歴を取得（最新の注文から順に）
 	orders, err := s.orderRepo.GetByTenantID(ctx, tenantID)
 	if err != nil {
@@ -127,10 +188,9 @@ func (s *MeasurementValidationService) getPreviousMeasurements(
 	// 顧客の注文をフィルターして、採寸データがある最新の注文を探す
 	for _, order := range orders {
 		if order.CustomerID == customerID && order.Details != nil && order.Details.MeasurementData != nil {
-			var data MeasurementData
-			if err := json.Unmarshal(order.Details.MeasurementData, &data); err == nil {
+			if _, err := domain.MeasurementValues(order.Details.MeasurementData); err == nil {
 				// 有効な採寸データが見つかった
-				return &data, nil
+				return order.Details.MeasurementData, nil
 			}
 		}
 	}
@@ -138,62 +198,53 @@ func (s *MeasurementValidationService) getPreviousMeasurements(
 	return nil, fmt.Errorf("no previous measurements found")
 }
 
-// compareMeasurements 採寸データを比較してアラートを生成
-// 閾値: ±5cm以上の差分で警告
+// compareMeasurements 採寸データ（cm）を比較してアラートを生成
+// 閾値: 採寸テンプレートの項目ごとの閾値（既定: 5cm以上で警告、10cm以上でエラー）
+// 閾値は項目の単位のため、値を項目の単位に換算して比較する
 func (s *MeasurementValidationService) compareMeasurements(
-	current MeasurementData,
-	previous MeasurementData,
+	template *domain.MeasurementTemplate,
+	current map[string]float64,
+	previous map[string]float64,
 ) []ValidationAlert {
 	var alerts []ValidationAlert
-	threshold := 5.0 // 閾値: 5cm
-
-	// 比較するフィールドのリスト
-	fields := []struct {
-		name     string
-		current  *float64
-		previous *float64
-	}{
-		{"height", current.Height, previous.Height},
-		{"bust", current.Bust, previous.Bust},
-		{"waist", current.Waist, previous.Waist},
-		{"hip", current.Hip, previous.Hip},
-		{"thigh", current.Thigh, previous.Thigh},
-		{"knee", current.Knee, previous.Knee},
-		{"calf", current.Calf, previous.Calf},
-		{"ob", current.OB, previous.OB},
-		{"jacket_length", current.JacketLength, previous.JacketLength},
-		{"sleeve", current.Sleeve, previous.Sleeve},
-		{"chest", current.Chest, previous.Chest},
-	}
+	current = template.ValuesInFieldUnits(current)
+	previous = template.ValuesInFieldUnits(previous)
 
-	for _, field := range fields {
+	for _, key := range measurementKeysInTemplateOrder(template, current) {
 		// 両方の値が存在する場合のみ比較
-		if field.current == nil || field.previous == nil {
+		currentValue, ok := current[key]
+		if !ok {
+			continue
+		}
+		previousValue, ok := previous[key]
+		if !ok {
 			continue
 		}
 
-		currentValue := *field.current
-		previousValue := *field.previous
 		difference := math.Abs(currentValue - previousValue)
+		threshold, errorThreshold := template.ChangeThresholds(key)
 
-		// 閾値（5cm）を超えている場合
+		// 警告閾値を超えている場合
 		if difference >= threshold {
 			severity := "warning"
-			// 10cm以上の差分はエラー
-			if difference >= 10.0 {
+			// エラー閾値以上の差分はエラー
+			if difference >= errorThreshold {
 				severity = "error"
 			}
 
+			unit := template.Unit(key)
 			alert := ValidationAlert{
-				Field:      field.name,
+				Field:      key,
 				Current:    currentValue,
 				Previous:   previousValue,
 				Difference: difference,
 				Threshold:  threshold,
+				Unit:       unit,
 				Severity:   severity,
-				Message:    fmt.Sprintf(
-					"%sの値が前回より%.1fcm異なります（現在: %.1fcm, 前回: %.1fcm）",
-					field.name, difference, currentValue, previousValue,
+				Check:      measurementCheckChange,
+				Message: fmt.Sprintf(
+					"%sの値が前回より%.1f%s異なります（現在: %.1f%s, 前回: %.1f%s）",
+					template.Label(key), difference, unit, currentValue, unit, previousValue, unit,
 				),
 			}
 			alerts = append(alerts, alert)
@@ -204,62 +255,136 @@ func (s *MeasurementValidationService) compareMeasurements(
 }
 
 // ValidateMeasurementRange 採寸データの範囲をバリデーション
-// 異常値（例: 身長が50cm以下、300cm以上など）を検出
+// 品目の採寸テンプレートの必須項目の欠落と正常範囲外の値（例: 身長が50cm未満、250cm超など）に加え、
+// 入力ミスの修正候補、身長に対する比率、テナントの顧客の傾向から外れた値を検出
+// 採寸データはcmに正規化してからチェックする（ValidateMeasurementsと同様）
 func (s *MeasurementValidationService) ValidateMeasurementRange(
+	ctx context.Context,
+	tenantID string,
+	garmentType domain.GarmentType,
 	measurements json.RawMessage,
-) []ValidationAlert {
-	var data MeasurementData
-	if err := json.Unmarshal(measurements, &data); err != nil {
-		return []ValidationAlert{{
-			Field:    "parse_error",
-			Severity: "error",
-			Message:  fmt.Sprintf("Failed to parse measurements: %v", err),
-		}}
+) ([]ValidationAlert, error) {
+	measurements, err := domain.NormalizeMeasurementData(measurements)
+	if err != nil {
+		return nil, err
 	}
 
-	var alerts []ValidationAlert
+	template, err := s.GetTemplate(ctx, tenantID, garmentType)
+	if err != nil {
+		return nil, err
+	}
+	return checkMeasurementRange(template, measurements, s.loadPopulation(ctx, tenantID)), nil
+}
+
+// loadPopulation テナントの顧客ごとの最新の採寸データから母集団の統計を作成
+// 採寸プロファイルがない場合・取得に失敗した場合は母集団によるチェックを行わない
+func (s *MeasurementValidationService) loadPopulation(ctx context.Context, tenantID string) measurementPopulation {
+	if s.profileRepo == nil || tenantID == "" {
+		return nil
+	}
+	measurements, err := s.profileRepo.ListLatestMeasurements(ctx, tenantID, measurementPopulationLimit)
+	if err != nil {
+		log.Printf("WARNING: Failed to load measurement population: %v", err)
+		return nil
+	}
+
+	samples := make([]map[string]float64, 0, len(measurements))
+	for _, data := range measurements {
+		if values, err := domain.MeasurementValues(data); err == nil {
+			samples = append(samples, values)
+		}
+	}
+	return buildMeasurementPopulation(samples)
+}
 
-	// 身長の範囲チェック（50cm - 250cm）
-	if data.Height != nil {
-		height := *data.Height
-		if height < 50.0 || height > 250.0 {
-			alerts = append(alerts, ValidationAlert{
-				Field:    "height",
-				Current:  height,
-				Severity: "error",
-				Message:  fmt.Sprintf("身長の値が異常です: %.1fcm（正常範囲: 50-250cm）", height),
-			})
+// hasTypoAlert 項目に入力ミスの可能性のアラートがあるか
+func hasTypoAlert(alerts []ValidationAlert, key string) bool {
+	for _, alert := range alerts {
+		if alert.Field == key && alert.Check == measurementCheckTypo {
+			return true
 		}
 	}
+	return false
+}
 
-	// バストの範囲チェック（50cm - 200cm）
-	if data.Bust != nil {
-		bust := *data.Bust
-		if bust < 50.0 || bust > 200.0 {
-			alerts = append(alerts, ValidationAlert{
-				Field:    "bust",
-				Current:  bust,
-				Severity: "error",
-				Message:  fmt.Sprintf("バストの値が異常です: %.1fcm（正常範囲: 50-200cm）", bust),
-			})
+// measurementKeysInTemplateOrder 採寸データの項目を採寸テンプレートの順に並べる（テンプレートにない項目はキー順で後に続ける）
+func measurementKeysInTemplateOrder(template *domain.MeasurementTemplate, values map[string]float64) []string {
+	keys := make([]string, 0, len(values))
+	inTemplate := make(map[string]bool, len(template.Fields))
+	for _, field := range template.Fields {
+		inTemplate[field.Key] = true
+		if _, ok := values[field.Key]; ok {
+			keys = append(keys, field.Key)
 		}
 	}
 
-	// ウエストの範囲チェック（40cm - 150cm）
-	if data.Waist != nil {
-		waist := *data.Waist
-		if waist < 40.0 || waist > 150.0 {
-			alerts = append(alerts, ValidationAlert{
-				Field:    "waist",
-				Current:  waist,
-				Severity: "error",
-				Message:  fmt.Sprintf("ウエストの値が異常です: %.1fcm（正常範囲: 40-150cm）", waist),
-			})
+	others := make([]string, 0)
+	for key := range values {
+		if !inTemplate[key] {
+			others = append(others, key)
 		}
 	}
+	sort.Strings(others)
+	return append(keys, others...)
+}
 
-	// その他のフィールドも同様にチェック（必要に応じて追加）
+// GetTemplate 品目の採寸テンプレートを取得（未設定の場合は既定のテンプレート）
+func (s *MeasurementValidationService) GetTemplate(ctx context.Context, tenantID string, garmentType domain.GarmentType) (*domain.MeasurementTemplate, error) {
+	if garmentType != "" && !garmentType.IsValid() {
+		return nil, fmt.Errorf("invalid garment_type: %s", garmentType)
+	}
+	if s.templateRepo == nil || tenantID == "" || garmentType == "" {
+		return domain.DefaultMeasurementTemplate(tenantID, garmentType), nil
+	}
 
-	return alerts
+	template, err := s.templateRepo.Get(ctx, tenantID, garmentType)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return domain.DefaultMeasurementTemplate(tenantID, garmentType), nil
+		}
+		return nil, err
+	}
+	return template, nil
+}
+
+// ListTemplates テナントで設定済みの採寸テンプレートを取得
+func (s *MeasurementValidationService) ListTemplates(ctx context.Context, tenantID string) ([]*domain.MeasurementTemplate, error) {
+	if tenantID == "" {
+		return nil, fmt.Errorf("tenant_id is required")
+	}
+	if s.templateRepo == nil {
+		return nil, fmt.Errorf("measurement template repository is not configured")
+	}
+	return s.templateRepo.List(ctx, tenantID)
 }
 
+// UpdateTemplate 品目の採寸テンプレートを登録・更新
+func (s *MeasurementValidationService) UpdateTemplate(ctx context.Context, req *UpdateMeasurementTemplateRequest) (*domain.MeasurementTemplate, error) {
+	if req.TenantID == "" {
+		return nil, fmt.Errorf("tenant_id is required")
+	}
+	if req.UpdatedBy == "" {
+		return nil, fmt.Errorf("updated_by is required")
+	}
+	if s.templateRepo == nil {
+		return nil, fmt.Errorf("measurement template repository is not configured")
+	}
+
+	template := &domain.MeasurementTemplate{
+		TenantID:               req.TenantID,
+		GarmentType:            req.GarmentType,
+		Fields:                 req.Fields,
+		ChangeWarningThreshold: req.ChangeWarningThreshold,
+		ChangeErrorThreshold:   req.ChangeErrorThreshold,
+		UpdatedAt:              time.Now(),
+		UpdatedBy:              req.UpdatedBy,
+	}
+	if err := template.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.templateRepo.Upsert(ctx, template); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
@@ -0,0 +1,264 @@
+package service
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+
+	"tailor-cloud/backend/internal/config/domain"
+)
+
+// TestDefaultMeasurementTemplate 品目ごとの既定の採寸項目・必須項目のテスト
+func TestDefaultMeasurementTemplate(t *testing.T) {
+	shirt := domain.DefaultMeasurementTemplate("tenant-1", domain.GarmentTypeShirt)
+	for _, key := range []string{"neck", "cuff", "sleeve"} {
+		if field := shirt.Field(key); field == nil || !field.Required {
+			t.Errorf("shirt template field %s = %+v, want required", key, field)
+		}
+	}
+
+	trousers := domain.DefaultMeasurementTemplate("tenant-1", domain.GarmentTypeTrousers)
+	if field := trousers.Field("inseam"); field == nil || !field.Required {
+		t.Errorf("trousers template inseam = %+v, want required", field)
+	}
+	if trousers.Field("rise") == nil {
+		t.Error("trousers template should include rise")
+	}
+	if trousers.Field("neck") != nil {
+		t.Error("trousers template should not include neck")
+	}
+
+	generic := domain.DefaultMeasurementTemplate("tenant-1", "")
+	for _, field := range generic.Fields {
+		if field.Required {
+			t.Errorf("generic template field %s should not be required", field.Key)
+		}
+	}
+}
+
+// TestMeasurementTemplateValidate 採寸テンプレートの検証と既定値の補完のテスト
+func TestMeasurementTemplateValidate(t *testing.T) {
+	valid := &domain.MeasurementTemplate{
+		GarmentType: domain.GarmentTypeShirt,
+		Fields: []*domain.MeasurementTemplateField{
+			{Key: "neck", Label: "ネック", Min: 30, Max: 50, Required: true, ChangeThreshold: 1.5},
+		},
+	}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if valid.Fields[0].Unit != domain.MeasurementUnitCM || valid.ChangeWarningThreshold != 5 || valid.ChangeErrorThreshold != 10 {
+		t.Errorf("defaults not applied: unit=%s warning=%v error=%v", valid.Fields[0].Unit, valid.ChangeWarningThreshold, valid.ChangeErrorThreshold)
+	}
+
+	tests := []struct {
+		name     string
+		template *domain.MeasurementTemplate
+	}{
+		{"品目なし", &domain.MeasurementTemplate{Fields: valid.Fields}},
+		{"項目なし", &domain.MeasurementTemplate{GarmentType: domain.GarmentTypeShirt}},
+		{"キーの形式", &domain.MeasurementTemplate{GarmentType: domain.GarmentTypeShirt, Fields: []*domain.MeasurementTemplateField{{Key: "Neck Size", Label: "ネック", Min: 30, Max: 50}}}},
+		{"キーの重複", &domain.MeasurementTemplate{GarmentType: domain.GarmentTypeShirt, Fields: []*domain.MeasurementTemplateField{{Key: "neck", Label: "ネック", Min: 30, Max: 50}, {Key: "neck", Label: "首回り", Min: 30, Max: 50}}}},
+		{"範囲の逆転", &domain.MeasurementTemplate{GarmentType: domain.GarmentTypeShirt, Fields: []*domain.MeasurementTemplateField{{Key: "neck", Label: "ネック", Min: 50, Max: 30}}}},
+		{"不明な単位", &domain.MeasurementTemplate{GarmentType: domain.GarmentTypeShirt, Fields: []*domain.MeasurementTemplateField{{Key: "neck", Label: "ネック", Unit: "shaku", Min: 30, Max: 50}}}},
+		{"閾値の逆転", &domain.MeasurementTemplate{GarmentType: domain.GarmentTypeShirt, ChangeWarningThreshold: 8, ChangeErrorThreshold: 4, Fields: valid.Fields}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.template.Validate(); err == nil {
+				t.Error("expected validation error")
+			}
+		})
+	}
+}
+
+// TestCheckMeasurementRange 採寸テンプレートによる必須項目・範囲のチェックのテスト
+func TestCheckMeasurementRange(t *testing.T) {
+	template := domain.DefaultMeasurementTemplate("tenant-1", domain.GarmentTypeTrousers)
+
+	alerts := checkMeasurementRange(template, json.RawMessage(`{"waist": 82, "hip": 250, "rise": 26}`), nil)
+	fields := make(map[string]string)
+	for _, alert := range alerts {
+		fields[alert.Field] = alert.Message
+	}
+	if len(alerts) != 2 {
+		t.Fatalf("alerts = %+v, want hip range and missing inseam", alerts)
+	}
+	if !strings.Contains(fields["hip"], "正常範囲") {
+		t.Errorf("hip alert = %q", fields["hip"])
+	}
+	if !strings.Contains(fields["inseam"], "必須") {
+		t.Errorf("inseam alert = %q", fields["inseam"])
+	}
+
+	if alerts := checkMeasurementRange(template, json.RawMessage(`not json`), nil); len(alerts) != 1 || alerts[0].Field != "parse_error" {
+		t.Errorf("parse error alerts = %+v", alerts)
+	}
+}
+
+// TestCheckMeasurementRangeFieldUnit cm以外の単位の項目は、cmに正規化した値を項目の単位に換算してチェックするテスト
+func TestCheckMeasurementRangeFieldUnit(t *testing.T) {
+	template := &domain.MeasurementTemplate{
+		GarmentType: domain.GarmentTypeShirt,
+		Fields: []*domain.MeasurementTemplateField{
+			{Key: "neck", Label: "ネック", Unit: domain.MeasurementUnitMM, Min: 300, Max: 500, Required: true},
+			{Key: "sleeve", Label: "袖長", Unit: domain.MeasurementUnitInch, Min: 20, Max: 40},
+		},
+	}
+
+	// 39cm = 390mm, 63.5cm = 25inch（正常範囲内）
+	if alerts := checkMeasurementSet(template, map[string]float64{"neck": 39, "sleeve": 63.5}, nil); len(alerts) != 0 {
+		t.Fatalf("alerts = %+v, want none", alerts)
+	}
+
+	// 55cm = 550mm（正常範囲外）、アラートは項目の単位で表示する
+	got := anomalyAlertsByField(checkMeasurementSet(template, map[string]float64{"neck": 55}, nil))
+	neck, ok := got["neck"]
+	if !ok || neck.Unit != domain.MeasurementUnitMM || neck.Current != 550 {
+		t.Fatalf("neck alert = %+v, want 550mm", neck)
+	}
+	if !strings.Contains(neck.Message, "550.0mm") {
+		t.Errorf("neck message = %q", neck.Message)
+	}
+
+	// 前回との差も項目の単位に換算して閾値と比較する（2cm = 20mm）
+	s := &MeasurementValidationService{}
+	changes := s.compareMeasurements(template, map[string]float64{"neck": 41}, map[string]float64{"neck": 39})
+	if len(changes) != 1 || changes[0].Unit != domain.MeasurementUnitMM || changes[0].Difference != 20 {
+		t.Errorf("change alerts = %+v, want 20mm difference", changes)
+	}
+}
+
+// TestCompareMeasurementsWithTemplate 採寸項目ごとの変化アラート閾値のテスト
+func TestCompareMeasurementsWithTemplate(t *testing.T) {
+	template := domain.DefaultMeasurementTemplate("tenant-1", domain.GarmentTypeShirt)
+	template.Field("neck").ChangeThreshold = 1.0
+	template.Field("neck").ChangeErrorThreshold = 2.0
+
+	s := &MeasurementValidationService{}
+	alerts := s.compareMeasurements(template,
+		map[string]float64{"neck": 41.5, "bust": 96, "waist": 84, "armhole": 50},
+		map[string]float64{"neck": 39.0, "bust": 94, "waist": 78, "armhole": 43},
+	)
+
+	got := make(map[string]ValidationAlert)
+	for _, alert := range alerts {
+		got[alert.Field] = alert
+	}
+	if len(alerts) != 3 {
+		t.Fatalf("alerts = %+v, want neck, waist and armhole", alerts)
+	}
+	if got["neck"].Severity != "error" || got["neck"].Threshold != 1.0 {
+		t.Errorf("neck alert = %+v", got["neck"])
+	}
+	if got["waist"].Severity != "warning" || got["waist"].Threshold != 5.0 {
+		t.Errorf("waist alert = %+v", got["waist"])
+	}
+	// テンプレートにない項目はテンプレートの既定の閾値で比較する
+	if got["armhole"].Severity != "warning" {
+		t.Errorf("armhole alert = %+v", got["armhole"])
+	}
+	if alerts[0].Field != "neck" {
+		t.Errorf("alerts should follow template order, got %s first", alerts[0].Field)
+	}
+}
+
+// anomalyAlertsByField チェック結果を項目ごとにまとめる
+func anomalyAlertsByField(alerts []ValidationAlert) map[string]ValidationAlert {
+	got := make(map[string]ValidationAlert)
+	for _, alert := range alerts {
+		got[alert.Field] = alert
+	}
+	return got
+}
+
+// TestCheckMeasurementSetTypo 小数点の位置の誤り（8.5と85）の修正候補のテスト
+func TestCheckMeasurementSetTypo(t *testing.T) {
+	template := domain.DefaultMeasurementTemplate("tenant-1", domain.GarmentTypeTrousers)
+
+	alerts := checkMeasurementSet(template, map[string]float64{"height": 175, "waist": 8.5, "hip": 95, "inseam": 80}, nil)
+	if len(alerts) != 1 {
+		t.Fatalf("alerts = %+v, want waist typo only", alerts)
+	}
+	waist := alerts[0]
+	if waist.Field != "waist" || waist.Check != measurementCheckTypo || waist.Severity != "error" {
+		t.Errorf("waist alert = %+v", waist)
+	}
+	if waist.SuggestedValue == nil || *waist.SuggestedValue != 85 {
+		t.Errorf("waist suggestion = %v, want 85", waist.SuggestedValue)
+	}
+}
+
+// TestCheckMeasurementSetInchHeight インチで入力された採寸データ（身長70）のテスト
+func TestCheckMeasurementSetInchHeight(t *testing.T) {
+	template := domain.DefaultMeasurementTemplate("tenant-1", domain.GarmentTypeJacket)
+
+	alerts := checkMeasurementSet(template, map[string]float64{"height": 70, "bust": 38, "waist": 32, "hip": 40}, nil)
+	got := anomalyAlertsByField(alerts)
+
+	height, ok := got["height"]
+	if !ok || height.Check != measurementCheckTypo || height.SuggestedValue == nil || *height.SuggestedValue != 177.8 {
+		t.Fatalf("height alert = %+v, want suggestion 177.8", height)
+	}
+	want := map[string]float64{"bust": 96.5, "waist": 81.3, "hip": 101.6}
+	for key, value := range want {
+		alert := got[key]
+		if alert.Check != measurementCheckTypo || alert.SuggestedValue == nil || *alert.SuggestedValue != value {
+			t.Errorf("%s alert = %+v, want suggestion %.1f", key, alert, value)
+		}
+	}
+	if len(alerts) != 4 {
+		t.Errorf("alerts = %+v, want typo alerts only", alerts)
+	}
+}
+
+// TestCheckMeasurementSetProportion 身長に対する比率・ウエストとヒップの比率のテスト
+func TestCheckMeasurementSetProportion(t *testing.T) {
+	template := domain.DefaultMeasurementTemplate("tenant-1", domain.GarmentTypeShirt)
+
+	// 袖丈の範囲内だが身長に対して長すぎる、ウエストがヒップに対して細すぎる
+	alerts := checkMeasurementSet(template, map[string]float64{
+		"height": 160, "neck": 38, "bust": 90, "waist": 55, "hip": 96, "sleeve": 80, "cuff": 24,
+	}, nil)
+	got := anomalyAlertsByField(alerts)
+	if got["sleeve"].Check != measurementCheckProportion || got["sleeve"].Severity != "warning" {
+		t.Errorf("sleeve alert = %+v", got["sleeve"])
+	}
+	if got["waist"].Check != measurementCheckProportion || !strings.Contains(got["waist"].Message, "ヒップ") {
+		t.Errorf("waist alert = %+v", got["waist"])
+	}
+	if len(alerts) != 2 {
+		t.Errorf("alerts = %+v, want sleeve and waist", alerts)
+	}
+}
+
+// TestCheckMeasurementSetPopulation テナントの顧客の傾向（身長に対する回帰）から外れた値のテスト
+func TestCheckMeasurementSetPopulation(t *testing.T) {
+	// ウエスト ≒ 0.5 × 身長 の顧客（残差 ±1cm）
+	var samples []map[string]float64
+	for i := 0; i < 40; i++ {
+		height := 155 + float64(i)
+		samples = append(samples, map[string]float64{"height": height, "waist": height*0.5 + float64(i%3-1)})
+	}
+	population := buildMeasurementPopulation(samples)
+	regression := population["waist"]
+	if regression == nil || regression.HeightSamples != 40 || math.Abs(regression.Slope-0.5) > 0.01 {
+		t.Fatalf("waist regression = %+v", regression)
+	}
+
+	template := domain.DefaultMeasurementTemplate("tenant-1", "")
+	if alerts := checkMeasurementSet(template, map[string]float64{"height": 170, "waist": 86}, population); len(alerts) != 0 {
+		t.Errorf("alerts = %+v, want none for typical waist", alerts)
+	}
+	alerts := checkMeasurementSet(template, map[string]float64{"height": 170, "waist": 100}, population)
+	if len(alerts) != 1 || alerts[0].Check != measurementCheckPopulation {
+		t.Errorf("alerts = %+v, want population outlier", alerts)
+	}
+
+	// 標本数が足りない場合はチェックしない
+	small := buildMeasurementPopulation(samples[:10])
+	if alerts := checkMeasurementSet(template, map[string]float64{"height": 170, "waist": 100}, small); len(alerts) != 0 {
+		t.Errorf("alerts = %+v, want none with small population", alerts)
+	}
+}
@@ -0,0 +1,369 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"tailor-cloud/backend/internal/config/domain"
+	"tailor-cloud/backend/internal/repository"
+)
+
+// OrderCancellationService 注文キャンセルサービス
+// 注文ステータスの更新、反物引当の解除、成果報酬の取消、発注取消通知書の保存、
+// キャンセル記録・監査ログの作成を単一トランザクションで行う
+type OrderCancellationService struct {
+	orderRepo                  repository.OrderRepository
+	tenantRepo                 repository.TenantRepository
+	cancellationRepo           repository.OrderCancellationRepository
+	inventoryAllocationService *InventoryAllocationService
+	complianceService          *ComplianceService
+	db                         *sql.DB // トランザクション管理用
+}
+
+// NewOrderCancellationService OrderCancellationServiceのコンストラクタ
+func NewOrderCancellationService(
+	orderRepo repository.OrderRepository,
+	tenantRepo repository.TenantRepository,
+	cancellationRepo repository.OrderCancellationRepository,
+	inventoryAllocationService *InventoryAllocationService,
+	complianceService *ComplianceService,
+	db *sql.DB,
+) *OrderCancellationService {
+	return &OrderCancellationService{
+		orderRepo:                  orderRepo,
+		tenantRepo:                 tenantRepo,
+		cancellationRepo:           cancellationRepo,
+		inventoryAllocationService: inventoryAllocationService,
+		complianceService:          complianceService,
+		db:                         db,
+	}
+}
+
+// CancelOrderRequest 注文キャンセルリクエスト
+type CancelOrderRequest struct {
+	OrderID   string `json:"-"`
+	TenantID  string `json:"-"`
+	Reason    string `json:"reason"`
+	UserID    string `json:"-"`
+	IPAddress string `json:"-"`
+	UserAgent string `json:"-"`
+}
+
+// CancelOrderResponse 注文キャンセルレスポンス
+type CancelOrderResponse struct {
+	Order              *domain.Order              `json:"order"`
+	Cancellation       *domain.OrderCancellation  `json:"cancellation"`
+	ComplianceDocument *domain.ComplianceDocument `json:"compliance_document,omitempty"`
+}
+
+// CancelOrder 注文をキャンセル
+func (s *OrderCancellationService) CancelOrder(ctx context.Context, req *CancelOrderRequest) (*CancelOrderResponse, error) {
+	if req.OrderID == "" {
+		return nil, fmt.Errorf("order_id is required")
+	}
+	if req.TenantID == "" {
+		return nil, fmt.Errorf("tenant_id is required")
+	}
+	if req.Reason == "" {
+		return nil, fmt.Errorf("reason is required")
+	}
+
+	// 1. 注文を取得
+	order, err := s.orderRepo.GetByID(ctx, req.OrderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+	if order.TenantID != req.TenantID {
+		return nil, fmt.Errorf("unauthorized: tenant_id mismatch")
+	}
+	if order.Status == domain.OrderStatusCancelled {
+		return nil, fmt.Errorf("invalid order status: order is already cancelled")
+	}
+
+	// 2. 到達工程に応じたキャンセル料規定を決定（発送済み以降はキャンセル不可）
+	policy, err := domain.CancellationFeePolicyFor(order.Status)
+	if err != nil {
+		return nil, err
+	}
+	cancellation := domain.NewOrderCancellation(order, policy, req.Reason, req.UserID)
+
+	// 3. 発注取消通知書を生成（発注書が発行済みの確定以降の注文のみ）
+	// PDFのアップロードはトランザクション外で行い、文書レコードはトランザクション内で保存する
+	var complianceDoc *domain.ComplianceDocument
+	if order.Status != domain.OrderStatusDraft {
+		var tenant *domain.Tenant
+		if s.tenantRepo != nil {
+			tenant, err = s.tenantRepo.GetByID(ctx, order.TenantID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get tenant: %w", err)
+			}
+		}
+		complianceDoc, err = s.complianceService.GenerateCancellationNotice(ctx, &GenerateCancellationNoticeRequest{
+			Order:        order,
+			Tenant:       tenant,
+			Cancellation: cancellation,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate cancellation notice: %w", err)
+		}
+		cancellation.ComplianceDocumentID = complianceDoc.ID
+	}
+
+	// トランザクション開始
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// 4. 振込予定の支払バッチに含まれる注文はキャンセル不可（振込ファイル作成済みのため、先にバッチを取り消す）
+	if err := s.checkScheduledPayoutsInTx(ctx, tx, order.ID, order.TenantID); err != nil {
+		return nil, err
+	}
+
+	// 5. 注文ステータスを更新（取得後に他の更新があった場合は中断）
+	oldOrder := *order
+	order.Status = domain.OrderStatusCancelled
+	order.UpdatedAt = time.Now()
+	if err := updateOrderStatusInTx(ctx, tx, order, oldOrder.Status); err != nil {
+		return nil, err
+	}
+
+	// 6. 反物引当を解除
+	released, err := s.inventoryAllocationService.ReleaseOrderAllocationsInTx(ctx, tx, order.ID, order.TenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to release allocations: %w", err)
+	}
+	cancellation.ReleasedAllocations = len(released)
+	for _, allocation := range released {
+		cancellation.ReleasedLength += allocation.AllocatedLength
+	}
+
+	// 7. 未払いの成果報酬を取消
+	cancelledCommissions, err := s.cancelCommissionsInTx(ctx, tx, order.ID, order.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	cancellation.CancelledCommissions = cancelledCommissions
+
+	// 8. 発注取消通知書を保存
+	if complianceDoc != nil {
+		if err := s.createComplianceDocumentInTx(ctx, tx, complianceDoc); err != nil {
+			return nil, err
+		}
+	}
+
+	// 9. キャンセル記録を作成
+	if err := s.createCancellationInTx(ctx, tx, cancellation); err != nil {
+		return nil, err
+	}
+
+	// 10. 監査ログを記録（キャンセルと同時にコミット）
+	auditLog := domain.NewAuditLog(order.TenantID, req.UserID, domain.AuditActionCancel, "order", order.ID)
+	auditLog.OldValue = s.toJSON(&oldOrder)
+	auditLog.NewValue = s.toJSON(map[string]interface{}{
+		"order":        order,
+		"cancellation": cancellation,
+	})
+	auditLog.ChangedFields = []string{"status"}
+	auditLog.IPAddress = req.IPAddress
+	auditLog.UserAgent = req.UserAgent
+	if err := createAuditLogInTx(ctx, tx, auditLog); err != nil {
+		return nil, err
+	}
+
+	// トランザクションコミット
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return &CancelOrderResponse{
+		Order:              order,
+		Cancellation:       cancellation,
+		ComplianceDocument: complianceDoc,
+	}, nil
+}
+
+// GetCancellation 注文のキャンセル記録を取得
+func (s *OrderCancellationService) GetCancellation(ctx context.Context, orderID string, tenantID string) (*domain.OrderCancellation, error) {
+	if orderID == "" {
+		return nil, fmt.Errorf("order_id is required")
+	}
+	return s.cancellationRepo.GetByOrderID(ctx, orderID, tenantID)
+}
+
+// updateOrderStatusInTx トランザクション内で注文ステータスを更新
+// 取得時のステータス（expected）から変わっている場合は更新しない（注文キャンセル・工程の進行）
+func updateOrderStatusInTx(ctx context.Context, tx *sql.Tx, order *domain.Order, expected domain.OrderStatus) error {
+	result, err := tx.ExecContext(ctx, `
+		UPDATE orders SET status = $4, updated_at = $5
+		WHERE id = $1 AND tenant_id = $2 AND status = $3
+	`, order.ID, order.TenantID, string(expected), string(order.Status), order.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update order status: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("invalid order status: order was modified by another request")
+	}
+	return nil
+}
+
+// checkScheduledPayoutsInTx 注文の下請代金・成果報酬が振込予定の支払バッチに含まれていないか確認
+// 支払明細を排他ロックし、確認後にバッチが振込完了となることを防ぐ
+func (s *OrderCancellationService) checkScheduledPayoutsInTx(ctx context.Context, tx *sql.Tx, orderID string, tenantID string) error {
+	var batchID string
+	err := tx.QueryRowContext(ctx, `
+		SELECT i.batch_id
+		FROM payout_items i
+		WHERE i.tenant_id = $2 AND i.status = $3
+		  AND (
+		      (i.item_type = $4 AND i.reference_id = $1)
+		      OR (i.item_type = $5 AND i.reference_id IN (
+		          SELECT c.id FROM commissions c WHERE c.order_id = $1 AND c.tenant_id = $2
+		      ))
+		  )
+		LIMIT 1
+		FOR UPDATE
+	`, orderID, tenantID,
+		string(domain.PayoutItemStatusScheduled),
+		string(domain.PayoutItemTypeFactoryPayment),
+		string(domain.PayoutItemTypeCommission),
+	).Scan(&batchID)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check payout items: %w", err)
+	}
+	return fmt.Errorf("invalid order status: payments for the order are scheduled in payout batch %s (cancel the payout batch first)", batchID)
+}
+
+// cancelCommissionsInTx トランザクション内で未払いの成果報酬を取消
+// 支払済み（Paid）の成果報酬は取り消さない（返金処理は別途）
+// 振込予定（Scheduled）の成果報酬はcheckScheduledPayoutsInTxでキャンセル自体を拒否する
+func (s *OrderCancellationService) cancelCommissionsInTx(ctx context.Context, tx *sql.Tx, orderID string, tenantID string) (int, error) {
+	result, err := tx.ExecContext(ctx, `
+		UPDATE commissions SET status = $3, updated_at = CURRENT_TIMESTAMP
+		WHERE order_id = $1 AND tenant_id = $2 AND status IN ($4, $5)
+	`, orderID, tenantID,
+		string(domain.CommissionStatusCancelled),
+		string(domain.CommissionStatusPending),
+		string(domain.CommissionStatusApproved),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to cancel commissions: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return int(rowsAffected), nil
+}
+
+// createComplianceDocumentInTx トランザクション内でコンプライアンス文書を保存
+func (s *OrderCancellationService) createComplianceDocumentInTx(ctx context.Context, tx *sql.Tx, doc *domain.ComplianceDocument) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO compliance_documents (
+			id, order_id, document_type, parent_document_id,
+			pdf_url, pdf_hash, generated_at, generated_by,
+			amendment_reason, version, tenant_id, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	`,
+		doc.ID,
+		doc.OrderID,
+		string(doc.DocumentType),
+		doc.ParentDocumentID,
+		doc.PDFURL,
+		doc.PDFHash,
+		doc.GeneratedAt,
+		doc.GeneratedBy,
+		doc.AmendmentReason,
+		doc.Version,
+		doc.TenantID,
+		doc.CreatedAt,
+		doc.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create compliance document: %w", err)
+	}
+	return nil
+}
+
+// createCancellationInTx トランザクション内でキャンセル記録を作成
+func (s *OrderCancellationService) createCancellationInTx(ctx context.Context, tx *sql.Tx, cancellation *domain.OrderCancellation) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO order_cancellations (
+			id, tenant_id, order_id, reason, stage, fee_policy_code, fee_rate_percent,
+			order_amount, fee_amount, released_allocations, released_length,
+			cancelled_commissions, compliance_document_id, cancelled_by, cancelled_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+	`,
+		cancellation.ID,
+		cancellation.TenantID,
+		cancellation.OrderID,
+		cancellation.Reason,
+		string(cancellation.Stage),
+		cancellation.FeePolicyCode,
+		cancellation.FeeRatePercent,
+		cancellation.OrderAmount,
+		cancellation.FeeAmount,
+		cancellation.ReleasedAllocations,
+		cancellation.ReleasedLength,
+		cancellation.CancelledCommissions,
+		sql.NullString{String: cancellation.ComplianceDocumentID, Valid: cancellation.ComplianceDocumentID != ""},
+		cancellation.CancelledBy,
+		cancellation.CancelledAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create order cancellation: %w", err)
+	}
+	return nil
+}
+
+// createAuditLogInTx トランザクション内で監査ログを記録
+// 業務データの更新と監査ログを同時にコミットする場合に使用する（注文キャンセル・一括取込など）
+func createAuditLogInTx(ctx context.Context, tx *sql.Tx, log *domain.AuditLog) error {
+	changedFieldsJSON, err := json.Marshal(log.ChangedFields)
+	if err != nil {
+		return fmt.Errorf("failed to marshal changed_fields: %w", err)
+	}
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO audit_logs (
+			id, tenant_id, user_id, action, resource_type, resource_id,
+			old_value, new_value, changed_fields, ip_address, user_agent, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`,
+		log.ID,
+		log.TenantID,
+		log.UserID,
+		string(log.Action),
+		log.ResourceType,
+		log.ResourceID,
+		log.OldValue,
+		log.NewValue,
+		string(changedFieldsJSON),
+		log.IPAddress,
+		log.UserAgent,
+		log.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create audit log: %w", err)
+	}
+	return nil
+}
+
+// toJSON 監査ログ用にJSON文字列に変換
+func (s *OrderCancellationService) toJSON(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf(`{"error": "failed to marshal: %v"}`, err)
+	}
+	return string(data)
+}
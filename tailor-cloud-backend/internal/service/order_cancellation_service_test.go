@@ -0,0 +1,77 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"tailor-cloud/backend/internal/config/domain"
+)
+
+// TestCancellationFeePolicy 到達工程ごとのキャンセル料のテスト
+func TestCancellationFeePolicy(t *testing.T) {
+	tests := []struct {
+		stage    domain.OrderStatus
+		wantCode string
+		wantFee  int64
+		wantErr  bool
+	}{
+		{domain.OrderStatusDraft, "NO_FEE", 0, false},
+		{domain.OrderStatusConfirmed, "NO_FEE", 0, false},
+		{domain.OrderStatusMaterialSecured, "MATERIAL", 45000, false},
+		{domain.OrderStatusCutting, "CUTTING", 90000, false},
+		{domain.OrderStatusSewing, "SEWING", 120000, false},
+		{domain.OrderStatusInspection, "FULL", 150000, false},
+		// 発送済み以降はキャンセル不可
+		{domain.OrderStatusShipped, "", 0, true},
+		{domain.OrderStatusDelivered, "", 0, true},
+		{domain.OrderStatusCancelled, "", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.stage), func(t *testing.T) {
+			policy, err := domain.CancellationFeePolicyFor(tt.stage)
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "invalid order status") {
+					t.Fatalf("Expected invalid order status error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CancellationFeePolicyFor returned error: %v", err)
+			}
+			if policy.Code != tt.wantCode {
+				t.Errorf("Expected code %s, got %s", tt.wantCode, policy.Code)
+			}
+			if fee := policy.FeeFor(150000); fee != tt.wantFee {
+				t.Errorf("Expected fee %d, got %d", tt.wantFee, fee)
+			}
+		})
+	}
+}
+
+// TestNewOrderCancellation キャンセル記録に到達工程とキャンセル料が記録されることのテスト
+func TestNewOrderCancellation(t *testing.T) {
+	order := &domain.Order{
+		ID:          "order-1",
+		TenantID:    "tenant-1",
+		Status:      domain.OrderStatusCutting,
+		TotalAmount: 99999,
+	}
+	policy, err := domain.CancellationFeePolicyFor(order.Status)
+	if err != nil {
+		t.Fatalf("CancellationFeePolicyFor returned error: %v", err)
+	}
+
+	cancellation := domain.NewOrderCancellation(order, policy, "顧客都合", "user-1")
+
+	if cancellation.Stage != domain.OrderStatusCutting {
+		t.Errorf("Expected stage Cutting, got %s", cancellation.Stage)
+	}
+	// 99999 × 60% = 59999.4 → 円未満切り捨て
+	if cancellation.FeeAmount != 59999 {
+		t.Errorf("Expected fee 59999, got %d", cancellation.FeeAmount)
+	}
+	if cancellation.OrderAmount != 99999 || cancellation.Reason != "顧客都合" {
+		t.Errorf("Unexpected cancellation: %+v", cancellation)
+	}
+}
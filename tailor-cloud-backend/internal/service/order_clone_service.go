@@ -0,0 +1,220 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"tailor-cloud/backend/internal/config/domain"
+	"tailor-cloud/backend/internal/repository"
+)
+
+// 採寸からこの期間が経過した採寸データは再採寸を推奨する
+const measurementStaleAfter = 180 * 24 * time.Hour
+
+// OrderCloneService 再注文サービス
+// 過去の注文の採寸データ・補正情報を引き継いだDraft注文を作成する
+type OrderCloneService struct {
+	orderRepo                    repository.OrderRepository
+	fabricRepo                   repository.FabricRepository // 生地の差し替え時の存在確認用（オプショナル）
+	orderService                 *OrderService
+	measurementValidationService *MeasurementValidationService
+}
+
+// NewOrderCloneService OrderCloneServiceのコンストラクタ
+func NewOrderCloneService(
+	orderRepo repository.OrderRepository,
+	fabricRepo repository.FabricRepository,
+	orderService *OrderService,
+	measurementValidationService *MeasurementValidationService,
+) *OrderCloneService {
+	return &OrderCloneService{
+		orderRepo:                    orderRepo,
+		fabricRepo:                   fabricRepo,
+		orderService:                 orderService,
+		measurementValidationService: measurementValidationService,
+	}
+}
+
+// CloneOrderRequest 再注文リクエスト
+type CloneOrderRequest struct {
+	SourceOrderID string          `json:"-"`
+	TenantID      string          `json:"-"`
+	FabricID      string          `json:"fabric_id"`     // 生地の差し替え（省略時は複製元と同じ生地）
+	DeliveryDate  time.Time       `json:"delivery_date"` // 納期（必須）
+	TotalAmount   int64           `json:"total_amount"`  // 省略時は価格表から計算（価格計算なしの場合は複製元の金額）
+	Description   string          `json:"description"`   // 給付の内容（省略時は複製元と同じ）
+	CreatedBy     string          `json:"-"`
+	CreatedByRole domain.UserRole `json:"-"`
+	IPAddress     string          `json:"-"`
+	UserAgent     string          `json:"-"`
+
+	// 価格計算用（CreateOrderRequestと同じ）
+	PlanType            domain.PlanType `json:"plan_type"`
+	FabricLength        float64         `json:"fabric_length"`
+	OptionCodes         []string        `json:"option_codes"`
+	PriceOverrideReason string          `json:"price_override_reason"`
+	CouponCodes         []string        `json:"coupon_codes"`
+}
+
+// CloneOrderResponse 再注文レスポンス
+type CloneOrderResponse struct {
+	Order                 *domain.Order                 `json:"order"`
+	SourceOrderID         string                        `json:"source_order_id"`
+	FabricSubstituted     bool                          `json:"fabric_substituted"`
+	MeasuredAt            time.Time                     `json:"measured_at"`        // 引き継いだ採寸データの採寸日（複製元の注文作成日）
+	StaleMeasurements     bool                          `json:"stale_measurements"` // 再採寸を推奨するか
+	StaleFields           []string                      `json:"stale_fields"`       // 最新の採寸データと乖離している項目
+	StaleReasons          []string                      `json:"stale_reasons"`
+	MeasurementValidation *ValidateMeasurementsResponse `json:"measurement_validation,omitempty"`
+}
+
+// CloneOrder 過去の注文を複製してDraft注文を作成
+// 採寸データと補正情報を引き継ぎ、最新の採寸データと比較して古くなった項目を通知する
+func (s *OrderCloneService) CloneOrder(ctx context.Context, req *CloneOrderRequest) (*CloneOrderResponse, error) {
+	if req.SourceOrderID == "" {
+		return nil, fmt.Errorf("source order_id is required")
+	}
+	if req.TenantID == "" {
+		return nil, fmt.Errorf("tenant_id is required")
+	}
+
+	// 1. 複製元の注文を取得
+	source, err := s.orderRepo.GetByID(ctx, req.SourceOrderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source order: %w", err)
+	}
+	if source.TenantID != req.TenantID {
+		return nil, fmt.Errorf("unauthorized: tenant_id mismatch")
+	}
+
+	// 2. 生地の差し替え
+	fabricID := source.FabricID
+	fabricSubstituted := false
+	if req.FabricID != "" && req.FabricID != source.FabricID {
+		if s.fabricRepo != nil {
+			if _, err := s.fabricRepo.GetByID(ctx, req.FabricID); err != nil {
+				return nil, fmt.Errorf("invalid fabric_id: %w", err)
+			}
+		}
+		fabricID = req.FabricID
+		fabricSubstituted = true
+	}
+
+	// 3. 採寸データ・補正情報を複製
+	details := cloneOrderDetails(source.Details)
+	if len(details.Adjustments) > 0 {
+		adjustments, err := upgradeStoredOrderAdjustments(details.Adjustments)
+		if err != nil {
+			return nil, err
+		}
+		details.Adjustments = adjustments
+	}
+	if req.Description != "" {
+		details.Description = req.Description
+	}
+
+	// 4. 最新の採寸データと比較（新しい注文の作成前に行い、複製元以降の採寸を検出する）
+	resp := &CloneOrderResponse{
+		SourceOrderID:     source.ID,
+		FabricSubstituted: fabricSubstituted,
+		MeasuredAt:        source.CreatedAt,
+		StaleFields:       make([]string, 0),
+		StaleReasons:      make([]string, 0),
+	}
+	if len(details.MeasurementData) > 0 && s.measurementValidationService != nil {
+		validation, err := s.measurementValidationService.ValidateMeasurements(ctx, &ValidateMeasurementsRequest{
+			CustomerID:          source.CustomerID,
+			TenantID:            source.TenantID,
+			GarmentType:         source.GarmentType,
+			CurrentMeasurements: details.MeasurementData,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("invalid measurement data in source order: %w", err)
+		}
+		resp.MeasurementValidation = validation
+	}
+	flagStaleMeasurements(resp, len(details.MeasurementData) > 0, time.Now())
+
+	// 5. 金額（価格計算がない場合、生地が同じであれば複製元の金額を引き継ぐ）
+	totalAmount := req.TotalAmount
+	if totalAmount == 0 && s.orderService.pricingService == nil {
+		if fabricSubstituted {
+			return nil, fmt.Errorf("total_amount is required when substituting fabric")
+		}
+		totalAmount = source.TotalAmount
+	}
+
+	// 6. Draft注文を作成
+	order, err := s.orderService.CreateOrder(ctx, &CreateOrderRequest{
+		TenantID:            source.TenantID,
+		CustomerID:          source.CustomerID,
+		FabricID:            fabricID,
+		GarmentType:         source.GarmentType,
+		TotalAmount:         totalAmount,
+		DeliveryDate:        req.DeliveryDate,
+		Details:             details,
+		CreatedBy:           req.CreatedBy,
+		IPAddress:           req.IPAddress,
+		UserAgent:           req.UserAgent,
+		PlanType:            req.PlanType,
+		FabricLength:        req.FabricLength,
+		OptionCodes:         req.OptionCodes,
+		PriceOverrideReason: req.PriceOverrideReason,
+		CreatedByRole:       req.CreatedByRole,
+		CouponCodes:         req.CouponCodes,
+		SourceOrderID:       source.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp.Order = order
+
+	return resp, nil
+}
+
+// cloneOrderDetails 注文詳細を複製（JSONデータは新しいスライスにコピー）
+func cloneOrderDetails(details *domain.OrderDetails) *domain.OrderDetails {
+	cloned := &domain.OrderDetails{}
+	if details == nil {
+		return cloned
+	}
+	if details.MeasurementData != nil {
+		cloned.MeasurementData = append(json.RawMessage(nil), details.MeasurementData...)
+	}
+	if details.Adjustments != nil {
+		cloned.Adjustments = append(json.RawMessage(nil), details.Adjustments...)
+	}
+	cloned.Description = details.Description
+	return cloned
+}
+
+// flagStaleMeasurements 引き継いだ採寸データが古くなっていないか判定
+// 最新の採寸データとの乖離（バリデーションアラート）と、採寸からの経過期間で判定する
+func flagStaleMeasurements(resp *CloneOrderResponse, hasMeasurements bool, now time.Time) {
+	if !hasMeasurements {
+		resp.StaleMeasurements = true
+		resp.StaleReasons = append(resp.StaleReasons, "複製元の注文に採寸データがありません")
+		return
+	}
+
+	if resp.MeasurementValidation != nil {
+		seen := make(map[string]bool)
+		for _, alert := range resp.MeasurementValidation.Alerts {
+			if seen[alert.Field] {
+				continue
+			}
+			seen[alert.Field] = true
+			resp.StaleFields = append(resp.StaleFields, alert.Field)
+			resp.StaleReasons = append(resp.StaleReasons, alert.Message)
+		}
+	}
+
+	if age := now.Sub(resp.MeasuredAt); age >= measurementStaleAfter {
+		resp.StaleReasons = append(resp.StaleReasons, fmt.Sprintf("採寸から%d日が経過しています（再採寸の目安: %d日）",
+			int(age.Hours()/24), int(measurementStaleAfter.Hours()/24)))
+	}
+
+	resp.StaleMeasurements = len(resp.StaleReasons) > 0
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"tailor-cloud/backend/internal/config/domain"
+)
+
+// TestCloneOrderDetails 採寸データ・補正情報が複製元と独立してコピーされることのテスト
+func TestCloneOrderDetails(t *testing.T) {
+	source := &domain.OrderDetails{
+		MeasurementData: json.RawMessage(`{"height": 175.0, "waist": 82.0}`),
+		Adjustments:     json.RawMessage(`{"sleeve": 1.0}`),
+		Description:     "スーツ縫製",
+	}
+
+	cloned := cloneOrderDetails(source)
+	if string(cloned.MeasurementData) != string(source.MeasurementData) || string(cloned.Adjustments) != string(source.Adjustments) {
+		t.Fatalf("Cloned details do not match source: %+v", cloned)
+	}
+
+	// 複製後に複製元を変更しても影響しない
+	source.MeasurementData[2] = 'X'
+	if cloned.MeasurementData[2] == 'X' {
+		t.Error("Cloned measurement data shares memory with source")
+	}
+
+	if empty := cloneOrderDetails(nil); empty == nil || empty.MeasurementData != nil {
+		t.Errorf("Expected empty details, got %+v", empty)
+	}
+}
+
+// TestFlagStaleMeasurements 古い採寸データの判定テスト
+func TestFlagStaleMeasurements(t *testing.T) {
+	now := time.Date(2025, 10, 1, 12, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name       string
+		measuredAt time.Time
+		hasData    bool
+		alerts     []ValidationAlert
+		wantStale  bool
+		wantFields int
+	}{
+		{"最新の採寸と一致", now.AddDate(0, -1, 0), true, nil, false, 0},
+		{"最新の採寸と乖離", now.AddDate(0, -1, 0), true, []ValidationAlert{
+			{Field: "waist", Severity: "warning", Message: "waist"},
+			{Field: "waist", Severity: "error", Message: "waist range"},
+			{Field: "hip", Severity: "warning", Message: "hip"},
+		}, true, 2},
+		{"採寸から期間が経過", now.AddDate(0, 0, -200), true, nil, true, 0},
+		{"採寸データなし", now, false, nil, true, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &CloneOrderResponse{
+				MeasuredAt:   tt.measuredAt,
+				StaleFields:  make([]string, 0),
+				StaleReasons: make([]string, 0),
+			}
+			if tt.hasData {
+				resp.MeasurementValidation = &ValidateMeasurementsResponse{Alerts: tt.alerts}
+			}
+
+			flagStaleMeasurements(resp, tt.hasData, now)
+
+			if resp.StaleMeasurements != tt.wantStale {
+				t.Errorf("Expected stale %v, got %v (%v)", tt.wantStale, resp.StaleMeasurements, resp.StaleReasons)
+			}
+			if len(resp.StaleFields) != tt.wantFields {
+				t.Errorf("Expected %d stale fields, got %v", tt.wantFields, resp.StaleFields)
+			}
+		})
+	}
+}
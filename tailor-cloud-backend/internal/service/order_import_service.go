@@ -0,0 +1,641 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"tailor-cloud/backend/internal/config/domain"
+	"tailor-cloud/backend/internal/repository"
+)
+
+// 一括取込の最大行数（1ファイルあたり）
+const maxOrderImportRows = 1000
+
+// OrderImportService 注文一括取込サービス
+// 受注会・法人採寸会でオフライン受注した注文をCSV/XLSXから取り込む
+// ドライランでは行ごとの検証結果のみを返し、確定時は顧客とDraft注文を単一トランザクションで作成する
+type OrderImportService struct {
+	orderRepo                    repository.OrderRepository
+	customerRepo                 repository.CustomerRepository
+	fabricRollRepo               repository.FabricRollRepository
+	pricingService               *PricingService // 価格計算サービス（nilの場合はファイルの金額を使用）
+	measurementValidationService *MeasurementValidationService
+	db                           *sql.DB // トランザクション管理用
+}
+
+// NewOrderImportService OrderImportServiceのコンストラクタ
+func NewOrderImportService(
+	orderRepo repository.OrderRepository,
+	customerRepo repository.CustomerRepository,
+	fabricRollRepo repository.FabricRollRepository,
+	pricingService *PricingService,
+	measurementValidationService *MeasurementValidationService,
+	db *sql.DB,
+) *OrderImportService {
+	return &OrderImportService{
+		orderRepo:                    orderRepo,
+		customerRepo:                 customerRepo,
+		fabricRollRepo:               fabricRollRepo,
+		pricingService:               pricingService,
+		measurementValidationService: measurementValidationService,
+		db:                           db,
+	}
+}
+
+// ImportOrdersRequest 注文一括取込リクエスト
+type ImportOrdersRequest struct {
+	TenantID  string
+	FileName  string
+	Format    domain.OrderImportFormat // 省略時はファイル名・内容から判定
+	Data      []byte
+	DryRun    bool
+	CreatedBy string
+	IPAddress string
+	UserAgent string
+}
+
+// OrderImportRow 取込ファイルの1行（パース済み・未検証）
+type OrderImportRow struct {
+	RowNumber        int               `json:"row_number"` // ファイル上の行番号（ヘッダーが1行目）
+	CustomerID       string            `json:"customer_id"`
+	CustomerName     string            `json:"customer_name"`
+	CustomerEmail    string            `json:"customer_email"`
+	CustomerPhone    string            `json:"customer_phone"`
+	GarmentType      string            `json:"garment_type"`
+	FabricRollNumber string            `json:"fabric_roll_number"`
+	PlanType         string            `json:"plan_type"`
+	TotalAmount      string            `json:"total_amount"`
+	DeliveryDate     string            `json:"delivery_date"`
+	Description      string            `json:"description"`
+	Measurements     map[string]string `json:"measurements"` // 採寸項目（MeasurementDataのJSONキー）→ 入力値
+}
+
+// OrderImportRowResult 行ごとの検証・取込結果
+type OrderImportRowResult struct {
+	RowNumber    int                `json:"row_number"`
+	Valid        bool               `json:"valid"`
+	Errors       []string           `json:"errors"`
+	Warnings     []string           `json:"warnings"`
+	CustomerID   string             `json:"customer_id,omitempty"` // 既存顧客のID（確定時は新規顧客のIDも設定）
+	CustomerName string             `json:"customer_name"`
+	NewCustomer  bool               `json:"new_customer"`
+	GarmentType  domain.GarmentType `json:"garment_type,omitempty"`
+	FabricID     string             `json:"fabric_id,omitempty"`
+	FabricRollID string             `json:"fabric_roll_id,omitempty"`
+	TotalAmount  int64              `json:"total_amount"`
+	DeliveryDate *time.Time         `json:"delivery_date,omitempty"`
+	OrderID      string             `json:"order_id,omitempty"` // 確定時のみ
+
+	customer        *domain.Customer
+	measurementData json.RawMessage
+	description     string
+}
+
+// ImportOrdersResponse 注文一括取込レスポンス
+type ImportOrdersResponse struct {
+	DryRun        bool                     `json:"dry_run"`
+	Committed     bool                     `json:"committed"`
+	Format        domain.OrderImportFormat `json:"format"`
+	TotalRows     int                      `json:"total_rows"`
+	ValidRows     int                      `json:"valid_rows"`
+	ErrorRows     int                      `json:"error_rows"`
+	NewCustomers  int                      `json:"new_customers"`
+	CreatedOrders int                      `json:"created_orders"`
+	Rows          []*OrderImportRowResult  `json:"rows"`
+}
+
+// ImportOrders 注文を一括取込
+// エラーのある行が1行でもある場合は確定せず、検証結果のみを返す（Committed = false）
+func (s *OrderImportService) ImportOrders(ctx context.Context, req *ImportOrdersRequest) (*ImportOrdersResponse, error) {
+	if req.TenantID == "" {
+		return nil, fmt.Errorf("tenant_id is required")
+	}
+	if len(req.Data) == 0 {
+		return nil, fmt.Errorf("file is required")
+	}
+	if !req.DryRun && req.CreatedBy == "" {
+		return nil, fmt.Errorf("created_by is required")
+	}
+
+	format := req.Format
+	if format == "" {
+		format = domain.DetectOrderImportFormat(req.FileName, req.Data)
+	}
+	if !format.IsValid() {
+		return nil, fmt.Errorf("invalid format: %s", format)
+	}
+
+	// 1. ファイルをパース
+	rows, err := ParseOrderImportFile(req.Data, format)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. 全行を検証
+	results, err := s.validateRows(ctx, req.TenantID, rows)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &ImportOrdersResponse{
+		DryRun:    req.DryRun,
+		Format:    format,
+		TotalRows: len(results),
+		Rows:      results,
+	}
+	newCustomers := make(map[*domain.Customer]bool)
+	for _, result := range results {
+		if result.Valid {
+			resp.ValidRows++
+		} else {
+			resp.ErrorRows++
+		}
+		if result.NewCustomer && result.customer != nil {
+			newCustomers[result.customer] = true
+		}
+	}
+	resp.NewCustomers = len(newCustomers)
+
+	if req.DryRun || resp.ErrorRows > 0 {
+		return resp, nil
+	}
+
+	// 3. 顧客とDraft注文を単一トランザクションで作成
+	if err := s.commit(ctx, req, results); err != nil {
+		return nil, err
+	}
+	resp.Committed = true
+	resp.CreatedOrders = len(results)
+
+	return resp, nil
+}
+
+// validateRows 全行を検証し、取込内容を確定
+func (s *OrderImportService) validateRows(ctx context.Context, tenantID string, rows []*OrderImportRow) ([]*OrderImportRowResult, error) {
+	// 既存顧客の照合用（メールアドレス・電話番号）
+	customers, err := s.customerRepo.GetByTenantID(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get customers: %w", err)
+	}
+	existing := make(map[string]*domain.Customer)
+	for _, customer := range customers {
+		if key := customerMatchKey(customer.Email, customer.Phone, ""); key != "" {
+			existing[key] = customer
+		}
+		if customer.Email != "" && customer.Phone != "" {
+			existing[customerMatchKey("", customer.Phone, "")] = customer
+		}
+	}
+
+	// 同じファイル内の同一人物（ジャケットとパンツを別行にした場合など）は1人の新規顧客にまとめる
+	newCustomers := make(map[string]*domain.Customer)
+	rollCache := make(map[string]*domain.FabricRoll)
+	now := time.Now()
+
+	results := make([]*OrderImportRowResult, 0, len(rows))
+	for _, row := range rows {
+		result := &OrderImportRowResult{
+			RowNumber:    row.RowNumber,
+			Errors:       make([]string, 0),
+			Warnings:     make([]string, 0),
+			CustomerName: row.CustomerName,
+			description:  row.Description,
+		}
+
+		s.resolveCustomer(ctx, tenantID, row, result, existing, newCustomers)
+
+		// 品目
+		if row.GarmentType == "" {
+			result.Errors = append(result.Errors, "garment_type is required")
+		} else if garmentType, ok := domain.ParseGarmentType(row.GarmentType); ok {
+			result.GarmentType = garmentType
+		} else {
+			result.Errors = append(result.Errors, fmt.Sprintf("invalid garment_type: %s", row.GarmentType))
+		}
+
+		// 反物（ロール番号から生地を特定）
+		if row.FabricRollNumber == "" {
+			result.Errors = append(result.Errors, "fabric_roll_number is required")
+		} else {
+			roll, ok := rollCache[row.FabricRollNumber]
+			if !ok {
+				roll, err = s.fabricRollRepo.GetByRollNumber(ctx, tenantID, row.FabricRollNumber)
+				if err != nil {
+					roll = nil
+				}
+				rollCache[row.FabricRollNumber] = roll
+			}
+			switch {
+			case roll == nil:
+				result.Errors = append(result.Errors, fmt.Sprintf("fabric roll not found: %s", row.FabricRollNumber))
+			case roll.Status == domain.FabricRollStatusDamaged || roll.Status == domain.FabricRollStatusConsumed:
+				result.Errors = append(result.Errors, fmt.Sprintf("fabric roll %s is not available (status: %s)", row.FabricRollNumber, roll.Status))
+			default:
+				result.FabricID = roll.FabricID
+				result.FabricRollID = roll.ID
+			}
+		}
+
+		// 納期
+		if row.DeliveryDate == "" {
+			result.Errors = append(result.Errors, "delivery_date is required")
+		} else if deliveryDate, err := parseImportDate(row.DeliveryDate); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("invalid delivery_date: %s", row.DeliveryDate))
+		} else if !deliveryDate.After(now) {
+			result.Errors = append(result.Errors, fmt.Sprintf("invalid delivery_date: %s is not in the future", row.DeliveryDate))
+		} else {
+			result.DeliveryDate = &deliveryDate
+		}
+
+		s.resolveAmount(ctx, tenantID, row, result)
+		s.validateMeasurements(ctx, tenantID, row, result)
+
+		result.Valid = len(result.Errors) == 0
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// resolveCustomer 顧客を照合（顧客ID → メールアドレス → 電話番号の順）、見つからない場合は新規顧客とする
+func (s *OrderImportService) resolveCustomer(
+	ctx context.Context,
+	tenantID string,
+	row *OrderImportRow,
+	result *OrderImportRowResult,
+	existing map[string]*domain.Customer,
+	newCustomers map[string]*domain.Customer,
+) {
+	if row.CustomerID != "" {
+		customer, err := s.customerRepo.GetByID(ctx, row.CustomerID, tenantID)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("customer not found: %s", row.CustomerID))
+			return
+		}
+		result.customer = customer
+		result.CustomerID = customer.ID
+		result.CustomerName = customer.Name
+		return
+	}
+
+	for _, key := range []string{
+		customerMatchKey(row.CustomerEmail, "", ""),
+		customerMatchKey("", row.CustomerPhone, ""),
+	} {
+		if key == "" {
+			continue
+		}
+		if customer, ok := existing[key]; ok {
+			result.customer = customer
+			result.CustomerID = customer.ID
+			if row.CustomerName != "" && row.CustomerName != customer.Name {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("customer name %q differs from registered name %q", row.CustomerName, customer.Name))
+			}
+			result.CustomerName = customer.Name
+			return
+		}
+	}
+
+	if row.CustomerName == "" {
+		result.Errors = append(result.Errors, "customer_name is required for a new customer")
+		return
+	}
+
+	key := customerMatchKey(row.CustomerEmail, row.CustomerPhone, row.CustomerName)
+	customer, ok := newCustomers[key]
+	if !ok {
+		now := time.Now()
+		customer = &domain.Customer{
+			TenantID:  tenantID,
+			Name:      row.CustomerName,
+			Email:     row.CustomerEmail,
+			Phone:     row.CustomerPhone,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		newCustomers[key] = customer
+	}
+	result.customer = customer
+	result.NewCustomer = true
+}
+
+// resolveAmount 金額を確定（プラン指定時は価格表で計算し、ファイルの金額と照合）
+func (s *OrderImportService) resolveAmount(ctx context.Context, tenantID string, row *OrderImportRow, result *OrderImportRowResult) {
+	var fileAmount int64
+	if row.TotalAmount != "" {
+		amount, err := strconv.ParseInt(strings.NewReplacer(",", "", "¥", "", "円", "").Replace(row.TotalAmount), 10, 64)
+		if err != nil || amount <= 0 {
+			result.Errors = append(result.Errors, fmt.Sprintf("invalid total_amount: %s", row.TotalAmount))
+			return
+		}
+		fileAmount = amount
+	}
+
+	if row.PlanType != "" {
+		planType := domain.PlanType(row.PlanType)
+		if !planType.IsValid() {
+			result.Errors = append(result.Errors, fmt.Sprintf("invalid plan_type: %s", row.PlanType))
+			return
+		}
+		if s.pricingService != nil && result.FabricID != "" && result.DeliveryDate != nil {
+			breakdown, err := s.pricingService.CalculatePrice(ctx, &PriceRequest{
+				TenantID:     tenantID,
+				PlanType:     planType,
+				FabricID:     result.FabricID,
+				DeliveryDate: *result.DeliveryDate,
+			})
+			if err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("failed to calculate price: %v", err))
+				return
+			}
+			if fileAmount != 0 && fileAmount != breakdown.TotalAmount {
+				result.Errors = append(result.Errors, fmt.Sprintf("invalid total_amount: %d does not match computed price %d", fileAmount, breakdown.TotalAmount))
+				return
+			}
+			result.TotalAmount = breakdown.TotalAmount
+			return
+		}
+	}
+
+	if fileAmount == 0 {
+		result.Errors = append(result.Errors, "total_amount is required")
+		return
+	}
+	result.TotalAmount = fileAmount
+}
+
+// validateMeasurements 採寸値をパースし、品目の採寸テンプレートで必須項目と範囲をバリデーション
+func (s *OrderImportService) validateMeasurements(ctx context.Context, tenantID string, row *OrderImportRow, result *OrderImportRowResult) {
+	if len(row.Measurements) == 0 {
+		result.Warnings = append(result.Warnings, "no measurements")
+		return
+	}
+
+	values := make(map[string]float64, len(row.Measurements))
+	for key, raw := range row.Measurements {
+		value, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(raw), "cm"), 64)
+		if err != nil || value <= 0 {
+			result.Errors = append(result.Errors, fmt.Sprintf("invalid measurement %s: %s", key, raw))
+			continue
+		}
+		values[key] = value
+	}
+
+	data, err := json.Marshal(values)
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("invalid measurements: %v", err))
+		return
+	}
+	result.measurementData = data
+
+	if s.measurementValidationService == nil {
+		return
+	}
+	alerts, err := s.measurementValidationService.ValidateMeasurementRange(ctx, tenantID, result.GarmentType, data)
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("failed to validate measurements: %v", err))
+		return
+	}
+	for _, alert := range alerts {
+		if alert.Severity == "error" {
+			result.Errors = append(result.Errors, alert.Message)
+		} else {
+			result.Warnings = append(result.Warnings, alert.Message)
+		}
+	}
+}
+
+// commit 顧客とDraft注文を単一トランザクションで作成
+func (s *OrderImportService) commit(ctx context.Context, req *ImportOrdersRequest, results []*OrderImportRowResult) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// 1. 新規顧客を作成（同一人物の複数行は1回のみ）
+	for _, result := range results {
+		if !result.NewCustomer || result.customer.ID != "" {
+			continue
+		}
+		result.customer.ID = uuid.New().String()
+		if err := s.createCustomerInTx(ctx, tx, result.customer); err != nil {
+			return err
+		}
+	}
+
+	// 2. Draft注文を作成
+	for _, result := range results {
+		result.CustomerID = result.customer.ID
+
+		order := domain.NewOrder(req.TenantID, result.customer.ID, result.FabricID, req.CreatedBy, result.TotalAmount, *result.DeliveryDate)
+		order.GarmentType = result.GarmentType
+		order.Details = &domain.OrderDetails{
+			MeasurementData: result.measurementData,
+			Description:     result.description,
+		}
+		if err := s.orderRepo.CreateInTx(ctx, tx, order); err != nil {
+			return fmt.Errorf("row %d: failed to create order: %w", result.RowNumber, err)
+		}
+		result.OrderID = order.ID
+
+		// 監査ログ（注文作成と同時にコミット）
+		auditLog := domain.NewAuditLog(req.TenantID, req.CreatedBy, domain.AuditActionCreate, "order", order.ID)
+		if data, err := json.Marshal(order); err == nil {
+			auditLog.NewValue = string(data)
+		}
+		auditLog.ChangedFields = []string{"all"}
+		auditLog.IPAddress = req.IPAddress
+		auditLog.UserAgent = req.UserAgent
+		if err := createAuditLogInTx(ctx, tx, auditLog); err != nil {
+			return err
+		}
+	}
+
+	// トランザクションコミット
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// createCustomerInTx トランザクション内で顧客を作成
+func (s *OrderImportService) createCustomerInTx(ctx context.Context, tx *sql.Tx, customer *domain.Customer) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO customers (
+			id, tenant_id, name, email, phone, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`,
+		customer.ID,
+		customer.TenantID,
+		customer.Name,
+		customer.Email,
+		customer.Phone,
+		customer.CreatedAt,
+		customer.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create customer: %w", err)
+	}
+	return nil
+}
+
+// customerMatchKey 顧客照合用のキー（メールアドレス > 電話番号 > 氏名の優先順）
+func customerMatchKey(email, phone, name string) string {
+	if email = strings.ToLower(strings.TrimSpace(email)); email != "" {
+		return "email:" + email
+	}
+	if digits := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, phone); digits != "" {
+		return "phone:" + digits
+	}
+	if name = strings.Join(strings.Fields(name), ""); name != "" {
+		return "name:" + name
+	}
+	return ""
+}
+
+// orderImportMeasurementColumns 採寸項目の列名（MeasurementDataのJSONキー → ヘッダー名の候補）
+var orderImportMeasurementColumns = []struct {
+	key   string
+	names []string
+}{
+	{"height", []string{"height", "身長"}},
+	{"bust", []string{"bust", "バスト"}},
+	{"waist", []string{"waist", "ウエスト"}},
+	{"hip", []string{"hip", "ヒップ"}},
+	{"thigh", []string{"thigh", "太もも", "ワタリ"}},
+	{"knee", []string{"knee", "膝", "ひざ"}},
+	{"calf", []string{"calf", "ふくらはぎ"}},
+	{"ob", []string{"ob"}},
+	{"jacket_length", []string{"jacket_length", "着丈", "ジャケット丈"}},
+	{"sleeve", []string{"sleeve", "袖丈"}},
+	{"chest", []string{"chest", "胸囲"}},
+}
+
+// ParseOrderImportFile 取込ファイル（CSV/XLSX）をパース
+// 1行目のヘッダー名から列を判定する（英語の列名・日本語の列名どちらも可、大文字小文字は区別しない）
+func ParseOrderImportFile(data []byte, format domain.OrderImportFormat) ([]*OrderImportRow, error) {
+	var records [][]string
+	switch format {
+	case domain.OrderImportFormatXLSX:
+		rows, err := ReadXLSXRows(data)
+		if err != nil {
+			return nil, err
+		}
+		records = rows
+	default:
+		text := string(data)
+		if !utf8.Valid(data) {
+			text = decodeShiftJIS(data)
+		}
+		text = strings.TrimPrefix(text, "\ufeff")
+
+		reader := csv.NewReader(strings.NewReader(text))
+		reader.FieldsPerRecord = -1
+		for {
+			row, err := reader.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("invalid import file: row %d: %w", len(records)+1, err)
+			}
+			records = append(records, row)
+		}
+	}
+
+	if len(records) == 0 {
+		return nil, fmt.Errorf("invalid import file: header row is required")
+	}
+
+	header := make([]string, len(records[0]))
+	for i, h := range records[0] {
+		header[i] = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))
+	}
+
+	customerIDCol := findCSVColumn(header, "customer_id", "顧客id")
+	nameCol := findCSVColumn(header, "customer_name", "name", "顧客名", "氏名", "お名前")
+	emailCol := findCSVColumn(header, "customer_email", "email", "メールアドレス", "メール")
+	phoneCol := findCSVColumn(header, "customer_phone", "phone", "電話番号", "電話")
+	garmentCol := findCSVColumn(header, "garment_type", "garment", "品目", "アイテム")
+	rollCol := findCSVColumn(header, "fabric_roll_number", "roll_number", "ロール番号", "反物番号")
+	planCol := findCSVColumn(header, "plan_type", "プラン")
+	amountCol := findCSVColumn(header, "total_amount", "amount", "金額", "税抜金額")
+	deliveryCol := findCSVColumn(header, "delivery_date", "納期")
+	descCol := findCSVColumn(header, "description", "給付の内容", "備考")
+	if garmentCol < 0 || rollCol < 0 || deliveryCol < 0 || (nameCol < 0 && customerIDCol < 0) {
+		return nil, fmt.Errorf("invalid import file: customer, garment_type, fabric_roll_number and delivery_date columns are required")
+	}
+
+	measurementCols := make(map[string]int)
+	for _, m := range orderImportMeasurementColumns {
+		if col := findCSVColumn(header, m.names...); col >= 0 {
+			measurementCols[m.key] = col
+		}
+	}
+
+	rows := make([]*OrderImportRow, 0, len(records)-1)
+	for i, record := range records[1:] {
+		// 空行は読み飛ばす
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+		if len(rows) >= maxOrderImportRows {
+			return nil, fmt.Errorf("invalid import file: too many rows (max %d)", maxOrderImportRows)
+		}
+
+		row := &OrderImportRow{
+			RowNumber:        i + 2,
+			CustomerID:       csvField(record, customerIDCol),
+			CustomerName:     csvField(record, nameCol),
+			CustomerEmail:    csvField(record, emailCol),
+			CustomerPhone:    csvField(record, phoneCol),
+			GarmentType:      csvField(record, garmentCol),
+			FabricRollNumber: csvField(record, rollCol),
+			PlanType:         csvField(record, planCol),
+			TotalAmount:      csvField(record, amountCol),
+			DeliveryDate:     csvField(record, deliveryCol),
+			Description:      csvField(record, descCol),
+			Measurements:     make(map[string]string),
+		}
+		for key, col := range measurementCols {
+			if value := csvField(record, col); value != "" {
+				row.Measurements[key] = value
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("invalid import file: no data rows")
+	}
+
+	return rows, nil
+}
+
+// parseImportDate 取込ファイルの日付をパース（CSVの日付表記、またはExcelのシリアル値）
+func parseImportDate(s string) (time.Time, error) {
+	if t, err := parseCSVDate(s); err == nil {
+		return t, nil
+	}
+	if serial, err := strconv.ParseFloat(strings.TrimSpace(s), 64); err == nil && serial > 1 && serial < 100000 {
+		return xlsxSerialDate(serial), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", s)
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/text/encoding/japanese"
+
+	"tailor-cloud/backend/internal/config/domain"
+)
+
+// TestParseOrderImportFileCSV 日本語ヘッダーのCSVのパーステスト
+func TestParseOrderImportFileCSV(t *testing.T) {
+	csv := "\ufeff氏名,メールアドレス,品目,ロール番号,金額,納期,身長,ウエスト,着丈\n" +
+		"山田 太郎,taro@example.com,スーツ,VBC-2025-001,\"128,000\",2026/12/01,175,82cm,74\n" +
+		",,,,,,,,\n" +
+		"佐藤 花子,,パンツ,VBC-2025-002,45000,2026-12-15,,,\n"
+
+	rows, err := ParseOrderImportFile([]byte(csv), domain.OrderImportFormatCSV)
+	if err != nil {
+		t.Fatalf("ParseOrderImportFile returned error: %v", err)
+	}
+
+	// 空行は読み飛ばし、行番号はファイル上の行番号
+	if len(rows) != 2 {
+		t.Fatalf("Expected 2 rows, got %d", len(rows))
+	}
+	first := rows[0]
+	if first.RowNumber != 2 || first.CustomerName != "山田 太郎" || first.CustomerEmail != "taro@example.com" {
+		t.Errorf("Unexpected first row: %+v", first)
+	}
+	if first.GarmentType != "スーツ" || first.FabricRollNumber != "VBC-2025-001" || first.TotalAmount != "128,000" {
+		t.Errorf("Unexpected first row: %+v", first)
+	}
+	if first.Measurements["height"] != "175" || first.Measurements["waist"] != "82cm" || first.Measurements["jacket_length"] != "74" {
+		t.Errorf("Unexpected measurements: %+v", first.Measurements)
+	}
+	if rows[1].RowNumber != 4 || len(rows[1].Measurements) != 0 {
+		t.Errorf("Unexpected second row: %+v", rows[1])
+	}
+}
+
+// TestParseOrderImportFileShiftJIS Shift_JISのCSV（Excelで保存したファイル）のパーステスト
+func TestParseOrderImportFileShiftJIS(t *testing.T) {
+	csv := "顧客名,品目,ロール番号,納期\n鈴木 一郎,ジャケット,R-1,2026/12/01\n"
+	data, err := japanese.ShiftJIS.NewEncoder().Bytes([]byte(csv))
+	if err != nil {
+		t.Fatalf("Failed to encode Shift_JIS: %v", err)
+	}
+
+	rows, err := ParseOrderImportFile(data, domain.OrderImportFormatCSV)
+	if err != nil {
+		t.Fatalf("ParseOrderImportFile returned error: %v", err)
+	}
+	if len(rows) != 1 || rows[0].CustomerName != "鈴木 一郎" || rows[0].GarmentType != "ジャケット" {
+		t.Errorf("Unexpected rows: %+v", rows[0])
+	}
+}
+
+// TestParseOrderImportFileMissingColumns 必須列がない場合のテスト
+func TestParseOrderImportFileMissingColumns(t *testing.T) {
+	_, err := ParseOrderImportFile([]byte("customer_name,garment_type\n山田,SUIT\n"), domain.OrderImportFormatCSV)
+	if err == nil || !strings.Contains(err.Error(), "invalid import file") {
+		t.Errorf("Expected invalid import file error, got %v", err)
+	}
+}
+
+// buildTestXLSX テスト用の最小構成のXLSXを作成（共有文字列と数値セル）
+func buildTestXLSX(t *testing.T) []byte {
+	t.Helper()
+	files := map[string]string{
+		"xl/workbook.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="受注" sheetId="1" r:id="rId1"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<?xml version="1.0" encoding="UTF-8"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/></Relationships>`,
+		"xl/sharedStrings.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<si><t>customer_name</t></si><si><t>garment_type</t></si><si><t>fabric_roll_number</t></si><si><t>delivery_date</t></si>
+<si><r><t>山田</t></r><r><t> 太郎</t></r></si><si><t>SUIT</t></si><si><t>VBC-2025-001</t></si></sst>`,
+		"xl/worksheets/sheet1.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>
+<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c><c r="C1" t="s"><v>2</v></c><c r="D1" t="s"><v>3</v></c></row>
+<row r="2"><c r="A2" t="s"><v>4</v></c><c r="B2" t="s"><v>5</v></c><c r="C2" t="s"><v>6</v></c><c r="D2"><v>46357</v></c></row>
+</sheetData></worksheet>`,
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatalf("Failed to create %s: %v", name, err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("Failed to close zip: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// TestParseOrderImportFileXLSX XLSXのパーステスト（日付はシリアル値）
+func TestParseOrderImportFileXLSX(t *testing.T) {
+	data := buildTestXLSX(t)
+	if format := domain.DetectOrderImportFormat("upload", data); format != domain.OrderImportFormatXLSX {
+		t.Fatalf("Expected XLSX format, got %s", format)
+	}
+
+	rows, err := ParseOrderImportFile(data, domain.OrderImportFormatXLSX)
+	if err != nil {
+		t.Fatalf("ParseOrderImportFile returned error: %v", err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("Expected 1 row, got %d", len(rows))
+	}
+	row := rows[0]
+	if row.CustomerName != "山田 太郎" || row.GarmentType != "SUIT" || row.FabricRollNumber != "VBC-2025-001" {
+		t.Errorf("Unexpected row: %+v", row)
+	}
+
+	// 46357 = 2026/12/01
+	deliveryDate, err := parseImportDate(row.DeliveryDate)
+	if err != nil {
+		t.Fatalf("parseImportDate returned error: %v", err)
+	}
+	if !deliveryDate.Equal(time.Date(2026, 12, 1, 0, 0, 0, 0, time.Local)) {
+		t.Errorf("Expected 2026-12-01, got %v", deliveryDate)
+	}
+}
+
+// TestCustomerMatchKey 顧客照合キーのテスト
+func TestCustomerMatchKey(t *testing.T) {
+	tests := []struct {
+		email, phone, name string
+		want               string
+	}{
+		{" Taro@Example.com ", "090-1234-5678", "山田", "email:taro@example.com"},
+		{"", "090-1234-5678", "山田", "phone:09012345678"},
+		{"", "", "山田　太郎", "name:山田太郎"},
+		{"", "", "", ""},
+	}
+
+	for _, tt := range tests {
+		if got := customerMatchKey(tt.email, tt.phone, tt.name); got != tt.want {
+			t.Errorf("customerMatchKey(%q, %q, %q) = %q, want %q", tt.email, tt.phone, tt.name, got, tt.want)
+		}
+	}
+}
+
+// TestValidateImportMeasurements 採寸値の入力チェックのテスト
+func TestValidateImportMeasurements(t *testing.T) {
+	s := &OrderImportService{}
+
+	result := &OrderImportRowResult{}
+	s.validateMeasurements(context.Background(), "tenant-1", &OrderImportRow{Measurements: map[string]string{"waist": "82cm", "hip": "abc"}}, result)
+	if len(result.Errors) != 1 || !strings.Contains(result.Errors[0], "hip") {
+		t.Errorf("Expected one error for hip, got %v", result.Errors)
+	}
+
+	result = &OrderImportRowResult{}
+	s.validateMeasurements(context.Background(), "tenant-1", &OrderImportRow{Measurements: map[string]string{}}, result)
+	if len(result.Errors) != 0 || len(result.Warnings) != 1 {
+		t.Errorf("Expected warning for missing measurements, got errors=%v warnings=%v", result.Errors, result.Warnings)
+	}
+}
@@ -0,0 +1,230 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"tailor-cloud/backend/internal/config/domain"
+	"tailor-cloud/backend/internal/repository"
+)
+
+// TestOrderSearchFilterNormalize 検索条件の既定値と検証のテスト
+func TestOrderSearchFilterNormalize(t *testing.T) {
+	filter := &domain.OrderSearchFilter{TenantID: "tenant-1", Limit: 500}
+	if err := filter.Normalize(); err != nil {
+		t.Fatalf("Normalize returned error: %v", err)
+	}
+	if filter.SortBy != domain.OrderSortCreatedAt || filter.SortOrder != domain.SortOrderDesc {
+		t.Errorf("Unexpected default sort: %s %s", filter.SortBy, filter.SortOrder)
+	}
+	if filter.Limit != 100 {
+		t.Errorf("Expected limit capped at 100, got %d", filter.Limit)
+	}
+
+	from := time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, -1)
+	minAmount, maxAmount := int64(200000), int64(100000)
+	cursor := &domain.OrderCursor{SortBy: domain.OrderSortTotalAmount, SortOrder: domain.SortOrderAsc, Value: "1", OrderID: "order-1"}
+
+	tests := []struct {
+		name    string
+		filter  domain.OrderSearchFilter
+		wantErr string
+	}{
+		{"テナント未指定", domain.OrderSearchFilter{}, "tenant_id is required"},
+		{"不正な並び替え項目", domain.OrderSearchFilter{TenantID: "tenant-1", SortBy: "customer_id"}, "invalid sort"},
+		{"不正なステータス", domain.OrderSearchFilter{TenantID: "tenant-1", Statuses: []domain.OrderStatus{"Unknown"}}, "invalid status"},
+		{"納期の範囲が逆", domain.OrderSearchFilter{TenantID: "tenant-1", DeliveryFrom: &from, DeliveryTo: &to}, "invalid delivery date range"},
+		{"金額の範囲が逆", domain.OrderSearchFilter{TenantID: "tenant-1", MinAmount: &minAmount, MaxAmount: &maxAmount}, "invalid amount range"},
+		{"計上期間が逆", domain.OrderSearchFilter{TenantID: "tenant-1", PostedFrom: &from, PostedTo: &to}, "invalid posting period"},
+		{"並び順とカーソルの不一致", domain.OrderSearchFilter{TenantID: "tenant-1", Cursor: cursor}, "invalid cursor"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.filter.Normalize()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+// TestOrderSearchFilterMatches 検索条件による絞り込みのテスト
+func TestOrderSearchFilterMatches(t *testing.T) {
+	delivery := time.Date(2025, 11, 15, 0, 0, 0, 0, time.UTC)
+	order := &domain.Order{
+		ID:           "order-1",
+		TenantID:     "tenant-1",
+		CustomerID:   "customer-1",
+		FabricID:     "fabric-1",
+		CreatedBy:    "user-1",
+		Status:       domain.OrderStatusSewing,
+		TotalAmount:  150000,
+		DeliveryDate: delivery,
+	}
+
+	from := delivery.AddDate(0, 0, -7)
+	to := delivery
+	minAmount := int64(150000)
+	maxAmount := int64(149999)
+
+	tests := []struct {
+		name   string
+		filter domain.OrderSearchFilter
+		want   bool
+	}{
+		{"条件なし", domain.OrderSearchFilter{TenantID: "tenant-1"}, true},
+		{"別テナント", domain.OrderSearchFilter{TenantID: "tenant-2"}, false},
+		{"ステータス一致", domain.OrderSearchFilter{TenantID: "tenant-1", Statuses: []domain.OrderStatus{domain.OrderStatusCutting, domain.OrderStatusSewing}}, true},
+		{"ステータス不一致", domain.OrderSearchFilter{TenantID: "tenant-1", Statuses: []domain.OrderStatus{domain.OrderStatusDraft}}, false},
+		{"顧客・生地・作成者一致", domain.OrderSearchFilter{TenantID: "tenant-1", CustomerID: "customer-1", FabricID: "fabric-1", CreatedBy: "user-1"}, true},
+		{"作成者不一致", domain.OrderSearchFilter{TenantID: "tenant-1", CreatedBy: "user-2"}, false},
+		{"納期の終了は含まない", domain.OrderSearchFilter{TenantID: "tenant-1", DeliveryFrom: &from, DeliveryTo: &to}, false},
+		{"金額の下限を含む", domain.OrderSearchFilter{TenantID: "tenant-1", MinAmount: &minAmount}, true},
+		{"金額の上限を超える", domain.OrderSearchFilter{TenantID: "tenant-1", MaxAmount: &maxAmount}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Matches(order); got != tt.want {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestOrderCursorRoundTrip カーソルの文字列変換と復元のテスト
+func TestOrderCursorRoundTrip(t *testing.T) {
+	order := &domain.Order{
+		ID:           "order-1",
+		TotalAmount:  98000,
+		CreatedAt:    time.Date(2025, 10, 1, 9, 30, 0, 123456000, time.Local),
+		DeliveryDate: time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	for _, sortBy := range []domain.OrderSortField{domain.OrderSortCreatedAt, domain.OrderSortDeliveryDate, domain.OrderSortTotalAmount} {
+		t.Run(string(sortBy), func(t *testing.T) {
+			encoded := domain.NewOrderCursor(order, sortBy, domain.SortOrderAsc).Encode()
+			cursor, err := domain.DecodeOrderCursor(encoded)
+			if err != nil {
+				t.Fatalf("DecodeOrderCursor returned error: %v", err)
+			}
+			if cursor.SortBy != sortBy || cursor.OrderID != "order-1" {
+				t.Fatalf("Unexpected cursor: %+v", cursor)
+			}
+
+			value, err := cursor.SortValue()
+			if err != nil {
+				t.Fatalf("SortValue returned error: %v", err)
+			}
+			switch v := value.(type) {
+			case int64:
+				if v != 98000 {
+					t.Errorf("Expected amount 98000, got %d", v)
+				}
+			case time.Time:
+				want := order.CreatedAt
+				if sortBy == domain.OrderSortDeliveryDate {
+					want = order.DeliveryDate
+				}
+				if !v.Equal(want) {
+					t.Errorf("Expected %v, got %v", want, v)
+				}
+			default:
+				t.Errorf("Unexpected sort value type %T", value)
+			}
+		})
+	}
+
+	if _, err := domain.DecodeOrderCursor("not-a-cursor"); err == nil {
+		t.Error("Expected error for invalid cursor")
+	}
+}
+
+// fakeOrderSearchRepository 作成日時順の検索のみ実装したメモリ上の注文リポジトリ
+type fakeOrderSearchRepository struct {
+	repository.OrderRepository
+	orders   []*domain.Order
+	searches int
+}
+
+// Search 条件に一致する注文を作成日時・注文IDの順にカーソルの次から最大 filter.Limit+1 件返す
+func (r *fakeOrderSearchRepository) Search(ctx context.Context, filter *domain.OrderSearchFilter) ([]*domain.Order, error) {
+	r.searches++
+	asc := filter.SortOrder == domain.SortOrderAsc
+	before := func(a, b *domain.Order) bool {
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.Before(b.CreatedAt) == asc
+		}
+		return (a.ID < b.ID) == asc
+	}
+
+	matched := make([]*domain.Order, 0)
+	for _, order := range r.orders {
+		if filter.Matches(order) {
+			matched = append(matched, order)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool { return before(matched[i], matched[j]) })
+
+	result := make([]*domain.Order, 0)
+	for _, order := range matched {
+		if filter.Cursor != nil {
+			value, err := filter.Cursor.SortValue()
+			if err != nil {
+				return nil, err
+			}
+			last := &domain.Order{ID: filter.Cursor.OrderID, CreatedAt: value.(time.Time)}
+			if !before(last, order) {
+				continue
+			}
+		}
+		result = append(result, order)
+		if len(result) > filter.Limit {
+			break
+		}
+	}
+	return result, nil
+}
+
+// TestSearchAllOrdersPagesPastLimit 1回の検索の上限（100件）を超える注文をすべて取得するテスト
+func TestSearchAllOrdersPagesPastLimit(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	repo := &fakeOrderSearchRepository{}
+	for i := 0; i < 250; i++ {
+		repo.orders = append(repo.orders, &domain.Order{
+			ID:        fmt.Sprintf("order-%03d", i),
+			TenantID:  "tenant-1",
+			Status:    domain.OrderStatusConfirmed,
+			CreatedAt: base.Add(time.Duration(i%50) * time.Hour), // 作成日時が同じ注文を含める
+		})
+	}
+
+	orders, err := searchAllOrders(context.Background(), repo, &domain.OrderSearchFilter{
+		TenantID:  "tenant-1",
+		SortBy:    domain.OrderSortCreatedAt,
+		SortOrder: domain.SortOrderAsc,
+		Limit:     100,
+	})
+	if err != nil {
+		t.Fatalf("searchAllOrders returned error: %v", err)
+	}
+	if len(orders) != 250 {
+		t.Fatalf("Expected 250 orders, got %d", len(orders))
+	}
+	seen := make(map[string]bool)
+	for _, order := range orders {
+		if seen[order.ID] {
+			t.Fatalf("Order %s returned twice", order.ID)
+		}
+		seen[order.ID] = true
+	}
+	if repo.searches != 3 {
+		t.Errorf("Expected 3 searches, got %d", repo.searches)
+	}
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"fmt"
 	"time"
@@ -16,14 +17,20 @@ type OrderService struct {
 	orderRepo         repository.OrderRepository
 	auditLogRepo      repository.AuditLogRepository // 監査ログリポジトリ（オプショナル）
 	ambassadorService *AmbassadorService            // アンバサダーサービス（成果報酬管理用）
+	pricingService    *PricingService               // 価格計算サービス（nilの場合はクライアント申告の金額を使用）
+	discountService   *DiscountService              // 割引サービス（nilの場合は割引を適用しない）
+	db                *sql.DB                       // トランザクション管理用（価格上書き・割引明細を注文と同時に保存）
 }
 
 // NewOrderService OrderServiceのコンストラクタ
-func NewOrderService(orderRepo repository.OrderRepository, auditLogRepo repository.AuditLogRepository, ambassadorService *AmbassadorService) *OrderService {
+func NewOrderService(orderRepo repository.OrderRepository, auditLogRepo repository.AuditLogRepository, ambassadorService *AmbassadorService, pricingService *PricingService, discountService *DiscountService, db *sql.DB) *OrderService {
 	return &OrderService{
 		orderRepo:         orderRepo,
 		auditLogRepo:      auditLogRepo,
 		ambassadorService: ambassadorService,
+		pricingService:    pricingService,
+		discountService:   discountService,
+		db:                db,
 	}
 }
 
@@ -32,12 +39,30 @@ type CreateOrderRequest struct {
 	TenantID     string               `json:"tenant_id"`
 	CustomerID   string               `json:"customer_id"`
 	FabricID     string               `json:"fabric_id"`
+	GarmentType  domain.GarmentType   `json:"garment_type"` // 品目（省略可）
 	TotalAmount  int64                `json:"total_amount"`
 	DeliveryDate time.Time            `json:"delivery_date"`
 	Details      *domain.OrderDetails `json:"details"`
 	CreatedBy    string               `json:"created_by"`
 	IPAddress    string               `json:"-"` // HTTPリクエストから取得
 	UserAgent    string               `json:"-"` // HTTPリクエストから取得
+
+	// 価格計算用（サーバー側で金額を計算し、TotalAmountと照合する）
+	PlanType            domain.PlanType `json:"plan_type"`
+	FabricLength        float64         `json:"fabric_length"`         // 用尺（メートル、0の場合は生地の最小発注数量）
+	OptionCodes         []string        `json:"option_codes"`          // オプションコード（価格表）
+	PriceOverrideReason string          `json:"price_override_reason"` // 計算価格と異なる金額で作成する場合の理由
+	CreatedByRole       domain.UserRole `json:"-"`                     // 価格上書きの権限チェック用（認証情報から取得）
+	AcceptedQuoteID     string          `json:"-"`                     // 承諾済み見積からの変換（見積金額で合意済みのため照合しない）
+
+	// 割引（TotalAmountは割引前の金額。注文のTotalAmountは割引後の金額となる）
+	CouponCodes []string `json:"coupon_codes"`
+
+	SourceOrderID string `json:"-"` // 複製元の注文ID（再注文の場合）
+	GroupOrderID  string `json:"-"` // 団体注文ID（団体注文の着用者を追加する場合）
+
+	// 注文と同一トランザクションで保存する処理（見積の変換済みの記録など。失敗した場合は注文を作成しない）
+	SaveInTx func(ctx context.Context, tx *sql.Tx, order *domain.Order) error `json:"-"`
 }
 
 // CreateOrder 注文を作成（Draftステータス）
@@ -52,15 +77,90 @@ func (s *OrderService) CreateOrder(ctx context.Context, req *CreateOrderRequest)
 	if req.FabricID == "" {
 		return nil, fmt.Errorf("fabric_id is required")
 	}
-	if req.TotalAmount <= 0 {
-		return nil, fmt.Errorf("total_amount must be greater than 0")
-	}
 	if req.DeliveryDate.IsZero() {
 		return nil, fmt.Errorf("delivery_date is required")
 	}
 	if req.CreatedBy == "" {
 		return nil, fmt.Errorf("created_by is required")
 	}
+	if req.GarmentType != "" && !req.GarmentType.IsValid() {
+		return nil, fmt.Errorf("invalid garment_type: %s", req.GarmentType)
+	}
+	if req.Details != nil && len(req.Details.Adjustments) > 0 {
+		adjustments, err := normalizeOrderAdjustments(req.Details.Adjustments)
+		if err != nil {
+			return nil, err
+		}
+		req.Details.Adjustments = adjustments
+	}
+	if req.Details != nil && len(req.Details.MeasurementData) > 0 {
+		// 採寸データはcmに正規化して保存
+		measurementData, err := domain.NormalizeMeasurementData(req.Details.MeasurementData)
+		if err != nil {
+			return nil, err
+		}
+		req.Details.MeasurementData = measurementData
+	}
+
+	// 価格計算: クライアント申告の金額と照合（省略時は計算価格を採用）
+	// 承諾済み見積・団体注文の価格取り決めは合意済みの金額のため照合しない
+	var priceOverride *domain.OrderPriceOverride
+	if s.pricingService != nil && req.AcceptedQuoteID == "" && req.GroupOrderID == "" {
+		breakdown, err := s.pricingService.CalculatePrice(ctx, &PriceRequest{
+			TenantID:     req.TenantID,
+			PlanType:     req.PlanType,
+			FabricID:     req.FabricID,
+			FabricLength: req.FabricLength,
+			OptionCodes:  req.OptionCodes,
+			DeliveryDate: req.DeliveryDate,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to calculate price: %w", err)
+		}
+
+		if req.TotalAmount == 0 {
+			req.TotalAmount = breakdown.TotalAmount
+		} else if req.TotalAmount != breakdown.TotalAmount {
+			if req.PriceOverrideReason == "" {
+				return nil, fmt.Errorf("invalid total_amount: %d does not match computed price %d (price_override_reason is required to override)", req.TotalAmount, breakdown.TotalAmount)
+			}
+			if req.CreatedByRole != domain.RoleOwner {
+				return nil, fmt.Errorf("unauthorized price override: owner role is required")
+			}
+			priceOverride = domain.NewOrderPriceOverride(req.TenantID, "", breakdown, req.TotalAmount, req.PriceOverrideReason, req.CreatedBy)
+		}
+	}
+	if req.TotalAmount <= 0 {
+		return nil, fmt.Errorf("total_amount must be greater than 0")
+	}
+
+	// 割引: キャンペーン・クーポンを割引明細として適用
+	// 注文金額は割引後の金額とし、消費税・請求書・アンバサダー成果報酬は割引後の金額を基準とする
+	var discountResult *DiscountResult
+	if req.AcceptedQuoteID == "" && req.GroupOrderID == "" {
+		if s.discountService != nil {
+			result, err := s.discountService.EvaluateDiscounts(ctx, &DiscountRequest{
+				TenantID:    req.TenantID,
+				CustomerID:  req.CustomerID,
+				PlanType:    req.PlanType,
+				FabricID:    req.FabricID,
+				GrossAmount: req.TotalAmount,
+				CouponCodes: req.CouponCodes,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to apply discounts: %w", err)
+			}
+			if len(result.Discounts) > 0 {
+				discountResult = result
+			}
+		} else if len(req.CouponCodes) > 0 {
+			return nil, fmt.Errorf("invalid coupon code: discounts are not available")
+		}
+	}
+	totalAmount := req.TotalAmount
+	if discountResult != nil {
+		totalAmount = discountResult.NetAmount
+	}
 
 	// 2. 注文オブジェクトを作成（Draftステータス）
 	order := domain.NewOrder(
@@ -68,10 +168,14 @@ func (s *OrderService) CreateOrder(ctx context.Context, req *CreateOrderRequest)
 		req.CustomerID,
 		req.FabricID,
 		req.CreatedBy,
-		req.TotalAmount,
+		totalAmount,
 		req.DeliveryDate,
 	)
 
+	order.SourceOrderID = req.SourceOrderID
+	order.GarmentType = req.GarmentType
+	order.GroupOrderID = req.GroupOrderID
+
 	// 詳細情報を設定
 	if req.Details != nil {
 		order.Details = req.Details
@@ -79,8 +183,15 @@ func (s *OrderService) CreateOrder(ctx context.Context, req *CreateOrderRequest)
 		order.Details = &domain.OrderDetails{}
 	}
 
-	// 3. リポジトリに保存
-	if err := s.orderRepo.Create(ctx, order); err != nil {
+	// 3. 保存（価格上書き・割引明細・SaveInTxがある場合は注文と同一トランザクションで保存し、記録できない場合は注文を作成しない）
+	if priceOverride != nil || discountResult != nil || req.SaveInTx != nil {
+		if priceOverride != nil {
+			priceOverride.OrderID = order.ID
+		}
+		if err := s.createOrderWithRecords(ctx, order, priceOverride, discountResult, req.SaveInTx); err != nil {
+			return nil, err
+		}
+	} else if err := s.orderRepo.Create(ctx, order); err != nil {
 		return nil, fmt.Errorf("failed to create order: %w", err)
 	}
 
@@ -99,9 +210,39 @@ func (s *OrderService) CreateOrder(ctx context.Context, req *CreateOrderRequest)
 			UserAgent:     req.UserAgent,
 		}
 		s.recordAuditLog(ctxData)
+
+		if priceOverride != nil {
+			s.recordAuditLog(&auditLogContext{
+				TenantID:      req.TenantID,
+				UserID:        req.CreatedBy,
+				Action:        domain.AuditActionUpdate,
+				ResourceType:  "order_price_override",
+				ResourceID:    order.ID,
+				OldValue:      fmt.Sprintf(`{"total_amount": %d}`, priceOverride.ComputedAmount),
+				NewValue:      s.priceOverrideToJSON(priceOverride),
+				ChangedFields: []string{"total_amount"},
+				IPAddress:     req.IPAddress,
+				UserAgent:     req.UserAgent,
+			})
+		}
+
+		if discountResult != nil {
+			s.recordAuditLog(&auditLogContext{
+				TenantID:      req.TenantID,
+				UserID:        req.CreatedBy,
+				Action:        domain.AuditActionUpdate,
+				ResourceType:  "order_discount",
+				ResourceID:    order.ID,
+				OldValue:      fmt.Sprintf(`{"total_amount": %d}`, discountResult.GrossAmount),
+				NewValue:      s.discountResultToJSON(discountResult),
+				ChangedFields: []string{"total_amount"},
+				IPAddress:     req.IPAddress,
+				UserAgent:     req.UserAgent,
+			})
+		}
 	}
 
-	// 5. アンバサダー成果報酬の作成（非同期・エラー時も継続）
+	// 5. アンバサダー成果報酬の作成（非同期・エラー時も継続、割引後の金額が基準）
 	if s.ambassadorService != nil {
 		go func() {
 			_, err := s.ambassadorService.CreateCommissionForOrder(context.Background(), order)
@@ -114,6 +255,44 @@ func (s *OrderService) CreateOrder(ctx context.Context, req *CreateOrderRequest)
 	return order, nil
 }
 
+// createOrderWithRecords 注文と価格上書き記録・割引明細・呼び出し元の記録（saveInTx）を同一トランザクションで保存
+// 割引明細は顧客ごとの利用回数上限をトランザクション内で再確認してから保存する
+func (s *OrderService) createOrderWithRecords(ctx context.Context, order *domain.Order, priceOverride *domain.OrderPriceOverride, discountResult *DiscountResult, saveInTx func(ctx context.Context, tx *sql.Tx, order *domain.Order) error) error {
+	if s.db == nil {
+		return fmt.Errorf("failed to create order: database is not available")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := s.orderRepo.CreateInTx(ctx, tx, order); err != nil {
+		return fmt.Errorf("failed to create order: %w", err)
+	}
+	if priceOverride != nil {
+		if err := s.pricingService.recordOverrideInTx(ctx, tx, priceOverride); err != nil {
+			return fmt.Errorf("failed to record price override: %w", err)
+		}
+	}
+	if discountResult != nil {
+		if err := s.discountService.recordOrderDiscountsInTx(ctx, tx, order, discountResult.Discounts); err != nil {
+			return fmt.Errorf("failed to record order discounts: %w", err)
+		}
+	}
+	if saveInTx != nil {
+		if err := saveInTx(ctx, tx, order); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
 // ConfirmOrderRequest 注文確定リクエスト
 type ConfirmOrderRequest struct {
 	OrderID       string `json:"order_id"`
@@ -204,6 +383,136 @@ func (s *OrderService) GetOrder(ctx context.Context, orderID, tenantID string) (
 	return order, nil
 }
 
+// OrderAdjustmentsResult 注文の補正情報と仕上がり寸法への加減
+type OrderAdjustmentsResult struct {
+	OrderID           string                   `json:"order_id"`
+	Adjustments       *domain.OrderAdjustments `json:"adjustments"`
+	MeasurementDeltas map[string]float64       `json:"measurement_deltas"` // 体型補正と個別の加減を合計した仕上がり寸法への加減（cm）
+}
+
+// GetAdjustments 注文の補正情報を取得
+func (s *OrderService) GetAdjustments(ctx context.Context, orderID, tenantID string) (*OrderAdjustmentsResult, error) {
+	order, err := s.GetOrder(ctx, orderID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	var raw json.RawMessage
+	if order.Details != nil {
+		raw = order.Details.Adjustments
+	}
+	adjustments, err := domain.ReadOrderAdjustments(raw)
+	if err != nil {
+		return nil, err
+	}
+	return &OrderAdjustmentsResult{
+		OrderID:           order.ID,
+		Adjustments:       adjustments,
+		MeasurementDeltas: adjustments.MeasurementDeltas(),
+	}, nil
+}
+
+// UpdateOrderAdjustmentsRequest 補正情報の更新リクエスト
+type UpdateOrderAdjustmentsRequest struct {
+	OrderID     string          `json:"-"`
+	TenantID    string          `json:"-"`
+	Adjustments json.RawMessage `json:"adjustments"` // 補正情報（domain.OrderAdjustments のスキーマ）
+	UserID      string          `json:"-"`           // HTTPリクエストから取得
+	IPAddress   string          `json:"-"`           // HTTPリクエストから取得
+	UserAgent   string          `json:"-"`           // HTTPリクエストから取得
+}
+
+// UpdateAdjustments 注文の補正情報（体型補正・仕上がり寸法の加減）を更新
+// 裁断を始めた注文は型紙が確定しているため変更できない
+func (s *OrderService) UpdateAdjustments(ctx context.Context, req *UpdateOrderAdjustmentsRequest) (*OrderAdjustmentsResult, error) {
+	oldOrder, err := s.GetOrder(ctx, req.OrderID, req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkOrderAdjustmentsEditable(oldOrder.Status); err != nil {
+		return nil, err
+	}
+	adjustments, err := domain.ParseOrderAdjustments(req.Adjustments)
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := json.Marshal(adjustments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal adjustments: %w", err)
+	}
+
+	newOrder := *oldOrder
+	details := domain.OrderDetails{}
+	if oldOrder.Details != nil {
+		details = *oldOrder.Details
+	}
+	details.Adjustments = encoded
+	newOrder.Details = &details
+	newOrder.UpdatedAt = time.Now()
+
+	if err := s.orderRepo.Update(ctx, &newOrder); err != nil {
+		return nil, fmt.Errorf("failed to update order adjustments: %w", err)
+	}
+
+	// 監査ログ記録（非同期・エラー時も継続）
+	if s.auditLogRepo != nil {
+		s.recordAuditLog(&auditLogContext{
+			TenantID:      req.TenantID,
+			UserID:        req.UserID,
+			Action:        domain.AuditActionUpdate,
+			ResourceType:  "order",
+			ResourceID:    newOrder.ID,
+			OldValue:      s.orderToJSON(oldOrder),
+			NewValue:      s.orderToJSON(&newOrder),
+			ChangedFields: []string{"adjustments"},
+			IPAddress:     req.IPAddress,
+			UserAgent:     req.UserAgent,
+		})
+	}
+
+	return &OrderAdjustmentsResult{
+		OrderID:           newOrder.ID,
+		Adjustments:       adjustments,
+		MeasurementDeltas: adjustments.MeasurementDeltas(),
+	}, nil
+}
+
+// checkOrderAdjustmentsEditable 補正情報を変更できるステータスか（生地確保まで）
+func checkOrderAdjustmentsEditable(status domain.OrderStatus) error {
+	switch status {
+	case domain.OrderStatusDraft, domain.OrderStatusConfirmed, domain.OrderStatusMaterialSecured:
+		return nil
+	default:
+		return fmt.Errorf("invalid order status: adjustments cannot be changed after cutting has started (current status: %s)", status)
+	}
+}
+
+// normalizeOrderAdjustments 補正情報をスキーマに沿って検証し、既定値を補った形で返す
+func normalizeOrderAdjustments(raw json.RawMessage) (json.RawMessage, error) {
+	adjustments, err := domain.ParseOrderAdjustments(raw)
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := json.Marshal(adjustments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal adjustments: %w", err)
+	}
+	return encoded, nil
+}
+
+// upgradeStoredOrderAdjustments 保存済みの補正情報をスキーマに沿った形に変換
+// 注文の複製・見積からの変換で引き継ぐ補正情報に使う（スキーマ導入前の自由形式はメモに移す）
+func upgradeStoredOrderAdjustments(raw json.RawMessage) (json.RawMessage, error) {
+	adjustments, err := domain.ReadOrderAdjustments(raw)
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := json.Marshal(adjustments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal adjustments: %w", err)
+	}
+	return encoded, nil
+}
+
 // ListOrders 注文一覧を取得
 func (s *OrderService) ListOrders(ctx context.Context, tenantID string) ([]*domain.Order, error) {
 	orders, err := s.orderRepo.GetByTenantID(ctx, tenantID)
@@ -214,6 +523,35 @@ func (s *OrderService) ListOrders(ctx context.Context, tenantID string) ([]*doma
 	return orders, nil
 }
 
+// SearchOrders 条件に一致する注文を検索（カーソル方式のページネーション）
+func (s *OrderService) SearchOrders(ctx context.Context, filter *domain.OrderSearchFilter) (*domain.PaginatedResponse, error) {
+	if err := filter.Normalize(); err != nil {
+		return nil, err
+	}
+
+	orders, err := s.orderRepo.Search(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search orders: %w", err)
+	}
+
+	total, err := s.orderRepo.CountBySearch(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count orders: %w", err)
+	}
+
+	// Limit+1件目があれば次ページあり（最後に返す注文の位置を次のカーソルとする）
+	nextCursor := ""
+	if len(orders) > filter.Limit {
+		orders = orders[:filter.Limit]
+		nextCursor = domain.NewOrderCursor(orders[len(orders)-1], filter.SortBy, filter.SortOrder).Encode()
+	}
+
+	return &domain.PaginatedResponse{
+		Data:       orders,
+		Pagination: domain.NewCursorPagination(filter.Limit, total, nextCursor),
+	}, nil
+}
+
 // auditLogContext 監査ログ記録用のコンテキスト
 type auditLogContext struct {
 	TenantID      string
@@ -262,3 +600,27 @@ func (s *OrderService) orderToJSON(order *domain.Order) string {
 	}
 	return string(data)
 }
+
+// priceOverrideToJSON 価格上書き後の金額と理由をJSON文字列に変換（監査ログ用）
+func (s *OrderService) priceOverrideToJSON(override *domain.OrderPriceOverride) string {
+	data, err := json.Marshal(map[string]interface{}{
+		"total_amount": override.OverrideAmount,
+		"reason":       override.Reason,
+	})
+	if err != nil {
+		return fmt.Sprintf(`{"error": "failed to marshal price override: %v"}`, err)
+	}
+	return string(data)
+}
+
+// discountResultToJSON 割引後の金額と割引明細をJSON文字列に変換（監査ログ用）
+func (s *OrderService) discountResultToJSON(result *DiscountResult) string {
+	data, err := json.Marshal(map[string]interface{}{
+		"total_amount": result.NetAmount,
+		"discounts":    result.Discounts,
+	})
+	if err != nil {
+		return fmt.Sprintf(`{"error": "failed to marshal discounts: %v"}`, err)
+	}
+	return string(data)
+}
@@ -0,0 +1,328 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"tailor-cloud/backend/internal/config/domain"
+)
+
+// DXF-AAMA（ASTM D6673）のレイヤー番号
+const (
+	aamaLayerBoundary  = "1"  // 外周線（裁断線 = 出来上がり線＋縫い代）
+	aamaLayerTurnPoint = "2"  // ターンポイント（角）
+	aamaLayerCurvePt   = "3"  // カーブ点
+	aamaLayerNotch     = "4"  // 合印
+	aamaLayerGrainline = "7"  // 地の目線
+	aamaLayerSewLine   = "14" // 縫製線（出来上がり線）
+)
+
+const (
+	patternDXFScale       = 10.0 // cm → mm（DXFはミリメートルで出力）
+	patternDXFNotchLength = 5.0  // 合印の長さ（mm）
+	patternDXFPieceGap    = 50.0 // 型紙パーツを並べる間隔（mm）
+	patternDXFTextHeight  = 5.0  // 注記の文字高（mm）
+
+	patternSeamAllowance  = 1.0 // 縫い代（cm）
+	patternSeamMiterLimit = 2.0 // 角の縫い代の延長の上限（縫い代の倍数、超える場合は角を落とす）
+)
+
+// dxfWriter DXFのグループコードと値の組を書き出す
+type dxfWriter struct {
+	buf bytes.Buffer
+}
+
+// pair グループコードと値を1組書き出す
+func (w *dxfWriter) pair(code int, value string) {
+	fmt.Fprintf(&w.buf, "%d\n%s\n", code, value)
+}
+
+// number 数値のグループコードを書き出す（mm、小数点以下3桁）
+func (w *dxfWriter) number(code int, value float64) {
+	if value == 0 {
+		value = 0 // -0 を 0 として出力
+	}
+	w.pair(code, strconv.FormatFloat(value, 'f', 3, 64))
+}
+
+// point 座標を書き出す（Yは上向きに反転）
+func (w *dxfWriter) point(code int, p domain.PatternPoint) {
+	w.number(code, p.X*patternDXFScale)
+	w.number(code+10, -p.Y*patternDXFScale)
+}
+
+// text TEXTエンティティを書き出す
+func (w *dxfWriter) text(layer string, x, y float64, value string) {
+	w.pair(0, "TEXT")
+	w.pair(8, layer)
+	w.number(10, x)
+	w.number(20, y)
+	w.number(40, patternDXFTextHeight)
+	w.pair(1, value)
+}
+
+// line LINEエンティティを書き出す（座標はmm）
+func (w *dxfWriter) line(layer string, x1, y1, x2, y2 float64) {
+	w.pair(0, "LINE")
+	w.pair(8, layer)
+	w.number(10, x1)
+	w.number(20, y1)
+	w.number(11, x2)
+	w.number(21, y2)
+}
+
+// writePatternDXF グレーディング後の型紙をDXF-AAMA形式（AutoCAD R12）で出力
+// 型紙パーツごとにBLOCKを作成し、ENTITIESで横に並べて配置する
+// CADの互換性のため、注記はASCIIのみ（パーツ名はコードで出力）
+func writePatternDXF(pattern *domain.GradedPattern, styleName string) []byte {
+	w := &dxfWriter{}
+
+	// HEADER
+	w.pair(0, "SECTION")
+	w.pair(2, "HEADER")
+	w.pair(9, "$ACADVER")
+	w.pair(1, "AC1009")
+	w.pair(9, "$MEASUREMENT")
+	w.pair(70, "1")
+	w.pair(0, "ENDSEC")
+
+	// TABLES（AAMAのレイヤー）
+	layers := []string{aamaLayerBoundary, aamaLayerTurnPoint, aamaLayerCurvePt, aamaLayerNotch, aamaLayerGrainline, aamaLayerSewLine}
+	w.pair(0, "SECTION")
+	w.pair(2, "TABLES")
+	w.pair(0, "TABLE")
+	w.pair(2, "LAYER")
+	w.pair(70, strconv.Itoa(len(layers)))
+	for _, layer := range layers {
+		w.pair(0, "LAYER")
+		w.pair(2, layer)
+		w.pair(70, "0")
+		w.pair(62, "7")
+		w.pair(6, "CONTINUOUS")
+	}
+	w.pair(0, "ENDTAB")
+	w.pair(0, "ENDSEC")
+
+	// BLOCKS（型紙パーツ）
+	w.pair(0, "SECTION")
+	w.pair(2, "BLOCKS")
+	for _, piece := range pattern.Pieces {
+		writePatternPieceBlock(w, piece)
+	}
+	w.pair(0, "ENDSEC")
+
+	// ENTITIES（スタイル情報と型紙パーツの配置）
+	w.pair(0, "SECTION")
+	w.pair(2, "ENTITIES")
+	generatedAt := pattern.GeneratedAt
+	if generatedAt.IsZero() {
+		generatedAt = time.Now()
+	}
+	styleTexts := []string{
+		"Style Name: " + styleName,
+		"Creation Date: " + generatedAt.Format("01/02/2006"),
+		"Creation Time: " + generatedAt.Format("15:04:05"),
+		"Author: TailorCloud",
+		"Sample Size: CUSTOM",
+		"Grading Rule Table: NONE",
+		"Units: METRIC",
+	}
+	for i, value := range styleTexts {
+		w.text(aamaLayerBoundary, 0, float64(len(styleTexts)-i)*patternDXFTextHeight*2, value)
+	}
+	offsetX := 0.0
+	for _, piece := range pattern.Pieces {
+		minX, minY, maxX := patternOutlineBounds(offsetPatternOutline(piece.Outline, patternSeamAllowance))
+		w.pair(0, "INSERT")
+		w.pair(8, aamaLayerBoundary)
+		w.pair(2, piece.Code)
+		w.number(10, offsetX-minX*patternDXFScale)
+		w.number(20, -patternDXFPieceGap+minY*patternDXFScale)
+		offsetX += (maxX-minX)*patternDXFScale + patternDXFPieceGap
+	}
+	w.pair(0, "ENDSEC")
+	w.pair(0, "EOF")
+	return w.buf.Bytes()
+}
+
+// writePatternPieceBlock 型紙パーツのBLOCKを書き出す
+func writePatternPieceBlock(w *dxfWriter, piece *domain.PatternPiece) {
+	w.pair(0, "BLOCK")
+	w.pair(8, "0")
+	w.pair(2, piece.Code)
+	w.pair(70, "0")
+	w.number(10, 0)
+	w.number(20, 0)
+	w.pair(3, piece.Code)
+
+	// 外周線（裁断線）と縫製線（出来上がり線）
+	cutLine := offsetPatternOutline(piece.Outline, patternSeamAllowance)
+	writePatternPolyline(w, aamaLayerBoundary, cutLine)
+	writePatternPolyline(w, aamaLayerSewLine, piece.Outline)
+
+	// ターンポイント・カーブ点（裁断線上）
+	for _, p := range cutLine {
+		layer := aamaLayerTurnPoint
+		if p.Curve {
+			layer = aamaLayerCurvePt
+		}
+		w.pair(0, "POINT")
+		w.pair(8, layer)
+		w.point(10, p)
+	}
+
+	// 合印（裁断線からパーツの中心方向への短い線）
+	cx, cy := patternPieceCentroid(piece)
+	for _, p := range cutLine {
+		if !p.Notch {
+			continue
+		}
+		dx, dy := cx-p.X, cy-p.Y
+		length := math.Hypot(dx, dy)
+		if length == 0 {
+			continue
+		}
+		x, y := p.X*patternDXFScale, -p.Y*patternDXFScale
+		w.line(aamaLayerNotch, x, y, x+dx/length*patternDXFNotchLength, y-dy/length*patternDXFNotchLength)
+	}
+
+	// 地の目線
+	w.line(aamaLayerGrainline,
+		piece.Grainline[0].X*patternDXFScale, -piece.Grainline[0].Y*patternDXFScale,
+		piece.Grainline[1].X*patternDXFScale, -piece.Grainline[1].Y*patternDXFScale)
+
+	// パーツ情報
+	tx, ty := piece.Grainline[0].X*patternDXFScale+patternDXFTextHeight, -piece.Grainline[0].Y*patternDXFScale
+	texts := []string{
+		"Piece Name: " + piece.Code,
+		"Quantity: " + strconv.Itoa(piece.Quantity),
+		"Category: " + string(piece.Material),
+		"Size: CUSTOM",
+	}
+	for i, value := range texts {
+		w.text(aamaLayerBoundary, tx, ty-float64(i+1)*patternDXFTextHeight*2, value)
+	}
+
+	w.pair(0, "ENDBLK")
+	w.pair(8, "0")
+}
+
+// writePatternPolyline 閉じたポリラインを書き出す
+func writePatternPolyline(w *dxfWriter, layer string, outline []domain.PatternPoint) {
+	w.pair(0, "POLYLINE")
+	w.pair(8, layer)
+	w.pair(66, "1")
+	w.pair(70, "1")
+	for _, p := range outline {
+		w.pair(0, "VERTEX")
+		w.pair(8, layer)
+		w.point(10, p)
+	}
+	w.pair(0, "SEQEND")
+	w.pair(8, layer)
+}
+
+// patternOutlineBounds 外周の外接矩形の左端・上端・右端（cm）
+// 型紙パーツの上端をスタイル情報の下にそろえ、横に並べて配置するために使う
+func patternOutlineBounds(outline []domain.PatternPoint) (float64, float64, float64) {
+	if len(outline) == 0 {
+		return 0, 0, 0
+	}
+	minX, minY, maxX := math.Inf(1), math.Inf(1), math.Inf(-1)
+	for _, p := range outline {
+		minX = math.Min(minX, p.X)
+		minY = math.Min(minY, p.Y)
+		maxX = math.Max(maxX, p.X)
+	}
+	return minX, minY, maxX
+}
+
+// offsetPatternOutline 出来上がり線を外側に distance（cm）だけ平行移動した裁断線
+// 角は隣り合う辺の平行線の交点とし、鋭い凸の角は延長が長くなりすぎないよう2点で角を落とす
+// カーブ点・合印の属性は対応する点に引き継ぐ
+func offsetPatternOutline(outline []domain.PatternPoint, distance float64) []domain.PatternPoint {
+	n := len(outline)
+	if n < 3 {
+		return outline
+	}
+
+	// 外周の向き（符号付き面積が正の場合、内側は辺の進行方向の左）
+	area := 0.0
+	for i := range outline {
+		j := (i + 1) % n
+		area += outline[i].X*outline[j].Y - outline[j].X*outline[i].Y
+	}
+	orientation := 1.0
+	if area < 0 {
+		orientation = -1
+	}
+
+	// 隣り合う異なる点（重複点は辺の向きが決まらないため飛ばす）
+	neighbor := func(i, step int) (domain.PatternPoint, bool) {
+		for k := 1; k < n; k++ {
+			q := outline[((i+step*k)%n+n)%n]
+			if q.X != outline[i].X || q.Y != outline[i].Y {
+				return q, true
+			}
+		}
+		return domain.PatternPoint{}, false
+	}
+	// 辺の外向きの単位法線
+	normal := func(from, to domain.PatternPoint) (float64, float64) {
+		dx, dy := to.X-from.X, to.Y-from.Y
+		length := math.Hypot(dx, dy)
+		return orientation * dy / length, -orientation * dx / length
+	}
+
+	offset := make([]domain.PatternPoint, 0, n)
+	for i, p := range outline {
+		prev, ok1 := neighbor(i, -1)
+		next, ok2 := neighbor(i, 1)
+		if !ok1 || !ok2 {
+			offset = append(offset, p)
+			continue
+		}
+		n1x, n1y := normal(prev, p)
+		n2x, n2y := normal(p, next)
+
+		bx, by := n1x+n2x, n1y+n2y
+		blength := math.Hypot(bx, by)
+		cosHalf := 0.0
+		if blength > 1e-9 {
+			bx, by = bx/blength, by/blength
+			cosHalf = bx*n1x + by*n1y
+		}
+		turn := (p.X-prev.X)*(next.Y-p.Y) - (p.Y-prev.Y)*(next.X-p.X)
+		convex := turn*orientation >= 0
+
+		if cosHalf < 1e-9 || (convex && 1/cosHalf > patternSeamMiterLimit) {
+			first, second := p, p
+			first.X, first.Y = p.X+n1x*distance, p.Y+n1y*distance
+			second.X, second.Y = p.X+n2x*distance, p.Y+n2y*distance
+			second.Notch = false
+			offset = append(offset, first, second)
+			continue
+		}
+		q := p
+		q.X, q.Y = p.X+bx*distance/cosHalf, p.Y+by*distance/cosHalf
+		offset = append(offset, q)
+	}
+	return offset
+}
+
+// patternPieceCentroid 型紙パーツの外周の点の重心（cm）
+func patternPieceCentroid(piece *domain.PatternPiece) (float64, float64) {
+	if len(piece.Outline) == 0 {
+		return 0, 0
+	}
+	var x, y float64
+	for _, p := range piece.Outline {
+		x += p.X
+		y += p.Y
+	}
+	n := float64(len(piece.Outline))
+	return x / n, y / n
+}
@@ -0,0 +1,331 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/jung-kurt/gofpdf/v2"
+
+	"tailor-cloud/backend/internal/config/domain"
+	"tailor-cloud/backend/internal/repository"
+)
+
+// PatternService 型紙サービス
+// 自動補正エンジンの仕上がり寸法とフィット補正で品目の基本ブロックをグレーディングし、
+// 工場のCAD裁断機向けのDXF-AAMAファイルと仕様書（テックシート）PDFを出力する
+type PatternService struct {
+	orderRepo         repository.OrderRepository
+	quoteRepo         repository.QuoteRepository    // オプションの取得用（nilの場合はオプションなし）
+	customerRepo      repository.CustomerRepository // 仕様書の顧客名（nilの場合は表示しない）
+	correctionService *MeasurementCorrectionService // ヌード寸からの変換（nilの場合は仕上がり寸法が保存された注文のみ）
+	unitService       *UnitService                  // 仕様書の寸法の表示単位（nilの場合はcm）
+	jpFontHelper      *JPFontHelper
+}
+
+// NewPatternService PatternServiceのコンストラクタ
+func NewPatternService(
+	orderRepo repository.OrderRepository,
+	quoteRepo repository.QuoteRepository,
+	customerRepo repository.CustomerRepository,
+	correctionService *MeasurementCorrectionService,
+	unitService *UnitService,
+) *PatternService {
+	return &PatternService{
+		orderRepo:         orderRepo,
+		quoteRepo:         quoteRepo,
+		customerRepo:      customerRepo,
+		correctionService: correctionService,
+		unitService:       unitService,
+		jpFontHelper:      NewJPFontHelper(GetFontDir()),
+	}
+}
+
+// OrderPattern 注文の型紙と仕様書の内容
+type OrderPattern struct {
+	Pattern        *domain.GradedPattern  `json:"pattern"`
+	Corrections    []Correction           `json:"corrections"`     // 仕上がり寸法の計算根拠（自動補正エンジンの補正履歴）
+	FitCorrections []domain.FitCorrection `json:"fit_corrections"` // 注文で選択した体型補正
+	Options        []*domain.QuoteLine    `json:"options"`         // オプション（見積から注文した場合）
+}
+
+// GeneratePattern 注文の仕上がり寸法とフィット補正で型紙をグレーディング
+func (s *PatternService) GeneratePattern(ctx context.Context, orderID, tenantID string) (*OrderPattern, error) {
+	order, err := s.getOrder(ctx, orderID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return s.buildOrderPattern(ctx, order)
+}
+
+// ExportDXF 注文の型紙をDXF-AAMA形式で出力
+func (s *PatternService) ExportDXF(ctx context.Context, orderID, tenantID string) ([]byte, error) {
+	order, err := s.getOrder(ctx, orderID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.buildOrderPattern(ctx, order)
+	if err != nil {
+		return nil, err
+	}
+	return writePatternDXF(result.Pattern, domain.InvoiceReference(order.ID)), nil
+}
+
+// GenerateTechSheetPDF 注文の仕様書（仕上がり寸法・フィット補正・オプション・型紙パーツ）PDFを生成
+func (s *PatternService) GenerateTechSheetPDF(ctx context.Context, orderID, tenantID string) ([]byte, error) {
+	order, err := s.getOrder(ctx, orderID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.buildOrderPattern(ctx, order)
+	if err != nil {
+		return nil, err
+	}
+	pattern := result.Pattern
+
+	// 寸法は顧客（未設定の場合はテナント）の表示単位で出力
+	unit := s.unitService.GetPreference(ctx, tenantID, order.CustomerID).MeasurementUnit
+
+	customerName := ""
+	if s.customerRepo != nil {
+		if customer, err := s.customerRepo.GetByID(ctx, order.CustomerID, tenantID); err == nil {
+			customerName = customer.Name
+		}
+	}
+
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetTitle("仕様書", false)
+	pdf.SetAuthor("TailorCloud", false)
+	pdf.AddPage()
+
+	// 日本語フォントを登録
+	if err := s.jpFontHelper.RegisterJPFonts(pdf); err != nil {
+		// フォント登録に失敗した場合は警告を出して続行（英語フォントで代替）
+		fmt.Printf("WARNING: Failed to register Japanese fonts: %v\n", err)
+	}
+
+	// タイトル
+	s.jpFontHelper.SetJPFont(pdf, "B", 16)
+	pdf.CellFormat(190, 10, "仕様書", "", 1, "C", false, 0, "")
+	pdf.Ln(3)
+
+	// 注文情報
+	s.jpFontHelper.SetJPFont(pdf, "", 10)
+	pdf.CellFormat(95, 6, fmt.Sprintf("注文番号: %s", domain.InvoiceReference(order.ID)), "", 0, "L", false, 0, "")
+	pdf.CellFormat(95, 6, fmt.Sprintf("品目: %s", pattern.GarmentType.Label()), "", 1, "L", false, 0, "")
+	if customerName != "" {
+		pdf.CellFormat(95, 6, fmt.Sprintf("顧客: %s 様", customerName), "", 0, "L", false, 0, "")
+	} else {
+		pdf.CellFormat(95, 6, "", "", 0, "L", false, 0, "")
+	}
+	pdf.CellFormat(95, 6, fmt.Sprintf("納期: %s", order.DeliveryDate.Format("2006年01月02日")), "", 1, "L", false, 0, "")
+	pdf.CellFormat(190, 6, fmt.Sprintf("生地: %s", order.FabricID), "", 1, "L", false, 0, "")
+	pdf.Ln(4)
+
+	// 仕上がり寸法
+	s.jpFontHelper.SetJPFont(pdf, "B", 11)
+	pdf.CellFormat(190, 7, fmt.Sprintf("仕上がり寸法 (%s)", unit), "", 1, "L", false, 0, "")
+	s.jpFontHelper.SetJPFont(pdf, "B", 9)
+	pdf.CellFormat(55, 7, "項目", "1", 0, "C", false, 0, "")
+	pdf.CellFormat(45, 7, "仕上がり寸法", "1", 0, "C", false, 0, "")
+	pdf.CellFormat(45, 7, "フィット補正", "1", 0, "C", false, 0, "")
+	pdf.CellFormat(45, 7, "基本ブロックとの差", "1", 1, "C", false, 0, "")
+	s.jpFontHelper.SetJPFont(pdf, "", 9)
+	for _, key := range domain.PatternMeasurementKeys {
+		value, ok := pattern.Measurements[key]
+		if !ok {
+			continue
+		}
+		adjustment := ""
+		if amount, ok := pattern.Adjustments[key]; ok {
+			adjustment = unit.FormatCentimeters(amount, true)
+		}
+		grade := ""
+		if amount, ok := pattern.Grades[key]; ok {
+			grade = unit.FormatCentimeters(amount, true)
+		}
+		pdf.CellFormat(55, 6, domain.PatternMeasurementLabel(key), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(45, 6, unit.FormatCentimeters(value, false), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(45, 6, adjustment, "1", 0, "R", false, 0, "")
+		pdf.CellFormat(45, 6, grade, "1", 1, "R", false, 0, "")
+	}
+	if len(pattern.MissingMeasurements) > 0 {
+		labels := make([]string, 0, len(pattern.MissingMeasurements))
+		for _, key := range pattern.MissingMeasurements {
+			labels = append(labels, domain.PatternMeasurementLabel(key))
+		}
+		pdf.MultiCell(190, 6, "※ 仕上がり寸法がない項目は基本ブロックの寸法のまま: "+strings.Join(labels, "、"), "", "L", false)
+	}
+	pdf.Ln(4)
+
+	// 体型補正
+	s.jpFontHelper.SetJPFont(pdf, "B", 11)
+	pdf.CellFormat(190, 7, "体型補正", "", 1, "L", false, 0, "")
+	s.jpFontHelper.SetJPFont(pdf, "", 9)
+	if len(result.FitCorrections) == 0 {
+		pdf.CellFormat(190, 6, "なし", "", 1, "L", false, 0, "")
+	}
+	for _, correction := range result.FitCorrections {
+		label := string(correction.Type)
+		if definition := domain.FitCorrectionDefinitionFor(correction.Type); definition != nil {
+			label = definition.Label
+		}
+		pdf.CellFormat(45, 6, label, "1", 0, "L", false, 0, "")
+		pdf.CellFormat(30, 6, fmt.Sprintf("%s %s", unit.FormatCentimeters(correction.Amount, false), unit), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(115, 6, correction.Notes, "1", 1, "L", false, 0, "")
+	}
+	pdf.Ln(4)
+
+	// オプション
+	s.jpFontHelper.SetJPFont(pdf, "B", 11)
+	pdf.CellFormat(190, 7, "オプション", "", 1, "L", false, 0, "")
+	s.jpFontHelper.SetJPFont(pdf, "", 9)
+	if len(result.Options) == 0 {
+		pdf.CellFormat(190, 6, "なし", "", 1, "L", false, 0, "")
+	}
+	for _, option := range result.Options {
+		pdf.CellFormat(45, 6, option.Code, "1", 0, "L", false, 0, "")
+		pdf.CellFormat(145, 6, option.Description, "1", 1, "L", false, 0, "")
+	}
+	pdf.Ln(4)
+
+	// 仕上がり寸法の根拠
+	if len(result.Corrections) > 0 {
+		s.jpFontHelper.SetJPFont(pdf, "B", 11)
+		pdf.CellFormat(190, 7, "仕上がり寸法の根拠", "", 1, "L", false, 0, "")
+		s.jpFontHelper.SetJPFont(pdf, "", 8)
+		for _, correction := range result.Corrections {
+			pdf.MultiCell(190, 5, "・"+correction.Description, "", "L", false)
+		}
+		pdf.Ln(4)
+	}
+
+	// 型紙パーツ
+	s.jpFontHelper.SetJPFont(pdf, "B", 11)
+	pdf.CellFormat(190, 7, "型紙パーツ", "", 1, "L", false, 0, "")
+	s.jpFontHelper.SetJPFont(pdf, "B", 9)
+	pdf.CellFormat(65, 7, "コード", "1", 0, "C", false, 0, "")
+	pdf.CellFormat(40, 7, "パーツ", "1", 0, "C", false, 0, "")
+	pdf.CellFormat(25, 7, "素材", "1", 0, "C", false, 0, "")
+	pdf.CellFormat(20, 7, "枚数", "1", 0, "C", false, 0, "")
+	pdf.CellFormat(40, 7, fmt.Sprintf("幅×高さ (%s)", unit), "1", 1, "C", false, 0, "")
+	s.jpFontHelper.SetJPFont(pdf, "", 9)
+	for _, piece := range pattern.Pieces {
+		pdf.CellFormat(65, 6, piece.Code, "1", 0, "L", false, 0, "")
+		pdf.CellFormat(40, 6, piece.Name, "1", 0, "L", false, 0, "")
+		pdf.CellFormat(25, 6, string(piece.Material), "1", 0, "C", false, 0, "")
+		pdf.CellFormat(20, 6, fmt.Sprintf("%d", piece.Quantity), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(40, 6, fmt.Sprintf("%s × %s", unit.FormatCentimeters(piece.Width, false), unit.FormatCentimeters(piece.Height, false)), "1", 1, "R", false, 0, "")
+	}
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("failed to output PDF: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// buildOrderPattern 注文の仕上がり寸法・フィット補正・オプションから型紙を作成
+func (s *PatternService) buildOrderPattern(ctx context.Context, order *domain.Order) (*OrderPattern, error) {
+	measurements, corrections, err := s.finalMeasurements(ctx, order)
+	if err != nil {
+		return nil, err
+	}
+	adjustments, err := domain.ReadOrderAdjustments(order.Details.Adjustments)
+	if err != nil {
+		return nil, err
+	}
+	pattern, err := domain.NewGradedPattern(order.ID, order.GarmentType, measurements, adjustments.MeasurementDeltas())
+	if err != nil {
+		return nil, err
+	}
+	if corrections == nil {
+		corrections = []Correction{}
+	}
+	return &OrderPattern{
+		Pattern:        pattern,
+		Corrections:    corrections,
+		FitCorrections: adjustments.FitCorrections,
+		Options:        s.orderOptions(ctx, order),
+	}, nil
+}
+
+// finalMeasurements 注文の仕上がり寸法と計算根拠
+// 採寸データに仕上がり寸法（final_measurements）が保存されていればそれを使い、
+// なければヌード寸を自動補正エンジンで変換する
+func (s *PatternService) finalMeasurements(ctx context.Context, order *domain.Order) (map[string]float64, []Correction, error) {
+	if order.Details == nil || len(order.Details.MeasurementData) == 0 {
+		return nil, nil, fmt.Errorf("invalid order: measurement data is required")
+	}
+
+	var data struct {
+		FinalMeasurements *FinalMeasurement `json:"final_measurements"`
+	}
+	if err := json.Unmarshal(order.Details.MeasurementData, &data); err != nil {
+		return nil, nil, fmt.Errorf("invalid measurement data: %w", err)
+	}
+	if data.FinalMeasurements != nil {
+		return expectedFinalMeasurements(order.Details.MeasurementData), data.FinalMeasurements.Corrections, nil
+	}
+
+	if s.correctionService == nil {
+		return nil, nil, fmt.Errorf("measurement correction service is not configured")
+	}
+	var raw RawMeasurement
+	if err := json.Unmarshal(order.Details.MeasurementData, &raw); err != nil {
+		return nil, nil, fmt.Errorf("invalid measurement data: %w", err)
+	}
+	converted, err := s.correctionService.ConvertToFinalMeasurements(ctx, &ConvertToFinalMeasurementsRequest{
+		RawMeasurements: &raw,
+		TenantID:        order.TenantID,
+		FabricID:        order.FabricID,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	encoded, err := json.Marshal(converted.FinalMeasurements)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal final measurements: %w", err)
+	}
+	return expectedFinalMeasurements(encoded), converted.FinalMeasurements.Corrections, nil
+}
+
+// orderOptions 注文に変換された見積のオプション明細
+func (s *PatternService) orderOptions(ctx context.Context, order *domain.Order) []*domain.QuoteLine {
+	options := []*domain.QuoteLine{}
+	if s.quoteRepo == nil {
+		return options
+	}
+	quotes, err := s.quoteRepo.GetByTenantID(ctx, order.TenantID, domain.QuoteStatusConverted)
+	if err != nil {
+		log.Printf("WARNING: Failed to get quotes for tech sheet options: %v", err)
+		return options
+	}
+	for _, quote := range quotes {
+		if quote.ConvertedOrderID != order.ID {
+			continue
+		}
+		for _, line := range quote.Lines {
+			if line.LineType == domain.QuoteLineTypeOption {
+				options = append(options, line)
+			}
+		}
+	}
+	return options
+}
+
+// getOrder 注文を取得（テナントIDもチェック）
+func (s *PatternService) getOrder(ctx context.Context, orderID, tenantID string) (*domain.Order, error) {
+	order, err := s.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+	if order.TenantID != tenantID {
+		return nil, fmt.Errorf("unauthorized: tenant_id mismatch")
+	}
+	return order, nil
+}
@@ -0,0 +1,346 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+
+	"tailor-cloud/backend/internal/config/domain"
+)
+
+// patternPieceByCode 型紙パーツをコードで探す
+func patternPieceByCode(t *testing.T, pattern *domain.GradedPattern, code string) *domain.PatternPiece {
+	t.Helper()
+	for _, piece := range pattern.Pieces {
+		if piece.Code == code {
+			return piece
+		}
+	}
+	t.Fatalf("piece %s not found", code)
+	return nil
+}
+
+// TestNewGradedPatternSuit スーツの基本ブロックのグレーディングのテスト
+func TestNewGradedPatternSuit(t *testing.T) {
+	measurements := map[string]float64{
+		"half_chest": 53, "jacket_length": 78, "shoulder_width": 45, "sleeve_length": 61,
+		"waist": 84, "hip": 100, "rise": 27, "hem": 21, "chest": 106,
+	}
+	pattern, err := domain.NewGradedPattern("order-1", domain.GarmentTypeSuit, measurements, map[string]float64{"sleeve_length": -1.5})
+	if err != nil {
+		t.Fatalf("NewGradedPattern returned error: %v", err)
+	}
+
+	// 身幅+2cm → 後身頃の脇は0.4倍、着丈+3cm → 裾は3cm下がる
+	back := patternPieceByCode(t, pattern, "JACKET-BACK")
+	if back.Outline[4].X != 25.3 || back.Outline[6].Y != 78 {
+		t.Errorf("back underarm = %+v, hem = %+v", back.Outline[4], back.Outline[6])
+	}
+	// フィット補正（袖丈-1.5cm）は仕上がり寸法に反映してからグレーディング
+	sleeve := patternPieceByCode(t, pattern, "JACKET-TOP-SLEEVE")
+	if sleeve.Outline[3].Y != 59.5 || pattern.Measurements["sleeve_length"] != 59.5 || pattern.Adjustments["sleeve_length"] != -1.5 {
+		t.Errorf("sleeve cuff = %+v, measurements = %v", sleeve.Outline[3], pattern.Measurements)
+	}
+	if pattern.Grades["half_chest"] != 2 || pattern.Grades["sleeve_length"] != -1.5 {
+		t.Errorf("grades = %v", pattern.Grades)
+	}
+	// 股下がないパンツは基本ブロックの寸法のまま
+	if len(pattern.MissingMeasurements) != 1 || pattern.MissingMeasurements[0] != "inseam" {
+		t.Errorf("missing = %v, want [inseam]", pattern.MissingMeasurements)
+	}
+	front := patternPieceByCode(t, pattern, "TROUSERS-FRONT")
+	if front.Outline[4].Y != 105 || front.Height <= 0 || front.Width <= 0 {
+		t.Errorf("trousers front = %+v", front)
+	}
+}
+
+// TestNewGradedPatternInvalid グレーディングできない仕上がり寸法・フィット補正のテスト
+func TestNewGradedPatternInvalid(t *testing.T) {
+	base := map[string]float64{"neck": 40, "half_chest": 51}
+	tests := []struct {
+		name         string
+		measurements map[string]float64
+		adjustments  map[string]float64
+		want         string
+	}{
+		{"too far from block", map[string]float64{"neck": 40, "half_chest": 70}, nil, "too far"},
+		{"unknown adjustment", base, map[string]float64{"collar_roll": 1}, "unknown measurement"},
+		{"adjustment without measurement", base, map[string]float64{"sleeve_length": 1}, "no final measurement"},
+	}
+	for _, tt := range tests {
+		_, err := domain.NewGradedPattern("order-1", domain.GarmentTypeShirt, tt.measurements, tt.adjustments)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: err = %v, want %q", tt.name, err, tt.want)
+		}
+	}
+	if _, err := domain.NewGradedPattern("order-1", "KIMONO", base, nil); err == nil {
+		t.Errorf("unknown garment type should fail")
+	}
+}
+
+// TestParseOrderAdjustments 補正情報のスキーマのパース・バリデーションのテスト
+func TestParseOrderAdjustments(t *testing.T) {
+	adjustments, err := domain.ParseOrderAdjustments(json.RawMessage(
+		`{"fit_corrections": [{"type": "ERECT", "amount": 1.5, "notes": "胸を張る"}, {"type": "PROMINENT_SEAT"}], "measurements": {"sleeve_length": -1}}`,
+	))
+	if err != nil {
+		t.Fatalf("ParseOrderAdjustments returned error: %v", err)
+	}
+	// 補正量の省略時はカタログの既定値
+	if adjustments.Version != domain.OrderAdjustmentsVersion || adjustments.FitCorrections[1].Amount != 1.5 {
+		t.Errorf("adjustments = %+v", adjustments)
+	}
+	empty, err := domain.ParseOrderAdjustments(nil)
+	if err != nil || len(empty.FitCorrections) != 0 || len(empty.MeasurementDeltas()) != 0 {
+		t.Errorf("empty adjustments = %+v, err = %v", empty, err)
+	}
+
+	tests := []struct {
+		name string
+		raw  string
+		want string
+	}{
+		{"legacy flat format", `{"sleeve_length": -1.5}`, "unknown field"},
+		{"unknown type", `{"fit_corrections": [{"type": "HUNCHBACK"}]}`, "unknown fit correction type"},
+		{"amount out of range", `{"fit_corrections": [{"type": "ERECT", "amount": 5}]}`, "must be between"},
+		{"duplicate", `{"fit_corrections": [{"type": "ERECT"}, {"type": "ERECT"}]}`, "duplicate"},
+		{"conflict", `{"fit_corrections": [{"type": "SQUARE_SHOULDERS"}, {"type": "SLOPING_SHOULDERS"}]}`, "cannot be combined"},
+		{"unknown measurement", `{"measurements": {"collar_roll": 1}}`, "unknown measurement"},
+		{"measurement too large", `{"measurements": {"waist": 12}}`, "within"},
+		{"unsupported version", `{"version": 2}`, "unsupported version"},
+	}
+	for _, tt := range tests {
+		_, err := domain.ParseOrderAdjustments(json.RawMessage(tt.raw))
+		if err == nil || !strings.Contains(err.Error(), "invalid adjustments") || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: err = %v, want %q", tt.name, err, tt.want)
+		}
+	}
+}
+
+// TestOrderAdjustmentsMeasurementDeltas 体型補正と個別の加減の合計のテスト
+func TestOrderAdjustmentsMeasurementDeltas(t *testing.T) {
+	adjustments := &domain.OrderAdjustments{
+		FitCorrections: []domain.FitCorrection{
+			{Type: domain.FitCorrectionErect, Amount: 1.5},
+			{Type: domain.FitCorrectionProminentBelly, Amount: 2},
+			{Type: domain.FitCorrectionSlopingShoulders, Amount: 1},
+		},
+		Measurements: map[string]float64{"half_chest": 0.25, "back_balance": 1.5},
+	}
+	if err := adjustments.Validate(); err != nil {
+		t.Fatalf("Validate returned error: %v", err)
+	}
+	got := adjustments.MeasurementDeltas()
+	want := map[string]float64{
+		"front_balance":  2.5, // 反身1.5 + 出腹2×0.5
+		"half_chest":     1.0, // 反身1.5×0.5 + 個別0.25
+		"belly":          2,
+		"shoulder_slope": -1,
+	}
+	// 後丈バランスは反身-1.5と個別+1.5で相殺
+	if len(got) != len(want) {
+		t.Errorf("deltas = %v, want %v", got, want)
+	}
+	for key, value := range want {
+		if got[key] != value {
+			t.Errorf("deltas[%s] = %v, want %v", key, got[key], value)
+		}
+	}
+}
+
+// TestNewGradedPatternFitCorrections 体型補正の項目のグレーディングのテスト
+func TestNewGradedPatternFitCorrections(t *testing.T) {
+	measurements := map[string]float64{
+		"half_chest": 51, "jacket_length": 75, "shoulder_width": 45, "sleeve_length": 61,
+		"waist": 84, "hip": 100, "rise": 27, "inseam": 78, "hem": 21,
+	}
+	adjustments, err := domain.ParseOrderAdjustments(json.RawMessage(
+		`{"fit_corrections": [{"type": "ERECT", "amount": 1}, {"type": "SQUARE_SHOULDERS", "amount": 1}, {"type": "PROMINENT_SEAT", "amount": 2}]}`,
+	))
+	if err != nil {
+		t.Fatalf("ParseOrderAdjustments returned error: %v", err)
+	}
+	pattern, err := domain.NewGradedPattern("order-1", domain.GarmentTypeSuit, measurements, adjustments.MeasurementDeltas())
+	if err != nil {
+		t.Fatalf("NewGradedPattern returned error: %v", err)
+	}
+
+	// 反身: 前身頃の首・肩は上がり、後身頃の首は下がる。いかり肩: 肩先がさらに上がる
+	front := patternPieceByCode(t, pattern, "JACKET-FRONT")
+	if front.Outline[0].Y != 7 || front.Outline[2].Y != 1 {
+		t.Errorf("front neck = %+v, shoulder = %+v", front.Outline[0], front.Outline[2])
+	}
+	back := patternPieceByCode(t, pattern, "JACKET-BACK")
+	if back.Outline[0].Y != 1 || back.Outline[2].Y != 2.5 {
+		t.Errorf("back neck = %+v, shoulder = %+v", back.Outline[0], back.Outline[2])
+	}
+	// 出尻: 後パンツの後中心が上がり、股ぐりが伸びる。ヒップは+1cm
+	trousers := patternPieceByCode(t, pattern, "TROUSERS-BACK")
+	if trousers.Outline[0].Y != -4.5 || trousers.Outline[7].X != -10.1 {
+		t.Errorf("trousers back = %+v", trousers.Outline)
+	}
+	if pattern.Measurements["hip"] != 101 || pattern.Grades["seat"] != 2 || pattern.Grades["front_balance"] != 1 {
+		t.Errorf("measurements = %v, grades = %v", pattern.Measurements, pattern.Grades)
+	}
+	if len(pattern.MissingMeasurements) != 0 {
+		t.Errorf("missing = %v, want none", pattern.MissingMeasurements)
+	}
+}
+
+// TestNormalizeOrderAdjustments 注文作成時の補正情報の正規化と変更可能なステータスのテスト
+func TestNormalizeOrderAdjustments(t *testing.T) {
+	normalized, err := normalizeOrderAdjustments(json.RawMessage(`{"fit_corrections": [{"type": "STOOPED"}]}`))
+	if err != nil {
+		t.Fatalf("normalizeOrderAdjustments returned error: %v", err)
+	}
+	if !strings.Contains(string(normalized), `"version":1`) || !strings.Contains(string(normalized), `"amount":1`) {
+		t.Errorf("normalized = %s", normalized)
+	}
+	if _, err := normalizeOrderAdjustments(json.RawMessage(`{"fit_corrections": [{"type": "ERECT"}, {"type": "STOOPED"}]}`)); err == nil {
+		t.Errorf("conflicting corrections should fail")
+	}
+
+	for _, status := range []domain.OrderStatus{domain.OrderStatusDraft, domain.OrderStatusConfirmed, domain.OrderStatusMaterialSecured} {
+		if err := checkOrderAdjustmentsEditable(status); err != nil {
+			t.Errorf("%s: err = %v, want nil", status, err)
+		}
+	}
+	if err := checkOrderAdjustmentsEditable(domain.OrderStatusCutting); err == nil || !strings.Contains(err.Error(), "invalid order status") {
+		t.Errorf("cutting: err = %v, want invalid order status", err)
+	}
+}
+
+// TestUpgradeStoredOrderAdjustments スキーマ導入前の自由形式の補正情報の読み込みのテスト
+func TestUpgradeStoredOrderAdjustments(t *testing.T) {
+	tests := []struct {
+		name      string
+		raw       string
+		wantNotes string
+	}{
+		{"自由形式のオブジェクト", `{"shoulder": "なで肩", "sleeve": -1}`, `{"shoulder":"なで肩","sleeve":-1}`},
+		{"文字列", `"右肩下がり 0.5cm"`, "右肩下がり 0.5cm"},
+		{"v1（バージョンなし）", `{"fit_corrections": [], "notes": "メモ"}`, "メモ"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			upgraded, err := upgradeStoredOrderAdjustments(json.RawMessage(tt.raw))
+			if err != nil {
+				t.Fatalf("upgradeStoredOrderAdjustments returned error: %v", err)
+			}
+			// 変換後は注文作成時の検証を通る
+			adjustments, err := domain.ParseOrderAdjustments(upgraded)
+			if err != nil {
+				t.Fatalf("upgraded adjustments %s should be valid: %v", upgraded, err)
+			}
+			if adjustments.Version != domain.OrderAdjustmentsVersion || adjustments.Notes != tt.wantNotes {
+				t.Errorf("adjustments = %+v, want notes %q", adjustments, tt.wantNotes)
+			}
+		})
+	}
+
+	// バージョン付きの不正な補正情報は読み込めない
+	if _, err := domain.ReadOrderAdjustments(json.RawMessage(`{"version": 1, "fit_corrections": [{"type": "UNKNOWN"}]}`)); err == nil {
+		t.Errorf("invalid versioned adjustments should fail")
+	}
+}
+
+// TestPatternFinalMeasurements 注文の採寸データからの仕上がり寸法の取得のテスト
+func TestPatternFinalMeasurements(t *testing.T) {
+	s := &PatternService{}
+	order := &domain.Order{Details: &domain.OrderDetails{MeasurementData: json.RawMessage(
+		`{"height": 175, "final_measurements": {"jacket_length": 75, "half_chest": 51.5, "corrections": [{"type": "CHEST_EASE", "description": "胸囲ゆとり"}]}}`,
+	)}}
+	measurements, corrections, err := s.finalMeasurements(context.Background(), order)
+	if err != nil {
+		t.Fatalf("finalMeasurements returned error: %v", err)
+	}
+	if measurements["jacket_length"] != 75 || measurements["half_chest"] != 51.5 || len(corrections) != 1 {
+		t.Errorf("measurements = %v, corrections = %v", measurements, corrections)
+	}
+
+	// ヌード寸のみの場合は自動補正エンジンが必要
+	order.Details.MeasurementData = json.RawMessage(`{"height": 175, "bust": 92}`)
+	if _, _, err := s.finalMeasurements(context.Background(), order); err == nil || !strings.Contains(err.Error(), "not configured") {
+		t.Errorf("err = %v, want not configured", err)
+	}
+	order.Details = nil
+	if _, _, err := s.finalMeasurements(context.Background(), order); err == nil || !strings.Contains(err.Error(), "required") {
+		t.Errorf("err = %v, want required", err)
+	}
+}
+
+// TestWritePatternDXF DXF-AAMA出力のテスト
+func TestWritePatternDXF(t *testing.T) {
+	pattern, err := domain.NewGradedPattern("order-1", domain.GarmentTypeTrousers, map[string]float64{"waist": 86, "hip": 100, "rise": 27, "inseam": 80, "hem": 20}, nil)
+	if err != nil {
+		t.Fatalf("NewGradedPattern returned error: %v", err)
+	}
+	dxf := string(writePatternDXF(pattern, "ORDER1"))
+
+	if !strings.HasPrefix(dxf, "0\nSECTION\n2\nHEADER\n9\n$ACADVER\n1\nAC1009\n") || !strings.HasSuffix(dxf, "0\nEOF\n") {
+		t.Errorf("unexpected DXF header/footer")
+	}
+	if got := strings.Count(dxf, "0\nBLOCK\n"); got != len(pattern.Pieces) {
+		t.Errorf("blocks = %d, want %d", got, len(pattern.Pieces))
+	}
+	if got := strings.Count(dxf, "0\nINSERT\n"); got != len(pattern.Pieces) {
+		t.Errorf("inserts = %d, want %d", got, len(pattern.Pieces))
+	}
+	for _, want := range []string{"Style Name: ORDER1", "Units: METRIC", "Piece Name: TROUSERS-FRONT", "Quantity: 2", "Category: SHELL"} {
+		if !strings.Contains(dxf, "1\n"+want+"\n") {
+			t.Errorf("DXF does not contain %q", want)
+		}
+	}
+	// 合印（レイヤー4）と地の目線（レイヤー7）
+	if !strings.Contains(dxf, "0\nLINE\n8\n4\n") || !strings.Contains(dxf, "0\nLINE\n8\n7\n") {
+		t.Errorf("DXF should contain notch and grainline layers")
+	}
+	// ウエスト+2cm → 前パンツの脇のウエスト点は +0.5cm（mm出力、Yは上向き）
+	if !strings.Contains(dxf, "10\n215.000\n20\n0.000\n") {
+		t.Errorf("DXF does not contain graded waist point")
+	}
+	// 裁断線（レイヤー1）と縫製線（レイヤー14）をパーツごとに出力
+	if got := strings.Count(dxf, "0\nPOLYLINE\n8\n1\n"); got != len(pattern.Pieces) {
+		t.Errorf("cut lines = %d, want %d", got, len(pattern.Pieces))
+	}
+	if got := strings.Count(dxf, "0\nPOLYLINE\n8\n14\n"); got != len(pattern.Pieces) {
+		t.Errorf("sew lines = %d, want %d", got, len(pattern.Pieces))
+	}
+}
+
+// TestOffsetPatternOutline 出来上がり線に縫い代を付けた裁断線のテスト
+func TestOffsetPatternOutline(t *testing.T) {
+	square := []domain.PatternPoint{{X: 0, Y: 0}, {X: 10, Y: 0, Notch: true}, {X: 10, Y: 10}, {X: 0, Y: 10}}
+	want := []domain.PatternPoint{{X: -1, Y: -1}, {X: 11, Y: -1, Notch: true}, {X: 11, Y: 11}, {X: -1, Y: 11}}
+
+	reversed := []domain.PatternPoint{square[3], square[2], square[1], square[0]}
+	wantReversed := []domain.PatternPoint{want[3], want[2], want[1], want[0]}
+
+	for name, tt := range map[string]struct{ outline, want []domain.PatternPoint }{
+		"時計回り":  {square, want},
+		"反時計回り": {reversed, wantReversed},
+	} {
+		got := offsetPatternOutline(tt.outline, 1)
+		if len(got) != len(tt.want) {
+			t.Fatalf("%s: points = %+v, want %+v", name, got, tt.want)
+		}
+		for i := range got {
+			if math.Abs(got[i].X-tt.want[i].X) > 1e-9 || math.Abs(got[i].Y-tt.want[i].Y) > 1e-9 || got[i].Notch != tt.want[i].Notch {
+				t.Errorf("%s: point %d = %+v, want %+v", name, i, got[i], tt.want[i])
+			}
+		}
+	}
+
+	// 鋭い角は2点で角を落とす（延長が縫い代の2倍を超えない）
+	spike := offsetPatternOutline([]domain.PatternPoint{{X: 0, Y: 0}, {X: 20, Y: 1}, {X: 0, Y: 2}}, 1)
+	if len(spike) != 4 {
+		t.Fatalf("spike points = %+v, want bevelled tip", spike)
+	}
+	for _, p := range spike {
+		if p.X > 21.0+1e-9 {
+			t.Errorf("spike point %+v extends beyond the miter limit", p)
+		}
+	}
+}
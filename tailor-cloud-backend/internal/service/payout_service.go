@@ -0,0 +1,604 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"tailor-cloud/backend/internal/config/domain"
+	"tailor-cloud/backend/internal/repository"
+)
+
+// PayoutService 支払業務サービス
+// 工場への下請代金（Order.PaymentDueDate）とアンバサダー成果報酬（Approved）を
+// 支払バッチにまとめ、全銀協 総合振込ファイルを生成する
+type PayoutService struct {
+	payoutRepo      repository.PayoutRepository
+	bankAccountRepo repository.BankAccountRepository
+	orderRepo       repository.OrderRepository
+	commissionRepo  repository.CommissionRepository
+	taxService      *TaxCalculationService
+	db              *sql.DB // トランザクション管理用
+}
+
+// NewPayoutService PayoutServiceのコンストラクタ
+func NewPayoutService(
+	payoutRepo repository.PayoutRepository,
+	bankAccountRepo repository.BankAccountRepository,
+	orderRepo repository.OrderRepository,
+	commissionRepo repository.CommissionRepository,
+	taxService *TaxCalculationService,
+	db *sql.DB,
+) *PayoutService {
+	return &PayoutService{
+		payoutRepo:      payoutRepo,
+		bankAccountRepo: bankAccountRepo,
+		orderRepo:       orderRepo,
+		commissionRepo:  commissionRepo,
+		taxService:      taxService,
+		db:              db,
+	}
+}
+
+// 成果報酬の取得上限（1バッチあたり）
+const payoutCommissionFetchLimit = 10000
+
+// CreatePayoutBatchRequest 支払バッチ作成リクエスト
+type CreatePayoutBatchRequest struct {
+	TenantID     string    `json:"-"`
+	DueBy        time.Time `json:"due_by"`        // この日までに支払期日が来るものを対象
+	TransferDate time.Time `json:"transfer_date"` // 振込指定日（省略時はDueBy）
+	UserID       string    `json:"-"`
+}
+
+// SkippedPayoutItem バッチに含められなかった支払義務
+type SkippedPayoutItem struct {
+	ItemType    domain.PayoutItemType `json:"item_type"`
+	ReferenceID string                `json:"reference_id"`
+	Amount      int64                 `json:"amount"`
+	Reason      string                `json:"reason"`
+}
+
+// CreatePayoutBatchResponse 支払バッチ作成レスポンス
+type CreatePayoutBatchResponse struct {
+	Batch   *domain.PayoutBatch  `json:"batch"`
+	Skipped []*SkippedPayoutItem `json:"skipped"`
+}
+
+// BankAccountRequest 銀行口座登録リクエスト
+type BankAccountRequest struct {
+	TenantID          string                  `json:"-"`
+	OwnerType         domain.BankAccountOwner `json:"owner_type"`
+	OwnerID           string                  `json:"owner_id"`
+	BankCode          string                  `json:"bank_code"`
+	BankNameKana      string                  `json:"bank_name_kana"`
+	BranchCode        string                  `json:"branch_code"`
+	BranchNameKana    string                  `json:"branch_name_kana"`
+	AccountType       domain.BankAccountType  `json:"account_type"`
+	AccountNumber     string                  `json:"account_number"`
+	AccountHolderKana string                  `json:"account_holder_kana"`
+	ClientCode        string                  `json:"client_code"`
+	IsDefault         bool                    `json:"is_default"`
+}
+
+// RegisterBankAccount 振込用の銀行口座を登録
+func (s *PayoutService) RegisterBankAccount(ctx context.Context, req *BankAccountRequest) (*domain.BankAccount, error) {
+	if req.TenantID == "" {
+		return nil, fmt.Errorf("tenant_id is required")
+	}
+	if !req.OwnerType.IsValid() {
+		return nil, fmt.Errorf("invalid owner_type: %s", req.OwnerType)
+	}
+	if req.OwnerType == domain.BankAccountOwnerTenant {
+		req.OwnerID = req.TenantID
+		if len(req.ClientCode) != 10 {
+			return nil, fmt.Errorf("invalid client_code: must be 10 digits for tenant account")
+		}
+	}
+	if req.OwnerID == "" {
+		return nil, fmt.Errorf("owner_id is required")
+	}
+	if len(req.BankCode) != 4 || len(req.BranchCode) != 3 {
+		return nil, fmt.Errorf("invalid bank_code or branch_code: must be 4 and 3 digits")
+	}
+	if req.AccountNumber == "" || len(req.AccountNumber) > 7 {
+		return nil, fmt.Errorf("invalid account_number: must be up to 7 digits")
+	}
+	if req.AccountHolderKana == "" {
+		return nil, fmt.Errorf("account_holder_kana is required")
+	}
+
+	account := domain.NewBankAccount(req.TenantID, req.OwnerType, req.OwnerID)
+	account.BankCode = req.BankCode
+	account.BankNameKana = req.BankNameKana
+	account.BranchCode = req.BranchCode
+	account.BranchNameKana = req.BranchNameKana
+	if req.AccountType != "" {
+		account.AccountType = req.AccountType
+	}
+	if !account.AccountType.IsValid() {
+		return nil, fmt.Errorf("invalid account_type: %s", account.AccountType)
+	}
+	account.AccountNumber = req.AccountNumber
+	account.AccountHolderKana = req.AccountHolderKana
+	account.ClientCode = req.ClientCode
+	account.IsDefault = req.IsDefault
+
+	// 口座情報が総合振込ファイルに出力できるかを事前に検証
+	if err := ValidateZenginTransferRecord(transferRecordFromAccount(account, 1, "")); err != nil {
+		return nil, fmt.Errorf("invalid bank account: %w", err)
+	}
+
+	if err := s.bankAccountRepo.Create(ctx, account); err != nil {
+		return nil, fmt.Errorf("failed to register bank account: %w", err)
+	}
+
+	return account, nil
+}
+
+// ListBankAccounts 銀行口座一覧を取得
+func (s *PayoutService) ListBankAccounts(ctx context.Context, tenantID string) ([]*domain.BankAccount, error) {
+	return s.bankAccountRepo.GetByTenantID(ctx, tenantID)
+}
+
+// CreatePayoutBatch 期日までの支払義務を集めて支払バッチを作成（振込予定にする）
+func (s *PayoutService) CreatePayoutBatch(ctx context.Context, req *CreatePayoutBatchRequest) (*CreatePayoutBatchResponse, error) {
+	// 1. バリデーション
+	if req.TenantID == "" {
+		return nil, fmt.Errorf("tenant_id is required")
+	}
+	if req.DueBy.IsZero() {
+		return nil, fmt.Errorf("due_by is required")
+	}
+	if req.TransferDate.IsZero() {
+		req.TransferDate = req.DueBy
+	}
+
+	// 振込元（自社）口座がないとヘッダーを作れない
+	if _, err := s.bankAccountRepo.GetDefault(ctx, req.TenantID, domain.BankAccountOwnerTenant); err != nil {
+		return nil, fmt.Errorf("remitter bank account is required: %w", err)
+	}
+
+	batch := domain.NewPayoutBatch(req.TenantID, req.DueBy, req.TransferDate, req.UserID)
+	skipped := make([]*SkippedPayoutItem, 0)
+
+	// 2. 工場への下請代金（支払期日が到来した注文）
+	if err := s.collectFactoryPayments(ctx, batch, &skipped); err != nil {
+		return nil, err
+	}
+
+	// 3. アンバサダー成果報酬（Approved）
+	if err := s.collectCommissions(ctx, batch, &skipped); err != nil {
+		return nil, err
+	}
+
+	if len(batch.Items) == 0 {
+		return nil, fmt.Errorf("no payable items found due by %s", req.DueBy.Format("2006-01-02"))
+	}
+
+	// 4. 総合振込ファイルを生成し、ハッシュを記録
+	file, err := s.buildTransferFile(ctx, batch)
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.Sum256(file)
+	batch.FileHash = hex.EncodeToString(hash[:])
+
+	// 5. トランザクション内でバッチ・明細を保存し、成果報酬を振込予定にする
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := s.createBatchInTx(ctx, tx, batch); err != nil {
+		return nil, err
+	}
+	for _, item := range batch.Items {
+		if err := s.createItemInTx(ctx, tx, item); err != nil {
+			return nil, err
+		}
+		if item.ItemType == domain.PayoutItemTypeCommission {
+			if err := s.updateCommissionStatusInTx(ctx, tx, item.ReferenceID, req.TenantID, domain.CommissionStatusScheduled, nil); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return &CreatePayoutBatchResponse{
+		Batch:   batch,
+		Skipped: skipped,
+	}, nil
+}
+
+// GetPayoutBatch 支払バッチを明細付きで取得
+func (s *PayoutService) GetPayoutBatch(ctx context.Context, batchID string, tenantID string) (*domain.PayoutBatch, error) {
+	return s.payoutRepo.GetBatchByID(ctx, batchID, tenantID)
+}
+
+// ListPayoutBatches 支払バッチ一覧を取得
+func (s *PayoutService) ListPayoutBatches(ctx context.Context, tenantID string, limit, offset int) ([]*domain.PayoutBatch, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	return s.payoutRepo.GetBatchesByTenantID(ctx, tenantID, limit, offset)
+}
+
+// GetTransferFile 支払バッチの総合振込ファイルを取得
+// 保存済みの明細（口座スナップショット）から再生成し、作成時のハッシュと照合する
+func (s *PayoutService) GetTransferFile(ctx context.Context, batchID string, tenantID string) ([]byte, string, error) {
+	batch, err := s.payoutRepo.GetBatchByID(ctx, batchID, tenantID)
+	if err != nil {
+		return nil, "", err
+	}
+	if batch.Status == domain.PayoutBatchStatusCancelled {
+		return nil, "", fmt.Errorf("payout batch is cancelled")
+	}
+
+	file, err := s.buildTransferFile(ctx, batch)
+	if err != nil {
+		return nil, "", err
+	}
+
+	hash := sha256.Sum256(file)
+	if batch.FileHash != "" && hex.EncodeToString(hash[:]) != batch.FileHash {
+		return nil, "", fmt.Errorf("transfer file hash mismatch: remitter account may have changed since the batch was created")
+	}
+
+	filename := fmt.Sprintf("sogofuri_%s_%s.txt", batch.TransferDate.Format("20060102"), batch.ID[:8])
+	return file, filename, nil
+}
+
+// ConfirmPayoutBatch 振込完了を確定（明細・成果報酬を支払済みにする）
+func (s *PayoutService) ConfirmPayoutBatch(ctx context.Context, batchID string, tenantID string, userID string) (*domain.PayoutBatch, error) {
+	return s.closePayoutBatch(ctx, batchID, tenantID, userID, domain.PayoutBatchStatusPaid)
+}
+
+// CancelPayoutBatch 支払バッチを取消（明細は再度バッチ対象になる）
+func (s *PayoutService) CancelPayoutBatch(ctx context.Context, batchID string, tenantID string, userID string) (*domain.PayoutBatch, error) {
+	return s.closePayoutBatch(ctx, batchID, tenantID, userID, domain.PayoutBatchStatusCancelled)
+}
+
+// closePayoutBatch 振込予定のバッチを振込完了または取消にする
+func (s *PayoutService) closePayoutBatch(ctx context.Context, batchID string, tenantID string, userID string, status domain.PayoutBatchStatus) (*domain.PayoutBatch, error) {
+	batch, err := s.payoutRepo.GetBatchByID(ctx, batchID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if batch.Status != domain.PayoutBatchStatusScheduled {
+		return nil, fmt.Errorf("payout batch is already %s", batch.Status)
+	}
+
+	itemStatus := domain.PayoutItemStatusPaid
+	commissionStatus := domain.CommissionStatusPaid
+	if status == domain.PayoutBatchStatusCancelled {
+		itemStatus = domain.PayoutItemStatusCancelled
+		commissionStatus = domain.CommissionStatusApproved
+	}
+
+	now := time.Now()
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// 二重確定防止: SCHEDULEDの場合のみ更新
+	if err := s.updateBatchStatusInTx(ctx, tx, batch.ID, tenantID, status, userID, now); err != nil {
+		return nil, err
+	}
+	if err := s.updateItemStatusInTx(ctx, tx, batch.ID, tenantID, itemStatus, now); err != nil {
+		return nil, err
+	}
+	for _, item := range batch.Items {
+		item.Status = itemStatus
+		item.UpdatedAt = now
+		if item.ItemType != domain.PayoutItemTypeCommission {
+			continue
+		}
+		var paidAt *time.Time
+		if commissionStatus == domain.CommissionStatusPaid {
+			paidAt = &now
+		}
+		if err := s.updateCommissionStatusInTx(ctx, tx, item.ReferenceID, tenantID, commissionStatus, paidAt); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	batch.Status = status
+	batch.ConfirmedBy = userID
+	batch.ConfirmedAt = &now
+	batch.UpdatedAt = now
+	return batch, nil
+}
+
+// collectFactoryPayments 支払期日が到来した注文を工場への支払明細として追加
+// 注文には工場の紐付けがないため、テナントの既定工場口座（FACTORY, is_default）へ振り込む
+func (s *PayoutService) collectFactoryPayments(ctx context.Context, batch *domain.PayoutBatch, skipped *[]*SkippedPayoutItem) error {
+	active, err := s.payoutRepo.GetActiveReferenceIDs(ctx, batch.TenantID, domain.PayoutItemTypeFactoryPayment)
+	if err != nil {
+		return err
+	}
+
+	orders, err := searchAllOrders(ctx, s.orderRepo, factoryPaymentOrderFilter(batch.TenantID, batch.DueBy))
+	if err != nil {
+		return fmt.Errorf("failed to list orders: %w", err)
+	}
+
+	var factoryAccount *domain.BankAccount
+	for _, order := range orders {
+		if active[order.ID] {
+			continue
+		}
+
+		// 下請代金は税込で支払う
+		taxResp, err := s.taxService.CalculateTaxForOrder(ctx, order)
+		if err != nil {
+			return fmt.Errorf("failed to calculate tax: %w", err)
+		}
+
+		if factoryAccount == nil {
+			factoryAccount, err = s.bankAccountRepo.GetDefault(ctx, batch.TenantID, domain.BankAccountOwnerFactory)
+			if err != nil {
+				*skipped = append(*skipped, &SkippedPayoutItem{
+					ItemType:    domain.PayoutItemTypeFactoryPayment,
+					ReferenceID: order.ID,
+					Amount:      taxResp.TaxIncludedAmount,
+					Reason:      "factory bank account not registered",
+				})
+				continue
+			}
+		}
+
+		batch.AddItem(domain.PayoutItemTypeFactoryPayment, order.ID, order.PaymentDueDate, taxResp.TaxIncludedAmount, factoryAccount)
+	}
+
+	return nil
+}
+
+// factoryPaymentOrderFilter 工場への支払対象（下書き・キャンセル以外で支払期日が到来した注文）の検索条件
+func factoryPaymentOrderFilter(tenantID string, dueBy time.Time) *domain.OrderSearchFilter {
+	return &domain.OrderSearchFilter{
+		TenantID: tenantID,
+		Statuses: []domain.OrderStatus{
+			domain.OrderStatusConfirmed, domain.OrderStatusMaterialSecured, domain.OrderStatusCutting,
+			domain.OrderStatusSewing, domain.OrderStatusInspection, domain.OrderStatusShipped,
+			domain.OrderStatusDelivered, domain.OrderStatusPaid,
+		},
+		PaymentDueBy: &dueBy,
+		SortBy:       domain.OrderSortCreatedAt,
+		SortOrder:    domain.SortOrderAsc,
+		Limit:        100, // 1回の検索の最大件数
+	}
+}
+
+// collectCommissions 確定済み（Approved）の成果報酬を支払明細として追加
+func (s *PayoutService) collectCommissions(ctx context.Context, batch *domain.PayoutBatch, skipped *[]*SkippedPayoutItem) error {
+	active, err := s.payoutRepo.GetActiveReferenceIDs(ctx, batch.TenantID, domain.PayoutItemTypeCommission)
+	if err != nil {
+		return err
+	}
+
+	commissions, err := s.commissionRepo.GetByTenantID(ctx, batch.TenantID, payoutCommissionFetchLimit, 0)
+	if err != nil {
+		return fmt.Errorf("failed to list commissions: %w", err)
+	}
+
+	for _, commission := range commissions {
+		if commission.Status != domain.CommissionStatusApproved || active[commission.ID] {
+			continue
+		}
+		if commission.CreatedAt.After(batch.DueBy) || commission.CommissionAmount <= 0 {
+			continue
+		}
+
+		account, err := s.bankAccountRepo.GetByOwner(ctx, batch.TenantID, domain.BankAccountOwnerAmbassador, commission.AmbassadorID)
+		if err != nil {
+			*skipped = append(*skipped, &SkippedPayoutItem{
+				ItemType:    domain.PayoutItemTypeCommission,
+				ReferenceID: commission.ID,
+				Amount:      commission.CommissionAmount,
+				Reason:      "ambassador bank account not registered",
+			})
+			continue
+		}
+
+		batch.AddItem(domain.PayoutItemTypeCommission, commission.ID, commission.CreatedAt, commission.CommissionAmount, account)
+	}
+
+	return nil
+}
+
+// buildTransferFile 支払バッチから総合振込ファイルを生成
+func (s *PayoutService) buildTransferFile(ctx context.Context, batch *domain.PayoutBatch) ([]byte, error) {
+	remitter, err := s.bankAccountRepo.GetDefault(ctx, batch.TenantID, domain.BankAccountOwnerTenant)
+	if err != nil {
+		return nil, fmt.Errorf("remitter bank account is required: %w", err)
+	}
+
+	header := &ZenginTransferHeader{
+		ClientCode:     remitter.ClientCode,
+		ClientName:     remitter.AccountHolderKana,
+		TransferDate:   batch.TransferDate,
+		BankCode:       remitter.BankCode,
+		BankNameKana:   remitter.BankNameKana,
+		BranchCode:     remitter.BranchCode,
+		BranchNameKana: remitter.BranchNameKana,
+		AccountType:    string(remitter.AccountType),
+		AccountNumber:  remitter.AccountNumber,
+	}
+
+	records := make([]*ZenginTransferRecord, 0, len(batch.Items))
+	for _, item := range batch.Items {
+		records = append(records, &ZenginTransferRecord{
+			BankCode:       item.BankCode,
+			BankNameKana:   item.BankNameKana,
+			BranchCode:     item.BranchCode,
+			BranchNameKana: item.BranchNameKana,
+			AccountType:    string(item.AccountType),
+			AccountNumber:  item.AccountNumber,
+			PayeeName:      item.AccountHolderKana,
+			Amount:         item.Amount,
+			CustomerCode1:  domain.InvoiceReference(item.ReferenceID), // 支払明細との照合用
+		})
+	}
+
+	file, err := GenerateZenginTransferFile(header, records)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate transfer file: %w", err)
+	}
+	return file, nil
+}
+
+// transferRecordFromAccount 口座情報から総合振込データを作成
+func transferRecordFromAccount(account *domain.BankAccount, amount int64, customerCode string) *ZenginTransferRecord {
+	return &ZenginTransferRecord{
+		BankCode:       account.BankCode,
+		BankNameKana:   account.BankNameKana,
+		BranchCode:     account.BranchCode,
+		BranchNameKana: account.BranchNameKana,
+		AccountType:    string(account.AccountType),
+		AccountNumber:  account.AccountNumber,
+		PayeeName:      account.AccountHolderKana,
+		Amount:         amount,
+		CustomerCode1:  customerCode,
+	}
+}
+
+// createBatchInTx トランザクション内で支払バッチを作成
+func (s *PayoutService) createBatchInTx(ctx context.Context, tx *sql.Tx, batch *domain.PayoutBatch) error {
+	query := `
+		INSERT INTO payout_batches (
+			id, tenant_id, due_by, transfer_date, status, item_count, total_amount,
+			file_hash, created_by, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+
+	_, err := tx.ExecContext(ctx, query,
+		batch.ID,
+		batch.TenantID,
+		batch.DueBy,
+		batch.TransferDate,
+		string(batch.Status),
+		batch.ItemCount,
+		batch.TotalAmount,
+		batch.FileHash,
+		batch.CreatedBy,
+		batch.CreatedAt,
+		batch.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create payout batch: %w", err)
+	}
+
+	return nil
+}
+
+// createItemInTx トランザクション内で支払明細を作成
+func (s *PayoutService) createItemInTx(ctx context.Context, tx *sql.Tx, item *domain.PayoutItem) error {
+	query := `
+		INSERT INTO payout_items (
+			id, batch_id, tenant_id, item_type, reference_id, due_date, amount,
+			bank_account_id, bank_code, bank_name_kana, branch_code, branch_name_kana,
+			account_type, account_number, account_holder_kana, status, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
+	`
+
+	_, err := tx.ExecContext(ctx, query,
+		item.ID,
+		item.BatchID,
+		item.TenantID,
+		string(item.ItemType),
+		item.ReferenceID,
+		item.DueDate,
+		item.Amount,
+		item.BankAccountID,
+		item.BankCode,
+		item.BankNameKana,
+		item.BranchCode,
+		item.BranchNameKana,
+		string(item.AccountType),
+		item.AccountNumber,
+		item.AccountHolderKana,
+		string(item.Status),
+		item.CreatedAt,
+		item.UpdatedAt,
+	)
+	if err != nil {
+		// 部分ユニークインデックス違反 = 別バッチで振込予定済み
+		return fmt.Errorf("failed to create payout item (already scheduled in another batch?): %w", err)
+	}
+
+	return nil
+}
+
+// updateBatchStatusInTx トランザクション内で振込予定のバッチのステータスを更新
+func (s *PayoutService) updateBatchStatusInTx(ctx context.Context, tx *sql.Tx, batchID string, tenantID string, status domain.PayoutBatchStatus, userID string, now time.Time) error {
+	query := `
+		UPDATE payout_batches SET
+			status = $3,
+			confirmed_by = $4,
+			confirmed_at = $5,
+			updated_at = $5
+		WHERE id = $1 AND tenant_id = $2 AND status = $6
+	`
+
+	result, err := tx.ExecContext(ctx, query, batchID, tenantID, string(status), userID, now, string(domain.PayoutBatchStatusScheduled))
+	if err != nil {
+		return fmt.Errorf("failed to update payout batch status: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("payout batch is already closed")
+	}
+
+	return nil
+}
+
+// updateItemStatusInTx トランザクション内でバッチの全明細のステータスを更新
+func (s *PayoutService) updateItemStatusInTx(ctx context.Context, tx *sql.Tx, batchID string, tenantID string, status domain.PayoutItemStatus, now time.Time) error {
+	query := `
+		UPDATE payout_items SET
+			status = $3,
+			updated_at = $4
+		WHERE batch_id = $1 AND tenant_id = $2
+	`
+
+	if _, err := tx.ExecContext(ctx, query, batchID, tenantID, string(status), now); err != nil {
+		return fmt.Errorf("failed to update payout item status: %w", err)
+	}
+
+	return nil
+}
+
+// updateCommissionStatusInTx トランザクション内で成果報酬のステータスを更新
+func (s *PayoutService) updateCommissionStatusInTx(ctx context.Context, tx *sql.Tx, commissionID string, tenantID string, status domain.CommissionStatus, paidAt *time.Time) error {
+	query := `
+		UPDATE commissions SET
+			status = $3,
+			paid_at = COALESCE($4, paid_at),
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND tenant_id = $2
+	`
+
+	if _, err := tx.ExecContext(ctx, query, commissionID, tenantID, string(status), paidAt); err != nil {
+		return fmt.Errorf("failed to update commission status: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"tailor-cloud/backend/internal/config/domain"
+)
+
+// TestFactoryPaymentOrderFilter 支払期日が到来した注文を最新100件に限らずすべて対象にするテスト
+func TestFactoryPaymentOrderFilter(t *testing.T) {
+	dueBy := time.Date(2025, 10, 31, 0, 0, 0, 0, time.UTC)
+	repo := &fakeOrderSearchRepository{}
+	for i := 0; i < 150; i++ {
+		// 支払期日が到来した古い注文
+		repo.orders = append(repo.orders, &domain.Order{
+			ID:             fmt.Sprintf("due-%03d", i),
+			TenantID:       "tenant-1",
+			Status:         domain.OrderStatusDelivered,
+			PaymentDueDate: dueBy.AddDate(0, 0, -30),
+			CreatedAt:      dueBy.AddDate(0, -3, 0).Add(time.Duration(i) * time.Minute),
+		})
+	}
+	for i := 0; i < 120; i++ {
+		// 支払期日が未到来の新しい注文
+		repo.orders = append(repo.orders, &domain.Order{
+			ID:             fmt.Sprintf("later-%03d", i),
+			TenantID:       "tenant-1",
+			Status:         domain.OrderStatusSewing,
+			PaymentDueDate: dueBy.AddDate(0, 1, 0),
+			CreatedAt:      dueBy.AddDate(0, 0, -1).Add(time.Duration(i) * time.Minute),
+		})
+	}
+	repo.orders = append(repo.orders,
+		&domain.Order{ID: "draft", TenantID: "tenant-1", Status: domain.OrderStatusDraft, PaymentDueDate: dueBy},
+		&domain.Order{ID: "cancelled", TenantID: "tenant-1", Status: domain.OrderStatusCancelled, PaymentDueDate: dueBy},
+		&domain.Order{ID: "other-tenant", TenantID: "tenant-2", Status: domain.OrderStatusDelivered, PaymentDueDate: dueBy},
+		&domain.Order{ID: "due-today", TenantID: "tenant-1", Status: domain.OrderStatusPaid, PaymentDueDate: dueBy},
+	)
+
+	orders, err := searchAllOrders(context.Background(), repo, factoryPaymentOrderFilter("tenant-1", dueBy))
+	if err != nil {
+		t.Fatalf("searchAllOrders returned error: %v", err)
+	}
+	if len(orders) != 151 {
+		t.Fatalf("Expected 151 orders, got %d", len(orders))
+	}
+	for _, order := range orders {
+		if order.PaymentDueDate.After(dueBy) || order.Status == domain.OrderStatusDraft || order.Status == domain.OrderStatusCancelled || order.TenantID != "tenant-1" {
+			t.Errorf("Unexpected order %s", order.ID)
+		}
+	}
+}
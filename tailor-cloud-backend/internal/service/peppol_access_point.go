@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PeppolAccessPoint Peppolアクセスポイントへの送信インターフェース
+// 本番ではアクセスポイント事業者（AP）のAPIを実装し、開発環境ではファイルシステムで代替する
+type PeppolAccessPoint interface {
+	Send(ctx context.Context, doc *PeppolDocument) (*PeppolSendResult, error)
+}
+
+// PeppolDocument 送信する電子インボイス
+type PeppolDocument struct {
+	TenantID    string // 送信元テナント
+	InvoiceID   string // 請求書番号
+	SenderID    string // 送信者のPeppol参加者ID（scheme:value）
+	ReceiverID  string // 受信者のPeppol参加者ID（未登録の場合は空）
+	DocumentXML []byte // JP PINT UBL 2.1 XML
+}
+
+// PeppolSendResult 送信結果
+type PeppolSendResult struct {
+	MessageID string    `json:"message_id"` // アクセスポイントが払い出したメッセージID
+	Status    string    `json:"status"`     // SENT / QUEUED
+	SentAt    time.Time `json:"sent_at"`
+	Location  string    `json:"location,omitempty"` // 代替実装での保存先
+}
+
+// FileSystemAccessPoint ファイルシステムを使ったアクセスポイントの代替実装
+// 送信内容を {baseDir}/{tenantID}/{invoiceID}_{messageID}.xml に保存する
+type FileSystemAccessPoint struct {
+	baseDir string
+}
+
+// NewFileSystemAccessPoint FileSystemAccessPointのコンストラクタ
+func NewFileSystemAccessPoint(baseDir string) *FileSystemAccessPoint {
+	return &FileSystemAccessPoint{
+		baseDir: baseDir,
+	}
+}
+
+// Send 電子インボイスをファイルとして保存（メタデータはJSONで併置）
+func (a *FileSystemAccessPoint) Send(ctx context.Context, doc *PeppolDocument) (*PeppolSendResult, error) {
+	if doc.TenantID == "" || doc.InvoiceID == "" {
+		return nil, fmt.Errorf("tenant_id and invoice_id are required")
+	}
+	if len(doc.DocumentXML) == 0 {
+		return nil, fmt.Errorf("document_xml is required")
+	}
+
+	dir := filepath.Join(a.baseDir, filepath.Base(doc.TenantID))
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create outbox directory: %w", err)
+	}
+
+	result := &PeppolSendResult{
+		MessageID: uuid.New().String(),
+		Status:    "QUEUED",
+		SentAt:    time.Now(),
+	}
+	base := filepath.Join(dir, fmt.Sprintf("%s_%s", filepath.Base(doc.InvoiceID), result.MessageID))
+	result.Location = base + ".xml"
+
+	if err := os.WriteFile(result.Location, doc.DocumentXML, 0o644); err != nil {
+		return nil, fmt.Errorf("failed to write document: %w", err)
+	}
+
+	metadata, err := json.MarshalIndent(map[string]interface{}{
+		"message_id":  result.MessageID,
+		"invoice_id":  doc.InvoiceID,
+		"sender_id":   doc.SenderID,
+		"receiver_id": doc.ReceiverID,
+		"sent_at":     result.SentAt,
+	}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+	if err := os.WriteFile(base+".json", metadata, 0o644); err != nil {
+		return nil, fmt.Errorf("failed to write metadata: %w", err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,288 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"tailor-cloud/backend/internal/config/domain"
+	"tailor-cloud/backend/internal/repository"
+)
+
+// PricingService 価格計算サービス
+// 注文金額をクライアントの申告ではなく価格表から計算する
+type PricingService struct {
+	priceBookRepo repository.PriceBookRepository
+	fabricRepo    repository.FabricRepository
+}
+
+// NewPricingService PricingServiceのコンストラクタ
+func NewPricingService(priceBookRepo repository.PriceBookRepository, fabricRepo repository.FabricRepository) *PricingService {
+	return &PricingService{
+		priceBookRepo: priceBookRepo,
+		fabricRepo:    fabricRepo,
+	}
+}
+
+// PriceRequest 価格計算リクエスト
+type PriceRequest struct {
+	TenantID     string          `json:"tenant_id"`
+	PlanType     domain.PlanType `json:"plan_type"`
+	FabricID     string          `json:"fabric_id"`
+	FabricLength float64         `json:"fabric_length"` // 用尺（メートル、0の場合は生地の最小発注数量）
+	OptionCodes  []string        `json:"option_codes"`
+	DeliveryDate time.Time       `json:"delivery_date"`
+}
+
+// PriceBookEntryRequest 価格表項目の登録・更新リクエスト
+type PriceBookEntryRequest struct {
+	TenantID     string                   `json:"-"`
+	ItemType     domain.PriceBookItemType `json:"item_type"`
+	Code         string                   `json:"code"`
+	Category     string                   `json:"category"`
+	Name         string                   `json:"name"`
+	Price        int64                    `json:"price"`
+	LeadTimeDays int                      `json:"lead_time_days"`
+	Active       *bool                    `json:"active"` // 省略時は有効
+}
+
+// CalculatePrice 価格表・生地単価から価格内訳を計算
+func (s *PricingService) CalculatePrice(ctx context.Context, req *PriceRequest) (*domain.PriceBreakdown, error) {
+	if req.TenantID == "" {
+		return nil, fmt.Errorf("tenant_id is required")
+	}
+	if req.FabricID == "" {
+		return nil, fmt.Errorf("fabric_id is required")
+	}
+
+	entries, err := s.priceBookRepo.GetActiveByTenantID(ctx, req.TenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get price book: %w", err)
+	}
+
+	fabric, err := s.fabricRepo.GetByID(ctx, req.FabricID)
+	if err != nil {
+		return nil, fmt.Errorf("fabric not found: %w", err)
+	}
+
+	return CalculatePriceBreakdown(entries, fabric, req, time.Now())
+}
+
+// CalculatePriceBreakdown 価格内訳を計算
+// 仕立て代（プラン基本価格）+ 生地代（単価 × 用尺）+ オプション加算 + 特急料金
+func CalculatePriceBreakdown(entries []*domain.PriceBookEntry, fabric *domain.Fabric, req *PriceRequest, now time.Time) (*domain.PriceBreakdown, error) {
+	if req.PlanType == "" {
+		return nil, fmt.Errorf("plan_type is required")
+	}
+	if !req.PlanType.IsValid() {
+		return nil, fmt.Errorf("invalid plan_type: %s", req.PlanType)
+	}
+	if req.FabricLength < 0 {
+		return nil, fmt.Errorf("invalid fabric_length: must not be negative")
+	}
+	if req.DeliveryDate.IsZero() {
+		return nil, fmt.Errorf("delivery_date is required")
+	}
+
+	bases := make(map[string]*domain.PriceBookEntry)
+	options := make(map[string]*domain.PriceBookEntry)
+	rushes := make([]*domain.PriceBookEntry, 0)
+	for _, entry := range entries {
+		if !entry.Active {
+			continue
+		}
+		switch entry.ItemType {
+		case domain.PriceBookItemTypeBase:
+			bases[entry.Code] = entry
+		case domain.PriceBookItemTypeOption:
+			options[entry.Code] = entry
+		case domain.PriceBookItemTypeRush:
+			rushes = append(rushes, entry)
+		}
+	}
+
+	fabricLength := req.FabricLength
+	if fabricLength == 0 {
+		fabricLength = fabric.MinimumOrder
+	}
+	if fabricLength == 0 {
+		fabricLength = domain.DefaultFabricLength
+	}
+
+	breakdown := &domain.PriceBreakdown{
+		PlanType:     req.PlanType,
+		FabricID:     fabric.ID,
+		FabricLength: fabricLength,
+		Lines:        make([]*domain.PriceLine, 0),
+		CalculatedAt: now,
+	}
+
+	// 1. 仕立て代
+	base, ok := bases[string(req.PlanType)]
+	if !ok {
+		return nil, fmt.Errorf("price not found for plan_type: %s", req.PlanType)
+	}
+	breakdown.AddLine(&domain.PriceLine{
+		Component:   domain.PriceComponentBase,
+		Code:        base.Code,
+		Description: base.Name,
+		Quantity:    1,
+		UnitPrice:   base.Price,
+		Amount:      base.Price,
+	})
+
+	// 2. 生地代
+	breakdown.AddLine(&domain.PriceLine{
+		Component:   domain.PriceComponentFabric,
+		Code:        fabric.ID,
+		Description: fmt.Sprintf("生地代（%s）", fabric.Name),
+		Quantity:    fabricLength,
+		UnitPrice:   fabric.Price,
+		Amount:      int64(math.Round(fabricLength * float64(fabric.Price))),
+	})
+
+	// 3. オプション加算（同じ分類のオプションは1つまで）
+	seenCodes := make(map[string]bool)
+	seenCategories := make(map[string]string)
+	for _, code := range req.OptionCodes {
+		code = strings.TrimSpace(code)
+		option, ok := options[code]
+		if !ok {
+			return nil, fmt.Errorf("invalid option code: %s", code)
+		}
+		if seenCodes[code] {
+			return nil, fmt.Errorf("invalid option code: %s is duplicated", code)
+		}
+		seenCodes[code] = true
+		if option.Category != "" {
+			if other, exists := seenCategories[option.Category]; exists {
+				return nil, fmt.Errorf("invalid option combination: %s and %s are both %s", other, code, option.Category)
+			}
+			seenCategories[option.Category] = code
+		}
+
+		breakdown.AddLine(&domain.PriceLine{
+			Component:   domain.PriceComponentOption,
+			Code:        option.Code,
+			Description: option.Name,
+			Quantity:    1,
+			UnitPrice:   option.Price,
+			Amount:      option.Price,
+		})
+	}
+
+	// 4. 特急料金（納期までの日数が最も短い区分を適用）
+	if rush := applicableRushFee(rushes, now, req.DeliveryDate); rush != nil {
+		breakdown.AddLine(&domain.PriceLine{
+			Component:   domain.PriceComponentRush,
+			Code:        rush.Code,
+			Description: rush.Name,
+			Quantity:    1,
+			UnitPrice:   rush.Price,
+			Amount:      rush.Price,
+		})
+	}
+
+	return breakdown, nil
+}
+
+// applicableRushFee 納期までの日数から特急料金の区分を決定
+// 日数が lead_time_days 未満の区分のうち、lead_time_days が最も小さい（最も急ぎの）区分を返す
+func applicableRushFee(rushes []*domain.PriceBookEntry, now, deliveryDate time.Time) *domain.PriceBookEntry {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	delivery := time.Date(deliveryDate.Year(), deliveryDate.Month(), deliveryDate.Day(), 0, 0, 0, 0, now.Location())
+	days := int(delivery.Sub(today).Hours() / 24)
+
+	var applied *domain.PriceBookEntry
+	for _, rush := range rushes {
+		if days >= rush.LeadTimeDays {
+			continue
+		}
+		if applied == nil || rush.LeadTimeDays < applied.LeadTimeDays {
+			applied = rush
+		}
+	}
+	return applied
+}
+
+// GetPriceBook テナントの価格表を取得
+func (s *PricingService) GetPriceBook(ctx context.Context, tenantID string) ([]*domain.PriceBookEntry, error) {
+	if tenantID == "" {
+		return nil, fmt.Errorf("tenant_id is required")
+	}
+	return s.priceBookRepo.GetByTenantID(ctx, tenantID)
+}
+
+// UpsertPriceBookEntry 価格表項目を登録・更新
+func (s *PricingService) UpsertPriceBookEntry(ctx context.Context, req *PriceBookEntryRequest) (*domain.PriceBookEntry, error) {
+	if req.TenantID == "" {
+		return nil, fmt.Errorf("tenant_id is required")
+	}
+	if !req.ItemType.IsValid() {
+		return nil, fmt.Errorf("invalid item_type: %s", req.ItemType)
+	}
+	if req.Code == "" {
+		return nil, fmt.Errorf("code is required")
+	}
+	if req.Name == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+	if req.Price < 0 {
+		return nil, fmt.Errorf("invalid price: must not be negative")
+	}
+	if req.ItemType == domain.PriceBookItemTypeBase && !domain.PlanType(req.Code).IsValid() {
+		return nil, fmt.Errorf("invalid code for BASE: %s is not a plan_type", req.Code)
+	}
+	if req.ItemType == domain.PriceBookItemTypeRush && req.LeadTimeDays <= 0 {
+		return nil, fmt.Errorf("invalid lead_time_days: must be greater than 0 for RUSH")
+	}
+
+	entry := domain.NewPriceBookEntry(req.TenantID, req.ItemType, req.Code, req.Name, req.Price)
+	entry.Category = req.Category
+	entry.LeadTimeDays = req.LeadTimeDays
+	if req.Active != nil {
+		entry.Active = *req.Active
+	}
+
+	if err := s.priceBookRepo.Upsert(ctx, entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// recordOverrideInTx トランザクション内で価格上書きを記録（注文の作成と同時に保存する）
+func (s *PricingService) recordOverrideInTx(ctx context.Context, tx *sql.Tx, override *domain.OrderPriceOverride) error {
+	breakdownJSON, err := json.Marshal(override.Breakdown)
+	if err != nil {
+		return fmt.Errorf("failed to marshal price breakdown: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO order_price_overrides (
+			id, tenant_id, order_id, computed_amount, override_amount, reason, breakdown, authorized_by, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`,
+		override.ID,
+		override.TenantID,
+		override.OrderID,
+		override.ComputedAmount,
+		override.OverrideAmount,
+		override.Reason,
+		breakdownJSON,
+		override.AuthorizedBy,
+		override.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create order price override: %w", err)
+	}
+	return nil
+}
+
+// GetOrderPriceOverrides 注文の価格上書き記録を取得
+func (s *PricingService) GetOrderPriceOverrides(ctx context.Context, orderID string, tenantID string) ([]*domain.OrderPriceOverride, error) {
+	return s.priceBookRepo.GetOverridesByOrderID(ctx, orderID, tenantID)
+}
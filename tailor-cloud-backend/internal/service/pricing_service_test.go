@@ -0,0 +1,108 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"tailor-cloud/backend/internal/config/domain"
+)
+
+// testPriceBook プラン基本価格・オプション・特急料金を含む価格表
+func testPriceBook() []*domain.PriceBookEntry {
+	lapel := domain.NewPriceBookEntry("tenant-1", domain.PriceBookItemTypeOption, "PEAK_LAPEL", "ピークドラペル", 5000)
+	lapel.Category = "LAPEL"
+	notch := domain.NewPriceBookEntry("tenant-1", domain.PriceBookItemTypeOption, "NOTCH_LAPEL", "ノッチドラペル", 0)
+	notch.Category = "LAPEL"
+	lining := domain.NewPriceBookEntry("tenant-1", domain.PriceBookItemTypeOption, "CUPRA_LINING", "キュプラ裏地", 8000)
+	lining.Category = "LINING"
+	rush := domain.NewPriceBookEntry("tenant-1", domain.PriceBookItemTypeRush, "RUSH_14", "特急料金（2週間以内）", 10000)
+	rush.LeadTimeDays = 14
+	express := domain.NewPriceBookEntry("tenant-1", domain.PriceBookItemTypeRush, "RUSH_7", "超特急料金（1週間以内）", 20000)
+	express.LeadTimeDays = 7
+
+	return []*domain.PriceBookEntry{
+		domain.NewPriceBookEntry("tenant-1", domain.PriceBookItemTypeBase, string(domain.PlanTypeBestValue), "仕立て代（ベストバリュー）", 50000),
+		lapel, notch, lining, rush, express,
+	}
+}
+
+// TestCalculatePriceBreakdown 価格内訳計算のテスト
+func TestCalculatePriceBreakdown(t *testing.T) {
+	now := time.Date(2025, 6, 1, 10, 0, 0, 0, time.Local)
+	fabric := &domain.Fabric{ID: "fabric-1", Name: "ウール", Price: 12345, MinimumOrder: 3.2}
+
+	tests := []struct {
+		name         string
+		deliveryDays int
+		options      []string
+		length       float64
+		wantTotal    int64
+		wantRush     string
+	}{
+		// 50000 + 12345 × 3.2 = 50000 + 39504
+		{"最小発注数量・特急なし", 30, nil, 0, 89504, ""},
+		// 50000 + 12345 × 3.5（43207.5 → 43208）+ 5000 + 8000
+		{"オプションあり", 30, []string{"PEAK_LAPEL", "CUPRA_LINING"}, 3.5, 106208, ""},
+		{"2週間以内は特急料金", 10, nil, 0, 99504, "RUSH_14"},
+		{"1週間以内は超特急料金のみ", 5, nil, 0, 109504, "RUSH_7"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			breakdown, err := CalculatePriceBreakdown(testPriceBook(), fabric, &PriceRequest{
+				TenantID:     "tenant-1",
+				PlanType:     domain.PlanTypeBestValue,
+				FabricID:     fabric.ID,
+				FabricLength: tt.length,
+				OptionCodes:  tt.options,
+				DeliveryDate: now.AddDate(0, 0, tt.deliveryDays),
+			}, now)
+			if err != nil {
+				t.Fatalf("CalculatePriceBreakdown failed: %v", err)
+			}
+			if breakdown.TotalAmount != tt.wantTotal {
+				t.Errorf("Expected total %d, got %d", tt.wantTotal, breakdown.TotalAmount)
+			}
+
+			rush := ""
+			for _, line := range breakdown.Lines {
+				if line.Component == domain.PriceComponentRush {
+					rush = line.Code
+				}
+			}
+			if rush != tt.wantRush {
+				t.Errorf("Expected rush fee %q, got %q", tt.wantRush, rush)
+			}
+		})
+	}
+}
+
+// TestCalculatePriceBreakdown_InvalidOptions 価格表にない・同一分類で重複するオプションのテスト
+func TestCalculatePriceBreakdown_InvalidOptions(t *testing.T) {
+	now := time.Date(2025, 6, 1, 10, 0, 0, 0, time.Local)
+	fabric := &domain.Fabric{ID: "fabric-1", Name: "ウール", Price: 10000}
+
+	cases := map[string][]string{
+		"invalid option code":        {"UNKNOWN"},
+		"invalid option combination": {"PEAK_LAPEL", "NOTCH_LAPEL"},
+	}
+	for want, options := range cases {
+		_, err := CalculatePriceBreakdown(testPriceBook(), fabric, &PriceRequest{
+			PlanType:     domain.PlanTypeBestValue,
+			OptionCodes:  options,
+			DeliveryDate: now.AddDate(0, 1, 0),
+		}, now)
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected error containing %q, got %v", want, err)
+		}
+	}
+
+	_, err := CalculatePriceBreakdown(testPriceBook(), fabric, &PriceRequest{
+		PlanType:     domain.PlanTypeAuthentic,
+		DeliveryDate: now.AddDate(0, 1, 0),
+	}, now)
+	if err == nil || !strings.Contains(err.Error(), "price not found") {
+		t.Errorf("Expected missing base price error, got %v", err)
+	}
+}
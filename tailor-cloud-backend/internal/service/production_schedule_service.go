@@ -0,0 +1,435 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"tailor-cloud/backend/internal/config/domain"
+	"tailor-cloud/backend/internal/repository"
+)
+
+const (
+	productionScheduleHorizonDays = 365 // 割り付けを行う最大日数（これを超える注文は計画外）
+	defaultScheduleViewDays       = 30  // ガントチャートの既定の表示日数
+	maxScheduleViewDays           = 180 // ガントチャートの最大表示日数
+)
+
+// ProductionScheduleService 生産計画サービス
+// 工場の生産能力（作業者数・稼働時間・標準作業時間）と日ごとの個別設定から、
+// 確定済みの注文を納期順に日ごとへ割り付け、納期遅延の注文と代替案を提示する
+type ProductionScheduleService struct {
+	capacityRepo repository.FactoryCapacityRepository
+	orderRepo    repository.OrderRepository
+}
+
+// NewProductionScheduleService ProductionScheduleServiceのコンストラクタ
+func NewProductionScheduleService(
+	capacityRepo repository.FactoryCapacityRepository,
+	orderRepo repository.OrderRepository,
+) *ProductionScheduleService {
+	return &ProductionScheduleService{
+		capacityRepo: capacityRepo,
+		orderRepo:    orderRepo,
+	}
+}
+
+// UpdateFactoryCapacityRequest 生産能力の更新リクエスト
+type UpdateFactoryCapacityRequest struct {
+	TenantID        string                 `json:"-"`
+	Headcount       int                    `json:"headcount"`
+	HoursPerDay     float64                `json:"hours_per_day"`
+	WorkingWeekdays []time.Weekday         `json:"working_weekdays"`
+	ShippingDays    *int                   `json:"shipping_days"` // 未指定の場合は1日
+	StandardTimes   []*domain.StandardTime `json:"standard_times"`
+	UpdatedBy       string                 `json:"-"`
+}
+
+// SetCapacityOverrideRequest 個別設定の登録リクエスト
+type SetCapacityOverrideRequest struct {
+	TenantID    string                      `json:"-"`
+	Date        time.Time                   `json:"-"` // 対象日（ハンドラーで "2006-01-02" から変換）
+	Type        domain.CapacityOverrideType `json:"type"`
+	Headcount   int                         `json:"headcount"`
+	HoursPerDay float64                     `json:"hours_per_day"`
+	Note        string                      `json:"note"`
+	CreatedBy   string                      `json:"-"`
+}
+
+// GetCapacity 生産能力を取得
+func (s *ProductionScheduleService) GetCapacity(ctx context.Context, tenantID string) (*domain.FactoryCapacity, error) {
+	if tenantID == "" {
+		return nil, fmt.Errorf("tenant_id is required")
+	}
+	return s.capacityRepo.Get(ctx, tenantID)
+}
+
+// UpdateCapacity 生産能力を登録・更新
+func (s *ProductionScheduleService) UpdateCapacity(ctx context.Context, req *UpdateFactoryCapacityRequest) (*domain.FactoryCapacity, error) {
+	if req.TenantID == "" {
+		return nil, fmt.Errorf("tenant_id is required")
+	}
+	if req.UpdatedBy == "" {
+		return nil, fmt.Errorf("updated_by is required")
+	}
+
+	capacity := &domain.FactoryCapacity{
+		TenantID:        req.TenantID,
+		Headcount:       req.Headcount,
+		HoursPerDay:     req.HoursPerDay,
+		WorkingWeekdays: req.WorkingWeekdays,
+		ShippingDays:    1,
+		StandardTimes:   req.StandardTimes,
+		UpdatedAt:       time.Now(),
+		UpdatedBy:       req.UpdatedBy,
+	}
+	if req.ShippingDays != nil {
+		capacity.ShippingDays = *req.ShippingDays
+	}
+	if capacity.StandardTimes == nil {
+		capacity.StandardTimes = make([]*domain.StandardTime, 0)
+	}
+	if err := capacity.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.capacityRepo.Upsert(ctx, capacity); err != nil {
+		return nil, err
+	}
+
+	return capacity, nil
+}
+
+// ListOverrides 期間内の個別設定を取得（from・toを含む）
+func (s *ProductionScheduleService) ListOverrides(ctx context.Context, tenantID string, from, to time.Time) ([]*domain.CapacityOverride, error) {
+	if tenantID == "" {
+		return nil, fmt.Errorf("tenant_id is required")
+	}
+	if to.Before(from) {
+		return nil, fmt.Errorf("invalid period: to must not be before from")
+	}
+	return s.capacityRepo.ListOverrides(ctx, tenantID, domain.ProductionDay(from), domain.ProductionDay(to))
+}
+
+// SetOverride 個別設定を登録（同じ日の設定は置き換える）
+func (s *ProductionScheduleService) SetOverride(ctx context.Context, req *SetCapacityOverrideRequest) (*domain.CapacityOverride, error) {
+	if req.TenantID == "" {
+		return nil, fmt.Errorf("tenant_id is required")
+	}
+	if req.Date.IsZero() {
+		return nil, fmt.Errorf("date is required")
+	}
+	if req.CreatedBy == "" {
+		return nil, fmt.Errorf("created_by is required")
+	}
+	if !req.Type.IsValid() {
+		return nil, fmt.Errorf("invalid override type: %s", req.Type)
+	}
+	if req.Headcount < 0 {
+		return nil, fmt.Errorf("invalid headcount: must not be negative")
+	}
+	if req.HoursPerDay < 0 || req.HoursPerDay > 24 {
+		return nil, fmt.Errorf("invalid hours_per_day: must be between 0 and 24")
+	}
+	if req.Type == domain.CapacityOverrideTypeOvertime && req.Headcount == 0 && req.HoursPerDay == 0 {
+		return nil, fmt.Errorf("headcount or hours_per_day is required for overtime")
+	}
+
+	override := domain.NewCapacityOverride(req.TenantID, req.Date, req.Type, req.CreatedBy)
+	if req.Type == domain.CapacityOverrideTypeOvertime {
+		override.Headcount = req.Headcount
+		override.HoursPerDay = req.HoursPerDay
+	}
+	override.Note = req.Note
+
+	if err := s.capacityRepo.UpsertOverride(ctx, override); err != nil {
+		return nil, err
+	}
+
+	return override, nil
+}
+
+// DeleteOverride 個別設定を削除
+func (s *ProductionScheduleService) DeleteOverride(ctx context.Context, overrideID, tenantID string) error {
+	if overrideID == "" {
+		return fmt.Errorf("override_id is required")
+	}
+	if tenantID == "" {
+		return fmt.Errorf("tenant_id is required")
+	}
+	return s.capacityRepo.DeleteOverride(ctx, overrideID, tenantID)
+}
+
+// GetSchedule 生産計画を作成
+// 製造中・製造待ちの注文（確定〜検品）をfromの日から割り付け、viewDays日分の負荷を返す
+func (s *ProductionScheduleService) GetSchedule(ctx context.Context, tenantID string, from time.Time, viewDays int) (*domain.ProductionSchedule, error) {
+	if tenantID == "" {
+		return nil, fmt.Errorf("tenant_id is required")
+	}
+	if viewDays == 0 {
+		viewDays = defaultScheduleViewDays
+	}
+	if viewDays < 0 || viewDays > maxScheduleViewDays {
+		return nil, fmt.Errorf("invalid days: must be between 1 and %d", maxScheduleViewDays)
+	}
+
+	capacity, err := s.capacityRepo.Get(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	from = domain.ProductionDay(from)
+	overrides, err := s.capacityRepo.ListOverrides(ctx, tenantID, from, from.AddDate(0, 0, productionScheduleHorizonDays-1))
+	if err != nil {
+		return nil, err
+	}
+
+	orders, err := searchAllOrders(ctx, s.orderRepo, &domain.OrderSearchFilter{
+		TenantID: tenantID,
+		Statuses: []domain.OrderStatus{
+			domain.OrderStatusConfirmed,
+			domain.OrderStatusMaterialSecured,
+			domain.OrderStatusCutting,
+			domain.OrderStatusSewing,
+			domain.OrderStatusInspection,
+		},
+		SortBy:    domain.OrderSortDeliveryDate,
+		SortOrder: domain.SortOrderAsc,
+		Limit:     100, // 1回の検索の最大件数
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get orders: %w", err)
+	}
+
+	schedule := BuildProductionSchedule(capacity, overrides, orders, from, viewDays)
+	schedule.TenantID = tenantID
+	return schedule, nil
+}
+
+// scheduleDay 割り付け中の1日分の生産能力
+type scheduleDay struct {
+	date          time.Time
+	capacity      int // 生産能力（分）
+	workerMinutes int // 1人あたりの稼働時間（分）。1着に1日で割り付けられる上限
+	remaining     int
+	override      *domain.CapacityOverride
+}
+
+// BuildProductionSchedule 注文を納期の早い順に日ごとの生産能力へ割り付ける
+// 工程（裁断→縫製→検品）は順に行い、1着は1日に1人の作業者が担当する前提で、
+// 1日に1着へ割り付ける時間は1人分の稼働時間を上限とする。
+// 検品完了の期限（納期 - 発送日数）に間に合わない注文には代替案（納期変更・残業・増員）を付ける
+func BuildProductionSchedule(capacity *domain.FactoryCapacity, overrides []*domain.CapacityOverride, orders []*domain.Order, from time.Time, viewDays int) *domain.ProductionSchedule {
+	from = domain.ProductionDay(from)
+
+	overrideByDate := make(map[string]*domain.CapacityOverride, len(overrides))
+	for _, override := range overrides {
+		overrideByDate[override.Date.Format("2006-01-02")] = override
+	}
+
+	days := make([]*scheduleDay, productionScheduleHorizonDays)
+	for i := range days {
+		date := from.AddDate(0, 0, i)
+		override := overrideByDate[date.Format("2006-01-02")]
+		headcount, hoursPerDay := capacity.DayCapacity(date, override)
+		workerMinutes := int(hoursPerDay * 60)
+		days[i] = &scheduleDay{
+			date:          date,
+			capacity:      headcount * workerMinutes,
+			workerMinutes: workerMinutes,
+			remaining:     headcount * workerMinutes,
+			override:      override,
+		}
+	}
+
+	// 納期の早い順（同じ納期は先に作成された注文を優先）
+	targets := make([]*domain.Order, 0, len(orders))
+	for _, order := range orders {
+		if len(domain.RemainingProcesses(order.Status)) > 0 {
+			targets = append(targets, order)
+		}
+	}
+	sort.SliceStable(targets, func(i, j int) bool {
+		if !targets[i].DeliveryDate.Equal(targets[j].DeliveryDate) {
+			return targets[i].DeliveryDate.Before(targets[j].DeliveryDate)
+		}
+		return targets[i].CreatedAt.Before(targets[j].CreatedAt)
+	})
+
+	schedule := &domain.ProductionSchedule{
+		From:        from,
+		To:          from.AddDate(0, 0, viewDays-1),
+		GeneratedAt: time.Now(),
+		Days:        make([]*domain.ScheduleDay, 0, viewDays),
+		Orders:      make([]*domain.ScheduledOrder, 0, len(targets)),
+	}
+
+	for _, order := range targets {
+		scheduled := scheduleOrder(capacity, days, order)
+		if scheduled.Late {
+			schedule.LateOrders++
+		}
+		if scheduled.CompletionDate == nil {
+			schedule.UnscheduledOrders++
+		}
+		schedule.Orders = append(schedule.Orders, scheduled)
+	}
+
+	for _, day := range days[:viewDays] {
+		summary := &domain.ScheduleDay{
+			Date:             day.date,
+			CapacityMinutes:  day.capacity,
+			AllocatedMinutes: day.capacity - day.remaining,
+		}
+		if day.capacity > 0 {
+			summary.UtilizationPercent = summary.AllocatedMinutes * 100 / day.capacity
+		}
+		if day.override != nil {
+			summary.OverrideType = day.override.Type
+		}
+		schedule.Days = append(schedule.Days, summary)
+	}
+
+	return schedule
+}
+
+// scheduleOrder 1件の注文の残り工程を割り付け、納期遅延を判定する
+func scheduleOrder(capacity *domain.FactoryCapacity, days []*scheduleDay, order *domain.Order) *domain.ScheduledOrder {
+	garmentType := order.GarmentType
+	if garmentType == "" {
+		garmentType = domain.GarmentTypeSuit
+	}
+	deadline := domain.ProductionDay(order.DeliveryDate).AddDate(0, 0, -capacity.ShippingDays)
+
+	scheduled := &domain.ScheduledOrder{
+		OrderID:      order.ID,
+		CustomerID:   order.CustomerID,
+		GarmentType:  garmentType,
+		Status:       order.Status,
+		DeliveryDate: order.DeliveryDate,
+		Deadline:     deadline,
+		Tasks:        make([]*domain.ScheduledTask, 0, 3),
+		Suggestions:  make([]*domain.ScheduleSuggestion, 0),
+	}
+
+	used := make(map[int]int) // この注文に日ごとに割り付けた時間（分）
+	shortfall := 0            // 期限に間に合わない作業時間（分）
+	d := 0
+	completed := true
+	for _, process := range domain.RemainingProcesses(order.Status) {
+		minutes := capacity.StandardMinutes(garmentType, process)
+		if minutes <= 0 {
+			continue
+		}
+
+		start, end := -1, -1
+		remaining := minutes
+		for remaining > 0 && d < len(days) {
+			day := days[d]
+			available := day.remaining
+			if limit := day.workerMinutes - used[d]; limit < available {
+				available = limit
+			}
+			if available > 0 {
+				use := available
+				if remaining < use {
+					use = remaining
+				}
+				day.remaining -= use
+				used[d] += use
+				remaining -= use
+				if start < 0 {
+					start = d
+				}
+				end = d
+				if day.date.After(deadline) {
+					shortfall += use
+				}
+			}
+			if remaining > 0 {
+				d++
+			}
+		}
+
+		if remaining > 0 {
+			// 計画期間内に収まらない
+			shortfall += remaining
+			completed = false
+			break
+		}
+		scheduled.Tasks = append(scheduled.Tasks, &domain.ScheduledTask{
+			Process:   process,
+			StartDate: days[start].date,
+			EndDate:   days[end].date,
+			Minutes:   minutes,
+		})
+	}
+
+	if completed {
+		completionDate := deadline
+		if len(scheduled.Tasks) > 0 {
+			completionDate = scheduled.Tasks[len(scheduled.Tasks)-1].EndDate
+		}
+		scheduled.CompletionDate = &completionDate
+		if completionDate.After(deadline) {
+			scheduled.Late = true
+			scheduled.LateDays = int(completionDate.Sub(deadline).Hours()/24 + 0.5)
+		}
+	} else {
+		scheduled.Late = true
+	}
+
+	if scheduled.Late {
+		scheduled.Suggestions = scheduleSuggestions(capacity, days, scheduled, shortfall)
+	}
+
+	return scheduled
+}
+
+// scheduleSuggestions 納期遅延の注文に対する代替案
+// 残業・増員の見込みは、期限後に割り付けた（または割り付けられなかった）作業時間を
+// 期限までの稼働日で補う場合の目安
+func scheduleSuggestions(capacity *domain.FactoryCapacity, days []*scheduleDay, scheduled *domain.ScheduledOrder, shortfall int) []*domain.ScheduleSuggestion {
+	suggestions := make([]*domain.ScheduleSuggestion, 0, 3)
+
+	if scheduled.CompletionDate != nil {
+		suggested := scheduled.CompletionDate.AddDate(0, 0, capacity.ShippingDays)
+		suggestions = append(suggestions, &domain.ScheduleSuggestion{
+			Type:                  domain.ScheduleSuggestionDelayDelivery,
+			Message:               fmt.Sprintf("納期を%sに変更すると現在の生産能力で間に合います", suggested.Format("2006/01/02")),
+			SuggestedDeliveryDate: &suggested,
+		})
+	}
+
+	workingDays := 0
+	for _, day := range days {
+		if day.date.After(scheduled.Deadline) {
+			break
+		}
+		if day.capacity > 0 {
+			workingDays++
+		}
+	}
+	if workingDays == 0 || shortfall <= 0 {
+		// 期限までに稼働日がない場合は納期の変更のみ
+		return suggestions
+	}
+
+	suggestions = append(suggestions, &domain.ScheduleSuggestion{
+		Type:            domain.ScheduleSuggestionOvertime,
+		Message:         fmt.Sprintf("期限までに合計%.1f時間の残業・休日出勤で間に合う見込みです", float64(shortfall)/60),
+		OvertimeMinutes: shortfall,
+	})
+
+	minutesPerWorker := capacity.HoursPerDay * 60 * float64(workingDays)
+	additional := int(math.Ceil(float64(shortfall) / minutesPerWorker))
+	suggestions = append(suggestions, &domain.ScheduleSuggestion{
+		Type:                domain.ScheduleSuggestionAddHeadcount,
+		Message:             fmt.Sprintf("期限までの稼働日（%d日）に作業者を%d名増員すると間に合う見込みです", workingDays, additional),
+		AdditionalHeadcount: additional,
+	})
+
+	return suggestions
+}
@@ -0,0 +1,177 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"tailor-cloud/backend/internal/config/domain"
+)
+
+// testFactoryCapacity 作業者1名・1日8時間・月〜金稼働・発送1日の生産能力
+func testFactoryCapacity() *domain.FactoryCapacity {
+	return &domain.FactoryCapacity{
+		TenantID:        "tenant-1",
+		Headcount:       1,
+		HoursPerDay:     8,
+		WorkingWeekdays: []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+		ShippingDays:    1,
+	}
+}
+
+// testScheduleOrder 生産計画用の注文
+func testScheduleOrder(id string, garmentType domain.GarmentType, status domain.OrderStatus, deliveryDate, createdAt time.Time) *domain.Order {
+	order := domain.NewOrder("tenant-1", "customer-"+id, "fabric-1", "user-1", 100000, deliveryDate)
+	order.ID = id
+	order.GarmentType = garmentType
+	order.Status = status
+	order.CreatedAt = createdAt
+	return order
+}
+
+// octoberDay 2026年10月の日付（2026/10/19は月曜日）
+func octoberDay(d int) time.Time {
+	return time.Date(2026, 10, d, 0, 0, 0, 0, time.Local)
+}
+
+// TestBuildProductionSchedule 納期順の割り付けと納期遅延・代替案のテスト
+func TestBuildProductionSchedule(t *testing.T) {
+	created := time.Date(2026, 10, 1, 9, 0, 0, 0, time.Local)
+	orders := []*domain.Order{
+		// 検品のみ残っている注文（納期に余裕あり）
+		testScheduleOrder("d", domain.GarmentTypeSuit, domain.OrderStatusInspection, time.Date(2026, 11, 30, 0, 0, 0, 0, time.Local), created),
+		testScheduleOrder("c", domain.GarmentTypeSuit, domain.OrderStatusConfirmed, octoberDay(26), created),
+		// 同じ納期は先に作成された注文を優先
+		testScheduleOrder("b", domain.GarmentTypeTrousers, domain.OrderStatusMaterialSecured, octoberDay(23), created.Add(time.Hour)),
+		testScheduleOrder("a", domain.GarmentTypeSuit, domain.OrderStatusConfirmed, octoberDay(23), created),
+		// 製造対象外
+		testScheduleOrder("e", domain.GarmentTypeSuit, domain.OrderStatusShipped, octoberDay(23), created),
+	}
+
+	schedule := BuildProductionSchedule(testFactoryCapacity(), nil, orders, octoberDay(19).Add(10*time.Hour), 14)
+
+	if len(schedule.Orders) != 4 {
+		t.Fatalf("Expected 4 scheduled orders, got %d", len(schedule.Orders))
+	}
+	ids := ""
+	for _, order := range schedule.Orders {
+		ids += order.OrderID
+	}
+	if ids != "abcd" {
+		t.Errorf("Expected earliest deadline first order abcd, got %s", ids)
+	}
+
+	// a: スーツ 1320分 → 月480・火480・水360
+	a := schedule.Orders[0]
+	if a.Late || !a.CompletionDate.Equal(octoberDay(21)) {
+		t.Errorf("Expected a to complete on 10/21 without delay, got late=%v completion=%v", a.Late, a.CompletionDate)
+	}
+	if len(a.Tasks) != 3 || !a.Tasks[1].StartDate.Equal(octoberDay(19)) || !a.Tasks[1].EndDate.Equal(octoberDay(21)) {
+		t.Errorf("Unexpected sewing task for a: %+v", a.Tasks[1])
+	}
+
+	// b: パンツ 405分 → 水120・木285、期限（納期の前日）の木曜に完了
+	b := schedule.Orders[1]
+	if b.Late || !b.CompletionDate.Equal(octoberDay(22)) || !b.Deadline.Equal(octoberDay(22)) {
+		t.Errorf("Expected b to complete on its deadline 10/22, got late=%v completion=%v deadline=%v", b.Late, b.CompletionDate, b.Deadline)
+	}
+
+	// c: スーツ 1320分 → 木195・金480・月480・火165、期限（10/25）を2日超過
+	c := schedule.Orders[2]
+	if !c.Late || c.LateDays != 2 || !c.CompletionDate.Equal(octoberDay(27)) {
+		t.Fatalf("Expected c to be 2 days late completing 10/27, got late=%v days=%d completion=%v", c.Late, c.LateDays, c.CompletionDate)
+	}
+	if len(c.Suggestions) != 3 {
+		t.Fatalf("Expected 3 suggestions, got %d", len(c.Suggestions))
+	}
+	if c.Suggestions[0].Type != domain.ScheduleSuggestionDelayDelivery || !c.Suggestions[0].SuggestedDeliveryDate.Equal(octoberDay(28)) {
+		t.Errorf("Unexpected delay suggestion: %+v", c.Suggestions[0])
+	}
+	if c.Suggestions[1].Type != domain.ScheduleSuggestionOvertime || c.Suggestions[1].OvertimeMinutes != 645 {
+		t.Errorf("Expected 645 overtime minutes, got %+v", c.Suggestions[1])
+	}
+	if c.Suggestions[2].Type != domain.ScheduleSuggestionAddHeadcount || c.Suggestions[2].AdditionalHeadcount != 1 {
+		t.Errorf("Expected 1 additional worker, got %+v", c.Suggestions[2])
+	}
+
+	// d: 検品のみ 30分 → 火曜に割り付け
+	d := schedule.Orders[3]
+	if len(d.Tasks) != 1 || d.Tasks[0].Process != domain.ProductionProcessInspection || !d.Tasks[0].StartDate.Equal(octoberDay(27)) {
+		t.Errorf("Unexpected tasks for d: %+v", d.Tasks)
+	}
+
+	if schedule.LateOrders != 1 || schedule.UnscheduledOrders != 0 {
+		t.Errorf("Expected 1 late order, got late=%d unscheduled=%d", schedule.LateOrders, schedule.UnscheduledOrders)
+	}
+	if len(schedule.Days) != 14 || !schedule.To.Equal(octoberDay(19).AddDate(0, 0, 13)) {
+		t.Fatalf("Expected 14 days, got %d (to=%v)", len(schedule.Days), schedule.To)
+	}
+	if schedule.Days[0].UtilizationPercent != 100 || schedule.Days[5].CapacityMinutes != 0 {
+		t.Errorf("Unexpected day load: mon=%+v sat=%+v", schedule.Days[0], schedule.Days[5])
+	}
+	if schedule.Days[8].AllocatedMinutes != 195 {
+		t.Errorf("Expected 195 allocated minutes on 10/27, got %d", schedule.Days[8].AllocatedMinutes)
+	}
+}
+
+// TestBuildProductionScheduleOverrides 休業日・休日出勤の個別設定のテスト
+func TestBuildProductionScheduleOverrides(t *testing.T) {
+	holiday := domain.NewCapacityOverride("tenant-1", octoberDay(20), domain.CapacityOverrideTypeHoliday, "user-1")
+	saturday := domain.NewCapacityOverride("tenant-1", octoberDay(24), domain.CapacityOverrideTypeOvertime, "user-1")
+	saturday.HoursPerDay = 4
+	overtime := domain.NewCapacityOverride("tenant-1", octoberDay(21), domain.CapacityOverrideTypeOvertime, "user-1")
+	overtime.Headcount = 2
+	overtime.HoursPerDay = 10
+
+	orders := []*domain.Order{
+		testScheduleOrder("a", domain.GarmentTypeSuit, domain.OrderStatusConfirmed, octoberDay(30), time.Now()),
+	}
+	schedule := BuildProductionSchedule(testFactoryCapacity(), []*domain.CapacityOverride{holiday, saturday, overtime}, orders, octoberDay(19), 7)
+
+	if schedule.Days[1].CapacityMinutes != 0 || schedule.Days[1].OverrideType != domain.CapacityOverrideTypeHoliday {
+		t.Errorf("Expected holiday on 10/20, got %+v", schedule.Days[1])
+	}
+	if schedule.Days[2].CapacityMinutes != 1200 {
+		t.Errorf("Expected 1200 minutes on overtime day, got %d", schedule.Days[2].CapacityMinutes)
+	}
+	if schedule.Days[5].CapacityMinutes != 240 || schedule.Days[6].CapacityMinutes != 0 {
+		t.Errorf("Expected Saturday work 240 and Sunday off, got sat=%d sun=%d", schedule.Days[5].CapacityMinutes, schedule.Days[6].CapacityMinutes)
+	}
+
+	// 1着は1日1人分（10/21は10時間）まで: 月480・水600・木240
+	order := schedule.Orders[0]
+	if !order.CompletionDate.Equal(octoberDay(22)) || schedule.Days[2].AllocatedMinutes != 600 {
+		t.Errorf("Expected completion 10/22 with 600 minutes on 10/21, got completion=%v allocated=%d", order.CompletionDate, schedule.Days[2].AllocatedMinutes)
+	}
+}
+
+// TestFactoryCapacityStandardMinutes 標準作業時間の上書きと既定値のテスト
+func TestFactoryCapacityStandardMinutes(t *testing.T) {
+	capacity := testFactoryCapacity()
+	capacity.StandardTimes = []*domain.StandardTime{
+		{GarmentType: domain.GarmentTypeSuit, Process: domain.ProductionProcessSewing, Minutes: 900},
+	}
+
+	if got := capacity.StandardMinutes(domain.GarmentTypeSuit, domain.ProductionProcessSewing); got != 900 {
+		t.Errorf("Expected overridden 900 minutes, got %d", got)
+	}
+	if got := capacity.StandardMinutes(domain.GarmentTypeSuit, domain.ProductionProcessCutting); got != 90 {
+		t.Errorf("Expected default 90 minutes, got %d", got)
+	}
+	// 品目未設定はスーツ扱い
+	if got := capacity.StandardMinutes("", domain.ProductionProcessSewing); got != 900 {
+		t.Errorf("Expected suit minutes for empty garment type, got %d", got)
+	}
+	if err := capacity.Validate(); err != nil {
+		t.Errorf("Validate returned error: %v", err)
+	}
+
+	capacity.StandardTimes[0].Minutes = 0
+	if err := capacity.Validate(); err == nil {
+		t.Error("Expected validation error for zero standard minutes")
+	}
+	capacity.StandardTimes = nil
+	capacity.WorkingWeekdays = nil
+	if err := capacity.Validate(); err == nil {
+		t.Error("Expected validation error for missing working weekdays")
+	}
+}
@@ -0,0 +1,504 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"tailor-cloud/backend/internal/config/domain"
+	"tailor-cloud/backend/internal/repository"
+)
+
+// ProductionTaskService 製造タスクサービス
+// 注文・工程ごとの製造タスクを作成し、作業者による開始・中断・完了と作業時間を記録する。
+// 工程の開始で注文ステータスをその工程へ、工程の最後のタスクの完了で次の工程へ進める
+type ProductionTaskService struct {
+	taskRepo     repository.ProductionTaskRepository
+	orderRepo    repository.OrderRepository
+	capacityRepo repository.FactoryCapacityRepository
+	db           *sql.DB // トランザクション管理用（タスクと注文ステータスの同時更新）
+}
+
+// NewProductionTaskService ProductionTaskServiceのコンストラクタ
+func NewProductionTaskService(
+	taskRepo repository.ProductionTaskRepository,
+	orderRepo repository.OrderRepository,
+	capacityRepo repository.FactoryCapacityRepository,
+	db *sql.DB,
+) *ProductionTaskService {
+	return &ProductionTaskService{
+		taskRepo:     taskRepo,
+		orderRepo:    orderRepo,
+		capacityRepo: capacityRepo,
+		db:           db,
+	}
+}
+
+// AssignProductionTaskRequest 担当作業者の割り当てリクエスト
+type AssignProductionTaskRequest struct {
+	TaskID   string `json:"-"`
+	TenantID string `json:"-"`
+	WorkerID string `json:"worker_id"` // 空の場合は割り当てを解除
+}
+
+// ProductionTaskActionRequest 作業の開始・中断・完了リクエスト
+type ProductionTaskActionRequest struct {
+	TaskID    string
+	TenantID  string
+	WorkerID  string
+	Role      domain.UserRole
+	IPAddress string
+	UserAgent string
+}
+
+// ProductionTaskActionResponse 作業の開始・中断・完了レスポンス
+type ProductionTaskActionResponse struct {
+	Task          *domain.ProductionTask `json:"task"`
+	OrderStatus   domain.OrderStatus     `json:"order_status"`
+	OrderAdvanced bool                   `json:"order_advanced"` // 注文ステータスを進めたか
+}
+
+// GenerateTasks 注文の残りの工程の製造タスクを作成し、注文の全タスクを返す
+// 作成済みの工程はスキップする（何度呼び出しても同じ工程のタスクは重複しない）
+func (s *ProductionTaskService) GenerateTasks(ctx context.Context, orderID, tenantID string) ([]*domain.ProductionTask, error) {
+	if orderID == "" {
+		return nil, fmt.Errorf("order_id is required")
+	}
+	if tenantID == "" {
+		return nil, fmt.Errorf("tenant_id is required")
+	}
+
+	order, err := s.getOrder(ctx, orderID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	processes := domain.RemainingProcesses(order.Status)
+	if len(processes) == 0 {
+		return nil, fmt.Errorf("invalid order status: %s order has no production processes", order.Status)
+	}
+
+	capacity, err := loadFactoryCapacity(ctx, s.capacityRepo, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := s.taskRepo.List(ctx, &domain.ProductionTaskFilter{TenantID: tenantID, OrderID: orderID})
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[domain.ProductionProcess]bool, len(tasks))
+	for _, task := range tasks {
+		existing[task.Process] = true
+	}
+
+	garmentType := order.GarmentType
+	if garmentType == "" {
+		garmentType = domain.GarmentTypeSuit
+	}
+	for _, process := range processes {
+		if existing[process] {
+			continue
+		}
+		task := domain.NewProductionTask(tenantID, orderID, garmentType, process, capacity.StandardMinutes(garmentType, process))
+		if err := s.taskRepo.Create(ctx, task); err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+
+	return tasks, nil
+}
+
+// ListOrderTasks 注文の製造タスクを取得
+func (s *ProductionTaskService) ListOrderTasks(ctx context.Context, orderID, tenantID string) ([]*domain.ProductionTask, error) {
+	if orderID == "" {
+		return nil, fmt.Errorf("order_id is required")
+	}
+	if tenantID == "" {
+		return nil, fmt.Errorf("tenant_id is required")
+	}
+	return s.taskRepo.List(ctx, &domain.ProductionTaskFilter{TenantID: tenantID, OrderID: orderID})
+}
+
+// ListTasks 製造タスクを検索
+func (s *ProductionTaskService) ListTasks(ctx context.Context, filter *domain.ProductionTaskFilter) ([]*domain.ProductionTask, error) {
+	if filter.TenantID == "" {
+		return nil, fmt.Errorf("tenant_id is required")
+	}
+	if filter.Process != "" && !filter.Process.IsValid() {
+		return nil, fmt.Errorf("invalid process: %s", filter.Process)
+	}
+	for _, status := range filter.Statuses {
+		if !status.IsValid() {
+			return nil, fmt.Errorf("invalid status: %s", status)
+		}
+	}
+	return s.taskRepo.List(ctx, filter)
+}
+
+// ListMyTasks 作業者の担当タスクを取得（ステータス未指定の場合は未完了のタスク）
+func (s *ProductionTaskService) ListMyTasks(ctx context.Context, tenantID, workerID string, statuses []domain.ProductionTaskStatus) ([]*domain.ProductionTask, error) {
+	if workerID == "" {
+		return nil, fmt.Errorf("worker_id is required")
+	}
+	if len(statuses) == 0 {
+		statuses = []domain.ProductionTaskStatus{
+			domain.ProductionTaskStatusPending,
+			domain.ProductionTaskStatusInProgress,
+			domain.ProductionTaskStatusPaused,
+		}
+	}
+	return s.ListTasks(ctx, &domain.ProductionTaskFilter{TenantID: tenantID, AssignedTo: workerID, Statuses: statuses})
+}
+
+// AssignTask 担当作業者を割り当て（作業中・完了のタスクは変更不可）
+func (s *ProductionTaskService) AssignTask(ctx context.Context, req *AssignProductionTaskRequest) (*domain.ProductionTask, error) {
+	if req.TaskID == "" {
+		return nil, fmt.Errorf("task_id is required")
+	}
+	if req.TenantID == "" {
+		return nil, fmt.Errorf("tenant_id is required")
+	}
+
+	task, err := s.taskRepo.GetByID(ctx, req.TaskID, req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if task.Status == domain.ProductionTaskStatusInProgress || task.Status == domain.ProductionTaskStatusCompleted {
+		return nil, fmt.Errorf("invalid task status: cannot reassign %s task", task.Status)
+	}
+
+	task.AssignedTo = req.WorkerID
+	task.UpdatedAt = time.Now()
+	if err := s.taskRepo.UpdateAssignee(ctx, task); err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// StartTask 作業を開始（未着手・中断から）
+// 前の工程が完了していない場合は開始できない。作業者が同時に作業中にできるタスクは1件まで
+func (s *ProductionTaskService) StartTask(ctx context.Context, req *ProductionTaskActionRequest) (*ProductionTaskActionResponse, error) {
+	task, order, err := s.prepareAction(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	inProgress, err := s.taskRepo.List(ctx, &domain.ProductionTaskFilter{
+		TenantID:   req.TenantID,
+		AssignedTo: req.WorkerID,
+		Statuses:   []domain.ProductionTaskStatus{domain.ProductionTaskStatusInProgress},
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, other := range inProgress {
+		if other.ID != task.ID {
+			return nil, fmt.Errorf("invalid task status: worker already has a task in progress (%s)", other.ID)
+		}
+	}
+
+	orderTasks, err := s.taskRepo.List(ctx, &domain.ProductionTaskFilter{TenantID: req.TenantID, OrderID: order.ID})
+	if err != nil {
+		return nil, err
+	}
+	if err := checkPreviousProcessesCompleted(orderTasks, task.Process); err != nil {
+		return nil, err
+	}
+
+	expected := task.Status
+	if err := task.Start(req.WorkerID, time.Now()); err != nil {
+		return nil, err
+	}
+
+	// 工程の開始: 注文ステータスが手前の場合はこの工程へ進める
+	return s.commitAction(ctx, req, task, expected, order, task.Process.OrderStatus(), false)
+}
+
+// PauseTask 作業を中断
+func (s *ProductionTaskService) PauseTask(ctx context.Context, req *ProductionTaskActionRequest) (*ProductionTaskActionResponse, error) {
+	task, order, err := s.prepareAction(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	expected := task.Status
+	if err := task.Pause(time.Now()); err != nil {
+		return nil, err
+	}
+
+	return s.commitAction(ctx, req, task, expected, order, "", false)
+}
+
+// CompleteTask 作業を完了
+// 工程の最後のタスクの場合は注文ステータスを次の工程へ進める
+func (s *ProductionTaskService) CompleteTask(ctx context.Context, req *ProductionTaskActionRequest) (*ProductionTaskActionResponse, error) {
+	task, order, err := s.prepareAction(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	expected := task.Status
+	if err := task.Complete(time.Now()); err != nil {
+		return nil, err
+	}
+
+	return s.commitAction(ctx, req, task, expected, order, domain.NextOrderStatusAfter(task.Process), true)
+}
+
+// GetWorkerThroughput 期間内（fromを含み、toを含まない）の作業者別の実績を集計
+func (s *ProductionTaskService) GetWorkerThroughput(ctx context.Context, tenantID string, from, to time.Time) (*domain.WorkerThroughputReport, error) {
+	if tenantID == "" {
+		return nil, fmt.Errorf("tenant_id is required")
+	}
+	if !to.After(from) {
+		return nil, fmt.Errorf("invalid period: to must be after from")
+	}
+
+	tasks, err := s.taskRepo.GetCompletedBetween(ctx, tenantID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	workers := domain.SummarizeWorkerThroughput(tasks)
+	report := &domain.WorkerThroughputReport{
+		TenantID: tenantID,
+		From:     from,
+		To:       to,
+		Workers:  workers,
+	}
+	for _, worker := range workers {
+		report.TotalCompleted += worker.CompletedTasks
+	}
+	return report, nil
+}
+
+// prepareAction タスクと注文を取得し、操作できるか確認
+func (s *ProductionTaskService) prepareAction(ctx context.Context, req *ProductionTaskActionRequest) (*domain.ProductionTask, *domain.Order, error) {
+	if req.TaskID == "" {
+		return nil, nil, fmt.Errorf("task_id is required")
+	}
+	if req.TenantID == "" {
+		return nil, nil, fmt.Errorf("tenant_id is required")
+	}
+	if req.WorkerID == "" {
+		return nil, nil, fmt.Errorf("worker_id is required")
+	}
+
+	task, err := s.taskRepo.GetByID(ctx, req.TaskID, req.TenantID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := canOperateTask(task, req.WorkerID, req.Role); err != nil {
+		return nil, nil, err
+	}
+
+	order, err := s.getOrder(ctx, task.OrderID, req.TenantID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if domain.ProductionStatusRank(order.Status) < 0 {
+		return nil, nil, fmt.Errorf("invalid order status: %s order is not in production", order.Status)
+	}
+
+	return task, order, nil
+}
+
+// commitAction タスクの更新と注文ステータスの進行を単一トランザクションで保存
+// advanceTo が空の場合は注文ステータスを変更しない。
+// processCompleted が true の場合は、同じ工程の未完了タスクがないときだけ進める
+func (s *ProductionTaskService) commitAction(ctx context.Context, req *ProductionTaskActionRequest, task *domain.ProductionTask, expected domain.ProductionTaskStatus, order *domain.Order, advanceTo domain.OrderStatus, processCompleted bool) (*ProductionTaskActionResponse, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := updateProductionTaskInTx(ctx, tx, task, expected); err != nil {
+		return nil, err
+	}
+
+	resp := &ProductionTaskActionResponse{Task: task, OrderStatus: order.Status}
+	advance := advanceTo != "" && domain.ProductionStatusRank(order.Status) < domain.ProductionStatusRank(advanceTo)
+	if advance && processCompleted {
+		open, err := s.countOpenTasksInTx(ctx, tx, task.OrderID, task.TenantID, task.Process)
+		if err != nil {
+			return nil, err
+		}
+		advance = open == 0
+	}
+
+	if advance {
+		oldStatus := order.Status
+		order.Status = advanceTo
+		order.UpdatedAt = task.UpdatedAt
+		if err := updateOrderStatusInTx(ctx, tx, order, oldStatus); err != nil {
+			return nil, err
+		}
+
+		auditLog := domain.NewAuditLog(order.TenantID, req.WorkerID, domain.AuditActionStatusChange, "order", order.ID)
+		auditLog.OldValue = s.toJSON(map[string]interface{}{"status": oldStatus})
+		auditLog.NewValue = s.toJSON(map[string]interface{}{"status": order.Status, "production_task_id": task.ID})
+		auditLog.ChangedFields = []string{"status"}
+		auditLog.IPAddress = req.IPAddress
+		auditLog.UserAgent = req.UserAgent
+		if err := createAuditLogInTx(ctx, tx, auditLog); err != nil {
+			return nil, err
+		}
+
+		resp.OrderStatus = order.Status
+		resp.OrderAdvanced = true
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return resp, nil
+}
+
+// getOrder 注文を取得（テナントIDもチェック）
+func (s *ProductionTaskService) getOrder(ctx context.Context, orderID, tenantID string) (*domain.Order, error) {
+	order, err := s.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+	if order.TenantID != tenantID {
+		return nil, fmt.Errorf("unauthorized: tenant_id mismatch")
+	}
+	return order, nil
+}
+
+// loadFactoryCapacity 工場の生産能力を取得（未設定の場合は標準作業時間が既定値の生産能力）
+func loadFactoryCapacity(ctx context.Context, capacityRepo repository.FactoryCapacityRepository, tenantID string) (*domain.FactoryCapacity, error) {
+	capacity := &domain.FactoryCapacity{TenantID: tenantID}
+	if capacityRepo == nil {
+		return capacity, nil
+	}
+	configured, err := capacityRepo.Get(ctx, tenantID)
+	if err != nil && !strings.Contains(err.Error(), "not found") {
+		return nil, err
+	}
+	if configured != nil {
+		capacity = configured
+	}
+	return capacity, nil
+}
+
+// createProductionTaskInTx トランザクション内で製造タスクを作成
+func createProductionTaskInTx(ctx context.Context, tx *sql.Tx, task *domain.ProductionTask) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO production_tasks (
+			id, tenant_id, order_id, garment_type, process, status, assigned_to,
+			standard_minutes, worked_seconds, inspection_id, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`,
+		task.ID,
+		task.TenantID,
+		task.OrderID,
+		string(task.GarmentType),
+		string(task.Process),
+		string(task.Status),
+		sql.NullString{String: task.AssignedTo, Valid: task.AssignedTo != ""},
+		task.StandardMinutes,
+		task.WorkedSeconds,
+		sql.NullString{String: task.InspectionID, Valid: task.InspectionID != ""},
+		task.CreatedAt,
+		task.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create production task: %w", err)
+	}
+	return nil
+}
+
+// updateProductionTaskInTx トランザクション内で製造タスクを更新
+// 取得時のステータス（expected）から変わっている場合は更新しない
+func updateProductionTaskInTx(ctx context.Context, tx *sql.Tx, task *domain.ProductionTask, expected domain.ProductionTaskStatus) error {
+	result, err := tx.ExecContext(ctx, `
+		UPDATE production_tasks SET
+			status = $4,
+			assigned_to = $5,
+			worked_seconds = $6,
+			started_at = $7,
+			resumed_at = $8,
+			completed_at = $9,
+			updated_at = $10
+		WHERE id = $1 AND tenant_id = $2 AND status = $3
+	`,
+		task.ID,
+		task.TenantID,
+		string(expected),
+		string(task.Status),
+		task.AssignedTo,
+		task.WorkedSeconds,
+		task.StartedAt,
+		task.ResumedAt,
+		task.CompletedAt,
+		task.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update production task: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("invalid task status: task was modified by another request")
+	}
+	return nil
+}
+
+// countOpenTasksInTx トランザクション内で注文・工程の未完了タスク数を取得
+func (s *ProductionTaskService) countOpenTasksInTx(ctx context.Context, tx *sql.Tx, orderID, tenantID string, process domain.ProductionProcess) (int, error) {
+	var count int
+	err := tx.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM production_tasks
+		WHERE order_id = $1 AND tenant_id = $2 AND process = $3 AND status <> 'COMPLETED'
+	`, orderID, tenantID, string(process)).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count open production tasks: %w", err)
+	}
+	return count, nil
+}
+
+// toJSON 監査ログ用にJSON文字列に変換
+func (s *ProductionTaskService) toJSON(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf(`{"error": "failed to marshal: %v"}`, err)
+	}
+	return string(data)
+}
+
+// canOperateTask 作業者がタスクを操作できるか
+// 作業者（Worker）は自分の担当または未割り当てのタスクのみ、オーナー・工場長は全タスクを操作できる
+func canOperateTask(task *domain.ProductionTask, workerID string, role domain.UserRole) error {
+	if role != domain.RoleWorker {
+		return nil
+	}
+	if task.AssignedTo != "" && task.AssignedTo != workerID {
+		return fmt.Errorf("unauthorized: task is assigned to another worker")
+	}
+	return nil
+}
+
+// checkPreviousProcessesCompleted 前の工程のタスクがすべて完了しているか
+func checkPreviousProcessesCompleted(orderTasks []*domain.ProductionTask, process domain.ProductionProcess) error {
+	for _, previous := range domain.ProductionProcesses {
+		if previous == process {
+			return nil
+		}
+		for _, task := range orderTasks {
+			if task.Process == previous && task.Status != domain.ProductionTaskStatusCompleted {
+				return fmt.Errorf("invalid task status: previous process %s is not completed", previous)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"tailor-cloud/backend/internal/config/domain"
+)
+
+// TestProductionTaskTimeTracking 開始・中断・再開・完了での作業時間の積算のテスト
+func TestProductionTaskTimeTracking(t *testing.T) {
+	task := domain.NewProductionTask("tenant-1", "order-1", domain.GarmentTypeSuit, domain.ProductionProcessSewing, 1200)
+	start := time.Date(2026, 10, 19, 9, 0, 0, 0, time.Local)
+
+	if err := task.Start("worker-1", start); err != nil {
+		t.Fatalf("Start returned error: %v", err)
+	}
+	if task.AssignedTo != "worker-1" || task.Status != domain.ProductionTaskStatusInProgress {
+		t.Errorf("Expected in-progress task assigned to worker-1, got %+v", task)
+	}
+	if got := task.WorkedMinutes(start.Add(30 * time.Minute)); got != 30 {
+		t.Errorf("Expected 30 minutes while in progress, got %d", got)
+	}
+
+	// 9:00〜12:00 作業、12:00〜13:00 中断、13:00〜15:30 作業
+	if err := task.Pause(start.Add(3 * time.Hour)); err != nil {
+		t.Fatalf("Pause returned error: %v", err)
+	}
+	if err := task.Pause(start.Add(3 * time.Hour)); err == nil {
+		t.Error("Expected error when pausing a paused task")
+	}
+	if got := task.WorkedMinutes(start.Add(4 * time.Hour)); got != 180 {
+		t.Errorf("Expected paused time to be excluded, got %d", got)
+	}
+	if err := task.Start("worker-1", start.Add(4*time.Hour)); err != nil {
+		t.Fatalf("Resume returned error: %v", err)
+	}
+	if err := task.Complete(start.Add(6*time.Hour + 30*time.Minute)); err != nil {
+		t.Fatalf("Complete returned error: %v", err)
+	}
+
+	if task.WorkedSeconds != 330*60 {
+		t.Errorf("Expected 330 minutes worked, got %d seconds", task.WorkedSeconds)
+	}
+	if !task.StartedAt.Equal(start) || task.ResumedAt != nil || task.CompletedAt == nil {
+		t.Errorf("Unexpected timestamps: started=%v resumed=%v completed=%v", task.StartedAt, task.ResumedAt, task.CompletedAt)
+	}
+	if err := task.Start("worker-1", start.Add(7*time.Hour)); err == nil {
+		t.Error("Expected error when starting a completed task")
+	}
+}
+
+// TestCheckPreviousProcessesCompleted 前工程の完了チェックのテスト
+func TestCheckPreviousProcessesCompleted(t *testing.T) {
+	cutting := domain.NewProductionTask("tenant-1", "order-1", domain.GarmentTypeSuit, domain.ProductionProcessCutting, 90)
+	sewing := domain.NewProductionTask("tenant-1", "order-1", domain.GarmentTypeSuit, domain.ProductionProcessSewing, 1200)
+	tasks := []*domain.ProductionTask{cutting, sewing}
+
+	if err := checkPreviousProcessesCompleted(tasks, domain.ProductionProcessCutting); err != nil {
+		t.Errorf("Expected cutting to be startable, got %v", err)
+	}
+	err := checkPreviousProcessesCompleted(tasks, domain.ProductionProcessSewing)
+	if err == nil || !strings.Contains(err.Error(), "CUTTING") {
+		t.Errorf("Expected cutting not completed error, got %v", err)
+	}
+
+	cutting.Status = domain.ProductionTaskStatusCompleted
+	if err := checkPreviousProcessesCompleted(tasks, domain.ProductionProcessInspection); err == nil {
+		t.Error("Expected sewing not completed error")
+	}
+	sewing.Status = domain.ProductionTaskStatusCompleted
+	if err := checkPreviousProcessesCompleted(tasks, domain.ProductionProcessInspection); err != nil {
+		t.Errorf("Expected inspection to be startable, got %v", err)
+	}
+}
+
+// TestCanOperateTask 担当者による操作権限のテスト
+func TestCanOperateTask(t *testing.T) {
+	task := domain.NewProductionTask("tenant-1", "order-1", domain.GarmentTypeSuit, domain.ProductionProcessCutting, 90)
+
+	// 未割り当てのタスクは作業者が開始できる
+	if err := canOperateTask(task, "worker-1", domain.RoleWorker); err != nil {
+		t.Errorf("Expected unassigned task to be operable, got %v", err)
+	}
+	task.AssignedTo = "worker-2"
+	if err := canOperateTask(task, "worker-1", domain.RoleWorker); err == nil || !strings.Contains(err.Error(), "unauthorized") {
+		t.Errorf("Expected unauthorized error, got %v", err)
+	}
+	// 工場長は他の作業者のタスクも操作できる
+	if err := canOperateTask(task, "manager-1", domain.RoleFactoryManager); err != nil {
+		t.Errorf("Expected factory manager to operate any task, got %v", err)
+	}
+}
+
+// TestOrderStatusAdvance 工程と注文ステータスの対応のテスト
+func TestOrderStatusAdvance(t *testing.T) {
+	tests := []struct {
+		process domain.ProductionProcess
+		start   domain.OrderStatus
+		after   domain.OrderStatus
+	}{
+		{domain.ProductionProcessCutting, domain.OrderStatusCutting, domain.OrderStatusSewing},
+		{domain.ProductionProcessSewing, domain.OrderStatusSewing, domain.OrderStatusInspection},
+		// 検品の完了後は出荷登録まで検品中のまま
+		{domain.ProductionProcessInspection, domain.OrderStatusInspection, domain.OrderStatusInspection},
+	}
+	for _, tt := range tests {
+		if got := tt.process.OrderStatus(); got != tt.start {
+			t.Errorf("%s: expected start status %s, got %s", tt.process, tt.start, got)
+		}
+		if got := domain.NextOrderStatusAfter(tt.process); got != tt.after {
+			t.Errorf("%s: expected status after completion %s, got %s", tt.process, tt.after, got)
+		}
+	}
+
+	if domain.ProductionStatusRank(domain.OrderStatusMaterialSecured) >= domain.ProductionStatusRank(domain.OrderStatusCutting) {
+		t.Error("Expected Material_Secured to precede Cutting")
+	}
+	if domain.ProductionStatusRank(domain.OrderStatusShipped) != -1 {
+		t.Error("Expected Shipped to be outside production")
+	}
+}
+
+// TestSummarizeWorkerThroughput 作業者別の実績集計のテスト
+func TestSummarizeWorkerThroughput(t *testing.T) {
+	completed := func(worker string, process domain.ProductionProcess, standard int, workedMinutes int64) *domain.ProductionTask {
+		task := domain.NewProductionTask("tenant-1", "order-1", domain.GarmentTypeSuit, process, standard)
+		task.AssignedTo = worker
+		task.Status = domain.ProductionTaskStatusCompleted
+		task.WorkedSeconds = workedMinutes * 60
+		return task
+	}
+	inProgress := completed("worker-2", domain.ProductionProcessSewing, 1200, 600)
+	inProgress.Status = domain.ProductionTaskStatusInProgress
+
+	workers := domain.SummarizeWorkerThroughput([]*domain.ProductionTask{
+		completed("worker-1", domain.ProductionProcessCutting, 90, 100),
+		completed("worker-2", domain.ProductionProcessSewing, 1200, 1000),
+		completed("worker-1", domain.ProductionProcessInspection, 30, 20),
+		inProgress, // 未完了は集計対象外
+	})
+
+	if len(workers) != 2 || workers[0].WorkerID != "worker-1" {
+		t.Fatalf("Expected worker-1 first, got %+v", workers)
+	}
+	w1 := workers[0]
+	if w1.CompletedTasks != 2 || w1.WorkedMinutes != 120 || w1.StandardMinutes != 120 || w1.EfficiencyPercent != 100 {
+		t.Errorf("Unexpected worker-1 throughput: %+v", w1)
+	}
+	if w1.ByProcess[domain.ProductionProcessCutting] != 1 || w1.ByProcess[domain.ProductionProcessInspection] != 1 {
+		t.Errorf("Unexpected worker-1 by_process: %v", w1.ByProcess)
+	}
+	if workers[1].EfficiencyPercent != 120 {
+		t.Errorf("Expected worker-2 efficiency 120%%, got %d", workers[1].EfficiencyPercent)
+	}
+}
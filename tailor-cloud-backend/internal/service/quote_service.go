@@ -0,0 +1,699 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/jung-kurt/gofpdf/v2"
+
+	"tailor-cloud/backend/internal/config/domain"
+	"tailor-cloud/backend/internal/repository"
+)
+
+// QuoteService 見積書サービス
+// 見積の作成・改訂・顧客承諾・注文（Draft）への変換を管理
+type QuoteService struct {
+	quoteRepo    repository.QuoteRepository
+	fabricRepo   repository.FabricRepository
+	customerRepo repository.CustomerRepository
+	tenantRepo   repository.TenantRepository
+	orderService *OrderService
+	jpFontHelper *JPFontHelper // 日本語フォントヘルパー
+	db           *sql.DB       // トランザクション管理用（改訂時の新バージョン作成と旧バージョンの置き換え）
+}
+
+// NewQuoteService QuoteServiceのコンストラクタ
+func NewQuoteService(
+	quoteRepo repository.QuoteRepository,
+	fabricRepo repository.FabricRepository,
+	customerRepo repository.CustomerRepository,
+	tenantRepo repository.TenantRepository,
+	orderService *OrderService,
+	db *sql.DB,
+) *QuoteService {
+	return &QuoteService{
+		quoteRepo:    quoteRepo,
+		fabricRepo:   fabricRepo,
+		customerRepo: customerRepo,
+		tenantRepo:   tenantRepo,
+		orderService: orderService,
+		jpFontHelper: NewJPFontHelper(GetFontDir()),
+		db:           db,
+	}
+}
+
+// QuoteLineInput 見積明細の入力（仕立て代・オプション・その他）
+// 生地代は fabric_length と生地単価から自動計算する
+type QuoteLineInput struct {
+	LineType    domain.QuoteLineType `json:"line_type"`
+	Code        string               `json:"code"`
+	Description string               `json:"description"`
+	Quantity    float64              `json:"quantity"`
+	UnitPrice   int64                `json:"unit_price"` // 税抜単価（円）
+}
+
+// CreateQuoteRequest 見積作成リクエスト
+type CreateQuoteRequest struct {
+	TenantID     string               `json:"tenant_id"`
+	CustomerID   string               `json:"customer_id"`
+	FabricID     string               `json:"fabric_id"`
+	FabricLength float64              `json:"fabric_length"` // 用尺（メートル）
+	PlanType     domain.PlanType      `json:"plan_type"`
+	Lines        []QuoteLineInput     `json:"lines"`
+	Details      *domain.OrderDetails `json:"details"`
+	DeliveryDate *time.Time           `json:"delivery_date"`
+	Notes        string               `json:"notes"`
+	CreatedBy    string               `json:"created_by"`
+}
+
+// ReviseQuoteRequest 見積改訂リクエスト（明細・用尺・採寸データを差し替えて新バージョンを作成）
+type ReviseQuoteRequest struct {
+	QuoteID      string               `json:"-"`
+	TenantID     string               `json:"-"`
+	FabricID     string               `json:"fabric_id"` // 空の場合は前バージョンを引き継ぐ
+	FabricLength float64              `json:"fabric_length"`
+	PlanType     domain.PlanType      `json:"plan_type"`
+	Lines        []QuoteLineInput     `json:"lines"`
+	Details      *domain.OrderDetails `json:"details"` // nilの場合は前バージョンを引き継ぐ
+	DeliveryDate *time.Time           `json:"delivery_date"`
+	Notes        string               `json:"notes"`
+	CreatedBy    string               `json:"created_by"`
+}
+
+// ConvertQuoteRequest 見積→注文変換リクエスト
+type ConvertQuoteRequest struct {
+	QuoteID      string     `json:"-"`
+	TenantID     string     `json:"-"`
+	DeliveryDate *time.Time `json:"delivery_date"` // 空の場合は見積の希望納期
+	CreatedBy    string     `json:"created_by"`
+	IPAddress    string     `json:"-"`
+	UserAgent    string     `json:"-"`
+}
+
+// ConvertQuoteResponse 見積→注文変換レスポンス
+type ConvertQuoteResponse struct {
+	Quote *domain.Quote `json:"quote"`
+	Order *domain.Order `json:"order"`
+}
+
+// QuoteConversionReport 見積→注文の変換率レポート
+// 改訂された見積は最新バージョンのみを1件として数える
+type QuoteConversionReport struct {
+	From            time.Time `json:"from"`
+	To              time.Time `json:"to"`
+	TotalQuotes     int       `json:"total_quotes"`
+	OpenQuotes      int       `json:"open_quotes"`
+	AcceptedQuotes  int       `json:"accepted_quotes"` // 承諾済み（未変換）
+	ConvertedQuotes int       `json:"converted_quotes"`
+	RejectedQuotes  int       `json:"rejected_quotes"`
+	ExpiredQuotes   int       `json:"expired_quotes"`
+	ConversionRate  float64   `json:"conversion_rate"`  // 変換数 / 見積数
+	QuotedAmount    int64     `json:"quoted_amount"`    // 見積総額（税抜）
+	ConvertedAmount int64     `json:"converted_amount"` // 注文化された見積額（税抜）
+}
+
+// CreateQuote 見積書を作成（初版・Draftステータス）
+func (s *QuoteService) CreateQuote(ctx context.Context, req *CreateQuoteRequest) (*domain.Quote, error) {
+	if req.TenantID == "" {
+		return nil, fmt.Errorf("tenant_id is required")
+	}
+	if req.CustomerID == "" {
+		return nil, fmt.Errorf("customer_id is required")
+	}
+	if req.FabricID == "" {
+		return nil, fmt.Errorf("fabric_id is required")
+	}
+	if req.CreatedBy == "" {
+		return nil, fmt.Errorf("created_by is required")
+	}
+	if req.PlanType != "" && !req.PlanType.IsValid() {
+		return nil, fmt.Errorf("invalid plan_type: %s", req.PlanType)
+	}
+
+	if s.customerRepo != nil {
+		if _, err := s.customerRepo.GetByID(ctx, req.CustomerID, req.TenantID); err != nil {
+			return nil, fmt.Errorf("customer not found: %w", err)
+		}
+	}
+
+	quote := domain.NewQuote(req.TenantID, req.CustomerID, req.FabricID, req.CreatedBy)
+	quote.PlanType = req.PlanType
+	quote.DeliveryDate = req.DeliveryDate
+	quote.Notes = req.Notes
+	quote.Details = req.Details
+	if quote.Details == nil {
+		quote.Details = &domain.OrderDetails{}
+	}
+
+	if err := s.buildLines(ctx, quote, req.FabricLength, req.Lines); err != nil {
+		return nil, err
+	}
+
+	if err := s.quoteRepo.Create(ctx, quote); err != nil {
+		return nil, fmt.Errorf("failed to create quote: %w", err)
+	}
+
+	return quote, nil
+}
+
+// ReviseQuote 見積書を改訂（新バージョンを作成し、旧バージョンは Superseded にする）
+func (s *QuoteService) ReviseQuote(ctx context.Context, req *ReviseQuoteRequest) (*domain.Quote, error) {
+	if req.CreatedBy == "" {
+		return nil, fmt.Errorf("created_by is required")
+	}
+	if req.PlanType != "" && !req.PlanType.IsValid() {
+		return nil, fmt.Errorf("invalid plan_type: %s", req.PlanType)
+	}
+
+	current, err := s.quoteRepo.GetByID(ctx, req.QuoteID, req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	switch current.Status {
+	case domain.QuoteStatusSuperseded:
+		return nil, fmt.Errorf("invalid quote status: only the latest version can be revised")
+	case domain.QuoteStatusAccepted, domain.QuoteStatusConverted:
+		return nil, fmt.Errorf("invalid quote status: %s quote cannot be revised", current.Status)
+	}
+
+	revision := current.NewRevision(req.CreatedBy)
+	if req.FabricID != "" {
+		revision.FabricID = req.FabricID
+	}
+	if req.PlanType != "" {
+		revision.PlanType = req.PlanType
+	}
+	if req.Details != nil {
+		revision.Details = req.Details
+	}
+	if req.DeliveryDate != nil {
+		revision.DeliveryDate = req.DeliveryDate
+	}
+	if req.Notes != "" {
+		revision.Notes = req.Notes
+	}
+
+	if err := s.buildLines(ctx, revision, req.FabricLength, req.Lines); err != nil {
+		return nil, err
+	}
+
+	// 旧バージョンの置き換え（改訂時のステータスのままの場合のみ）と新バージョンの作成を単一トランザクションで保存
+	// 同時に改訂・承諾された場合は新バージョンを作成しない
+	if s.db == nil {
+		return nil, fmt.Errorf("failed to create quote revision: database is not available")
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	previousStatus := current.Status
+	current.Status = domain.QuoteStatusSuperseded
+	current.UpdatedAt = time.Now()
+	if err := s.quoteRepo.UpdateInTx(ctx, tx, current, previousStatus); err != nil {
+		return nil, fmt.Errorf("failed to supersede previous quote version: %w", err)
+	}
+	if err := s.quoteRepo.CreateInTx(ctx, tx, revision); err != nil {
+		return nil, fmt.Errorf("failed to create quote revision: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return revision, nil
+}
+
+// buildLines 見積明細を組み立てる（生地代は生地単価 × 用尺で計算）
+func (s *QuoteService) buildLines(ctx context.Context, quote *domain.Quote, fabricLength float64, inputs []QuoteLineInput) error {
+	if fabricLength < 0 {
+		return fmt.Errorf("invalid fabric_length: must not be negative")
+	}
+
+	quote.Lines = nil
+	quote.TotalAmount = 0
+	quote.FabricLength = fabricLength
+
+	for i, input := range inputs {
+		if !input.LineType.IsValid() || input.LineType == domain.QuoteLineTypeFabric {
+			return fmt.Errorf("invalid line_type at line %d: %s", i+1, input.LineType)
+		}
+		if input.Description == "" {
+			return fmt.Errorf("description is required at line %d", i+1)
+		}
+		if input.Quantity <= 0 {
+			return fmt.Errorf("invalid quantity at line %d: must be greater than 0", i+1)
+		}
+		if input.UnitPrice < 0 {
+			return fmt.Errorf("invalid unit_price at line %d: must not be negative", i+1)
+		}
+		quote.AddLine(&domain.QuoteLine{
+			LineType:    input.LineType,
+			Code:        input.Code,
+			Description: input.Description,
+			Quantity:    input.Quantity,
+			UnitPrice:   input.UnitPrice,
+			Amount:      quoteLineAmount(input.Quantity, input.UnitPrice),
+		})
+	}
+
+	if fabricLength > 0 && s.fabricRepo != nil {
+		fabric, err := s.fabricRepo.GetByID(ctx, quote.FabricID)
+		if err != nil {
+			return fmt.Errorf("fabric not found: %w", err)
+		}
+		quote.AddLine(&domain.QuoteLine{
+			LineType:    domain.QuoteLineTypeFabric,
+			Code:        fabric.ID,
+			Description: fmt.Sprintf("生地代（%s）", fabric.Name),
+			Quantity:    fabricLength,
+			UnitPrice:   fabric.Price,
+			Amount:      quoteLineAmount(fabricLength, fabric.Price),
+		})
+	}
+
+	if len(quote.Lines) == 0 {
+		return fmt.Errorf("lines are required")
+	}
+	if quote.TotalAmount <= 0 {
+		return fmt.Errorf("invalid total_amount: must be greater than 0")
+	}
+
+	return nil
+}
+
+// quoteLineAmount 明細金額（数量 × 単価、円未満四捨五入）
+func quoteLineAmount(quantity float64, unitPrice int64) int64 {
+	return int64(math.Round(quantity * float64(unitPrice)))
+}
+
+// GetQuote 見積書を取得（有効期限切れの場合はステータスを更新）
+func (s *QuoteService) GetQuote(ctx context.Context, quoteID string, tenantID string) (*domain.Quote, error) {
+	quote, err := s.quoteRepo.GetByID(ctx, quoteID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	s.markExpired(ctx, quote, time.Now())
+	return quote, nil
+}
+
+// ListQuotes 見積書一覧を取得（有効期限切れの見積はステータスを Expired に更新）
+func (s *QuoteService) ListQuotes(ctx context.Context, tenantID string, status domain.QuoteStatus) ([]*domain.Quote, error) {
+	if tenantID == "" {
+		return nil, fmt.Errorf("tenant_id is required")
+	}
+	if status != "" && !status.IsValid() {
+		return nil, fmt.Errorf("invalid status: %s", status)
+	}
+
+	quotes, err := s.quoteRepo.GetByTenantID(ctx, tenantID, status)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	result := make([]*domain.Quote, 0, len(quotes))
+	for _, quote := range quotes {
+		s.markExpired(ctx, quote, now)
+		// 回答待ちで絞り込んだ場合、期限切れになった見積は除外
+		if status != "" && quote.Status != status {
+			continue
+		}
+		result = append(result, quote)
+	}
+
+	return result, nil
+}
+
+// GetQuoteVersions 見積書の全バージョンを取得
+func (s *QuoteService) GetQuoteVersions(ctx context.Context, quoteID string, tenantID string) ([]*domain.Quote, error) {
+	quote, err := s.quoteRepo.GetByID(ctx, quoteID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return s.quoteRepo.GetVersions(ctx, quote.QuoteGroupID, tenantID)
+}
+
+// markExpired 回答待ちの見積が有効期限を過ぎていれば Expired に更新（エラー時も継続）
+// 取得後に別のリクエストで承諾・改訂された場合は更新せず、最新の見積を返す
+func (s *QuoteService) markExpired(ctx context.Context, quote *domain.Quote, now time.Time) {
+	if !quote.IsExpired(now) {
+		return
+	}
+	previousStatus, previousUpdatedAt := quote.Status, quote.UpdatedAt
+	quote.Status = domain.QuoteStatusExpired
+	quote.UpdatedAt = now
+	if err := s.quoteRepo.Update(ctx, quote, previousStatus); err != nil {
+		fmt.Printf("WARNING: Failed to mark quote %s as expired: %v\n", quote.ID, err)
+		if latest, getErr := s.quoteRepo.GetByID(ctx, quote.ID, quote.TenantID); getErr == nil {
+			*quote = *latest
+		} else {
+			quote.Status, quote.UpdatedAt = previousStatus, previousUpdatedAt
+		}
+	}
+}
+
+// SendQuote 見積書を顧客へ提示（Draft → Sent）
+func (s *QuoteService) SendQuote(ctx context.Context, quoteID string, tenantID string) (*domain.Quote, error) {
+	quote, err := s.GetQuote(ctx, quoteID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if quote.Status != domain.QuoteStatusDraft {
+		return nil, fmt.Errorf("invalid quote status: %s (expected %s)", quote.Status, domain.QuoteStatusDraft)
+	}
+
+	quote.Status = domain.QuoteStatusSent
+	quote.UpdatedAt = time.Now()
+	if err := s.quoteRepo.Update(ctx, quote, domain.QuoteStatusDraft); err != nil {
+		return nil, err
+	}
+	return quote, nil
+}
+
+// AcceptQuote 顧客の承諾を記録（有効期限内の最新バージョンのみ）
+func (s *QuoteService) AcceptQuote(ctx context.Context, quoteID string, tenantID string) (*domain.Quote, error) {
+	quote, err := s.GetQuote(ctx, quoteID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if quote.Status == domain.QuoteStatusExpired {
+		return nil, fmt.Errorf("invalid quote status: quote expired on %s", quote.ValidUntil.Format("2006-01-02"))
+	}
+	if !quote.IsOpen() {
+		return nil, fmt.Errorf("invalid quote status: %s quote cannot be accepted", quote.Status)
+	}
+
+	previousStatus := quote.Status
+	now := time.Now()
+	quote.Status = domain.QuoteStatusAccepted
+	quote.AcceptedAt = &now
+	quote.UpdatedAt = now
+	if err := s.quoteRepo.Update(ctx, quote, previousStatus); err != nil {
+		return nil, err
+	}
+	return quote, nil
+}
+
+// RejectQuote 顧客の辞退を記録
+func (s *QuoteService) RejectQuote(ctx context.Context, quoteID string, tenantID string) (*domain.Quote, error) {
+	quote, err := s.GetQuote(ctx, quoteID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if !quote.IsOpen() && quote.Status != domain.QuoteStatusAccepted {
+		return nil, fmt.Errorf("invalid quote status: %s quote cannot be rejected", quote.Status)
+	}
+
+	previousStatus := quote.Status
+	quote.Status = domain.QuoteStatusRejected
+	quote.UpdatedAt = time.Now()
+	if err := s.quoteRepo.Update(ctx, quote, previousStatus); err != nil {
+		return nil, err
+	}
+	return quote, nil
+}
+
+// ConvertToOrder 承諾済みの見積書を注文（Draft）に変換
+// 採寸データ・補正情報・見積金額（税抜）をそのまま注文へ引き継ぐ
+func (s *QuoteService) ConvertToOrder(ctx context.Context, req *ConvertQuoteRequest) (*ConvertQuoteResponse, error) {
+	if req.CreatedBy == "" {
+		return nil, fmt.Errorf("created_by is required")
+	}
+	if s.orderService == nil {
+		return nil, fmt.Errorf("order service is not configured")
+	}
+
+	quote, err := s.quoteRepo.GetByID(ctx, req.QuoteID, req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if quote.Status == domain.QuoteStatusConverted {
+		return nil, fmt.Errorf("invalid quote status: already converted to order %s", quote.ConvertedOrderID)
+	}
+	if quote.Status != domain.QuoteStatusAccepted {
+		return nil, fmt.Errorf("invalid quote status: %s (expected %s)", quote.Status, domain.QuoteStatusAccepted)
+	}
+
+	deliveryDate := quote.DeliveryDate
+	if req.DeliveryDate != nil {
+		deliveryDate = req.DeliveryDate
+	}
+	if deliveryDate == nil {
+		return nil, fmt.Errorf("delivery_date is required")
+	}
+
+	details := &domain.OrderDetails{}
+	if quote.Details != nil {
+		*details = *quote.Details
+	}
+	if details.Description == "" {
+		details.Description = quoteOrderDescription(quote)
+	}
+	if len(details.Adjustments) > 0 {
+		adjustments, err := upgradeStoredOrderAdjustments(details.Adjustments)
+		if err != nil {
+			return nil, err
+		}
+		details.Adjustments = adjustments
+	}
+
+	order, err := s.orderService.CreateOrder(ctx, &CreateOrderRequest{
+		TenantID:     quote.TenantID,
+		CustomerID:   quote.CustomerID,
+		FabricID:     quote.FabricID,
+		TotalAmount:  quote.TotalAmount,
+		DeliveryDate: *deliveryDate,
+		Details:      details,
+		CreatedBy:    req.CreatedBy,
+		IPAddress:    req.IPAddress,
+		UserAgent:    req.UserAgent,
+		// 見積の承諾金額で注文する（価格表との照合は行わない）
+		PlanType:        quote.PlanType,
+		FabricLength:    quote.FabricLength,
+		AcceptedQuoteID: quote.ID,
+		// 見積を変換済みにする（承諾済みのままの場合のみ）。同時に変換された場合は注文を作成しない
+		SaveInTx: func(ctx context.Context, tx *sql.Tx, order *domain.Order) error {
+			converted := *quote
+			converted.Status = domain.QuoteStatusConverted
+			converted.ConvertedOrderID = order.ID
+			converted.UpdatedAt = time.Now()
+			if err := s.quoteRepo.UpdateInTx(ctx, tx, &converted, domain.QuoteStatusAccepted); err != nil {
+				return fmt.Errorf("failed to mark quote as converted: %w", err)
+			}
+			*quote = converted
+			return nil
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ConvertQuoteResponse{Quote: quote, Order: order}, nil
+}
+
+// quoteOrderDescription 見積明細から給付の内容を作成
+func quoteOrderDescription(quote *domain.Quote) string {
+	for _, line := range quote.Lines {
+		if line.LineType == domain.QuoteLineTypeBase {
+			return line.Description
+		}
+	}
+	if quote.PlanType != "" {
+		return fmt.Sprintf("オーダースーツ（%s）", quote.PlanType)
+	}
+	return defaultInvoiceLineDescription
+}
+
+// GetConversionReport 期間内に作成された見積の変換率レポートを取得
+func (s *QuoteService) GetConversionReport(ctx context.Context, tenantID string, from, to time.Time) (*QuoteConversionReport, error) {
+	if tenantID == "" {
+		return nil, fmt.Errorf("tenant_id is required")
+	}
+	if !to.After(from) {
+		return nil, fmt.Errorf("invalid period: to must be after from")
+	}
+
+	quotes, err := s.quoteRepo.GetByCreatedPeriod(ctx, tenantID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	report := SummarizeQuoteConversion(quotes, time.Now())
+	report.From = from
+	report.To = to
+	return report, nil
+}
+
+// SummarizeQuoteConversion 見積一覧から変換率を集計
+// 改訂により Superseded となった旧バージョンは集計対象外
+func SummarizeQuoteConversion(quotes []*domain.Quote, now time.Time) *QuoteConversionReport {
+	report := &QuoteConversionReport{}
+
+	for _, quote := range quotes {
+		if quote.Status == domain.QuoteStatusSuperseded {
+			continue
+		}
+
+		report.TotalQuotes++
+		report.QuotedAmount += quote.TotalAmount
+
+		switch {
+		case quote.Status == domain.QuoteStatusConverted:
+			report.ConvertedQuotes++
+			report.ConvertedAmount += quote.TotalAmount
+		case quote.Status == domain.QuoteStatusAccepted:
+			report.AcceptedQuotes++
+		case quote.Status == domain.QuoteStatusRejected:
+			report.RejectedQuotes++
+		case quote.Status == domain.QuoteStatusExpired || quote.IsExpired(now):
+			report.ExpiredQuotes++
+		default:
+			report.OpenQuotes++
+		}
+	}
+
+	if report.TotalQuotes > 0 {
+		report.ConversionRate = float64(report.ConvertedQuotes) / float64(report.TotalQuotes)
+	}
+
+	return report
+}
+
+// GenerateQuotePDF 見積書PDFを生成
+func (s *QuoteService) GenerateQuotePDF(ctx context.Context, quoteID string, tenantID string) ([]byte, error) {
+	quote, err := s.GetQuote(ctx, quoteID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	roundingMethod := domain.TaxRoundingMethodHalfUp
+	sellerName, sellerAddress, registrationNo := "", "", ""
+	fabricUnit := domain.FabricUnitMeter
+	if s.tenantRepo != nil {
+		tenant, err := s.tenantRepo.GetByID(ctx, tenantID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tenant: %w", err)
+		}
+		fabricUnit = domain.ResolveUnitPreference(tenant, nil).FabricUnit
+		sellerName = tenant.LegalName
+		sellerAddress = tenant.Address
+		registrationNo = tenant.InvoiceRegistrationNo
+		if tenant.TaxRoundingMethod != "" {
+			roundingMethod = tenant.TaxRoundingMethod
+		}
+	}
+
+	customerName := ""
+	if s.customerRepo != nil {
+		customer, err := s.customerRepo.GetByID(ctx, quote.CustomerID, tenantID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get customer: %w", err)
+		}
+		customerName = customer.Name
+	}
+
+	taxAmount := domain.CalculateTax(quote.TotalAmount, quote.TaxRate, roundingMethod)
+	totalAmount := quote.TotalAmount + taxAmount
+
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetTitle("御見積書", false)
+	pdf.SetAuthor("TailorCloud", false)
+	pdf.AddPage()
+
+	// 日本語フォントを登録
+	if err := s.jpFontHelper.RegisterJPFonts(pdf); err != nil {
+		// フォント登録に失敗した場合は警告を出して続行（英語フォントで代替）
+		fmt.Printf("WARNING: Failed to register Japanese fonts: %v\n", err)
+	}
+
+	// タイトル
+	s.jpFontHelper.SetJPFont(pdf, "B", 16)
+	pdf.CellFormat(190, 10, "御見積書", "", 1, "C", false, 0, "")
+	pdf.Ln(5)
+
+	// 見積番号・発行日・有効期限
+	s.jpFontHelper.SetJPFont(pdf, "", 9)
+	pdf.CellFormat(190, 5, fmt.Sprintf("見積番号: %s-%d", domain.InvoiceReference(quote.QuoteGroupID), quote.Version), "", 1, "R", false, 0, "")
+	pdf.CellFormat(190, 5, fmt.Sprintf("発行日: %s", quote.CreatedAt.Format("2006年01月02日")), "", 1, "R", false, 0, "")
+	pdf.CellFormat(190, 5, fmt.Sprintf("有効期限: %s", quote.ValidUntil.Format("2006年01月02日")), "", 1, "R", false, 0, "")
+	pdf.Ln(5)
+
+	// 宛名
+	s.jpFontHelper.SetJPFont(pdf, "B", 13)
+	pdf.CellFormat(120, 8, customerName+" 様", "B", 1, "L", false, 0, "")
+	pdf.Ln(3)
+
+	// 発行者
+	s.jpFontHelper.SetJPFont(pdf, "", 10)
+	if sellerName != "" {
+		pdf.CellFormat(190, 6, sellerName, "", 1, "R", false, 0, "")
+	}
+	if sellerAddress != "" {
+		pdf.CellFormat(190, 6, sellerAddress, "", 1, "R", false, 0, "")
+	}
+	if registrationNo != "" {
+		pdf.CellFormat(190, 6, fmt.Sprintf("登録番号: %s", registrationNo), "", 1, "R", false, 0, "")
+	}
+	pdf.Ln(5)
+
+	// 御見積金額
+	s.jpFontHelper.SetJPFont(pdf, "B", 13)
+	pdf.CellFormat(60, 10, "御見積金額（税込）", "1", 0, "C", false, 0, "")
+	pdf.CellFormat(70, 10, fmt.Sprintf("¥%s", formatCurrency(totalAmount)), "1", 1, "R", false, 0, "")
+	pdf.Ln(5)
+
+	// 明細
+	s.jpFontHelper.SetJPFont(pdf, "B", 10)
+	pdf.CellFormat(95, 7, "品目", "1", 0, "C", false, 0, "")
+	pdf.CellFormat(25, 7, "数量", "1", 0, "C", false, 0, "")
+	pdf.CellFormat(35, 7, "単価（税抜）", "1", 0, "C", false, 0, "")
+	pdf.CellFormat(35, 7, "金額（税抜）", "1", 1, "C", false, 0, "")
+
+	s.jpFontHelper.SetJPFont(pdf, "", 10)
+	for _, line := range quote.Lines {
+		quantity := fmt.Sprintf("%g", line.Quantity)
+		unitPrice := line.UnitPrice
+		if line.LineType == domain.QuoteLineTypeFabric {
+			// 生地の用尺・単価はテナントの生地の単位で表示（金額はメートルで計算した値のまま）
+			quantity = fmt.Sprintf("%g%s", fabricUnit.FromMeters(line.Quantity), fabricUnit)
+			unitPrice = fabricUnit.PriceFromMeter(line.UnitPrice)
+		}
+		pdf.CellFormat(95, 7, line.Description, "1", 0, "L", false, 0, "")
+		pdf.CellFormat(25, 7, quantity, "1", 0, "R", false, 0, "")
+		pdf.CellFormat(35, 7, formatCurrency(unitPrice), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(35, 7, formatCurrency(line.Amount), "1", 1, "R", false, 0, "")
+	}
+
+	// 合計
+	pdf.CellFormat(155, 7, "小計（税抜）", "1", 0, "R", false, 0, "")
+	pdf.CellFormat(35, 7, formatCurrency(quote.TotalAmount), "1", 1, "R", false, 0, "")
+	pdf.CellFormat(155, 7, fmt.Sprintf("消費税（%d%%）", int(math.Round(float64(quote.TaxRate)*100))), "1", 0, "R", false, 0, "")
+	pdf.CellFormat(35, 7, formatCurrency(taxAmount), "1", 1, "R", false, 0, "")
+	s.jpFontHelper.SetJPFont(pdf, "B", 10)
+	pdf.CellFormat(155, 7, "合計（税込）", "1", 0, "R", false, 0, "")
+	pdf.CellFormat(35, 7, formatCurrency(totalAmount), "1", 1, "R", false, 0, "")
+	pdf.Ln(5)
+
+	// 備考
+	s.jpFontHelper.SetJPFont(pdf, "", 10)
+	if quote.DeliveryDate != nil {
+		pdf.CellFormat(190, 6, fmt.Sprintf("希望納期: %s", quote.DeliveryDate.Format("2006年01月02日")), "", 1, "L", false, 0, "")
+	}
+	if quote.Notes != "" {
+		pdf.MultiCell(190, 6, fmt.Sprintf("備考: %s", quote.Notes), "", "L", false)
+	}
+	pdf.CellFormat(190, 6, fmt.Sprintf("本見積書の有効期限は発行日から%d日間です。", domain.QuoteValidityDays), "", 1, "L", false, 0, "")
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("failed to output PDF: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
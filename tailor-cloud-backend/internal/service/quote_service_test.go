@@ -0,0 +1,72 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"tailor-cloud/backend/internal/config/domain"
+)
+
+// TestSummarizeQuoteConversion 旧バージョンを除外した変換率集計のテスト
+func TestSummarizeQuoteConversion(t *testing.T) {
+	now := time.Date(2025, 6, 30, 12, 0, 0, 0, time.Local)
+
+	newQuote := func(status domain.QuoteStatus, amount int64, validUntil time.Time) *domain.Quote {
+		return &domain.Quote{Status: status, TotalAmount: amount, ValidUntil: validUntil}
+	}
+	future := now.AddDate(0, 0, 10)
+	past := now.AddDate(0, 0, -1)
+
+	quotes := []*domain.Quote{
+		newQuote(domain.QuoteStatusSuperseded, 100000, future), // 改訂済みの旧版は対象外
+		newQuote(domain.QuoteStatusConverted, 120000, future),
+		newQuote(domain.QuoteStatusConverted, 80000, past),
+		newQuote(domain.QuoteStatusSent, 90000, future),
+		newQuote(domain.QuoteStatusSent, 70000, past), // 期限切れ（未更新）
+		newQuote(domain.QuoteStatusRejected, 60000, future),
+	}
+
+	report := SummarizeQuoteConversion(quotes, now)
+
+	if report.TotalQuotes != 5 {
+		t.Errorf("Expected 5 quotes, got %d", report.TotalQuotes)
+	}
+	if report.ConvertedQuotes != 2 || report.ConvertedAmount != 200000 {
+		t.Errorf("Expected 2 converted quotes (200000), got %d (%d)", report.ConvertedQuotes, report.ConvertedAmount)
+	}
+	if report.OpenQuotes != 1 || report.ExpiredQuotes != 1 || report.RejectedQuotes != 1 {
+		t.Errorf("Unexpected breakdown: open=%d expired=%d rejected=%d", report.OpenQuotes, report.ExpiredQuotes, report.RejectedQuotes)
+	}
+	if report.QuotedAmount != 420000 {
+		t.Errorf("Expected quoted amount 420000, got %d", report.QuotedAmount)
+	}
+	if report.ConversionRate != 0.4 {
+		t.Errorf("Expected conversion rate 0.4, got %f", report.ConversionRate)
+	}
+}
+
+// TestQuoteNewRevision 改訂版のバージョン・有効期限のテスト
+func TestQuoteNewRevision(t *testing.T) {
+	quote := domain.NewQuote("tenant-1", "customer-1", "fabric-1", "user-1")
+	quote.AddLine(&domain.QuoteLine{LineType: domain.QuoteLineTypeBase, Description: "仕立て代", Quantity: 1, UnitPrice: 50000, Amount: 50000})
+	quote.Status = domain.QuoteStatusSent
+	quote.ValidUntil = time.Now().AddDate(0, 0, -1)
+
+	if !quote.IsExpired(time.Now()) {
+		t.Fatalf("Expected quote to be expired")
+	}
+
+	revision := quote.NewRevision("user-2")
+	if revision.ID == quote.ID || revision.QuoteGroupID != quote.QuoteGroupID {
+		t.Errorf("Revision must have a new ID within the same quote group")
+	}
+	if revision.Version != 2 || revision.Status != domain.QuoteStatusDraft {
+		t.Errorf("Expected version 2 Draft, got version %d %s", revision.Version, revision.Status)
+	}
+	if revision.IsExpired(time.Now()) {
+		t.Errorf("Revision must have a fresh validity period")
+	}
+	if len(revision.Lines) != 0 || revision.TotalAmount != 0 {
+		t.Errorf("Revision lines must be rebuilt, got %d lines (total %d)", len(revision.Lines), revision.TotalAmount)
+	}
+}
@@ -0,0 +1,721 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jung-kurt/gofpdf/v2"
+	"tailor-cloud/backend/internal/config/domain"
+	"tailor-cloud/backend/internal/repository"
+)
+
+// ShipmentService 出荷管理サービス
+// 検品済みの注文の出荷を記録して注文を発送済みにし、配送業者の追跡情報で配達完了を確認して納品完了にする。
+// 配達完了時は実際の配達日から支払期日（下請法60日ルール）を見直す
+type ShipmentService struct {
+	shipmentRepo   repository.ShipmentRepository
+	orderRepo      repository.OrderRepository
+	inspectionRepo repository.InspectionRepository // nilの場合は検品結果を確認しない
+	tenantRepo     repository.TenantRepository     // 納品書・送り状の差出人（nilの場合は省略）
+	customerRepo   repository.CustomerRepository   // 届け先の既定値（nilの場合は入力必須）
+	tracker        CarrierTracker
+	jpFontHelper   *JPFontHelper // 日本語フォントヘルパー
+	db             *sql.DB       // トランザクション管理用（出荷記録と注文ステータスの同時更新）
+}
+
+// NewShipmentService ShipmentServiceのコンストラクタ
+func NewShipmentService(
+	shipmentRepo repository.ShipmentRepository,
+	orderRepo repository.OrderRepository,
+	inspectionRepo repository.InspectionRepository,
+	tenantRepo repository.TenantRepository,
+	customerRepo repository.CustomerRepository,
+	tracker CarrierTracker,
+	db *sql.DB,
+) *ShipmentService {
+	return &ShipmentService{
+		shipmentRepo:   shipmentRepo,
+		orderRepo:      orderRepo,
+		inspectionRepo: inspectionRepo,
+		tenantRepo:     tenantRepo,
+		customerRepo:   customerRepo,
+		tracker:        tracker,
+		jpFontHelper:   NewJPFontHelper(GetFontDir()),
+		db:             db,
+	}
+}
+
+// 追跡情報の定期取得で1回に処理する出荷記録の上限
+const trackingPollBatchSize = 100
+
+// CreateShipmentRequest 出荷登録リクエスト
+type CreateShipmentRequest struct {
+	OrderID             string         `json:"-"`
+	TenantID            string         `json:"-"`
+	CreatedBy           string         `json:"-"`
+	Carrier             domain.Carrier `json:"carrier"`
+	TrackingNumber      string         `json:"tracking_number"`
+	PackageCount        int            `json:"package_count"`  // 未指定の場合は1
+	ShippedAt           time.Time      `json:"-"`              // 出荷日（ハンドラーで "2006-01-02" から変換、未指定の場合は当日）
+	RecipientName       string         `json:"recipient_name"` // 未指定の場合は顧客名
+	RecipientPostalCode string         `json:"recipient_postal_code"`
+	RecipientAddress    string         `json:"recipient_address"`
+	RecipientPhone      string         `json:"recipient_phone"` // 未指定の場合は顧客の電話番号
+	Notes               string         `json:"notes"`
+	IPAddress           string         `json:"-"`
+	UserAgent           string         `json:"-"`
+}
+
+// ConfirmDeliveryRequest 配達完了の手動登録リクエスト（手渡し・追跡できない場合）
+type ConfirmDeliveryRequest struct {
+	ShipmentID  string    `json:"-"`
+	TenantID    string    `json:"-"`
+	UserID      string    `json:"-"`
+	DeliveredAt time.Time `json:"-"` // 配達日時（ハンドラーで変換、未指定の場合は現在日時）
+	Note        string    `json:"note"`
+	IPAddress   string    `json:"-"`
+	UserAgent   string    `json:"-"`
+}
+
+// ShipmentResponse 出荷記録と注文ステータス
+type ShipmentResponse struct {
+	Shipment       *domain.Shipment   `json:"shipment"`
+	OrderStatus    domain.OrderStatus `json:"order_status"`
+	PaymentDueDate *time.Time         `json:"payment_due_date,omitempty"` // 配達完了時の支払期日
+}
+
+// TrackingPollResult 追跡情報の定期取得の結果
+type TrackingPollResult struct {
+	Checked   int `json:"checked"`
+	Delivered int `json:"delivered"`
+	Failed    int `json:"failed"`
+}
+
+// CreateShipment 出荷を登録し、注文を発送済みにする
+// 検品中の注文のみ出荷できる。検品記録がある場合は最新の検品が合格している必要がある
+func (s *ShipmentService) CreateShipment(ctx context.Context, req *CreateShipmentRequest) (*ShipmentResponse, error) {
+	if req.OrderID == "" {
+		return nil, fmt.Errorf("order_id is required")
+	}
+	if req.TenantID == "" {
+		return nil, fmt.Errorf("tenant_id is required")
+	}
+
+	order, err := s.getOrder(ctx, req.OrderID, req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != domain.OrderStatusInspection {
+		return nil, fmt.Errorf("invalid order status: %s order cannot be shipped", order.Status)
+	}
+	if s.inspectionRepo != nil {
+		inspections, err := s.inspectionRepo.ListByOrderID(ctx, order.ID, req.TenantID)
+		if err != nil {
+			return nil, err
+		}
+		if len(inspections) > 0 && !inspections[len(inspections)-1].Passed {
+			return nil, fmt.Errorf("invalid order status: latest inspection has not passed")
+		}
+	}
+
+	shippedAt := req.ShippedAt
+	if shippedAt.IsZero() {
+		shippedAt = domain.ProductionDay(time.Now())
+	}
+	packageCount := req.PackageCount
+	if packageCount == 0 {
+		packageCount = 1
+	}
+
+	shipment := domain.NewShipment(req.TenantID, order.ID, req.Carrier, domain.NormalizeTrackingNumber(req.TrackingNumber), packageCount, shippedAt, req.CreatedBy)
+	shipment.RecipientName = req.RecipientName
+	shipment.RecipientPostalCode = req.RecipientPostalCode
+	shipment.RecipientAddress = req.RecipientAddress
+	shipment.RecipientPhone = req.RecipientPhone
+	shipment.Notes = req.Notes
+	if (shipment.RecipientName == "" || shipment.RecipientPhone == "") && s.customerRepo != nil && order.CustomerID != "" {
+		customer, err := s.customerRepo.GetByID(ctx, order.CustomerID, req.TenantID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get customer: %w", err)
+		}
+		if shipment.RecipientName == "" {
+			shipment.RecipientName = customer.Name
+		}
+		if shipment.RecipientPhone == "" {
+			shipment.RecipientPhone = customer.Phone
+		}
+	}
+	if err := shipment.Validate(); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := s.createShipmentInTx(ctx, tx, shipment); err != nil {
+		return nil, err
+	}
+
+	oldStatus := order.Status
+	order.Status = domain.OrderStatusShipped
+	order.UpdatedAt = shipment.CreatedAt
+	if err := updateOrderStatusInTx(ctx, tx, order, oldStatus); err != nil {
+		return nil, err
+	}
+
+	auditLog := domain.NewAuditLog(order.TenantID, req.CreatedBy, domain.AuditActionStatusChange, "order", order.ID)
+	auditLog.OldValue = s.toJSON(map[string]interface{}{"status": oldStatus})
+	auditLog.NewValue = s.toJSON(map[string]interface{}{
+		"status":          order.Status,
+		"shipment_id":     shipment.ID,
+		"carrier":         shipment.Carrier,
+		"tracking_number": shipment.TrackingNumber,
+	})
+	auditLog.ChangedFields = []string{"status"}
+	auditLog.IPAddress = req.IPAddress
+	auditLog.UserAgent = req.UserAgent
+	if err := createAuditLogInTx(ctx, tx, auditLog); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return &ShipmentResponse{Shipment: shipment, OrderStatus: order.Status}, nil
+}
+
+// GetShipment 出荷記録を取得
+func (s *ShipmentService) GetShipment(ctx context.Context, shipmentID, tenantID string) (*domain.Shipment, error) {
+	if shipmentID == "" {
+		return nil, fmt.Errorf("shipment_id is required")
+	}
+	if tenantID == "" {
+		return nil, fmt.Errorf("tenant_id is required")
+	}
+	return s.shipmentRepo.GetByID(ctx, shipmentID, tenantID)
+}
+
+// GetOrderShipment 注文の出荷記録を取得
+func (s *ShipmentService) GetOrderShipment(ctx context.Context, orderID, tenantID string) (*domain.Shipment, error) {
+	if orderID == "" {
+		return nil, fmt.Errorf("order_id is required")
+	}
+	if tenantID == "" {
+		return nil, fmt.Errorf("tenant_id is required")
+	}
+	return s.shipmentRepo.GetByOrderID(ctx, orderID, tenantID)
+}
+
+// ListShipments 出荷記録を検索
+func (s *ShipmentService) ListShipments(ctx context.Context, filter *domain.ShipmentFilter) ([]*domain.Shipment, error) {
+	if filter.TenantID == "" {
+		return nil, fmt.Errorf("tenant_id is required")
+	}
+	if filter.Carrier != "" && !filter.Carrier.IsValid() {
+		return nil, fmt.Errorf("invalid carrier: %s", filter.Carrier)
+	}
+	for _, status := range filter.Statuses {
+		if !status.IsValid() {
+			return nil, fmt.Errorf("invalid status: %s", status)
+		}
+	}
+	return s.shipmentRepo.List(ctx, filter)
+}
+
+// RefreshTracking 配送業者から追跡情報を取得して反映する（配達完了の場合は注文を納品完了にする）
+func (s *ShipmentService) RefreshTracking(ctx context.Context, shipmentID, tenantID string) (*ShipmentResponse, error) {
+	shipment, err := s.GetShipment(ctx, shipmentID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return s.track(ctx, shipment)
+}
+
+// ConfirmDelivery 配達完了を手動で登録し、注文を納品完了にする
+func (s *ShipmentService) ConfirmDelivery(ctx context.Context, req *ConfirmDeliveryRequest) (*ShipmentResponse, error) {
+	if req.UserID == "" {
+		return nil, fmt.Errorf("user_id is required")
+	}
+	shipment, err := s.GetShipment(ctx, req.ShipmentID, req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	deliveredAt := req.DeliveredAt
+	if deliveredAt.IsZero() {
+		deliveredAt = now
+	}
+	if deliveredAt.After(now) {
+		return nil, fmt.Errorf("invalid delivered_at: must not be in the future")
+	}
+	detail := "配達完了（手動登録）"
+	if req.Note != "" {
+		detail += ": " + req.Note
+	}
+	if _, err := shipment.ApplyTracking(domain.ShipmentStatusDelivered, &deliveredAt, detail, now); err != nil {
+		return nil, err
+	}
+
+	return s.markDelivered(ctx, shipment, req.UserID, req.IPAddress, req.UserAgent)
+}
+
+// PollTracking 配達完了前の出荷記録の追跡情報を取得して反映する（全テナント）
+// 個別の出荷記録の失敗はログに記録して続行する
+func (s *ShipmentService) PollTracking(ctx context.Context) (*TrackingPollResult, error) {
+	shipments, err := s.shipmentRepo.ListActive(ctx, trackingPollBatchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &TrackingPollResult{}
+	for _, shipment := range shipments {
+		result.Checked++
+		resp, err := s.track(ctx, shipment)
+		if err != nil {
+			result.Failed++
+			log.Printf("WARNING: Failed to track shipment %s (%s %s): %v", shipment.ID, shipment.Carrier, shipment.TrackingNumber, err)
+			continue
+		}
+		if resp.Shipment.Status == domain.ShipmentStatusDelivered {
+			result.Delivered++
+		}
+	}
+	return result, nil
+}
+
+// StartTrackingPoller 追跡情報の定期取得を開始（ctxのキャンセルで停止）
+func (s *ShipmentService) StartTrackingPoller(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				result, err := s.PollTracking(ctx)
+				if err != nil {
+					log.Printf("WARNING: Failed to poll shipment tracking: %v", err)
+					continue
+				}
+				if result.Checked > 0 {
+					log.Printf("Shipment tracking polled: checked=%d delivered=%d failed=%d", result.Checked, result.Delivered, result.Failed)
+				}
+			}
+		}
+	}()
+}
+
+// GeneratePackingSlipPDF 納品書PDFを生成
+func (s *ShipmentService) GeneratePackingSlipPDF(ctx context.Context, shipmentID, tenantID string) ([]byte, error) {
+	shipment, err := s.GetShipment(ctx, shipmentID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	order, err := s.getOrder(ctx, shipment.OrderID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	senderName, senderAddress, err := s.sender(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetTitle("納品書", false)
+	pdf.SetAuthor("TailorCloud", false)
+	pdf.AddPage()
+
+	// 日本語フォントを登録
+	if err := s.jpFontHelper.RegisterJPFonts(pdf); err != nil {
+		// フォント登録に失敗した場合は警告を出して続行（英語フォントで代替）
+		fmt.Printf("WARNING: Failed to register Japanese fonts: %v\n", err)
+	}
+
+	// タイトル
+	s.jpFontHelper.SetJPFont(pdf, "B", 16)
+	pdf.CellFormat(190, 10, "納品書", "", 1, "C", false, 0, "")
+	pdf.Ln(5)
+
+	// 注文番号・出荷日
+	s.jpFontHelper.SetJPFont(pdf, "", 9)
+	pdf.CellFormat(190, 5, fmt.Sprintf("注文番号: %s", domain.InvoiceReference(order.ID)), "", 1, "R", false, 0, "")
+	pdf.CellFormat(190, 5, fmt.Sprintf("出荷日: %s", shipment.ShippedAt.Format("2006年01月02日")), "", 1, "R", false, 0, "")
+	pdf.Ln(5)
+
+	// 届け先
+	s.jpFontHelper.SetJPFont(pdf, "B", 13)
+	pdf.CellFormat(120, 8, shipment.RecipientName+" 様", "B", 1, "L", false, 0, "")
+	s.jpFontHelper.SetJPFont(pdf, "", 10)
+	if shipment.RecipientPostalCode != "" {
+		pdf.CellFormat(120, 6, "〒"+shipment.RecipientPostalCode, "", 1, "L", false, 0, "")
+	}
+	pdf.MultiCell(120, 6, shipment.RecipientAddress, "", "L", false)
+	pdf.Ln(3)
+
+	// 差出人
+	if senderName != "" {
+		pdf.CellFormat(190, 6, senderName, "", 1, "R", false, 0, "")
+	}
+	if senderAddress != "" {
+		pdf.CellFormat(190, 6, senderAddress, "", 1, "R", false, 0, "")
+	}
+	pdf.Ln(5)
+
+	pdf.CellFormat(190, 6, "下記の通り納品いたします。", "", 1, "L", false, 0, "")
+	pdf.Ln(2)
+
+	// 明細
+	s.jpFontHelper.SetJPFont(pdf, "B", 10)
+	pdf.CellFormat(50, 7, "品目", "1", 0, "C", false, 0, "")
+	pdf.CellFormat(110, 7, "内容", "1", 0, "C", false, 0, "")
+	pdf.CellFormat(30, 7, "数量", "1", 1, "C", false, 0, "")
+
+	s.jpFontHelper.SetJPFont(pdf, "", 10)
+	garmentType := order.GarmentType
+	if garmentType == "" {
+		garmentType = domain.GarmentTypeSuit
+	}
+	description := ""
+	if order.Details != nil {
+		description = order.Details.Description
+	}
+	pdf.CellFormat(50, 7, garmentType.Label(), "1", 0, "L", false, 0, "")
+	pdf.CellFormat(110, 7, description, "1", 0, "L", false, 0, "")
+	pdf.CellFormat(30, 7, "1", "1", 1, "R", false, 0, "")
+	pdf.Ln(5)
+
+	// 配送情報
+	pdf.CellFormat(190, 6, fmt.Sprintf("配送業者: %s", shipment.Carrier.Label()), "", 1, "L", false, 0, "")
+	pdf.CellFormat(190, 6, fmt.Sprintf("伝票番号: %s", formatTrackingNumber(shipment.TrackingNumber)), "", 1, "L", false, 0, "")
+	pdf.CellFormat(190, 6, fmt.Sprintf("個口数: %d", shipment.PackageCount), "", 1, "L", false, 0, "")
+	if shipment.Notes != "" {
+		pdf.MultiCell(190, 6, fmt.Sprintf("備考: %s", shipment.Notes), "", "L", false)
+	}
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("failed to output PDF: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// GenerateShippingLabelPDF 送り状（荷札）PDFを生成（100mm×150mm、1個口につき1ページ）
+func (s *ShipmentService) GenerateShippingLabelPDF(ctx context.Context, shipmentID, tenantID string) ([]byte, error) {
+	shipment, err := s.GetShipment(ctx, shipmentID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	senderName, senderAddress, err := s.sender(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	pdf := gofpdf.NewCustom(&gofpdf.InitType{
+		OrientationStr: "P",
+		UnitStr:        "mm",
+		Size:           gofpdf.SizeType{Wd: 100, Ht: 150},
+	})
+	pdf.SetTitle("送り状", false)
+	pdf.SetAuthor("TailorCloud", false)
+	pdf.SetMargins(6, 6, 6)
+	pdf.SetAutoPageBreak(false, 6)
+
+	// 日本語フォントを登録
+	if err := s.jpFontHelper.RegisterJPFonts(pdf); err != nil {
+		fmt.Printf("WARNING: Failed to register Japanese fonts: %v\n", err)
+	}
+
+	for i := 1; i <= shipment.PackageCount; i++ {
+		pdf.AddPage()
+
+		// 配送業者・個口
+		s.jpFontHelper.SetJPFont(pdf, "B", 12)
+		pdf.CellFormat(58, 8, shipment.Carrier.Label(), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(30, 8, fmt.Sprintf("%d / %d 個口", i, shipment.PackageCount), "1", 1, "C", false, 0, "")
+
+		// 伝票番号
+		s.jpFontHelper.SetJPFont(pdf, "", 8)
+		pdf.CellFormat(88, 5, "伝票番号", "LTR", 1, "L", false, 0, "")
+		s.jpFontHelper.SetJPFont(pdf, "B", 16)
+		pdf.CellFormat(88, 10, formatTrackingNumber(shipment.TrackingNumber), "LBR", 1, "C", false, 0, "")
+		pdf.Ln(3)
+
+		// お届け先
+		s.jpFontHelper.SetJPFont(pdf, "", 8)
+		pdf.CellFormat(88, 5, "お届け先", "", 1, "L", false, 0, "")
+		s.jpFontHelper.SetJPFont(pdf, "", 10)
+		if shipment.RecipientPostalCode != "" {
+			pdf.CellFormat(88, 6, "〒"+shipment.RecipientPostalCode, "", 1, "L", false, 0, "")
+		}
+		pdf.MultiCell(88, 6, shipment.RecipientAddress, "", "L", false)
+		s.jpFontHelper.SetJPFont(pdf, "B", 13)
+		pdf.CellFormat(88, 8, shipment.RecipientName+" 様", "B", 1, "L", false, 0, "")
+		s.jpFontHelper.SetJPFont(pdf, "", 9)
+		if shipment.RecipientPhone != "" {
+			pdf.CellFormat(88, 5, "TEL: "+shipment.RecipientPhone, "", 1, "L", false, 0, "")
+		}
+		pdf.Ln(4)
+
+		// ご依頼主
+		s.jpFontHelper.SetJPFont(pdf, "", 8)
+		pdf.CellFormat(88, 5, "ご依頼主", "", 1, "L", false, 0, "")
+		s.jpFontHelper.SetJPFont(pdf, "", 9)
+		if senderAddress != "" {
+			pdf.MultiCell(88, 5, senderAddress, "", "L", false)
+		}
+		if senderName != "" {
+			pdf.CellFormat(88, 5, senderName, "", 1, "L", false, 0, "")
+		}
+		pdf.Ln(3)
+
+		// 品名・出荷日
+		s.jpFontHelper.SetJPFont(pdf, "", 9)
+		pdf.CellFormat(88, 5, fmt.Sprintf("品名: 衣料品（注文番号 %s）", domain.InvoiceReference(shipment.OrderID)), "", 1, "L", false, 0, "")
+		pdf.CellFormat(88, 5, fmt.Sprintf("出荷日: %s", shipment.ShippedAt.Format("2006年01月02日")), "", 1, "L", false, 0, "")
+	}
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("failed to output PDF: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// track 追跡情報を取得して反映する
+func (s *ShipmentService) track(ctx context.Context, shipment *domain.Shipment) (*ShipmentResponse, error) {
+	if shipment.Status == domain.ShipmentStatusDelivered {
+		return nil, fmt.Errorf("invalid shipment status: shipment is already delivered")
+	}
+	if s.tracker == nil {
+		return nil, fmt.Errorf("carrier tracker is not configured")
+	}
+
+	result, err := s.tracker.Track(ctx, &TrackingQuery{
+		Carrier:        shipment.Carrier,
+		TrackingNumber: shipment.TrackingNumber,
+		ShippedAt:      shipment.ShippedAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to track shipment: %w", err)
+	}
+
+	result = confirmedTrackingResult(result)
+
+	delivered, err := shipment.ApplyTracking(result.Status, result.DeliveredAt, result.Detail, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if delivered {
+		// 追跡情報による配達完了はシステムによる変更として記録する
+		return s.markDelivered(ctx, shipment, "system", "", "")
+	}
+
+	if err := s.shipmentRepo.UpdateTracking(ctx, shipment); err != nil {
+		return nil, err
+	}
+	order, err := s.getOrder(ctx, shipment.OrderID, shipment.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	return &ShipmentResponse{Shipment: shipment, OrderStatus: order.Status}, nil
+}
+
+// confirmedTrackingResult 推定値の配達完了を配達中として扱った追跡結果
+// 推定値では納品完了・支払期日の見直しは行わない（手動で登録する）
+func confirmedTrackingResult(result *TrackingResult) *TrackingResult {
+	if !result.Estimated || result.Status != domain.ShipmentStatusDelivered {
+		return result
+	}
+	return &TrackingResult{
+		Status:    domain.ShipmentStatusOutForDelivery,
+		Detail:    result.Detail + "（推定）",
+		Estimated: true,
+	}
+}
+
+// markDelivered 出荷記録の配達完了・注文の納品完了と支払期日の見直しを単一トランザクションで保存
+// 納品前に全額の入金が消込済み（入金完了日時あり）の注文は、納品と同時に支払済み（Paid）にする
+func (s *ShipmentService) markDelivered(ctx context.Context, shipment *domain.Shipment, userID, ipAddress, userAgent string) (*ShipmentResponse, error) {
+	order, err := s.getOrder(ctx, shipment.OrderID, shipment.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != domain.OrderStatusShipped {
+		return nil, fmt.Errorf("invalid order status: %s order cannot be delivered", order.Status)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE shipments SET
+			status = $3,
+			delivered_at = $4,
+			last_tracked_at = $5,
+			tracking_detail = $6,
+			updated_at = $7
+		WHERE id = $1 AND tenant_id = $2 AND status <> 'DELIVERED'
+	`,
+		shipment.ID,
+		shipment.TenantID,
+		string(shipment.Status),
+		shipment.DeliveredAt,
+		shipment.LastTrackedAt,
+		shipment.TrackingDetail,
+		shipment.UpdatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update shipment: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return nil, fmt.Errorf("invalid shipment status: shipment was modified by another request")
+	}
+
+	oldStatus := order.Status
+	oldDueDate := order.PaymentDueDate
+	order.PaymentDueDate = domain.PaymentDueDateAfterDelivery(order.PaymentDueDate, *shipment.DeliveredAt)
+	order.UpdatedAt = shipment.UpdatedAt
+	// 入金完了の判定は消込と競合しないよう更新時の行の値で行う
+	var newStatus string
+	err = tx.QueryRowContext(ctx, `
+		UPDATE orders SET
+			status = CASE WHEN paid_at IS NOT NULL THEN $5 ELSE $4 END,
+			payment_due_date = $6,
+			updated_at = $7
+		WHERE id = $1 AND tenant_id = $2 AND status = $3
+		RETURNING status
+	`, order.ID, order.TenantID, string(oldStatus), string(domain.OrderStatusDelivered), string(domain.OrderStatusPaid), order.PaymentDueDate, order.UpdatedAt).Scan(&newStatus)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("invalid order status: order was modified by another request")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update order status: %w", err)
+	}
+	order.Status = domain.OrderStatus(newStatus)
+
+	auditLog := domain.NewAuditLog(order.TenantID, userID, domain.AuditActionStatusChange, "order", order.ID)
+	auditLog.OldValue = s.toJSON(map[string]interface{}{"status": oldStatus, "payment_due_date": oldDueDate})
+	auditLog.NewValue = s.toJSON(map[string]interface{}{
+		"status":           order.Status,
+		"payment_due_date": order.PaymentDueDate,
+		"shipment_id":      shipment.ID,
+		"delivered_at":     shipment.DeliveredAt,
+	})
+	auditLog.ChangedFields = []string{"status"}
+	if !order.PaymentDueDate.Equal(oldDueDate) {
+		auditLog.ChangedFields = append(auditLog.ChangedFields, "payment_due_date")
+	}
+	auditLog.IPAddress = ipAddress
+	auditLog.UserAgent = userAgent
+	if err := createAuditLogInTx(ctx, tx, auditLog); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return &ShipmentResponse{Shipment: shipment, OrderStatus: order.Status, PaymentDueDate: &order.PaymentDueDate}, nil
+}
+
+// getOrder 注文を取得（テナントIDもチェック）
+func (s *ShipmentService) getOrder(ctx context.Context, orderID, tenantID string) (*domain.Order, error) {
+	order, err := s.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+	if order.TenantID != tenantID {
+		return nil, fmt.Errorf("unauthorized: tenant_id mismatch")
+	}
+	return order, nil
+}
+
+// sender 納品書・送り状の差出人（テナントの名称・住所）
+func (s *ShipmentService) sender(ctx context.Context, tenantID string) (string, string, error) {
+	if s.tenantRepo == nil {
+		return "", "", nil
+	}
+	tenant, err := s.tenantRepo.GetByID(ctx, tenantID)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get tenant: %w", err)
+	}
+	return tenant.LegalName, tenant.Address, nil
+}
+
+// createShipmentInTx トランザクション内で出荷記録を作成
+func (s *ShipmentService) createShipmentInTx(ctx context.Context, tx *sql.Tx, shipment *domain.Shipment) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO shipments (
+			id, tenant_id, order_id, carrier, tracking_number, package_count, shipped_at,
+			status, recipient_name, recipient_postal_code, recipient_address, recipient_phone,
+			notes, created_at, updated_at, created_by
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+	`,
+		shipment.ID,
+		shipment.TenantID,
+		shipment.OrderID,
+		string(shipment.Carrier),
+		shipment.TrackingNumber,
+		shipment.PackageCount,
+		shipment.ShippedAt.Format("2006-01-02"),
+		string(shipment.Status),
+		shipment.RecipientName,
+		shipment.RecipientPostalCode,
+		shipment.RecipientAddress,
+		shipment.RecipientPhone,
+		shipment.Notes,
+		shipment.CreatedAt,
+		shipment.UpdatedAt,
+		shipment.CreatedBy,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create shipment: %w", err)
+	}
+	return nil
+}
+
+// toJSON 監査ログ用にJSON文字列に変換
+func (s *ShipmentService) toJSON(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf(`{"error": "failed to marshal: %v"}`, err)
+	}
+	return string(data)
+}
+
+// formatTrackingNumber 伝票番号を4桁ごとにハイフンで区切る（数字のみの番号）
+func formatTrackingNumber(trackingNumber string) string {
+	for _, r := range trackingNumber {
+		if r < '0' || r > '9' {
+			return trackingNumber
+		}
+	}
+	var buf bytes.Buffer
+	for i, r := range trackingNumber {
+		if i > 0 && i%4 == 0 {
+			buf.WriteByte('-')
+		}
+		buf.WriteRune(r)
+	}
+	return buf.String()
+}
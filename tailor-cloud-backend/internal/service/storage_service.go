@@ -13,6 +13,7 @@ import (
 type StorageService interface {
 	UploadPDF(ctx context.Context, bucketName string, objectPath string, pdfBytes []byte) (string, error)
 	UploadJSON(ctx context.Context, bucketName string, objectPath string, jsonBytes []byte) (string, error)
+	UploadImage(ctx context.Context, bucketName string, objectPath string, imageBytes []byte, contentType string) (string, error)
 	GetPublicURL(bucketName string, objectPath string) string
 }
 
@@ -106,6 +107,29 @@ func (s *GCSStorageService) UploadJSON(ctx context.Context, bucketName string, o
 	return publicURL, nil
 }
 
+// UploadImage 画像ファイルをCloud Storageにアップロード（検品写真など）
+func (s *GCSStorageService) UploadImage(ctx context.Context, bucketName string, objectPath string, imageBytes []byte, contentType string) (string, error) {
+	obj := s.client.Bucket(bucketName).Object(objectPath)
+
+	// アップロード処理（Contextタイムアウト設定）
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	writer := obj.NewWriter(ctx)
+	writer.ContentType = contentType
+
+	if _, err := writer.Write(imageBytes); err != nil {
+		writer.Close()
+		return "", fmt.Errorf("failed to write image bytes: %w", err)
+	}
+
+	if err := writer.Close(); err != nil {
+		return "", fmt.Errorf("failed to close writer: %w", err)
+	}
+
+	return s.GetPublicURL(bucketName, objectPath), nil
+}
+
 // GetPublicURL オブジェクトの公開URLを生成
 func (s *GCSStorageService) GetPublicURL(bucketName string, objectPath string) string {
 	// 公開URLの形式: https://storage.googleapis.com/{bucket}/{object}
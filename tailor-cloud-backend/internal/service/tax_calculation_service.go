@@ -96,3 +96,26 @@ func (s *TaxCalculationService) CalculateTaxForOrder(ctx context.Context, order
 	return s.CalculateTax(ctx, req)
 }
 
+
+// CalculateTaxFromIncluded 税込金額に含まれる消費税額を計算
+// 成果報酬・下請代金など税込で確定している金額の仕訳に使用
+func (s *TaxCalculationService) CalculateTaxFromIncluded(ctx context.Context, tenantID string, taxIncludedAmount int64, taxRate domain.TaxRate) (int64, error) {
+	if taxIncludedAmount < 0 {
+		return 0, fmt.Errorf("tax_included_amount must be >= 0")
+	}
+	if taxRate <= 0 || taxRate > 1 {
+		return 0, fmt.Errorf("tax_rate must be between 0 and 1")
+	}
+
+	tenant, err := s.tenantRepo.GetByID(ctx, tenantID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	roundingMethod := tenant.TaxRoundingMethod
+	if roundingMethod == "" {
+		roundingMethod = domain.TaxRoundingMethodHalfUp
+	}
+
+	return domain.CalculateTaxFromIncluded(taxIncludedAmount, taxRate, roundingMethod), nil
+}
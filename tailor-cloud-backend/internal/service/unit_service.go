@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"tailor-cloud/backend/internal/config/domain"
+	"tailor-cloud/backend/internal/repository"
+)
+
+// UnitService 表示単位サービス
+// 採寸値はcm、生地の長さ・単価はmで保存し、テナント・顧客の設定に従って表示単位に換算する
+type UnitService struct {
+	tenantRepo   repository.TenantRepository
+	customerRepo repository.CustomerRepository // 顧客の表示単位（nilの場合はテナントの設定のみ）
+}
+
+// NewUnitService UnitServiceのコンストラクタ
+func NewUnitService(tenantRepo repository.TenantRepository, customerRepo repository.CustomerRepository) *UnitService {
+	return &UnitService{
+		tenantRepo:   tenantRepo,
+		customerRepo: customerRepo,
+	}
+}
+
+// GetPreference テナント・顧客の設定から表示単位を取得（customerIDが空の場合はテナントの設定）
+// 設定を取得できない場合は既定値（cm・m）で表示する
+func (s *UnitService) GetPreference(ctx context.Context, tenantID, customerID string) domain.UnitPreference {
+	if s == nil {
+		return domain.DefaultUnitPreference()
+	}
+
+	var tenant *domain.Tenant
+	if s.tenantRepo != nil {
+		found, err := s.tenantRepo.GetByID(ctx, tenantID)
+		if err != nil {
+			fmt.Printf("WARNING: Failed to get tenant unit preference: %v\n", err)
+		} else {
+			tenant = found
+		}
+	}
+	var customer *domain.Customer
+	if s.customerRepo != nil && customerID != "" {
+		found, err := s.customerRepo.GetByID(ctx, customerID, tenantID)
+		if err != nil {
+			fmt.Printf("WARNING: Failed to get customer unit preference: %v\n", err)
+		} else {
+			customer = found
+		}
+	}
+	return domain.ResolveUnitPreference(tenant, customer)
+}
+
+// GetTenantPreference テナントの表示単位を取得
+func (s *UnitService) GetTenantPreference(ctx context.Context, tenantID string) (*domain.UnitPreference, error) {
+	if s.tenantRepo == nil {
+		return nil, fmt.Errorf("tenant repository is not configured")
+	}
+	tenant, err := s.tenantRepo.GetByID(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	preference := domain.ResolveUnitPreference(tenant, nil)
+	return &preference, nil
+}
+
+// UpdateTenantPreference テナントの表示単位を更新
+func (s *UnitService) UpdateTenantPreference(ctx context.Context, tenantID string, preference *domain.UnitPreference) (*domain.UnitPreference, error) {
+	if s.tenantRepo == nil {
+		return nil, fmt.Errorf("tenant repository is not configured")
+	}
+	if err := preference.Validate(); err != nil {
+		return nil, err
+	}
+	tenant, err := s.tenantRepo.GetByID(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	tenant.MeasurementUnit = preference.MeasurementUnit
+	tenant.FabricUnit = preference.FabricUnit
+	if err := s.tenantRepo.Update(ctx, tenant); err != nil {
+		return nil, err
+	}
+	return preference, nil
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"tailor-cloud/backend/internal/config/domain"
+)
+
+// TestLengthUnitConversion 採寸値・生地の長さ・生地単価の換算のテスト
+func TestLengthUnitConversion(t *testing.T) {
+	if got := domain.MeasurementUnitInch.ToCentimeters(40); got != 101.6 {
+		t.Errorf("40inch = %vcm, want 101.6", got)
+	}
+	if got := domain.MeasurementUnitMM.ToCentimeters(825); got != 82.5 {
+		t.Errorf("825mm = %vcm, want 82.5", got)
+	}
+	if got := domain.MeasurementUnitInch.FromCentimeters(101.6); got != 40 {
+		t.Errorf("101.6cm = %vinch, want 40", got)
+	}
+	if got := domain.MeasurementUnitMM.FormatCentimeters(-1.5, true); got != "-15" {
+		t.Errorf("FormatCentimeters = %q, want -15", got)
+	}
+
+	if got := domain.FabricUnitYard.ToMeters(10); got != 9.144 {
+		t.Errorf("10yd = %vm, want 9.144", got)
+	}
+	if got := domain.FabricUnitYard.FromMeters(3.2); got != 3.5 {
+		t.Errorf("3.2m = %vyd, want 3.5", got)
+	}
+	// 1ヤード9,144円 → 1メートル10,000円
+	if got := domain.FabricUnitYard.PriceToMeter(9144); got != 10000 {
+		t.Errorf("9144 yen/yd = %d yen/m, want 10000", got)
+	}
+	if got := domain.FabricUnitYard.PriceFromMeter(10000); got != 9144 {
+		t.Errorf("10000 yen/m = %d yen/yd, want 9144", got)
+	}
+}
+
+// TestNormalizeMeasurementData 採寸データのcmへの正規化と表示単位への換算のテスト
+func TestNormalizeMeasurementData(t *testing.T) {
+	normalized, err := domain.NormalizeMeasurementData(json.RawMessage(`{"unit":"inch","bust":40,"weight":70,"memo":"反り身","final_measurements":{"chest":42}}`))
+	if err != nil {
+		t.Fatalf("NormalizeMeasurementData returned error: %v", err)
+	}
+	var data map[string]interface{}
+	if err := json.Unmarshal(normalized, &data); err != nil {
+		t.Fatalf("invalid normalized data: %v", err)
+	}
+	if data["unit"] != "cm" || data["bust"] != 101.6 || data["memo"] != "反り身" {
+		t.Errorf("normalized = %v", data)
+	}
+	// 体重は長さではないため換算しない
+	if data["weight"] != float64(70) {
+		t.Errorf("weight = %v, want 70", data["weight"])
+	}
+	if final := data["final_measurements"].(map[string]interface{}); final["chest"] != 106.68 {
+		t.Errorf("final chest = %v, want 106.68", final["chest"])
+	}
+
+	// 単位の指定がない採寸データはcm
+	normalized, err = domain.NormalizeMeasurementData(json.RawMessage(`{"bust":92}`))
+	if err != nil {
+		t.Fatalf("NormalizeMeasurementData returned error: %v", err)
+	}
+	if string(normalized) != `{"bust":92,"unit":"cm"}` {
+		t.Errorf("normalized = %s", normalized)
+	}
+
+	if _, err := domain.NormalizeMeasurementData(json.RawMessage(`{"unit":"feet","bust":3}`)); err == nil {
+		t.Error("expected error for unknown unit")
+	}
+
+	converted, err := domain.ConvertMeasurementData(json.RawMessage(`{"bust":101.6,"unit":"cm"}`), domain.MeasurementUnitInch)
+	if err != nil {
+		t.Fatalf("ConvertMeasurementData returned error: %v", err)
+	}
+	if string(converted) != `{"bust":40,"unit":"inch"}` {
+		t.Errorf("converted = %s", converted)
+	}
+	values, err := domain.MeasurementValues(converted)
+	if err != nil || len(values) != 1 {
+		t.Errorf("MeasurementValues = %v, %v (unit must be ignored)", values, err)
+	}
+}
+
+// TestRawMeasurementInCentimeters ヌード寸のcmへの換算のテスト
+func TestRawMeasurementInCentimeters(t *testing.T) {
+	raw := &RawMeasurement{Height: 70, Bust: 40, Inseam: 32, Unit: domain.MeasurementUnitInch}
+	converted, err := raw.InCentimeters()
+	if err != nil {
+		t.Fatalf("InCentimeters returned error: %v", err)
+	}
+	if converted.Height != 177.8 || converted.Bust != 101.6 || converted.Inseam != 81.28 || converted.Unit != domain.MeasurementUnitCM {
+		t.Errorf("converted = %+v", converted)
+	}
+	if raw.Bust != 40 {
+		t.Error("InCentimeters must not modify the original")
+	}
+
+	if _, err := (&RawMeasurement{Bust: 40, Unit: "feet"}).InCentimeters(); err == nil {
+		t.Error("expected error for unknown unit")
+	}
+}
+
+// TestResolveUnitPreference テナント・顧客の表示単位の解決のテスト
+func TestResolveUnitPreference(t *testing.T) {
+	if got := domain.ResolveUnitPreference(nil, nil); got != domain.DefaultUnitPreference() {
+		t.Errorf("default = %+v", got)
+	}
+
+	tenant := &domain.Tenant{MeasurementUnit: domain.MeasurementUnitMM, FabricUnit: domain.FabricUnitYard}
+	if got := domain.ResolveUnitPreference(tenant, &domain.Customer{}); got.MeasurementUnit != domain.MeasurementUnitMM || got.FabricUnit != domain.FabricUnitYard {
+		t.Errorf("tenant preference = %+v", got)
+	}
+	// 採寸値は顧客の設定を優先
+	customer := &domain.Customer{MeasurementUnit: domain.MeasurementUnitInch}
+	if got := domain.ResolveUnitPreference(tenant, customer); got.MeasurementUnit != domain.MeasurementUnitInch || got.FabricUnit != domain.FabricUnitYard {
+		t.Errorf("customer preference = %+v", got)
+	}
+
+	if err := (domain.UnitPreference{MeasurementUnit: domain.MeasurementUnitCM, FabricUnit: "ft"}).Validate(); err == nil {
+		t.Error("expected error for unknown fabric unit")
+	}
+}
+
+// TestFabricInUnit 生地・反物の表示単位への換算のテスト
+func TestFabricInUnit(t *testing.T) {
+	fabric := &domain.Fabric{ID: "fabric-1", StockAmount: 9.144, MinimumOrder: 3.2, Price: 9144}
+	// 仕入先の単価（円/ヤード）を円/メートルに正規化
+	fabric.NormalizePrice(domain.FabricUnitYard)
+	if fabric.Price != 10000 || fabric.SupplierPriceUnit != domain.FabricUnitYard || fabric.LengthUnit != domain.FabricUnitMeter {
+		t.Fatalf("normalized fabric = %+v", fabric)
+	}
+
+	converted := fabric.InUnit(domain.FabricUnitYard)
+	if converted.StockAmount != 10 || converted.MinimumOrder != 3.5 || converted.Price != 9144 || converted.LengthUnit != domain.FabricUnitYard {
+		t.Errorf("converted fabric = %+v", converted)
+	}
+	if fabric.StockAmount != 9.144 {
+		t.Error("InUnit must not modify the original")
+	}
+
+	roll := &domain.FabricRoll{InitialLength: 45.72, CurrentLength: 9.144}
+	if converted := roll.InUnit(domain.FabricUnitYard); converted.InitialLength != 50 || converted.CurrentLength != 10 {
+		t.Errorf("converted roll = %+v", converted)
+	}
+}
+
+// TestBuildInventoryReport 在庫レポートの集計のテスト
+func TestBuildInventoryReport(t *testing.T) {
+	fabrics := []*domain.Fabric{
+		{ID: "fabric-1", Name: "ネイビー無地", StockAmount: 18.288, Price: 10000},
+		{ID: "fabric-2", Name: "グレーストライプ", StockAmount: 0, Price: 8000},
+	}
+	rolls := map[string][]*domain.FabricRoll{
+		"fabric-1": {
+			{ID: "roll-1", CurrentLength: 9.144, Status: domain.FabricRollStatusAvailable},
+			{ID: "roll-2", CurrentLength: 4.572, Status: domain.FabricRollStatusAllocated},
+			{ID: "roll-3", CurrentLength: 0, Status: domain.FabricRollStatusConsumed},
+			{ID: "roll-4", CurrentLength: 0.9144, Status: domain.FabricRollStatusDamaged},
+		},
+	}
+	report := domain.BuildInventoryReport("tenant-1", fabrics, rolls, domain.FabricUnitYard, time.Now())
+
+	if len(report.Lines) != 2 || report.LengthUnit != domain.FabricUnitYard {
+		t.Fatalf("report = %+v", report)
+	}
+	line := report.Lines[0]
+	if line.RollCount != 2 || line.RollLength != 15 || line.AvailableLength != 10 || line.DamagedLength != 1 || line.StockAmount != 20 {
+		t.Errorf("line = %+v", line)
+	}
+	// 在庫金額はメートルで計算（13.716m × 10,000円）
+	if line.UnitPrice != 9144 || line.StockValue != 137160 {
+		t.Errorf("unit price = %d, stock value = %d", line.UnitPrice, line.StockValue)
+	}
+	if report.TotalRolls != 2 || report.TotalLength != 15 || report.TotalValue != 137160 || report.Lines[1].RollCount != 0 {
+		t.Errorf("totals = %d rolls, %v yd, %d yen", report.TotalRolls, report.TotalLength, report.TotalValue)
+	}
+}
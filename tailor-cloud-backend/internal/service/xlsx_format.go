@@ -0,0 +1,217 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// XLSX（Office Open XML スプレッドシート）の読み取り
+// 取込用途のため、先頭シートのセル値（共有文字列・インライン文字列・数値）のみを扱う
+
+// xlsxWorkbook xl/workbook.xml
+type xlsxWorkbook struct {
+	Sheets []struct {
+		Name string `xml:"name,attr"`
+		RID  string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+// xlsxRelationships xl/_rels/workbook.xml.rels
+type xlsxRelationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+// xlsxRichText 文字列（書式付きの場合は複数のrun）
+type xlsxRichText struct {
+	Text string `xml:"t"`
+	Runs []struct {
+		Text string `xml:"t"`
+	} `xml:"r"`
+}
+
+// String 文字列値を取得
+func (t xlsxRichText) String() string {
+	if len(t.Runs) == 0 {
+		return t.Text
+	}
+	var b strings.Builder
+	for _, r := range t.Runs {
+		b.WriteString(r.Text)
+	}
+	return b.String()
+}
+
+// xlsxSharedStrings xl/sharedStrings.xml
+type xlsxSharedStrings struct {
+	Items []xlsxRichText `xml:"si"`
+}
+
+// xlsxWorksheet xl/worksheets/sheetN.xml
+type xlsxWorksheet struct {
+	Rows []struct {
+		Index int `xml:"r,attr"`
+		Cells []struct {
+			Ref    string        `xml:"r,attr"`
+			Type   string        `xml:"t,attr"`
+			Value  string        `xml:"v"`
+			Inline *xlsxRichText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// ReadXLSXRows XLSXファイルの先頭シートを行ごとの文字列に変換
+// 空のセルは空文字で補完し、行番号の欠落（空行）は空の行として返す
+func ReadXLSXRows(data []byte) ([][]string, error) {
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid xlsx file: %w", err)
+	}
+	files := make(map[string]*zip.File, len(reader.File))
+	for _, f := range reader.File {
+		files[f.Name] = f
+	}
+
+	sheetPath, err := xlsxFirstSheetPath(files)
+	if err != nil {
+		return nil, err
+	}
+
+	var sharedStrings xlsxSharedStrings
+	if f, ok := files["xl/sharedStrings.xml"]; ok {
+		if err := decodeXLSXPart(f, &sharedStrings); err != nil {
+			return nil, err
+		}
+	}
+
+	sheetFile, ok := files[sheetPath]
+	if !ok {
+		return nil, fmt.Errorf("invalid xlsx file: worksheet %s not found", sheetPath)
+	}
+	var sheet xlsxWorksheet
+	if err := decodeXLSXPart(sheetFile, &sheet); err != nil {
+		return nil, err
+	}
+
+	rows := make([][]string, 0, len(sheet.Rows))
+	for _, row := range sheet.Rows {
+		// 空行の補完（行番号が飛んでいる場合）
+		for row.Index > len(rows)+1 {
+			rows = append(rows, []string{})
+		}
+
+		values := make([]string, 0, len(row.Cells))
+		for i, cell := range row.Cells {
+			col := i
+			if cell.Ref != "" {
+				if c, err := xlsxColumnIndex(cell.Ref); err == nil {
+					col = c
+				}
+			}
+			for len(values) < col {
+				values = append(values, "")
+			}
+
+			value := cell.Value
+			switch cell.Type {
+			case "s":
+				idx, err := strconv.Atoi(cell.Value)
+				if err != nil || idx < 0 || idx >= len(sharedStrings.Items) {
+					return nil, fmt.Errorf("invalid xlsx file: shared string index %q in cell %s", cell.Value, cell.Ref)
+				}
+				value = sharedStrings.Items[idx].String()
+			case "inlineStr":
+				if cell.Inline != nil {
+					value = cell.Inline.String()
+				}
+			}
+			values = append(values, value)
+		}
+		rows = append(rows, values)
+	}
+
+	return rows, nil
+}
+
+// xlsxFirstSheetPath ワークブックの先頭シートのパスを取得
+func xlsxFirstSheetPath(files map[string]*zip.File) (string, error) {
+	const fallback = "xl/worksheets/sheet1.xml"
+
+	workbookFile, ok := files["xl/workbook.xml"]
+	if !ok {
+		return "", fmt.Errorf("invalid xlsx file: workbook not found")
+	}
+	var workbook xlsxWorkbook
+	if err := decodeXLSXPart(workbookFile, &workbook); err != nil {
+		return "", err
+	}
+	if len(workbook.Sheets) == 0 {
+		return "", fmt.Errorf("invalid xlsx file: no worksheets")
+	}
+
+	relsFile, ok := files["xl/_rels/workbook.xml.rels"]
+	if !ok {
+		return fallback, nil
+	}
+	var rels xlsxRelationships
+	if err := decodeXLSXPart(relsFile, &rels); err != nil {
+		return "", err
+	}
+	for _, rel := range rels.Relationships {
+		if rel.ID != workbook.Sheets[0].RID {
+			continue
+		}
+		if strings.HasPrefix(rel.Target, "/") {
+			return strings.TrimPrefix(rel.Target, "/"), nil
+		}
+		return path.Join("xl", rel.Target), nil
+	}
+	return fallback, nil
+}
+
+// decodeXLSXPart ZIP内のXMLパートをデコード
+func decodeXLSXPart(f *zip.File, v interface{}) error {
+	rc, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("invalid xlsx file: failed to open %s: %w", f.Name, err)
+	}
+	defer rc.Close()
+
+	if err := xml.NewDecoder(io.LimitReader(rc, 50<<20)).Decode(v); err != nil {
+		return fmt.Errorf("invalid xlsx file: failed to parse %s: %w", f.Name, err)
+	}
+	return nil
+}
+
+// xlsxColumnIndex セル参照（例: "AB12"）から0始まりの列番号を取得
+func xlsxColumnIndex(ref string) (int, error) {
+	col := 0
+	n := 0
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		col = col*26 + int(r-'A'+1)
+		n++
+	}
+	if n == 0 {
+		return 0, fmt.Errorf("invalid cell reference %q", ref)
+	}
+	return col - 1, nil
+}
+
+// xlsxSerialDate Excelのシリアル値（1900年日付システム）を日付に変換
+func xlsxSerialDate(serial float64) time.Time {
+	base := time.Date(1899, 12, 30, 0, 0, 0, 0, time.Local)
+	days := int(serial)
+	return base.AddDate(0, 0, days)
+}
@@ -0,0 +1,489 @@
+package service
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+	"golang.org/x/text/width"
+)
+
+// 全銀協フォーマット共通定義
+// 固定長レコード、Shift_JIS（半角カナ・英数字）
+// 入出金取引明細: 200バイト / 総合振込: 120バイト
+
+const (
+	zenginStatementRecordLength = 200 // 入出金取引明細のレコード長
+
+	zenginRecordHeader  = '1' // ヘッダー・レコード
+	zenginRecordData    = '2' // データ・レコード
+	zenginRecordTrailer = '8' // トレーラ・レコード
+	zenginRecordEnd     = '9' // エンド・レコード
+
+	zenginDepositFlag = '1' // 入払区分: 入金
+)
+
+// ParsedStatementLine 取込で読み取った入金1件分
+// ヘッダー・トレーラは検証のみに使い、入金データのみを返す
+type ParsedStatementLine struct {
+	ReferenceNo      string    // 照会番号
+	TransactionDate  time.Time // 勘定日
+	Amount           int64     // 取引金額（円）
+	PayerCode        string    // 振込依頼人コード
+	PayerName        string    // 振込依頼人名（半角カナ）
+	RemitterBankName string    // 仕向銀行名
+	Description      string    // 摘要内容 + EDI情報
+}
+
+// ParseZenginStatement 全銀協 入出金取引明細（固定長200バイト）をパース
+// 改行区切り・改行なしの両方に対応し、入金（入払区分=1）のデータ・レコードのみを返す
+func ParseZenginStatement(data []byte) ([]*ParsedStatementLine, error) {
+	records := splitZenginRecords(data, zenginStatementRecordLength)
+	if len(records) == 0 {
+		return nil, fmt.Errorf("invalid zengin statement: no records")
+	}
+	if records[0][0] != zenginRecordHeader {
+		return nil, fmt.Errorf("invalid zengin statement: first record must be header")
+	}
+
+	lines := make([]*ParsedStatementLine, 0)
+	var depositCount, trailerDepositCount int64
+	hasTrailer := false
+
+	for i, rec := range records {
+		switch rec[0] {
+		case zenginRecordHeader, zenginRecordEnd:
+			continue
+		case zenginRecordTrailer:
+			// トレーラ: 入金件数(6) 入金額合計(13) で件数を検証
+			hasTrailer = true
+			trailerDepositCount, _ = strconv.ParseInt(strings.TrimSpace(string(rec[1:7])), 10, 64)
+			continue
+		case zenginRecordData:
+		default:
+			return nil, fmt.Errorf("invalid zengin statement: unknown record type %q at record %d", rec[0], i+1)
+		}
+
+		// 出金は対象外
+		if rec[21] != zenginDepositFlag {
+			continue
+		}
+		depositCount++
+
+		txDate, err := parseZenginDate(string(rec[9:15]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid zengin statement: record %d: %w", i+1, err)
+		}
+		amount, err := strconv.ParseInt(strings.TrimSpace(string(rec[24:36])), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid zengin statement: record %d: invalid amount: %w", i+1, err)
+		}
+
+		description := strings.TrimSpace(decodeShiftJIS(rec[159:179]) + " " + decodeShiftJIS(rec[179:199]))
+		lines = append(lines, &ParsedStatementLine{
+			ReferenceNo:      decodeShiftJIS(rec[1:9]),
+			TransactionDate:  txDate,
+			Amount:           amount,
+			PayerCode:        decodeShiftJIS(rec[71:81]),
+			PayerName:        decodeShiftJIS(rec[81:129]),
+			RemitterBankName: decodeShiftJIS(rec[129:144]),
+			Description:      description,
+		})
+	}
+
+	if hasTrailer && trailerDepositCount != depositCount {
+		return nil, fmt.Errorf("invalid zengin statement: trailer deposit count %d does not match data records %d", trailerDepositCount, depositCount)
+	}
+
+	return lines, nil
+}
+
+// ParseStatementCSV インターネットバンキングのCSV明細をパース
+// 1行目のヘッダー名から列を判定する（UTF-8/Shift_JISどちらも可）
+// 必須列: 日付（取引日/勘定日/日付）, 入金額（入金額/お預り金額/金額）, 振込依頼人名（振込依頼人名/依頼人名/摘要/お取引内容）
+func ParseStatementCSV(data []byte) ([]*ParsedStatementLine, error) {
+	text := string(data)
+	if !utf8.Valid(data) {
+		text = decodeShiftJIS(data)
+	}
+	text = strings.TrimPrefix(text, "\ufeff")
+
+	reader := csv.NewReader(strings.NewReader(text))
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("invalid statement csv: failed to read header: %w", err)
+	}
+
+	dateCol := findCSVColumn(header, "取引日", "勘定日", "日付", "date")
+	amountCol := findCSVColumn(header, "入金額", "お預り金額", "お預入金額", "金額", "amount")
+	payerCol := findCSVColumn(header, "振込依頼人名", "依頼人名", "摘要", "お取引内容", "payer_name")
+	descCol := findCSVColumn(header, "メモ", "備考", "EDI情報", "description")
+	refCol := findCSVColumn(header, "照会番号", "reference_no")
+	if dateCol < 0 || amountCol < 0 || payerCol < 0 {
+		return nil, fmt.Errorf("invalid statement csv: date, amount and payer name columns are required")
+	}
+
+	lines := make([]*ParsedStatementLine, 0)
+	rowNum := 1
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		rowNum++
+		if err != nil {
+			return nil, fmt.Errorf("invalid statement csv: row %d: %w", rowNum, err)
+		}
+
+		amountStr := strings.NewReplacer(",", "", "¥", "", "円", "").Replace(csvField(row, amountCol))
+		if amountStr == "" {
+			continue // 出金行（入金額が空欄）
+		}
+		amount, err := strconv.ParseInt(amountStr, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid statement csv: row %d: invalid amount %q", rowNum, amountStr)
+		}
+		if amount <= 0 {
+			continue
+		}
+
+		txDate, err := parseCSVDate(csvField(row, dateCol))
+		if err != nil {
+			return nil, fmt.Errorf("invalid statement csv: row %d: %w", rowNum, err)
+		}
+
+		lines = append(lines, &ParsedStatementLine{
+			ReferenceNo:     csvField(row, refCol),
+			TransactionDate: txDate,
+			Amount:          amount,
+			PayerName:       csvField(row, payerCol),
+			Description:     csvField(row, descCol),
+		})
+	}
+
+	return lines, nil
+}
+
+// NormalizeKana 照合用にカナ名義を正規化
+// 半角カナ→全角カナ、ひらがな→カタカナ、空白・法人略語（カ）/（ユ）等）を除去
+func NormalizeKana(s string) string {
+	// 半角濁点（ﾞ）は結合文字になるためNFCで合成する
+	s = norm.NFC.String(width.Fold.String(s))
+	s = strings.ToUpper(s)
+
+	var b strings.Builder
+	for _, r := range s {
+		// ひらがな→カタカナ
+		if r >= 'ぁ' && r <= 'ゖ' {
+			r += 'ァ' - 'ぁ'
+		}
+		b.WriteRune(r)
+	}
+	s = b.String()
+
+	// 法人略語（全銀協の振込依頼人名で使われる表記）
+	for _, abbr := range []string{"カ)", "(カ", "ユ)", "(ユ", "ド)", "(ド", "シヤ)", "(シヤ", "株式会社", "有限会社", "合同会社"} {
+		s = strings.ReplaceAll(s, abbr, "")
+	}
+	s = strings.NewReplacer(" ", "", "　", "", "(", "", ")", "", ".", "", "-", "").Replace(s)
+
+	return s
+}
+
+// splitZenginRecords 全銀ファイルをレコード単位に分割
+func splitZenginRecords(data []byte, recordLength int) [][]byte {
+	records := make([][]byte, 0)
+	data = bytes.TrimRight(data, "\x1a") // EOFマーク
+
+	if bytes.ContainsAny(data, "\r\n") {
+		for _, line := range bytes.Split(bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n")), []byte("\n")) {
+			if len(bytes.TrimSpace(line)) == 0 {
+				continue
+			}
+			records = append(records, padRecord(line, recordLength))
+		}
+		return records
+	}
+
+	for start := 0; start < len(data); start += recordLength {
+		end := start + recordLength
+		if end > len(data) {
+			end = len(data)
+		}
+		records = append(records, padRecord(data[start:end], recordLength))
+	}
+	return records
+}
+
+// padRecord 末尾の空白が削られたレコードを固定長に補完
+func padRecord(rec []byte, recordLength int) []byte {
+	if len(rec) >= recordLength {
+		return rec[:recordLength]
+	}
+	padded := make([]byte, recordLength)
+	copy(padded, rec)
+	for i := len(rec); i < recordLength; i++ {
+		padded[i] = ' '
+	}
+	return padded
+}
+
+// decodeShiftJIS Shift_JISバイト列をUTF-8文字列に変換（前後の空白を除去）
+func decodeShiftJIS(b []byte) string {
+	decoded, _, err := transform.Bytes(japanese.ShiftJIS.NewDecoder(), b)
+	if err != nil {
+		return strings.TrimSpace(string(b))
+	}
+	return strings.TrimSpace(string(decoded))
+}
+
+// parseZenginDate 全銀フォーマットの日付（和暦YYMMDD、令和）をパース
+func parseZenginDate(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if len(s) != 6 {
+		return time.Time{}, fmt.Errorf("invalid date %q", s)
+	}
+	yy, err1 := strconv.Atoi(s[0:2])
+	mm, err2 := strconv.Atoi(s[2:4])
+	dd, err3 := strconv.Atoi(s[4:6])
+	if err1 != nil || err2 != nil || err3 != nil || mm < 1 || mm > 12 || dd < 1 || dd > 31 {
+		return time.Time{}, fmt.Errorf("invalid date %q", s)
+	}
+	// 令和元年 = 2019年
+	return time.Date(2018+yy, time.Month(mm), dd, 0, 0, 0, 0, time.Local), nil
+}
+
+// parseCSVDate CSV明細の日付をパース
+func parseCSVDate(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range []string{"2006/01/02", "2006-01-02", "2006/1/2", "20060102", "2006年1月2日"} {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", s)
+}
+
+// findCSVColumn ヘッダー名から列番号を取得（見つからない場合は-1）
+func findCSVColumn(header []string, names ...string) int {
+	for _, name := range names {
+		for i, h := range header {
+			if strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")) == name {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// csvField 列番号からフィールド値を取得（範囲外の場合は空文字）
+func csvField(row []string, col int) string {
+	if col < 0 || col >= len(row) {
+		return ""
+	}
+	return strings.TrimSpace(row[col])
+}
+
+// 総合振込（全銀協フォーマット、固定長120バイト）
+
+const (
+	zenginTransferRecordLength = 120  // 総合振込のレコード長
+	zenginTransferTypeCode     = "21" // 種別コード: 総合振込
+	zenginCodeTypeShiftJIS     = "0"  // コード区分: JIS（Shift_JIS）
+)
+
+// ZenginTransferHeader 総合振込ヘッダー（振込依頼人 = 自社口座）
+type ZenginTransferHeader struct {
+	ClientCode     string    // 振込依頼人コード（10桁）
+	ClientName     string    // 振込依頼人名（カナ）
+	TransferDate   time.Time // 取組日（振込指定日）
+	BankCode       string    // 仕向銀行番号
+	BankNameKana   string    // 仕向銀行名
+	BranchCode     string    // 仕向支店番号
+	BranchNameKana string    // 仕向支店名
+	AccountType    string    // 預金種目
+	AccountNumber  string    // 口座番号
+}
+
+// ZenginTransferRecord 総合振込データ（受取人1件分）
+type ZenginTransferRecord struct {
+	BankCode       string // 被仕向銀行番号
+	BankNameKana   string // 被仕向銀行名
+	BranchCode     string // 被仕向支店番号
+	BranchNameKana string // 被仕向支店名
+	AccountType    string // 預金種目
+	AccountNumber  string // 口座番号
+	PayeeName      string // 受取人名（カナ）
+	Amount         int64  // 振込金額
+	CustomerCode1  string // 顧客コード1（支払明細の照合用）
+	CustomerCode2  string // 顧客コード2
+}
+
+// GenerateZenginTransferFile 全銀協 総合振込ファイルを生成
+// ヘッダー・データ・トレーラ・エンドの各レコードをCRLF区切りで出力（Shift_JIS）
+func GenerateZenginTransferFile(header *ZenginTransferHeader, records []*ZenginTransferRecord) ([]byte, error) {
+	if len(records) == 0 {
+		return nil, fmt.Errorf("at least one transfer record is required")
+	}
+
+	var buf bytes.Buffer
+
+	// ヘッダー・レコード
+	w := newZenginRecordWriter(zenginTransferRecordLength)
+	w.alpha("1", 1)
+	w.numeric(zenginTransferTypeCode, 2)
+	w.alpha(zenginCodeTypeShiftJIS, 1)
+	w.numeric(header.ClientCode, 10)
+	w.alpha(header.ClientName, 40)
+	w.numeric(header.TransferDate.Format("0102"), 4)
+	w.numeric(header.BankCode, 4)
+	w.alpha(header.BankNameKana, 15)
+	w.numeric(header.BranchCode, 3)
+	w.alpha(header.BranchNameKana, 15)
+	w.numeric(header.AccountType, 1)
+	w.numeric(header.AccountNumber, 7)
+	w.alpha("", 17)
+	if err := w.writeTo(&buf); err != nil {
+		return nil, fmt.Errorf("invalid transfer header: %w", err)
+	}
+
+	// データ・レコード
+	var total int64
+	for i, rec := range records {
+		if err := writeZenginTransferRecord(&buf, rec); err != nil {
+			return nil, fmt.Errorf("invalid transfer record %d: %w", i+1, err)
+		}
+		total += rec.Amount
+	}
+
+	// トレーラ・レコード
+	w = newZenginRecordWriter(zenginTransferRecordLength)
+	w.alpha("8", 1)
+	w.numeric(strconv.Itoa(len(records)), 6)
+	w.numeric(strconv.FormatInt(total, 10), 12)
+	w.alpha("", 101)
+	if err := w.writeTo(&buf); err != nil {
+		return nil, fmt.Errorf("invalid transfer trailer: %w", err)
+	}
+
+	// エンド・レコード
+	w = newZenginRecordWriter(zenginTransferRecordLength)
+	w.alpha("9", 1)
+	w.alpha("", 119)
+	if err := w.writeTo(&buf); err != nil {
+		return nil, fmt.Errorf("invalid transfer end record: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// ValidateZenginTransferRecord 総合振込データとして出力可能か検証
+// 口座登録時に、半角カナ化できない名義や桁数超過を早期に検出するために使用
+func ValidateZenginTransferRecord(rec *ZenginTransferRecord) error {
+	var buf bytes.Buffer
+	return writeZenginTransferRecord(&buf, rec)
+}
+
+// writeZenginTransferRecord 総合振込データ・レコードを1件書き出す
+func writeZenginTransferRecord(buf *bytes.Buffer, rec *ZenginTransferRecord) error {
+	if rec.Amount <= 0 {
+		return fmt.Errorf("amount must be greater than 0")
+	}
+
+	w := newZenginRecordWriter(zenginTransferRecordLength)
+	w.alpha("2", 1)
+	w.numeric(rec.BankCode, 4)
+	w.alpha(rec.BankNameKana, 15)
+	w.numeric(rec.BranchCode, 3)
+	w.alpha(rec.BranchNameKana, 15)
+	w.alpha("", 4) // 手形交換所番号（未使用）
+	w.numeric(rec.AccountType, 1)
+	w.numeric(rec.AccountNumber, 7)
+	w.alpha(rec.PayeeName, 30)
+	w.numeric(strconv.FormatInt(rec.Amount, 10), 10)
+	w.alpha("0", 1) // 新規コード: その他
+	w.alpha(rec.CustomerCode1, 10)
+	w.alpha(rec.CustomerCode2, 10)
+	w.alpha("", 1) // 振込指定区分
+	w.alpha("", 1) // 識別表示
+	w.alpha("", 7)
+	return w.writeTo(buf)
+}
+
+// zenginRecordWriter 固定長レコードの組み立て
+// 最初に発生したエラーを保持し、writeToで返す
+type zenginRecordWriter struct {
+	buf    []byte
+	length int
+	err    error
+}
+
+// newZenginRecordWriter zenginRecordWriterのコンストラクタ
+func newZenginRecordWriter(length int) *zenginRecordWriter {
+	return &zenginRecordWriter{
+		buf:    make([]byte, 0, length),
+		length: length,
+	}
+}
+
+// numeric 数字項目（右詰め・前ゼロ埋め）
+func (w *zenginRecordWriter) numeric(value string, size int) {
+	if w.err != nil {
+		return
+	}
+	value = strings.TrimSpace(value)
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			w.err = fmt.Errorf("numeric field %q contains non-digit characters", value)
+			return
+		}
+	}
+	if len(value) > size {
+		w.err = fmt.Errorf("numeric field %q exceeds %d digits", value, size)
+		return
+	}
+	w.buf = append(w.buf, strings.Repeat("0", size-len(value))+value...)
+}
+
+// alpha 英数カナ項目（左詰め・スペース埋め、超過分は切り捨て）
+// 全角カナ・英小文字は半角大文字に変換し、半角にできない文字はエラーとする
+func (w *zenginRecordWriter) alpha(value string, size int) {
+	if w.err != nil {
+		return
+	}
+	// 濁点・半濁点を分解してから半角化する（ダ → ﾀﾞ）
+	value = strings.ToUpper(width.Narrow.String(norm.NFD.String(value)))
+	encoded, _, err := transform.Bytes(japanese.ShiftJIS.NewEncoder(), []byte(value))
+	if err != nil || len(encoded) != utf8.RuneCountInString(value) {
+		w.err = fmt.Errorf("field %q must contain only half-width kana and alphanumerics", value)
+		return
+	}
+	if len(encoded) > size {
+		encoded = encoded[:size]
+	}
+	w.buf = append(w.buf, encoded...)
+	w.buf = append(w.buf, bytes.Repeat([]byte(" "), size-len(encoded))...)
+}
+
+// writeTo レコードをCRLF付きで書き出す
+func (w *zenginRecordWriter) writeTo(buf *bytes.Buffer) error {
+	if w.err != nil {
+		return w.err
+	}
+	if len(w.buf) != w.length {
+		return fmt.Errorf("record length %d does not match %d", len(w.buf), w.length)
+	}
+	buf.Write(w.buf)
+	buf.WriteString("\r\n")
+	return nil
+}
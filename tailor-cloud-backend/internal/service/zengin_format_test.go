@@ -0,0 +1,232 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/text/encoding/japanese"
+
+	"tailor-cloud/backend/internal/config/domain"
+)
+
+// buildStatementRecord テスト用に固定長200バイトのレコードを組み立てる
+// fields: バイト位置 → 値（Shift_JIS変換後に配置）
+func buildStatementRecord(t *testing.T, recordType byte, fields map[int]string) []byte {
+	t.Helper()
+	rec := []byte(strings.Repeat(" ", zenginStatementRecordLength))
+	rec[0] = recordType
+	for pos, value := range fields {
+		encoded, err := japanese.ShiftJIS.NewEncoder().Bytes([]byte(value))
+		if err != nil {
+			t.Fatalf("failed to encode %q: %v", value, err)
+		}
+		copy(rec[pos:], encoded)
+	}
+	return rec
+}
+
+// TestParseZenginStatement 全銀協 入出金取引明細のパーステスト
+func TestParseZenginStatement(t *testing.T) {
+	var data []byte
+	data = append(data, buildStatementRecord(t, '1', map[int]string{1: "03"})...)
+	data = append(data, '\r', '\n')
+	// 入金レコード（令和7年4月15日、110,000円）
+	data = append(data, buildStatementRecord(t, '2', map[int]string{
+		1:   "00000001",
+		9:   "070415",
+		21:  "1",
+		24:  "000000110000",
+		81:  "ｶ)ﾔﾏﾀﾞｼｮｳｼﾞ 1A2B3C4D",
+		129: "ﾐｽﾞﾎ",
+	})...)
+	data = append(data, '\r', '\n')
+	// 出金レコード（取込対象外）
+	data = append(data, buildStatementRecord(t, '2', map[int]string{
+		1:  "00000002",
+		9:  "070416",
+		21: "2",
+		24: "000000005000",
+	})...)
+	data = append(data, '\r', '\n')
+	data = append(data, buildStatementRecord(t, '8', map[int]string{1: "000001"})...)
+	data = append(data, '\r', '\n')
+	data = append(data, buildStatementRecord(t, '9', nil)...)
+
+	lines, err := ParseZenginStatement(data)
+	if err != nil {
+		t.Fatalf("ParseZenginStatement returned error: %v", err)
+	}
+	if len(lines) != 1 {
+		t.Fatalf("Expected 1 deposit line, got %d", len(lines))
+	}
+
+	line := lines[0]
+	if line.Amount != 110000 {
+		t.Errorf("Expected amount 110000, got %d", line.Amount)
+	}
+	if !line.TransactionDate.Equal(time.Date(2025, 4, 15, 0, 0, 0, 0, time.Local)) {
+		t.Errorf("Expected transaction date 2025-04-15, got %s", line.TransactionDate)
+	}
+	if line.PayerName != "ｶ)ﾔﾏﾀﾞｼｮｳｼﾞ 1A2B3C4D" {
+		t.Errorf("Unexpected payer name: %q", line.PayerName)
+	}
+	if line.RemitterBankName != "ﾐｽﾞﾎ" {
+		t.Errorf("Unexpected remitter bank name: %q", line.RemitterBankName)
+	}
+}
+
+// TestParseZenginStatement_TrailerMismatch トレーラの入金件数不一致はエラー
+func TestParseZenginStatement_TrailerMismatch(t *testing.T) {
+	var data []byte
+	data = append(data, buildStatementRecord(t, '1', nil)...)
+	data = append(data, buildStatementRecord(t, '2', map[int]string{9: "070415", 21: "1", 24: "000000001000"})...)
+	data = append(data, buildStatementRecord(t, '8', map[int]string{1: "000002"})...)
+
+	if _, err := ParseZenginStatement(data); err == nil {
+		t.Error("Expected error for trailer count mismatch, got nil")
+	}
+}
+
+// TestParseStatementCSV CSV明細のパーステスト（出金行はスキップ）
+func TestParseStatementCSV(t *testing.T) {
+	csvData := "取引日,お取引内容,出金額,入金額\n" +
+		"2025/04/15,ﾔﾏﾀﾞ ﾀﾛｳ,,\"55,000\"\n" +
+		"2025/04/16,ﾃｽｳﾘｮｳ,440,\n"
+
+	lines, err := ParseStatementCSV([]byte(csvData))
+	if err != nil {
+		t.Fatalf("ParseStatementCSV returned error: %v", err)
+	}
+	if len(lines) != 1 {
+		t.Fatalf("Expected 1 deposit line, got %d", len(lines))
+	}
+	if lines[0].Amount != 55000 {
+		t.Errorf("Expected amount 55000, got %d", lines[0].Amount)
+	}
+	if lines[0].PayerName != "ﾔﾏﾀﾞ ﾀﾛｳ" {
+		t.Errorf("Unexpected payer name: %q", lines[0].PayerName)
+	}
+}
+
+// TestNormalizeKana 半角カナ・ひらがな・法人略語の正規化テスト
+func TestNormalizeKana(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"ﾔﾏﾀﾞ ﾀﾛｳ", "ヤマダタロウ"},
+		{"やまだ　たろう", "ヤマダタロウ"},
+		{"ｶ)ﾔﾏﾀﾞｼｮｳｼﾞ", "ヤマダショウジ"},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeKana(tt.input); got != tt.expected {
+			t.Errorf("NormalizeKana(%q) = %q, expected %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+// TestScoreCandidates 金額・名義・請求書番号による候補スコアリングのテスト
+func TestScoreCandidates(t *testing.T) {
+	orderA := &domain.Order{ID: "1a2b3c4d-0000-0000-0000-000000000000", CustomerID: "c1"}
+	orderB := &domain.Order{ID: "9f9f9f9f-0000-0000-0000-000000000000", CustomerID: "c2"}
+	invoices := []*openInvoice{
+		{order: orderA, customerName: "ヤマダショウジ", amountDue: 110000},
+		{order: orderB, customerName: "スズキ", amountDue: 110000},
+	}
+
+	entry := &domain.BankStatementEntry{
+		Amount:    110000,
+		PayerName: "ｶ)ﾔﾏﾀﾞｼｮｳｼﾞ 1A2B3C4D",
+	}
+
+	candidates := scoreCandidates(entry, invoices)
+	if len(candidates) != 2 {
+		t.Fatalf("Expected 2 candidates, got %d", len(candidates))
+	}
+	if candidates[0].OrderID != orderA.ID {
+		t.Errorf("Expected best candidate %s, got %s", orderA.ID, candidates[0].OrderID)
+	}
+	expectedScore := matchScoreInvoiceReference + matchScoreAmount + matchScorePayerExact
+	if candidates[0].Score != expectedScore {
+		t.Errorf("Expected score %d, got %d", expectedScore, candidates[0].Score)
+	}
+	if candidates[1].Score != matchScoreAmount {
+		t.Errorf("Expected amount-only score %d, got %d", matchScoreAmount, candidates[1].Score)
+	}
+}
+
+// TestGenerateZenginTransferFile 総合振込ファイル生成のテスト
+func TestGenerateZenginTransferFile(t *testing.T) {
+	header := &ZenginTransferHeader{
+		ClientCode:     "1234567890",
+		ClientName:     "ｶ)ﾃｲﾗｰｸﾗｳﾄﾞ",
+		TransferDate:   time.Date(2025, 5, 30, 0, 0, 0, 0, time.Local),
+		BankCode:       "0001",
+		BankNameKana:   "ﾐｽﾞﾎ",
+		BranchCode:     "001",
+		BranchNameKana: "ﾄｳｷﾖｳ",
+		AccountType:    "1",
+		AccountNumber:  "1234567",
+	}
+	records := []*ZenginTransferRecord{
+		{BankCode: "0005", BranchCode: "123", AccountType: "1", AccountNumber: "7654321", PayeeName: "ヤマダホウセイ", Amount: 110000, CustomerCode1: "1A2B3C4D"},
+		{BankCode: "0009", BranchCode: "456", AccountType: "1", AccountNumber: "1111", PayeeName: "ｽｽﾞｷ ｲﾁﾛｳ", Amount: 5000},
+	}
+
+	file, err := GenerateZenginTransferFile(header, records)
+	if err != nil {
+		t.Fatalf("GenerateZenginTransferFile returned error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(string(file), "\r\n"), "\r\n")
+	if len(lines) != 5 {
+		t.Fatalf("Expected 5 records (header, 2 data, trailer, end), got %d", len(lines))
+	}
+	for i, line := range lines {
+		if len(line) != zenginTransferRecordLength {
+			t.Errorf("Record %d: expected %d bytes, got %d", i+1, zenginTransferRecordLength, len(line))
+		}
+	}
+
+	// ヘッダー: データ区分 + 種別コード21 + コード区分0 + 依頼人コード
+	if !strings.HasPrefix(lines[0], "12101234567890") {
+		t.Errorf("Unexpected header prefix: %q", lines[0][:14])
+	}
+	// 取組日（MMDD）
+	if lines[0][54:58] != "0530" {
+		t.Errorf("Expected transfer date 0530, got %q", lines[0][54:58])
+	}
+	// データ: 口座番号は前ゼロ埋め、金額は10桁
+	if lines[2][43:50] != "0001111" {
+		t.Errorf("Expected zero-padded account number, got %q", lines[2][43:50])
+	}
+	if lines[1][80:90] != "0000110000" {
+		t.Errorf("Expected amount 0000110000, got %q", lines[1][80:90])
+	}
+	// 受取人名は半角カナに変換される
+	payee, _ := japanese.ShiftJIS.NewDecoder().String(strings.TrimSpace(lines[1][50:80]))
+	if payee != "ﾔﾏﾀﾞﾎｳｾｲ" {
+		t.Errorf("Expected half-width payee name, got %q", payee)
+	}
+	// トレーラ: 件数・合計金額
+	if lines[3][:19] != "8000002000000115000" {
+		t.Errorf("Unexpected trailer: %q", lines[3][:19])
+	}
+	if lines[4][0] != '9' {
+		t.Errorf("Expected end record, got %q", lines[4][0])
+	}
+}
+
+// TestGenerateZenginTransferFile_InvalidName 半角にできない名義はエラー
+func TestGenerateZenginTransferFile_InvalidName(t *testing.T) {
+	header := &ZenginTransferHeader{ClientCode: "1", TransferDate: time.Now(), BankCode: "1", BranchCode: "1", AccountType: "1", AccountNumber: "1"}
+	records := []*ZenginTransferRecord{
+		{BankCode: "0005", BranchCode: "123", AccountType: "1", AccountNumber: "1", PayeeName: "山田縫製", Amount: 1000},
+	}
+
+	if _, err := GenerateZenginTransferFile(header, records); err == nil {
+		t.Error("Expected error for kanji payee name, got nil")
+	}
+}
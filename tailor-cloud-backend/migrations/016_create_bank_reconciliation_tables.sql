@@ -0,0 +1,58 @@
+-- ============================================================================
+-- TailorCloud: 入金消込 - 入出金明細・入金取引テーブル作成
+-- ============================================================================
+-- 目的: 全銀協フォーマット/CSVの入出金明細を取り込み、請求（注文）と消込する
+--       消込確定時に入金Transactionを作成し、未消込の入金は仮受として残す
+-- ============================================================================
+
+-- Transactions (入金取引) テーブル
+CREATE TABLE IF NOT EXISTS transactions (
+    id VARCHAR(255) PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    order_id VARCHAR(255) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'Pending',
+    payment_method VARCHAR(50) NOT NULL, -- bank_transfer / stripe など
+    amount BIGINT NOT NULL, -- 入金額（円）
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    CONSTRAINT transactions_status_check CHECK (status IN ('Pending', 'Completed', 'Failed'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_transactions_tenant_id ON transactions(tenant_id);
+CREATE INDEX IF NOT EXISTS idx_transactions_order_id ON transactions(order_id);
+
+-- Bank Statement Entries (入出金明細) テーブル
+CREATE TABLE IF NOT EXISTS bank_statement_entries (
+    id VARCHAR(255) PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    import_id VARCHAR(255) NOT NULL, -- 取込バッチID
+    source_format VARCHAR(20) NOT NULL, -- ZENGIN / CSV
+    reference_no VARCHAR(50), -- 照会番号
+    transaction_date DATE NOT NULL, -- 勘定日
+    amount BIGINT NOT NULL, -- 入金額（円）
+    payer_name VARCHAR(255), -- 振込依頼人名（カナ）
+    payer_code VARCHAR(50), -- 振込依頼人コード
+    remitter_bank_name VARCHAR(255), -- 仕向銀行名
+    description TEXT, -- 摘要 / EDI情報
+    status VARCHAR(20) NOT NULL DEFAULT 'UNMATCHED',
+    matched_order_id VARCHAR(255), -- 消込先の注文ID
+    transaction_id VARCHAR(255), -- 作成された入金Transaction
+    reconciled_by VARCHAR(255),
+    reconciled_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    CONSTRAINT bank_statement_entries_status_check CHECK (status IN ('UNMATCHED', 'MATCHED', 'IGNORED')),
+    CONSTRAINT bank_statement_entries_format_check CHECK (source_format IN ('ZENGIN', 'CSV'))
+);
+
+-- インデックス作成
+CREATE INDEX IF NOT EXISTS idx_bank_statement_entries_tenant_id ON bank_statement_entries(tenant_id);
+CREATE INDEX IF NOT EXISTS idx_bank_statement_entries_import_id ON bank_statement_entries(import_id);
+CREATE INDEX IF NOT EXISTS idx_bank_statement_entries_tenant_status ON bank_statement_entries(tenant_id, status);
+CREATE INDEX IF NOT EXISTS idx_bank_statement_entries_matched_order_id ON bank_statement_entries(matched_order_id);
+
+-- コメント追加
+COMMENT ON TABLE transactions IS '入金取引テーブル（消込確定時に作成）';
+COMMENT ON TABLE bank_statement_entries IS '入出金明細テーブル（未消込の入金は仮受として残る）';
+COMMENT ON COLUMN bank_statement_entries.payer_name IS '振込依頼人名（半角カナ）';
+COMMENT ON COLUMN bank_statement_entries.status IS 'UNMATCHED: 未消込（仮受）, MATCHED: 消込済み, IGNORED: 対象外';
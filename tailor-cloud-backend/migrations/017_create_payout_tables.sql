@@ -0,0 +1,89 @@
+-- ============================================================================
+-- TailorCloud: 支払業務 - 銀行口座・支払バッチテーブル作成
+-- ============================================================================
+-- 目的: 工場への下請代金（支払期日）とアンバサダー成果報酬を
+--       全銀協 総合振込ファイルとして出力し、振込予定→振込完了を管理する
+-- ============================================================================
+
+-- Bank Accounts (銀行口座) テーブル
+CREATE TABLE IF NOT EXISTS bank_accounts (
+    id VARCHAR(255) PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    owner_type VARCHAR(20) NOT NULL, -- TENANT / FACTORY / AMBASSADOR
+    owner_id VARCHAR(255) NOT NULL,
+    bank_code CHAR(4) NOT NULL,
+    bank_name_kana VARCHAR(15),
+    branch_code CHAR(3) NOT NULL,
+    branch_name_kana VARCHAR(15),
+    account_type CHAR(1) NOT NULL DEFAULT '1', -- 1:普通 2:当座 4:貯蓄
+    account_number VARCHAR(7) NOT NULL,
+    account_holder_kana VARCHAR(40) NOT NULL,
+    client_code VARCHAR(10), -- 振込依頼人コード（自社口座のみ）
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    CONSTRAINT bank_accounts_owner_type_check CHECK (owner_type IN ('TENANT', 'FACTORY', 'AMBASSADOR')),
+    CONSTRAINT bank_accounts_account_type_check CHECK (account_type IN ('1', '2', '4'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_bank_accounts_tenant_owner ON bank_accounts(tenant_id, owner_type, owner_id);
+
+-- Payout Batches (支払バッチ) テーブル
+CREATE TABLE IF NOT EXISTS payout_batches (
+    id VARCHAR(255) PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    due_by DATE NOT NULL, -- 対象とする支払期日
+    transfer_date DATE NOT NULL, -- 振込指定日
+    status VARCHAR(20) NOT NULL DEFAULT 'SCHEDULED',
+    item_count INTEGER NOT NULL DEFAULT 0,
+    total_amount BIGINT NOT NULL DEFAULT 0,
+    file_hash VARCHAR(64), -- 総合振込ファイルのSHA256
+    created_by VARCHAR(255) NOT NULL,
+    confirmed_by VARCHAR(255),
+    confirmed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    CONSTRAINT payout_batches_status_check CHECK (status IN ('SCHEDULED', 'PAID', 'CANCELLED'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_payout_batches_tenant_id ON payout_batches(tenant_id);
+CREATE INDEX IF NOT EXISTS idx_payout_batches_tenant_status ON payout_batches(tenant_id, status);
+
+-- Payout Items (支払明細) テーブル
+CREATE TABLE IF NOT EXISTS payout_items (
+    id VARCHAR(255) PRIMARY KEY,
+    batch_id VARCHAR(255) NOT NULL REFERENCES payout_batches(id) ON DELETE CASCADE,
+    tenant_id VARCHAR(255) NOT NULL,
+    item_type VARCHAR(30) NOT NULL, -- FACTORY_PAYMENT / COMMISSION
+    reference_id VARCHAR(255) NOT NULL, -- 注文ID / 成果報酬ID
+    due_date DATE NOT NULL,
+    amount BIGINT NOT NULL,
+    bank_account_id VARCHAR(255) NOT NULL,
+    bank_code CHAR(4) NOT NULL, -- 以下、振込時点の口座情報のスナップショット
+    bank_name_kana VARCHAR(15),
+    branch_code CHAR(3) NOT NULL,
+    branch_name_kana VARCHAR(15),
+    account_type CHAR(1) NOT NULL,
+    account_number VARCHAR(7) NOT NULL,
+    account_holder_kana VARCHAR(40) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'SCHEDULED',
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    CONSTRAINT payout_items_type_check CHECK (item_type IN ('FACTORY_PAYMENT', 'COMMISSION')),
+    CONSTRAINT payout_items_status_check CHECK (status IN ('SCHEDULED', 'PAID', 'CANCELLED'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_payout_items_batch_id ON payout_items(batch_id);
+CREATE INDEX IF NOT EXISTS idx_payout_items_reference ON payout_items(tenant_id, item_type, reference_id);
+
+-- 同一の支払義務が複数の有効なバッチに含まれないようにする
+CREATE UNIQUE INDEX IF NOT EXISTS idx_payout_items_active_reference
+    ON payout_items(tenant_id, item_type, reference_id)
+    WHERE status IN ('SCHEDULED', 'PAID');
+
+-- コメント追加
+COMMENT ON TABLE bank_accounts IS '振込用銀行口座（自社・工場・アンバサダー）';
+COMMENT ON TABLE payout_batches IS '支払バッチ（全銀協 総合振込ファイル1本分）';
+COMMENT ON TABLE payout_items IS '支払明細（振込1件分）';
+COMMENT ON COLUMN bank_accounts.client_code IS '振込依頼人コード（銀行から付与される委託者コード）';
+COMMENT ON COLUMN payout_items.reference_id IS 'FACTORY_PAYMENT: 注文ID, COMMISSION: 成果報酬ID';
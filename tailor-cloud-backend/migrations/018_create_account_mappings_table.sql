@@ -0,0 +1,34 @@
+-- ============================================================================
+-- TailorCloud: 会計連携 - 勘定科目マッピングテーブル作成
+-- ============================================================================
+-- 目的: 請求・入金・成果報酬・下請代金の仕訳を freee / マネーフォワード / 弥生 の
+--       インポート形式で出力する際の、テナントごとの勘定科目名・コードを管理する
+-- ============================================================================
+
+-- Account Mappings (勘定科目マッピング) テーブル
+CREATE TABLE IF NOT EXISTS account_mappings (
+    id VARCHAR(255) PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    account_key VARCHAR(50) NOT NULL, -- SALES / ACCOUNTS_RECEIVABLE / ...
+    account_name VARCHAR(100) NOT NULL, -- 勘定科目名
+    sub_account_name VARCHAR(100), -- 補助科目名
+    account_code VARCHAR(20), -- 会計ソフト側の科目コード
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    CONSTRAINT account_mappings_tenant_key_unique UNIQUE (tenant_id, account_key),
+    CONSTRAINT account_mappings_account_key_check CHECK (account_key IN (
+        'ACCOUNTS_RECEIVABLE', 'SALES', 'BANK_DEPOSIT', 'OUTSOURCING_COST',
+        'ACCOUNTS_PAYABLE', 'COMMISSION_EXPENSE', 'ACCRUED_EXPENSES'
+    ))
+);
+
+-- インデックス作成
+CREATE INDEX IF NOT EXISTS idx_account_mappings_tenant_id ON account_mappings(tenant_id);
+
+-- 入金取引の期間検索用
+CREATE INDEX IF NOT EXISTS idx_transactions_tenant_created_at ON transactions(tenant_id, created_at);
+
+-- コメント追加
+COMMENT ON TABLE account_mappings IS '勘定科目マッピングテーブル（未設定の科目は会計ソフトの標準科目名を使用）';
+COMMENT ON COLUMN account_mappings.account_key IS 'システム内の勘定科目キー';
+COMMENT ON COLUMN account_mappings.account_name IS '会計ソフトに出力する勘定科目名';
@@ -0,0 +1,48 @@
+-- ============================================================================
+-- TailorCloud: 見積書テーブル作成
+-- ============================================================================
+-- 目的: 注文（Draft）の前段階として見積書を発行し、有効期限（30日）・改訂履歴・
+--       顧客承諾・注文への変換を管理する
+-- ============================================================================
+
+-- Quotes (見積書) テーブル
+CREATE TABLE IF NOT EXISTS quotes (
+    id VARCHAR(255) PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    quote_group_id VARCHAR(255) NOT NULL, -- 全バージョン共通のID（初版のID）
+    version INTEGER NOT NULL DEFAULT 1,
+    customer_id VARCHAR(255) NOT NULL,
+    fabric_id VARCHAR(255) NOT NULL,
+    fabric_length NUMERIC(6, 2) NOT NULL DEFAULT 0, -- 用尺（メートル）
+    plan_type VARCHAR(50),
+    status VARCHAR(20) NOT NULL DEFAULT 'Draft',
+    lines JSONB NOT NULL DEFAULT '[]', -- 見積明細（バージョンごとのスナップショット）
+    total_amount BIGINT NOT NULL DEFAULT 0, -- 税抜金額（円）
+    tax_rate NUMERIC(4, 2) NOT NULL DEFAULT 0.10,
+    measurement_data JSONB, -- 採寸データ（注文へ引き継ぐ）
+    adjustments JSONB, -- 補正情報（注文へ引き継ぐ）
+    description TEXT, -- 給付の内容
+    delivery_date DATE, -- 希望納期
+    notes TEXT,
+    valid_until TIMESTAMPTZ NOT NULL,
+    accepted_at TIMESTAMPTZ,
+    converted_order_id VARCHAR(255),
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    created_by VARCHAR(255) NOT NULL,
+    CONSTRAINT quotes_group_version_unique UNIQUE (quote_group_id, version),
+    CONSTRAINT quotes_status_check CHECK (status IN (
+        'Draft', 'Sent', 'Accepted', 'Rejected', 'Expired', 'Superseded', 'Converted'
+    ))
+);
+
+-- インデックス作成
+CREATE INDEX IF NOT EXISTS idx_quotes_tenant_id ON quotes(tenant_id);
+CREATE INDEX IF NOT EXISTS idx_quotes_tenant_status ON quotes(tenant_id, status);
+CREATE INDEX IF NOT EXISTS idx_quotes_quote_group_id ON quotes(quote_group_id);
+CREATE INDEX IF NOT EXISTS idx_quotes_customer_id ON quotes(customer_id);
+
+-- コメント追加
+COMMENT ON TABLE quotes IS '見積書テーブル（改訂ごとに1行、旧版は Superseded）';
+COMMENT ON COLUMN quotes.valid_until IS '有効期限（発行から30日）';
+COMMENT ON COLUMN quotes.converted_order_id IS '変換先の注文ID（Draft注文）';
@@ -0,0 +1,48 @@
+-- ============================================================================
+-- TailorCloud: 価格計算エンジン - 価格表・価格上書き記録テーブル作成
+-- ============================================================================
+-- 目的: 注文金額をサーバー側で計算する（プラン基本価格 + 生地単価 × 用尺 +
+--       オプション加算 + 特急料金）。計算価格と異なる金額で注文を作成する場合は、
+--       権限者による理由付きの上書きとして記録する
+-- ============================================================================
+
+-- Price Book Entries (価格表) テーブル
+CREATE TABLE IF NOT EXISTS price_book_entries (
+    id VARCHAR(255) PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    item_type VARCHAR(20) NOT NULL, -- BASE / OPTION / RUSH
+    code VARCHAR(100) NOT NULL, -- BASE: プランタイプ / OPTION: オプションコード / RUSH: 特急区分
+    category VARCHAR(50), -- オプション分類（LAPEL, LINING, BUTTON 等）
+    name VARCHAR(255) NOT NULL,
+    price BIGINT NOT NULL, -- 税抜価格（円）
+    lead_time_days INTEGER NOT NULL DEFAULT 0, -- RUSH: 納期までの日数がこの値未満で適用
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    CONSTRAINT price_book_entries_tenant_item_unique UNIQUE (tenant_id, item_type, code),
+    CONSTRAINT price_book_entries_item_type_check CHECK (item_type IN ('BASE', 'OPTION', 'RUSH')),
+    CONSTRAINT price_book_entries_price_check CHECK (price >= 0)
+);
+
+-- Order Price Overrides (価格上書き記録) テーブル
+CREATE TABLE IF NOT EXISTS order_price_overrides (
+    id VARCHAR(255) PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    order_id VARCHAR(255) NOT NULL, -- 注文作成前に記録するため外部キーは設定しない
+    computed_amount BIGINT NOT NULL, -- 計算価格（税抜）
+    override_amount BIGINT NOT NULL, -- 採用した金額（税抜）
+    reason TEXT NOT NULL,
+    breakdown JSONB NOT NULL, -- 計算時の価格内訳
+    authorized_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+-- インデックス作成
+CREATE INDEX IF NOT EXISTS idx_price_book_entries_tenant_id ON price_book_entries(tenant_id);
+CREATE INDEX IF NOT EXISTS idx_order_price_overrides_tenant_id ON order_price_overrides(tenant_id);
+CREATE INDEX IF NOT EXISTS idx_order_price_overrides_order_id ON order_price_overrides(order_id);
+
+-- コメント追加
+COMMENT ON TABLE price_book_entries IS '価格表テーブル（テナントごとのプラン基本価格・オプション加算・特急料金）';
+COMMENT ON TABLE order_price_overrides IS '価格上書き記録テーブル（計算価格と異なる金額で作成した注文の承認記録）';
+COMMENT ON COLUMN order_price_overrides.reason IS '上書き理由（必須）';
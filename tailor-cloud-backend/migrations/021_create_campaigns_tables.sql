@@ -0,0 +1,58 @@
+-- ============================================================================
+-- TailorCloud: 割引・クーポン・キャンペーン管理テーブル作成
+-- ============================================================================
+-- 目的: 定率・定額の割引をキャンペーン（自動適用）またはクーポンコードとして管理し、
+--       注文の明示的な割引明細として記録する。割引後の金額を消費税・請求書・
+--       アンバサダー成果報酬の計算基準とする
+-- ============================================================================
+
+-- Campaigns (キャンペーン・クーポン) テーブル
+CREATE TABLE IF NOT EXISTS campaigns (
+    id VARCHAR(255) PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    code VARCHAR(100), -- クーポンコード（NULLの場合は自動適用キャンペーン）
+    discount_type VARCHAR(20) NOT NULL, -- PERCENTAGE / FIXED_AMOUNT
+    value BIGINT NOT NULL, -- 割引率（%）または割引額（円）
+    eligible_archetypes JSONB NOT NULL DEFAULT '[]', -- 対象アーキタイプ（空配列 = 全て）
+    eligible_plan_types JSONB NOT NULL DEFAULT '[]', -- 対象プラン（空配列 = 全て）
+    eligible_fabric_ids JSONB NOT NULL DEFAULT '[]', -- 対象生地（空配列 = 全て）
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ, -- NULLの場合は無期限
+    per_customer_limit INTEGER NOT NULL DEFAULT 0, -- 顧客ごとの利用回数上限（0 = 無制限）
+    stackable BOOLEAN NOT NULL DEFAULT FALSE,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    CONSTRAINT campaigns_discount_type_check CHECK (discount_type IN ('PERCENTAGE', 'FIXED_AMOUNT')),
+    CONSTRAINT campaigns_value_check CHECK (value > 0),
+    CONSTRAINT campaigns_percentage_check CHECK (discount_type <> 'PERCENTAGE' OR value <= 100),
+    CONSTRAINT campaigns_per_customer_limit_check CHECK (per_customer_limit >= 0),
+    CONSTRAINT campaigns_period_check CHECK (ends_at IS NULL OR ends_at > starts_at)
+);
+
+-- Order Discounts (注文の割引明細) テーブル
+CREATE TABLE IF NOT EXISTS order_discounts (
+    id VARCHAR(255) PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    order_id VARCHAR(255) NOT NULL, -- 注文作成前に記録するため外部キーは設定しない
+    customer_id VARCHAR(255) NOT NULL,
+    campaign_id VARCHAR(255) NOT NULL REFERENCES campaigns(id),
+    code VARCHAR(100), -- 利用したクーポンコード
+    description VARCHAR(255) NOT NULL,
+    amount BIGINT NOT NULL, -- 割引額（税抜・円）
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    CONSTRAINT order_discounts_amount_check CHECK (amount > 0)
+);
+
+-- インデックス作成
+CREATE UNIQUE INDEX IF NOT EXISTS idx_campaigns_tenant_code ON campaigns(tenant_id, code) WHERE code IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_campaigns_tenant_active ON campaigns(tenant_id, active);
+CREATE INDEX IF NOT EXISTS idx_order_discounts_order_id ON order_discounts(order_id);
+CREATE INDEX IF NOT EXISTS idx_order_discounts_campaign_customer ON order_discounts(campaign_id, customer_id);
+
+-- コメント追加
+COMMENT ON TABLE campaigns IS 'キャンペーン・クーポンテーブル（定率・定額割引、対象条件、有効期間、利用回数上限、併用可否）';
+COMMENT ON TABLE order_discounts IS '注文の割引明細テーブル（適用した割引の明細行兼クーポン利用記録）';
+COMMENT ON COLUMN campaigns.stackable IS '併用可否（TRUEのキャンペーン同士のみ併用可能）';
+COMMENT ON COLUMN order_discounts.amount IS '割引額（税抜・円）。注文のtotal_amountは割引後の金額';
@@ -0,0 +1,44 @@
+-- ============================================================================
+-- TailorCloud: 注文キャンセルワークフロー - キャンセル記録テーブル作成
+-- ============================================================================
+-- 目的: 注文キャンセル時の理由・到達工程・キャンセル料規定を記録する。
+--       反物引当の解除、成果報酬の取消、発注取消通知書の発行と同一トランザクションで
+--       記録し、下請法上の発注取消の経緯を追跡できるようにする
+-- ============================================================================
+
+-- Order Cancellations (注文キャンセル記録) テーブル
+CREATE TABLE IF NOT EXISTS order_cancellations (
+    id VARCHAR(255) PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    order_id VARCHAR(255) NOT NULL REFERENCES orders(id),
+    reason TEXT NOT NULL,
+    stage VARCHAR(50) NOT NULL, -- キャンセル時点の注文ステータス
+    fee_policy_code VARCHAR(50) NOT NULL,
+    fee_rate_percent INTEGER NOT NULL,
+    order_amount BIGINT NOT NULL, -- 注文金額（税抜）
+    fee_amount BIGINT NOT NULL, -- キャンセル料（税抜）
+    released_allocations INTEGER NOT NULL DEFAULT 0,
+    released_length DECIMAL(10,2) NOT NULL DEFAULT 0,
+    cancelled_commissions INTEGER NOT NULL DEFAULT 0,
+    compliance_document_id VARCHAR(255), -- 発注取消通知書（発注書未発行の場合はNULL）
+    cancelled_by VARCHAR(255) NOT NULL,
+    cancelled_at TIMESTAMPTZ DEFAULT NOW(),
+    CONSTRAINT order_cancellations_order_unique UNIQUE (order_id),
+    CONSTRAINT order_cancellations_fee_rate_check CHECK (fee_rate_percent BETWEEN 0 AND 100),
+    CONSTRAINT order_cancellations_fee_amount_check CHECK (fee_amount >= 0)
+);
+
+-- 発注取消通知書を文書タイプに追加
+ALTER TABLE compliance_documents DROP CONSTRAINT IF EXISTS compliance_documents_type_check;
+ALTER TABLE compliance_documents ADD CONSTRAINT compliance_documents_type_check
+    CHECK (document_type IN ('INITIAL', 'AMENDMENT', 'CANCELLATION'));
+
+-- インデックス作成
+CREATE INDEX IF NOT EXISTS idx_order_cancellations_tenant_id ON order_cancellations(tenant_id);
+CREATE INDEX IF NOT EXISTS idx_order_cancellations_cancelled_at ON order_cancellations(cancelled_at DESC);
+
+-- コメント追加
+COMMENT ON TABLE order_cancellations IS '注文キャンセル記録テーブル（理由・到達工程・キャンセル料・連動処理の結果）';
+COMMENT ON COLUMN order_cancellations.reason IS 'キャンセル理由（必須）';
+COMMENT ON COLUMN order_cancellations.fee_policy_code IS 'キャンセル料規定コード（NO_FEE, MATERIAL, CUTTING, SEWING, FULL）';
+COMMENT ON COLUMN compliance_documents.document_type IS '文書タイプ: INITIAL（初回発注書）, AMENDMENT（修正発注書）, CANCELLATION（発注取消通知書）';
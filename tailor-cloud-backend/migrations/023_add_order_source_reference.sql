@@ -0,0 +1,16 @@
+-- ============================================================================
+-- TailorCloud: 再注文（注文の複製） - 複製元注文の参照を追加
+-- ============================================================================
+-- 目的: 過去の注文を複製して作成した注文に複製元の注文IDを保持し、
+--       リピート注文の系譜（どの注文から作られたか）を追跡できるようにする
+-- ============================================================================
+
+-- 注文テーブルに複製元注文IDを追加
+ALTER TABLE orders
+ADD COLUMN IF NOT EXISTS source_order_id VARCHAR(255) REFERENCES orders(id); -- 複製元の注文ID
+
+-- インデックス追加
+CREATE INDEX IF NOT EXISTS idx_orders_source_order_id ON orders(source_order_id) WHERE source_order_id IS NOT NULL;
+
+-- コメント追加
+COMMENT ON COLUMN orders.source_order_id IS '複製元の注文ID（再注文で作成した場合のみ）';
@@ -0,0 +1,27 @@
+-- ============================================================================
+-- TailorCloud: 注文検索・カーソル方式ページネーション用インデックス
+-- ============================================================================
+-- 目的: GET /api/orders の並び替え（作成日時・納期・金額）とキーセット方式の
+--       ページネーション（並び替え項目 + 注文ID）をインデックスで処理する
+-- ============================================================================
+
+-- テナント×作成日時×注文ID（既定の並び順）
+CREATE INDEX IF NOT EXISTS idx_orders_tenant_created_id
+ON orders(tenant_id, created_at DESC, id DESC);
+
+-- テナント×納期×注文ID（納期順・納期範囲での絞り込み）
+CREATE INDEX IF NOT EXISTS idx_orders_tenant_delivery_id
+ON orders(tenant_id, delivery_date, id);
+
+-- テナント×金額×注文ID（金額順・金額範囲での絞り込み）
+CREATE INDEX IF NOT EXISTS idx_orders_tenant_amount_id
+ON orders(tenant_id, total_amount, id);
+
+-- テナント×作成者×作成日時（担当者別の注文一覧）
+CREATE INDEX IF NOT EXISTS idx_orders_tenant_created_by_created
+ON orders(tenant_id, created_by, created_at DESC);
+
+-- コメント追加
+COMMENT ON INDEX idx_orders_tenant_created_id IS '注文検索用（テナント×作成日時×注文ID、キーセット方式ページネーション）';
+COMMENT ON INDEX idx_orders_tenant_delivery_id IS '注文検索用（テナント×納期×注文ID）';
+COMMENT ON INDEX idx_orders_tenant_amount_id IS '注文検索用（テナント×金額×注文ID）';
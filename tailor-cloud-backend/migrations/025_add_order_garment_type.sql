@@ -0,0 +1,16 @@
+-- ============================================================================
+-- TailorCloud: 注文の品目（スーツ・ジャケット・シャツ等）
+-- ============================================================================
+-- 目的: 一括取込・工程計画・検品など品目ごとに扱いが異なる処理のため、
+--       注文に品目を保持する（既存の注文は品目未設定）
+-- ============================================================================
+
+ALTER TABLE orders
+ADD COLUMN IF NOT EXISTS garment_type VARCHAR(50); -- 品目
+
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS orders_garment_type_check;
+ALTER TABLE orders ADD CONSTRAINT orders_garment_type_check
+    CHECK (garment_type IS NULL OR garment_type IN ('SUIT', 'JACKET', 'TROUSERS', 'VEST', 'COAT', 'SHIRT'));
+
+-- コメント追加
+COMMENT ON COLUMN orders.garment_type IS '品目: SUIT, JACKET, TROUSERS, VEST, COAT, SHIRT（未設定の場合はNULL）';
@@ -0,0 +1,39 @@
+-- ============================================================================
+-- TailorCloud: 団体注文（法人・ユニフォーム契約） - 団体注文テーブル作成
+-- ============================================================================
+-- 目的: 1つの法人顧客（支払者）と複数の着用者の注文を束ね、
+--       共通の生地・品目ごとの価格取り決め・まとめての生地引当と一括請求を行う。
+--       着用者ごとの注文は orders.group_order_id で団体注文に紐付ける
+-- ============================================================================
+
+-- Group Orders (団体注文) テーブル
+CREATE TABLE IF NOT EXISTS group_orders (
+    id VARCHAR(255) PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    corporate_customer_id VARCHAR(255) NOT NULL REFERENCES customers(id), -- 支払者（法人顧客）
+    fabric_id VARCHAR(255) NOT NULL, -- 共通の生地
+    prices JSONB NOT NULL, -- 品目ごとの価格取り決め（税抜単価・用尺）
+    delivery_date TIMESTAMPTZ NOT NULL, -- 共通の納期
+    status VARCHAR(20) NOT NULL DEFAULT 'OPEN',
+    notes TEXT,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    created_by VARCHAR(255) NOT NULL,
+    CONSTRAINT group_orders_status_check CHECK (status IN ('OPEN', 'CLOSED'))
+);
+
+-- 注文テーブルに団体注文IDを追加
+ALTER TABLE orders
+ADD COLUMN IF NOT EXISTS group_order_id VARCHAR(255) REFERENCES group_orders(id); -- 団体注文ID
+
+-- インデックス作成
+CREATE INDEX IF NOT EXISTS idx_group_orders_tenant_status ON group_orders(tenant_id, status);
+CREATE INDEX IF NOT EXISTS idx_group_orders_corporate_customer_id ON group_orders(corporate_customer_id);
+CREATE INDEX IF NOT EXISTS idx_orders_group_order_id ON orders(group_order_id) WHERE group_order_id IS NOT NULL;
+
+-- コメント追加
+COMMENT ON TABLE group_orders IS '団体注文テーブル（法人顧客を支払者として着用者ごとの注文を束ねる）';
+COMMENT ON COLUMN group_orders.prices IS '品目ごとの価格取り決め: [{"garment_type", "unit_price", "fabric_length"}]';
+COMMENT ON COLUMN group_orders.status IS 'ステータス: OPEN（受付中）, CLOSED（締切）';
+COMMENT ON COLUMN orders.group_order_id IS '団体注文ID（団体注文の着用者ごとの注文の場合のみ）';
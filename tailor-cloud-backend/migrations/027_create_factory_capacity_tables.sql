@@ -0,0 +1,45 @@
+-- ============================================================================
+-- TailorCloud: 生産計画 - 工場の生産能力・個別設定テーブル作成
+-- ============================================================================
+-- 目的: 作業者数・稼働時間・品目/工程ごとの標準作業時間から1日の生産能力を求め、
+--       確定済みの注文を納期順に日ごとへ割り付ける（生産計画・ガントチャート）。
+--       祝日・夏季休業・残業・休日出勤は日ごとの個別設定で生産能力を上書きする
+-- ============================================================================
+
+-- Factory Capacities (工場の生産能力) テーブル（テナントごとに1件）
+CREATE TABLE IF NOT EXISTS factory_capacities (
+    tenant_id VARCHAR(255) PRIMARY KEY,
+    headcount INTEGER NOT NULL, -- 作業者数
+    hours_per_day NUMERIC(4, 2) NOT NULL, -- 1日の稼働時間
+    working_weekdays JSONB NOT NULL, -- 稼働曜日（0 = 日曜）
+    shipping_days INTEGER NOT NULL DEFAULT 1, -- 検品完了から納品までの日数
+    standard_times JSONB NOT NULL DEFAULT '[]', -- 品目・工程ごとの標準作業時間（分）
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_by VARCHAR(255) NOT NULL,
+    CONSTRAINT factory_capacities_headcount_check CHECK (headcount > 0),
+    CONSTRAINT factory_capacities_hours_check CHECK (hours_per_day > 0 AND hours_per_day <= 24)
+);
+
+-- Capacity Overrides (生産能力の個別設定) テーブル
+CREATE TABLE IF NOT EXISTS capacity_overrides (
+    id VARCHAR(255) PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    date DATE NOT NULL, -- 対象日
+    type VARCHAR(20) NOT NULL, -- HOLIDAY, OVERTIME
+    headcount INTEGER NOT NULL DEFAULT 0, -- 作業者数（0の場合は通常の作業者数）
+    hours_per_day NUMERIC(4, 2) NOT NULL DEFAULT 0, -- 稼働時間（0の場合は通常の稼働時間）
+    note TEXT,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    created_by VARCHAR(255) NOT NULL,
+    CONSTRAINT capacity_overrides_type_check CHECK (type IN ('HOLIDAY', 'OVERTIME')),
+    CONSTRAINT capacity_overrides_tenant_date_unique UNIQUE (tenant_id, date)
+);
+
+-- インデックス作成
+CREATE INDEX IF NOT EXISTS idx_capacity_overrides_tenant_date ON capacity_overrides(tenant_id, date);
+
+-- コメント追加
+COMMENT ON TABLE factory_capacities IS '工場の生産能力テーブル（1日の生産能力 = 作業者数 × 稼働時間）';
+COMMENT ON COLUMN factory_capacities.standard_times IS '品目・工程ごとの標準作業時間: [{"garment_type", "process", "minutes"}]（未設定は既定値）';
+COMMENT ON TABLE capacity_overrides IS '生産能力の個別設定テーブル（祝日・夏季休業・残業・休日出勤）';
+COMMENT ON COLUMN capacity_overrides.type IS '種別: HOLIDAY（休業日）, OVERTIME（残業・休日出勤）';
@@ -0,0 +1,37 @@
+-- ============================================================================
+-- TailorCloud: 作業者の工程管理 - 製造タスクテーブル作成
+-- ============================================================================
+-- 目的: 注文・工程（裁断・縫製・検品）ごとの製造タスクを作業者が開始・中断・完了し、
+--       作業時間を記録する。工程の最後のタスクの完了で注文ステータスを次の工程へ進め、
+--       作業者ごとの実績（完了数・作業時間・能率）を集計する
+-- ============================================================================
+
+-- Production Tasks (製造タスク) テーブル
+CREATE TABLE IF NOT EXISTS production_tasks (
+    id VARCHAR(255) PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    order_id VARCHAR(255) NOT NULL REFERENCES orders(id),
+    garment_type VARCHAR(20) NOT NULL,
+    process VARCHAR(20) NOT NULL, -- CUTTING, SEWING, INSPECTION
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+    assigned_to VARCHAR(255), -- 担当作業者（ユーザーID）
+    standard_minutes INTEGER NOT NULL DEFAULT 0, -- 標準作業時間（分）
+    worked_seconds BIGINT NOT NULL DEFAULT 0, -- 積算作業時間（秒、作業中の区間を除く）
+    started_at TIMESTAMPTZ, -- 最初に開始した日時
+    resumed_at TIMESTAMPTZ, -- 作業中の区間の開始日時
+    completed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    CONSTRAINT production_tasks_process_check CHECK (process IN ('CUTTING', 'SEWING', 'INSPECTION')),
+    CONSTRAINT production_tasks_status_check CHECK (status IN ('PENDING', 'IN_PROGRESS', 'PAUSED', 'COMPLETED'))
+);
+
+-- インデックス作成
+CREATE INDEX IF NOT EXISTS idx_production_tasks_order_id ON production_tasks(order_id, process);
+CREATE INDEX IF NOT EXISTS idx_production_tasks_assigned_to ON production_tasks(tenant_id, assigned_to, status);
+CREATE INDEX IF NOT EXISTS idx_production_tasks_completed_at ON production_tasks(tenant_id, completed_at) WHERE status = 'COMPLETED';
+
+-- コメント追加
+COMMENT ON TABLE production_tasks IS '製造タスクテーブル（注文・工程ごとの作業単位と作業時間）';
+COMMENT ON COLUMN production_tasks.status IS 'ステータス: PENDING（未着手）, IN_PROGRESS（作業中）, PAUSED（中断）, COMPLETED（完了）';
+COMMENT ON COLUMN production_tasks.worked_seconds IS '中断・完了時に作業中の区間（resumed_at から）の時間を積算する';
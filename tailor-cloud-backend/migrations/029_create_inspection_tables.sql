@@ -0,0 +1,49 @@
+-- ============================================================================
+-- TailorCloud: 品質検品 - 検品チェックリスト・検品記録テーブル作成
+-- ============================================================================
+-- 目的: 品目ごとの検品チェックリスト（仕上がり寸法の許容差・縫製・プレス等）で
+--       検品結果を項目ごとに合否判定して記録し、写真を添付する。
+--       不合格の場合は手直しの製造タスクを作成して注文を縫製へ戻し、
+--       工場・工程・作業者ごとの不良率を集計する
+-- ============================================================================
+
+-- Inspection Checklists (検品チェックリスト) テーブル
+CREATE TABLE IF NOT EXISTS inspection_checklists (
+    tenant_id VARCHAR(255) NOT NULL,
+    garment_type VARCHAR(20) NOT NULL,
+    items JSONB NOT NULL DEFAULT '[]', -- 検品項目の配列
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_by VARCHAR(255),
+    PRIMARY KEY (tenant_id, garment_type)
+);
+
+-- Inspections (検品記録) テーブル
+CREATE TABLE IF NOT EXISTS inspections (
+    id VARCHAR(255) PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    order_id VARCHAR(255) NOT NULL REFERENCES orders(id),
+    garment_type VARCHAR(20) NOT NULL,
+    inspector_id VARCHAR(255) NOT NULL,
+    passed BOOLEAN NOT NULL,
+    defect_count INTEGER NOT NULL DEFAULT 0, -- 不合格の項目数
+    results JSONB NOT NULL DEFAULT '[]', -- 項目ごとの結果
+    photos JSONB NOT NULL DEFAULT '[]', -- 検品写真
+    responsible_workers JSONB NOT NULL DEFAULT '{}', -- 工程ごとの作業者
+    rework_task_id VARCHAR(255), -- 不合格時に作成した手直しタスク
+    notes TEXT,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+-- 手直しタスクの起点となった検品
+ALTER TABLE production_tasks ADD COLUMN IF NOT EXISTS inspection_id VARCHAR(255);
+
+-- インデックス作成
+CREATE INDEX IF NOT EXISTS idx_inspections_order_id ON inspections(order_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_inspections_tenant_created_at ON inspections(tenant_id, created_at);
+
+-- コメント追加
+COMMENT ON TABLE inspection_checklists IS '検品チェックリストテーブル（テナント・品目ごと）';
+COMMENT ON COLUMN inspection_checklists.items IS '検品項目: code, label, type（MEASUREMENT, STITCHING, PRESSING, VISUAL）, measurement_field, tolerance, process';
+COMMENT ON TABLE inspections IS '検品記録テーブル（項目ごとの合否・写真・責任工程の作業者）';
+COMMENT ON COLUMN inspections.responsible_workers IS '検品時点で工程ごとに最後に完了した製造タスクの担当者（不良率の作業者別集計に使用）';
+COMMENT ON COLUMN production_tasks.inspection_id IS '検品不合格による手直しタスクの場合、起点の検品ID';
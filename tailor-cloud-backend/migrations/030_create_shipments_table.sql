@@ -0,0 +1,44 @@
+-- ============================================================================
+-- TailorCloud: 出荷管理 - 出荷記録テーブル作成
+-- ============================================================================
+-- 目的: 検品済みの注文の出荷（配送業者・伝票番号・個口数・出荷日）を記録し、
+--       配送業者の追跡情報で配達完了を確認して注文を納品完了にする。
+--       実際の配達日を支払期日（下請法60日ルール）の起算日として記録する
+-- ============================================================================
+
+-- Shipments (出荷記録) テーブル
+CREATE TABLE IF NOT EXISTS shipments (
+    id VARCHAR(255) PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    order_id VARCHAR(255) NOT NULL REFERENCES orders(id),
+    carrier VARCHAR(20) NOT NULL, -- YAMATO, SAGAWA, JAPAN_POST
+    tracking_number VARCHAR(50) NOT NULL, -- 伝票番号（ハイフンなし）
+    package_count INTEGER NOT NULL DEFAULT 1, -- 個口数
+    shipped_at DATE NOT NULL, -- 出荷日
+    status VARCHAR(20) NOT NULL DEFAULT 'SHIPPED',
+    recipient_name VARCHAR(255) NOT NULL,
+    recipient_postal_code VARCHAR(10),
+    recipient_address TEXT NOT NULL,
+    recipient_phone VARCHAR(50),
+    notes TEXT,
+    delivered_at TIMESTAMPTZ, -- 実際の配達日時（支払期日の起算日）
+    last_tracked_at TIMESTAMPTZ, -- 最後に追跡情報を取得した日時
+    tracking_detail TEXT, -- 最新の追跡情報
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    created_by VARCHAR(255),
+    CONSTRAINT shipments_order_id_unique UNIQUE (order_id),
+    CONSTRAINT shipments_carrier_check CHECK (carrier IN ('YAMATO', 'SAGAWA', 'JAPAN_POST')),
+    CONSTRAINT shipments_status_check CHECK (status IN ('SHIPPED', 'IN_TRANSIT', 'OUT_FOR_DELIVERY', 'DELIVERED', 'EXCEPTION')),
+    CONSTRAINT shipments_package_count_check CHECK (package_count BETWEEN 1 AND 99)
+);
+
+-- インデックス作成
+CREATE INDEX IF NOT EXISTS idx_shipments_tenant_status ON shipments(tenant_id, status, shipped_at);
+CREATE INDEX IF NOT EXISTS idx_shipments_tracking_number ON shipments(carrier, tracking_number);
+CREATE INDEX IF NOT EXISTS idx_shipments_active ON shipments(last_tracked_at NULLS FIRST) WHERE status <> 'DELIVERED';
+
+-- コメント追加
+COMMENT ON TABLE shipments IS '出荷記録テーブル（注文ごとに1件、複数口は個口数で管理）';
+COMMENT ON COLUMN shipments.status IS 'ステータス: SHIPPED（出荷済み）, IN_TRANSIT（輸送中）, OUT_FOR_DELIVERY（配達中）, DELIVERED（配達完了）, EXCEPTION（配送異常）';
+COMMENT ON COLUMN shipments.delivered_at IS '配達完了日時。受領日から60日以内になるよう注文の支払期日を前倒しする';
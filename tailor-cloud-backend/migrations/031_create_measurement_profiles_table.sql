@@ -0,0 +1,82 @@
+-- ============================================================================
+-- TailorCloud: 顧客の採寸プロファイル - 採寸プロファイル・版・注文の紐付けテーブル作成
+-- ============================================================================
+-- 目的: 注文の採寸データ（orders.measurement_data）とは別に、顧客ごとの採寸履歴を
+--       版（スナップショット）として記録する。版ごとに採寸者・採寸日時・体型メモと、
+--       その版の採寸データを使った注文を記録し、前回採寸との比較や推移のグラフに使う
+-- ============================================================================
+
+-- Measurement Profiles (採寸プロファイル) テーブル
+CREATE TABLE IF NOT EXISTS measurement_profiles (
+    id VARCHAR(255) PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    customer_id VARCHAR(255) NOT NULL,
+    latest_version INTEGER NOT NULL DEFAULT 0, -- 最新版の版番号
+    body_shape_notes TEXT, -- 最新版の体型メモ
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    CONSTRAINT measurement_profiles_customer_unique UNIQUE (tenant_id, customer_id)
+);
+
+-- Measurement Profile Versions (採寸プロファイルの版) テーブル
+CREATE TABLE IF NOT EXISTS measurement_profile_versions (
+    id VARCHAR(255) PRIMARY KEY,
+    profile_id VARCHAR(255) NOT NULL REFERENCES measurement_profiles(id) ON DELETE CASCADE,
+    tenant_id VARCHAR(255) NOT NULL,
+    customer_id VARCHAR(255) NOT NULL,
+    version INTEGER NOT NULL,
+    measurements JSONB NOT NULL, -- 採寸データ（注文の採寸データと同じ形式）
+    body_shape_notes TEXT, -- 体型メモ
+    notes TEXT,
+    measured_by VARCHAR(255) NOT NULL, -- 採寸者
+    measured_at TIMESTAMPTZ NOT NULL, -- 採寸日時
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    CONSTRAINT measurement_profile_versions_version_unique UNIQUE (profile_id, version),
+    CONSTRAINT measurement_profile_versions_version_check CHECK (version >= 1)
+);
+
+-- Measurement Profile Orders (版と注文の紐付け) テーブル
+-- 注文は採寸データを1つの版から取るため、注文ごとに1件
+CREATE TABLE IF NOT EXISTS measurement_profile_orders (
+    order_id VARCHAR(255) PRIMARY KEY REFERENCES orders(id) ON DELETE CASCADE,
+    version_id VARCHAR(255) NOT NULL REFERENCES measurement_profile_versions(id) ON DELETE CASCADE,
+    tenant_id VARCHAR(255) NOT NULL,
+    linked_at TIMESTAMPTZ DEFAULT NOW(),
+    linked_by VARCHAR(255)
+);
+
+-- インデックス作成
+CREATE INDEX IF NOT EXISTS idx_measurement_profile_versions_customer ON measurement_profile_versions(tenant_id, customer_id, version);
+CREATE INDEX IF NOT EXISTS idx_measurement_profile_orders_version_id ON measurement_profile_orders(version_id);
+
+-- 既存の注文の採寸データから採寸プロファイルを作成（注文の作成順に1注文1版）
+INSERT INTO measurement_profiles (id, tenant_id, customer_id, latest_version, created_at, updated_at)
+SELECT gen_random_uuid()::text, o.tenant_id, o.customer_id, COUNT(*), MIN(o.created_at), MAX(o.created_at)
+FROM orders o
+WHERE jsonb_typeof(o.measurement_data) = 'object'
+GROUP BY o.tenant_id, o.customer_id
+ON CONFLICT (tenant_id, customer_id) DO NOTHING;
+
+INSERT INTO measurement_profile_versions (id, profile_id, tenant_id, customer_id, version, measurements, measured_by, measured_at, created_at)
+SELECT gen_random_uuid()::text, p.id, o.tenant_id, o.customer_id,
+       ROW_NUMBER() OVER (PARTITION BY o.tenant_id, o.customer_id ORDER BY o.created_at, o.id),
+       o.measurement_data, o.created_by, o.created_at, o.created_at
+FROM orders o
+JOIN measurement_profiles p ON p.tenant_id = o.tenant_id AND p.customer_id = o.customer_id
+WHERE jsonb_typeof(o.measurement_data) = 'object'
+  AND NOT EXISTS (SELECT 1 FROM measurement_profile_versions v WHERE v.profile_id = p.id);
+
+INSERT INTO measurement_profile_orders (order_id, version_id, tenant_id, linked_at, linked_by)
+SELECT o.id, v.id, o.tenant_id, o.created_at, o.created_by
+FROM orders o
+JOIN measurement_profile_versions v
+  ON v.tenant_id = o.tenant_id AND v.customer_id = o.customer_id
+ AND v.measured_at = o.created_at AND v.measured_by = o.created_by AND v.measurements = o.measurement_data
+WHERE jsonb_typeof(o.measurement_data) = 'object'
+ON CONFLICT (order_id) DO NOTHING;
+
+-- コメント追加
+COMMENT ON TABLE measurement_profiles IS '顧客の採寸プロファイルテーブル（顧客ごとに1件）';
+COMMENT ON TABLE measurement_profile_versions IS '採寸プロファイルの版テーブル（採寸のたびに追加、変更しない）';
+COMMENT ON COLUMN measurement_profile_versions.body_shape_notes IS '体型メモ（反り身・なで肩・左右差など、補正の根拠）';
+COMMENT ON TABLE measurement_profile_orders IS '採寸プロファイルの版と、その採寸データを使った注文の紐付け';
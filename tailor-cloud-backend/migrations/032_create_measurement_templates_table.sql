@@ -0,0 +1,25 @@
+-- ============================================================================
+-- TailorCloud: 採寸テンプレート - 品目ごとの採寸テンプレートテーブル作成
+-- ============================================================================
+-- 目的: シャツのネック・カフス・ヨーク、パンツの股上・股下など、品目ごとに必要な
+--       採寸項目をテナントが設定できるようにする。項目ごとの単位・正常範囲・必須と、
+--       前回採寸との差のアラート閾値（既定: 警告5cm、エラー10cm）を定め、
+--       採寸データの範囲チェックと前回比較に使う
+-- ============================================================================
+
+-- Measurement Templates (採寸テンプレート) テーブル
+CREATE TABLE IF NOT EXISTS measurement_templates (
+    tenant_id VARCHAR(255) NOT NULL,
+    garment_type VARCHAR(20) NOT NULL,
+    fields JSONB NOT NULL DEFAULT '[]', -- 採寸項目の配列
+    change_warning_threshold NUMERIC(6, 2) NOT NULL DEFAULT 5.0, -- 前回との差の警告閾値
+    change_error_threshold NUMERIC(6, 2) NOT NULL DEFAULT 10.0, -- 前回との差のエラー閾値
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_by VARCHAR(255),
+    PRIMARY KEY (tenant_id, garment_type),
+    CONSTRAINT measurement_templates_thresholds_check CHECK (change_error_threshold >= change_warning_threshold)
+);
+
+-- コメント追加
+COMMENT ON TABLE measurement_templates IS '採寸テンプレートテーブル（テナント・品目ごと、未設定の品目は既定のテンプレートを使用）';
+COMMENT ON COLUMN measurement_templates.fields IS '採寸項目: key, label, unit（cm, mm, inch）, min, max, required, change_threshold, change_error_threshold';
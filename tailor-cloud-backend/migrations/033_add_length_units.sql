@@ -0,0 +1,43 @@
+-- ============================================================================
+-- TailorCloud: 長さの単位（採寸値・生地）
+-- ============================================================================
+-- 目的: 輸入生地はヤード単価で仕入れ、海外の顧客はインチで採寸値を伝えるため、
+--       生地単価の単位と、テナント・顧客ごとの表示単位を保持する。
+--       採寸値はセンチメートル、生地の長さ・単価はメートルに正規化して保存し、
+--       APIレスポンス・PDF・在庫レポートは表示単位に換算する
+-- ============================================================================
+
+-- テナントの表示単位
+ALTER TABLE tenants
+ADD COLUMN IF NOT EXISTS measurement_unit VARCHAR(10) NOT NULL DEFAULT 'cm', -- 採寸値の表示単位
+ADD COLUMN IF NOT EXISTS fabric_unit VARCHAR(10) NOT NULL DEFAULT 'm';       -- 生地の長さ・単価の表示単位
+
+ALTER TABLE tenants DROP CONSTRAINT IF EXISTS tenants_measurement_unit_check;
+ALTER TABLE tenants ADD CONSTRAINT tenants_measurement_unit_check
+    CHECK (measurement_unit IN ('cm', 'mm', 'inch'));
+ALTER TABLE tenants DROP CONSTRAINT IF EXISTS tenants_fabric_unit_check;
+ALTER TABLE tenants ADD CONSTRAINT tenants_fabric_unit_check
+    CHECK (fabric_unit IN ('m', 'yd'));
+
+-- 顧客の採寸値の表示単位（NULLはテナントの設定に従う）
+ALTER TABLE customers
+ADD COLUMN IF NOT EXISTS measurement_unit VARCHAR(10);
+
+ALTER TABLE customers DROP CONSTRAINT IF EXISTS customers_measurement_unit_check;
+ALTER TABLE customers ADD CONSTRAINT customers_measurement_unit_check
+    CHECK (measurement_unit IS NULL OR measurement_unit IN ('cm', 'mm', 'inch'));
+
+-- 生地単価の単位（仕入先の単価の単位。在庫数量・最小発注数量はメートル）
+ALTER TABLE fabrics
+ADD COLUMN IF NOT EXISTS price_unit VARCHAR(10) NOT NULL DEFAULT 'm';
+
+ALTER TABLE fabrics DROP CONSTRAINT IF EXISTS fabrics_price_unit_check;
+ALTER TABLE fabrics ADD CONSTRAINT fabrics_price_unit_check
+    CHECK (price_unit IN ('m', 'yd'));
+
+-- コメント追加
+COMMENT ON COLUMN tenants.measurement_unit IS '採寸値の表示単位: cm, mm, inch（保存はcm）';
+COMMENT ON COLUMN tenants.fabric_unit IS '生地の長さ・単価の表示単位: m, yd（保存はm）';
+COMMENT ON COLUMN customers.measurement_unit IS '採寸値の表示単位: cm, mm, inch（NULLはテナントの設定）';
+COMMENT ON COLUMN fabrics.price IS '単価（円/price_unit）';
+COMMENT ON COLUMN fabrics.price_unit IS '単価の単位: m, yd（輸入生地はヤード単価）';
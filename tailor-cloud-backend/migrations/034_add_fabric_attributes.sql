@@ -0,0 +1,49 @@
+-- ============================================================================
+-- TailorCloud: Suit-MBTI統合 - 生地の属性・生地レコメンドの重み
+-- ============================================================================
+-- 目的: 診断結果のアーキタイプ・プランと予算から、在庫のある生地をおすすめ順に
+--       提案するため、生地に色系統・柄・目付・シーズン・フォーマル度を持たせ、
+--       アーキタイプごとの属性の重みをテナントごとに調整できるようにする
+-- ============================================================================
+
+-- 生地の属性（NULLは未設定 = レコメンドの加点なし）
+ALTER TABLE fabrics
+ADD COLUMN IF NOT EXISTS color_family VARCHAR(20),
+ADD COLUMN IF NOT EXISTS pattern VARCHAR(20),
+ADD COLUMN IF NOT EXISTS weight INTEGER,
+ADD COLUMN IF NOT EXISTS season VARCHAR(20),
+ADD COLUMN IF NOT EXISTS formality INTEGER;
+
+ALTER TABLE fabrics DROP CONSTRAINT IF EXISTS fabrics_color_family_check;
+ALTER TABLE fabrics ADD CONSTRAINT fabrics_color_family_check
+    CHECK (color_family IS NULL OR color_family IN ('navy', 'grey', 'black', 'blue', 'brown', 'green', 'beige'));
+ALTER TABLE fabrics DROP CONSTRAINT IF EXISTS fabrics_pattern_check;
+ALTER TABLE fabrics ADD CONSTRAINT fabrics_pattern_check
+    CHECK (pattern IS NULL OR pattern IN ('solid', 'stripe', 'check', 'herringbone', 'texture'));
+ALTER TABLE fabrics DROP CONSTRAINT IF EXISTS fabrics_weight_check;
+ALTER TABLE fabrics ADD CONSTRAINT fabrics_weight_check
+    CHECK (weight IS NULL OR weight > 0);
+ALTER TABLE fabrics DROP CONSTRAINT IF EXISTS fabrics_season_check;
+ALTER TABLE fabrics ADD CONSTRAINT fabrics_season_check
+    CHECK (season IS NULL OR season IN ('all_season', 'spring_summer', 'autumn_winter'));
+ALTER TABLE fabrics DROP CONSTRAINT IF EXISTS fabrics_formality_check;
+ALTER TABLE fabrics ADD CONSTRAINT fabrics_formality_check
+    CHECK (formality IS NULL OR formality BETWEEN 1 AND 5);
+
+-- Fabric Recommendation Weights (生地レコメンドの重み) テーブル（テナントごとに1件、未登録は既定の重み）
+CREATE TABLE IF NOT EXISTS fabric_recommendation_weights (
+    tenant_id VARCHAR(255) PRIMARY KEY,
+    archetypes JSONB NOT NULL DEFAULT '{}', -- アーキタイプごとの属性の重み（例: {"Classic": {"color_family:navy": 3}}）
+    plan_weight NUMERIC(6, 2) NOT NULL DEFAULT 0, -- プランに合う価格帯への加点
+    season_weight NUMERIC(6, 2) NOT NULL DEFAULT 0, -- 今の季節に合う生地への加点
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_by VARCHAR(255) NOT NULL
+);
+
+-- コメント追加
+COMMENT ON COLUMN fabrics.color_family IS '色系統: navy, grey, black, blue, brown, green, beige';
+COMMENT ON COLUMN fabrics.pattern IS '柄: solid, stripe, check, herringbone, texture';
+COMMENT ON COLUMN fabrics.weight IS '目付（g/m）';
+COMMENT ON COLUMN fabrics.season IS 'シーズン: all_season, spring_summer, autumn_winter';
+COMMENT ON COLUMN fabrics.formality IS 'フォーマル度（1: カジュアル 〜 5: フォーマル）';
+COMMENT ON TABLE fabric_recommendation_weights IS '生地レコメンドの重み（テナントごとの調整）';
@@ -0,0 +1,68 @@
+-- ============================================================================
+-- TailorCloud: 入出金明細の重複取込防止
+-- ============================================================================
+-- 目的: 期間が重なる全銀協フォーマット/CSVを再取込すると同じ入金が未消込で
+--       二重に登録され、それぞれを消込確定できてしまう（入金の二重計上）ため、
+--       照会番号・勘定日・金額が同じ明細はテナント内で1件に限定する。
+--       照会番号のない明細（一部のCSV）は一意に識別できないため対象外
+-- ============================================================================
+
+-- 同じ明細が複数回消込確定されている場合（入金の二重計上）は自動で解消できないため、
+-- 該当する明細を一覧してエラーとする（二重計上した入金を取り消し、余分な明細を削除した後に再実行する）
+DO $$
+DECLARE
+    duplicated TEXT;
+BEGIN
+    SELECT string_agg(
+               format('tenant=%s reference_no=%s transaction_date=%s amount=%s entry_ids=%s',
+                      tenant_id, reference_no, transaction_date, amount, entry_ids),
+               E'\n')
+    INTO duplicated
+    FROM (
+        SELECT tenant_id, reference_no, transaction_date, amount,
+               string_agg(id::TEXT, ',' ORDER BY created_at, id) AS entry_ids
+        FROM bank_statement_entries
+        WHERE status = 'MATCHED'
+          AND reference_no IS NOT NULL
+          AND reference_no <> ''
+        GROUP BY tenant_id, reference_no, transaction_date, amount
+        HAVING COUNT(*) > 1
+    ) AS matched_duplicates;
+
+    IF duplicated IS NOT NULL THEN
+        RAISE EXCEPTION 'bank_statement_entries has duplicated lines matched more than once; reverse the duplicated payments and delete the extra entries before applying this migration:%', E'\n' || duplicated;
+    END IF;
+END
+$$;
+
+-- 既存の重複明細のうち1件を残して削除
+-- 残す明細の優先順: 消込済み > 対象外 > 未消込、同じ状態の場合は最初に取り込んだ明細
+DELETE FROM bank_statement_entries AS dup
+WHERE dup.status <> 'MATCHED'
+  AND dup.reference_no IS NOT NULL
+  AND dup.reference_no <> ''
+  AND EXISTS (
+      SELECT 1
+      FROM bank_statement_entries AS kept
+      WHERE kept.tenant_id = dup.tenant_id
+        AND kept.reference_no = dup.reference_no
+        AND kept.transaction_date = dup.transaction_date
+        AND kept.amount = dup.amount
+        AND kept.id <> dup.id
+        AND (
+            CASE kept.status WHEN 'MATCHED' THEN 0 WHEN 'IGNORED' THEN 1 ELSE 2 END
+                < CASE dup.status WHEN 'MATCHED' THEN 0 WHEN 'IGNORED' THEN 1 ELSE 2 END
+            OR (
+                kept.status = dup.status
+                AND (
+                    kept.created_at < dup.created_at
+                    OR (kept.created_at = dup.created_at AND kept.id < dup.id)
+                )
+            )
+        )
+  );
+
+-- 照会番号・勘定日・金額による一意制約
+CREATE UNIQUE INDEX IF NOT EXISTS idx_bank_statement_entries_unique_line
+    ON bank_statement_entries(tenant_id, reference_no, transaction_date, amount)
+    WHERE reference_no IS NOT NULL AND reference_no <> '';
@@ -0,0 +1,48 @@
+-- ============================================================================
+-- TailorCloud: スキーマ導入前の補正情報の移行
+-- ============================================================================
+-- 目的: 補正情報（adjustments）はバージョン付きのスキーマ（v1: fit_corrections,
+--       measurements, notes）で検証するようになったため、スキーマ導入前の
+--       自由形式の補正情報を、内容をメモ（notes）に移したv1に変換する。
+--       注文の複製・見積からの変換・型紙/DXF/仕様書の出力で検証エラーにならないようにする
+-- ============================================================================
+
+-- 注文
+UPDATE orders
+SET adjustments = jsonb_build_object(
+        'version', 1,
+        'fit_corrections', '[]'::jsonb,
+        'notes', CASE WHEN jsonb_typeof(adjustments) = 'string' THEN adjustments #>> '{}' ELSE adjustments::text END
+    )
+WHERE adjustments IS NOT NULL
+  AND jsonb_typeof(adjustments) <> 'null'
+  AND (
+      jsonb_typeof(adjustments) <> 'object'
+      OR (
+          NOT adjustments ? 'version'
+          AND EXISTS (
+              SELECT 1 FROM jsonb_object_keys(adjustments) AS key
+              WHERE key NOT IN ('fit_corrections', 'measurements', 'notes')
+          )
+      )
+  );
+
+-- 見積（承諾時に注文へ引き継ぐ）
+UPDATE quotes
+SET adjustments = jsonb_build_object(
+        'version', 1,
+        'fit_corrections', '[]'::jsonb,
+        'notes', CASE WHEN jsonb_typeof(adjustments) = 'string' THEN adjustments #>> '{}' ELSE adjustments::text END
+    )
+WHERE adjustments IS NOT NULL
+  AND jsonb_typeof(adjustments) <> 'null'
+  AND (
+      jsonb_typeof(adjustments) <> 'object'
+      OR (
+          NOT adjustments ? 'version'
+          AND EXISTS (
+              SELECT 1 FROM jsonb_object_keys(adjustments) AS key
+              WHERE key NOT IN ('fit_corrections', 'measurements', 'notes')
+          )
+      )
+  );
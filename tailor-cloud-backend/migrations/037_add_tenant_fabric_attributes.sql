@@ -0,0 +1,27 @@
+-- ============================================================================
+-- TailorCloud: 生地の属性のテナント別管理
+-- ============================================================================
+-- 目的: 生地（fabrics）はテナント共通のカタログのため、属性を生地に直接保存すると
+--       あるテナントの更新が全テナントの生地レコメンドに影響してしまう。
+--       属性の更新はテナントごとに保存し、fabricsの属性はカタログの既定値
+--       （テナントが未設定の生地に使用）として扱う
+-- ============================================================================
+
+-- Tenant Fabric Attributes (テナント別の生地の属性) テーブル（NULLは未設定 = レコメンドの加点なし）
+CREATE TABLE IF NOT EXISTS tenant_fabric_attributes (
+    tenant_id VARCHAR(255) NOT NULL,
+    fabric_id VARCHAR(255) NOT NULL REFERENCES fabrics(id) ON DELETE CASCADE,
+    color_family VARCHAR(20) CHECK (color_family IS NULL OR color_family IN ('navy', 'grey', 'black', 'blue', 'brown', 'green', 'beige')),
+    pattern VARCHAR(20) CHECK (pattern IS NULL OR pattern IN ('solid', 'stripe', 'check', 'herringbone', 'texture')),
+    weight INTEGER CHECK (weight IS NULL OR weight > 0),
+    season VARCHAR(20) CHECK (season IS NULL OR season IN ('all_season', 'spring_summer', 'autumn_winter')),
+    formality INTEGER CHECK (formality IS NULL OR formality BETWEEN 1 AND 5),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_by VARCHAR(255) NOT NULL,
+    PRIMARY KEY (tenant_id, fabric_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_tenant_fabric_attributes_fabric_id ON tenant_fabric_attributes(fabric_id);
+
+-- コメント追加
+COMMENT ON TABLE tenant_fabric_attributes IS 'テナント別の生地の属性（登録がない生地はfabricsの属性を使用）';
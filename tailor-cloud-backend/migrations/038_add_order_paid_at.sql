@@ -0,0 +1,19 @@
+-- ============================================================================
+-- TailorCloud: 注文の入金完了日時
+-- ============================================================================
+-- 目的: 納品前に請求額の全額が入金された（前払いの）注文は、消込時点では
+--       Paidに変更できず入金済みであることが記録されないため、納品後も未払いのまま
+--       残ってしまう。全額の入金を確認した日時を注文に記録し、納品時に
+--       入金済みの注文はそのまま支払済み（Paid）にする
+-- ============================================================================
+
+ALTER TABLE orders
+ADD COLUMN IF NOT EXISTS paid_at TIMESTAMPTZ;
+
+-- 支払済みの注文は最終更新日時を入金完了日時とする
+UPDATE orders
+SET paid_at = updated_at
+WHERE status = 'Paid' AND paid_at IS NULL;
+
+-- コメント追加
+COMMENT ON COLUMN orders.paid_at IS '入金完了日時（請求額の全額の入金を確認した日時、NULLは未入金）';